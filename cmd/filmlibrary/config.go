@@ -2,15 +2,22 @@ package main
 
 import (
 	"errors"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/service/auth"
 	"github.com/vaberof/vk-internship-task/pkg/config"
 	"github.com/vaberof/vk-internship-task/pkg/database/postgres"
+	"github.com/vaberof/vk-internship-task/pkg/database/sqlite"
 	"github.com/vaberof/vk-internship-task/pkg/http/httpserver"
 	"os"
 )
 
 type AppConfig struct {
 	Server   httpserver.ServerConfig
+	Storage  StorageConfig
 	Postgres postgres.Config
+	Sqlite   sqlite.Config
+	Auth     auth.Config
+	Trash    domain.TrashConfig
 }
 
 func mustGetAppConfig(sources ...string) AppConfig {
@@ -39,6 +46,15 @@ func tryGetAppConfig(sources ...string) (*AppConfig, error) {
 		return nil, err
 	}
 
+	var storageConfig StorageConfig
+	err = config.ParseConfig(provider, "app.storage", &storageConfig)
+	if err != nil {
+		return nil, err
+	}
+	if storageConfig.Driver == "" {
+		storageConfig.Driver = storageDriverPostgres
+	}
+
 	var postgresConfig postgres.Config
 	err = config.ParseConfig(provider, "app.postgres", &postgresConfig)
 	if err != nil {
@@ -47,9 +63,41 @@ func tryGetAppConfig(sources ...string) (*AppConfig, error) {
 	postgresConfig.User = os.Getenv("POSTGRES_USER")
 	postgresConfig.Password = os.Getenv("POSTGRES_PASSWORD")
 
+	var sqliteConfig sqlite.Config
+	err = config.ParseConfig(provider, "app.sqlite", &sqliteConfig)
+	if err != nil {
+		return nil, err
+	}
+	if storageConfig.Driver == storageDriverSqlite && sqliteConfig.Path == "" {
+		return nil, errors.New("'app.sqlite.path' must be set if 'app.storage.driver' is 'sqlite'")
+	}
+
+	var authConfig auth.Config
+	err = config.ParseConfig(provider, "app.auth", &authConfig)
+	if err != nil {
+		return nil, err
+	}
+	authConfig.AccessTokenSecret = os.Getenv("AUTH_ACCESS_TOKEN_SECRET")
+	if authConfig.AccessTokenSecret == "" {
+		return nil, errors.New("'AUTH_ACCESS_TOKEN_SECRET' environment variable must be set")
+	}
+
+	var trashConfig domain.TrashConfig
+	err = config.ParseConfig(provider, "app.trash", &trashConfig)
+	if err != nil {
+		return nil, err
+	}
+	if trashConfig.Retention > 0 && trashConfig.PurgeInterval <= 0 {
+		return nil, errors.New("'app.trash.purge-interval' must be positive if 'app.trash.retention' is set")
+	}
+
 	appConfig := AppConfig{
 		Server:   serverConfig,
+		Storage:  storageConfig,
 		Postgres: postgresConfig,
+		Sqlite:   sqliteConfig,
+		Auth:     authConfig,
+		Trash:    trashConfig,
 	}
 
 	return &appConfig, nil
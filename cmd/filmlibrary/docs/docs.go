@@ -18,20 +18,58 @@ const docTemplate = `{
         "/actors": {
             "get": {
                 "security": [
+                    {
+                        "BearerAuth": []
+                    },
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "List all actors with optional query parameters 'limit' and 'offset'",
+                "description": "List all actors with the possibility of sorting via 'sort' parameter by 'id' and/or 'name' and/or 'birth-date' and/or 'films-count' and/or with 'limit' and/or 'offset' parameters.\nActors are sorted by the fields in the order they are listed in 'sort'. Filters are combined with AND.\nIf there are more actors, 'next_cursor' is returned, which can be passed as 'cursor' to get the next page",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "actors"
                 ],
-                "summary": "List all actors with optional query parameters 'limit' and 'offset'",
-                "operationId": "list-actors",
+                "summary": "List and search actors with optional filters and 'sort', 'limit', 'offset', 'cursor', 'total' query parameters",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'sort' that indicates how actors should be sorted. By default 'sort' = 'id:asc'. Expected as ` + "`" + `name:asc,birth-date:desc` + "`" + ` in any order of necessary parameters. Allowed fields: 'id', 'name', 'birth-date', 'films-count'. Allowed directions: 'asc', 'desc'",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'name' with a case-insensitive fragment of the actor name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'sex'. If set, only actors of this sex are listed. Allowed values: 0, 1, 2, 9",
+                        "name": "sex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'birth-date-from' in 'YYYY-MM-DD' format. Actors born on this date are included",
+                        "name": "birth-date-from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'birth-date-to' in 'YYYY-MM-DD' format. Actors born on this date are included",
+                        "name": "birth-date-to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'film' with a film id. If set, only actors who appeared in this film are listed",
+                        "name": "film",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "description": "An optional query parameter 'limit' that limits total number of returned actors. By default 'limit' = 100",
@@ -43,6 +81,18 @@ const docTemplate = `{
                         "description": "An optional query parameter 'offset' that indicates how many records should be skipped while listing actors. By default 'offset' = 0",
                         "name": "offset",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'cursor' with the value of 'next_cursor' from the previous page. If set, 'offset' is counted from the cursor position. A cursor is valid only with the same 'sort' and filters",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "An optional query parameter 'total' that indicates whether total number of actors should be returned. By default 'total' = false",
+                        "name": "total",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -80,6 +130,9 @@ const docTemplate = `{
             },
             "post": {
                 "security": [
+                    {
+                        "BearerAuth": []
+                    },
                     {
                         "BasicAuth": []
                     }
@@ -112,6 +165,12 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/internal_app_entrypoint_http.createActorResponseBody"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the actor version"
+                            }
                         }
                     },
                     "400": {
@@ -141,39 +200,91 @@ const docTemplate = `{
                 }
             }
         },
-        "/actors/{id}": {
-            "delete": {
+        "/actors/searches": {
+            "get": {
                 "security": [
+                    {
+                        "BearerAuth": []
+                    },
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "Delete an actor by path parameter 'id'",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "List all actors with the possibility of sorting via 'sort' parameter by 'id' and/or 'name' and/or 'birth-date' and/or 'films-count' and/or with 'limit' and/or 'offset' parameters.\nActors are sorted by the fields in the order they are listed in 'sort'. Filters are combined with AND.\nIf there are more actors, 'next_cursor' is returned, which can be passed as 'cursor' to get the next page",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "actors"
                 ],
-                "summary": "Delete an actor by path parameter 'id'",
-                "operationId": "delete-actor",
+                "summary": "List and search actors with optional filters and 'sort', 'limit', 'offset', 'cursor', 'total' query parameters",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'sort' that indicates how actors should be sorted. By default 'sort' = 'id:asc'. Expected as ` + "`" + `name:asc,birth-date:desc` + "`" + ` in any order of necessary parameters. Allowed fields: 'id', 'name', 'birth-date', 'films-count'. Allowed directions: 'asc', 'desc'",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'name' with a case-insensitive fragment of the actor name",
+                        "name": "name",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Actors` + "`" + `s id that needs to be deleted",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "An optional query parameter 'sex'. If set, only actors of this sex are listed. Allowed values: 0, 1, 2, 9",
+                        "name": "sex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'birth-date-from' in 'YYYY-MM-DD' format. Actors born on this date are included",
+                        "name": "birth-date-from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'birth-date-to' in 'YYYY-MM-DD' format. Actors born on this date are included",
+                        "name": "birth-date-to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'film' with a film id. If set, only actors who appeared in this film are listed",
+                        "name": "film",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'limit' that limits total number of returned actors. By default 'limit' = 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'offset' that indicates how many records should be skipped while listing actors. By default 'offset' = 0",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'cursor' with the value of 'next_cursor' from the previous page. If set, 'offset' is counted from the cursor position. A cursor is valid only with the same 'sort' and filters",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "An optional query parameter 'total' that indicates whether total number of actors should be returned. By default 'total' = false",
+                        "name": "total",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_app_entrypoint_http.deleteActorResponseBody"
+                            "$ref": "#/definitions/internal_app_entrypoint_http.listActorsResponseBody"
                         }
                     },
                     "400": {
@@ -194,12 +305,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -207,48 +312,47 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/actors/{id}": {
+            "get": {
                 "security": [
+                    {
+                        "BearerAuth": []
+                    },
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "Update fully or partially an actor by path parameter 'id'",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get an actor with their filmography by path parameter 'id'",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "actors"
                 ],
-                "summary": "Update fully or partially an actor by path parameter 'id'",
-                "operationId": "update-actor",
+                "summary": "Get an actor by path parameter 'id'",
+                "operationId": "get-actor",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Actors` + "`" + `s id that needs to be updated",
+                        "description": "Actor` + "`" + `s id that needs to be returned",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Actor object with values that will be updated",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_app_entrypoint_http.updateActorRequestBody"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_app_entrypoint_http.updateActorResponseBody"
+                            "$ref": "#/definitions/internal_app_entrypoint_http.getActorResponseBody"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the actor version"
+                            }
                         }
                     },
                     "400": {
@@ -282,49 +386,48 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/films": {
-            "get": {
+            },
+            "delete": {
                 "security": [
+                    {
+                        "BearerAuth": []
+                    },
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "List all films with the possibility of sorting via 'sort' parameter by 'title' and/or 'rating' and/or 'release-date' and/or with 'limit' and/or 'offset' parameters",
+                "description": "Delete an actor by path parameter 'id'",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "films"
+                    "actors"
                 ],
-                "summary": "List all films with optional 'sort', 'limit', 'offset' query parameters",
-                "operationId": "list-films",
+                "summary": "Delete an actor by path parameter 'id'",
+                "operationId": "delete-actor",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "An optional query parameter 'sort' that indicates how films should be sorted. By default 'sort' = 'rating:desc'. Expected as ` + "`" + `title:asc,release-date:desc,rating:desc` + "`" + ` in any order of necessary parameters",
-                        "name": "sort",
-                        "in": "query"
-                    },
                     {
                         "type": "integer",
-                        "description": "An optional query parameter 'limit' that limits total number of returned films. By default 'limit' = 100",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Actors` + "`" + `s id that needs to be deleted",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "An optional query parameter 'offset' that indicates how many records should be skipped while listing films. By default 'offset' = 0",
-                        "name": "offset",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Entity tag of the actor version that is expected to be changed, like '\\",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_app_entrypoint_http.listFilmsResponseBody"
+                            "$ref": "#/definitions/internal_app_entrypoint_http.deleteActorResponseBody"
                         }
                     },
                     "400": {
@@ -345,6 +448,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -353,13 +468,16 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
+            "patch": {
                 "security": [
+                    {
+                        "BearerAuth": []
+                    },
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "Create a new film",
+                "description": "Update fully or partially an actor by path parameter 'id'",
                 "consumes": [
                     "application/json"
                 ],
@@ -367,26 +485,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "films"
+                    "actors"
                 ],
-                "summary": "Create a new film",
-                "operationId": "create-film",
+                "summary": "Update fully or partially an actor by path parameter 'id'",
+                "operationId": "update-actor",
                 "parameters": [
                     {
-                        "description": "Film object that needs to be created",
+                        "type": "integer",
+                        "description": "Actors` + "`" + `s id that needs to be updated",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Actor object with values that will be updated",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_app_entrypoint_http.createFilmRequestBody"
+                            "$ref": "#/definitions/internal_app_entrypoint_http.updateActorRequestBody"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity tag of the actor version that is expected to be changed, like '\\",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_app_entrypoint_http.createFilmResponseBody"
+                            "$ref": "#/definitions/internal_app_entrypoint_http.updateActorResponseBody"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the actor version"
+                            }
                         }
                     },
                     "400": {
@@ -413,6 +550,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
                         }
                     },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -422,44 +565,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/films/searches": {
+        "/actors/{id}/revisions": {
             "get": {
                 "security": [
+                    {
+                        "BearerAuth": []
+                    },
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "Search films by film title or/and actor name with optional 'limit' and 'offset' query parameters.\nIf 'film-title' and 'actor-name' are empty, than non-empty list of films with max length = 'limit' will be returned",
+                "description": "List the history of changes of an actor starting with the latest revision. A revision is the state of the actor after a change and is numbered by the version the change has given to the actor",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "films"
+                    "revisions"
                 ],
-                "summary": "Search films by film` + "`" + `s title or/and actor` + "`" + `s name with optional 'limit' and 'offset' query parameters",
-                "operationId": "search-films",
+                "summary": "List revisions of an actor by path parameter 'id' with optional query parameters 'limit' and 'offset'",
+                "operationId": "list-actor-revisions",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "An optional query parameter 'film-title'",
-                        "name": "film-title",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "An optional query parameter 'actor-name'",
-                        "name": "actor-name",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "Actor` + "`" + `s id whose revisions need to be listed",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "An optional query parameter 'limit' that limits total number of returned films. By default 'limit' = 100",
+                        "description": "An optional query parameter 'limit' that limits total number of returned revisions. By default 'limit' = 20",
                         "name": "limit",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "An optional query parameter 'offset' that indicates how many records should be skipped while listing films. By default 'offset' = 0",
+                        "description": "An optional query parameter 'offset' that indicates how many records should be skipped while listing revisions. By default 'offset' = 0",
                         "name": "offset",
                         "in": "query"
                     }
@@ -468,7 +609,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_app_entrypoint_http.searchFilmsResponseBody"
+                            "$ref": "#/definitions/internal_app_entrypoint_http.listActorRevisionsResponseBody"
                         }
                     },
                     "400": {
@@ -489,6 +630,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -498,39 +645,53 @@ const docTemplate = `{
                 }
             }
         },
-        "/films/{id}": {
-            "delete": {
+        "/actors/{id}/revisions/diff": {
+            "get": {
                 "security": [
+                    {
+                        "BearerAuth": []
+                    },
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "Delete a film by path parameter 'id'",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "List the fields of an actor that differ between two revisions with their values in both of them.\nThe fields are 'name', 'sex' and 'birthdate'",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "films"
+                    "revisions"
                 ],
-                "summary": "Delete a film by path parameter 'id'",
-                "operationId": "delete-film",
+                "summary": "Compare two revisions of an actor by path parameter 'id' and query parameters 'from' and 'to'",
+                "operationId": "diff-actor-revisions",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Film` + "`" + `s id that needs to be deleted",
+                        "description": "Actor` + "`" + `s id whose revisions need to be compared",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The revision to compare with",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The revision to compare",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_app_entrypoint_http.deleteFilmResponseBody"
+                            "$ref": "#/definitions/internal_app_entrypoint_http.diffActorRevisionsResponseBody"
                         }
                     },
                     "400": {
@@ -564,48 +725,140 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/actors/{id}/revisions/{revision}:revert": {
+            "post": {
                 "security": [
+                    {
+                        "BearerAuth": []
+                    },
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "Update fully or partially a film by path parameter 'id'",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
+                "description": "Bring an actor back to the state of the revision. It is an ordinary update of the actor,\nso it is checked the same way and makes a new revision instead of removing the later ones",
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "films"
+                    "revisions"
                 ],
-                "summary": "Update fully or partially a film by path parameter 'id'",
-                "operationId": "update-film",
+                "summary": "Revert an actor to a revision by path parameters 'id' and 'revision'",
+                "operationId": "revert-actor",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Films` + "`" + `s id that needs to be updated",
+                        "description": "Actor` + "`" + `s id that needs to be reverted",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Film object with values that will be updated",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
+                        "type": "integer",
+                        "description": "The revision the actor needs to be reverted to",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity tag of the actor version that is expected to be changed, like '\\",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_app_entrypoint_http.updateFilmRequestBody"
+                            "$ref": "#/definitions/internal_app_entrypoint_http.revertActorResponseBody"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the actor version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
                         }
                     }
+                }
+            }
+        },
+        "/actors/{id}:restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Bring an actor back from the trash into the films they had. Films of the actor that are still in the trash get the actor back once they are restored too",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Restore a deleted actor by path parameter 'id'",
+                "operationId": "restore-actor",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Actor` + "`" + `s id that needs to be restored",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_app_entrypoint_http.updateFilmResponseBody"
+                            "$ref": "#/definitions/internal_app_entrypoint_http.restoreActorResponseBody"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the actor version"
+                            }
                         }
                     },
                     "400": {
@@ -640,59 +893,4187 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response": {
-            "type": "object",
-            "properties": {
-                "payload": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
+        },
+        "/actors:batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Apply a list of operations: 'create' with 'actor' like in 'POST /actors', 'update' with 'id' and 'actor' like in 'PATCH /actors/{id}', 'delete' with 'id'.\nEvery operation is checked the same way as by the respective single actor request. Delete operations require the 'actors:delete' permission.\n'update' and 'delete' operations with 'version' fail with 'PRECONDITION_FAILED' if the actor has another version, like with the 'If-Match' header.\nIn the 'transactional' mode either all operations are applied or none of them, in the 'best-effort' mode every operation which succeeds is applied.\nThe outcome of every operation is returned in 'items' with the same index. If a transactional batch fails, the items are returned in the error details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "actors"
+                ],
+                "summary": "Create, update and delete actors in a batch",
+                "operationId": "batch-actors",
+                "parameters": [
+                    {
+                        "description": "Operations with actors",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.batchActorsRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.batchActorsResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
                     }
-                },
-                "status": {
-                    "type": "string"
                 }
             }
         },
-        "internal_app_entrypoint_http.actor": {
-            "type": "object",
-            "properties": {
-                "birthdate": {
-                    "type": "string"
-                },
-                "films": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/internal_app_entrypoint_http.actorFilm"
+        "/audit": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "List audit events from the newest to the oldest. Every event holds the user who made the change, the operation and JSON snapshots of the entity before and after the change",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "List audit events of films and actors mutations with optional filters",
+                "operationId": "list-audit-events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'entity_type'. Allowed values: 'film', 'actor'",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'entity_id'",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'user_id' of the user who made the change",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'from' in RFC 3339 format, e.g. '2024-03-01T00:00:00Z'. Events made at 'from' are included",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'to' in RFC 3339 format, e.g. '2024-04-01T00:00:00Z'. Events made at 'to' are excluded",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'limit' that limits total number of returned events. By default 'limit' = 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'offset' that indicates how many records should be skipped while listing events. By default 'offset' = 0",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.listAuditEventsResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
                     }
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "sex": {
-                    "type": "integer"
                 }
             }
         },
-        "internal_app_entrypoint_http.actorFilm": {
-            "type": "object",
-            "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "rating": {
-                    "type": "integer"
-                },
-                "release_date": {
+        "/auth/login": {
+            "post": {
+                "description": "Issue a short-lived access token and a refresh token.\nThe access token is passed as 'Authorization: Bearer \u003caccess_token\u003e' header, the refresh token is used to get a new pair of tokens",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Log in with email and password",
+                "operationId": "login",
+                "parameters": [
+                    {
+                        "description": "User credentials",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.loginRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.tokensResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "description": "Revoke a refresh token. Already issued access tokens stay valid until they expire",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Log out",
+                "operationId": "logout",
+                "parameters": [
+                    {
+                        "description": "Refresh token that needs to be revoked",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.refreshTokenRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.logoutResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Issue a new pair of tokens in exchange for a refresh token. The refresh token can be used only once",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Refresh tokens",
+                "operationId": "refresh-tokens",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.refreshTokenRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.tokensResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/exports/actors": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Stream all actors with their films ordered by id.\nThe format is taken from 'format' or from the first acceptable media type of 'Accept' ('application/json', 'text/csv', 'application/x-ndjson'), JSON is used by default.\nJSON is an array of actors, NDJSON has an actor per line. CSV has a header with the columns 'id', 'name', 'sex', 'birthdate', 'film_ids', film ids are separated by ';'.\nIf the export fails after it has started, the connection is aborted, so that an incomplete export is never received as a complete one",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "exports"
+                ],
+                "summary": "Export all actors with their films",
+                "operationId": "export-actors",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'format'. Allowed values: 'json', 'csv', 'ndjson'",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/exports/films": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Stream all films with their actors ordered by id.\nThe format is taken from 'format' or from the first acceptable media type of 'Accept' ('application/json', 'text/csv', 'application/x-ndjson'), JSON is used by default.\nJSON is an array of films, NDJSON has a film per line. CSV has a header with the columns 'id', 'title', 'description', 'release_date', 'rating', 'actor_ids', 'actors',\nactor ids are separated by ';', actors are like ` + "`" + `Name|1|1970-01-02;Name 2|2|1980-01-02` + "`" + `.\nIf the export fails after it has started, the connection is aborted, so that an incomplete export is never received as a complete one",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "exports"
+                ],
+                "summary": "Export all films with their actors",
+                "operationId": "export-films",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'format'. Allowed values: 'json', 'csv', 'ndjson'",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/films": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "List all films with the possibility of sorting via 'sort' parameter by 'id' and/or 'title' and/or 'release-date' and/or 'rating' and/or 'actors-count' and/or 'community-score' and/or with 'limit' and/or 'offset' parameters.\nFilms are sorted by the fields in the order they are listed in 'sort'\nFilters are combined with AND. With a free text query 'q' and without 'sort' films are ordered by relevance,\nevery found film has 'relevance' and 'highlights' with the matched words wrapped in '\u003cb\u003e' and '\u003c/b\u003e'.\nIf there are more films, 'next_cursor' is returned, which can be passed as 'cursor' to get the next page",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "films"
+                ],
+                "summary": "List and search films with optional filters and 'sort', 'limit', 'offset', 'cursor', 'total' query parameters",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'sort' that indicates how films should be sorted. By default 'sort' = 'rating:desc'. Expected as ` + "`" + `title:asc,release-date:desc,rating:desc` + "`" + ` in any order of necessary parameters. Allowed fields: 'id', 'title', 'release-date', 'rating', 'actors-count', 'community-score'. Allowed directions: 'asc', 'desc'",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'q' with a free text query matched against titles, descriptions and names of film credits, tolerating typos. Quoted phrases, 'or' and '-' before excluded words are supported",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'film-title' with a case-insensitive fragment of the film title",
+                        "name": "film-title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'actor-name' with a case-insensitive fragment of a name of the film actor",
+                        "name": "actor-name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'director-name' with a case-insensitive fragment of a name of the film director",
+                        "name": "director-name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'genre' with a genre id. If set, only films of this genre are listed",
+                        "name": "genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'release-date-from' in 'YYYY-MM-DD' format. Films released on this date are included",
+                        "name": "release-date-from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'release-date-to' in 'YYYY-MM-DD' format. Films released on this date are included",
+                        "name": "release-date-to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'rating-from' with the minimal film rating from 0 to 10",
+                        "name": "rating-from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'rating-to' with the maximal film rating from 0 to 10",
+                        "name": "rating-to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'actor-ids' with comma separated actor ids, e.g. '1,2,3'",
+                        "name": "actor-ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'actor-ids-match' that indicates whether films with any ('any') or all ('all') of 'actor-ids' are listed. By default 'actor-ids-match' = 'any'",
+                        "name": "actor-ids-match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'actor-sex'. If set, only films with an actor of this sex are listed. Allowed values: 0, 1, 2, 9",
+                        "name": "actor-sex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "An optional query parameter 'no-actors'. If 'true', only films without actors are listed",
+                        "name": "no-actors",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'limit' that limits total number of returned films. By default 'limit' = 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'offset' that indicates how many records should be skipped while listing films. By default 'offset' = 0",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'cursor' with the value of 'next_cursor' from the previous page. If set, 'offset' is counted from the cursor position. A cursor is valid only with the same 'sort' and filters",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "An optional query parameter 'total' that indicates whether total number of films should be returned. By default 'total' = false",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.listFilmsResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Create a new film. Actors from 'actor_ids' are credited with the 'actor' role before the explicit 'credits'",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "films"
+                ],
+                "summary": "Create a new film",
+                "operationId": "create-film",
+                "parameters": [
+                    {
+                        "description": "Film object that needs to be created",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.createFilmRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.createFilmResponseBody"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the film version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/films/searches": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "List all films with the possibility of sorting via 'sort' parameter by 'id' and/or 'title' and/or 'release-date' and/or 'rating' and/or 'actors-count' and/or 'community-score' and/or with 'limit' and/or 'offset' parameters.\nFilms are sorted by the fields in the order they are listed in 'sort'\nFilters are combined with AND. With a free text query 'q' and without 'sort' films are ordered by relevance,\nevery found film has 'relevance' and 'highlights' with the matched words wrapped in '\u003cb\u003e' and '\u003c/b\u003e'.\nIf there are more films, 'next_cursor' is returned, which can be passed as 'cursor' to get the next page",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "films"
+                ],
+                "summary": "List and search films with optional filters and 'sort', 'limit', 'offset', 'cursor', 'total' query parameters",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'sort' that indicates how films should be sorted. By default 'sort' = 'rating:desc'. Expected as ` + "`" + `title:asc,release-date:desc,rating:desc` + "`" + ` in any order of necessary parameters. Allowed fields: 'id', 'title', 'release-date', 'rating', 'actors-count', 'community-score'. Allowed directions: 'asc', 'desc'",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'q' with a free text query matched against titles, descriptions and names of film credits, tolerating typos. Quoted phrases, 'or' and '-' before excluded words are supported",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'film-title' with a case-insensitive fragment of the film title",
+                        "name": "film-title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'actor-name' with a case-insensitive fragment of a name of the film actor",
+                        "name": "actor-name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'director-name' with a case-insensitive fragment of a name of the film director",
+                        "name": "director-name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'genre' with a genre id. If set, only films of this genre are listed",
+                        "name": "genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'release-date-from' in 'YYYY-MM-DD' format. Films released on this date are included",
+                        "name": "release-date-from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'release-date-to' in 'YYYY-MM-DD' format. Films released on this date are included",
+                        "name": "release-date-to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'rating-from' with the minimal film rating from 0 to 10",
+                        "name": "rating-from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'rating-to' with the maximal film rating from 0 to 10",
+                        "name": "rating-to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'actor-ids' with comma separated actor ids, e.g. '1,2,3'",
+                        "name": "actor-ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'actor-ids-match' that indicates whether films with any ('any') or all ('all') of 'actor-ids' are listed. By default 'actor-ids-match' = 'any'",
+                        "name": "actor-ids-match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'actor-sex'. If set, only films with an actor of this sex are listed. Allowed values: 0, 1, 2, 9",
+                        "name": "actor-sex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "An optional query parameter 'no-actors'. If 'true', only films without actors are listed",
+                        "name": "no-actors",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'limit' that limits total number of returned films. By default 'limit' = 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'offset' that indicates how many records should be skipped while listing films. By default 'offset' = 0",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'cursor' with the value of 'next_cursor' from the previous page. If set, 'offset' is counted from the cursor position. A cursor is valid only with the same 'sort' and filters",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "An optional query parameter 'total' that indicates whether total number of films should be returned. By default 'total' = false",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.listFilmsResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/films/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Get a film with its actors by path parameter 'id'",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "films"
+                ],
+                "summary": "Get a film by path parameter 'id'",
+                "operationId": "get-film",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Film` + "`" + `s id that needs to be returned",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.getFilmResponseBody"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the film version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Delete a film by path parameter 'id'",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "films"
+                ],
+                "summary": "Delete a film by path parameter 'id'",
+                "operationId": "delete-film",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Film` + "`" + `s id that needs to be deleted",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity tag of the film version that is expected to be changed, like '\\",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.deleteFilmResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Update fully or partially a film by path parameter 'id'.\n'credits' replace all credits of the film, 'actor_ids' alone replace only the credits with the 'actor' role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "films"
+                ],
+                "summary": "Update fully or partially a film by path parameter 'id'",
+                "operationId": "update-film",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Films` + "`" + `s id that needs to be updated",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Film object with values that will be updated",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.updateFilmRequestBody"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity tag of the film version that is expected to be changed, like '\\",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.updateFilmResponseBody"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the film version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/films/{id}/credits": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Get everyone attached to a film (actors, directors, writers, producers, composers) ordered by billing order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "films"
+                ],
+                "summary": "Get credits of a film by path parameter 'id'",
+                "operationId": "get-film-credits",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Film` + "`" + `s id whose credits need to be returned",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.getFilmCreditsResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/films/{id}/reviews": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "List reviews of a film starting with the latest ones with optional query parameters 'limit' and 'offset'",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "List reviews of a film by path parameter 'id' with optional query parameters 'limit' and 'offset'",
+                "operationId": "list-film-reviews",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Film` + "`" + `s id whose reviews need to be listed",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'limit' that limits total number of returned reviews. By default 'limit' = 20",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'offset' that indicates how many records should be skipped while listing reviews. By default 'offset' = 0",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.listFilmReviewsResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Post a rating from 1 to 10 with an optional text review of a film. A user can review a film only once",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Review a film by path parameter 'id'",
+                "operationId": "create-review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Film` + "`" + `s id that needs to be reviewed",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review object that needs to be created",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.createReviewRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.createReviewResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/films/{id}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "List the history of changes of a film starting with the latest revision. A revision is the state of the film after a change and is numbered by the version the change has given to the film",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revisions"
+                ],
+                "summary": "List revisions of a film by path parameter 'id' with optional query parameters 'limit' and 'offset'",
+                "operationId": "list-film-revisions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Film` + "`" + `s id whose revisions need to be listed",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'limit' that limits total number of returned revisions. By default 'limit' = 20",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'offset' that indicates how many records should be skipped while listing revisions. By default 'offset' = 0",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.listFilmRevisionsResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/films/{id}/revisions/diff": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "List the fields of a film that differ between two revisions with their values in both of them.\nThe fields are 'title', 'description', 'release_date', 'rating', 'credits' and 'genre_ids'",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revisions"
+                ],
+                "summary": "Compare two revisions of a film by path parameter 'id' and query parameters 'from' and 'to'",
+                "operationId": "diff-film-revisions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Film` + "`" + `s id whose revisions need to be compared",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The revision to compare with",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The revision to compare",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.diffFilmRevisionsResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/films/{id}/revisions/{revision}:revert": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Bring a film back to the state of the revision. It is an ordinary update of the film,\nso it is checked the same way and makes a new revision instead of removing the later ones",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revisions"
+                ],
+                "summary": "Revert a film to a revision by path parameters 'id' and 'revision'",
+                "operationId": "revert-film",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Film` + "`" + `s id that needs to be reverted",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The revision the film needs to be reverted to",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity tag of the film version that is expected to be changed, like '\\",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.revertFilmResponseBody"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the film version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/films/{id}:restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Bring a film back from the trash with the actors, credits, genres and reviews it had. Credits of actors that are still in the trash come back with the actors",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Restore a deleted film by path parameter 'id'",
+                "operationId": "restore-film",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Film` + "`" + `s id that needs to be restored",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.restoreFilmResponseBody"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the film version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/films:batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Apply a list of operations: 'create' with 'film' like in 'POST /films', 'update' with 'id' and 'film' like in 'PATCH /films/{id}', 'delete' with 'id'.\nEvery operation is checked the same way as by the respective single film request. Delete operations require the 'films:delete' permission.\n'update' and 'delete' operations with 'version' fail with 'PRECONDITION_FAILED' if the film has another version, like with the 'If-Match' header.\nIn the 'transactional' mode either all operations are applied or none of them, in the 'best-effort' mode every operation which succeeds is applied.\nThe outcome of every operation is returned in 'items' with the same index. If a transactional batch fails, the items are returned in the error details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "films"
+                ],
+                "summary": "Create, update and delete films in a batch",
+                "operationId": "batch-films",
+                "parameters": [
+                    {
+                        "description": "Operations with films",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.batchFilmsRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.batchFilmsResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/genres": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "List all genres ordered by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genres"
+                ],
+                "summary": "List all genres",
+                "operationId": "list-genres",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.listGenresResponseBody"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Create a new genre with a unique name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genres"
+                ],
+                "summary": "Create a new genre",
+                "operationId": "create-genre",
+                "parameters": [
+                    {
+                        "description": "Genre object that needs to be created",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.createGenreRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.createGenreResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/genres/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Get a genre by path parameter 'id'",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genres"
+                ],
+                "summary": "Get a genre by path parameter 'id'",
+                "operationId": "get-genre",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Genre` + "`" + `s id that needs to be returned",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.getGenreResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Delete a genre by path parameter 'id'. The genre is also removed from all of its films",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genres"
+                ],
+                "summary": "Delete a genre by path parameter 'id'",
+                "operationId": "delete-genre",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Genre` + "`" + `s id that needs to be deleted",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.deleteGenreResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Rename a genre by path parameter 'id'",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genres"
+                ],
+                "summary": "Rename a genre by path parameter 'id'",
+                "operationId": "update-genre",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Genre` + "`" + `s id that needs to be updated",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Genre object with a new name",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.updateGenreRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.updateGenreResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Import films in bulk. Every film is validated with the same rules as in 'POST /films', but actors are embedded instead of 'actor_ids'.\nActors are matched with the existing ones by name and birth date, the unmatched actors are created.\nThe format is taken from 'format' or from 'Content-Type' ('text/csv', 'application/x-ndjson').\nNDJSON lines are like ` + "`" + `{\"title\":\"Title\",\"release_date\":\"2006-01-02\",\"rating\":8,\"actors\":[{\"name\":\"Name\",\"sex\":1,\"birthdate\":\"1970-01-02\"}],\"genre_ids\":[1]}` + "`" + `.\nCSV has a header with the columns 'title', 'description', 'release_date', 'rating', 'actors', 'genre_ids', actors are like ` + "`" + `Name|1|1970-01-02;Name 2|2|1980-01-02` + "`" + `, genre ids are separated by ';'.\nAll films are imported in a single transaction: if any row fails, nothing is imported and errors of all rows are returned with their line numbers",
+                "consumes": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Import films with embedded actors from CSV or NDJSON",
+                "operationId": "import-films",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'format'. Allowed values: 'csv', 'ndjson'",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "An optional query parameter 'dry-run'. If 'true', films are checked, but not imported. By default 'dry-run' = false",
+                        "name": "dry-run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Films in CSV or NDJSON",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.importFilmsResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Delete own review by path parameter 'id'. The community score of the film is recalculated",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Delete own review by path parameter 'id'",
+                "operationId": "delete-review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review` + "`" + `s id that needs to be deleted",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.deleteReviewResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Update fully or partially own review by path parameter 'id'. An empty 'text' removes the text of the review",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Update own review by path parameter 'id'",
+                "operationId": "update-review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review` + "`" + `s id that needs to be updated",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review object with fields that need to be updated",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.updateReviewRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.updateReviewResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "List films and actors in the trash from the most recently deleted. Every item holds the user who deleted it and the time after which it is purged for good, if the trash is purged",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "List deleted films and actors",
+                "operationId": "list-trash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'type'. Allowed values: 'film', 'actor'",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'limit' that limits total number of returned items. By default 'limit' = 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'offset' that indicates how many records should be skipped while listing items. By default 'offset' = 0",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.listTrashResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "List all users ordered by id with optional query parameters 'limit' and 'offset'",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "List all users with optional query parameters 'limit' and 'offset'",
+                "operationId": "list-users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'limit' that limits total number of returned users. By default 'limit' = 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'offset' that indicates how many records should be skipped while listing users. By default 'offset' = 0",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.listUsersResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Register a new user with 'user' role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Register a new user",
+                "operationId": "register-user",
+                "parameters": [
+                    {
+                        "description": "User credentials",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.registerUserRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.registerUserResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Get the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get the authenticated user",
+                "operationId": "get-current-user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.getCurrentUserResponseBody"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Change the password of the authenticated user. All refresh tokens of the user are revoked",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Change the password of the authenticated user",
+                "operationId": "update-current-user",
+                "parameters": [
+                    {
+                        "description": "Current and new passwords",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.updateCurrentUserRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.updateCurrentUserResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/watched": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "List films of the personal list of the authenticated user with the same sorting and pagination as the list of all films",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "List the watchlist or the watched films of the authenticated user with optional 'sort', 'limit', 'offset', 'cursor', 'total' query parameters",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'sort' that indicates how films should be sorted. By default 'sort' = 'rating:desc'. Allowed fields: 'id', 'title', 'release-date', 'rating', 'actors-count', 'community-score'. Allowed directions: 'asc', 'desc'",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'limit' that limits total number of returned films. By default 'limit' = 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'offset' that indicates how many records should be skipped while listing films. By default 'offset' = 0",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'cursor' with the value of 'next_cursor' from the previous page. If set, 'offset' is counted from the cursor position. A cursor is valid only with the same 'sort'",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "An optional query parameter 'total' that indicates whether total number of films should be returned. By default 'total' = false",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.listUserFilmsResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Add a film to the personal list of the authenticated user. Adding a film that is already in the list does nothing",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Add a film to the watchlist or to the watched films of the authenticated user",
+                "parameters": [
+                    {
+                        "description": "Film that needs to be added to the list",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.addUserFilmRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.addUserFilmResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/watched/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Remove a film by path parameter 'id' from the personal list of the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Remove a film from the watchlist or from the watched films of the authenticated user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Film` + "`" + `s id that needs to be removed from the list",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.removeUserFilmResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/watchlist": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "List films of the personal list of the authenticated user with the same sorting and pagination as the list of all films",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "List the watchlist or the watched films of the authenticated user with optional 'sort', 'limit', 'offset', 'cursor', 'total' query parameters",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'sort' that indicates how films should be sorted. By default 'sort' = 'rating:desc'. Allowed fields: 'id', 'title', 'release-date', 'rating', 'actors-count', 'community-score'. Allowed directions: 'asc', 'desc'",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'limit' that limits total number of returned films. By default 'limit' = 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "An optional query parameter 'offset' that indicates how many records should be skipped while listing films. By default 'offset' = 0",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "An optional query parameter 'cursor' with the value of 'next_cursor' from the previous page. If set, 'offset' is counted from the cursor position. A cursor is valid only with the same 'sort'",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "An optional query parameter 'total' that indicates whether total number of films should be returned. By default 'total' = false",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.listUserFilmsResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Add a film to the personal list of the authenticated user. Adding a film that is already in the list does nothing",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Add a film to the watchlist or to the watched films of the authenticated user",
+                "parameters": [
+                    {
+                        "description": "Film that needs to be added to the list",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.addUserFilmRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.addUserFilmResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/watchlist/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Remove a film by path parameter 'id' from the personal list of the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Remove a film from the watchlist or from the watched films of the authenticated user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Film` + "`" + `s id that needs to be removed from the list",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.removeUserFilmResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/disable": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Disable a user by path parameter 'id'. A disabled user cannot log in and all of their refresh tokens are revoked",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Disable a user by path parameter 'id'",
+                "operationId": "disable-user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User` + "`" + `s id that needs to be disabled",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.disableUserResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/password": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Set a new password of a user by path parameter 'id'. All refresh tokens of the user are revoked",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Reset a password of a user by path parameter 'id'",
+                "operationId": "reset-user-password",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User` + "`" + `s id whose password needs to be reset",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New password",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.resetUserPasswordRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.resetUserPasswordResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/role": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Change a role of a user by path parameter 'id'",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Change a role of a user by path parameter 'id'",
+                "operationId": "update-user-role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User` + "`" + `s id whose role needs to be changed",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New role",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.updateUserRoleRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_entrypoint_http.updateUserRoleResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.ErrorDescription": {
+            "type": "object",
+            "additionalProperties": {}
+        },
+        "github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.Response": {
+            "type": "object",
+            "properties": {
+                "payload": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.actor": {
+            "type": "object",
+            "properties": {
+                "birthdate": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "films": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.actorFilm"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sex": {
+                    "type": "integer"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.actorBatchItem": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "$ref": "#/definitions/internal_app_entrypoint_http.actor"
+                },
+                "error": {
+                    "$ref": "#/definitions/internal_app_entrypoint_http.batchItemError"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "op": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.actorBatchOperationRequest": {
+            "type": "object",
+            "required": [
+                "op"
+            ],
+            "properties": {
+                "actor": {
+                    "type": "object"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "op": {
+                    "type": "string",
+                    "enum": [
+                        "create",
+                        "update",
+                        "delete"
+                    ],
+                    "example": "update"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "internal_app_entrypoint_http.actorFilm": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.actorRevision": {
+            "type": "object",
+            "properties": {
+                "birthdate": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "revision": {
+                    "type": "integer"
+                },
+                "sex": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.addUserFilmRequestBody": {
+            "type": "object",
+            "required": [
+                "film_id"
+            ],
+            "properties": {
+                "film_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "internal_app_entrypoint_http.addUserFilmResponseBody": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.auditEvent": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "integer"
+                },
+                "entity_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "operation": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.batchActorsRequestBody": {
+            "type": "object",
+            "required": [
+                "mode",
+                "operations"
+            ],
+            "properties": {
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "transactional",
+                        "best-effort"
+                    ],
+                    "example": "transactional"
+                },
+                "operations": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.actorBatchOperationRequest"
+                    }
+                }
+            }
+        },
+        "internal_app_entrypoint_http.batchActorsResponseBody": {
+            "type": "object",
+            "properties": {
+                "committed": {
+                    "type": "boolean"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.actorBatchItem"
+                    }
+                },
+                "mode": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.batchFilmsRequestBody": {
+            "type": "object",
+            "required": [
+                "mode",
+                "operations"
+            ],
+            "properties": {
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "transactional",
+                        "best-effort"
+                    ],
+                    "example": "transactional"
+                },
+                "operations": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.filmBatchOperationRequest"
+                    }
+                }
+            }
+        },
+        "internal_app_entrypoint_http.batchFilmsResponseBody": {
+            "type": "object",
+            "properties": {
+                "committed": {
+                    "type": "boolean"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.filmBatchItem"
+                    }
+                },
+                "mode": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.batchItemError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "$ref": "#/definitions/github_com_vaberof_vk-internship-task_pkg_http_protocols_apiv1.ErrorDescription"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.createActorRequestBody": {
+            "type": "object",
+            "required": [
+                "birthdate",
+                "name",
+                "sex"
+            ],
+            "properties": {
+                "birthdate": {
+                    "type": "string",
+                    "example": "2006-01-02"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "sex": {
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1,
+                        2,
+                        9
+                    ]
+                }
+            }
+        },
+        "internal_app_entrypoint_http.createActorResponseBody": {
+            "type": "object",
+            "properties": {
+                "birthdate": {
+                    "type": "string"
+                },
+                "films": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.actorFilm"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sex": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.createFilmRequestBody": {
+            "type": "object",
+            "required": [
+                "actor_ids",
+                "rating",
+                "release_date",
+                "title"
+            ],
+            "properties": {
+                "actor_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2,
+                        3
+                    ]
+                },
+                "credits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.filmCreditRequest"
+                    }
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "genre_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "rating": {
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 0
+                },
+                "release_date": {
+                    "type": "string",
+                    "example": "2006-01-02"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 150,
+                    "minLength": 1
+                }
+            }
+        },
+        "internal_app_entrypoint_http.createFilmResponseBody": {
+            "type": "object",
+            "properties": {
+                "actors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.filmActor"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.genre"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.createGenreRequestBody": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "Drama"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.createGenreResponseBody": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.createReviewRequestBody": {
+            "type": "object",
+            "required": [
+                "rating"
+            ],
+            "properties": {
+                "rating": {
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 1,
+                    "example": 8
+                },
+                "text": {
+                    "type": "string",
+                    "maxLength": 5000,
+                    "example": "Great film"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.createReviewResponseBody": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "film_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.deleteActorResponseBody": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.deleteFilmResponseBody": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.deleteGenreResponseBody": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.deleteReviewResponseBody": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.diffActorRevisionsResponseBody": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.revisionChange"
+                    }
+                },
+                "from": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.diffFilmRevisionsResponseBody": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.revisionChange"
+                    }
+                },
+                "from": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.disableUserResponseBody": {
+            "type": "object",
+            "properties": {
+                "disabled": {
+                    "type": "boolean"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.film": {
+            "type": "object",
+            "properties": {
+                "actors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.filmActor"
+                    }
+                },
+                "community_score": {
+                    "type": "number"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.genre"
+                    }
+                },
+                "highlights": {
+                    "$ref": "#/definitions/internal_app_entrypoint_http.filmHighlights"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "in_watchlist": {
+                    "type": "boolean"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "relevance": {
+                    "type": "number"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                },
+                "votes_count": {
+                    "type": "integer"
+                },
+                "watched": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.filmActor": {
+            "type": "object",
+            "properties": {
+                "birthdate": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sex": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.filmBatchItem": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/internal_app_entrypoint_http.batchItemError"
+                },
+                "film": {
+                    "$ref": "#/definitions/internal_app_entrypoint_http.film"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "op": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.filmBatchOperationRequest": {
+            "type": "object",
+            "required": [
+                "op"
+            ],
+            "properties": {
+                "film": {
+                    "type": "object"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "op": {
+                    "type": "string",
+                    "enum": [
+                        "create",
+                        "update",
+                        "delete"
+                    ],
+                    "example": "update"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "internal_app_entrypoint_http.filmCredit": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "$ref": "#/definitions/internal_app_entrypoint_http.filmActor"
+                },
+                "billing_order": {
+                    "type": "integer"
+                },
+                "character_name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.filmCreditRequest": {
+            "type": "object",
+            "required": [
+                "actor_id",
+                "role"
+            ],
+            "properties": {
+                "actor_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "billing_order": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "character_name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "actor",
+                        "director",
+                        "writer",
+                        "producer",
+                        "composer"
+                    ],
+                    "example": "director"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.filmHighlights": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.filmRevision": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "credits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.filmRevisionCredit"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "genre_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "revision": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.filmRevisionCredit": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "type": "integer"
+                },
+                "billing_order": {
+                    "type": "integer"
+                },
+                "character_name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.genre": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.getActorResponseBody": {
+            "type": "object",
+            "properties": {
+                "birthdate": {
+                    "type": "string"
+                },
+                "films": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.actorFilm"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sex": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.getCurrentUserResponseBody": {
+            "type": "object",
+            "properties": {
+                "disabled": {
+                    "type": "boolean"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.getFilmCreditsResponseBody": {
+            "type": "object",
+            "properties": {
+                "credits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.filmCredit"
+                    }
+                }
+            }
+        },
+        "internal_app_entrypoint_http.getFilmResponseBody": {
+            "type": "object",
+            "properties": {
+                "actors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.filmActor"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.genre"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "release_date": {
                     "type": "string"
                 },
                 "title": {
@@ -700,34 +5081,292 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_app_entrypoint_http.createActorRequestBody": {
+        "internal_app_entrypoint_http.getGenreResponseBody": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.importFilmsResponseBody": {
+            "type": "object",
+            "properties": {
+                "committed": {
+                    "type": "boolean"
+                },
+                "created_actors": {
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "film_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "films": {
+                    "type": "integer"
+                },
+                "matched_actors": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.listActorRevisionsResponseBody": {
+            "type": "object",
+            "properties": {
+                "revisions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.actorRevision"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.listActorsResponseBody": {
+            "type": "object",
+            "properties": {
+                "actors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.actor"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.listAuditEventsResponseBody": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.auditEvent"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.listFilmReviewsResponseBody": {
+            "type": "object",
+            "properties": {
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.review"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.listFilmRevisionsResponseBody": {
+            "type": "object",
+            "properties": {
+                "revisions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.filmRevision"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.listFilmsResponseBody": {
+            "type": "object",
+            "properties": {
+                "films": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.film"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.listGenresResponseBody": {
+            "type": "object",
+            "properties": {
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.genre"
+                    }
+                }
+            }
+        },
+        "internal_app_entrypoint_http.listTrashResponseBody": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.trashItem"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.listUserFilmsResponseBody": {
+            "type": "object",
+            "properties": {
+                "films": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.film"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.listUsersResponseBody": {
+            "type": "object",
+            "properties": {
+                "total": {
+                    "type": "integer"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.userAccount"
+                    }
+                }
+            }
+        },
+        "internal_app_entrypoint_http.loginRequestBody": {
             "type": "object",
             "required": [
-                "birthdate",
-                "name",
-                "sex"
+                "email",
+                "password"
             ],
             "properties": {
-                "birthdate": {
+                "email": {
                     "type": "string",
-                    "example": "2006-01-02"
+                    "example": "user@example.com"
                 },
-                "name": {
+                "password": {
                     "type": "string",
-                    "maxLength": 100
+                    "example": "asdf1234"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.logoutResponseBody": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.refreshTokenRequestBody": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.registerUserRequestBody": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "user@example.com"
                 },
-                "sex": {
-                    "type": "integer",
-                    "enum": [
-                        0,
-                        1,
-                        2,
-                        9
-                    ]
+                "password": {
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8,
+                    "example": "asdf1234"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.registerUserResponseBody": {
+            "type": "object",
+            "properties": {
+                "disabled": {
+                    "type": "boolean"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.removeUserFilmResponseBody": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
                 }
             }
         },
-        "internal_app_entrypoint_http.createActorResponseBody": {
+        "internal_app_entrypoint_http.resetUserPasswordRequestBody": {
+            "type": "object",
+            "required": [
+                "new_password"
+            ],
+            "properties": {
+                "new_password": {
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8
+                }
+            }
+        },
+        "internal_app_entrypoint_http.resetUserPasswordResponseBody": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.restoreActorResponseBody": {
             "type": "object",
             "properties": {
                 "birthdate": {
@@ -750,47 +5389,7 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_app_entrypoint_http.createFilmRequestBody": {
-            "type": "object",
-            "required": [
-                "actor_ids",
-                "rating",
-                "release_date",
-                "title"
-            ],
-            "properties": {
-                "actor_ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    },
-                    "example": [
-                        1,
-                        2,
-                        3
-                    ]
-                },
-                "description": {
-                    "type": "string",
-                    "maxLength": 1000
-                },
-                "rating": {
-                    "type": "integer",
-                    "maximum": 10,
-                    "minimum": 0
-                },
-                "release_date": {
-                    "type": "string",
-                    "example": "2006-01-02"
-                },
-                "title": {
-                    "type": "string",
-                    "maxLength": 150,
-                    "minLength": 1
-                }
-            }
-        },
-        "internal_app_entrypoint_http.createFilmResponseBody": {
+        "internal_app_entrypoint_http.restoreFilmResponseBody": {
             "type": "object",
             "properties": {
                 "actors": {
@@ -802,6 +5401,12 @@ const docTemplate = `{
                 "description": {
                     "type": "string"
                 },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.genre"
+                    }
+                },
                 "id": {
                     "type": "integer"
                 },
@@ -816,23 +5421,30 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_app_entrypoint_http.deleteActorResponseBody": {
+        "internal_app_entrypoint_http.revertActorResponseBody": {
             "type": "object",
             "properties": {
-                "message": {
+                "birthdate": {
                     "type": "string"
-                }
-            }
-        },
-        "internal_app_entrypoint_http.deleteFilmResponseBody": {
-            "type": "object",
-            "properties": {
-                "message": {
+                },
+                "films": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.actorFilm"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
+                },
+                "sex": {
+                    "type": "integer"
                 }
             }
         },
-        "internal_app_entrypoint_http.film": {
+        "internal_app_entrypoint_http.revertFilmResponseBody": {
             "type": "object",
             "properties": {
                 "actors": {
@@ -844,6 +5456,12 @@ const docTemplate = `{
                 "description": {
                     "type": "string"
                 },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.genre"
+                    }
+                },
                 "id": {
                     "type": "integer"
                 },
@@ -858,53 +5476,81 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_app_entrypoint_http.filmActor": {
+        "internal_app_entrypoint_http.review": {
             "type": "object",
             "properties": {
-                "birthdate": {
+                "created_at": {
                     "type": "string"
                 },
+                "film_id": {
+                    "type": "integer"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "name": {
+                "rating": {
+                    "type": "integer"
+                },
+                "text": {
                     "type": "string"
                 },
-                "sex": {
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
                     "type": "integer"
                 }
             }
         },
-        "internal_app_entrypoint_http.listActorsResponseBody": {
+        "internal_app_entrypoint_http.revisionChange": {
             "type": "object",
             "properties": {
-                "actors": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/internal_app_entrypoint_http.actor"
-                    }
-                }
+                "field": {
+                    "type": "string"
+                },
+                "from": {},
+                "to": {}
             }
         },
-        "internal_app_entrypoint_http.listFilmsResponseBody": {
+        "internal_app_entrypoint_http.tokensResponseBody": {
             "type": "object",
             "properties": {
-                "films": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/internal_app_entrypoint_http.film"
-                    }
+                "access_token": {
+                    "type": "string"
+                },
+                "access_token_expires_at": {
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "refresh_token_expires_at": {
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z"
                 }
             }
         },
-        "internal_app_entrypoint_http.searchFilmsResponseBody": {
+        "internal_app_entrypoint_http.trashItem": {
             "type": "object",
             "properties": {
-                "films": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/internal_app_entrypoint_http.film"
-                    }
+                "deleted_at": {
+                    "type": "string"
+                },
+                "deleted_by": {
+                    "type": "integer"
+                },
+                "entity_id": {
+                    "type": "integer"
+                },
+                "entity_type": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "purge_at": {
+                    "type": "string"
                 }
             }
         },
@@ -953,6 +5599,31 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_app_entrypoint_http.updateCurrentUserRequestBody": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8
+                }
+            }
+        },
+        "internal_app_entrypoint_http.updateCurrentUserResponseBody": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
         "internal_app_entrypoint_http.updateFilmRequestBody": {
             "type": "object",
             "properties": {
@@ -967,10 +5638,26 @@ const docTemplate = `{
                         3
                     ]
                 },
+                "credits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.filmCreditRequest"
+                    }
+                },
                 "description": {
                     "type": "string",
                     "maxLength": 1000
                 },
+                "genre_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
                 "rating": {
                     "type": "integer",
                     "maximum": 10,
@@ -999,6 +5686,12 @@ const docTemplate = `{
                 "description": {
                     "type": "string"
                 },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_app_entrypoint_http.genre"
+                    }
+                },
                 "id": {
                     "type": "integer"
                 },
@@ -1012,11 +5705,129 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "internal_app_entrypoint_http.updateGenreRequestBody": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "Drama"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.updateGenreResponseBody": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.updateReviewRequestBody": {
+            "type": "object",
+            "properties": {
+                "rating": {
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 1,
+                    "example": 9
+                },
+                "text": {
+                    "type": "string",
+                    "maxLength": 5000,
+                    "example": "Even better the second time"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.updateReviewResponseBody": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "film_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.updateUserRoleRequestBody": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "example": "admin"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.updateUserRoleResponseBody": {
+            "type": "object",
+            "properties": {
+                "disabled": {
+                    "type": "boolean"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_app_entrypoint_http.userAccount": {
+            "type": "object",
+            "properties": {
+                "disabled": {
+                    "type": "boolean"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
         "BasicAuth": {
             "type": "basic"
+        },
+        "BearerAuth": {
+            "description": "Access token from '/auth/login' prefixed with 'Bearer '",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
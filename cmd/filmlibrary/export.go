@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/vaberof/vk-internship-task/internal/app/catalog"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const exportCommand = "export"
+
+const (
+	exportFilms  = "films"
+	exportActors = "actors"
+)
+
+// runExportCommand writes all films or actors to a file the same way as 'GET /api/v1/exports/{films|actors}' does:
+//
+//	filmlibrary [flags] export [-format json|csv|ndjson] -output <file> films|actors
+//
+// Without '-format' the format is taken from the file extension. The file is removed if the export fails.
+func runExportCommand(ctx context.Context, args []string, filmService domain.FilmService, actorService domain.ActorService, output io.Writer) error {
+	flagSet := flag.NewFlagSet(exportCommand, flag.ContinueOnError)
+	formatName := flagSet.String("format", "", "Format of the file: 'json', 'csv' or 'ndjson'. By default it is taken from the file extension")
+	path := flagSet.String("output", "", "File to write the export to")
+
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+	if flagSet.NArg() != 1 || *path == "" {
+		return errors.New("usage: export [-format json|csv|ndjson] -output <file> films|actors")
+	}
+
+	entity := flagSet.Arg(0)
+	if entity != exportFilms && entity != exportActors {
+		return fmt.Errorf("unexpected export '%s'. Allowed exports: '%s', '%s'", entity, exportFilms, exportActors)
+	}
+
+	if *formatName == "" {
+		*formatName = strings.TrimPrefix(filepath.Ext(*path), ".")
+	}
+	format, err := catalog.ParseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(*path)
+	if err != nil {
+		return fmt.Errorf("failed to create file for export: %w", err)
+	}
+
+	if err = writeExport(ctx, file, entity, format, filmService, actorService); err != nil {
+		file.Close()
+		os.Remove(*path)
+		return err
+	}
+
+	if err = file.Close(); err != nil {
+		os.Remove(*path)
+		return fmt.Errorf("failed to close file with export: %w", err)
+	}
+
+	fmt.Fprintf(output, "%s have exported to '%s'\n", entity, *path)
+
+	return nil
+}
+
+func writeExport(ctx context.Context, writer io.Writer, entity string, format catalog.Format, filmService domain.FilmService, actorService domain.ActorService) error {
+	bufferedWriter := bufio.NewWriter(writer)
+
+	switch entity {
+	case exportFilms:
+		encoder, err := catalog.NewFilmsEncoder(bufferedWriter, format)
+		if err != nil {
+			return err
+		}
+		if err = filmService.Export(ctx, encoder.Encode); err != nil {
+			return err
+		}
+		if err = encoder.Close(); err != nil {
+			return err
+		}
+	case exportActors:
+		encoder, err := catalog.NewActorsEncoder(bufferedWriter, format)
+		if err != nil {
+			return err
+		}
+		if err = actorService.Export(ctx, encoder.Encode); err != nil {
+			return err
+		}
+		if err = encoder.Close(); err != nil {
+			return err
+		}
+	}
+
+	if err := bufferedWriter.Flush(); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+
+	return nil
+}
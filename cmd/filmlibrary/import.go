@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/vaberof/vk-internship-task/internal/app/catalog"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const importCommand = "import"
+
+// runImportCommand imports films from a file the same way as 'POST /api/v1/imports' does:
+//
+//	filmlibrary [flags] import [-format csv|ndjson] [-dry-run] <file>
+//
+// Without '-format' the format is taken from the file extension.
+func runImportCommand(ctx context.Context, args []string, filmImportService domain.FilmImportService, validate *validator.Validate, output io.Writer) error {
+	flagSet := flag.NewFlagSet(importCommand, flag.ContinueOnError)
+	formatName := flagSet.String("format", "", "Format of the file: 'csv' or 'ndjson'. By default it is taken from the file extension")
+	dryRun := flagSet.Bool("dry-run", false, "Check the films without importing them")
+
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+	if flagSet.NArg() != 1 {
+		return errors.New("usage: import [-format csv|ndjson] [-dry-run] <file>")
+	}
+
+	path := flagSet.Arg(0)
+
+	if *formatName == "" {
+		*formatName = strings.TrimPrefix(filepath.Ext(path), ".")
+	}
+	format, err := catalog.ParseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open file with films: %w", err)
+	}
+	defer file.Close()
+
+	rows, rowErrors, err := catalog.DecodeFilms(file, format, validate)
+	if err != nil {
+		return err
+	}
+
+	report, err := filmImportService.Import(ctx, rows, rowErrors, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	for _, rowError := range report.Errors {
+		fmt.Fprintf(output, "line %d: %s\n", rowError.Line, rowError.Message)
+	}
+	fmt.Fprintf(output, "films: %d, created actors: %d, matched actors: %d, dry run: %t, committed: %t\n",
+		report.Films, report.CreatedActors, report.MatchedActors, report.DryRun, report.Committed)
+
+	if len(report.Errors) > 0 {
+		return fmt.Errorf("%d rows of '%s' have errors, nothing is imported", len(report.Errors), path)
+	}
+
+	return nil
+}
@@ -3,17 +3,13 @@ package main
 import (
 	"context"
 	"flag"
-	"fmt"
 	"github.com/go-playground/validator/v10"
 	"github.com/joho/godotenv"
 	_ "github.com/vaberof/vk-internship-task/cmd/filmlibrary/docs"
 	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http"
 	"github.com/vaberof/vk-internship-task/internal/domain"
-	pgstorage "github.com/vaberof/vk-internship-task/internal/infra/storage/postgres"
-	"github.com/vaberof/vk-internship-task/internal/infra/storage/postgres/pguser"
 	"github.com/vaberof/vk-internship-task/internal/service/auth"
 	"github.com/vaberof/vk-internship-task/internal/service/user"
-	"github.com/vaberof/vk-internship-task/pkg/database/postgres"
 	"github.com/vaberof/vk-internship-task/pkg/http/httpserver"
 	"github.com/vaberof/vk-internship-task/pkg/logging/logs"
 	"log"
@@ -35,6 +31,11 @@ var environmentVariablesPath = flag.String("env.vars.file", "not-found.env", "Pa
 //	@host		localhost:8000
 //	@BasePath	/api/v1
 
+//	@securityDefinitions.apikey	BearerAuth
+//	@in							header
+//	@name						Authorization
+//	@description				Access token from '/auth/login' prefixed with 'Bearer '
+
 //	@securityDefinitions.basic	BasicAuth
 func main() {
 	flag.Parse()
@@ -44,28 +45,57 @@ func main() {
 
 	appConfig := mustGetAppConfig(*appConfigPaths)
 
-	fmt.Printf("%+v\n", appConfig)
-
 	logger := logs.New(os.Stdout, nil)
 
-	postgresManagedDb, err := postgres.New(&appConfig.Postgres)
+	appStorages, err := newStorages(&appConfig)
 	if err != nil {
 		panic(err)
 	}
 
-	actorStorage := pgstorage.NewPgActorStorage(postgresManagedDb.PostgresDb)
-	filmStorage := pgstorage.NewPgFilmStorage(postgresManagedDb.PostgresDb)
-	userStorage := pguser.NewPgUserStorage(postgresManagedDb.PostgresDb)
+	actorService := domain.NewActorService(appStorages.actor, logger)
+	filmService := domain.NewFilmService(appStorages.film, appStorages.actor, appStorages.genre, appStorages.filmList, logger)
+	genreService := domain.NewGenreService(appStorages.genre, logger)
+	reviewService := domain.NewReviewService(appStorages.review, appStorages.film, logger)
+	filmListService := domain.NewFilmListService(appStorages.filmList, logger)
+	auditService := domain.NewAuditService(appStorages.audit, logger)
+	filmImportService := domain.NewFilmImportService(appStorages.filmImport, appStorages.genre, logger)
+	trashService := domain.NewTrashService(appStorages.trash, &appConfig.Trash, logger)
+	filmRevisionService := domain.NewFilmRevisionService(appStorages.filmRevision, appStorages.film, filmService, logger)
+	actorRevisionService := domain.NewActorRevisionService(appStorages.actorRevision, appStorages.actor, actorService, logger)
 
-	actorService := domain.NewActorService(actorStorage, logger)
-	filmService := domain.NewFilmService(filmStorage, actorStorage, logger)
+	httpRequestBodyValidator := validator.New()
 
-	userService := user.NewUserService(userStorage, logger)
-	authService := auth.NewAuthService(userService, logger)
+	if flag.Arg(0) == importCommand {
+		err = runImportCommand(context.Background(), flag.Args()[1:], filmImportService, httpRequestBodyValidator, os.Stdout)
+		if closeErr := appStorages.close(); closeErr != nil {
+			log.Printf("Storage Shutdown: %v\n", closeErr)
+		}
+		if err != nil {
+			log.Fatalf("Import failed: %v\n", err)
+		}
+		return
+	}
 
-	httpRequestBodyValidator := validator.New()
+	if flag.Arg(0) == exportCommand {
+		err = runExportCommand(context.Background(), flag.Args()[1:], filmService, actorService, os.Stdout)
+		if closeErr := appStorages.close(); closeErr != nil {
+			log.Printf("Storage Shutdown: %v\n", closeErr)
+		}
+		if err != nil {
+			log.Fatalf("Export failed: %v\n", err)
+		}
+		return
+	}
+
+	userService := user.NewUserService(appStorages.user, logger)
+	authService := auth.NewAuthService(userService, appStorages.refreshToken, &appConfig.Auth, logger)
 
-	httpHandler := http.NewHandler(actorService, filmService, authService, httpRequestBodyValidator, logger)
+	rolePermissions, err := userService.ListRolePermissions(context.Background())
+	if err != nil {
+		panic(err)
+	}
+
+	httpHandler := http.NewHandler(actorService, filmService, genreService, reviewService, filmListService, auditService, filmImportService, trashService, filmRevisionService, actorRevisionService, authService, userService, rolePermissions, httpRequestBodyValidator, logger)
 
 	appServer := httpserver.New(&appConfig.Server, logger)
 
@@ -73,6 +103,9 @@ func main() {
 
 	serverExitChannel := appServer.StartAsync()
 
+	trashPurgeCtx, stopTrashPurge := context.WithCancel(context.Background())
+	trashPurgeDone := startTrashPurge(trashPurgeCtx, trashService, &appConfig.Trash)
+
 	quitCh := make(chan os.Signal, 1)
 	signal.Notify(quitCh, syscall.SIGTERM, syscall.SIGINT)
 
@@ -80,21 +113,27 @@ func main() {
 	case signalValue := <-quitCh:
 		logger.GetLogger().Info("stopping application", "signal", signalValue.String())
 
-		gracefulShutdown(appServer, postgresManagedDb)
+		stopTrashPurge()
+		<-trashPurgeDone
+
+		gracefulShutdown(appServer, appStorages)
 	case err := <-serverExitChannel:
 		logger.GetLogger().Info("stopping application", "err", err.Error())
 
-		gracefulShutdown(appServer, postgresManagedDb)
+		stopTrashPurge()
+		<-trashPurgeDone
+
+		gracefulShutdown(appServer, appStorages)
 	}
 }
 
-func gracefulShutdown(server *httpserver.AppServer, postgresManagedDb *postgres.ManagedDatabase) {
+func gracefulShutdown(server *httpserver.AppServer, appStorages *storages) {
 	if err := server.Server.Shutdown(context.Background()); err != nil {
 		log.Printf("HTTP server Shutdown: %v\n", err)
 	}
 
-	if err := postgresManagedDb.Disconnect(); err != nil {
-		log.Printf("Postgres database Shutdown: %v\n", err)
+	if err := appStorages.close(); err != nil {
+		log.Printf("Storage Shutdown: %v\n", err)
 	}
 
 	log.Println("Server successfully shutdown")
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/memory"
+	pgstorage "github.com/vaberof/vk-internship-task/internal/infra/storage/postgres"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/postgres/pgauth"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/postgres/pguser"
+	sqlitestorage "github.com/vaberof/vk-internship-task/internal/infra/storage/sqlite"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/sqlite/sqliteauth"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/sqlite/sqliteuser"
+	"github.com/vaberof/vk-internship-task/internal/service/auth"
+	"github.com/vaberof/vk-internship-task/internal/service/user"
+	"github.com/vaberof/vk-internship-task/pkg/database/postgres"
+	"github.com/vaberof/vk-internship-task/pkg/database/sqlite"
+)
+
+const (
+	storageDriverPostgres = "postgres"
+	storageDriverSqlite   = "sqlite"
+	storageDriverMemory   = "memory"
+)
+
+// StorageConfig selects the storages of the application. The 'postgres' driver is used if none is set.
+// The 'sqlite' driver keeps everything in a single database file and needs no database server.
+// The 'memory' driver keeps everything in the memory of the process and needs no database,
+// its data is lost once the application stops.
+type StorageConfig struct {
+	Driver string `yaml:"driver"`
+}
+
+// storages are the storages of the selected driver. close releases the database of the driver, if there is one.
+type storages struct {
+	actor         domain.ActorStorage
+	film          domain.FilmStorage
+	genre         domain.GenreStorage
+	review        domain.ReviewStorage
+	filmList      domain.FilmListStorage
+	audit         domain.AuditStorage
+	filmImport    domain.FilmImportStorage
+	trash         domain.TrashStorage
+	filmRevision  domain.FilmRevisionStorage
+	actorRevision domain.ActorRevisionStorage
+	user          user.UserStorage
+	refreshToken  auth.RefreshTokenStorage
+
+	close func() error
+}
+
+func newStorages(config *AppConfig) (*storages, error) {
+	switch config.Storage.Driver {
+	case storageDriverPostgres:
+		return newPostgresStorages(&config.Postgres)
+	case storageDriverSqlite:
+		return newSqliteStorages(&config.Sqlite)
+	case storageDriverMemory:
+		return newMemoryStorages(), nil
+	default:
+		return nil, fmt.Errorf("unexpected storage driver '%s'", config.Storage.Driver)
+	}
+}
+
+func newPostgresStorages(config *postgres.Config) (*storages, error) {
+	postgresManagedDb, err := postgres.New(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &storages{
+		actor:         pgstorage.NewPgActorStorage(postgresManagedDb.PostgresDb),
+		film:          pgstorage.NewPgFilmStorage(postgresManagedDb.PostgresDb),
+		genre:         pgstorage.NewPgGenreStorage(postgresManagedDb.PostgresDb),
+		review:        pgstorage.NewPgReviewStorage(postgresManagedDb.PostgresDb),
+		filmList:      pgstorage.NewPgFilmListStorage(postgresManagedDb.PostgresDb),
+		audit:         pgstorage.NewPgAuditStorage(postgresManagedDb.PostgresDb),
+		filmImport:    pgstorage.NewPgFilmImportStorage(postgresManagedDb.PostgresDb),
+		trash:         pgstorage.NewPgTrashStorage(postgresManagedDb.PostgresDb),
+		filmRevision:  pgstorage.NewPgFilmRevisionStorage(postgresManagedDb.PostgresDb),
+		actorRevision: pgstorage.NewPgActorRevisionStorage(postgresManagedDb.PostgresDb),
+		user:          pguser.NewPgUserStorage(postgresManagedDb.PostgresDb),
+		refreshToken:  pgauth.NewPgRefreshTokenStorage(postgresManagedDb.PostgresDb),
+		close:         postgresManagedDb.Disconnect,
+	}, nil
+}
+
+func newSqliteStorages(config *sqlite.Config) (*storages, error) {
+	sqliteManagedDb, err := sqlite.New(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &storages{
+		actor:         sqlitestorage.NewSqliteActorStorage(sqliteManagedDb.SqliteDb),
+		film:          sqlitestorage.NewSqliteFilmStorage(sqliteManagedDb.SqliteDb),
+		genre:         sqlitestorage.NewSqliteGenreStorage(sqliteManagedDb.SqliteDb),
+		review:        sqlitestorage.NewSqliteReviewStorage(sqliteManagedDb.SqliteDb),
+		filmList:      sqlitestorage.NewSqliteFilmListStorage(sqliteManagedDb.SqliteDb),
+		audit:         sqlitestorage.NewSqliteAuditStorage(sqliteManagedDb.SqliteDb),
+		filmImport:    sqlitestorage.NewSqliteFilmImportStorage(sqliteManagedDb.SqliteDb),
+		trash:         sqlitestorage.NewSqliteTrashStorage(sqliteManagedDb.SqliteDb),
+		filmRevision:  sqlitestorage.NewSqliteFilmRevisionStorage(sqliteManagedDb.SqliteDb),
+		actorRevision: sqlitestorage.NewSqliteActorRevisionStorage(sqliteManagedDb.SqliteDb),
+		user:          sqliteuser.NewSqliteUserStorage(sqliteManagedDb.SqliteDb),
+		refreshToken:  sqliteauth.NewSqliteRefreshTokenStorage(sqliteManagedDb.SqliteDb),
+		close:         sqliteManagedDb.Disconnect,
+	}, nil
+}
+
+func newMemoryStorages() *storages {
+	memoryDb := memory.NewDb()
+
+	return &storages{
+		actor:         memory.NewMemActorStorage(memoryDb),
+		film:          memory.NewMemFilmStorage(memoryDb),
+		genre:         memory.NewMemGenreStorage(memoryDb),
+		review:        memory.NewMemReviewStorage(memoryDb),
+		filmList:      memory.NewMemFilmListStorage(memoryDb),
+		audit:         memory.NewMemAuditStorage(memoryDb),
+		filmImport:    memory.NewMemFilmImportStorage(memoryDb),
+		trash:         memory.NewMemTrashStorage(memoryDb),
+		filmRevision:  memory.NewMemFilmRevisionStorage(memoryDb),
+		actorRevision: memory.NewMemActorRevisionStorage(memoryDb),
+		user:          memory.NewMemUserStorage(memoryDb),
+		refreshToken:  memory.NewMemRefreshTokenStorage(memoryDb),
+		close:         func() error { return nil },
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"time"
+)
+
+// startTrashPurge purges the trash once on start and then every purge interval until ctx is done.
+// The returned channel is closed once the purging has stopped. Nothing is purged if the retention is zero.
+func startTrashPurge(ctx context.Context, trashService domain.TrashService, config *domain.TrashConfig) <-chan struct{} {
+	done := make(chan struct{})
+
+	if config.Retention <= 0 {
+		close(done)
+		return done
+	}
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(config.PurgeInterval)
+		defer ticker.Stop()
+
+		for {
+			// The service logs failures, and the next tick retries them
+			_ = trashService.Purge(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return done
+}
@@ -0,0 +1,269 @@
+package catalog
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// exportedFilm is an exported film with its actors
+type exportedFilm struct {
+	Id          int64                `json:"id"`
+	Title       string               `json:"title"`
+	Description string               `json:"description"`
+	ReleaseDate string               `json:"release_date"`
+	Rating      uint8                `json:"rating"`
+	Actors      []*exportedFilmActor `json:"actors"`
+}
+
+// exportedFilmActor is an actor of an exported film
+type exportedFilmActor struct {
+	Id        int64  `json:"id"`
+	Name      string `json:"name"`
+	Sex       uint8  `json:"sex"`
+	BirthDate string `json:"birthdate"`
+}
+
+// exportedActor is an exported actor with ids of their films
+type exportedActor struct {
+	Id        int64   `json:"id"`
+	Name      string  `json:"name"`
+	Sex       uint8   `json:"sex"`
+	BirthDate string  `json:"birthdate"`
+	FilmIds   []int64 `json:"film_ids"`
+}
+
+// CSV columns of exported films and actors. Actors of a film are listed as 'name|sex|birthdate' separated by ';'
+// like in imported films, ids are separated by ';' as well.
+var (
+	exportedFilmColumns  = []string{"id", columnTitle, columnDescription, columnReleaseDate, columnRating, "actor_ids", columnActors}
+	exportedActorColumns = []string{"id", "name", "sex", "birthdate", "film_ids"}
+)
+
+// FilmsEncoder writes exported films one by one, so that the whole export is never held in memory
+type FilmsEncoder struct {
+	encoder *recordEncoder
+}
+
+// NewFilmsEncoder creates an encoder of films in the format. Close must be called after the last film.
+func NewFilmsEncoder(writer io.Writer, format Format) (*FilmsEncoder, error) {
+	encoder, err := newRecordEncoder(writer, format, exportedFilmColumns)
+	if err != nil {
+		return nil, err
+	}
+	return &FilmsEncoder{encoder: encoder}, nil
+}
+
+func (e *FilmsEncoder) Encode(film *domain.Film) error {
+	record := buildExportedFilm(film)
+
+	actorIds := make([]int64, len(record.Actors))
+	actors := make([]string, len(record.Actors))
+	for i, actor := range record.Actors {
+		actorIds[i] = actor.Id
+		actors[i] = strings.Join([]string{actor.Name, strconv.Itoa(int(actor.Sex)), actor.BirthDate}, "|")
+	}
+
+	return e.encoder.encode(record, func() []string {
+		return []string{
+			strconv.FormatInt(record.Id, 10),
+			record.Title,
+			record.Description,
+			record.ReleaseDate,
+			strconv.Itoa(int(record.Rating)),
+			joinIds(actorIds),
+			strings.Join(actors, ";"),
+		}
+	})
+}
+
+// Close finishes the output, e.g. closes the JSON array
+func (e *FilmsEncoder) Close() error {
+	return e.encoder.close()
+}
+
+// ActorsEncoder writes exported actors one by one, so that the whole export is never held in memory
+type ActorsEncoder struct {
+	encoder *recordEncoder
+}
+
+// NewActorsEncoder creates an encoder of actors in the format. Close must be called after the last actor.
+func NewActorsEncoder(writer io.Writer, format Format) (*ActorsEncoder, error) {
+	encoder, err := newRecordEncoder(writer, format, exportedActorColumns)
+	if err != nil {
+		return nil, err
+	}
+	return &ActorsEncoder{encoder: encoder}, nil
+}
+
+func (e *ActorsEncoder) Encode(actor *domain.Actor) error {
+	record := buildExportedActor(actor)
+
+	return e.encoder.encode(record, func() []string {
+		return []string{
+			strconv.FormatInt(record.Id, 10),
+			record.Name,
+			strconv.Itoa(int(record.Sex)),
+			record.BirthDate,
+			joinIds(record.FilmIds),
+		}
+	})
+}
+
+// Close finishes the output, e.g. closes the JSON array
+func (e *ActorsEncoder) Close() error {
+	return e.encoder.close()
+}
+
+// recordEncoder writes records as a JSON array, as JSON lines or as CSV rows with a header
+type recordEncoder struct {
+	writer    io.Writer
+	format    Format
+	csvWriter *csv.Writer
+	header    []string
+	started   bool
+	encoded   int
+}
+
+func newRecordEncoder(writer io.Writer, format Format, header []string) (*recordEncoder, error) {
+	encoder := &recordEncoder{
+		writer: writer,
+		format: format,
+		header: header,
+	}
+
+	switch format {
+	case FormatJSON, FormatNDJSON:
+	case FormatCSV:
+		encoder.csvWriter = csv.NewWriter(writer)
+	default:
+		return nil, fmt.Errorf("unexpected format '%s'. Allowed formats: 'json', 'csv', 'ndjson'", format)
+	}
+
+	return encoder, nil
+}
+
+// encode writes the record as JSON or the fields built by csvFields as a CSV row
+func (e *recordEncoder) encode(record any, csvFields func() []string) error {
+	if err := e.start(); err != nil {
+		return err
+	}
+
+	switch e.format {
+	case FormatCSV:
+		if err := e.csvWriter.Write(csvFields()); err != nil {
+			return fmt.Errorf("failed to write record: %w", err)
+		}
+	default:
+		rawRecord, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("failed to marshal record: %w", err)
+		}
+
+		var separator string
+		switch {
+		case e.format == FormatNDJSON:
+			separator = "\n"
+		case e.encoded > 0:
+			rawRecord = append([]byte(","), rawRecord...)
+		}
+
+		if _, err = io.WriteString(e.writer, string(rawRecord)+separator); err != nil {
+			return fmt.Errorf("failed to write record: %w", err)
+		}
+	}
+
+	e.encoded++
+
+	return nil
+}
+
+func (e *recordEncoder) close() error {
+	if err := e.start(); err != nil {
+		return err
+	}
+
+	switch e.format {
+	case FormatJSON:
+		if _, err := io.WriteString(e.writer, "]\n"); err != nil {
+			return fmt.Errorf("failed to write records: %w", err)
+		}
+	case FormatCSV:
+		e.csvWriter.Flush()
+		if err := e.csvWriter.Error(); err != nil {
+			return fmt.Errorf("failed to write records: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// start writes the beginning of the output before the first record, so that an empty output is still valid
+func (e *recordEncoder) start() error {
+	if e.started {
+		return nil
+	}
+	e.started = true
+
+	switch e.format {
+	case FormatJSON:
+		if _, err := io.WriteString(e.writer, "["); err != nil {
+			return fmt.Errorf("failed to write records: %w", err)
+		}
+	case FormatCSV:
+		if err := e.csvWriter.Write(e.header); err != nil {
+			return fmt.Errorf("failed to write records: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func buildExportedFilm(film *domain.Film) *exportedFilm {
+	actors := make([]*exportedFilmActor, len(film.Actors))
+	for i, actor := range film.Actors {
+		actors[i] = &exportedFilmActor{
+			Id:        actor.Id.Int64(),
+			Name:      actor.Name.String(),
+			Sex:       actor.Sex.Uint8(),
+			BirthDate: actor.BirthDate.Time().Format(time.DateOnly),
+		}
+	}
+
+	return &exportedFilm{
+		Id:          film.Id.Int64(),
+		Title:       film.Title.String(),
+		Description: film.Description.String(),
+		ReleaseDate: film.ReleaseDate.Time().Format(time.DateOnly),
+		Rating:      film.Rating.Uint8(),
+		Actors:      actors,
+	}
+}
+
+func buildExportedActor(actor *domain.Actor) *exportedActor {
+	filmIds := make([]int64, len(actor.Films))
+	for i, film := range actor.Films {
+		filmIds[i] = film.Id.Int64()
+	}
+
+	return &exportedActor{
+		Id:        actor.Id.Int64(),
+		Name:      actor.Name.String(),
+		Sex:       actor.Sex.Uint8(),
+		BirthDate: actor.BirthDate.Time().Format(time.DateOnly),
+		FilmIds:   filmIds,
+	}
+}
+
+func joinIds(ids []int64) string {
+	idStrs := make([]string, len(ids))
+	for i, id := range ids {
+		idStrs[i] = strconv.FormatInt(id, 10)
+	}
+	return strings.Join(idStrs, ";")
+}
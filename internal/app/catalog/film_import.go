@@ -0,0 +1,257 @@
+package catalog
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const maxNDJSONLineSize = 1 << 20
+
+// filmRecord is an imported film. It is validated with the rules of createFilmRequestBody,
+// except that actors are embedded instead of being referenced by ids.
+type filmRecord struct {
+	Title       string         `json:"title" validate:"required,min=1,max=150"`
+	Description string         `json:"description,omitempty" validate:"max=1000"`
+	ReleaseDate string         `json:"release_date" validate:"required"`
+	Rating      uint8          `json:"rating" validate:"required,numeric,min=0,max=10"`
+	Actors      []*actorRecord `json:"actors" validate:"required,gt=0,dive"`
+	GenreIds    []int64        `json:"genre_ids,omitempty" validate:"omitempty,dive,numeric"`
+}
+
+// actorRecord is an actor embedded into a film, it is validated with the rules of createActorRequestBody
+type actorRecord struct {
+	Name      string `json:"name" validate:"required,max=100"`
+	Sex       *uint8 `json:"sex" validate:"required,oneof=0 1 2 9"`
+	BirthDate string `json:"birthdate" validate:"required"`
+}
+
+// CSV columns of films. Actors are listed in a single column as 'name|sex|birthdate' separated by ';',
+// genre ids are separated by ';' as well.
+const (
+	columnTitle       = "title"
+	columnDescription = "description"
+	columnReleaseDate = "release_date"
+	columnRating      = "rating"
+	columnActors      = "actors"
+	columnGenreIds    = "genre_ids"
+)
+
+var requiredFilmColumns = []string{columnTitle, columnReleaseDate, columnRating, columnActors}
+
+// DecodeFilms reads films to import from CSV or NDJSON. Rows which cannot be decoded or are invalid
+// are returned as row errors, an error is returned only if the source cannot be read at all.
+func DecodeFilms(reader io.Reader, format Format, validate *validator.Validate) ([]*domain.FilmImportRow, []*domain.FilmImportRowError, error) {
+	switch format {
+	case FormatCSV:
+		return decodeCSVFilms(reader, validate)
+	case FormatNDJSON:
+		return decodeNDJSONFilms(reader, validate)
+	default:
+		return nil, nil, fmt.Errorf("films cannot be imported from '%s', allowed formats: 'csv', 'ndjson'", format)
+	}
+}
+
+func decodeNDJSONFilms(reader io.Reader, validate *validator.Validate) ([]*domain.FilmImportRow, []*domain.FilmImportRowError, error) {
+	var rows []*domain.FilmImportRow
+	var rowErrors []*domain.FilmImportRowError
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxNDJSONLineSize)
+
+	var line int
+	for scanner.Scan() {
+		line++
+
+		rawRecord := strings.TrimSpace(scanner.Text())
+		if rawRecord == "" {
+			continue
+		}
+
+		var record filmRecord
+		if err := json.Unmarshal([]byte(rawRecord), &record); err != nil {
+			rowErrors = append(rowErrors, &domain.FilmImportRowError{Line: line, Message: "invalid JSON"})
+			continue
+		}
+
+		row, err := buildFilmImportRow(line, &record, validate)
+		if err != nil {
+			rowErrors = append(rowErrors, &domain.FilmImportRowError{Line: line, Message: err.Error()})
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read films: %w", err)
+	}
+
+	return rows, rowErrors, nil
+}
+
+func decodeCSVFilms(reader io.Reader, validate *validator.Validate) ([]*domain.FilmImportRow, []*domain.FilmImportRowError, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.TrimLeadingSpace = true
+
+	header, err := csvReader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read header of films: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+		switch column {
+		case columnTitle, columnDescription, columnReleaseDate, columnRating, columnActors, columnGenreIds:
+			columns[column] = i
+		default:
+			return nil, nil, fmt.Errorf("unexpected column '%s' in header of films", column)
+		}
+	}
+	for _, column := range requiredFilmColumns {
+		if _, ok := columns[column]; !ok {
+			return nil, nil, fmt.Errorf("column '%s' is missing in header of films", column)
+		}
+	}
+
+	var rows []*domain.FilmImportRow
+	var rowErrors []*domain.FilmImportRowError
+
+	for {
+		fields, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, nil, fmt.Errorf("failed to read films: %w", err)
+			}
+			rowErrors = append(rowErrors, &domain.FilmImportRowError{Line: parseErr.StartLine, Message: parseErr.Err.Error()})
+			continue
+		}
+
+		line, _ := csvReader.FieldPos(0)
+
+		record, err := buildCSVFilmRecord(fields, columns)
+		if err != nil {
+			rowErrors = append(rowErrors, &domain.FilmImportRowError{Line: line, Message: err.Error()})
+			continue
+		}
+
+		row, err := buildFilmImportRow(line, record, validate)
+		if err != nil {
+			rowErrors = append(rowErrors, &domain.FilmImportRowError{Line: line, Message: err.Error()})
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, rowErrors, nil
+}
+
+func buildCSVFilmRecord(fields []string, columns map[string]int) (*filmRecord, error) {
+	field := func(column string) string {
+		i, ok := columns[column]
+		if !ok {
+			return ""
+		}
+		return strings.TrimSpace(fields[i])
+	}
+
+	record := filmRecord{
+		Title:       field(columnTitle),
+		Description: field(columnDescription),
+		ReleaseDate: field(columnReleaseDate),
+	}
+
+	if ratingStr := field(columnRating); ratingStr != "" {
+		rating, err := strconv.ParseUint(ratingStr, 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' must be an integer from 0 to 10", columnRating)
+		}
+		record.Rating = uint8(rating)
+	}
+
+	if actorsStr := field(columnActors); actorsStr != "" {
+		for _, actorStr := range strings.Split(actorsStr, ";") {
+			// Expect actor like 'Keanu Reeves|1|1964-09-02'
+			actorFields := strings.Split(actorStr, "|")
+			if len(actorFields) != 3 {
+				return nil, fmt.Errorf("invalid actor '%s'. Must be like 'name|sex|birthdate'", strings.TrimSpace(actorStr))
+			}
+
+			sex, err := strconv.ParseUint(strings.TrimSpace(actorFields[1]), 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("sex of actor '%s' must be an integer", strings.TrimSpace(actorFields[0]))
+			}
+			actorSex := uint8(sex)
+
+			record.Actors = append(record.Actors, &actorRecord{
+				Name:      strings.TrimSpace(actorFields[0]),
+				Sex:       &actorSex,
+				BirthDate: strings.TrimSpace(actorFields[2]),
+			})
+		}
+	}
+
+	if genreIdsStr := field(columnGenreIds); genreIdsStr != "" {
+		for _, genreIdStr := range strings.Split(genreIdsStr, ";") {
+			genreId, err := strconv.ParseInt(strings.TrimSpace(genreIdStr), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("'%s' must be integers separated by ';'", columnGenreIds)
+			}
+			record.GenreIds = append(record.GenreIds, genreId)
+		}
+	}
+
+	return &record, nil
+}
+
+func buildFilmImportRow(line int, record *filmRecord, validate *validator.Validate) (*domain.FilmImportRow, error) {
+	if err := validate.Struct(record); err != nil {
+		return nil, err
+	}
+
+	releaseDate, err := time.Parse(time.DateOnly, record.ReleaseDate)
+	if err != nil {
+		return nil, fmt.Errorf("'%s' must be in 'YYYY-MM-DD' format", columnReleaseDate)
+	}
+
+	actors := make([]*domain.FilmImportActor, len(record.Actors))
+	for i, actor := range record.Actors {
+		birthDate, err := time.Parse(time.DateOnly, actor.BirthDate)
+		if err != nil {
+			return nil, fmt.Errorf("birthdate of actor '%s' must be in 'YYYY-MM-DD' format", actor.Name)
+		}
+		actors[i] = &domain.FilmImportActor{
+			Name:      domain.ActorName(actor.Name),
+			Sex:       domain.ActorSex(*actor.Sex),
+			BirthDate: domain.ActorBirthDate(birthDate),
+		}
+	}
+
+	genreIds := make([]domain.GenreId, len(record.GenreIds))
+	for i, genreId := range record.GenreIds {
+		genreIds[i] = domain.GenreId(genreId)
+	}
+
+	return &domain.FilmImportRow{
+		Line:        line,
+		Title:       domain.FilmTitle(record.Title),
+		Description: domain.FilmDescription(record.Description),
+		ReleaseDate: domain.FilmReleaseDate(releaseDate),
+		Rating:      domain.FilmRating(record.Rating),
+		Actors:      actors,
+		GenreIds:    genreIds,
+	}, nil
+}
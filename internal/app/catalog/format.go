@@ -0,0 +1,61 @@
+package catalog
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+)
+
+// Format is a format of catalog files
+type Format string
+
+const (
+	FormatJSON   Format = "json"
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+)
+
+var formatMediaTypes = map[Format]string{
+	FormatJSON:   "application/json",
+	FormatCSV:    "text/csv",
+	FormatNDJSON: "application/x-ndjson",
+}
+
+func (format Format) String() string {
+	return string(format)
+}
+
+// MediaType returns the media type of the format, e.g. 'text/csv'
+func (format Format) MediaType() string {
+	return formatMediaTypes[format]
+}
+
+// ParseFormat parses a format name such as 'csv'
+func ParseFormat(name string) (Format, error) {
+	format := Format(strings.ToLower(strings.TrimSpace(name)))
+	if _, ok := formatMediaTypes[format]; !ok {
+		return "", fmt.Errorf("unexpected format '%s'. Allowed formats: 'json', 'csv', 'ndjson'", name)
+	}
+	return format, nil
+}
+
+// FormatFromMediaType returns the format of a media type such as 'text/csv; charset=utf-8'.
+// Both 'application/x-ndjson' and 'application/ndjson' mean NDJSON.
+func FormatFromMediaType(mediaType string) (Format, bool) {
+	parsedMediaType, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return "", false
+	}
+
+	if parsedMediaType == "application/ndjson" {
+		return FormatNDJSON, true
+	}
+
+	for format, formatMediaType := range formatMediaTypes {
+		if parsedMediaType == formatMediaType {
+			return format, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,184 @@
+package catalog_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/app/catalog"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"testing"
+	"time"
+)
+
+var (
+	actor1 = &domain.Actor{
+		Id:        1,
+		Name:      "Actor_1",
+		Sex:       1,
+		BirthDate: domain.ActorBirthDate(time.Date(1970, 1, 2, 0, 0, 0, 0, time.UTC)),
+	}
+	actor2 = &domain.Actor{
+		Id:        2,
+		Name:      "Actor, 2",
+		Sex:       2,
+		BirthDate: domain.ActorBirthDate(time.Date(1980, 1, 2, 0, 0, 0, 0, time.UTC)),
+	}
+	film1 = &domain.Film{
+		Id:          1,
+		Title:       "Title_1",
+		Description: "Description",
+		ReleaseDate: domain.FilmReleaseDate(time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC)),
+		Rating:      8,
+		Actors:      []*domain.Actor{actor1, actor2},
+	}
+	film2 = &domain.Film{
+		Id:          2,
+		Title:       "Title_2",
+		ReleaseDate: domain.FilmReleaseDate(time.Date(2010, 1, 2, 0, 0, 0, 0, time.UTC)),
+		Rating:      5,
+	}
+)
+
+func TestFilmsEncoder(t *testing.T) {
+	type tCase struct {
+		name     string
+		format   catalog.Format
+		films    []*domain.Film
+		expected string
+	}
+
+	tCases := []tCase{
+		{
+			name:   "json",
+			format: catalog.FormatJSON,
+			films:  []*domain.Film{film1, film2},
+			expected: `[{"id":1,"title":"Title_1","description":"Description","release_date":"2000-01-02","rating":8,"actors":[{"id":1,"name":"Actor_1","sex":1,"birthdate":"1970-01-02"},{"id":2,"name":"Actor, 2","sex":2,"birthdate":"1980-01-02"}]},` +
+				`{"id":2,"title":"Title_2","description":"","release_date":"2010-01-02","rating":5,"actors":[]}]` + "\n",
+		},
+		{
+			name:   "ndjson",
+			format: catalog.FormatNDJSON,
+			films:  []*domain.Film{film2, film2},
+			expected: `{"id":2,"title":"Title_2","description":"","release_date":"2010-01-02","rating":5,"actors":[]}` + "\n" +
+				`{"id":2,"title":"Title_2","description":"","release_date":"2010-01-02","rating":5,"actors":[]}` + "\n",
+		},
+		{
+			name:   "csv",
+			format: catalog.FormatCSV,
+			films:  []*domain.Film{film1, film2},
+			expected: "id,title,description,release_date,rating,actor_ids,actors\n" +
+				"1,Title_1,Description,2000-01-02,8,1;2,\"Actor_1|1|1970-01-02;Actor, 2|2|1980-01-02\"\n" +
+				"2,Title_2,,2010-01-02,5,,\n",
+		},
+		{
+			name:     "json_empty",
+			format:   catalog.FormatJSON,
+			expected: "[]\n",
+		},
+		{
+			name:     "ndjson_empty",
+			format:   catalog.FormatNDJSON,
+			expected: "",
+		},
+		{
+			name:     "csv_empty",
+			format:   catalog.FormatCSV,
+			expected: "id,title,description,release_date,rating,actor_ids,actors\n",
+		},
+	}
+
+	for _, tCase := range tCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			var output bytes.Buffer
+
+			encoder, err := catalog.NewFilmsEncoder(&output, tCase.format)
+			require.NoError(t, err)
+
+			for _, film := range tCase.films {
+				require.NoError(t, encoder.Encode(film))
+			}
+			require.NoError(t, encoder.Close())
+
+			require.Equal(t, tCase.expected, output.String())
+		})
+	}
+}
+
+func TestFilmsEncoderJSONIsValid(t *testing.T) {
+	var output bytes.Buffer
+
+	encoder, err := catalog.NewFilmsEncoder(&output, catalog.FormatJSON)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, encoder.Encode(film1))
+	}
+	require.NoError(t, encoder.Close())
+
+	var films []map[string]any
+	require.NoError(t, json.Unmarshal(output.Bytes(), &films))
+	require.Len(t, films, 3)
+}
+
+func TestActorsEncoder(t *testing.T) {
+	type tCase struct {
+		name     string
+		format   catalog.Format
+		expected string
+	}
+
+	actor := *actor1
+	actor.Films = []*domain.Film{film1, film2}
+
+	tCases := []tCase{
+		{
+			name:     "json",
+			format:   catalog.FormatJSON,
+			expected: `[{"id":1,"name":"Actor_1","sex":1,"birthdate":"1970-01-02","film_ids":[1,2]},{"id":2,"name":"Actor, 2","sex":2,"birthdate":"1980-01-02","film_ids":[]}]` + "\n",
+		},
+		{
+			name:     "ndjson",
+			format:   catalog.FormatNDJSON,
+			expected: `{"id":1,"name":"Actor_1","sex":1,"birthdate":"1970-01-02","film_ids":[1,2]}` + "\n" + `{"id":2,"name":"Actor, 2","sex":2,"birthdate":"1980-01-02","film_ids":[]}` + "\n",
+		},
+		{
+			name:     "csv",
+			format:   catalog.FormatCSV,
+			expected: "id,name,sex,birthdate,film_ids\n1,Actor_1,1,1970-01-02,1;2\n2,\"Actor, 2\",2,1980-01-02,\n",
+		},
+	}
+
+	for _, tCase := range tCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			var output bytes.Buffer
+
+			encoder, err := catalog.NewActorsEncoder(&output, tCase.format)
+			require.NoError(t, err)
+
+			require.NoError(t, encoder.Encode(&actor))
+			require.NoError(t, encoder.Encode(actor2))
+			require.NoError(t, encoder.Close())
+
+			require.Equal(t, tCase.expected, output.String())
+		})
+	}
+}
+
+func TestEncoderError(t *testing.T) {
+	_, err := catalog.NewFilmsEncoder(&bytes.Buffer{}, catalog.Format("xml"))
+	require.Error(t, err)
+
+	_, err = catalog.NewActorsEncoder(&bytes.Buffer{}, catalog.Format("xml"))
+	require.Error(t, err)
+
+	encoder, err := catalog.NewFilmsEncoder(failingWriter{}, catalog.FormatNDJSON)
+	require.NoError(t, err)
+	require.Error(t, encoder.Encode(film1))
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
@@ -0,0 +1,106 @@
+package catalog_test
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/app/catalog"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecodeFilmsCSV(t *testing.T) {
+	source := `title,description,release_date,rating,actors,genre_ids
+Title_1,Description,2000-01-02,8,Actor_1|1|1970-01-02;Actor_2|2|1980-01-02,1;2
+"Title, 2",,2010-01-02,5,Actor_1|1|1970-01-02,
+Title_3,,2010-01-02,5,,
+Title_4,,02.01.2010,5,Actor_1|1|1970-01-02,
+Title_5,,2010-01-02,5
+`
+
+	rows, rowErrors, err := catalog.DecodeFilms(strings.NewReader(source), catalog.FormatCSV, validator.New())
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	require.Equal(t, &domain.FilmImportRow{
+		Line:        2,
+		Title:       "Title_1",
+		Description: "Description",
+		ReleaseDate: domain.FilmReleaseDate(time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC)),
+		Rating:      8,
+		Actors: []*domain.FilmImportActor{
+			{Name: "Actor_1", Sex: 1, BirthDate: domain.ActorBirthDate(time.Date(1970, 1, 2, 0, 0, 0, 0, time.UTC))},
+			{Name: "Actor_2", Sex: 2, BirthDate: domain.ActorBirthDate(time.Date(1980, 1, 2, 0, 0, 0, 0, time.UTC))},
+		},
+		GenreIds: []domain.GenreId{1, 2},
+	}, rows[0])
+	require.Equal(t, domain.FilmTitle("Title, 2"), rows[1].Title)
+	require.Equal(t, 3, rows[1].Line)
+
+	require.Len(t, rowErrors, 3)
+	require.Equal(t, 4, rowErrors[0].Line)
+	require.Equal(t, 5, rowErrors[1].Line)
+	require.Equal(t, 6, rowErrors[2].Line)
+}
+
+func TestDecodeFilmsNDJSON(t *testing.T) {
+	source := `{"title":"Title_1","release_date":"2000-01-02","rating":8,"actors":[{"name":"Actor_1","sex":1,"birthdate":"1970-01-02"}],"genre_ids":[1]}
+
+{"title":"Title_2","release_date":"2000-01-02","rating":8,"actors":[]}
+{"title":"Title_3",
+{"title":"","release_date":"2000-01-02","rating":8,"actors":[{"name":"Actor_1","sex":1,"birthdate":"1970-01-02"}]}
+`
+
+	rows, rowErrors, err := catalog.DecodeFilms(strings.NewReader(source), catalog.FormatNDJSON, validator.New())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, 1, rows[0].Line)
+	require.Equal(t, domain.FilmTitle("Title_1"), rows[0].Title)
+	require.Equal(t, []domain.GenreId{1}, rows[0].GenreIds)
+	require.Len(t, rows[0].Actors, 1)
+
+	require.Len(t, rowErrors, 3)
+	require.Equal(t, 3, rowErrors[0].Line)
+	require.Equal(t, 4, rowErrors[1].Line)
+	require.Equal(t, "invalid JSON", rowErrors[1].Message)
+	require.Equal(t, 5, rowErrors[2].Line)
+}
+
+func TestDecodeFilmsError(t *testing.T) {
+	testCases := []struct {
+		name   string
+		source string
+		format catalog.Format
+	}{
+		{
+			name:   "err_missing_column",
+			source: "title,release_date,rating\n",
+			format: catalog.FormatCSV,
+		},
+		{
+			name:   "err_unexpected_column",
+			source: "title,release_date,rating,actors,director\n",
+			format: catalog.FormatCSV,
+		},
+		{
+			name:   "err_empty_csv",
+			source: "",
+			format: catalog.FormatCSV,
+		},
+		{
+			name:   "err_unsupported_format",
+			source: "[]",
+			format: catalog.FormatJSON,
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			rows, rowErrors, err := catalog.DecodeFilms(strings.NewReader(tCase.source), tCase.format, validator.New())
+			require.Error(t, err)
+			require.Nil(t, rows)
+			require.Nil(t, rowErrors)
+		})
+	}
+}
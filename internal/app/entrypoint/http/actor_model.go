@@ -11,6 +11,8 @@ type actor struct {
 	Sex       uint8        `json:"sex"`
 	BirthDate string       `json:"birthdate"`
 	Films     []*actorFilm `json:"films,omitempty"`
+	CreatedBy *int64       `json:"created_by,omitempty"`
+	UpdatedBy *int64       `json:"updated_by,omitempty"`
 }
 
 type actorFilm struct {
@@ -44,6 +46,8 @@ func buildActor(domainActor *domain.Actor) *actor {
 		Sex:       domainActor.Sex.Uint8(),
 		BirthDate: domainActor.BirthDate.Time().Format(time.DateOnly),
 		Films:     buildActorFilms(domainActor.Films),
+		CreatedBy: domainActor.CreatedBy,
+		UpdatedBy: domainActor.UpdatedBy,
 	}
 }
 
@@ -0,0 +1,89 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+)
+
+type addUserFilmRequestBody struct {
+	FilmId int64 `json:"film_id" validate:"required" example:"1"`
+}
+
+type addUserFilmResponseBody struct {
+	Message string `json:"message"`
+}
+
+// @Summary		Add a film to the watchlist or to the watched films of the authenticated user
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			users
+// @Description	Add a film to the personal list of the authenticated user. Adding a film that is already in the list does nothing
+// @Accept			json
+// @Produce		json
+// @Param			input	body		addUserFilmRequestBody	true	"Film that needs to be added to the list"
+// @Success		200		{object}	addUserFilmResponseBody
+// @Failure		400		{object}	apiv1.Response
+// @Failure		401		{object}	apiv1.Response
+// @Failure		403		{object}	apiv1.Response
+// @Failure		404		{object}	apiv1.Response
+// @Failure		500		{object}	apiv1.Response
+// @Router			/users/me/watchlist [post]
+// @Router			/users/me/watched [post]
+func (h *Handler) AddUserFilmHandler(list domain.FilmList) http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "AddUserFilmHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName), slog.String("list", list.String()))
+
+		var addUserFilmReqBody addUserFilmRequestBody
+		err := json.NewDecoder(request.Body).Decode(&addUserFilmReqBody)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageFilmInvalidRequestBody, apiv1.ErrorDescription{"error": "invalid request body"}))
+
+			return
+		}
+
+		err = h.validator.Struct(&addUserFilmReqBody)
+		if err != nil {
+			errors, ok := err.(validator.ValidationErrors)
+			if !ok {
+				log.Error("failed to type cast validator.ValidationErrors", "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageFilmInternalServerError, apiv1.ErrorDescription{"error": "failed to get validation errors"}))
+			} else {
+				errs := validateRequestBody(errors)
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageFilmInvalidRequestBody, apiv1.ErrorDescription{"error": errs.Error()}))
+			}
+
+			return
+		}
+
+		err = h.filmListService.Add(request.Context(), list, domain.FilmId(addUserFilmReqBody.FilmId))
+		if err != nil {
+			if errors.Is(err, domain.ErrFilmNotFound) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageFilmNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else if errors.Is(err, domain.ErrFilmListForbidden) {
+				views.RenderJSON(rw, http.StatusForbidden, apiv1.Error(apiv1.CodeForbidden, ErrMessageFilmListForbidden, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to add film to the list", "filmId", addUserFilmReqBody.FilmId, "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageFilmInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(&addUserFilmResponseBody{
+			Message: fmt.Sprintf("Film with id '%d' has added to the %s successfully", addUserFilmReqBody.FilmId, list),
+		})
+
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
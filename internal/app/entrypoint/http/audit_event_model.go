@@ -0,0 +1,39 @@
+package http
+
+import (
+	"encoding/json"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"time"
+)
+
+type auditEvent struct {
+	Id         int64           `json:"id"`
+	UserId     *int64          `json:"user_id,omitempty"`
+	Operation  string          `json:"operation"`
+	EntityType string          `json:"entity_type"`
+	EntityId   int64           `json:"entity_id"`
+	Before     json.RawMessage `json:"before,omitempty" swaggertype:"object"`
+	After      json.RawMessage `json:"after,omitempty" swaggertype:"object"`
+	CreatedAt  string          `json:"created_at"`
+}
+
+func buildAuditEvents(domainEvents []*domain.AuditEvent) []*auditEvent {
+	events := make([]*auditEvent, len(domainEvents))
+	for i := range domainEvents {
+		events[i] = buildAuditEvent(domainEvents[i])
+	}
+	return events
+}
+
+func buildAuditEvent(domainEvent *domain.AuditEvent) *auditEvent {
+	return &auditEvent{
+		Id:         domainEvent.Id.Int64(),
+		UserId:     domainEvent.UserId,
+		Operation:  domainEvent.Operation.String(),
+		EntityType: domainEvent.EntityType.String(),
+		EntityId:   domainEvent.EntityId,
+		Before:     domainEvent.Before,
+		After:      domainEvent.After,
+		CreatedAt:  domainEvent.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,245 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/service/user"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+type batchActorsRequestBody struct {
+	Mode       string                        `json:"mode" validate:"required,oneof=transactional best-effort" example:"transactional"`
+	Operations []*actorBatchOperationRequest `json:"operations" validate:"required,gt=0,max=1000,dive,required"`
+}
+
+// actorBatchOperationRequest is an operation of a batch. 'actor' is a createActorRequestBody for the 'create' operation
+// and an updateActorRequestBody for the 'update' operation, 'id' is the id of the updated or deleted actor.
+// 'version', like the 'If-Match' header of the single actor requests, is the version the updated or deleted actor must have.
+type actorBatchOperationRequest struct {
+	Op      string          `json:"op" validate:"required,oneof=create update delete" example:"update"`
+	Id      int64           `json:"id,omitempty" validate:"required_unless=Op create,omitempty,gt=0" example:"1"`
+	Version *int64          `json:"version,omitempty" validate:"omitempty,gt=0" example:"3"`
+	Actor   json.RawMessage `json:"actor,omitempty" swaggertype:"object"`
+}
+
+type batchActorsResponseBody struct {
+	Mode      string            `json:"mode"`
+	Committed bool              `json:"committed"`
+	Items     []*actorBatchItem `json:"items"`
+}
+
+// actorBatchItem is the outcome of the operation with the same index.
+// Status is one of 'succeeded', 'failed' and 'aborted', the latter means that the operation has not been applied,
+// because another operation of the transactional batch has failed.
+type actorBatchItem struct {
+	Index  int             `json:"index"`
+	Op     string          `json:"op"`
+	Id     int64           `json:"id,omitempty"`
+	Status string          `json:"status"`
+	Actor  *actor          `json:"actor,omitempty"`
+	Error  *batchItemError `json:"error,omitempty"`
+}
+
+// @Summary		Create, update and delete actors in a batch
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			actors
+// @Description	Apply a list of operations: 'create' with 'actor' like in 'POST /actors', 'update' with 'id' and 'actor' like in 'PATCH /actors/{id}', 'delete' with 'id'.
+// @Description	Every operation is checked the same way as by the respective single actor request. Delete operations require the 'actors:delete' permission.
+// @Description	'update' and 'delete' operations with 'version' fail with 'PRECONDITION_FAILED' if the actor has another version, like with the 'If-Match' header.
+// @Description	In the 'transactional' mode either all operations are applied or none of them, in the 'best-effort' mode every operation which succeeds is applied.
+// @Description	The outcome of every operation is returned in 'items' with the same index. If a transactional batch fails, the items are returned in the error details
+// @ID				batch-actors
+// @Accept			json
+// @Produce		json
+// @Param			input	body		batchActorsRequestBody	true	"Operations with actors"
+// @Success		200		{object}	batchActorsResponseBody
+// @Failure		400		{object}	apiv1.Response
+// @Failure		401		{object}	apiv1.Response
+// @Failure		403		{object}	apiv1.Response
+// @Failure		500		{object}	apiv1.Response
+// @Router			/actors:batch [post]
+func (h *Handler) BatchActorsHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "BatchActorsHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		var batchActorsReqBody batchActorsRequestBody
+		err := json.NewDecoder(request.Body).Decode(&batchActorsReqBody)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageActorInvalidRequestBody, apiv1.ErrorDescription{"error": "invalid request body"}))
+
+			return
+		}
+
+		if err = h.validator.Struct(&batchActorsReqBody); err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageActorInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+
+			return
+		}
+
+		operations, operationErrors := h.buildDomainActorBatchOperations(batchActorsReqBody.Operations)
+		if len(operationErrors) > 0 {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageActorInvalidRequestBody, apiv1.ErrorDescription{"operations": operationErrors}))
+
+			return
+		}
+
+		for _, operation := range operations {
+			if operation.Type == domain.BatchOperationDelete && !h.hasPermission(request, user.PermissionActorsDelete) {
+				views.RenderJSON(rw, http.StatusForbidden, apiv1.Error(apiv1.CodeForbidden, ErrMessageActorBatchForbidden, apiv1.ErrorDescription{"error": fmt.Sprintf("'delete' operations require the '%s' permission", user.PermissionActorsDelete)}))
+
+				return
+			}
+		}
+
+		report, err := h.actorService.Batch(request.Context(), domain.BatchMode(batchActorsReqBody.Mode), operations)
+		if err != nil {
+			if errors.Is(err, domain.ErrInvalidBatch) {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageActorInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to apply batch of actors", "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageActorInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		items := buildActorBatchItems(report.Items)
+
+		if report.Mode == domain.BatchModeTransactional && !report.Committed {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageActorBatchFailed, apiv1.ErrorDescription{"items": items}))
+
+			return
+		}
+
+		payload, _ := json.Marshal(&batchActorsResponseBody{
+			Mode:      string(report.Mode),
+			Committed: report.Committed,
+			Items:     items,
+		})
+
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
+
+// buildDomainActorBatchOperations decodes and validates the actor of every operation the same way
+// as the single actor requests do and returns the errors of all invalid operations
+func (h *Handler) buildDomainActorBatchOperations(operationRequests []*actorBatchOperationRequest) ([]*domain.ActorBatchOperation, []*batchOperationError) {
+	operations := make([]*domain.ActorBatchOperation, len(operationRequests))
+	var operationErrors []*batchOperationError
+
+	for i, operationRequest := range operationRequests {
+		operation, err := h.buildDomainActorBatchOperation(operationRequest)
+		if err != nil {
+			operationErrors = append(operationErrors, &batchOperationError{Index: i, Error: err.Error()})
+			continue
+		}
+		operations[i] = operation
+	}
+
+	return operations, operationErrors
+}
+
+func (h *Handler) buildDomainActorBatchOperation(operationRequest *actorBatchOperationRequest) (*domain.ActorBatchOperation, error) {
+	operation := &domain.ActorBatchOperation{
+		Type:    domain.BatchOperationType(operationRequest.Op),
+		Id:      domain.ActorId(operationRequest.Id),
+		Version: operationRequest.Version,
+	}
+
+	switch operation.Type {
+	case domain.BatchOperationCreate:
+		var createActorReqBody createActorRequestBody
+		if err := json.Unmarshal(operationRequest.Actor, &createActorReqBody); err != nil {
+			return nil, errors.New("invalid actor")
+		}
+		if err := h.validator.Struct(&createActorReqBody); err != nil {
+			return nil, err
+		}
+
+		birthDate, err := time.Parse(time.DateOnly, createActorReqBody.BirthDate)
+		if err != nil {
+			return nil, err
+		}
+
+		operation.Create = &domain.ActorCreateParams{
+			Name:      domain.ActorName(createActorReqBody.Name),
+			Sex:       domain.ActorSex(*createActorReqBody.Sex),
+			BirthDate: domain.ActorBirthDate(birthDate),
+		}
+	case domain.BatchOperationUpdate:
+		var updateActorReqBody updateActorRequestBody
+		if err := json.Unmarshal(operationRequest.Actor, &updateActorReqBody); err != nil {
+			return nil, errors.New("invalid actor")
+		}
+		if err := h.validator.Struct(&updateActorReqBody); err != nil {
+			return nil, err
+		}
+
+		updateParams := &domain.ActorUpdateParams{}
+
+		if updateActorReqBody.BirthDate != nil {
+			parsedBirthdate, err := time.Parse(time.DateOnly, *updateActorReqBody.BirthDate)
+			if err != nil {
+				return nil, err
+			}
+			convDomainBirthdate := domain.ActorBirthDate(parsedBirthdate)
+			updateParams.BirthDate = &convDomainBirthdate
+		}
+		if updateActorReqBody.Name != nil {
+			convDomainActorName := domain.ActorName(*updateActorReqBody.Name)
+			updateParams.Name = &convDomainActorName
+		}
+		if updateActorReqBody.Sex != nil {
+			convDomainActorSex := domain.ActorSex(*updateActorReqBody.Sex)
+			updateParams.Sex = &convDomainActorSex
+		}
+
+		operation.Update = updateParams
+	}
+
+	return operation, nil
+}
+
+func buildActorBatchItems(domainItems []*domain.ActorBatchItem) []*actorBatchItem {
+	items := make([]*actorBatchItem, len(domainItems))
+	for i, domainItem := range domainItems {
+		item := &actorBatchItem{
+			Index:  domainItem.Index,
+			Op:     string(domainItem.Type),
+			Id:     domainItem.Id.Int64(),
+			Status: string(domainItem.Status),
+		}
+		if domainItem.Actor != nil {
+			item.Actor = buildActor(domainItem.Actor)
+		}
+		if domainItem.Err != nil {
+			item.Error = buildActorBatchItemError(domainItem.Err)
+		}
+		items[i] = item
+	}
+	return items
+}
+
+// buildActorBatchItemError maps the error of an operation the same way as the single actor requests do
+func buildActorBatchItemError(err error) *batchItemError {
+	switch {
+	case errors.Is(err, domain.ErrActorNotFound):
+		return newBatchItemError(apiv1.CodeNotFound, ErrMessageActorNotFound, err)
+	case errors.Is(err, domain.ErrActorVersionMismatch):
+		return newBatchItemError(apiv1.CodePreconditionFailed, ErrMessageActorPreconditionFailed, err)
+	case errors.Is(err, domain.ErrInvalidBatch):
+		return newBatchItemError(apiv1.CodeBadRequest, ErrMessageActorInvalidRequestBody, err)
+	default:
+		return newBatchItemError(apiv1.CodeInternalError, ErrMessageActorInternalServerError, err)
+	}
+}
@@ -0,0 +1,269 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/service/user"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+type batchFilmsRequestBody struct {
+	Mode       string                       `json:"mode" validate:"required,oneof=transactional best-effort" example:"transactional"`
+	Operations []*filmBatchOperationRequest `json:"operations" validate:"required,gt=0,max=1000,dive,required"`
+}
+
+// filmBatchOperationRequest is an operation of a batch. 'film' is a createFilmRequestBody for the 'create' operation
+// and an updateFilmRequestBody for the 'update' operation, 'id' is the id of the updated or deleted film.
+// 'version', like the 'If-Match' header of the single film requests, is the version the updated or deleted film must have.
+type filmBatchOperationRequest struct {
+	Op      string          `json:"op" validate:"required,oneof=create update delete" example:"update"`
+	Id      int64           `json:"id,omitempty" validate:"required_unless=Op create,omitempty,gt=0" example:"1"`
+	Version *int64          `json:"version,omitempty" validate:"omitempty,gt=0" example:"3"`
+	Film    json.RawMessage `json:"film,omitempty" swaggertype:"object"`
+}
+
+type batchFilmsResponseBody struct {
+	Mode      string           `json:"mode"`
+	Committed bool             `json:"committed"`
+	Items     []*filmBatchItem `json:"items"`
+}
+
+// filmBatchItem is the outcome of the operation with the same index.
+// Status is one of 'succeeded', 'failed' and 'aborted', the latter means that the operation has not been applied,
+// because another operation of the transactional batch has failed.
+type filmBatchItem struct {
+	Index  int             `json:"index"`
+	Op     string          `json:"op"`
+	Id     int64           `json:"id,omitempty"`
+	Status string          `json:"status"`
+	Film   *film           `json:"film,omitempty"`
+	Error  *batchItemError `json:"error,omitempty"`
+}
+
+// @Summary		Create, update and delete films in a batch
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			films
+// @Description	Apply a list of operations: 'create' with 'film' like in 'POST /films', 'update' with 'id' and 'film' like in 'PATCH /films/{id}', 'delete' with 'id'.
+// @Description	Every operation is checked the same way as by the respective single film request. Delete operations require the 'films:delete' permission.
+// @Description	'update' and 'delete' operations with 'version' fail with 'PRECONDITION_FAILED' if the film has another version, like with the 'If-Match' header.
+// @Description	In the 'transactional' mode either all operations are applied or none of them, in the 'best-effort' mode every operation which succeeds is applied.
+// @Description	The outcome of every operation is returned in 'items' with the same index. If a transactional batch fails, the items are returned in the error details
+// @ID				batch-films
+// @Accept			json
+// @Produce		json
+// @Param			input	body		batchFilmsRequestBody	true	"Operations with films"
+// @Success		200		{object}	batchFilmsResponseBody
+// @Failure		400		{object}	apiv1.Response
+// @Failure		401		{object}	apiv1.Response
+// @Failure		403		{object}	apiv1.Response
+// @Failure		500		{object}	apiv1.Response
+// @Router			/films:batch [post]
+func (h *Handler) BatchFilmsHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "BatchFilmsHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		var batchFilmsReqBody batchFilmsRequestBody
+		err := json.NewDecoder(request.Body).Decode(&batchFilmsReqBody)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageFilmInvalidRequestBody, apiv1.ErrorDescription{"error": "invalid request body"}))
+
+			return
+		}
+
+		if err = h.validator.Struct(&batchFilmsReqBody); err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageFilmInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+
+			return
+		}
+
+		operations, operationErrors := h.buildDomainFilmBatchOperations(batchFilmsReqBody.Operations)
+		if len(operationErrors) > 0 {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageFilmInvalidRequestBody, apiv1.ErrorDescription{"operations": operationErrors}))
+
+			return
+		}
+
+		for _, operation := range operations {
+			if operation.Type == domain.BatchOperationDelete && !h.hasPermission(request, user.PermissionFilmsDelete) {
+				views.RenderJSON(rw, http.StatusForbidden, apiv1.Error(apiv1.CodeForbidden, ErrMessageFilmBatchForbidden, apiv1.ErrorDescription{"error": fmt.Sprintf("'delete' operations require the '%s' permission", user.PermissionFilmsDelete)}))
+
+				return
+			}
+		}
+
+		report, err := h.filmService.Batch(request.Context(), domain.BatchMode(batchFilmsReqBody.Mode), operations)
+		if err != nil {
+			if errors.Is(err, domain.ErrInvalidBatch) {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageFilmInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to apply batch of films", "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageFilmInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		items := buildFilmBatchItems(report.Items)
+
+		if report.Mode == domain.BatchModeTransactional && !report.Committed {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageFilmBatchFailed, apiv1.ErrorDescription{"items": items}))
+
+			return
+		}
+
+		payload, _ := json.Marshal(&batchFilmsResponseBody{
+			Mode:      string(report.Mode),
+			Committed: report.Committed,
+			Items:     items,
+		})
+
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
+
+// buildDomainFilmBatchOperations decodes and validates the film of every operation the same way
+// as the single film requests do and returns the errors of all invalid operations
+func (h *Handler) buildDomainFilmBatchOperations(operationRequests []*filmBatchOperationRequest) ([]*domain.FilmBatchOperation, []*batchOperationError) {
+	operations := make([]*domain.FilmBatchOperation, len(operationRequests))
+	var operationErrors []*batchOperationError
+
+	for i, operationRequest := range operationRequests {
+		operation, err := h.buildDomainFilmBatchOperation(operationRequest)
+		if err != nil {
+			operationErrors = append(operationErrors, &batchOperationError{Index: i, Error: err.Error()})
+			continue
+		}
+		operations[i] = operation
+	}
+
+	return operations, operationErrors
+}
+
+func (h *Handler) buildDomainFilmBatchOperation(operationRequest *filmBatchOperationRequest) (*domain.FilmBatchOperation, error) {
+	operation := &domain.FilmBatchOperation{
+		Type:    domain.BatchOperationType(operationRequest.Op),
+		Id:      domain.FilmId(operationRequest.Id),
+		Version: operationRequest.Version,
+	}
+
+	switch operation.Type {
+	case domain.BatchOperationCreate:
+		var createFilmReqBody createFilmRequestBody
+		if err := json.Unmarshal(operationRequest.Film, &createFilmReqBody); err != nil {
+			return nil, errors.New("invalid film")
+		}
+		if err := h.validator.Struct(&createFilmReqBody); err != nil {
+			return nil, err
+		}
+
+		releaseDate, err := time.Parse(time.DateOnly, createFilmReqBody.ReleaseDate)
+		if err != nil {
+			return nil, err
+		}
+
+		operation.Create = &domain.FilmCreateParams{
+			Title:       domain.FilmTitle(createFilmReqBody.Title),
+			Description: domain.FilmDescription(createFilmReqBody.Description),
+			ReleaseDate: domain.FilmReleaseDate(releaseDate),
+			Rating:      domain.FilmRating(createFilmReqBody.Rating),
+			ActorIds:    buildDomainActorIds(createFilmReqBody.ActorIds),
+			Credits:     buildDomainFilmCredits(createFilmReqBody.Credits),
+			GenreIds:    buildDomainGenreIds(createFilmReqBody.GenreIds),
+		}
+	case domain.BatchOperationUpdate:
+		var updateFilmReqBody updateFilmRequestBody
+		if err := json.Unmarshal(operationRequest.Film, &updateFilmReqBody); err != nil {
+			return nil, errors.New("invalid film")
+		}
+		if err := h.validator.Struct(&updateFilmReqBody); err != nil {
+			return nil, err
+		}
+
+		updateParams := &domain.FilmUpdateParams{}
+
+		if updateFilmReqBody.ReleaseDate != nil {
+			parsedReleaseDate, err := time.Parse(time.DateOnly, *updateFilmReqBody.ReleaseDate)
+			if err != nil {
+				return nil, err
+			}
+			convDomainReleaseDate := domain.FilmReleaseDate(parsedReleaseDate)
+			updateParams.ReleaseDate = &convDomainReleaseDate
+		}
+		if updateFilmReqBody.Title != nil {
+			convDomainFilmTitle := domain.FilmTitle(*updateFilmReqBody.Title)
+			updateParams.Title = &convDomainFilmTitle
+		}
+		if updateFilmReqBody.Description != nil {
+			convDomainFilmDescription := domain.FilmDescription(*updateFilmReqBody.Description)
+			updateParams.Description = &convDomainFilmDescription
+		}
+		if updateFilmReqBody.Rating != nil {
+			convDomainFilmRating := domain.FilmRating(*updateFilmReqBody.Rating)
+			updateParams.Rating = &convDomainFilmRating
+		}
+		if updateFilmReqBody.ActorIds != nil {
+			convDomainActorIds := buildDomainActorIds(*updateFilmReqBody.ActorIds)
+			updateParams.ActorIds = &convDomainActorIds
+		}
+		if updateFilmReqBody.Credits != nil {
+			convDomainCredits := buildDomainFilmCredits(*updateFilmReqBody.Credits)
+			updateParams.Credits = &convDomainCredits
+		}
+		if updateFilmReqBody.GenreIds != nil {
+			convDomainGenreIds := buildDomainGenreIds(*updateFilmReqBody.GenreIds)
+			updateParams.GenreIds = &convDomainGenreIds
+		}
+
+		operation.Update = updateParams
+	}
+
+	return operation, nil
+}
+
+func buildFilmBatchItems(domainItems []*domain.FilmBatchItem) []*filmBatchItem {
+	items := make([]*filmBatchItem, len(domainItems))
+	for i, domainItem := range domainItems {
+		item := &filmBatchItem{
+			Index:  domainItem.Index,
+			Op:     string(domainItem.Type),
+			Id:     domainItem.Id.Int64(),
+			Status: string(domainItem.Status),
+		}
+		if domainItem.Film != nil {
+			item.Film = buildFilm(domainItem.Film)
+		}
+		if domainItem.Err != nil {
+			item.Error = buildFilmBatchItemError(domainItem.Err)
+		}
+		items[i] = item
+	}
+	return items
+}
+
+// buildFilmBatchItemError maps the error of an operation the same way as the single film requests do
+func buildFilmBatchItemError(err error) *batchItemError {
+	switch {
+	case errors.Is(err, domain.ErrFilmNotFound):
+		return newBatchItemError(apiv1.CodeNotFound, ErrMessageFilmNotFound, err)
+	case errors.Is(err, domain.ErrFilmVersionMismatch):
+		return newBatchItemError(apiv1.CodePreconditionFailed, ErrMessageFilmPreconditionFailed, err)
+	case errors.Is(err, domain.ErrFilmActorsNotFound):
+		return newBatchItemError(apiv1.CodeNotFound, ErrMessageFilmActorsNotFound, err)
+	case errors.Is(err, domain.ErrFilmGenresNotFound):
+		return newBatchItemError(apiv1.CodeNotFound, ErrMessageFilmGenresNotFound, err)
+	case errors.Is(err, domain.ErrInvalidFilmCredit), errors.Is(err, domain.ErrInvalidBatch):
+		return newBatchItemError(apiv1.CodeBadRequest, ErrMessageFilmInvalidRequestBody, err)
+	default:
+		return newBatchItemError(apiv1.CodeInternalError, ErrMessageFilmInternalServerError, err)
+	}
+}
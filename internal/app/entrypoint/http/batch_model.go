@@ -0,0 +1,34 @@
+package http
+
+import (
+	"github.com/vaberof/vk-internship-task/internal/service/user"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"net/http"
+)
+
+// batchOperationError is an operation of a batch request that cannot be decoded or is invalid
+type batchOperationError struct {
+	Index int    `json:"index"`
+	Error string `json:"error"`
+}
+
+// batchItemError is the error of a failed operation in the same shape as the error payload of the 'apiv1' envelope
+type batchItemError struct {
+	Code    string                 `json:"code"`
+	Message string                 `json:"message"`
+	Details apiv1.ErrorDescription `json:"details"`
+}
+
+func newBatchItemError(code, message string, err error) *batchItemError {
+	return &batchItemError{
+		Code:    code,
+		Message: message,
+		Details: apiv1.ErrorDescription{"error": err.Error()},
+	}
+}
+
+// hasPermission tells whether the authenticated user has the permission in addition to the one checked by the route
+func (h *Handler) hasPermission(request *http.Request, permission user.Permission) bool {
+	principal, ok := user.PrincipalFromContext(request.Context())
+	return ok && h.rolePermissions.Has(principal.Role, permission)
+}
@@ -26,6 +26,7 @@ type createActorResponseBody struct {
 }
 
 // @Summary		Create a new actor
+// @Security		BearerAuth
 // @Security		BasicAuth
 // @Tags			actors
 // @Description	Create a new actor
@@ -34,6 +35,7 @@ type createActorResponseBody struct {
 // @Produce		json
 // @Param			input	body		createActorRequestBody	true	"Actor object that needs to be created"
 // @Success		200		{object}	createActorResponseBody
+// @Header			200		{string}	ETag	"Entity tag of the actor version"
 // @Failure		400		{object}	apiv1.Response
 // @Failure		401		{object}	apiv1.Response
 // @Failure		403		{object}	apiv1.Response
@@ -76,6 +78,7 @@ func (h *Handler) CreateActorHandler() http.HandlerFunc {
 		}
 
 		domainActor, err := h.actorService.Create(
+			request.Context(),
 			domain.ActorName(createActorReqBody.Name),
 			domain.ActorSex(*createActorReqBody.Sex),
 			domain.ActorBirthDate(birthdate),
@@ -96,6 +99,7 @@ func (h *Handler) CreateActorHandler() http.HandlerFunc {
 			Films:     buildActorFilms(domainActor.Films),
 		})
 
+		setETag(rw, domainActor.Version)
 		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
 	}
 }
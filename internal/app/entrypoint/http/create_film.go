@@ -13,11 +13,13 @@ import (
 )
 
 type createFilmRequestBody struct {
-	Title       string  `json:"title" validate:"required,min=1,max=150"`
-	Description string  `json:"description,omitempty" validate:"max=1000"`
-	ReleaseDate string  `json:"release_date" validate:"required" example:"2006-01-02"`
-	Rating      uint8   `json:"rating" validate:"required,numeric,min=0,max=10"`
-	ActorIds    []int64 `json:"actor_ids" validate:"required,gt=0,dive,numeric" example:"1,2,3"`
+	Title       string               `json:"title" validate:"required,min=1,max=150"`
+	Description string               `json:"description,omitempty" validate:"max=1000"`
+	ReleaseDate string               `json:"release_date" validate:"required" example:"2006-01-02"`
+	Rating      uint8                `json:"rating" validate:"required,numeric,min=0,max=10"`
+	ActorIds    []int64              `json:"actor_ids" validate:"required,gt=0,dive,numeric" example:"1,2,3"`
+	Credits     []*filmCreditRequest `json:"credits,omitempty" validate:"omitempty,dive"`
+	GenreIds    []int64              `json:"genre_ids,omitempty" validate:"omitempty,dive,numeric" example:"1,2"`
 }
 
 type createFilmResponseBody struct {
@@ -27,17 +29,20 @@ type createFilmResponseBody struct {
 	ReleaseDate string       `json:"release_date"`
 	Rating      uint8        `json:"rating"`
 	Actors      []*filmActor `json:"actors"`
+	Genres      []*genre     `json:"genres"`
 }
 
 // @Summary		Create a new film
+// @Security		BearerAuth
 // @Security		BasicAuth
 // @Tags			films
-// @Description	Create a new film
+// @Description	Create a new film. Actors from 'actor_ids' are credited with the 'actor' role before the explicit 'credits'
 // @ID				create-film
 // @Accept			json
 // @Produce		json
 // @Param			input	body		createFilmRequestBody	true	"Film object that needs to be created"
 // @Success		200		{object}	createFilmResponseBody
+// @Header			200		{string}	ETag	"Entity tag of the film version"
 // @Failure		400		{object}	apiv1.Response
 // @Failure		401		{object}	apiv1.Response
 // @Failure		403		{object}	apiv1.Response
@@ -80,15 +85,22 @@ func (h *Handler) CreateFilmHandler() http.HandlerFunc {
 		}
 
 		domainFilm, err := h.filmService.Create(
+			request.Context(),
 			domain.FilmTitle(createFilmReqBody.Title),
 			domain.FilmDescription(createFilmReqBody.Description),
 			domain.FilmReleaseDate(releaseDate),
 			domain.FilmRating(createFilmReqBody.Rating),
 			buildDomainActorIds(createFilmReqBody.ActorIds),
+			buildDomainFilmCredits(createFilmReqBody.Credits),
+			buildDomainGenreIds(createFilmReqBody.GenreIds),
 		)
 		if err != nil {
 			if errors.Is(err, domain.ErrFilmActorsNotFound) {
 				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageFilmActorsNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else if errors.Is(err, domain.ErrInvalidFilmCredit) {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageFilmInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+			} else if errors.Is(err, domain.ErrFilmGenresNotFound) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageFilmGenresNotFound, apiv1.ErrorDescription{"error": err.Error()}))
 			} else {
 				log.Error("failed to create a film", "error", err.Error())
 
@@ -105,8 +117,10 @@ func (h *Handler) CreateFilmHandler() http.HandlerFunc {
 			ReleaseDate: domainFilm.ReleaseDate.Time().Format(time.DateOnly),
 			Rating:      domainFilm.Rating.Uint8(),
 			Actors:      buildFilmActors(domainFilm.Actors),
+			Genres:      buildGenres(domainFilm.Genres),
 		})
 
+		setETag(rw, domainFilm.Version)
 		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
 	}
 }
@@ -0,0 +1,86 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+)
+
+type createGenreRequestBody struct {
+	Name string `json:"name" validate:"required,max=50" example:"Drama"`
+}
+
+type createGenreResponseBody struct {
+	*genre
+}
+
+// @Summary		Create a new genre
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			genres
+// @Description	Create a new genre with a unique name
+// @ID				create-genre
+// @Accept			json
+// @Produce		json
+// @Param			input	body		createGenreRequestBody	true	"Genre object that needs to be created"
+// @Success		200		{object}	createGenreResponseBody
+// @Failure		400		{object}	apiv1.Response
+// @Failure		401		{object}	apiv1.Response
+// @Failure		403		{object}	apiv1.Response
+// @Failure		409		{object}	apiv1.Response
+// @Failure		500		{object}	apiv1.Response
+// @Router			/genres [post]
+func (h *Handler) CreateGenreHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "CreateGenreHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		var createGenreReqBody createGenreRequestBody
+		err := json.NewDecoder(request.Body).Decode(&createGenreReqBody)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageGenreInvalidRequestBody, apiv1.ErrorDescription{"error": "invalid request body"}))
+
+			return
+		}
+
+		err = h.validator.Struct(&createGenreReqBody)
+		if err != nil {
+			errors, ok := err.(validator.ValidationErrors)
+			if !ok {
+				log.Error("failed to type cast validator.ValidationErrors", "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageGenreInternalServerError, apiv1.ErrorDescription{"error": "failed to get validation errors"}))
+			} else {
+				errs := validateRequestBody(errors)
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageGenreInvalidRequestBody, apiv1.ErrorDescription{"error": errs.Error()}))
+			}
+
+			return
+		}
+
+		domainGenre, err := h.genreService.Create(request.Context(), domain.GenreName(createGenreReqBody.Name))
+		if err != nil {
+			if errors.Is(err, domain.ErrGenreAlreadyExists) {
+				views.RenderJSON(rw, http.StatusConflict, apiv1.Error(apiv1.CodeConflict, ErrMessageGenreAlreadyExists, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to create a genre", "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageGenreInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(&createGenreResponseBody{
+			genre: buildGenre(domainGenre),
+		})
+
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
@@ -0,0 +1,107 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type createReviewRequestBody struct {
+	Rating uint8  `json:"rating" validate:"required,min=1,max=10" example:"8"`
+	Text   string `json:"text,omitempty" validate:"max=5000" example:"Great film"`
+}
+
+type createReviewResponseBody struct {
+	*review
+}
+
+// @Summary		Review a film by path parameter 'id'
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			reviews
+// @Description	Post a rating from 1 to 10 with an optional text review of a film. A user can review a film only once
+// @ID				create-review
+// @Accept			json
+// @Produce		json
+// @Param			id		path		integer					true	"Film`s id that needs to be reviewed"
+// @Param			input	body		createReviewRequestBody	true	"Review object that needs to be created"
+// @Success		200		{object}	createReviewResponseBody
+// @Failure		400		{object}	apiv1.Response
+// @Failure		401		{object}	apiv1.Response
+// @Failure		403		{object}	apiv1.Response
+// @Failure		404		{object}	apiv1.Response
+// @Failure		409		{object}	apiv1.Response
+// @Failure		500		{object}	apiv1.Response
+// @Router			/films/{id}/reviews [post]
+func (h *Handler) CreateReviewHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "CreateReviewHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		filmIdPathParam := request.PathValue("id")
+		filmId, err := strconv.ParseInt(filmIdPathParam, 10, 64)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageReviewInvalidRequestBody, apiv1.ErrorDescription{"error": "path parameter 'id' must be an integer"}))
+
+			return
+		}
+
+		var createReviewReqBody createReviewRequestBody
+		err = json.NewDecoder(request.Body).Decode(&createReviewReqBody)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageReviewInvalidRequestBody, apiv1.ErrorDescription{"error": "invalid request body"}))
+
+			return
+		}
+
+		err = h.validator.Struct(&createReviewReqBody)
+		if err != nil {
+			errors, ok := err.(validator.ValidationErrors)
+			if !ok {
+				log.Error("failed to type cast validator.ValidationErrors", "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageReviewInternalServerError, apiv1.ErrorDescription{"error": "failed to get validation errors"}))
+			} else {
+				errs := validateRequestBody(errors)
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageReviewInvalidRequestBody, apiv1.ErrorDescription{"error": errs.Error()}))
+			}
+
+			return
+		}
+
+		domainReview, err := h.reviewService.Create(
+			request.Context(),
+			domain.FilmId(filmId),
+			domain.ReviewRating(createReviewReqBody.Rating),
+			domain.ReviewText(createReviewReqBody.Text),
+		)
+		if err != nil {
+			if errors.Is(err, domain.ErrFilmNotFound) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageFilmNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else if errors.Is(err, domain.ErrReviewAlreadyExists) {
+				views.RenderJSON(rw, http.StatusConflict, apiv1.Error(apiv1.CodeConflict, ErrMessageReviewAlreadyExists, apiv1.ErrorDescription{"error": err.Error()}))
+			} else if errors.Is(err, domain.ErrReviewForbidden) {
+				views.RenderJSON(rw, http.StatusForbidden, apiv1.Error(apiv1.CodeForbidden, ErrMessageReviewForbidden, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to create review", "filmId", filmId, "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageReviewInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(&createReviewResponseBody{
+			review: buildReview(domainReview),
+		})
+
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
@@ -0,0 +1,177 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	errInvalidCursor  = errors.New("invalid 'cursor' parameter")
+	errCursorMismatch = errors.New("'cursor' parameter was issued for another 'sort' or filter")
+)
+
+// Cursors are opaque for clients: they are base64url encoded JSON documents
+// with the keys of the last item of the previous page, the sort of the page and a hash of its filter.
+// The keys make sense only in the order of the same sort and filter, so a cursor of another page is rejected.
+
+type filmCursor struct {
+	Id             int64   `json:"id"`
+	Title          string  `json:"title"`
+	ReleaseDate    string  `json:"release_date"`
+	Rating         uint8   `json:"rating"`
+	ActorsCount    int64   `json:"actors_count"`
+	CommunityScore float64 `json:"community_score"`
+	Relevance      float64 `json:"relevance,omitempty"`
+	Sort           string  `json:"sort"`
+	Filter         string  `json:"filter"`
+}
+
+type actorCursor struct {
+	Id         int64  `json:"id"`
+	Name       string `json:"name"`
+	BirthDate  string `json:"birth_date"`
+	FilmsCount int64  `json:"films_count"`
+	Sort       string `json:"sort"`
+	Filter     string `json:"filter"`
+}
+
+func encodeFilmCursor(domainCursor *domain.FilmCursor, sort domain.FilmSort, filterHash string) string {
+	if domainCursor == nil {
+		return ""
+	}
+
+	return encodeCursor(&filmCursor{
+		Id:             domainCursor.Id.Int64(),
+		Title:          domainCursor.Title.String(),
+		ReleaseDate:    domainCursor.ReleaseDate.Time().Format(time.DateOnly),
+		Rating:         domainCursor.Rating.Uint8(),
+		ActorsCount:    domainCursor.ActorsCount,
+		CommunityScore: domainCursor.CommunityScore.Float64(),
+		Relevance:      domainCursor.Relevance,
+		Sort:           formatFilmSort(sort),
+		Filter:         filterHash,
+	})
+}
+
+func decodeFilmCursor(encodedCursor string, sort domain.FilmSort, filterHash string) (*domain.FilmCursor, error) {
+	var cursor filmCursor
+	if err := decodeCursor(encodedCursor, &cursor); err != nil {
+		return nil, err
+	}
+
+	if cursor.Sort != formatFilmSort(sort) || cursor.Filter != filterHash {
+		return nil, errCursorMismatch
+	}
+
+	releaseDate, err := time.Parse(time.DateOnly, cursor.ReleaseDate)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	return &domain.FilmCursor{
+		Id:             domain.FilmId(cursor.Id),
+		Title:          domain.FilmTitle(cursor.Title),
+		ReleaseDate:    domain.FilmReleaseDate(releaseDate),
+		Rating:         domain.FilmRating(cursor.Rating),
+		ActorsCount:    cursor.ActorsCount,
+		CommunityScore: domain.CommunityScore(cursor.CommunityScore),
+		Relevance:      cursor.Relevance,
+	}, nil
+}
+
+func encodeActorCursor(domainCursor *domain.ActorCursor, sort domain.ActorSort, filterHash string) string {
+	if domainCursor == nil {
+		return ""
+	}
+
+	return encodeCursor(&actorCursor{
+		Id:         domainCursor.Id.Int64(),
+		Name:       domainCursor.Name.String(),
+		BirthDate:  domainCursor.BirthDate.Time().Format(time.DateOnly),
+		FilmsCount: domainCursor.FilmsCount,
+		Sort:       formatActorSort(sort),
+		Filter:     filterHash,
+	})
+}
+
+func decodeActorCursor(encodedCursor string, sort domain.ActorSort, filterHash string) (*domain.ActorCursor, error) {
+	var cursor actorCursor
+	if err := decodeCursor(encodedCursor, &cursor); err != nil {
+		return nil, err
+	}
+
+	if cursor.Sort != formatActorSort(sort) || cursor.Filter != filterHash {
+		return nil, errCursorMismatch
+	}
+
+	birthDate, err := time.Parse(time.DateOnly, cursor.BirthDate)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	return &domain.ActorCursor{
+		Id:         domain.ActorId(cursor.Id),
+		Name:       domain.ActorName(cursor.Name),
+		BirthDate:  domain.ActorBirthDate(birthDate),
+		FilmsCount: cursor.FilmsCount,
+	}, nil
+}
+
+func encodeCursor(cursor any) string {
+	rawCursor, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(rawCursor)
+}
+
+func decodeCursor(encodedCursor string, cursor any) error {
+	rawCursor, err := base64.RawURLEncoding.DecodeString(encodedCursor)
+	if err != nil {
+		return errInvalidCursor
+	}
+
+	if err = json.Unmarshal(rawCursor, cursor); err != nil {
+		return errInvalidCursor
+	}
+
+	return nil
+}
+
+// hashCursorFilter returns a short hash of the values of a filter, which cursors keep instead of the filter itself
+func hashCursorFilter(values ...string) string {
+	rawValues, _ := json.Marshal(values)
+	hash := sha256.Sum256(rawValues)
+	return base64.RawURLEncoding.EncodeToString(hash[:12])
+}
+
+// getQueryParams returns the values of the query parameters of the request in the order of params
+func getQueryParams(request *http.Request, params ...string) []string {
+	query := request.URL.Query()
+
+	values := make([]string, len(params))
+	for i, param := range params {
+		values[i] = query.Get(param)
+	}
+
+	return values
+}
+
+func formatFilmSort(sort domain.FilmSort) string {
+	keys := make([]string, len(sort))
+	for i, key := range sort {
+		keys[i] = string(key.Field) + ":" + string(key.Direction)
+	}
+	return strings.Join(keys, ",")
+}
+
+func formatActorSort(sort domain.ActorSort) string {
+	keys := make([]string, len(sort))
+	for i, key := range sort {
+		keys[i] = string(key.Field) + ":" + string(key.Direction)
+	}
+	return strings.Join(keys, ",")
+}
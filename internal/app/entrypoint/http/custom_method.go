@@ -0,0 +1,48 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	restoreMethod = "restore"
+	revertMethod  = "revert"
+)
+
+var (
+	errUnknownCustomMethod = errors.New("unknown custom method")
+)
+
+// customMethodRoute serves the 'POST .../{name}:method' route: a wildcard of the mux always takes the whole path segment,
+// so the route is registered with the wildcard alone and the requests without the ':method' suffix are answered
+// with 404 here, before next authenticates them.
+func customMethodRoute(name, method, errMessage string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, request *http.Request) {
+		if !strings.HasSuffix(request.PathValue(name), ":"+method) {
+			err := fmt.Errorf("%w, only ':%s' is supported", errUnknownCustomMethod, method)
+			views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, errMessage, apiv1.ErrorDescription{"error": err.Error()}))
+
+			return
+		}
+
+		next.ServeHTTP(rw, request)
+	})
+}
+
+// getCustomMethodParam parses the integer path parameter 'name' of the routes of customMethodRoute
+func getCustomMethodParam(request *http.Request, name, method string) (int64, error) {
+	pathParam := strings.TrimSuffix(request.PathValue(name), ":"+method)
+
+	value, err := strconv.ParseInt(pathParam, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("path parameter '%s' must be an integer followed by ':%s'", name, method)
+	}
+
+	return value, nil
+}
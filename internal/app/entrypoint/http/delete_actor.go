@@ -17,19 +17,22 @@ type deleteActorResponseBody struct {
 }
 
 // @Summary		Delete an actor by path parameter 'id'
+// @Security		BearerAuth
 // @Security		BasicAuth
 // @Tags			actors
 // @Description	Delete an actor by path parameter 'id'
 // @ID				delete-actor
 // @Accept			json
 // @Produce		json
-// @Param			id	path		integer	true	"Actors`s id that needs to be deleted"
-// @Success		200	{object}	deleteActorResponseBody
-// @Failure		400	{object}	apiv1.Response
-// @Failure		401	{object}	apiv1.Response
-// @Failure		403	{object}	apiv1.Response
-// @Failure		404	{object}	apiv1.Response
-// @Failure		500	{object}	apiv1.Response
+// @Param			id			path		integer	true	"Actors`s id that needs to be deleted"
+// @Param			If-Match	header		string	false	"Entity tag of the actor version that is expected to be changed, like '\"1\"'"
+// @Success		200			{object}	deleteActorResponseBody
+// @Failure		400			{object}	apiv1.Response
+// @Failure		401			{object}	apiv1.Response
+// @Failure		403			{object}	apiv1.Response
+// @Failure		404			{object}	apiv1.Response
+// @Failure		412			{object}	apiv1.Response
+// @Failure		500			{object}	apiv1.Response
 // @Router			/actors/{id} [delete]
 func (h *Handler) DeleteActorHandler() http.HandlerFunc {
 	return func(rw http.ResponseWriter, request *http.Request) {
@@ -47,10 +50,19 @@ func (h *Handler) DeleteActorHandler() http.HandlerFunc {
 			return
 		}
 
-		err = h.actorService.Delete(domain.ActorId(actorId))
+		version, err := getIfMatchVersion(request)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageActorInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+
+			return
+		}
+
+		err = h.actorService.Delete(request.Context(), domain.ActorId(actorId), version)
 		if err != nil {
 			if errors.Is(err, domain.ErrActorNotFound) {
 				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageActorNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else if errors.Is(err, domain.ErrActorVersionMismatch) {
+				views.RenderJSON(rw, http.StatusPreconditionFailed, apiv1.Error(apiv1.CodePreconditionFailed, ErrMessageActorPreconditionFailed, apiv1.ErrorDescription{"error": err.Error()}))
 			} else {
 				log.Error("failed to delete actor", "id", actorId, "error", err.Error())
 
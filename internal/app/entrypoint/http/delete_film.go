@@ -17,19 +17,22 @@ type deleteFilmResponseBody struct {
 }
 
 // @Summary		Delete a film by path parameter 'id'
+// @Security		BearerAuth
 // @Security		BasicAuth
 // @Tags			films
 // @Description	Delete a film by path parameter 'id'
 // @ID				delete-film
 // @Accept			json
 // @Produce		json
-// @Param			id	path		integer	true	"Film`s id that needs to be deleted"
-// @Success		200	{object}	deleteFilmResponseBody
-// @Failure		400	{object}	apiv1.Response
-// @Failure		401	{object}	apiv1.Response
-// @Failure		403	{object}	apiv1.Response
-// @Failure		404	{object}	apiv1.Response
-// @Failure		500	{object}	apiv1.Response
+// @Param			id			path		integer	true	"Film`s id that needs to be deleted"
+// @Param			If-Match	header		string	false	"Entity tag of the film version that is expected to be changed, like '\"1\"'"
+// @Success		200			{object}	deleteFilmResponseBody
+// @Failure		400			{object}	apiv1.Response
+// @Failure		401			{object}	apiv1.Response
+// @Failure		403			{object}	apiv1.Response
+// @Failure		404			{object}	apiv1.Response
+// @Failure		412			{object}	apiv1.Response
+// @Failure		500			{object}	apiv1.Response
 // @Router			/films/{id} [delete]
 func (h *Handler) DeleteFilmHandler() http.HandlerFunc {
 	return func(rw http.ResponseWriter, request *http.Request) {
@@ -53,10 +56,19 @@ func (h *Handler) DeleteFilmHandler() http.HandlerFunc {
 			return
 		}
 
-		err = h.filmService.Delete(domain.FilmId(filmId))
+		version, err := getIfMatchVersion(request)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageFilmInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+
+			return
+		}
+
+		err = h.filmService.Delete(request.Context(), domain.FilmId(filmId), version)
 		if err != nil {
 			if errors.Is(err, domain.ErrFilmNotFound) {
 				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageFilmNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else if errors.Is(err, domain.ErrFilmVersionMismatch) {
+				views.RenderJSON(rw, http.StatusPreconditionFailed, apiv1.Error(apiv1.CodePreconditionFailed, ErrMessageFilmPreconditionFailed, apiv1.ErrorDescription{"error": err.Error()}))
 			} else {
 				log.Error("failed to delete film", "id", filmId, "error", err.Error())
 
@@ -0,0 +1,69 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type deleteReviewResponseBody struct {
+	Message string `json:"message"`
+}
+
+// @Summary		Delete own review by path parameter 'id'
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			reviews
+// @Description	Delete own review by path parameter 'id'. The community score of the film is recalculated
+// @ID				delete-review
+// @Produce		json
+// @Param			id	path		integer	true	"Review`s id that needs to be deleted"
+// @Success		200	{object}	deleteReviewResponseBody
+// @Failure		400	{object}	apiv1.Response
+// @Failure		401	{object}	apiv1.Response
+// @Failure		403	{object}	apiv1.Response
+// @Failure		404	{object}	apiv1.Response
+// @Failure		500	{object}	apiv1.Response
+// @Router			/reviews/{id} [delete]
+func (h *Handler) DeleteReviewHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "DeleteReviewHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		reviewIdPathParam := request.PathValue("id")
+		reviewId, err := strconv.ParseInt(reviewIdPathParam, 10, 64)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageReviewInvalidRequestBody, apiv1.ErrorDescription{"error": "path parameter 'id' must be an integer"}))
+
+			return
+		}
+
+		err = h.reviewService.Delete(request.Context(), domain.ReviewId(reviewId))
+		if err != nil {
+			if errors.Is(err, domain.ErrReviewNotFound) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageReviewNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else if errors.Is(err, domain.ErrReviewForbidden) {
+				views.RenderJSON(rw, http.StatusForbidden, apiv1.Error(apiv1.CodeForbidden, ErrMessageReviewForbidden, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to delete review", "id", reviewId, "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageReviewInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(&deleteReviewResponseBody{
+			Message: fmt.Sprintf("Review with id '%d' has deleted successfully", reviewId),
+		})
+
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
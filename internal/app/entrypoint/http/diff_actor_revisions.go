@@ -0,0 +1,82 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type diffActorRevisionsResponseBody struct {
+	From    int64             `json:"from"`
+	To      int64             `json:"to"`
+	Changes []*revisionChange `json:"changes"`
+}
+
+// @Summary		Compare two revisions of an actor by path parameter 'id' and query parameters 'from' and 'to'
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			revisions
+// @Description	List the fields of an actor that differ between two revisions with their values in both of them.
+// @Description	The fields are 'name', 'sex' and 'birthdate'
+// @ID				diff-actor-revisions
+// @Produce		json
+// @Param			id		path		integer	true	"Actor`s id whose revisions need to be compared"
+// @Param			from	query		integer	true	"The revision to compare with"
+// @Param			to		query		integer	true	"The revision to compare"
+// @Success		200		{object}	diffActorRevisionsResponseBody
+// @Failure		400		{object}	apiv1.Response
+// @Failure		401		{object}	apiv1.Response
+// @Failure		403		{object}	apiv1.Response
+// @Failure		404		{object}	apiv1.Response
+// @Failure		500		{object}	apiv1.Response
+// @Router			/actors/{id}/revisions/diff [get]
+func (h *Handler) DiffActorRevisionsHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "DiffActorRevisionsHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		actorIdPathParam := request.PathValue("id")
+		actorId, err := strconv.ParseInt(actorIdPathParam, 10, 64)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageRevisionInvalidRequestBody, apiv1.ErrorDescription{"error": "path parameter 'id' must be an integer"}))
+
+			return
+		}
+
+		from, to, err := getRevisionsRange(request)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageRevisionInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+
+			return
+		}
+
+		changes, err := h.actorRevisionService.Diff(request.Context(), domain.ActorId(actorId), from, to)
+		if err != nil {
+			if errors.Is(err, domain.ErrActorNotFound) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageActorNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else if errors.Is(err, domain.ErrActorRevisionNotFound) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageRevisionNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to compare actor revisions", "actorId", actorId, "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageRevisionInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(&diffActorRevisionsResponseBody{
+			From:    from,
+			To:      to,
+			Changes: buildRevisionChanges(changes),
+		})
+
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
@@ -0,0 +1,82 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type diffFilmRevisionsResponseBody struct {
+	From    int64             `json:"from"`
+	To      int64             `json:"to"`
+	Changes []*revisionChange `json:"changes"`
+}
+
+// @Summary		Compare two revisions of a film by path parameter 'id' and query parameters 'from' and 'to'
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			revisions
+// @Description	List the fields of a film that differ between two revisions with their values in both of them.
+// @Description	The fields are 'title', 'description', 'release_date', 'rating', 'credits' and 'genre_ids'
+// @ID				diff-film-revisions
+// @Produce		json
+// @Param			id		path		integer	true	"Film`s id whose revisions need to be compared"
+// @Param			from	query		integer	true	"The revision to compare with"
+// @Param			to		query		integer	true	"The revision to compare"
+// @Success		200		{object}	diffFilmRevisionsResponseBody
+// @Failure		400		{object}	apiv1.Response
+// @Failure		401		{object}	apiv1.Response
+// @Failure		403		{object}	apiv1.Response
+// @Failure		404		{object}	apiv1.Response
+// @Failure		500		{object}	apiv1.Response
+// @Router			/films/{id}/revisions/diff [get]
+func (h *Handler) DiffFilmRevisionsHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "DiffFilmRevisionsHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		filmIdPathParam := request.PathValue("id")
+		filmId, err := strconv.ParseInt(filmIdPathParam, 10, 64)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageRevisionInvalidRequestBody, apiv1.ErrorDescription{"error": "path parameter 'id' must be an integer"}))
+
+			return
+		}
+
+		from, to, err := getRevisionsRange(request)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageRevisionInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+
+			return
+		}
+
+		changes, err := h.filmRevisionService.Diff(request.Context(), domain.FilmId(filmId), from, to)
+		if err != nil {
+			if errors.Is(err, domain.ErrFilmNotFound) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageFilmNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else if errors.Is(err, domain.ErrFilmRevisionNotFound) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageRevisionNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to compare film revisions", "filmId", filmId, "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageRevisionInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(&diffFilmRevisionsResponseBody{
+			From:    from,
+			To:      to,
+			Changes: buildRevisionChanges(changes),
+		})
+
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
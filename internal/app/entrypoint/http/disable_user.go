@@ -0,0 +1,66 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/service/user"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type disableUserResponseBody struct {
+	*userAccount
+}
+
+// @Summary		Disable a user by path parameter 'id'
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			users
+// @Description	Disable a user by path parameter 'id'. A disabled user cannot log in and all of their refresh tokens are revoked
+// @ID				disable-user
+// @Produce		json
+// @Param			id	path		integer	true	"User`s id that needs to be disabled"
+// @Success		200	{object}	disableUserResponseBody
+// @Failure		400	{object}	apiv1.Response
+// @Failure		401	{object}	apiv1.Response
+// @Failure		403	{object}	apiv1.Response
+// @Failure		404	{object}	apiv1.Response
+// @Failure		500	{object}	apiv1.Response
+// @Router			/users/{id}/disable [post]
+func (h *Handler) DisableUserHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "DisableUserHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		userIdPathParam := request.PathValue("id")
+		userId, err := strconv.ParseInt(userIdPathParam, 10, 64)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageUserInvalidRequestBody, apiv1.ErrorDescription{"error": "path parameter 'id' must be an integer"}))
+
+			return
+		}
+
+		usr, err := h.userService.Disable(request.Context(), userId)
+		if err != nil {
+			if errors.Is(err, user.ErrUserNotFound) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageUserNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to disable user", "id", userId, "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageUserInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(&disableUserResponseBody{
+			userAccount: buildUserAccount(usr),
+		})
+
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
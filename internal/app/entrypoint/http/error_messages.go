@@ -4,9 +4,61 @@ var (
 	ErrMessageActorInvalidRequestBody  = "errors.actor.invalidRequestBody"
 	ErrMessageActorInternalServerError = "errors.actor.internalServerError"
 	ErrMessageActorNotFound            = "errors.actor.notFound"
+	ErrMessageActorPreconditionFailed  = "errors.actor.preconditionFailed"
+	ErrMessageActorBatchFailed         = "errors.actor.batchFailed"
+	ErrMessageActorBatchForbidden      = "errors.actor.batchForbidden"
 
 	ErrMessageFilmInvalidRequestBody  = "errors.film.invalidRequestBody"
 	ErrMessageFilmActorsNotFound      = "errors.film.actorsNotFound"
+	ErrMessageFilmGenresNotFound      = "errors.film.genresNotFound"
 	ErrMessageFilmNotFound            = "errors.film.notFound"
+	ErrMessageFilmPreconditionFailed  = "errors.film.preconditionFailed"
+	ErrMessageFilmNotInList           = "errors.film.notInList"
+	ErrMessageFilmListForbidden       = "errors.film.listForbidden"
+	ErrMessageFilmBatchFailed         = "errors.film.batchFailed"
+	ErrMessageFilmBatchForbidden      = "errors.film.batchForbidden"
 	ErrMessageFilmInternalServerError = "errors.film.internalServerError"
+
+	ErrMessageGenreInvalidRequestBody  = "errors.genre.invalidRequestBody"
+	ErrMessageGenreNotFound            = "errors.genre.notFound"
+	ErrMessageGenreAlreadyExists       = "errors.genre.alreadyExists"
+	ErrMessageGenreInternalServerError = "errors.genre.internalServerError"
+
+	ErrMessageReviewInvalidRequestBody  = "errors.review.invalidRequestBody"
+	ErrMessageReviewNotFound            = "errors.review.notFound"
+	ErrMessageReviewAlreadyExists       = "errors.review.alreadyExists"
+	ErrMessageReviewForbidden           = "errors.review.forbidden"
+	ErrMessageReviewInternalServerError = "errors.review.internalServerError"
+
+	ErrMessageUserInvalidRequestBody  = "errors.user.invalidRequestBody"
+	ErrMessageUserInvalidPassword     = "errors.user.invalidPassword"
+	ErrMessageUserNotFound            = "errors.user.notFound"
+	ErrMessageUserAlreadyExists       = "errors.user.alreadyExists"
+	ErrMessageUserInternalServerError = "errors.user.internalServerError"
+
+	ErrMessageAuthInvalidRequestBody  = "errors.auth.invalidRequestBody"
+	ErrMessageAuthUnauthorized        = "errors.auth.unauthorized"
+	ErrMessageAuthInternalServerError = "errors.auth.internalServerError"
+
+	ErrMessageAuditInvalidRequestBody  = "errors.audit.invalidRequestBody"
+	ErrMessageAuditInternalServerError = "errors.audit.internalServerError"
+
+	ErrMessageImportInvalidRequestBody  = "errors.import.invalidRequestBody"
+	ErrMessageImportInvalidRows         = "errors.import.invalidRows"
+	ErrMessageImportInternalServerError = "errors.import.internalServerError"
+
+	ErrMessageExportInvalidRequestBody  = "errors.export.invalidRequestBody"
+	ErrMessageExportNotAcceptable       = "errors.export.notAcceptable"
+	ErrMessageExportInternalServerError = "errors.export.internalServerError"
+
+	ErrMessageTrashInvalidRequestBody  = "errors.trash.invalidRequestBody"
+	ErrMessageTrashUnknownMethod       = "errors.trash.unknownMethod"
+	ErrMessageTrashFilmNotFound        = "errors.trash.filmNotFound"
+	ErrMessageTrashActorNotFound       = "errors.trash.actorNotFound"
+	ErrMessageTrashInternalServerError = "errors.trash.internalServerError"
+
+	ErrMessageRevisionInvalidRequestBody  = "errors.revision.invalidRequestBody"
+	ErrMessageRevisionUnknownMethod       = "errors.revision.unknownMethod"
+	ErrMessageRevisionNotFound            = "errors.revision.notFound"
+	ErrMessageRevisionInternalServerError = "errors.revision.internalServerError"
 )
@@ -0,0 +1,35 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var errInvalidIfMatch = errors.New("'If-Match' header must be '*' or a single strong entity tag like '\"1\"'")
+
+// setETag sets the entity tag of the film or actor, which is its quoted version
+func setETag(rw http.ResponseWriter, version int64) {
+	rw.Header().Set("ETag", strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// getIfMatchVersion returns the version the 'If-Match' header requires the film or actor to have.
+// It returns nil if the header is missing or is '*', because an existing entity matches it with any version.
+func getIfMatchVersion(request *http.Request) (*int64, error) {
+	ifMatch := strings.TrimSpace(request.Header.Get("If-Match"))
+	if ifMatch == "" || ifMatch == "*" {
+		return nil, nil
+	}
+
+	if len(ifMatch) < 2 || ifMatch[0] != '"' || ifMatch[len(ifMatch)-1] != '"' {
+		return nil, errInvalidIfMatch
+	}
+
+	version, err := strconv.ParseInt(ifMatch[1:len(ifMatch)-1], 10, 64)
+	if err != nil || version <= 0 {
+		return nil, errInvalidIfMatch
+	}
+
+	return &version, nil
+}
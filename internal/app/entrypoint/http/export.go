@@ -0,0 +1,67 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"github.com/vaberof/vk-internship-task/internal/app/catalog"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"net/http"
+	"strings"
+)
+
+var errNotAcceptableExportFormat = errors.New("'Accept' header must allow 'application/json', 'text/csv' or 'application/x-ndjson'")
+
+// getExportFormat takes the format from the 'format' parameter or else from the first acceptable media type
+// of the 'Accept' header. JSON is used if any media type is accepted.
+func getExportFormat(request *http.Request) (catalog.Format, error) {
+	if formatStr := request.URL.Query().Get("format"); formatStr != "" {
+		return catalog.ParseFormat(formatStr)
+	}
+
+	accept := strings.TrimSpace(request.Header.Get("Accept"))
+	if accept == "" {
+		return catalog.FormatJSON, nil
+	}
+
+	for _, mediaType := range strings.Split(accept, ",") {
+		if format, ok := catalog.FormatFromMediaType(mediaType); ok {
+			return format, nil
+		}
+		if mediaType = strings.TrimSpace(strings.Split(mediaType, ";")[0]); mediaType == "*/*" || mediaType == "application/*" {
+			return catalog.FormatJSON, nil
+		}
+	}
+
+	return "", errNotAcceptableExportFormat
+}
+
+// setExportHeaders makes the export downloaded as a file like 'films.csv'
+func setExportHeaders(rw http.ResponseWriter, name string, format catalog.Format) {
+	rw.Header().Set("Content-Type", format.MediaType())
+	rw.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.%s\"", name, format))
+}
+
+// exportResponseWriter remembers whether the export has started being written, because an error
+// can be rendered only before that. After that the response can only be aborted.
+type exportResponseWriter struct {
+	http.ResponseWriter
+	written bool
+}
+
+func (w *exportResponseWriter) Write(p []byte) (int, error) {
+	w.written = true
+	return w.ResponseWriter.Write(p)
+}
+
+// renderExportError renders the error if the export has not started yet, otherwise it aborts the response,
+// so that the client does not take a truncated export for a complete one
+func renderExportError(rw *exportResponseWriter, err error) {
+	if rw.written {
+		panic(http.ErrAbortHandler)
+	}
+
+	rw.Header().Del("Content-Disposition")
+
+	views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageExportInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+}
@@ -0,0 +1,79 @@
+package http
+
+import (
+	"errors"
+	"github.com/vaberof/vk-internship-task/internal/app/catalog"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+)
+
+// @Summary		Export all films with their actors
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			exports
+// @Description	Stream all films with their actors ordered by id.
+// @Description	The format is taken from 'format' or from the first acceptable media type of 'Accept' ('application/json', 'text/csv', 'application/x-ndjson'), JSON is used by default.
+// @Description	JSON is an array of films, NDJSON has a film per line. CSV has a header with the columns 'id', 'title', 'description', 'release_date', 'rating', 'actor_ids', 'actors',
+// @Description	actor ids are separated by ';', actors are like `Name|1|1970-01-02;Name 2|2|1980-01-02`.
+// @Description	If the export fails after it has started, the connection is aborted, so that an incomplete export is never received as a complete one
+// @ID				export-films
+// @Produce		json,text/csv,application/x-ndjson
+// @Param			format	query		string	false	"An optional query parameter 'format'. Allowed values: 'json', 'csv', 'ndjson'"
+// @Success		200		{file}		file
+// @Failure		400		{object}	apiv1.Response
+// @Failure		401		{object}	apiv1.Response
+// @Failure		403		{object}	apiv1.Response
+// @Failure		406		{object}	apiv1.Response
+// @Failure		500		{object}	apiv1.Response
+// @Router			/exports/films [get]
+func (h *Handler) ExportFilmsHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "ExportFilmsHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		format, err := getExportFormat(request)
+		if err != nil {
+			if errors.Is(err, errNotAcceptableExportFormat) {
+				views.RenderJSON(rw, http.StatusNotAcceptable, apiv1.Error(apiv1.CodeNotAcceptable, ErrMessageExportNotAcceptable, apiv1.ErrorDescription{"error": err.Error()}))
+
+				return
+			}
+
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageExportInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+
+			return
+		}
+
+		exportWriter := &exportResponseWriter{ResponseWriter: rw}
+
+		setExportHeaders(rw, "films", format)
+
+		encoder, err := catalog.NewFilmsEncoder(exportWriter, format)
+		if err != nil {
+			log.Error("failed to export films", "error", err.Error())
+
+			renderExportError(exportWriter, err)
+
+			return
+		}
+
+		if err = h.filmService.Export(request.Context(), encoder.Encode); err != nil {
+			log.Error("failed to export films", "error", err.Error())
+
+			renderExportError(exportWriter, err)
+
+			return
+		}
+
+		if err = encoder.Close(); err != nil {
+			log.Error("failed to export films", "error", err.Error())
+
+			renderExportError(exportWriter, err)
+
+			return
+		}
+	}
+}
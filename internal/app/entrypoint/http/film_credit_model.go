@@ -0,0 +1,45 @@
+package http
+
+import (
+	"github.com/vaberof/vk-internship-task/internal/domain"
+)
+
+type filmCreditRequest struct {
+	ActorId       int64  `json:"actor_id" validate:"required" example:"1"`
+	Role          string `json:"role" validate:"required,oneof=actor director writer producer composer" example:"director"`
+	CharacterName string `json:"character_name,omitempty" validate:"max=100"`
+	BillingOrder  int    `json:"billing_order" validate:"min=0"`
+}
+
+type filmCredit struct {
+	Actor         *filmActor `json:"actor"`
+	Role          string     `json:"role"`
+	CharacterName string     `json:"character_name,omitempty"`
+	BillingOrder  int        `json:"billing_order"`
+}
+
+func buildDomainFilmCredits(credits []*filmCreditRequest) []*domain.FilmCreditParams {
+	domainCredits := make([]*domain.FilmCreditParams, len(credits))
+	for i, credit := range credits {
+		domainCredits[i] = &domain.FilmCreditParams{
+			ActorId:       domain.ActorId(credit.ActorId),
+			Role:          domain.CreditRole(credit.Role),
+			CharacterName: domain.CharacterName(credit.CharacterName),
+			BillingOrder:  credit.BillingOrder,
+		}
+	}
+	return domainCredits
+}
+
+func buildFilmCredits(domainCredits []*domain.FilmCredit) []*filmCredit {
+	credits := make([]*filmCredit, len(domainCredits))
+	for i, domainCredit := range domainCredits {
+		credits[i] = &filmCredit{
+			Actor:         buildFilmActor(domainCredit.Actor),
+			Role:          domainCredit.Role.String(),
+			CharacterName: domainCredit.CharacterName.String(),
+			BillingOrder:  domainCredit.BillingOrder,
+		}
+	}
+	return credits
+}
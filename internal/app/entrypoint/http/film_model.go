@@ -6,12 +6,27 @@ import (
 )
 
 type film struct {
-	Id          int64        `json:"id"`
-	Title       string       `json:"title"`
-	Description string       `json:"description"`
-	ReleaseDate string       `json:"release_date"`
-	Rating      uint8        `json:"rating"`
-	Actors      []*filmActor `json:"actors,omitempty"`
+	Id             int64           `json:"id"`
+	Title          string          `json:"title"`
+	Description    string          `json:"description"`
+	ReleaseDate    string          `json:"release_date"`
+	Rating         uint8           `json:"rating"`
+	CommunityScore float64         `json:"community_score"`
+	VotesCount     int64           `json:"votes_count"`
+	Actors         []*filmActor    `json:"actors,omitempty"`
+	Genres         []*genre        `json:"genres,omitempty"`
+	CreatedBy      *int64          `json:"created_by,omitempty"`
+	UpdatedBy      *int64          `json:"updated_by,omitempty"`
+	InWatchlist    bool            `json:"in_watchlist"`
+	Watched        bool            `json:"watched"`
+	Relevance      *float64        `json:"relevance,omitempty"`
+	Highlights     *filmHighlights `json:"highlights,omitempty"`
+}
+
+// filmHighlights are fragments of the film title and description with the words matched by a search query wrapped in '<b>' and '</b>'
+type filmHighlights struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
 }
 
 type filmActor struct {
@@ -30,14 +45,31 @@ func buildFilms(domainFilms []*domain.Film) []*film {
 }
 
 func buildFilm(domainFilm *domain.Film) *film {
-	return &film{
-		Id:          domainFilm.Id.Int64(),
-		Title:       domainFilm.Title.String(),
-		Description: domainFilm.Description.String(),
-		ReleaseDate: domainFilm.ReleaseDate.Time().Format(time.DateOnly),
-		Rating:      domainFilm.Rating.Uint8(),
-		Actors:      buildFilmActors(domainFilm.Actors),
+	film := &film{
+		Id:             domainFilm.Id.Int64(),
+		Title:          domainFilm.Title.String(),
+		Description:    domainFilm.Description.String(),
+		ReleaseDate:    domainFilm.ReleaseDate.Time().Format(time.DateOnly),
+		Rating:         domainFilm.Rating.Uint8(),
+		CommunityScore: domainFilm.CommunityScore.Float64(),
+		VotesCount:     domainFilm.VotesCount,
+		Actors:         buildFilmActors(domainFilm.Actors),
+		Genres:         buildGenres(domainFilm.Genres),
+		CreatedBy:      domainFilm.CreatedBy,
+		UpdatedBy:      domainFilm.UpdatedBy,
+		InWatchlist:    domainFilm.InWatchlist,
+		Watched:        domainFilm.Watched,
 	}
+
+	if domainFilm.Match != nil {
+		film.Relevance = &domainFilm.Match.Relevance
+		film.Highlights = &filmHighlights{
+			Title:       domainFilm.Match.TitleHighlight,
+			Description: domainFilm.Match.DescriptionHighlight,
+		}
+	}
+
+	return film
 }
 
 func buildFilmActors(domainActors []*domain.Actor) []*filmActor {
@@ -0,0 +1,53 @@
+package http
+
+import (
+	"errors"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"net/http"
+	"strconv"
+)
+
+type genre struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+func buildDomainGenreIds(genreIds []int64) []domain.GenreId {
+	domainGenreIds := make([]domain.GenreId, len(genreIds))
+	for i := range genreIds {
+		domainGenreIds[i] = domain.GenreId(genreIds[i])
+	}
+	return domainGenreIds
+}
+
+func buildGenres(domainGenres []*domain.Genre) []*genre {
+	genres := make([]*genre, len(domainGenres))
+	for i := range domainGenres {
+		genres[i] = buildGenre(domainGenres[i])
+	}
+	return genres
+}
+
+func buildGenre(domainGenre *domain.Genre) *genre {
+	return &genre{
+		Id:   domainGenre.Id.Int64(),
+		Name: domainGenre.Name.String(),
+	}
+}
+
+// getGenreIdQueryParam parses an optional 'genre' query parameter with a genre id
+func getGenreIdQueryParam(request *http.Request) (*domain.GenreId, error) {
+	genreStr := request.URL.Query().Get(filterParamGenre)
+	if genreStr == "" {
+		return nil, nil
+	}
+
+	genreId, err := strconv.ParseInt(genreStr, 10, 64)
+	if err != nil {
+		return nil, errors.New("'genre' must be an integer")
+	}
+
+	domainGenreId := domain.GenreId(genreId)
+
+	return &domainGenreId, nil
+}
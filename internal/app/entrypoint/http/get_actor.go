@@ -0,0 +1,77 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type getActorResponseBody struct {
+	Id        int64        `json:"id"`
+	Name      string       `json:"name"`
+	Sex       uint8        `json:"sex"`
+	BirthDate string       `json:"birthdate"`
+	Films     []*actorFilm `json:"films"`
+}
+
+// @Summary		Get an actor by path parameter 'id'
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			actors
+// @Description	Get an actor with their filmography by path parameter 'id'
+// @ID				get-actor
+// @Produce		json
+// @Param			id	path		integer	true	"Actor`s id that needs to be returned"
+// @Success		200	{object}	getActorResponseBody
+// @Header		200	{string}	ETag	"Entity tag of the actor version"
+// @Failure		400	{object}	apiv1.Response
+// @Failure		401	{object}	apiv1.Response
+// @Failure		403	{object}	apiv1.Response
+// @Failure		404	{object}	apiv1.Response
+// @Failure		500	{object}	apiv1.Response
+// @Router			/actors/{id} [get]
+func (h *Handler) GetActorHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "GetActorHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		actorIdPathParam := request.PathValue("id")
+		actorId, err := strconv.Atoi(actorIdPathParam)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageActorInvalidRequestBody, apiv1.ErrorDescription{"error": "path parameter 'id' must be an integer"}))
+
+			return
+		}
+
+		domainActor, err := h.actorService.GetById(request.Context(), domain.ActorId(actorId))
+		if err != nil {
+			if errors.Is(err, domain.ErrActorNotFound) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageActorNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to get actor", "id", actorId, "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageActorInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(&getActorResponseBody{
+			Id:        domainActor.Id.Int64(),
+			Name:      domainActor.Name.String(),
+			Sex:       domainActor.Sex.Uint8(),
+			BirthDate: domainActor.BirthDate.Time().Format(time.DateOnly),
+			Films:     buildActorFilms(domainActor.Films),
+		})
+
+		setETag(rw, domainActor.Version)
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
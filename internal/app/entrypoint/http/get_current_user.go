@@ -0,0 +1,61 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/service/user"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+)
+
+type getCurrentUserResponseBody struct {
+	*userAccount
+}
+
+// @Summary		Get the authenticated user
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			users
+// @Description	Get the authenticated user
+// @ID				get-current-user
+// @Produce		json
+// @Success		200	{object}	getCurrentUserResponseBody
+// @Failure		401	{object}	apiv1.Response
+// @Failure		404	{object}	apiv1.Response
+// @Failure		500	{object}	apiv1.Response
+// @Router			/users/me [get]
+func (h *Handler) GetCurrentUserHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "GetCurrentUserHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		principal, ok := user.PrincipalFromContext(request.Context())
+		if !ok {
+			views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageUserInternalServerError, apiv1.ErrorDescription{"error": "failed to get user from context"}))
+
+			return
+		}
+
+		usr, err := h.userService.FindById(request.Context(), principal.Id)
+		if err != nil {
+			if errors.Is(err, user.ErrUserNotFound) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageUserNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to get current user", "id", principal.Id, "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageUserInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(&getCurrentUserResponseBody{
+			userAccount: buildUserAccount(usr),
+		})
+
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
@@ -0,0 +1,81 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type getFilmResponseBody struct {
+	Id          int64        `json:"id"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	ReleaseDate string       `json:"release_date"`
+	Rating      uint8        `json:"rating"`
+	Actors      []*filmActor `json:"actors"`
+	Genres      []*genre     `json:"genres"`
+}
+
+// @Summary		Get a film by path parameter 'id'
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			films
+// @Description	Get a film with its actors by path parameter 'id'
+// @ID				get-film
+// @Produce		json
+// @Param			id	path		integer	true	"Film`s id that needs to be returned"
+// @Success		200	{object}	getFilmResponseBody
+// @Header		200	{string}	ETag	"Entity tag of the film version"
+// @Failure		400	{object}	apiv1.Response
+// @Failure		401	{object}	apiv1.Response
+// @Failure		403	{object}	apiv1.Response
+// @Failure		404	{object}	apiv1.Response
+// @Failure		500	{object}	apiv1.Response
+// @Router			/films/{id} [get]
+func (h *Handler) GetFilmHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "GetFilmHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		filmIdPathParam := request.PathValue("id")
+		filmId, err := strconv.Atoi(filmIdPathParam)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageFilmInvalidRequestBody, apiv1.ErrorDescription{"error": "path parameter 'id' must be an integer"}))
+
+			return
+		}
+
+		domainFilm, err := h.filmService.GetById(request.Context(), domain.FilmId(filmId))
+		if err != nil {
+			if errors.Is(err, domain.ErrFilmNotFound) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageFilmNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to get film", "id", filmId, "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageFilmInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(&getFilmResponseBody{
+			Id:          domainFilm.Id.Int64(),
+			Title:       domainFilm.Title.String(),
+			Description: domainFilm.Description.String(),
+			ReleaseDate: domainFilm.ReleaseDate.Time().Format(time.DateOnly),
+			Rating:      domainFilm.Rating.Uint8(),
+			Actors:      buildFilmActors(domainFilm.Actors),
+			Genres:      buildGenres(domainFilm.Genres),
+		})
+
+		setETag(rw, domainFilm.Version)
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
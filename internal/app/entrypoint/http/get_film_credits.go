@@ -0,0 +1,66 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type getFilmCreditsResponseBody struct {
+	Credits []*filmCredit `json:"credits"`
+}
+
+// @Summary		Get credits of a film by path parameter 'id'
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			films
+// @Description	Get everyone attached to a film (actors, directors, writers, producers, composers) ordered by billing order
+// @ID				get-film-credits
+// @Produce		json
+// @Param			id	path		integer	true	"Film`s id whose credits need to be returned"
+// @Success		200	{object}	getFilmCreditsResponseBody
+// @Failure		400	{object}	apiv1.Response
+// @Failure		401	{object}	apiv1.Response
+// @Failure		403	{object}	apiv1.Response
+// @Failure		404	{object}	apiv1.Response
+// @Failure		500	{object}	apiv1.Response
+// @Router			/films/{id}/credits [get]
+func (h *Handler) GetFilmCreditsHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "GetFilmCreditsHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		filmIdPathParam := request.PathValue("id")
+		filmId, err := strconv.ParseInt(filmIdPathParam, 10, 64)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageFilmInvalidRequestBody, apiv1.ErrorDescription{"error": "path parameter 'id' must be an integer"}))
+
+			return
+		}
+
+		domainFilm, err := h.filmService.GetById(request.Context(), domain.FilmId(filmId))
+		if err != nil {
+			if errors.Is(err, domain.ErrFilmNotFound) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageFilmNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to get film credits", "id", filmId, "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageFilmInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(&getFilmCreditsResponseBody{
+			Credits: buildFilmCredits(domainFilm.Credits),
+		})
+
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
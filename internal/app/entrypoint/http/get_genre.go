@@ -0,0 +1,66 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type getGenreResponseBody struct {
+	*genre
+}
+
+// @Summary		Get a genre by path parameter 'id'
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			genres
+// @Description	Get a genre by path parameter 'id'
+// @ID				get-genre
+// @Produce		json
+// @Param			id	path		integer	true	"Genre`s id that needs to be returned"
+// @Success		200	{object}	getGenreResponseBody
+// @Failure		400	{object}	apiv1.Response
+// @Failure		401	{object}	apiv1.Response
+// @Failure		403	{object}	apiv1.Response
+// @Failure		404	{object}	apiv1.Response
+// @Failure		500	{object}	apiv1.Response
+// @Router			/genres/{id} [get]
+func (h *Handler) GetGenreHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "GetGenreHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		genreIdPathParam := request.PathValue("id")
+		genreId, err := strconv.ParseInt(genreIdPathParam, 10, 64)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageGenreInvalidRequestBody, apiv1.ErrorDescription{"error": "path parameter 'id' must be an integer"}))
+
+			return
+		}
+
+		domainGenre, err := h.genreService.GetById(request.Context(), domain.GenreId(genreId))
+		if err != nil {
+			if errors.Is(err, domain.ErrGenreNotFound) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageGenreNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to get genre", "id", genreId, "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageGenreInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(&getGenreResponseBody{
+			genre: buildGenre(domainGenre),
+		})
+
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
@@ -13,46 +13,157 @@ import (
 )
 
 type Handler struct {
-	actorService domain.ActorService
-	filmService  domain.FilmService
-	authService  authservice.AuthService
+	actorService         domain.ActorService
+	filmService          domain.FilmService
+	genreService         domain.GenreService
+	reviewService        domain.ReviewService
+	filmListService      domain.FilmListService
+	auditService         domain.AuditService
+	filmImportService    domain.FilmImportService
+	trashService         domain.TrashService
+	filmRevisionService  domain.FilmRevisionService
+	actorRevisionService domain.ActorRevisionService
+	authService          authservice.AuthService
+	userService          user.UserService
+
+	rolePermissions user.RolePermissions
 
 	validator *validator.Validate
 
 	logger *slog.Logger
 }
 
-func NewHandler(actorService domain.ActorService, filmService domain.FilmService, authService authservice.AuthService, validator *validator.Validate, logsBuilder *logs.Logs) *Handler {
+func NewHandler(actorService domain.ActorService, filmService domain.FilmService, genreService domain.GenreService, reviewService domain.ReviewService, filmListService domain.FilmListService, auditService domain.AuditService, filmImportService domain.FilmImportService, trashService domain.TrashService, filmRevisionService domain.FilmRevisionService, actorRevisionService domain.ActorRevisionService, authService authservice.AuthService, userService user.UserService, rolePermissions user.RolePermissions, validator *validator.Validate, logsBuilder *logs.Logs) *Handler {
 	logger := logsBuilder.WithName("handler")
 	return &Handler{
-		actorService: actorService,
-		filmService:  filmService,
-		authService:  authService,
-		validator:    validator,
-		logger:       logger,
+		actorService:         actorService,
+		filmService:          filmService,
+		genreService:         genreService,
+		reviewService:        reviewService,
+		filmListService:      filmListService,
+		auditService:         auditService,
+		filmImportService:    filmImportService,
+		trashService:         trashService,
+		filmRevisionService:  filmRevisionService,
+		actorRevisionService: actorRevisionService,
+		authService:          authService,
+		userService:          userService,
+		rolePermissions:      rolePermissions,
+		validator:            validator,
+		logger:               logger,
 	}
 }
 
 func (h *Handler) InitRoutes(mux *http.ServeMux) *http.ServeMux {
+	// ====== Auth routes ======
+
+	mux.Handle("POST /api/v1/auth/login", h.LoginHandler())
+	mux.Handle("POST /api/v1/auth/refresh", h.RefreshTokensHandler())
+	mux.Handle("POST /api/v1/auth/logout", h.LogoutHandler())
+
+	// ====== End of Auth routes ======
+
+	// ====== Users routes ======
+
+	mux.Handle("POST /api/v1/users", h.RegisterUserHandler())
+	mux.Handle("GET /api/v1/users", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionUsersManage, h.ListUsersHandler())))
+	mux.Handle("GET /api/v1/users/me", auth.AuthenticationMiddleware(h.authService, h.GetCurrentUserHandler()))
+	mux.Handle("PATCH /api/v1/users/me", auth.AuthenticationMiddleware(h.authService, h.UpdateCurrentUserHandler()))
+	mux.Handle("PATCH /api/v1/users/{id}/role", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionUsersManage, h.UpdateUserRoleHandler())))
+	mux.Handle("POST /api/v1/users/{id}/disable", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionUsersManage, h.DisableUserHandler())))
+	mux.Handle("PUT /api/v1/users/{id}/password", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionUsersManage, h.ResetUserPasswordHandler())))
+
+	mux.Handle("GET /api/v1/users/me/watchlist", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionFilmsRead, h.ListUserFilmsHandler(domain.FilmListWatchlist))))
+	mux.Handle("POST /api/v1/users/me/watchlist", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionFilmsRead, h.AddUserFilmHandler(domain.FilmListWatchlist))))
+	mux.Handle("DELETE /api/v1/users/me/watchlist/{id}", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionFilmsRead, h.RemoveUserFilmHandler(domain.FilmListWatchlist))))
+	mux.Handle("GET /api/v1/users/me/watched", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionFilmsRead, h.ListUserFilmsHandler(domain.FilmListWatched))))
+	mux.Handle("POST /api/v1/users/me/watched", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionFilmsRead, h.AddUserFilmHandler(domain.FilmListWatched))))
+	mux.Handle("DELETE /api/v1/users/me/watched/{id}", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionFilmsRead, h.RemoveUserFilmHandler(domain.FilmListWatched))))
+
+	// ====== End of Users routes ======
+
 	// ====== Actors routes ======
 
-	mux.Handle("POST /api/v1/actors", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware([]user.UserRole{user.RoleAdmin}, h.CreateActorHandler())))
-	mux.Handle("PATCH /api/v1/actors/{id}", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware([]user.UserRole{user.RoleAdmin}, h.UpdateActorHandler())))
-	mux.Handle("DELETE /api/v1/actors/{id}", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware([]user.UserRole{user.RoleAdmin}, h.DeleteActorHandler())))
-	mux.Handle("GET /api/v1/actors", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware([]user.UserRole{user.RoleUser, user.RoleAdmin}, h.ListActorsHandler())))
+	mux.Handle("POST /api/v1/actors", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionActorsWrite, h.CreateActorHandler())))
+	mux.Handle("PATCH /api/v1/actors/{id}", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionActorsWrite, h.UpdateActorHandler())))
+	mux.Handle("DELETE /api/v1/actors/{id}", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionActorsDelete, h.DeleteActorHandler())))
+	mux.Handle("GET /api/v1/actors", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionActorsRead, h.ListActorsHandler())))
+	mux.Handle("GET /api/v1/actors/searches", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionActorsRead, h.ListActorsHandler())))
+	mux.Handle("GET /api/v1/actors/{id}", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionActorsRead, h.GetActorHandler())))
+	mux.Handle("POST /api/v1/actors:batch", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionActorsWrite, h.BatchActorsHandler())))
 
 	// ====== End of Actors routes ======
 
 	// ====== Films routes ======
 
-	mux.Handle("POST /api/v1/films", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware([]user.UserRole{user.RoleAdmin}, h.CreateFilmHandler())))
-	mux.Handle("PATCH /api/v1/films/{id}", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware([]user.UserRole{user.RoleAdmin}, h.UpdateFilmHandler())))
-	mux.Handle("DELETE /api/v1/films/{id}", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware([]user.UserRole{user.RoleAdmin}, h.DeleteFilmHandler())))
-	mux.Handle("GET /api/v1/films", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware([]user.UserRole{user.RoleUser, user.RoleAdmin}, h.ListFilmsHandler())))
-	mux.Handle("GET /api/v1/films/searches", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware([]user.UserRole{user.RoleUser, user.RoleAdmin}, h.SearchFilmsHandler())))
+	mux.Handle("POST /api/v1/films", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionFilmsWrite, h.CreateFilmHandler())))
+	mux.Handle("PATCH /api/v1/films/{id}", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionFilmsWrite, h.UpdateFilmHandler())))
+	mux.Handle("DELETE /api/v1/films/{id}", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionFilmsDelete, h.DeleteFilmHandler())))
+	mux.Handle("GET /api/v1/films", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionFilmsRead, h.ListFilmsHandler())))
+	mux.Handle("GET /api/v1/films/{id}", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionFilmsRead, h.GetFilmHandler())))
+	mux.Handle("GET /api/v1/films/{id}/credits", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionFilmsRead, h.GetFilmCreditsHandler())))
+	mux.Handle("GET /api/v1/films/searches", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionFilmsRead, h.ListFilmsHandler())))
+	mux.Handle("POST /api/v1/films:batch", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionFilmsWrite, h.BatchFilmsHandler())))
 
 	// ====== End of Films routes ======
 
+	// ====== Genres routes ======
+
+	mux.Handle("POST /api/v1/genres", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionGenresWrite, h.CreateGenreHandler())))
+	mux.Handle("PATCH /api/v1/genres/{id}", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionGenresWrite, h.UpdateGenreHandler())))
+	mux.Handle("DELETE /api/v1/genres/{id}", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionGenresDelete, h.DeleteGenreHandler())))
+	mux.Handle("GET /api/v1/genres", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionGenresRead, h.ListGenresHandler())))
+	mux.Handle("GET /api/v1/genres/{id}", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionGenresRead, h.GetGenreHandler())))
+
+	// ====== End of Genres routes ======
+
+	// ====== Reviews routes ======
+
+	mux.Handle("POST /api/v1/films/{id}/reviews", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionReviewsWrite, h.CreateReviewHandler())))
+	mux.Handle("GET /api/v1/films/{id}/reviews", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionFilmsRead, h.ListFilmReviewsHandler())))
+	mux.Handle("PATCH /api/v1/reviews/{id}", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionReviewsWrite, h.UpdateReviewHandler())))
+	mux.Handle("DELETE /api/v1/reviews/{id}", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionReviewsWrite, h.DeleteReviewHandler())))
+
+	// ====== End of Reviews routes ======
+
+	// ====== Audit routes ======
+
+	mux.Handle("GET /api/v1/audit", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionAuditRead, h.ListAuditEventsHandler())))
+
+	// ====== End of Audit routes ======
+
+	// ====== Imports routes ======
+
+	mux.Handle("POST /api/v1/imports", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionCatalogImport, h.ImportFilmsHandler())))
+
+	// ====== End of Imports routes ======
+
+	// ====== Exports routes ======
+
+	mux.Handle("GET /api/v1/exports/films", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionCatalogExport, h.ExportFilmsHandler())))
+	mux.Handle("GET /api/v1/exports/actors", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionCatalogExport, h.ExportActorsHandler())))
+
+	// ====== End of Exports routes ======
+
+	// ====== Trash routes ======
+
+	mux.Handle("GET /api/v1/trash", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionTrashManage, h.ListTrashHandler())))
+	mux.Handle("POST /api/v1/films/{id}", customMethodRoute("id", restoreMethod, ErrMessageTrashUnknownMethod, auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionTrashManage, h.RestoreFilmHandler()))))
+	mux.Handle("POST /api/v1/actors/{id}", customMethodRoute("id", restoreMethod, ErrMessageTrashUnknownMethod, auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionTrashManage, h.RestoreActorHandler()))))
+
+	// ====== End of Trash routes ======
+
+	// ====== Revisions routes ======
+
+	mux.Handle("GET /api/v1/films/{id}/revisions", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionFilmsRead, h.ListFilmRevisionsHandler())))
+	mux.Handle("GET /api/v1/films/{id}/revisions/diff", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionFilmsRead, h.DiffFilmRevisionsHandler())))
+	mux.Handle("POST /api/v1/films/{id}/revisions/{revision}", customMethodRoute("revision", revertMethod, ErrMessageRevisionUnknownMethod, auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionFilmsWrite, h.RevertFilmHandler()))))
+	mux.Handle("GET /api/v1/actors/{id}/revisions", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionActorsRead, h.ListActorRevisionsHandler())))
+	mux.Handle("GET /api/v1/actors/{id}/revisions/diff", auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionActorsRead, h.DiffActorRevisionsHandler())))
+	mux.Handle("POST /api/v1/actors/{id}/revisions/{revision}", customMethodRoute("revision", revertMethod, ErrMessageRevisionUnknownMethod, auth.AuthenticationMiddleware(h.authService, auth.AuthorizationMiddleware(h.rolePermissions, user.PermissionActorsWrite, h.RevertActorHandler()))))
+
+	// ====== End of Revisions routes ======
+
 	// ====== Swagger route ======
 
 	mux.Handle("GET /swagger/", httpSwagger.Handler(
@@ -0,0 +1,144 @@
+package http
+
+import (
+	"encoding/json"
+	"github.com/vaberof/vk-internship-task/internal/app/catalog"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+const maxImportFilmsBodySize = 32 << 20
+
+type importFilmsResponseBody struct {
+	DryRun        bool    `json:"dry_run"`
+	Committed     bool    `json:"committed"`
+	Films         int     `json:"films"`
+	FilmIds       []int64 `json:"film_ids,omitempty"`
+	CreatedActors int     `json:"created_actors"`
+	MatchedActors int     `json:"matched_actors"`
+}
+
+type importRowError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// @Summary		Import films with embedded actors from CSV or NDJSON
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			imports
+// @Description	Import films in bulk. Every film is validated with the same rules as in 'POST /films', but actors are embedded instead of 'actor_ids'.
+// @Description	Actors are matched with the existing ones by name and birth date, the unmatched actors are created.
+// @Description	The format is taken from 'format' or from 'Content-Type' ('text/csv', 'application/x-ndjson').
+// @Description	NDJSON lines are like `{"title":"Title","release_date":"2006-01-02","rating":8,"actors":[{"name":"Name","sex":1,"birthdate":"1970-01-02"}],"genre_ids":[1]}`.
+// @Description	CSV has a header with the columns 'title', 'description', 'release_date', 'rating', 'actors', 'genre_ids', actors are like `Name|1|1970-01-02;Name 2|2|1980-01-02`, genre ids are separated by ';'.
+// @Description	All films are imported in a single transaction: if any row fails, nothing is imported and errors of all rows are returned with their line numbers
+// @ID				import-films
+// @Accept			text/csv,application/x-ndjson
+// @Produce		json
+// @Param			format	query		string	false	"An optional query parameter 'format'. Allowed values: 'csv', 'ndjson'"
+// @Param			dry-run	query		boolean	false	"An optional query parameter 'dry-run'. If 'true', films are checked, but not imported. By default 'dry-run' = false"
+// @Param			input	body		string	true	"Films in CSV or NDJSON"
+// @Success		200		{object}	importFilmsResponseBody
+// @Failure		400		{object}	apiv1.Response
+// @Failure		401		{object}	apiv1.Response
+// @Failure		403		{object}	apiv1.Response
+// @Failure		500		{object}	apiv1.Response
+// @Router			/imports [post]
+func (h *Handler) ImportFilmsHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "ImportFilmsHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		var format catalog.Format
+		var err error
+
+		if formatStr := request.URL.Query().Get("format"); formatStr != "" {
+			format, err = catalog.ParseFormat(formatStr)
+			if err != nil {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageImportInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+
+				return
+			}
+		} else {
+			var ok bool
+			format, ok = catalog.FormatFromMediaType(request.Header.Get("Content-Type"))
+			if !ok {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageImportInvalidRequestBody, apiv1.ErrorDescription{"error": "format must be set by 'format' parameter or 'Content-Type' header"}))
+
+				return
+			}
+		}
+
+		var dryRun bool
+
+		dryRunStr := request.URL.Query().Get("dry-run")
+		if dryRunStr != "" {
+			dryRun, err = strconv.ParseBool(dryRunStr)
+			if err != nil {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageImportInvalidRequestBody, apiv1.ErrorDescription{"error": "'dry-run' must be a boolean"}))
+
+				return
+			}
+		}
+
+		body := http.MaxBytesReader(rw, request.Body, maxImportFilmsBodySize)
+
+		rows, rowErrors, err := catalog.DecodeFilms(body, format, h.validator)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageImportInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+
+			return
+		}
+
+		report, err := h.filmImportService.Import(request.Context(), rows, rowErrors, dryRun)
+		if err != nil {
+			log.Error("failed to import films", "error", err.Error())
+
+			views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageImportInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+
+			return
+		}
+
+		if len(report.Errors) > 0 {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageImportInvalidRows, apiv1.ErrorDescription{"rows": buildImportRowErrors(report.Errors)}))
+
+			return
+		}
+
+		payload, _ := json.Marshal(&importFilmsResponseBody{
+			DryRun:        report.DryRun,
+			Committed:     report.Committed,
+			Films:         report.Films,
+			FilmIds:       buildFilmIds(report.FilmIds),
+			CreatedActors: report.CreatedActors,
+			MatchedActors: report.MatchedActors,
+		})
+
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
+
+func buildImportRowErrors(domainRowErrors []*domain.FilmImportRowError) []*importRowError {
+	rowErrors := make([]*importRowError, len(domainRowErrors))
+	for i, domainRowError := range domainRowErrors {
+		rowErrors[i] = &importRowError{
+			Line:  domainRowError.Line,
+			Error: domainRowError.Message,
+		}
+	}
+	return rowErrors
+}
+
+func buildFilmIds(domainFilmIds []domain.FilmId) []int64 {
+	filmIds := make([]int64, len(domainFilmIds))
+	for i := range domainFilmIds {
+		filmIds[i] = domainFilmIds[i].Int64()
+	}
+	return filmIds
+}
@@ -2,11 +2,16 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/domain"
 	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 )
 
 const (
@@ -15,23 +20,36 @@ const (
 )
 
 type listActorsResponseBody struct {
-	Actors []*actor `json:"actors"`
+	Actors     []*actor `json:"actors"`
+	NextCursor string   `json:"next_cursor,omitempty"`
+	Total      *int64   `json:"total,omitempty"`
 }
 
-// @Summary		List all actors with optional query parameters 'limit' and 'offset'
+// @Summary		List and search actors with optional filters and 'sort', 'limit', 'offset', 'cursor', 'total' query parameters
+// @Security		BearerAuth
 // @Security		BasicAuth
 // @Tags			actors
-// @Description	List all actors with optional query parameters 'limit' and 'offset'
-// @ID				list-actors
+// @Description	List all actors with the possibility of sorting via 'sort' parameter by 'id' and/or 'name' and/or 'birth-date' and/or 'films-count' and/or with 'limit' and/or 'offset' parameters.
+// @Description	Actors are sorted by the fields in the order they are listed in 'sort'. Filters are combined with AND.
+// @Description	If there are more actors, 'next_cursor' is returned, which can be passed as 'cursor' to get the next page
 // @Produce		json
-// @Param			limit	query		integer	false	"An optional query parameter 'limit' that limits total number of returned actors. By default 'limit' = 100"
-// @Param			offset	query		integer	false	"An optional query parameter 'offset' that indicates how many records should be skipped while listing actors. By default 'offset' = 0"
-// @Success		200		{object}	listActorsResponseBody
-// @Failure		400		{object}	apiv1.Response
-// @Failure		401		{object}	apiv1.Response
-// @Failure		403		{object}	apiv1.Response
-// @Failure		500		{object}	apiv1.Response
+// @Param			sort			query		string	false	"An optional query parameter 'sort' that indicates how actors should be sorted. By default 'sort' = 'id:asc'. Expected as `name:asc,birth-date:desc` in any order of necessary parameters. Allowed fields: 'id', 'name', 'birth-date', 'films-count'. Allowed directions: 'asc', 'desc'"
+// @Param			name			query		string	false	"An optional query parameter 'name' with a case-insensitive fragment of the actor name"
+// @Param			sex				query		integer	false	"An optional query parameter 'sex'. If set, only actors of this sex are listed. Allowed values: 0, 1, 2, 9"
+// @Param			birth-date-from	query		string	false	"An optional query parameter 'birth-date-from' in 'YYYY-MM-DD' format. Actors born on this date are included"
+// @Param			birth-date-to	query		string	false	"An optional query parameter 'birth-date-to' in 'YYYY-MM-DD' format. Actors born on this date are included"
+// @Param			film			query		integer	false	"An optional query parameter 'film' with a film id. If set, only actors who appeared in this film are listed"
+// @Param			limit			query		integer	false	"An optional query parameter 'limit' that limits total number of returned actors. By default 'limit' = 100"
+// @Param			offset			query		integer	false	"An optional query parameter 'offset' that indicates how many records should be skipped while listing actors. By default 'offset' = 0"
+// @Param			cursor			query		string	false	"An optional query parameter 'cursor' with the value of 'next_cursor' from the previous page. If set, 'offset' is counted from the cursor position. A cursor is valid only with the same 'sort' and filters"
+// @Param			total			query		boolean	false	"An optional query parameter 'total' that indicates whether total number of actors should be returned. By default 'total' = false"
+// @Success		200				{object}	listActorsResponseBody
+// @Failure		400				{object}	apiv1.Response
+// @Failure		401				{object}	apiv1.Response
+// @Failure		403				{object}	apiv1.Response
+// @Failure		500				{object}	apiv1.Response
 // @Router			/actors [get]
+// @Router			/actors/searches [get]
 func (h *Handler) ListActorsHandler() http.HandlerFunc {
 	return func(rw http.ResponseWriter, request *http.Request) {
 		const handlerName = "ListActorsHandler"
@@ -47,15 +65,8 @@ func (h *Handler) ListActorsHandler() http.HandlerFunc {
 			limit = defaultListActorsLimit
 		} else {
 			limit, err = strconv.Atoi(limitStr)
-			if err != nil {
-				log.Error("failed to convert 'limit' parameter", "limit", limitStr, "error", err.Error())
-
-				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageActorInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
-
-				return
-			}
-			if limit < 0 {
-				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageActorInvalidRequestBody, apiv1.ErrorDescription{"error": "'limit' must not be negative"}))
+			if err != nil || limit < 0 {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageActorInvalidRequestBody, apiv1.ErrorDescription{"error": "'limit' must be a non-negative integer"}))
 
 				return
 			}
@@ -67,33 +78,159 @@ func (h *Handler) ListActorsHandler() http.HandlerFunc {
 			offset = defaultListOffset
 		} else {
 			offset, err = strconv.Atoi(offsetStr)
-			if err != nil {
-				log.Error("failed to convert 'offset' parameter", "offset", offsetStr, "error", err.Error())
+			if err != nil || offset < 0 {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageActorInvalidRequestBody, apiv1.ErrorDescription{"error": "'offset' must be a non-negative integer"}))
 
-				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageActorInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+				return
+			}
+		}
+
+		sort, err := getActorSort(request.URL.Query().Get("sort"))
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageActorInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+
+			return
+		}
+
+		filter, err := getActorFilter(request)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageActorInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+
+			return
+		}
+
+		var cursor *domain.ActorCursor
+
+		cursorStr := request.URL.Query().Get("cursor")
+		if cursorStr != "" {
+			cursor, err = decodeActorCursor(cursorStr, sort, getActorFilterHash(request))
+			if err != nil {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageActorInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
 
 				return
 			}
-			if offset < 0 {
-				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageActorInvalidRequestBody, apiv1.ErrorDescription{"error": "'offset' must not be negative"}))
+		}
+
+		var withTotal bool
+
+		totalStr := request.URL.Query().Get("total")
+		if totalStr != "" {
+			withTotal, err = strconv.ParseBool(totalStr)
+			if err != nil {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageActorInvalidRequestBody, apiv1.ErrorDescription{"error": "'total' must be a boolean"}))
 
 				return
 			}
 		}
 
-		domainActors, err := h.actorService.List(limit, offset)
+		domainActorsPage, err := h.actorService.List(request.Context(), filter, sort, cursor, withTotal, limit, offset)
 		if err != nil {
-			log.Error("failed to list actors", "error", err.Error())
+			if errors.Is(err, domain.ErrInvalidActorFilter) {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageActorInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to list actors", "error", err.Error())
 
-			views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageActorInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageActorInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
 
 			return
 		}
 
 		payload, _ := json.Marshal(&listActorsResponseBody{
-			Actors: buildActors(domainActors),
+			Actors:     buildActors(domainActorsPage.Actors),
+			NextCursor: encodeActorCursor(domainActorsPage.NextCursor, sort, getActorFilterHash(request)),
+			Total:      domainActorsPage.Total,
 		})
 
 		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
 	}
 }
+
+func getActorSort(sortParams string) (domain.ActorSort, error) {
+	if sortParams == "" {
+		return nil, nil
+	}
+
+	// sortParams expected as 'name:asc,birth-date:desc'
+
+	splitSortParams := strings.Split(sortParams, ",")
+
+	sortKeys := make([]domain.ActorSortKey, 0, len(splitSortParams))
+
+	for _, param := range splitSortParams {
+
+		// Expect parameter like 'name:asc'
+		paramWithSortOrder := strings.Split(param, ":")
+
+		if len(paramWithSortOrder) != 2 {
+			return nil, errors.New("invalid sort parameters. Must be like 'name:asc,birth-date:desc'")
+		}
+
+		sortKeys = append(sortKeys, domain.ActorSortKey{
+			Field:     domain.ActorSortField(paramWithSortOrder[0]),
+			Direction: domain.SortDirection(strings.ToLower(paramWithSortOrder[1])),
+		})
+	}
+
+	return domain.NewActorSort(sortKeys...)
+}
+
+const (
+	filterParamName          = "name"
+	filterParamSex           = "sex"
+	filterParamBirthDateFrom = "birth-date-from"
+	filterParamBirthDateTo   = "birth-date-to"
+	filterParamFilm          = "film"
+)
+
+// getActorFilterHash returns a hash of the actor filter parameters of the request for the cursors of its pages
+func getActorFilterHash(request *http.Request) string {
+	return hashCursorFilter(getQueryParams(request,
+		filterParamName, filterParamSex, filterParamBirthDateFrom, filterParamBirthDateTo, filterParamFilm)...)
+}
+
+func getActorFilter(request *http.Request) (*domain.ActorFilter, error) {
+	query := request.URL.Query()
+
+	filter := domain.ActorFilter{
+		Name: domain.ActorName(query.Get(filterParamName)),
+	}
+
+	if sexStr := query.Get(filterParamSex); sexStr != "" {
+		sex, err := strconv.ParseUint(sexStr, 10, 8)
+		if err != nil || (sex != 0 && sex != 1 && sex != 2 && sex != 9) {
+			return nil, fmt.Errorf("'%s' must be one of 0, 1, 2, 9", filterParamSex)
+		}
+		domainSex := domain.ActorSex(sex)
+		filter.Sex = &domainSex
+	}
+
+	if birthDateFromStr := query.Get(filterParamBirthDateFrom); birthDateFromStr != "" {
+		birthDateFrom, err := time.Parse(time.DateOnly, birthDateFromStr)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' must be in 'YYYY-MM-DD' format", filterParamBirthDateFrom)
+		}
+		domainBirthDateFrom := domain.ActorBirthDate(birthDateFrom)
+		filter.BornFrom = &domainBirthDateFrom
+	}
+
+	if birthDateToStr := query.Get(filterParamBirthDateTo); birthDateToStr != "" {
+		birthDateTo, err := time.Parse(time.DateOnly, birthDateToStr)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' must be in 'YYYY-MM-DD' format", filterParamBirthDateTo)
+		}
+		domainBirthDateTo := domain.ActorBirthDate(birthDateTo)
+		filter.BornTo = &domainBirthDateTo
+	}
+
+	if filmIdStr := query.Get(filterParamFilm); filmIdStr != "" {
+		filmId, err := strconv.ParseInt(filmIdStr, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' must be an integer", filterParamFilm)
+		}
+		domainFilmId := domain.FilmId(filmId)
+		filter.FilmId = &domainFilmId
+	}
+
+	return &filter, nil
+}
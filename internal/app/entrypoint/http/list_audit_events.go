@@ -0,0 +1,151 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultListAuditEventsLimit  = 100
+	defaultListAuditEventsOffset = 0
+)
+
+type listAuditEventsResponseBody struct {
+	Events []*auditEvent `json:"events"`
+	Total  int64         `json:"total"`
+}
+
+// @Summary		List audit events of films and actors mutations with optional filters
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			audit
+// @Description	List audit events from the newest to the oldest. Every event holds the user who made the change, the operation and JSON snapshots of the entity before and after the change
+// @ID				list-audit-events
+// @Produce		json
+// @Param			entity_type	query		string	false	"An optional query parameter 'entity_type'. Allowed values: 'film', 'actor'"
+// @Param			entity_id	query		integer	false	"An optional query parameter 'entity_id'"
+// @Param			user_id		query		integer	false	"An optional query parameter 'user_id' of the user who made the change"
+// @Param			from		query		string	false	"An optional query parameter 'from' in RFC 3339 format, e.g. '2024-03-01T00:00:00Z'. Events made at 'from' are included"
+// @Param			to			query		string	false	"An optional query parameter 'to' in RFC 3339 format, e.g. '2024-04-01T00:00:00Z'. Events made at 'to' are excluded"
+// @Param			limit		query		integer	false	"An optional query parameter 'limit' that limits total number of returned events. By default 'limit' = 100"
+// @Param			offset		query		integer	false	"An optional query parameter 'offset' that indicates how many records should be skipped while listing events. By default 'offset' = 0"
+// @Success		200			{object}	listAuditEventsResponseBody
+// @Failure		400			{object}	apiv1.Response
+// @Failure		401			{object}	apiv1.Response
+// @Failure		403			{object}	apiv1.Response
+// @Failure		500			{object}	apiv1.Response
+// @Router			/audit [get]
+func (h *Handler) ListAuditEventsHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "ListAuditEventsHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		var limit, offset int
+		var err error
+
+		limitStr := request.URL.Query().Get("limit")
+
+		if limitStr == "" {
+			limit = defaultListAuditEventsLimit
+		} else {
+			limit, err = strconv.Atoi(limitStr)
+			if err != nil || limit < 0 {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageAuditInvalidRequestBody, apiv1.ErrorDescription{"error": "'limit' must be a non-negative integer"}))
+
+				return
+			}
+		}
+
+		offsetStr := request.URL.Query().Get("offset")
+
+		if offsetStr == "" {
+			offset = defaultListAuditEventsOffset
+		} else {
+			offset, err = strconv.Atoi(offsetStr)
+			if err != nil || offset < 0 {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageAuditInvalidRequestBody, apiv1.ErrorDescription{"error": "'offset' must be a non-negative integer"}))
+
+				return
+			}
+		}
+
+		filter, err := getAuditFilter(request.URL.Query())
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageAuditInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+
+			return
+		}
+
+		auditEventsPage, err := h.auditService.List(request.Context(), filter, limit, offset)
+		if err != nil {
+			if errors.Is(err, domain.ErrInvalidAuditFilter) {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageAuditInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to list audit events", "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageAuditInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(&listAuditEventsResponseBody{
+			Events: buildAuditEvents(auditEventsPage.Events),
+			Total:  auditEventsPage.Total,
+		})
+
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
+
+func getAuditFilter(query url.Values) (*domain.AuditFilter, error) {
+	var filter domain.AuditFilter
+
+	if entityTypeStr := query.Get("entity_type"); entityTypeStr != "" {
+		entityType := domain.AuditEntityType(entityTypeStr)
+		filter.EntityType = &entityType
+	}
+
+	if entityIdStr := query.Get("entity_id"); entityIdStr != "" {
+		entityId, err := strconv.ParseInt(entityIdStr, 10, 64)
+		if err != nil {
+			return nil, errors.New("'entity_id' must be an integer")
+		}
+		filter.EntityId = &entityId
+	}
+
+	if userIdStr := query.Get("user_id"); userIdStr != "" {
+		userId, err := strconv.ParseInt(userIdStr, 10, 64)
+		if err != nil {
+			return nil, errors.New("'user_id' must be an integer")
+		}
+		filter.UserId = &userId
+	}
+
+	if fromStr := query.Get("from"); fromStr != "" {
+		from, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			return nil, errors.New("'from' must be in RFC 3339 format, e.g. '2024-03-01T00:00:00Z'")
+		}
+		filter.From = &from
+	}
+
+	if toStr := query.Get("to"); toStr != "" {
+		to, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			return nil, errors.New("'to' must be in RFC 3339 format, e.g. '2024-04-01T00:00:00Z'")
+		}
+		filter.To = &to
+	}
+
+	return &filter, nil
+}
@@ -0,0 +1,103 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+const (
+	defaultListReviewsLimit  = 20
+	defaultListReviewsOffset = 0
+)
+
+type listFilmReviewsResponseBody struct {
+	Reviews []*review `json:"reviews"`
+	Total   int64     `json:"total"`
+}
+
+// @Summary		List reviews of a film by path parameter 'id' with optional query parameters 'limit' and 'offset'
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			reviews
+// @Description	List reviews of a film starting with the latest ones with optional query parameters 'limit' and 'offset'
+// @ID				list-film-reviews
+// @Produce		json
+// @Param			id		path		integer	true	"Film`s id whose reviews need to be listed"
+// @Param			limit	query		integer	false	"An optional query parameter 'limit' that limits total number of returned reviews. By default 'limit' = 20"
+// @Param			offset	query		integer	false	"An optional query parameter 'offset' that indicates how many records should be skipped while listing reviews. By default 'offset' = 0"
+// @Success		200		{object}	listFilmReviewsResponseBody
+// @Failure		400		{object}	apiv1.Response
+// @Failure		401		{object}	apiv1.Response
+// @Failure		403		{object}	apiv1.Response
+// @Failure		404		{object}	apiv1.Response
+// @Failure		500		{object}	apiv1.Response
+// @Router			/films/{id}/reviews [get]
+func (h *Handler) ListFilmReviewsHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "ListFilmReviewsHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		filmIdPathParam := request.PathValue("id")
+		filmId, err := strconv.ParseInt(filmIdPathParam, 10, 64)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageReviewInvalidRequestBody, apiv1.ErrorDescription{"error": "path parameter 'id' must be an integer"}))
+
+			return
+		}
+
+		var limit, offset int
+
+		limitStr := request.URL.Query().Get("limit")
+
+		if limitStr == "" {
+			limit = defaultListReviewsLimit
+		} else {
+			limit, err = strconv.Atoi(limitStr)
+			if err != nil || limit < 0 {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageReviewInvalidRequestBody, apiv1.ErrorDescription{"error": "'limit' must be a non-negative integer"}))
+
+				return
+			}
+		}
+
+		offsetStr := request.URL.Query().Get("offset")
+
+		if offsetStr == "" {
+			offset = defaultListReviewsOffset
+		} else {
+			offset, err = strconv.Atoi(offsetStr)
+			if err != nil || offset < 0 {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageReviewInvalidRequestBody, apiv1.ErrorDescription{"error": "'offset' must be a non-negative integer"}))
+
+				return
+			}
+		}
+
+		reviewsPage, err := h.reviewService.ListByFilmId(request.Context(), domain.FilmId(filmId), limit, offset)
+		if err != nil {
+			if errors.Is(err, domain.ErrFilmNotFound) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageFilmNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to list film reviews", "filmId", filmId, "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageReviewInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(&listFilmReviewsResponseBody{
+			Reviews: buildReviews(reviewsPage.Reviews),
+			Total:   reviewsPage.Total,
+		})
+
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
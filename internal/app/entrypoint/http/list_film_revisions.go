@@ -0,0 +1,103 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+const (
+	defaultListRevisionsLimit  = 20
+	defaultListRevisionsOffset = 0
+)
+
+type listFilmRevisionsResponseBody struct {
+	Revisions []*filmRevision `json:"revisions"`
+	Total     int64           `json:"total"`
+}
+
+// @Summary		List revisions of a film by path parameter 'id' with optional query parameters 'limit' and 'offset'
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			revisions
+// @Description	List the history of changes of a film starting with the latest revision. A revision is the state of the film after a change and is numbered by the version the change has given to the film
+// @ID				list-film-revisions
+// @Produce		json
+// @Param			id		path		integer	true	"Film`s id whose revisions need to be listed"
+// @Param			limit	query		integer	false	"An optional query parameter 'limit' that limits total number of returned revisions. By default 'limit' = 20"
+// @Param			offset	query		integer	false	"An optional query parameter 'offset' that indicates how many records should be skipped while listing revisions. By default 'offset' = 0"
+// @Success		200		{object}	listFilmRevisionsResponseBody
+// @Failure		400		{object}	apiv1.Response
+// @Failure		401		{object}	apiv1.Response
+// @Failure		403		{object}	apiv1.Response
+// @Failure		404		{object}	apiv1.Response
+// @Failure		500		{object}	apiv1.Response
+// @Router			/films/{id}/revisions [get]
+func (h *Handler) ListFilmRevisionsHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "ListFilmRevisionsHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		filmIdPathParam := request.PathValue("id")
+		filmId, err := strconv.ParseInt(filmIdPathParam, 10, 64)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageRevisionInvalidRequestBody, apiv1.ErrorDescription{"error": "path parameter 'id' must be an integer"}))
+
+			return
+		}
+
+		var limit, offset int
+
+		limitStr := request.URL.Query().Get("limit")
+
+		if limitStr == "" {
+			limit = defaultListRevisionsLimit
+		} else {
+			limit, err = strconv.Atoi(limitStr)
+			if err != nil || limit < 0 {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageRevisionInvalidRequestBody, apiv1.ErrorDescription{"error": "'limit' must be a non-negative integer"}))
+
+				return
+			}
+		}
+
+		offsetStr := request.URL.Query().Get("offset")
+
+		if offsetStr == "" {
+			offset = defaultListRevisionsOffset
+		} else {
+			offset, err = strconv.Atoi(offsetStr)
+			if err != nil || offset < 0 {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageRevisionInvalidRequestBody, apiv1.ErrorDescription{"error": "'offset' must be a non-negative integer"}))
+
+				return
+			}
+		}
+
+		revisionsPage, err := h.filmRevisionService.List(request.Context(), domain.FilmId(filmId), limit, offset)
+		if err != nil {
+			if errors.Is(err, domain.ErrFilmNotFound) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageFilmNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to list film revisions", "filmId", filmId, "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageRevisionInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(&listFilmRevisionsResponseBody{
+			Revisions: buildFilmRevisions(revisionsPage.Revisions),
+			Total:     revisionsPage.Total,
+		})
+
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
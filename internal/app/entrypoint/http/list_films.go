@@ -5,11 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/domain"
 	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
 	"log/slog"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -17,31 +19,47 @@ const (
 	defaultListFilmsOffset = 0
 )
 
-const (
-	sortParamTitle       = "title"
-	sortParamReleaseDate = "release-date"
-	sortParamRating      = "rating"
-)
-
 type listFilmsResponseBody struct {
-	Films []*film `json:"films"`
+	Films      []*film `json:"films"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+	Total      *int64  `json:"total,omitempty"`
 }
 
-// @Summary		List all films with optional 'sort', 'limit', 'offset' query parameters
+// @Summary		List and search films with optional filters and 'sort', 'limit', 'offset', 'cursor', 'total' query parameters
+// @Security		BearerAuth
 // @Security		BasicAuth
 // @Tags			films
-// @Description	List all films with the possibility of sorting via 'sort' parameter by 'title' and/or 'rating' and/or 'release-date' and/or with 'limit' and/or 'offset' parameters
-// @ID				list-films
+// @Description	List all films with the possibility of sorting via 'sort' parameter by 'id' and/or 'title' and/or 'release-date' and/or 'rating' and/or 'actors-count' and/or 'community-score' and/or with 'limit' and/or 'offset' parameters.
+// @Description	Films are sorted by the fields in the order they are listed in 'sort'
+// @Description	Filters are combined with AND. With a free text query 'q' and without 'sort' films are ordered by relevance,
+// @Description	every found film has 'relevance' and 'highlights' with the matched words wrapped in '<b>' and '</b>'.
+// @Description	If there are more films, 'next_cursor' is returned, which can be passed as 'cursor' to get the next page
 // @Produce		json
-// @Param			sort	query		string	false	"An optional query parameter 'sort' that indicates how films should be sorted. By default 'sort' = 'rating:desc'. Expected as `title:asc,release-date:desc,rating:desc` in any order of necessary parameters"
-// @Param			limit	query		integer	false	"An optional query parameter 'limit' that limits total number of returned films. By default 'limit' = 100"
-// @Param			offset	query		integer	false	"An optional query parameter 'offset' that indicates how many records should be skipped while listing films. By default 'offset' = 0"
-// @Success		200		{object}	listFilmsResponseBody
-// @Failure		400		{object}	apiv1.Response
-// @Failure		401		{object}	apiv1.Response
-// @Failure		403		{object}	apiv1.Response
-// @Failure		500		{object}	apiv1.Response
+// @Param			sort				query		string	false	"An optional query parameter 'sort' that indicates how films should be sorted. By default 'sort' = 'rating:desc'. Expected as `title:asc,release-date:desc,rating:desc` in any order of necessary parameters. Allowed fields: 'id', 'title', 'release-date', 'rating', 'actors-count', 'community-score'. Allowed directions: 'asc', 'desc'"
+// @Param			q					query		string	false	"An optional query parameter 'q' with a free text query matched against titles, descriptions and names of film credits, tolerating typos. Quoted phrases, 'or' and '-' before excluded words are supported"
+// @Param			film-title			query		string	false	"An optional query parameter 'film-title' with a case-insensitive fragment of the film title"
+// @Param			actor-name			query		string	false	"An optional query parameter 'actor-name' with a case-insensitive fragment of a name of the film actor"
+// @Param			director-name		query		string	false	"An optional query parameter 'director-name' with a case-insensitive fragment of a name of the film director"
+// @Param			genre				query		integer	false	"An optional query parameter 'genre' with a genre id. If set, only films of this genre are listed"
+// @Param			release-date-from	query		string	false	"An optional query parameter 'release-date-from' in 'YYYY-MM-DD' format. Films released on this date are included"
+// @Param			release-date-to		query		string	false	"An optional query parameter 'release-date-to' in 'YYYY-MM-DD' format. Films released on this date are included"
+// @Param			rating-from			query		integer	false	"An optional query parameter 'rating-from' with the minimal film rating from 0 to 10"
+// @Param			rating-to			query		integer	false	"An optional query parameter 'rating-to' with the maximal film rating from 0 to 10"
+// @Param			actor-ids			query		string	false	"An optional query parameter 'actor-ids' with comma separated actor ids, e.g. '1,2,3'"
+// @Param			actor-ids-match		query		string	false	"An optional query parameter 'actor-ids-match' that indicates whether films with any ('any') or all ('all') of 'actor-ids' are listed. By default 'actor-ids-match' = 'any'"
+// @Param			actor-sex			query		integer	false	"An optional query parameter 'actor-sex'. If set, only films with an actor of this sex are listed. Allowed values: 0, 1, 2, 9"
+// @Param			no-actors			query		boolean	false	"An optional query parameter 'no-actors'. If 'true', only films without actors are listed"
+// @Param			limit				query		integer	false	"An optional query parameter 'limit' that limits total number of returned films. By default 'limit' = 100"
+// @Param			offset				query		integer	false	"An optional query parameter 'offset' that indicates how many records should be skipped while listing films. By default 'offset' = 0"
+// @Param			cursor				query		string	false	"An optional query parameter 'cursor' with the value of 'next_cursor' from the previous page. If set, 'offset' is counted from the cursor position. A cursor is valid only with the same 'sort' and filters"
+// @Param			total				query		boolean	false	"An optional query parameter 'total' that indicates whether total number of films should be returned. By default 'total' = false"
+// @Success		200					{object}	listFilmsResponseBody
+// @Failure		400					{object}	apiv1.Response
+// @Failure		401					{object}	apiv1.Response
+// @Failure		403					{object}	apiv1.Response
+// @Failure		500					{object}	apiv1.Response
 // @Router			/films [get]
+// @Router			/films/searches [get]
 func (h *Handler) ListFilmsHandler() http.HandlerFunc {
 	return func(rw http.ResponseWriter, request *http.Request) {
 		const handlerName = "ListFilmsHandler"
@@ -57,15 +75,8 @@ func (h *Handler) ListFilmsHandler() http.HandlerFunc {
 			limit = defaultListFilmsLimit
 		} else {
 			limit, err = strconv.Atoi(limitStr)
-			if err != nil {
-				log.Error("failed to convert 'limit' parameter", "limit", limitStr, "error", err.Error())
-
-				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageFilmInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
-
-				return
-			}
-			if limit < 0 {
-				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageFilmInvalidRequestBody, apiv1.ErrorDescription{"error": "'limit' must not be negative"}))
+			if err != nil || limit < 0 {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageFilmInvalidRequestBody, apiv1.ErrorDescription{"error": "'limit' must be a non-negative integer"}))
 
 				return
 			}
@@ -77,83 +88,208 @@ func (h *Handler) ListFilmsHandler() http.HandlerFunc {
 			offset = defaultListFilmsOffset
 		} else {
 			offset, err = strconv.Atoi(offsetStr)
-			if err != nil {
-				log.Error("failed to convert 'offset' parameter", "offset", offsetStr, "error", err.Error())
-
-				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageFilmInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
-
-				return
-			}
-			if offset < 0 {
-				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageFilmInvalidRequestBody, apiv1.ErrorDescription{"error": "'offset' must not be negative"}))
+			if err != nil || offset < 0 {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageFilmInvalidRequestBody, apiv1.ErrorDescription{"error": "'offset' must be a non-negative integer"}))
 
 				return
 			}
 		}
 
-		var titleOrder, releaseDateOrder, ratingOrder string
-
 		sortQueryParams := request.URL.Query().Get("sort")
 
-		titleOrder, releaseDateOrder, ratingOrder, err = getSortParams(sortQueryParams)
+		sort, err := getFilmSort(sortQueryParams)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageFilmInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+
+			return
+		}
+
+		filter, err := getFilmFilter(request)
 		if err != nil {
 			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageFilmInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
 
 			return
 		}
 
-		domainFilms, err := h.filmService.ListWithSort(titleOrder, releaseDateOrder, ratingOrder, limit, offset)
+		var cursor *domain.FilmCursor
+
+		cursorStr := request.URL.Query().Get("cursor")
+		if cursorStr != "" {
+			cursor, err = decodeFilmCursor(cursorStr, sort, getFilmFilterHash(request))
+			if err != nil {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageFilmInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+
+				return
+			}
+		}
+
+		var withTotal bool
+
+		totalStr := request.URL.Query().Get("total")
+		if totalStr != "" {
+			withTotal, err = strconv.ParseBool(totalStr)
+			if err != nil {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageFilmInvalidRequestBody, apiv1.ErrorDescription{"error": "'total' must be a boolean"}))
+
+				return
+			}
+		}
+
+		domainFilmsPage, err := h.filmService.List(request.Context(), filter, sort, cursor, withTotal, limit, offset)
 		if err != nil {
-			log.Error("failed to list films", "error", err.Error())
+			if errors.Is(err, domain.ErrInvalidFilmFilter) {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageFilmInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to list films", "error", err.Error())
 
-			views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageFilmInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageFilmInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
 
 			return
 		}
 
 		payload, _ := json.Marshal(&listFilmsResponseBody{
-			Films: buildFilms(domainFilms),
+			Films:      buildFilms(domainFilmsPage.Films),
+			NextCursor: encodeFilmCursor(domainFilmsPage.NextCursor, sort, getFilmFilterHash(request)),
+			Total:      domainFilmsPage.Total,
 		})
 
 		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
 	}
 }
 
-func getSortParams(sortParams string) (titleOrder string, releaseDateOrder string, ratingOrder string, err error) {
+func getFilmSort(sortParams string) (domain.FilmSort, error) {
 	if sortParams == "" {
-		return "", "", "", nil
-	}
-
-	setParamOrder := func(param string, order string) {
-		switch param {
-		case sortParamTitle:
-			titleOrder = order
-		case sortParamReleaseDate:
-			releaseDateOrder = order
-		case sortParamRating:
-			ratingOrder = order
-		}
+		return nil, nil
 	}
 
 	// sortParams expected as 'title:asc,release-date:desc,rating:desc'
 
 	splitSortParams := strings.Split(sortParams, ",")
 
+	sortKeys := make([]domain.FilmSortKey, 0, len(splitSortParams))
+
 	for _, param := range splitSortParams {
 
 		// Expect parameter like 'title:asc'
 		paramWithSortOrder := strings.Split(param, ":")
 
 		if len(paramWithSortOrder) != 2 {
-			return titleOrder, releaseDateOrder, ratingOrder, errors.New("invalid sort parameters. Must be like 'title:asc,release-date:desc,rating:desc'")
+			return nil, errors.New("invalid sort parameters. Must be like 'title:asc,release-date:desc,rating:desc'")
 		}
 
-		if paramWithSortOrder[0] != sortParamTitle && paramWithSortOrder[0] != sortParamReleaseDate && paramWithSortOrder[0] != sortParamRating {
-			return titleOrder, releaseDateOrder, ratingOrder, errors.New(fmt.Sprintf("unexpected sort parameter: '%s'", paramWithSortOrder[0]))
+		sortKeys = append(sortKeys, domain.FilmSortKey{
+			Field:     domain.FilmSortField(paramWithSortOrder[0]),
+			Direction: domain.SortDirection(strings.ToLower(paramWithSortOrder[1])),
+		})
+	}
+
+	return domain.NewFilmSort(sortKeys...)
+}
+
+const (
+	filterParamQuery           = "q"
+	filterParamFilmTitle       = "film-title"
+	filterParamActorName       = "actor-name"
+	filterParamDirectorName    = "director-name"
+	filterParamReleaseDateFrom = "release-date-from"
+	filterParamReleaseDateTo   = "release-date-to"
+	filterParamRatingFrom      = "rating-from"
+	filterParamRatingTo        = "rating-to"
+	filterParamActorIds        = "actor-ids"
+	filterParamActorIdsMatch   = "actor-ids-match"
+	filterParamActorSex        = "actor-sex"
+	filterParamNoActors        = "no-actors"
+	filterParamGenre           = "genre"
+)
+
+// getFilmFilterHash returns a hash of the film filter parameters of the request for the cursors of its pages
+func getFilmFilterHash(request *http.Request) string {
+	return hashCursorFilter(getQueryParams(request,
+		filterParamQuery, filterParamFilmTitle, filterParamActorName, filterParamDirectorName,
+		filterParamReleaseDateFrom, filterParamReleaseDateTo, filterParamRatingFrom, filterParamRatingTo,
+		filterParamActorIds, filterParamActorIdsMatch, filterParamActorSex, filterParamNoActors, filterParamGenre)...)
+}
+
+func getFilmFilter(request *http.Request) (*domain.FilmFilter, error) {
+	query := request.URL.Query()
+
+	filter := domain.FilmFilter{
+		Query:        domain.FilmSearchQuery(query.Get(filterParamQuery)),
+		Title:        domain.FilmTitle(query.Get(filterParamFilmTitle)),
+		ActorName:    domain.ActorName(query.Get(filterParamActorName)),
+		DirectorName: domain.ActorName(query.Get(filterParamDirectorName)),
+		ActorsMatch:  domain.FilmActorsMatch(query.Get(filterParamActorIdsMatch)),
+	}
+
+	genreId, err := getGenreIdQueryParam(request)
+	if err != nil {
+		return nil, err
+	}
+	filter.GenreId = genreId
+
+	if releaseDateFromStr := query.Get(filterParamReleaseDateFrom); releaseDateFromStr != "" {
+		releaseDateFrom, err := time.Parse(time.DateOnly, releaseDateFromStr)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' must be in 'YYYY-MM-DD' format", filterParamReleaseDateFrom)
 		}
+		domainReleaseDateFrom := domain.FilmReleaseDate(releaseDateFrom)
+		filter.ReleasedFrom = &domainReleaseDateFrom
+	}
 
-		setParamOrder(paramWithSortOrder[0], paramWithSortOrder[1])
+	if releaseDateToStr := query.Get(filterParamReleaseDateTo); releaseDateToStr != "" {
+		releaseDateTo, err := time.Parse(time.DateOnly, releaseDateToStr)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' must be in 'YYYY-MM-DD' format", filterParamReleaseDateTo)
+		}
+		domainReleaseDateTo := domain.FilmReleaseDate(releaseDateTo)
+		filter.ReleasedTo = &domainReleaseDateTo
+	}
+
+	if ratingFromStr := query.Get(filterParamRatingFrom); ratingFromStr != "" {
+		ratingFrom, err := strconv.ParseUint(ratingFromStr, 10, 8)
+		if err != nil || ratingFrom > 10 {
+			return nil, fmt.Errorf("'%s' must be an integer from 0 to 10", filterParamRatingFrom)
+		}
+		domainRatingFrom := domain.FilmRating(ratingFrom)
+		filter.MinRating = &domainRatingFrom
+	}
+
+	if ratingToStr := query.Get(filterParamRatingTo); ratingToStr != "" {
+		ratingTo, err := strconv.ParseUint(ratingToStr, 10, 8)
+		if err != nil || ratingTo > 10 {
+			return nil, fmt.Errorf("'%s' must be an integer from 0 to 10", filterParamRatingTo)
+		}
+		domainRatingTo := domain.FilmRating(ratingTo)
+		filter.MaxRating = &domainRatingTo
+	}
+
+	if actorIdsStr := query.Get(filterParamActorIds); actorIdsStr != "" {
+		for _, actorIdStr := range strings.Split(actorIdsStr, ",") {
+			actorId, err := strconv.ParseInt(strings.TrimSpace(actorIdStr), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("'%s' must be comma separated integers", filterParamActorIds)
+			}
+			filter.ActorIds = append(filter.ActorIds, domain.ActorId(actorId))
+		}
+	}
+
+	if actorSexStr := query.Get(filterParamActorSex); actorSexStr != "" {
+		actorSex, err := strconv.ParseUint(actorSexStr, 10, 8)
+		if err != nil || (actorSex != 0 && actorSex != 1 && actorSex != 2 && actorSex != 9) {
+			return nil, fmt.Errorf("'%s' must be one of 0, 1, 2, 9", filterParamActorSex)
+		}
+		domainActorSex := domain.ActorSex(actorSex)
+		filter.ActorSex = &domainActorSex
+	}
+
+	if noActorsStr := query.Get(filterParamNoActors); noActorsStr != "" {
+		noActors, err := strconv.ParseBool(noActorsStr)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' must be a boolean", filterParamNoActors)
+		}
+		filter.WithoutActors = noActors
 	}
 
-	return titleOrder, releaseDateOrder, ratingOrder, nil
+	return &filter, nil
 }
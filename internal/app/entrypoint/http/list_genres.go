@@ -0,0 +1,48 @@
+package http
+
+import (
+	"encoding/json"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+)
+
+type listGenresResponseBody struct {
+	Genres []*genre `json:"genres"`
+}
+
+// @Summary		List all genres
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			genres
+// @Description	List all genres ordered by name
+// @ID				list-genres
+// @Produce		json
+// @Success		200	{object}	listGenresResponseBody
+// @Failure		401	{object}	apiv1.Response
+// @Failure		403	{object}	apiv1.Response
+// @Failure		500	{object}	apiv1.Response
+// @Router			/genres [get]
+func (h *Handler) ListGenresHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "ListGenresHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		domainGenres, err := h.genreService.List(request.Context())
+		if err != nil {
+			log.Error("failed to list genres", "error", err.Error())
+
+			views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageGenreInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+
+			return
+		}
+
+		payload, _ := json.Marshal(&listGenresResponseBody{
+			Genres: buildGenres(domainGenres),
+		})
+
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
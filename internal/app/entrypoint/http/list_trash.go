@@ -0,0 +1,101 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+const (
+	defaultListTrashLimit  = 100
+	defaultListTrashOffset = 0
+)
+
+type listTrashResponseBody struct {
+	Items []*trashItem `json:"items"`
+	Total int64        `json:"total"`
+}
+
+// @Summary		List deleted films and actors
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			trash
+// @Description	List films and actors in the trash from the most recently deleted. Every item holds the user who deleted it and the time after which it is purged for good, if the trash is purged
+// @ID				list-trash
+// @Produce		json
+// @Param			type	query		string	false	"An optional query parameter 'type'. Allowed values: 'film', 'actor'"
+// @Param			limit	query		integer	false	"An optional query parameter 'limit' that limits total number of returned items. By default 'limit' = 100"
+// @Param			offset	query		integer	false	"An optional query parameter 'offset' that indicates how many records should be skipped while listing items. By default 'offset' = 0"
+// @Success		200		{object}	listTrashResponseBody
+// @Failure		400		{object}	apiv1.Response
+// @Failure		401		{object}	apiv1.Response
+// @Failure		403		{object}	apiv1.Response
+// @Failure		500		{object}	apiv1.Response
+// @Router			/trash [get]
+func (h *Handler) ListTrashHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "ListTrashHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		var limit, offset int
+		var err error
+
+		limitStr := request.URL.Query().Get("limit")
+
+		if limitStr == "" {
+			limit = defaultListTrashLimit
+		} else {
+			limit, err = strconv.Atoi(limitStr)
+			if err != nil || limit < 0 {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageTrashInvalidRequestBody, apiv1.ErrorDescription{"error": "'limit' must be a non-negative integer"}))
+
+				return
+			}
+		}
+
+		offsetStr := request.URL.Query().Get("offset")
+
+		if offsetStr == "" {
+			offset = defaultListTrashOffset
+		} else {
+			offset, err = strconv.Atoi(offsetStr)
+			if err != nil || offset < 0 {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageTrashInvalidRequestBody, apiv1.ErrorDescription{"error": "'offset' must be a non-negative integer"}))
+
+				return
+			}
+		}
+
+		var filter domain.TrashFilter
+		if entityTypeStr := request.URL.Query().Get("type"); entityTypeStr != "" {
+			entityType := domain.TrashEntityType(entityTypeStr)
+			filter.EntityType = &entityType
+		}
+
+		trashPage, err := h.trashService.List(request.Context(), &filter, limit, offset)
+		if err != nil {
+			if errors.Is(err, domain.ErrInvalidTrashFilter) {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageTrashInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to list trash", "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageTrashInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(&listTrashResponseBody{
+			Items: buildTrashItems(trashPage.Items),
+			Total: trashPage.Total,
+		})
+
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
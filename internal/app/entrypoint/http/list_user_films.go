@@ -0,0 +1,130 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+const (
+	defaultListUserFilmsLimit  = 100
+	defaultListUserFilmsOffset = 0
+)
+
+type listUserFilmsResponseBody struct {
+	Films      []*film `json:"films"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+	Total      *int64  `json:"total,omitempty"`
+}
+
+// @Summary		List the watchlist or the watched films of the authenticated user with optional 'sort', 'limit', 'offset', 'cursor', 'total' query parameters
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			users
+// @Description	List films of the personal list of the authenticated user with the same sorting and pagination as the list of all films
+// @Produce		json
+// @Param			sort	query		string	false	"An optional query parameter 'sort' that indicates how films should be sorted. By default 'sort' = 'rating:desc'. Allowed fields: 'id', 'title', 'release-date', 'rating', 'actors-count', 'community-score'. Allowed directions: 'asc', 'desc'"
+// @Param			limit	query		integer	false	"An optional query parameter 'limit' that limits total number of returned films. By default 'limit' = 100"
+// @Param			offset	query		integer	false	"An optional query parameter 'offset' that indicates how many records should be skipped while listing films. By default 'offset' = 0"
+// @Param			cursor	query		string	false	"An optional query parameter 'cursor' with the value of 'next_cursor' from the previous page. If set, 'offset' is counted from the cursor position. A cursor is valid only with the same 'sort'"
+// @Param			total	query		boolean	false	"An optional query parameter 'total' that indicates whether total number of films should be returned. By default 'total' = false"
+// @Success		200		{object}	listUserFilmsResponseBody
+// @Failure		400		{object}	apiv1.Response
+// @Failure		401		{object}	apiv1.Response
+// @Failure		403		{object}	apiv1.Response
+// @Failure		500		{object}	apiv1.Response
+// @Router			/users/me/watchlist [get]
+// @Router			/users/me/watched [get]
+func (h *Handler) ListUserFilmsHandler(list domain.FilmList) http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "ListUserFilmsHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName), slog.String("list", list.String()))
+
+		var limit, offset int
+		var err error
+
+		limitStr := request.URL.Query().Get("limit")
+
+		if limitStr == "" {
+			limit = defaultListUserFilmsLimit
+		} else {
+			limit, err = strconv.Atoi(limitStr)
+			if err != nil || limit < 0 {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageFilmInvalidRequestBody, apiv1.ErrorDescription{"error": "'limit' must be a non-negative integer"}))
+
+				return
+			}
+		}
+
+		offsetStr := request.URL.Query().Get("offset")
+
+		if offsetStr == "" {
+			offset = defaultListUserFilmsOffset
+		} else {
+			offset, err = strconv.Atoi(offsetStr)
+			if err != nil || offset < 0 {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageFilmInvalidRequestBody, apiv1.ErrorDescription{"error": "'offset' must be a non-negative integer"}))
+
+				return
+			}
+		}
+
+		sort, err := getFilmSort(request.URL.Query().Get("sort"))
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageFilmInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+
+			return
+		}
+
+		var cursor *domain.FilmCursor
+
+		cursorStr := request.URL.Query().Get("cursor")
+		if cursorStr != "" {
+			cursor, err = decodeFilmCursor(cursorStr, sort, hashCursorFilter(list.String()))
+			if err != nil {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageFilmInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+
+				return
+			}
+		}
+
+		var withTotal bool
+
+		totalStr := request.URL.Query().Get("total")
+		if totalStr != "" {
+			withTotal, err = strconv.ParseBool(totalStr)
+			if err != nil {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageFilmInvalidRequestBody, apiv1.ErrorDescription{"error": "'total' must be a boolean"}))
+
+				return
+			}
+		}
+
+		domainFilmsPage, err := h.filmListService.List(request.Context(), list, sort, cursor, withTotal, limit, offset)
+		if err != nil {
+			if errors.Is(err, domain.ErrFilmListForbidden) {
+				views.RenderJSON(rw, http.StatusForbidden, apiv1.Error(apiv1.CodeForbidden, ErrMessageFilmListForbidden, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to list films of the list", "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageFilmInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(&listUserFilmsResponseBody{
+			Films:      buildFilms(domainFilmsPage.Films),
+			NextCursor: encodeFilmCursor(domainFilmsPage.NextCursor, sort, hashCursorFilter(list.String())),
+			Total:      domainFilmsPage.Total,
+		})
+
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
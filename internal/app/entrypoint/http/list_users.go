@@ -0,0 +1,88 @@
+package http
+
+import (
+	"encoding/json"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+const (
+	defaultListUsersLimit  = 100
+	defaultListUsersOffset = 0
+)
+
+type listUsersResponseBody struct {
+	Users []*userAccount `json:"users"`
+	Total int64          `json:"total"`
+}
+
+// @Summary		List all users with optional query parameters 'limit' and 'offset'
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			users
+// @Description	List all users ordered by id with optional query parameters 'limit' and 'offset'
+// @ID				list-users
+// @Produce		json
+// @Param			limit	query		integer	false	"An optional query parameter 'limit' that limits total number of returned users. By default 'limit' = 100"
+// @Param			offset	query		integer	false	"An optional query parameter 'offset' that indicates how many records should be skipped while listing users. By default 'offset' = 0"
+// @Success		200		{object}	listUsersResponseBody
+// @Failure		400		{object}	apiv1.Response
+// @Failure		401		{object}	apiv1.Response
+// @Failure		403		{object}	apiv1.Response
+// @Failure		500		{object}	apiv1.Response
+// @Router			/users [get]
+func (h *Handler) ListUsersHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "ListUsersHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		var limit, offset int
+		var err error
+
+		limitStr := request.URL.Query().Get("limit")
+
+		if limitStr == "" {
+			limit = defaultListUsersLimit
+		} else {
+			limit, err = strconv.Atoi(limitStr)
+			if err != nil || limit < 0 {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageUserInvalidRequestBody, apiv1.ErrorDescription{"error": "'limit' must be a non-negative integer"}))
+
+				return
+			}
+		}
+
+		offsetStr := request.URL.Query().Get("offset")
+
+		if offsetStr == "" {
+			offset = defaultListUsersOffset
+		} else {
+			offset, err = strconv.Atoi(offsetStr)
+			if err != nil || offset < 0 {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageUserInvalidRequestBody, apiv1.ErrorDescription{"error": "'offset' must be a non-negative integer"}))
+
+				return
+			}
+		}
+
+		usersPage, err := h.userService.List(request.Context(), limit, offset)
+		if err != nil {
+			log.Error("failed to list users", "error", err.Error())
+
+			views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageUserInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+
+			return
+		}
+
+		payload, _ := json.Marshal(&listUsersResponseBody{
+			Users: buildUserAccounts(usersPage.Users),
+			Total: usersPage.Total,
+		})
+
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
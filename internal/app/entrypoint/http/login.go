@@ -0,0 +1,95 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/service/auth"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+type loginRequestBody struct {
+	Email    string `json:"email" validate:"required" example:"user@example.com"`
+	Password string `json:"password" validate:"required" example:"asdf1234"`
+}
+
+type tokensResponseBody struct {
+	AccessToken           string `json:"access_token"`
+	AccessTokenExpiresAt  string `json:"access_token_expires_at" example:"2006-01-02T15:04:05Z"`
+	RefreshToken          string `json:"refresh_token"`
+	RefreshTokenExpiresAt string `json:"refresh_token_expires_at" example:"2006-01-02T15:04:05Z"`
+}
+
+// @Summary		Log in with email and password
+// @Tags			auth
+// @Description	Issue a short-lived access token and a refresh token.
+// @Description	The access token is passed as 'Authorization: Bearer <access_token>' header, the refresh token is used to get a new pair of tokens
+// @ID				login
+// @Accept			json
+// @Produce		json
+// @Param			input	body		loginRequestBody	true	"User credentials"
+// @Success		200		{object}	tokensResponseBody
+// @Failure		400		{object}	apiv1.Response
+// @Failure		401		{object}	apiv1.Response
+// @Failure		500		{object}	apiv1.Response
+// @Router			/auth/login [post]
+func (h *Handler) LoginHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "LoginHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		var loginReqBody loginRequestBody
+		err := json.NewDecoder(request.Body).Decode(&loginReqBody)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageAuthInvalidRequestBody, apiv1.ErrorDescription{"error": "invalid request body"}))
+
+			return
+		}
+
+		err = h.validator.Struct(&loginReqBody)
+		if err != nil {
+			errors, ok := err.(validator.ValidationErrors)
+			if !ok {
+				log.Error("failed to type cast validator.ValidationErrors", "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageAuthInternalServerError, apiv1.ErrorDescription{"error": "failed to get validation errors"}))
+			} else {
+				errs := validateRequestBody(errors)
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageAuthInvalidRequestBody, apiv1.ErrorDescription{"error": errs.Error()}))
+			}
+
+			return
+		}
+
+		tokens, err := h.authService.Login(request.Context(), loginReqBody.Email, loginReqBody.Password)
+		if err != nil {
+			if errors.Is(err, auth.ErrInvalidEmailOrPassword) || errors.Is(err, auth.ErrUserDisabled) {
+				views.RenderJSON(rw, http.StatusUnauthorized, apiv1.Error(apiv1.CodeUnauthorized, ErrMessageAuthUnauthorized, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to log in", "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageAuthInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(buildTokensResponseBody(tokens))
+
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
+
+func buildTokensResponseBody(tokens *auth.Tokens) *tokensResponseBody {
+	return &tokensResponseBody{
+		AccessToken:           tokens.AccessToken,
+		AccessTokenExpiresAt:  tokens.AccessTokenExpiresAt.UTC().Format(time.RFC3339),
+		RefreshToken:          tokens.RefreshToken,
+		RefreshTokenExpiresAt: tokens.RefreshTokenExpiresAt.UTC().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,78 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/service/auth"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+)
+
+type logoutResponseBody struct {
+	Message string `json:"message"`
+}
+
+// @Summary		Log out
+// @Tags			auth
+// @Description	Revoke a refresh token. Already issued access tokens stay valid until they expire
+// @ID				logout
+// @Accept			json
+// @Produce		json
+// @Param			input	body		refreshTokenRequestBody	true	"Refresh token that needs to be revoked"
+// @Success		200		{object}	logoutResponseBody
+// @Failure		400		{object}	apiv1.Response
+// @Failure		401		{object}	apiv1.Response
+// @Failure		500		{object}	apiv1.Response
+// @Router			/auth/logout [post]
+func (h *Handler) LogoutHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "LogoutHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		var refreshTokenReqBody refreshTokenRequestBody
+		err := json.NewDecoder(request.Body).Decode(&refreshTokenReqBody)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageAuthInvalidRequestBody, apiv1.ErrorDescription{"error": "invalid request body"}))
+
+			return
+		}
+
+		err = h.validator.Struct(&refreshTokenReqBody)
+		if err != nil {
+			errors, ok := err.(validator.ValidationErrors)
+			if !ok {
+				log.Error("failed to type cast validator.ValidationErrors", "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageAuthInternalServerError, apiv1.ErrorDescription{"error": "failed to get validation errors"}))
+			} else {
+				errs := validateRequestBody(errors)
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageAuthInvalidRequestBody, apiv1.ErrorDescription{"error": errs.Error()}))
+			}
+
+			return
+		}
+
+		err = h.authService.Logout(request.Context(), refreshTokenReqBody.RefreshToken)
+		if err != nil {
+			if errors.Is(err, auth.ErrInvalidRefreshToken) {
+				views.RenderJSON(rw, http.StatusUnauthorized, apiv1.Error(apiv1.CodeUnauthorized, ErrMessageAuthUnauthorized, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to log out", "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageAuthInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(&logoutResponseBody{
+			Message: "User has logged out successfully",
+		})
+
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
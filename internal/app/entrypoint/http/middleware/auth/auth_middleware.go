@@ -5,8 +5,10 @@ import (
 	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
 	"github.com/vaberof/vk-internship-task/internal/service/auth"
 	"github.com/vaberof/vk-internship-task/internal/service/user"
+	"github.com/vaberof/vk-internship-task/pkg/http/httpserver/middleware/logging"
 	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
 	"net/http"
+	"strings"
 )
 
 var (
@@ -15,18 +17,27 @@ var (
 	ErrMessageInternalServerError = "errors.middleware.internalServerError"
 )
 
+// AuthenticationMiddleware accepts 'Authorization: Bearer <access token>'.
+// 'Authorization: Basic' is accepted as a fallback unless it is disabled in the auth service config.
 func AuthenticationMiddleware(authService auth.AuthService, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
-		email, password, hasAuth := request.BasicAuth()
-		if !hasAuth {
-			views.RenderJSON(writer, http.StatusUnauthorized, apiv1.Error(apiv1.CodeUnauthorized, ErrMessageUnauthorized, apiv1.ErrorDescription{"error": "Missing required 'Authorization' header"}))
+		var usr *user.User
+		var err error
 
-			return
-		}
+		if accessToken, isBearer := bearerToken(request); isBearer {
+			usr, err = authService.AuthenticateAccessToken(request.Context(), accessToken)
+		} else {
+			email, password, hasAuth := request.BasicAuth()
+			if !hasAuth {
+				views.RenderJSON(writer, http.StatusUnauthorized, apiv1.Error(apiv1.CodeUnauthorized, ErrMessageUnauthorized, apiv1.ErrorDescription{"error": "Missing required 'Authorization' header"}))
+
+				return
+			}
 
-		usr, err := authService.AuthenticateUser(email, password)
+			usr, err = authService.AuthenticateUser(request.Context(), email, password)
+		}
 		if err != nil {
-			if errors.Is(err, auth.ErrInvalidEmailOrPassword) {
+			if errors.Is(err, auth.ErrInvalidEmailOrPassword) || errors.Is(err, auth.ErrInvalidAccessToken) || errors.Is(err, auth.ErrBasicAuthDisabled) || errors.Is(err, auth.ErrUserDisabled) {
 				views.RenderJSON(writer, http.StatusUnauthorized, apiv1.Error(apiv1.CodeUnauthorized, ErrMessageUnauthorized, apiv1.ErrorDescription{"error": err.Error()}))
 			} else {
 				views.RenderJSON(writer, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
@@ -35,31 +46,29 @@ func AuthenticationMiddleware(authService auth.AuthService, next http.Handler) h
 			return
 		}
 
-		ctxWithUserRole := userRoleToContext(request.Context(), &usr.Role)
+		logging.SetUserId(request.Context(), usr.Id)
+
+		ctxWithPrincipal := user.ContextWithPrincipal(request.Context(), &user.Principal{
+			Id:    usr.Id,
+			Email: usr.Email,
+			Role:  usr.Role,
+		})
 
-		next.ServeHTTP(writer, request.WithContext(ctxWithUserRole))
+		next.ServeHTTP(writer, request.WithContext(ctxWithPrincipal))
 	})
 }
 
-func AuthorizationMiddleware(needRoles []user.UserRole, next http.Handler) http.Handler {
+// AuthorizationMiddleware allows the request if the role of the authenticated user has needPermission
+func AuthorizationMiddleware(rolePermissions user.RolePermissions, needPermission user.Permission, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
-		userRole := userRoleFromContext(request.Context())
-		if userRole == nil {
-			views.RenderJSON(writer, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageForbidden, apiv1.ErrorDescription{"error": "Failed to get user role from context"}))
+		principal, ok := user.PrincipalFromContext(request.Context())
+		if !ok {
+			views.RenderJSON(writer, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageForbidden, apiv1.ErrorDescription{"error": "Failed to get user from context"}))
 
 			return
 		}
 
-		var hasAccess bool
-
-		for _, needRole := range needRoles {
-			if *userRole == needRole {
-				hasAccess = true
-				break
-			}
-		}
-
-		if !hasAccess {
+		if !rolePermissions.Has(principal.Role, needPermission) {
 			views.RenderJSON(writer, http.StatusForbidden, apiv1.Error(apiv1.CodeForbidden, ErrMessageForbidden, apiv1.ErrorDescription{"error": "Access to requested resource has denied"}))
 
 			return
@@ -68,3 +77,11 @@ func AuthorizationMiddleware(needRoles []user.UserRole, next http.Handler) http.
 		next.ServeHTTP(writer, request)
 	})
 }
+
+func bearerToken(request *http.Request) (string, bool) {
+	scheme, token, found := strings.Cut(request.Header.Get("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
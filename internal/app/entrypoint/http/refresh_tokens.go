@@ -0,0 +1,76 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/service/auth"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+)
+
+type refreshTokenRequestBody struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// @Summary		Refresh tokens
+// @Tags			auth
+// @Description	Issue a new pair of tokens in exchange for a refresh token. The refresh token can be used only once
+// @ID				refresh-tokens
+// @Accept			json
+// @Produce		json
+// @Param			input	body		refreshTokenRequestBody	true	"Refresh token"
+// @Success		200		{object}	tokensResponseBody
+// @Failure		400		{object}	apiv1.Response
+// @Failure		401		{object}	apiv1.Response
+// @Failure		500		{object}	apiv1.Response
+// @Router			/auth/refresh [post]
+func (h *Handler) RefreshTokensHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "RefreshTokensHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		var refreshTokenReqBody refreshTokenRequestBody
+		err := json.NewDecoder(request.Body).Decode(&refreshTokenReqBody)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageAuthInvalidRequestBody, apiv1.ErrorDescription{"error": "invalid request body"}))
+
+			return
+		}
+
+		err = h.validator.Struct(&refreshTokenReqBody)
+		if err != nil {
+			errors, ok := err.(validator.ValidationErrors)
+			if !ok {
+				log.Error("failed to type cast validator.ValidationErrors", "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageAuthInternalServerError, apiv1.ErrorDescription{"error": "failed to get validation errors"}))
+			} else {
+				errs := validateRequestBody(errors)
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageAuthInvalidRequestBody, apiv1.ErrorDescription{"error": errs.Error()}))
+			}
+
+			return
+		}
+
+		tokens, err := h.authService.Refresh(request.Context(), refreshTokenReqBody.RefreshToken)
+		if err != nil {
+			if errors.Is(err, auth.ErrInvalidRefreshToken) || errors.Is(err, auth.ErrUserDisabled) {
+				views.RenderJSON(rw, http.StatusUnauthorized, apiv1.Error(apiv1.CodeUnauthorized, ErrMessageAuthUnauthorized, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to refresh tokens", "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageAuthInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(buildTokensResponseBody(tokens))
+
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
@@ -0,0 +1,83 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/service/user"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+)
+
+type registerUserRequestBody struct {
+	Email    string `json:"email" validate:"required,email,max=50" example:"user@example.com"`
+	Password string `json:"password" validate:"required,min=8,max=72" example:"asdf1234"`
+}
+
+type registerUserResponseBody struct {
+	*userAccount
+}
+
+// @Summary		Register a new user
+// @Tags			users
+// @Description	Register a new user with 'user' role
+// @ID				register-user
+// @Accept			json
+// @Produce		json
+// @Param			input	body		registerUserRequestBody	true	"User credentials"
+// @Success		200		{object}	registerUserResponseBody
+// @Failure		400		{object}	apiv1.Response
+// @Failure		409		{object}	apiv1.Response
+// @Failure		500		{object}	apiv1.Response
+// @Router			/users [post]
+func (h *Handler) RegisterUserHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "RegisterUserHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		var registerUserReqBody registerUserRequestBody
+		err := json.NewDecoder(request.Body).Decode(&registerUserReqBody)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageUserInvalidRequestBody, apiv1.ErrorDescription{"error": "invalid request body"}))
+
+			return
+		}
+
+		err = h.validator.Struct(&registerUserReqBody)
+		if err != nil {
+			errors, ok := err.(validator.ValidationErrors)
+			if !ok {
+				log.Error("failed to type cast validator.ValidationErrors", "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageUserInternalServerError, apiv1.ErrorDescription{"error": "failed to get validation errors"}))
+			} else {
+				errs := validateRequestBody(errors)
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageUserInvalidRequestBody, apiv1.ErrorDescription{"error": errs.Error()}))
+			}
+
+			return
+		}
+
+		usr, err := h.userService.Register(request.Context(), registerUserReqBody.Email, registerUserReqBody.Password)
+		if err != nil {
+			if errors.Is(err, user.ErrUserAlreadyExists) {
+				views.RenderJSON(rw, http.StatusConflict, apiv1.Error(apiv1.CodeConflict, ErrMessageUserAlreadyExists, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to register a user", "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageUserInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(&registerUserResponseBody{
+			userAccount: buildUserAccount(usr),
+		})
+
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
@@ -0,0 +1,69 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type removeUserFilmResponseBody struct {
+	Message string `json:"message"`
+}
+
+// @Summary		Remove a film from the watchlist or from the watched films of the authenticated user
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			users
+// @Description	Remove a film by path parameter 'id' from the personal list of the authenticated user
+// @Produce		json
+// @Param			id	path		integer	true	"Film`s id that needs to be removed from the list"
+// @Success		200	{object}	removeUserFilmResponseBody
+// @Failure		400	{object}	apiv1.Response
+// @Failure		401	{object}	apiv1.Response
+// @Failure		403	{object}	apiv1.Response
+// @Failure		404	{object}	apiv1.Response
+// @Failure		500	{object}	apiv1.Response
+// @Router			/users/me/watchlist/{id} [delete]
+// @Router			/users/me/watched/{id} [delete]
+func (h *Handler) RemoveUserFilmHandler(list domain.FilmList) http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "RemoveUserFilmHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName), slog.String("list", list.String()))
+
+		filmIdPathParam := request.PathValue("id")
+		filmId, err := strconv.ParseInt(filmIdPathParam, 10, 64)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageFilmInvalidRequestBody, apiv1.ErrorDescription{"error": "path parameter 'id' must be an integer"}))
+
+			return
+		}
+
+		err = h.filmListService.Remove(request.Context(), list, domain.FilmId(filmId))
+		if err != nil {
+			if errors.Is(err, domain.ErrFilmNotInList) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageFilmNotInList, apiv1.ErrorDescription{"error": err.Error()}))
+			} else if errors.Is(err, domain.ErrFilmListForbidden) {
+				views.RenderJSON(rw, http.StatusForbidden, apiv1.Error(apiv1.CodeForbidden, ErrMessageFilmListForbidden, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to remove film from the list", "filmId", filmId, "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageFilmInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(&removeUserFilmResponseBody{
+			Message: fmt.Sprintf("Film with id '%d' has removed from the %s successfully", filmId, list),
+		})
+
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
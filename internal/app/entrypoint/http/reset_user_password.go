@@ -0,0 +1,97 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/service/user"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type resetUserPasswordRequestBody struct {
+	NewPassword string `json:"new_password" validate:"required,min=8,max=72"`
+}
+
+type resetUserPasswordResponseBody struct {
+	Message string `json:"message"`
+}
+
+// @Summary		Reset a password of a user by path parameter 'id'
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			users
+// @Description	Set a new password of a user by path parameter 'id'. All refresh tokens of the user are revoked
+// @ID				reset-user-password
+// @Accept			json
+// @Produce		json
+// @Param			id		path		integer							true	"User`s id whose password needs to be reset"
+// @Param			input	body		resetUserPasswordRequestBody	true	"New password"
+// @Success		200		{object}	resetUserPasswordResponseBody
+// @Failure		400		{object}	apiv1.Response
+// @Failure		401		{object}	apiv1.Response
+// @Failure		403		{object}	apiv1.Response
+// @Failure		404		{object}	apiv1.Response
+// @Failure		500		{object}	apiv1.Response
+// @Router			/users/{id}/password [put]
+func (h *Handler) ResetUserPasswordHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "ResetUserPasswordHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		userIdPathParam := request.PathValue("id")
+		userId, err := strconv.ParseInt(userIdPathParam, 10, 64)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageUserInvalidRequestBody, apiv1.ErrorDescription{"error": "path parameter 'id' must be an integer"}))
+
+			return
+		}
+
+		var resetUserPasswordReqBody resetUserPasswordRequestBody
+		err = json.NewDecoder(request.Body).Decode(&resetUserPasswordReqBody)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageUserInvalidRequestBody, apiv1.ErrorDescription{"error": "invalid request body"}))
+
+			return
+		}
+
+		err = h.validator.Struct(&resetUserPasswordReqBody)
+		if err != nil {
+			errors, ok := err.(validator.ValidationErrors)
+			if !ok {
+				log.Error("failed to type cast validator.ValidationErrors", "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageUserInternalServerError, apiv1.ErrorDescription{"error": "failed to get validation errors"}))
+			} else {
+				errs := validateRequestBody(errors)
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageUserInvalidRequestBody, apiv1.ErrorDescription{"error": errs.Error()}))
+			}
+
+			return
+		}
+
+		err = h.userService.ResetPassword(request.Context(), userId, resetUserPasswordReqBody.NewPassword)
+		if err != nil {
+			if errors.Is(err, user.ErrUserNotFound) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageUserNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to reset user password", "id", userId, "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageUserInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(&resetUserPasswordResponseBody{
+			Message: fmt.Sprintf("Password of user with id '%d' has reset successfully", userId),
+		})
+
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
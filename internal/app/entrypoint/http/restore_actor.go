@@ -0,0 +1,75 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+type restoreActorResponseBody struct {
+	Id        int64        `json:"id"`
+	Name      string       `json:"name"`
+	Sex       uint8        `json:"sex"`
+	BirthDate string       `json:"birthdate"`
+	Films     []*actorFilm `json:"films"`
+}
+
+// @Summary		Restore a deleted actor by path parameter 'id'
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			trash
+// @Description	Bring an actor back from the trash into the films they had. Films of the actor that are still in the trash get the actor back once they are restored too
+// @ID				restore-actor
+// @Produce		json
+// @Param			id	path		integer	true	"Actor`s id that needs to be restored"
+// @Success		200	{object}	restoreActorResponseBody
+// @Header		200	{string}	ETag	"Entity tag of the actor version"
+// @Failure		400	{object}	apiv1.Response
+// @Failure		401	{object}	apiv1.Response
+// @Failure		403	{object}	apiv1.Response
+// @Failure		404	{object}	apiv1.Response
+// @Failure		500	{object}	apiv1.Response
+// @Router			/actors/{id}:restore [post]
+func (h *Handler) RestoreActorHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "RestoreActorHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		actorId, err := getCustomMethodParam(request, "id", restoreMethod)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageTrashInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+
+			return
+		}
+
+		domainActor, err := h.actorService.Restore(request.Context(), domain.ActorId(actorId))
+		if err != nil {
+			if errors.Is(err, domain.ErrActorNotFound) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageTrashActorNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to restore actor", "id", actorId, "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageTrashInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(&restoreActorResponseBody{
+			Id:        domainActor.Id.Int64(),
+			Name:      domainActor.Name.String(),
+			Sex:       domainActor.Sex.Uint8(),
+			BirthDate: domainActor.BirthDate.Time().Format(time.DateOnly),
+			Films:     buildActorFilms(domainActor.Films),
+		})
+
+		setETag(rw, domainActor.Version)
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
@@ -0,0 +1,79 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+type restoreFilmResponseBody struct {
+	Id          int64        `json:"id"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	ReleaseDate string       `json:"release_date"`
+	Rating      uint8        `json:"rating"`
+	Actors      []*filmActor `json:"actors"`
+	Genres      []*genre     `json:"genres"`
+}
+
+// @Summary		Restore a deleted film by path parameter 'id'
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			trash
+// @Description	Bring a film back from the trash with the actors, credits, genres and reviews it had. Credits of actors that are still in the trash come back with the actors
+// @ID				restore-film
+// @Produce		json
+// @Param			id	path		integer	true	"Film`s id that needs to be restored"
+// @Success		200	{object}	restoreFilmResponseBody
+// @Header		200	{string}	ETag	"Entity tag of the film version"
+// @Failure		400	{object}	apiv1.Response
+// @Failure		401	{object}	apiv1.Response
+// @Failure		403	{object}	apiv1.Response
+// @Failure		404	{object}	apiv1.Response
+// @Failure		500	{object}	apiv1.Response
+// @Router			/films/{id}:restore [post]
+func (h *Handler) RestoreFilmHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "RestoreFilmHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		filmId, err := getCustomMethodParam(request, "id", restoreMethod)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageTrashInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+
+			return
+		}
+
+		domainFilm, err := h.filmService.Restore(request.Context(), domain.FilmId(filmId))
+		if err != nil {
+			if errors.Is(err, domain.ErrFilmNotFound) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageTrashFilmNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to restore film", "id", filmId, "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageTrashInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(&restoreFilmResponseBody{
+			Id:          domainFilm.Id.Int64(),
+			Title:       domainFilm.Title.String(),
+			Description: domainFilm.Description.String(),
+			ReleaseDate: domainFilm.ReleaseDate.Time().Format(time.DateOnly),
+			Rating:      domainFilm.Rating.Uint8(),
+			Actors:      buildFilmActors(domainFilm.Actors),
+			Genres:      buildGenres(domainFilm.Genres),
+		})
+
+		setETag(rw, domainFilm.Version)
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
@@ -0,0 +1,99 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type revertActorResponseBody struct {
+	Id        int64        `json:"id"`
+	Name      string       `json:"name"`
+	Sex       uint8        `json:"sex"`
+	BirthDate string       `json:"birthdate"`
+	Films     []*actorFilm `json:"films"`
+}
+
+// @Summary		Revert an actor to a revision by path parameters 'id' and 'revision'
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			revisions
+// @Description	Bring an actor back to the state of the revision. It is an ordinary update of the actor,
+// @Description	so it is checked the same way and makes a new revision instead of removing the later ones
+// @ID				revert-actor
+// @Produce		json
+// @Param			id			path		integer	true	"Actor`s id that needs to be reverted"
+// @Param			revision	path		integer	true	"The revision the actor needs to be reverted to"
+// @Param			If-Match	header		string	false	"Entity tag of the actor version that is expected to be changed, like '\"1\"'"
+// @Success		200			{object}	revertActorResponseBody
+// @Header			200			{string}	ETag	"Entity tag of the actor version"
+// @Failure		400			{object}	apiv1.Response
+// @Failure		401			{object}	apiv1.Response
+// @Failure		403			{object}	apiv1.Response
+// @Failure		404			{object}	apiv1.Response
+// @Failure		412			{object}	apiv1.Response
+// @Failure		500			{object}	apiv1.Response
+// @Router			/actors/{id}/revisions/{revision}:revert [post]
+func (h *Handler) RevertActorHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "RevertActorHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		revision, err := getCustomMethodParam(request, "revision", revertMethod)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageRevisionInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+
+			return
+		}
+
+		actorIdPathParam := request.PathValue("id")
+		actorId, err := strconv.ParseInt(actorIdPathParam, 10, 64)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageRevisionInvalidRequestBody, apiv1.ErrorDescription{"error": "path parameter 'id' must be an integer"}))
+
+			return
+		}
+
+		version, err := getIfMatchVersion(request)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageRevisionInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+
+			return
+		}
+
+		domainActor, err := h.actorRevisionService.Revert(request.Context(), domain.ActorId(actorId), revision, version)
+		if err != nil {
+			if errors.Is(err, domain.ErrActorNotFound) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageActorNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else if errors.Is(err, domain.ErrActorRevisionNotFound) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageRevisionNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else if errors.Is(err, domain.ErrActorVersionMismatch) {
+				views.RenderJSON(rw, http.StatusPreconditionFailed, apiv1.Error(apiv1.CodePreconditionFailed, ErrMessageActorPreconditionFailed, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to revert actor", "id", actorId, "revision", revision, "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageRevisionInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(&revertActorResponseBody{
+			Id:        domainActor.Id.Int64(),
+			Name:      domainActor.Name.String(),
+			Sex:       domainActor.Sex.Uint8(),
+			BirthDate: domainActor.BirthDate.Time().Format(time.DateOnly),
+			Films:     buildActorFilms(domainActor.Films),
+		})
+
+		setETag(rw, domainActor.Version)
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
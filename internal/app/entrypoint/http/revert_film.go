@@ -0,0 +1,109 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type revertFilmResponseBody struct {
+	Id          int64        `json:"id"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	ReleaseDate string       `json:"release_date"`
+	Rating      uint8        `json:"rating"`
+	Actors      []*filmActor `json:"actors"`
+	Genres      []*genre     `json:"genres"`
+}
+
+// @Summary		Revert a film to a revision by path parameters 'id' and 'revision'
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			revisions
+// @Description	Bring a film back to the state of the revision. It is an ordinary update of the film,
+// @Description	so it is checked the same way and makes a new revision instead of removing the later ones
+// @ID				revert-film
+// @Produce		json
+// @Param			id			path		integer	true	"Film`s id that needs to be reverted"
+// @Param			revision	path		integer	true	"The revision the film needs to be reverted to"
+// @Param			If-Match	header		string	false	"Entity tag of the film version that is expected to be changed, like '\"1\"'"
+// @Success		200			{object}	revertFilmResponseBody
+// @Header			200			{string}	ETag	"Entity tag of the film version"
+// @Failure		400			{object}	apiv1.Response
+// @Failure		401			{object}	apiv1.Response
+// @Failure		403			{object}	apiv1.Response
+// @Failure		404			{object}	apiv1.Response
+// @Failure		412			{object}	apiv1.Response
+// @Failure		500			{object}	apiv1.Response
+// @Router			/films/{id}/revisions/{revision}:revert [post]
+func (h *Handler) RevertFilmHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "RevertFilmHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		revision, err := getCustomMethodParam(request, "revision", revertMethod)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageRevisionInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+
+			return
+		}
+
+		filmIdPathParam := request.PathValue("id")
+		filmId, err := strconv.ParseInt(filmIdPathParam, 10, 64)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageRevisionInvalidRequestBody, apiv1.ErrorDescription{"error": "path parameter 'id' must be an integer"}))
+
+			return
+		}
+
+		version, err := getIfMatchVersion(request)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageRevisionInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+
+			return
+		}
+
+		domainFilm, err := h.filmRevisionService.Revert(request.Context(), domain.FilmId(filmId), revision, version)
+		if err != nil {
+			if errors.Is(err, domain.ErrFilmNotFound) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageFilmNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else if errors.Is(err, domain.ErrFilmRevisionNotFound) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageRevisionNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else if errors.Is(err, domain.ErrFilmVersionMismatch) {
+				views.RenderJSON(rw, http.StatusPreconditionFailed, apiv1.Error(apiv1.CodePreconditionFailed, ErrMessageFilmPreconditionFailed, apiv1.ErrorDescription{"error": err.Error()}))
+			} else if errors.Is(err, domain.ErrFilmActorsNotFound) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageFilmActorsNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else if errors.Is(err, domain.ErrInvalidFilmCredit) {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageFilmInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+			} else if errors.Is(err, domain.ErrFilmGenresNotFound) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageFilmGenresNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to revert film", "id", filmId, "revision", revision, "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageRevisionInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(&revertFilmResponseBody{
+			Id:          domainFilm.Id.Int64(),
+			Title:       domainFilm.Title.String(),
+			Description: domainFilm.Description.String(),
+			ReleaseDate: domainFilm.ReleaseDate.Time().Format(time.DateOnly),
+			Rating:      domainFilm.Rating.Uint8(),
+			Actors:      buildFilmActors(domainFilm.Actors),
+			Genres:      buildGenres(domainFilm.Genres),
+		})
+
+		setETag(rw, domainFilm.Version)
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
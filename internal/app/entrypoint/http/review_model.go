@@ -0,0 +1,36 @@
+package http
+
+import (
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"time"
+)
+
+type review struct {
+	Id        int64  `json:"id"`
+	FilmId    int64  `json:"film_id"`
+	UserId    int64  `json:"user_id"`
+	Rating    uint8  `json:"rating"`
+	Text      string `json:"text,omitempty"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+func buildReviews(domainReviews []*domain.Review) []*review {
+	reviews := make([]*review, len(domainReviews))
+	for i := range domainReviews {
+		reviews[i] = buildReview(domainReviews[i])
+	}
+	return reviews
+}
+
+func buildReview(domainReview *domain.Review) *review {
+	return &review{
+		Id:        domainReview.Id.Int64(),
+		FilmId:    domainReview.FilmId.Int64(),
+		UserId:    domainReview.UserId,
+		Rating:    domainReview.Rating.Uint8(),
+		Text:      domainReview.Text.String(),
+		CreatedAt: domainReview.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: domainReview.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,26 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+var (
+	errInvalidRevisionsRange = errors.New("query parameters 'from' and 'to' must be positive integers")
+)
+
+// getRevisionsRange returns the required query parameters 'from' and 'to' of the revisions diff routes
+func getRevisionsRange(request *http.Request) (int64, int64, error) {
+	from, err := strconv.ParseInt(request.URL.Query().Get("from"), 10, 64)
+	if err != nil || from <= 0 {
+		return 0, 0, errInvalidRevisionsRange
+	}
+
+	to, err := strconv.ParseInt(request.URL.Query().Get("to"), 10, 64)
+	if err != nil || to <= 0 {
+		return 0, 0, errInvalidRevisionsRange
+	}
+
+	return from, to, nil
+}
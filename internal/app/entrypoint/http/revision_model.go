@@ -0,0 +1,108 @@
+package http
+
+import (
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"time"
+)
+
+type filmRevision struct {
+	Revision    int64                 `json:"revision"`
+	UserId      *int64                `json:"user_id,omitempty"`
+	Title       string                `json:"title"`
+	Description string                `json:"description"`
+	ReleaseDate string                `json:"release_date"`
+	Rating      uint8                 `json:"rating"`
+	Credits     []*filmRevisionCredit `json:"credits"`
+	GenreIds    []int64               `json:"genre_ids"`
+	CreatedAt   string                `json:"created_at"`
+}
+
+type filmRevisionCredit struct {
+	ActorId       int64  `json:"actor_id"`
+	Role          string `json:"role"`
+	CharacterName string `json:"character_name,omitempty"`
+	BillingOrder  int    `json:"billing_order"`
+}
+
+type actorRevision struct {
+	Revision  int64  `json:"revision"`
+	UserId    *int64 `json:"user_id,omitempty"`
+	Name      string `json:"name"`
+	Sex       uint8  `json:"sex"`
+	BirthDate string `json:"birthdate"`
+	CreatedAt string `json:"created_at"`
+}
+
+type revisionChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+func buildFilmRevisions(domainRevisions []*domain.FilmRevision) []*filmRevision {
+	revisions := make([]*filmRevision, len(domainRevisions))
+	for i := range domainRevisions {
+		revisions[i] = buildFilmRevision(domainRevisions[i])
+	}
+	return revisions
+}
+
+func buildFilmRevision(domainRevision *domain.FilmRevision) *filmRevision {
+	credits := make([]*filmRevisionCredit, len(domainRevision.Credits))
+	for i, domainCredit := range domainRevision.Credits {
+		credits[i] = &filmRevisionCredit{
+			ActorId:       domainCredit.ActorId.Int64(),
+			Role:          domainCredit.Role.String(),
+			CharacterName: domainCredit.CharacterName.String(),
+			BillingOrder:  domainCredit.BillingOrder,
+		}
+	}
+
+	genreIds := make([]int64, len(domainRevision.GenreIds))
+	for i := range domainRevision.GenreIds {
+		genreIds[i] = domainRevision.GenreIds[i].Int64()
+	}
+
+	return &filmRevision{
+		Revision:    domainRevision.Revision,
+		UserId:      domainRevision.UserId,
+		Title:       domainRevision.Title.String(),
+		Description: domainRevision.Description.String(),
+		ReleaseDate: domainRevision.ReleaseDate.Time().Format(time.DateOnly),
+		Rating:      domainRevision.Rating.Uint8(),
+		Credits:     credits,
+		GenreIds:    genreIds,
+		CreatedAt:   domainRevision.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func buildActorRevisions(domainRevisions []*domain.ActorRevision) []*actorRevision {
+	revisions := make([]*actorRevision, len(domainRevisions))
+	for i := range domainRevisions {
+		revisions[i] = buildActorRevision(domainRevisions[i])
+	}
+	return revisions
+}
+
+func buildActorRevision(domainRevision *domain.ActorRevision) *actorRevision {
+	return &actorRevision{
+		Revision:  domainRevision.Revision,
+		UserId:    domainRevision.UserId,
+		Name:      domainRevision.Name.String(),
+		Sex:       domainRevision.Sex.Uint8(),
+		BirthDate: domainRevision.BirthDate.Time().Format(time.DateOnly),
+		CreatedAt: domainRevision.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func buildRevisionChanges(domainChanges []*domain.RevisionChange) []*revisionChange {
+	changes := make([]*revisionChange, len(domainChanges))
+	for i, domainChange := range domainChanges {
+		changes[i] = &revisionChange{
+			Field: domainChange.Field,
+			From:  domainChange.From,
+			To:    domainChange.To,
+		}
+	}
+	return changes
+}
@@ -0,0 +1,38 @@
+package http
+
+import (
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"time"
+)
+
+type trashItem struct {
+	EntityType string  `json:"entity_type"`
+	EntityId   int64   `json:"entity_id"`
+	Name       string  `json:"name"`
+	DeletedAt  string  `json:"deleted_at"`
+	DeletedBy  *int64  `json:"deleted_by,omitempty"`
+	PurgeAt    *string `json:"purge_at,omitempty"`
+}
+
+func buildTrashItems(domainItems []*domain.TrashItem) []*trashItem {
+	items := make([]*trashItem, len(domainItems))
+	for i := range domainItems {
+		items[i] = buildTrashItem(domainItems[i])
+	}
+	return items
+}
+
+func buildTrashItem(domainItem *domain.TrashItem) *trashItem {
+	item := &trashItem{
+		EntityType: domainItem.EntityType.String(),
+		EntityId:   domainItem.EntityId,
+		Name:       domainItem.Name,
+		DeletedAt:  domainItem.DeletedAt.Format(time.RFC3339),
+		DeletedBy:  domainItem.DeletedBy,
+	}
+	if domainItem.PurgeAt != nil {
+		purgeAt := domainItem.PurgeAt.Format(time.RFC3339)
+		item.PurgeAt = &purgeAt
+	}
+	return item
+}
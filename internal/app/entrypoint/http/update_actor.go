@@ -28,20 +28,24 @@ type updateActorResponseBody struct {
 }
 
 // @Summary		Update fully or partially an actor by path parameter 'id'
+// @Security		BearerAuth
 // @Security		BasicAuth
 // @Tags			actors
 // @Description	Update fully or partially an actor by path parameter 'id'
 // @ID				update-actor
 // @Accept			json
 // @Produce		json
-// @Param			id		path		integer					true	"Actors`s id that needs to be updated"
-// @Param			input	body		updateActorRequestBody	true	"Actor object with values that will be updated"
-// @Success		200		{object}	updateActorResponseBody
-// @Failure		400		{object}	apiv1.Response
-// @Failure		401		{object}	apiv1.Response
-// @Failure		403		{object}	apiv1.Response
-// @Failure		404		{object}	apiv1.Response
-// @Failure		500		{object}	apiv1.Response
+// @Param			id			path		integer					true	"Actors`s id that needs to be updated"
+// @Param			input		body		updateActorRequestBody	true	"Actor object with values that will be updated"
+// @Param			If-Match	header		string					false	"Entity tag of the actor version that is expected to be changed, like '\"1\"'"
+// @Success		200			{object}	updateActorResponseBody
+// @Header			200			{string}	ETag	"Entity tag of the actor version"
+// @Failure		400			{object}	apiv1.Response
+// @Failure		401			{object}	apiv1.Response
+// @Failure		403			{object}	apiv1.Response
+// @Failure		404			{object}	apiv1.Response
+// @Failure		412			{object}	apiv1.Response
+// @Failure		500			{object}	apiv1.Response
 // @Router			/actors/{id} [patch]
 func (h *Handler) UpdateActorHandler() http.HandlerFunc {
 	return func(rw http.ResponseWriter, request *http.Request) {
@@ -105,8 +109,17 @@ func (h *Handler) UpdateActorHandler() http.HandlerFunc {
 			domainActorSex = &convDomainActorSex
 		}
 
+		version, err := getIfMatchVersion(request)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageActorInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+
+			return
+		}
+
 		domainActor, err := h.actorService.Update(
+			request.Context(),
 			domain.ActorId(actorId),
+			version,
 			domainActorName,
 			domainActorSex,
 			domainBirthdate,
@@ -114,6 +127,8 @@ func (h *Handler) UpdateActorHandler() http.HandlerFunc {
 		if err != nil {
 			if errors.Is(err, domain.ErrActorNotFound) {
 				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageActorNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else if errors.Is(err, domain.ErrActorVersionMismatch) {
+				views.RenderJSON(rw, http.StatusPreconditionFailed, apiv1.Error(apiv1.CodePreconditionFailed, ErrMessageActorPreconditionFailed, apiv1.ErrorDescription{"error": err.Error()}))
 			} else {
 				log.Error("failed to update actor", "id", actorId, "error", err.Error())
 
@@ -131,6 +146,7 @@ func (h *Handler) UpdateActorHandler() http.HandlerFunc {
 			Films:     buildActorFilms(domainActor.Films),
 		})
 
+		setETag(rw, domainActor.Version)
 		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
 	}
 }
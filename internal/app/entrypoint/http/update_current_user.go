@@ -0,0 +1,95 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/service/user"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+)
+
+type updateCurrentUserRequestBody struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=8,max=72"`
+}
+
+type updateCurrentUserResponseBody struct {
+	Message string `json:"message"`
+}
+
+// @Summary		Change the password of the authenticated user
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			users
+// @Description	Change the password of the authenticated user. All refresh tokens of the user are revoked
+// @ID				update-current-user
+// @Accept			json
+// @Produce		json
+// @Param			input	body		updateCurrentUserRequestBody	true	"Current and new passwords"
+// @Success		200		{object}	updateCurrentUserResponseBody
+// @Failure		400		{object}	apiv1.Response
+// @Failure		401		{object}	apiv1.Response
+// @Failure		404		{object}	apiv1.Response
+// @Failure		500		{object}	apiv1.Response
+// @Router			/users/me [patch]
+func (h *Handler) UpdateCurrentUserHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "UpdateCurrentUserHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		principal, ok := user.PrincipalFromContext(request.Context())
+		if !ok {
+			views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageUserInternalServerError, apiv1.ErrorDescription{"error": "failed to get user from context"}))
+
+			return
+		}
+
+		var updateCurrentUserReqBody updateCurrentUserRequestBody
+		err := json.NewDecoder(request.Body).Decode(&updateCurrentUserReqBody)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageUserInvalidRequestBody, apiv1.ErrorDescription{"error": "invalid request body"}))
+
+			return
+		}
+
+		err = h.validator.Struct(&updateCurrentUserReqBody)
+		if err != nil {
+			errors, ok := err.(validator.ValidationErrors)
+			if !ok {
+				log.Error("failed to type cast validator.ValidationErrors", "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageUserInternalServerError, apiv1.ErrorDescription{"error": "failed to get validation errors"}))
+			} else {
+				errs := validateRequestBody(errors)
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageUserInvalidRequestBody, apiv1.ErrorDescription{"error": errs.Error()}))
+			}
+
+			return
+		}
+
+		err = h.userService.ChangePassword(request.Context(), principal.Id, updateCurrentUserReqBody.CurrentPassword, updateCurrentUserReqBody.NewPassword)
+		if err != nil {
+			if errors.Is(err, user.ErrInvalidPassword) {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageUserInvalidPassword, apiv1.ErrorDescription{"error": "'current_password' is invalid"}))
+			} else if errors.Is(err, user.ErrUserNotFound) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageUserNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to change user password", "id", principal.Id, "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageUserInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(&updateCurrentUserResponseBody{
+			Message: "Password has changed successfully",
+		})
+
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
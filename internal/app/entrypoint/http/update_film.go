@@ -14,11 +14,13 @@ import (
 )
 
 type updateFilmRequestBody struct {
-	Title       *string  `json:"title,omitempty" validate:"omitempty,min=1,max=150"`
-	Description *string  `json:"description,omitempty" validate:"omitempty,max=1000"`
-	ReleaseDate *string  `json:"release_date,omitempty" example:"2006-01-02"`
-	Rating      *uint8   `json:"rating,omitempty" validate:"omitempty,numeric,min=0,max=10"`
-	ActorIds    *[]int64 `json:"actor_ids,omitempty" validate:"omitempty,dive,numeric" example:"1,2,3"`
+	Title       *string               `json:"title,omitempty" validate:"omitempty,min=1,max=150"`
+	Description *string               `json:"description,omitempty" validate:"omitempty,max=1000"`
+	ReleaseDate *string               `json:"release_date,omitempty" example:"2006-01-02"`
+	Rating      *uint8                `json:"rating,omitempty" validate:"omitempty,numeric,min=0,max=10"`
+	ActorIds    *[]int64              `json:"actor_ids,omitempty" validate:"omitempty,dive,numeric" example:"1,2,3"`
+	Credits     *[]*filmCreditRequest `json:"credits,omitempty" validate:"omitempty,dive"`
+	GenreIds    *[]int64              `json:"genre_ids,omitempty" validate:"omitempty,dive,numeric" example:"1,2"`
 }
 
 type updateFilmResponseBody struct {
@@ -28,23 +30,29 @@ type updateFilmResponseBody struct {
 	ReleaseDate string       `json:"release_date"`
 	Rating      uint8        `json:"rating"`
 	Actors      []*filmActor `json:"actors"`
+	Genres      []*genre     `json:"genres"`
 }
 
 // @Summary		Update fully or partially a film by path parameter 'id'
+// @Security		BearerAuth
 // @Security		BasicAuth
 // @Tags			films
-// @Description	Update fully or partially a film by path parameter 'id'
+// @Description	Update fully or partially a film by path parameter 'id'.
+// @Description	'credits' replace all credits of the film, 'actor_ids' alone replace only the credits with the 'actor' role
 // @ID				update-film
 // @Accept			json
 // @Produce		json
-// @Param			id		path		integer					true	"Films`s id that needs to be updated"
-// @Param			input	body		updateFilmRequestBody	true	"Film object with values that will be updated"
-// @Success		200		{object}	updateFilmResponseBody
-// @Failure		400		{object}	apiv1.Response
-// @Failure		401		{object}	apiv1.Response
-// @Failure		403		{object}	apiv1.Response
-// @Failure		404		{object}	apiv1.Response
-// @Failure		500		{object}	apiv1.Response
+// @Param			id			path		integer					true	"Films`s id that needs to be updated"
+// @Param			input		body		updateFilmRequestBody	true	"Film object with values that will be updated"
+// @Param			If-Match	header		string					false	"Entity tag of the film version that is expected to be changed, like '\"1\"'"
+// @Success		200			{object}	updateFilmResponseBody
+// @Header			200			{string}	ETag	"Entity tag of the film version"
+// @Failure		400			{object}	apiv1.Response
+// @Failure		401			{object}	apiv1.Response
+// @Failure		403			{object}	apiv1.Response
+// @Failure		404			{object}	apiv1.Response
+// @Failure		412			{object}	apiv1.Response
+// @Failure		500			{object}	apiv1.Response
 // @Router			/films/{id} [patch]
 func (h *Handler) UpdateFilmHandler() http.HandlerFunc {
 	return func(rw http.ResponseWriter, request *http.Request) {
@@ -120,19 +128,48 @@ func (h *Handler) UpdateFilmHandler() http.HandlerFunc {
 			domainActorIds = &convDomainActorIds
 		}
 
+		var domainCredits *[]*domain.FilmCreditParams
+		if updateFilmReqBody.Credits != nil {
+			convDomainCredits := buildDomainFilmCredits(*updateFilmReqBody.Credits)
+			domainCredits = &convDomainCredits
+		}
+
+		var domainGenreIds *[]domain.GenreId
+		if updateFilmReqBody.GenreIds != nil {
+			convDomainGenreIds := buildDomainGenreIds(*updateFilmReqBody.GenreIds)
+			domainGenreIds = &convDomainGenreIds
+		}
+
+		version, err := getIfMatchVersion(request)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageFilmInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+
+			return
+		}
+
 		domainFilm, err := h.filmService.Update(
+			request.Context(),
 			domain.FilmId(filmId),
+			version,
 			domainFilmTitle,
 			domainFilmDescription,
 			domainReleaseDate,
 			domainFilmRating,
 			domainActorIds,
+			domainCredits,
+			domainGenreIds,
 		)
 		if err != nil {
 			if errors.Is(err, domain.ErrFilmNotFound) {
 				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageFilmNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else if errors.Is(err, domain.ErrFilmVersionMismatch) {
+				views.RenderJSON(rw, http.StatusPreconditionFailed, apiv1.Error(apiv1.CodePreconditionFailed, ErrMessageFilmPreconditionFailed, apiv1.ErrorDescription{"error": err.Error()}))
 			} else if errors.Is(err, domain.ErrFilmActorsNotFound) {
 				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageFilmActorsNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else if errors.Is(err, domain.ErrInvalidFilmCredit) {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageFilmInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+			} else if errors.Is(err, domain.ErrFilmGenresNotFound) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageFilmGenresNotFound, apiv1.ErrorDescription{"error": err.Error()}))
 			} else {
 				log.Error("failed to update film", "id", filmId, "error", err.Error())
 
@@ -149,8 +186,10 @@ func (h *Handler) UpdateFilmHandler() http.HandlerFunc {
 			ReleaseDate: domainFilm.ReleaseDate.Time().Format(time.DateOnly),
 			Rating:      domainFilm.Rating.Uint8(),
 			Actors:      buildFilmActors(domainFilm.Actors),
+			Genres:      buildGenres(domainFilm.Genres),
 		})
 
+		setETag(rw, domainFilm.Version)
 		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
 	}
 }
@@ -0,0 +1,99 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type updateGenreRequestBody struct {
+	Name string `json:"name" validate:"required,max=50" example:"Drama"`
+}
+
+type updateGenreResponseBody struct {
+	*genre
+}
+
+// @Summary		Rename a genre by path parameter 'id'
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			genres
+// @Description	Rename a genre by path parameter 'id'
+// @ID				update-genre
+// @Accept			json
+// @Produce		json
+// @Param			id		path		integer					true	"Genre`s id that needs to be updated"
+// @Param			input	body		updateGenreRequestBody	true	"Genre object with a new name"
+// @Success		200		{object}	updateGenreResponseBody
+// @Failure		400		{object}	apiv1.Response
+// @Failure		401		{object}	apiv1.Response
+// @Failure		403		{object}	apiv1.Response
+// @Failure		404		{object}	apiv1.Response
+// @Failure		409		{object}	apiv1.Response
+// @Failure		500		{object}	apiv1.Response
+// @Router			/genres/{id} [patch]
+func (h *Handler) UpdateGenreHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "UpdateGenreHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		genreIdPathParam := request.PathValue("id")
+		genreId, err := strconv.ParseInt(genreIdPathParam, 10, 64)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageGenreInvalidRequestBody, apiv1.ErrorDescription{"error": "path parameter 'id' must be an integer"}))
+
+			return
+		}
+
+		var updateGenreReqBody updateGenreRequestBody
+		err = json.NewDecoder(request.Body).Decode(&updateGenreReqBody)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageGenreInvalidRequestBody, apiv1.ErrorDescription{"error": "invalid request body"}))
+
+			return
+		}
+
+		err = h.validator.Struct(&updateGenreReqBody)
+		if err != nil {
+			errors, ok := err.(validator.ValidationErrors)
+			if !ok {
+				log.Error("failed to type cast validator.ValidationErrors", "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageGenreInternalServerError, apiv1.ErrorDescription{"error": "failed to get validation errors"}))
+			} else {
+				errs := validateRequestBody(errors)
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageGenreInvalidRequestBody, apiv1.ErrorDescription{"error": errs.Error()}))
+			}
+
+			return
+		}
+
+		domainGenre, err := h.genreService.Update(request.Context(), domain.GenreId(genreId), domain.GenreName(updateGenreReqBody.Name))
+		if err != nil {
+			if errors.Is(err, domain.ErrGenreNotFound) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageGenreNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else if errors.Is(err, domain.ErrGenreAlreadyExists) {
+				views.RenderJSON(rw, http.StatusConflict, apiv1.Error(apiv1.CodeConflict, ErrMessageGenreAlreadyExists, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to update genre", "id", genreId, "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageGenreInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(&updateGenreResponseBody{
+			genre: buildGenre(domainGenre),
+		})
+
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
@@ -0,0 +1,111 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type updateReviewRequestBody struct {
+	Rating *uint8  `json:"rating,omitempty" validate:"omitempty,min=1,max=10" example:"9"`
+	Text   *string `json:"text,omitempty" validate:"omitempty,max=5000" example:"Even better the second time"`
+}
+
+type updateReviewResponseBody struct {
+	*review
+}
+
+// @Summary		Update own review by path parameter 'id'
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			reviews
+// @Description	Update fully or partially own review by path parameter 'id'. An empty 'text' removes the text of the review
+// @ID				update-review
+// @Accept			json
+// @Produce		json
+// @Param			id		path		integer					true	"Review`s id that needs to be updated"
+// @Param			input	body		updateReviewRequestBody	true	"Review object with fields that need to be updated"
+// @Success		200		{object}	updateReviewResponseBody
+// @Failure		400		{object}	apiv1.Response
+// @Failure		401		{object}	apiv1.Response
+// @Failure		403		{object}	apiv1.Response
+// @Failure		404		{object}	apiv1.Response
+// @Failure		500		{object}	apiv1.Response
+// @Router			/reviews/{id} [patch]
+func (h *Handler) UpdateReviewHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "UpdateReviewHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		reviewIdPathParam := request.PathValue("id")
+		reviewId, err := strconv.ParseInt(reviewIdPathParam, 10, 64)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageReviewInvalidRequestBody, apiv1.ErrorDescription{"error": "path parameter 'id' must be an integer"}))
+
+			return
+		}
+
+		var updateReviewReqBody updateReviewRequestBody
+		err = json.NewDecoder(request.Body).Decode(&updateReviewReqBody)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageReviewInvalidRequestBody, apiv1.ErrorDescription{"error": "invalid request body"}))
+
+			return
+		}
+
+		err = h.validator.Struct(&updateReviewReqBody)
+		if err != nil {
+			errors, ok := err.(validator.ValidationErrors)
+			if !ok {
+				log.Error("failed to type cast validator.ValidationErrors", "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageReviewInternalServerError, apiv1.ErrorDescription{"error": "failed to get validation errors"}))
+			} else {
+				errs := validateRequestBody(errors)
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageReviewInvalidRequestBody, apiv1.ErrorDescription{"error": errs.Error()}))
+			}
+
+			return
+		}
+
+		var domainRating *domain.ReviewRating
+		if updateReviewReqBody.Rating != nil {
+			convDomainRating := domain.ReviewRating(*updateReviewReqBody.Rating)
+			domainRating = &convDomainRating
+		}
+
+		var domainText *domain.ReviewText
+		if updateReviewReqBody.Text != nil {
+			convDomainText := domain.ReviewText(*updateReviewReqBody.Text)
+			domainText = &convDomainText
+		}
+
+		domainReview, err := h.reviewService.Update(request.Context(), domain.ReviewId(reviewId), domainRating, domainText)
+		if err != nil {
+			if errors.Is(err, domain.ErrReviewNotFound) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageReviewNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else if errors.Is(err, domain.ErrReviewForbidden) {
+				views.RenderJSON(rw, http.StatusForbidden, apiv1.Error(apiv1.CodeForbidden, ErrMessageReviewForbidden, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to update review", "id", reviewId, "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageReviewInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(&updateReviewResponseBody{
+			review: buildReview(domainReview),
+		})
+
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
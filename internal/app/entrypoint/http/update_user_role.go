@@ -0,0 +1,98 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/vaberof/vk-internship-task/internal/app/entrypoint/http/views"
+	"github.com/vaberof/vk-internship-task/internal/service/user"
+	"github.com/vaberof/vk-internship-task/pkg/http/protocols/apiv1"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type updateUserRoleRequestBody struct {
+	Role string `json:"role" validate:"required" example:"admin"`
+}
+
+type updateUserRoleResponseBody struct {
+	*userAccount
+}
+
+// @Summary		Change a role of a user by path parameter 'id'
+// @Security		BearerAuth
+// @Security		BasicAuth
+// @Tags			users
+// @Description	Change a role of a user by path parameter 'id'
+// @ID				update-user-role
+// @Accept			json
+// @Produce		json
+// @Param			id		path		integer						true	"User`s id whose role needs to be changed"
+// @Param			input	body		updateUserRoleRequestBody	true	"New role"
+// @Success		200		{object}	updateUserRoleResponseBody
+// @Failure		400		{object}	apiv1.Response
+// @Failure		401		{object}	apiv1.Response
+// @Failure		403		{object}	apiv1.Response
+// @Failure		404		{object}	apiv1.Response
+// @Failure		500		{object}	apiv1.Response
+// @Router			/users/{id}/role [patch]
+func (h *Handler) UpdateUserRoleHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, request *http.Request) {
+		const handlerName = "UpdateUserRoleHandler"
+
+		log := h.logger.With(slog.String("handlerName", handlerName))
+
+		userIdPathParam := request.PathValue("id")
+		userId, err := strconv.ParseInt(userIdPathParam, 10, 64)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageUserInvalidRequestBody, apiv1.ErrorDescription{"error": "path parameter 'id' must be an integer"}))
+
+			return
+		}
+
+		var updateUserRoleReqBody updateUserRoleRequestBody
+		err = json.NewDecoder(request.Body).Decode(&updateUserRoleReqBody)
+		if err != nil {
+			views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageUserInvalidRequestBody, apiv1.ErrorDescription{"error": "invalid request body"}))
+
+			return
+		}
+
+		err = h.validator.Struct(&updateUserRoleReqBody)
+		if err != nil {
+			errors, ok := err.(validator.ValidationErrors)
+			if !ok {
+				log.Error("failed to type cast validator.ValidationErrors", "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageUserInternalServerError, apiv1.ErrorDescription{"error": "failed to get validation errors"}))
+			} else {
+				errs := validateRequestBody(errors)
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageUserInvalidRequestBody, apiv1.ErrorDescription{"error": errs.Error()}))
+			}
+
+			return
+		}
+
+		usr, err := h.userService.UpdateRole(request.Context(), userId, user.UserRole(updateUserRoleReqBody.Role))
+		if err != nil {
+			if errors.Is(err, user.ErrInvalidUserRole) {
+				views.RenderJSON(rw, http.StatusBadRequest, apiv1.Error(apiv1.CodeBadRequest, ErrMessageUserInvalidRequestBody, apiv1.ErrorDescription{"error": err.Error()}))
+			} else if errors.Is(err, user.ErrUserNotFound) {
+				views.RenderJSON(rw, http.StatusNotFound, apiv1.Error(apiv1.CodeNotFound, ErrMessageUserNotFound, apiv1.ErrorDescription{"error": err.Error()}))
+			} else {
+				log.Error("failed to update user role", "id", userId, "error", err.Error())
+
+				views.RenderJSON(rw, http.StatusInternalServerError, apiv1.Error(apiv1.CodeInternalError, ErrMessageUserInternalServerError, apiv1.ErrorDescription{"error": err.Error()}))
+			}
+
+			return
+		}
+
+		payload, _ := json.Marshal(&updateUserRoleResponseBody{
+			userAccount: buildUserAccount(usr),
+		})
+
+		views.RenderJSON(rw, http.StatusOK, apiv1.Success(payload))
+	}
+}
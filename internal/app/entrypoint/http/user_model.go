@@ -0,0 +1,29 @@
+package http
+
+import (
+	"github.com/vaberof/vk-internship-task/internal/service/user"
+)
+
+type userAccount struct {
+	Id       int64  `json:"id"`
+	Email    string `json:"email"`
+	Role     string `json:"role"`
+	Disabled bool   `json:"disabled"`
+}
+
+func buildUserAccounts(users []*user.User) []*userAccount {
+	userAccounts := make([]*userAccount, len(users))
+	for i, usr := range users {
+		userAccounts[i] = buildUserAccount(usr)
+	}
+	return userAccounts
+}
+
+func buildUserAccount(usr *user.User) *userAccount {
+	return &userAccount{
+		Id:       usr.Id,
+		Email:    usr.Email,
+		Role:     usr.Role.String(),
+		Disabled: usr.Disabled,
+	}
+}
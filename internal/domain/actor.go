@@ -28,10 +28,14 @@ func (actorBirthDate *ActorBirthDate) Time() time.Time {
 	return time.Time(*actorBirthDate)
 }
 
+// Actor is a catalog actor. Version grows with every update of the actor and is filled only when a single actor is read or written
 type Actor struct {
 	Id        ActorId
 	Name      ActorName
 	Sex       ActorSex
 	BirthDate ActorBirthDate
 	Films     []*Film
+	CreatedBy *int64
+	UpdatedBy *int64
+	Version   int64
 }
@@ -0,0 +1,45 @@
+package domain
+
+// ActorBatchOperation creates, updates or deletes an actor within a batch.
+// Id is the id of the updated or deleted actor, Create and Update hold the values of the respective operations.
+// Version, like the 'If-Match' header of the single actor requests, is the version the updated or deleted actor must have,
+// the version is not checked if it is nil.
+type ActorBatchOperation struct {
+	Type    BatchOperationType
+	Id      ActorId
+	Version *int64
+	Create  *ActorCreateParams
+	Update  *ActorUpdateParams
+}
+
+// ActorCreateParams are the values of a created actor
+type ActorCreateParams struct {
+	Name      ActorName
+	Sex       ActorSex
+	BirthDate ActorBirthDate
+}
+
+// ActorUpdateParams are the changed values of an updated actor
+type ActorUpdateParams struct {
+	Name      *ActorName
+	Sex       *ActorSex
+	BirthDate *ActorBirthDate
+}
+
+// ActorBatchItem is the outcome of an operation with the same index in the batch.
+// Actor is the created or updated actor, Err is set for failed operations.
+type ActorBatchItem struct {
+	Index  int
+	Type   BatchOperationType
+	Id     ActorId
+	Status BatchItemStatus
+	Actor  *Actor
+	Err    error
+}
+
+// ActorBatchReport describes the outcome of a batch. The changes are saved only if Committed is true.
+type ActorBatchReport struct {
+	Mode      BatchMode
+	Committed bool
+	Items     []*ActorBatchItem
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidActorFilter = errors.New("invalid actor filter")
+
+// ActorFilter narrows down a list of actors. Zero fields do not filter, set fields are combined with AND.
+// Name is a case-insensitive fragment, the birth date range includes its bounds.
+// FilmId keeps the actors credited in the film with the 'actor' role.
+type ActorFilter struct {
+	Name     ActorName
+	Sex      *ActorSex
+	BornFrom *ActorBirthDate
+	BornTo   *ActorBirthDate
+	FilmId   *FilmId
+}
+
+// Validate checks that the birth date range is not reversed
+func (filter *ActorFilter) Validate() error {
+	if filter == nil {
+		return nil
+	}
+	if filter.BornFrom != nil && filter.BornTo != nil && filter.BornFrom.Time().After(filter.BornTo.Time()) {
+		return fmt.Errorf("%w: birth date range is reversed", ErrInvalidActorFilter)
+	}
+	return nil
+}
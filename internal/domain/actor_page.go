@@ -0,0 +1,25 @@
+package domain
+
+// ActorCursor points at the last actor of a previously returned page.
+// It holds every sortable key of that actor, so it stays valid for any sort order.
+type ActorCursor struct {
+	Id         ActorId
+	Name       ActorName
+	BirthDate  ActorBirthDate
+	FilmsCount int64
+}
+
+type ActorsPage struct {
+	Actors     []*Actor
+	NextCursor *ActorCursor
+	Total      *int64
+}
+
+func NewActorCursor(actor *Actor) *ActorCursor {
+	return &ActorCursor{
+		Id:         actor.Id,
+		Name:       actor.Name,
+		BirthDate:  actor.BirthDate,
+		FilmsCount: int64(len(actor.Films)),
+	}
+}
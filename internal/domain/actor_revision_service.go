@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"github.com/vaberof/vk-internship-task/pkg/logging/logs"
+	"log/slog"
+)
+
+var (
+	ErrActorRevisionNotFound = errors.New("actor revision not found")
+)
+
+type ActorRevisionService interface {
+	List(ctx context.Context, actorId ActorId, limit, offset int) (*ActorRevisionsPage, error)
+	Diff(ctx context.Context, actorId ActorId, from, to int64) ([]*RevisionChange, error)
+	// Revert brings the actor back to the state of the revision, like FilmRevisionService.Revert
+	Revert(ctx context.Context, actorId ActorId, revision int64, version *int64) (*Actor, error)
+}
+
+type actorRevisionServiceImpl struct {
+	actorRevisionStorage ActorRevisionStorage
+	actorStorage         ActorStorage
+	actorService         ActorService
+
+	logger *slog.Logger
+}
+
+func NewActorRevisionService(actorRevisionStorage ActorRevisionStorage, actorStorage ActorStorage, actorService ActorService, logsBuilder *logs.Logs) ActorRevisionService {
+	logger := logsBuilder.WithName("domain.service.actorrevision")
+	return &actorRevisionServiceImpl{
+		actorRevisionStorage: actorRevisionStorage,
+		actorStorage:         actorStorage,
+		actorService:         actorService,
+		logger:               logger,
+	}
+}
+
+func (a *actorRevisionServiceImpl) List(ctx context.Context, actorId ActorId, limit, offset int) (*ActorRevisionsPage, error) {
+	const operation = "List"
+
+	log := a.logger.With(
+		slog.String("operation", operation),
+		slog.Int64("actorId", actorId.Int64()),
+		slog.Int("limit", limit),
+		slog.Int("offset", offset))
+
+	log.Info("listing revisions of an actor")
+
+	if err := a.checkActor(ctx, log, actorId); err != nil {
+		return nil, err
+	}
+
+	revisions, err := a.actorRevisionStorage.List(ctx, actorId, limit, offset)
+	if err != nil {
+		log.Error("failed to list revisions", "error", err)
+		return nil, err
+	}
+
+	total, err := a.actorRevisionStorage.Count(ctx, actorId)
+	if err != nil {
+		log.Error("failed to count revisions", "error", err)
+		return nil, err
+	}
+
+	log.Info("revisions have listed")
+
+	return &ActorRevisionsPage{
+		Revisions: revisions,
+		Total:     total,
+	}, nil
+}
+
+func (a *actorRevisionServiceImpl) Diff(ctx context.Context, actorId ActorId, from, to int64) ([]*RevisionChange, error) {
+	const operation = "Diff"
+
+	log := a.logger.With(
+		slog.String("operation", operation),
+		slog.Int64("actorId", actorId.Int64()),
+		slog.Int64("from", from),
+		slog.Int64("to", to))
+
+	log.Info("comparing revisions of an actor")
+
+	if err := a.checkActor(ctx, log, actorId); err != nil {
+		return nil, err
+	}
+
+	fromRevision, err := a.getRevision(ctx, log, actorId, from)
+	if err != nil {
+		return nil, err
+	}
+
+	toRevision, err := a.getRevision(ctx, log, actorId, to)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := DiffActorRevisions(fromRevision, toRevision)
+
+	log.Info("revisions have compared", "changes", len(changes))
+
+	return changes, nil
+}
+
+func (a *actorRevisionServiceImpl) Revert(ctx context.Context, actorId ActorId, revision int64, version *int64) (*Actor, error) {
+	const operation = "Revert"
+
+	log := a.logger.With(
+		slog.String("operation", operation),
+		slog.Int64("actorId", actorId.Int64()),
+		slog.Int64("revision", revision))
+
+	log.Info("reverting an actor")
+
+	if err := a.checkActor(ctx, log, actorId); err != nil {
+		return nil, err
+	}
+
+	domainRevision, err := a.getRevision(ctx, log, actorId, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	domainActor, err := a.actorService.Update(ctx, actorId, version, &domainRevision.Name, &domainRevision.Sex, &domainRevision.BirthDate)
+	if err != nil {
+		log.Warn("failed to revert an actor", "error", err)
+		return nil, err
+	}
+
+	log.Info("actor has reverted", "version", domainActor.Version)
+
+	return domainActor, nil
+}
+
+func (a *actorRevisionServiceImpl) checkActor(ctx context.Context, log *slog.Logger, actorId ActorId) error {
+	exists, err := a.actorStorage.IsExists(ctx, actorId)
+	if err != nil {
+		log.Error("failed to check an actor", "error", err)
+		return err
+	}
+	if !exists {
+		log.Warn("actor not found", "error", fmt.Sprintf("actor with id '%d' not found", actorId.Int64()))
+		return ErrActorNotFound
+	}
+	return nil
+}
+
+func (a *actorRevisionServiceImpl) getRevision(ctx context.Context, log *slog.Logger, actorId ActorId, revision int64) (*ActorRevision, error) {
+	domainRevision, err := a.actorRevisionStorage.Get(ctx, actorId, revision)
+	if err != nil {
+		if errors.Is(err, storage.ErrActorRevisionNotFound) {
+			log.Warn("actor revision not found", "error", fmt.Sprintf("actor with id '%d' has not revision '%d'", actorId.Int64(), revision))
+			return nil, ErrActorRevisionNotFound
+		}
+
+		log.Error("failed to get an actor revision", "error", err)
+		return nil, err
+	}
+	return domainRevision, nil
+}
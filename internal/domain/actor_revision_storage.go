@@ -0,0 +1,11 @@
+package domain
+
+import "context"
+
+// ActorRevisionStorage reads revisions of actors, like FilmRevisionStorage.
+// Get fails with storage.ErrActorRevisionNotFound if the actor has no such revision.
+type ActorRevisionStorage interface {
+	List(ctx context.Context, actorId ActorId, limit, offset int) ([]*ActorRevision, error)
+	Count(ctx context.Context, actorId ActorId) (int64, error)
+	Get(ctx context.Context, actorId ActorId, revision int64) (*ActorRevision, error)
+}
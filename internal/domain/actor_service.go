@@ -1,21 +1,32 @@
 package domain
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
 	"github.com/vaberof/vk-internship-task/pkg/logging/logs"
 	"log/slog"
 )
 
 var (
-	ErrActorNotFound = errors.New("actor not found")
+	ErrActorNotFound        = errors.New("actor not found")
+	ErrActorVersionMismatch = errors.New("actor version mismatch")
 )
 
 type ActorService interface {
-	Create(name ActorName, sex ActorSex, birthDate ActorBirthDate) (*Actor, error)
-	Update(id ActorId, name *ActorName, sex *ActorSex, birthDate *ActorBirthDate) (*Actor, error)
-	Delete(id ActorId) error
-	List(limit, offset int) ([]*Actor, error)
+	Create(ctx context.Context, name ActorName, sex ActorSex, birthDate ActorBirthDate) (*Actor, error)
+	// Update and Delete check that the actor has the given version, if it is set
+	Update(ctx context.Context, id ActorId, version *int64, name *ActorName, sex *ActorSex, birthDate *ActorBirthDate) (*Actor, error)
+	Delete(ctx context.Context, id ActorId, version *int64) error
+	// Restore brings the actor back from the trash
+	Restore(ctx context.Context, id ActorId) (*Actor, error)
+	GetById(ctx context.Context, id ActorId) (*Actor, error)
+	List(ctx context.Context, filter *ActorFilter, sort ActorSort, cursor *ActorCursor, withTotal bool, limit, offset int) (*ActorsPage, error)
+	// Export streams all actors with their films to fn one by one
+	Export(ctx context.Context, fn func(actor *Actor) error) error
+	// Batch applies the operations in a single transaction, the outcome of each of them is reported in the items of the report
+	Batch(ctx context.Context, mode BatchMode, operations []*ActorBatchOperation) (*ActorBatchReport, error)
 }
 
 type actorServiceImpl struct {
@@ -32,7 +43,7 @@ func NewActorService(actorStorage ActorStorage, logsBuilder *logs.Logs) ActorSer
 	}
 }
 
-func (a *actorServiceImpl) Create(name ActorName, sex ActorSex, birthDate ActorBirthDate) (*Actor, error) {
+func (a *actorServiceImpl) Create(ctx context.Context, name ActorName, sex ActorSex, birthDate ActorBirthDate) (*Actor, error) {
 	const operation = "Create"
 
 	log := a.logger.With(
@@ -41,7 +52,7 @@ func (a *actorServiceImpl) Create(name ActorName, sex ActorSex, birthDate ActorB
 
 	log.Info("creating an actor")
 
-	domainActor, err := a.actorStorage.Create(name, sex, birthDate)
+	domainActor, err := a.actorStorage.Create(ctx, name, sex, birthDate, principalId(ctx))
 	if err != nil {
 		log.Error("failed to create an actor", "error", err)
 		return nil, err
@@ -52,7 +63,7 @@ func (a *actorServiceImpl) Create(name ActorName, sex ActorSex, birthDate ActorB
 	return domainActor, nil
 }
 
-func (a *actorServiceImpl) Update(id ActorId, name *ActorName, sex *ActorSex, birthDate *ActorBirthDate) (*Actor, error) {
+func (a *actorServiceImpl) Update(ctx context.Context, id ActorId, version *int64, name *ActorName, sex *ActorSex, birthDate *ActorBirthDate) (*Actor, error) {
 	const operation = "Update"
 
 	log := a.logger.With(
@@ -61,7 +72,7 @@ func (a *actorServiceImpl) Update(id ActorId, name *ActorName, sex *ActorSex, bi
 
 	log.Info("updating an actor")
 
-	exists, err := a.actorStorage.IsExists(id)
+	exists, err := a.actorStorage.IsExists(ctx, id)
 	if err != nil {
 		log.Error("failed to update an actor", "error", err)
 		return nil, err
@@ -71,8 +82,13 @@ func (a *actorServiceImpl) Update(id ActorId, name *ActorName, sex *ActorSex, bi
 		return nil, ErrActorNotFound
 	}
 
-	domainActor, err := a.actorStorage.Update(id, name, sex, birthDate)
+	domainActor, err := a.actorStorage.Update(ctx, id, version, name, sex, birthDate, principalId(ctx))
 	if err != nil {
+		if errors.Is(err, storage.ErrActorVersionMismatch) {
+			log.Warn("failed to update an actor", "error", fmt.Sprintf("actor with id '%d' has not version '%d'", id.Int64(), *version))
+			return nil, ErrActorVersionMismatch
+		}
+
 		log.Error("failed to update an actor", "error", err)
 		return nil, err
 	}
@@ -82,7 +98,7 @@ func (a *actorServiceImpl) Update(id ActorId, name *ActorName, sex *ActorSex, bi
 	return domainActor, nil
 }
 
-func (a *actorServiceImpl) Delete(id ActorId) error {
+func (a *actorServiceImpl) Delete(ctx context.Context, id ActorId, version *int64) error {
 	const operation = "Delete"
 
 	log := a.logger.With(
@@ -91,7 +107,7 @@ func (a *actorServiceImpl) Delete(id ActorId) error {
 
 	log.Info("deleting an actor")
 
-	exists, err := a.actorStorage.IsExists(id)
+	exists, err := a.actorStorage.IsExists(ctx, id)
 	if err != nil {
 		log.Error("failed to delete an actor", "error", err)
 		return err
@@ -101,8 +117,13 @@ func (a *actorServiceImpl) Delete(id ActorId) error {
 		return ErrActorNotFound
 	}
 
-	err = a.actorStorage.Delete(id)
+	err = a.actorStorage.Delete(ctx, id, version, principalId(ctx))
 	if err != nil {
+		if errors.Is(err, storage.ErrActorVersionMismatch) {
+			log.Warn("failed to delete an actor", "error", fmt.Sprintf("actor with id '%d' has not version '%d'", id.Int64(), *version))
+			return ErrActorVersionMismatch
+		}
+
 		log.Error("failed to delete an actor", "error", err)
 		return err
 	}
@@ -112,24 +133,226 @@ func (a *actorServiceImpl) Delete(id ActorId) error {
 	return nil
 }
 
-func (a *actorServiceImpl) List(limit, offset int) ([]*Actor, error) {
+func (a *actorServiceImpl) Restore(ctx context.Context, id ActorId) (*Actor, error) {
+	const operation = "Restore"
+
+	log := a.logger.With(
+		slog.String("operation", operation),
+		slog.Int64("id", id.Int64()))
+
+	log.Info("restoring an actor")
+
+	domainActor, err := a.actorStorage.Restore(ctx, id, principalId(ctx))
+	if err != nil {
+		if errors.Is(err, storage.ErrActorNotFound) {
+			log.Warn("failed to restore an actor", "error", fmt.Sprintf("deleted actor with id '%d' not found", id.Int64()))
+			return nil, ErrActorNotFound
+		}
+
+		log.Error("failed to restore an actor", "error", err)
+		return nil, err
+	}
+
+	log.Info("actor has restored")
+
+	return domainActor, nil
+}
+
+func (a *actorServiceImpl) GetById(ctx context.Context, id ActorId) (*Actor, error) {
+	const operation = "GetById"
+
+	log := a.logger.With(
+		slog.String("operation", operation),
+		slog.Int64("id", id.Int64()))
+
+	log.Info("getting an actor")
+
+	domainActor, err := a.actorStorage.GetById(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrActorNotFound) {
+			log.Warn("failed to get an actor", "error", fmt.Sprintf("actor with id '%d' not found", id.Int64()))
+			return nil, ErrActorNotFound
+		}
+
+		log.Error("failed to get an actor", "error", err)
+		return nil, err
+	}
+
+	log.Info("actor has got")
+
+	return domainActor, nil
+}
+
+func (a *actorServiceImpl) List(ctx context.Context, filter *ActorFilter, sort ActorSort, cursor *ActorCursor, withTotal bool, limit, offset int) (*ActorsPage, error) {
 	const operation = "List"
 
 	log := a.logger.With(
 		slog.String("operation", operation),
 		slog.Int("limit", limit),
 		slog.Int("offset", offset),
+		slog.Any("filter", filter),
 	)
 
 	log.Info("listing actors")
 
-	domainActors, err := a.actorStorage.List(limit, offset)
+	if err := filter.Validate(); err != nil {
+		log.Warn("failed to list actors", "error", err)
+		return nil, err
+	}
+
+	domainActors, nextCursor, err := a.actorStorage.List(ctx, filter, sort, cursor, limit, offset)
 	if err != nil {
 		log.Error("failed to list actors", "error", err)
 		return nil, err
 	}
 
+	actorsPage := &ActorsPage{
+		Actors:     domainActors,
+		NextCursor: nextCursor,
+	}
+
+	if withTotal {
+		total, err := a.actorStorage.Count(ctx, filter)
+		if err != nil {
+			log.Error("failed to count actors", "error", err)
+			return nil, err
+		}
+		actorsPage.Total = &total
+	}
+
 	log.Info("actors have listed")
 
-	return domainActors, nil
+	return actorsPage, nil
+}
+
+func (a *actorServiceImpl) Export(ctx context.Context, fn func(actor *Actor) error) error {
+	const operation = "Export"
+
+	log := a.logger.With(slog.String("operation", operation))
+
+	log.Info("exporting actors")
+
+	var exported int
+
+	err := a.actorStorage.Export(ctx, func(actor *Actor) error {
+		if err := fn(actor); err != nil {
+			return err
+		}
+		exported++
+		return nil
+	})
+	if err != nil {
+		log.Error("failed to export actors", "error", err, "exported", exported)
+		return err
+	}
+
+	log.Info("actors have exported", "exported", exported)
+
+	return nil
+}
+
+func (a *actorServiceImpl) Batch(ctx context.Context, mode BatchMode, operations []*ActorBatchOperation) (*ActorBatchReport, error) {
+	const operation = "Batch"
+
+	log := a.logger.With(
+		slog.String("operation", operation),
+		slog.String("mode", string(mode)),
+		slog.Int("operations", len(operations)),
+	)
+
+	log.Info("applying a batch of actors")
+
+	if err := validateBatch(mode, len(operations)); err != nil {
+		log.Warn("failed to apply a batch of actors", "error", err)
+		return nil, err
+	}
+
+	report := &ActorBatchReport{
+		Mode:  mode,
+		Items: make([]*ActorBatchItem, len(operations)),
+	}
+
+	var checkedOperations []*ActorBatchOperation
+	var checkedItems []*ActorBatchItem
+	var failed bool
+
+	for i, batchOperation := range operations {
+		item := &ActorBatchItem{Index: i, Type: batchOperation.Type, Id: batchOperation.Id}
+		report.Items[i] = item
+
+		if err := checkActorBatchOperation(batchOperation); err != nil {
+			item.Status = BatchItemFailed
+			item.Err = err
+			failed = true
+			continue
+		}
+
+		checkedOperations = append(checkedOperations, batchOperation)
+		checkedItems = append(checkedItems, item)
+	}
+
+	// Nothing of a transactional batch can be applied once any of its operations is invalid
+	if mode == BatchModeTransactional && failed {
+		for _, item := range checkedItems {
+			item.Status = BatchItemAborted
+		}
+
+		log.Warn("batch of actors has not applied")
+
+		return report, nil
+	}
+
+	if len(checkedOperations) > 0 {
+		storedItems, committed, err := a.actorStorage.Batch(ctx, mode, checkedOperations, principalId(ctx))
+		if err != nil {
+			log.Error("failed to apply a batch of actors", "error", err)
+			return nil, err
+		}
+		report.Committed = committed
+
+		for i, storedItem := range storedItems {
+			item := checkedItems[i]
+			item.Status = storedItem.Status
+			item.Actor = storedItem.Actor
+			item.Err = storedItem.Err
+
+			if errors.Is(item.Err, storage.ErrActorNotFound) {
+				item.Err = ErrActorNotFound
+			} else if errors.Is(item.Err, storage.ErrActorVersionMismatch) {
+				item.Err = ErrActorVersionMismatch
+			}
+			if item.Actor != nil {
+				item.Id = item.Actor.Id
+			}
+		}
+	}
+
+	if !report.Committed {
+		log.Warn("batch of actors has not applied")
+		return report, nil
+	}
+
+	log.Info("batch of actors has applied")
+
+	return report, nil
+}
+
+func checkActorBatchOperation(batchOperation *ActorBatchOperation) error {
+	switch batchOperation.Type {
+	case BatchOperationCreate:
+		if batchOperation.Create == nil {
+			return fmt.Errorf("%w: actor values of the 'create' operation are missing", ErrInvalidBatch)
+		}
+		if batchOperation.Version != nil {
+			return fmt.Errorf("%w: the 'create' operation cannot have a version", ErrInvalidBatch)
+		}
+	case BatchOperationUpdate:
+		if batchOperation.Update == nil {
+			return fmt.Errorf("%w: actor values of the 'update' operation are missing", ErrInvalidBatch)
+		}
+	case BatchOperationDelete:
+	default:
+		return fmt.Errorf("%w: unexpected operation '%s'", ErrInvalidBatch, batchOperation.Type)
+	}
+	return nil
 }
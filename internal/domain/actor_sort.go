@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidActorSort = errors.New("invalid actor sort")
+
+type ActorSortField string
+
+const (
+	ActorSortFieldId         ActorSortField = "id"
+	ActorSortFieldName       ActorSortField = "name"
+	ActorSortFieldBirthDate  ActorSortField = "birth-date"
+	ActorSortFieldFilmsCount ActorSortField = "films-count"
+)
+
+var actorSortFields = []ActorSortField{
+	ActorSortFieldId,
+	ActorSortFieldName,
+	ActorSortFieldBirthDate,
+	ActorSortFieldFilmsCount,
+}
+
+func (field ActorSortField) IsValid() bool {
+	for _, actorSortField := range actorSortFields {
+		if field == actorSortField {
+			return true
+		}
+	}
+	return false
+}
+
+type ActorSortKey struct {
+	Field     ActorSortField
+	Direction SortDirection
+}
+
+// ActorSort is an ordered list of sort keys, like FilmSort. An empty ActorSort means actors ordered by id.
+type ActorSort []ActorSortKey
+
+// NewActorSort validates the keys the same way as NewFilmSort does
+func NewActorSort(keys ...ActorSortKey) (ActorSort, error) {
+	seen := make(map[ActorSortField]bool, len(keys))
+
+	for _, key := range keys {
+		if !key.Field.IsValid() {
+			return nil, fmt.Errorf("%w: unexpected sort field '%s'", ErrInvalidActorSort, key.Field)
+		}
+		if !key.Direction.IsValid() {
+			return nil, fmt.Errorf("%w: unexpected sort direction '%s' of field '%s'", ErrInvalidActorSort, key.Direction, key.Field)
+		}
+		if seen[key.Field] {
+			return nil, fmt.Errorf("%w: sort field '%s' is repeated", ErrInvalidActorSort, key.Field)
+		}
+		seen[key.Field] = true
+	}
+
+	return keys, nil
+}
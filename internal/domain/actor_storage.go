@@ -1,10 +1,24 @@
 package domain
 
+import "context"
+
+// ActorStorage keeps actors. Every update increments the version of the actor, deleting and restoring keep it. Update and Delete with a non-nil version
+// fail with storage.ErrActorVersionMismatch if the actor has another one, and so do the update and delete operations of Batch.
+// Delete moves the actor to the trash, other methods never see deleted actors and credits in deleted films.
+// Restore brings a deleted actor back into their films, it fails with storage.ErrActorNotFound if the actor is not in the trash.
+// Export calls fn for every actor with their films in the order of ids and stops at the first error of fn.
+// Batch applies the operations in a single transaction and returns an item for each of them in the same order.
+// The transaction is committed in the best-effort mode or if all operations have succeeded.
 type ActorStorage interface {
-	Create(name ActorName, sex ActorSex, birthDate ActorBirthDate) (*Actor, error)
-	Update(id ActorId, name *ActorName, sex *ActorSex, birthDate *ActorBirthDate) (*Actor, error)
-	Delete(id ActorId) error
-	List(limit, offset int) ([]*Actor, error)
-	IsExists(id ActorId) (bool, error)
-	AreExists(ids []ActorId) (bool, error)
+	Create(ctx context.Context, name ActorName, sex ActorSex, birthDate ActorBirthDate, createdBy *int64) (*Actor, error)
+	Update(ctx context.Context, id ActorId, version *int64, name *ActorName, sex *ActorSex, birthDate *ActorBirthDate, updatedBy *int64) (*Actor, error)
+	Delete(ctx context.Context, id ActorId, version *int64, deletedBy *int64) error
+	Restore(ctx context.Context, id ActorId, restoredBy *int64) (*Actor, error)
+	GetById(ctx context.Context, id ActorId) (*Actor, error)
+	List(ctx context.Context, filter *ActorFilter, sort ActorSort, cursor *ActorCursor, limit, offset int) ([]*Actor, *ActorCursor, error)
+	Count(ctx context.Context, filter *ActorFilter) (int64, error)
+	IsExists(ctx context.Context, id ActorId) (bool, error)
+	AreExists(ctx context.Context, ids []ActorId) (bool, error)
+	Export(ctx context.Context, fn func(actor *Actor) error) error
+	Batch(ctx context.Context, mode BatchMode, operations []*ActorBatchOperation, by *int64) ([]*ActorBatchItem, bool, error)
 }
@@ -0,0 +1,191 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditEventId int64
+
+func (auditEventId *AuditEventId) Int64() int64 {
+	return int64(*auditEventId)
+}
+
+type AuditOperation string
+
+func (auditOperation *AuditOperation) String() string {
+	return string(*auditOperation)
+}
+
+const (
+	AuditOperationCreate  = AuditOperation("create")
+	AuditOperationUpdate  = AuditOperation("update")
+	AuditOperationDelete  = AuditOperation("delete")
+	AuditOperationRestore = AuditOperation("restore")
+)
+
+type AuditEntityType string
+
+func (auditEntityType *AuditEntityType) String() string {
+	return string(*auditEntityType)
+}
+
+func (auditEntityType *AuditEntityType) IsValid() bool {
+	switch *auditEntityType {
+	case AuditEntityTypeFilm, AuditEntityTypeActor:
+		return true
+	default:
+		return false
+	}
+}
+
+const (
+	AuditEntityTypeFilm  = AuditEntityType("film")
+	AuditEntityTypeActor = AuditEntityType("actor")
+)
+
+// AuditEvent is a single mutation of the catalog.
+// Before is empty for created and restored entities, After is empty for deleted ones.
+type AuditEvent struct {
+	Id         AuditEventId
+	UserId     *int64
+	Operation  AuditOperation
+	EntityType AuditEntityType
+	EntityId   int64
+	Before     json.RawMessage
+	After      json.RawMessage
+	CreatedAt  time.Time
+}
+
+// AuditFilter narrows down the listed audit events. Nil fields are not filtered by.
+type AuditFilter struct {
+	EntityType *AuditEntityType
+	EntityId   *int64
+	UserId     *int64
+	From       *time.Time
+	To         *time.Time
+}
+
+type AuditEventsPage struct {
+	Events []*AuditEvent
+	Total  int64
+}
+
+type filmAuditSnapshot struct {
+	Id          int64                      `json:"id"`
+	Title       string                     `json:"title"`
+	Description string                     `json:"description"`
+	ReleaseDate string                     `json:"release_date"`
+	Rating      uint8                      `json:"rating"`
+	ActorIds    []int64                    `json:"actor_ids"`
+	Credits     []*filmCreditAuditSnapshot `json:"credits,omitempty"`
+	GenreIds    []int64                    `json:"genre_ids"`
+}
+
+type filmCreditAuditSnapshot struct {
+	ActorId       int64  `json:"actor_id"`
+	Role          string `json:"role"`
+	CharacterName string `json:"character_name,omitempty"`
+	BillingOrder  int    `json:"billing_order"`
+}
+
+type actorAuditSnapshot struct {
+	Id        int64  `json:"id"`
+	Name      string `json:"name"`
+	Sex       uint8  `json:"sex"`
+	BirthDate string `json:"birthdate"`
+}
+
+// NewFilmAuditEvent builds an audit event of a film mutation made by userId.
+// Storages record it in the transaction of the mutation, so that there is no mutation without an event.
+func NewFilmAuditEvent(userId *int64, operation AuditOperation, before, after *Film) (*AuditEvent, error) {
+	event := &AuditEvent{
+		UserId:     userId,
+		Operation:  operation,
+		EntityType: AuditEntityTypeFilm,
+	}
+
+	var err error
+	if before != nil {
+		event.EntityId = before.Id.Int64()
+		if event.Before, err = json.Marshal(buildFilmAuditSnapshot(before)); err != nil {
+			return nil, err
+		}
+	}
+	if after != nil {
+		event.EntityId = after.Id.Int64()
+		if event.After, err = json.Marshal(buildFilmAuditSnapshot(after)); err != nil {
+			return nil, err
+		}
+	}
+
+	return event, nil
+}
+
+// NewActorAuditEvent builds an audit event of an actor mutation made by userId.
+// Storages record it in the transaction of the mutation, so that there is no mutation without an event.
+func NewActorAuditEvent(userId *int64, operation AuditOperation, before, after *Actor) (*AuditEvent, error) {
+	event := &AuditEvent{
+		UserId:     userId,
+		Operation:  operation,
+		EntityType: AuditEntityTypeActor,
+	}
+
+	var err error
+	if before != nil {
+		event.EntityId = before.Id.Int64()
+		if event.Before, err = json.Marshal(buildActorAuditSnapshot(before)); err != nil {
+			return nil, err
+		}
+	}
+	if after != nil {
+		event.EntityId = after.Id.Int64()
+		if event.After, err = json.Marshal(buildActorAuditSnapshot(after)); err != nil {
+			return nil, err
+		}
+	}
+
+	return event, nil
+}
+
+func buildFilmAuditSnapshot(film *Film) *filmAuditSnapshot {
+	actorIds := make([]int64, len(film.Actors))
+	for i := range film.Actors {
+		actorIds[i] = film.Actors[i].Id.Int64()
+	}
+
+	var credits []*filmCreditAuditSnapshot
+	for _, credit := range film.Credits {
+		credits = append(credits, &filmCreditAuditSnapshot{
+			ActorId:       credit.Actor.Id.Int64(),
+			Role:          credit.Role.String(),
+			CharacterName: credit.CharacterName.String(),
+			BillingOrder:  credit.BillingOrder,
+		})
+	}
+
+	genreIds := make([]int64, len(film.Genres))
+	for i := range film.Genres {
+		genreIds[i] = film.Genres[i].Id.Int64()
+	}
+
+	return &filmAuditSnapshot{
+		Id:          film.Id.Int64(),
+		Title:       film.Title.String(),
+		Description: film.Description.String(),
+		ReleaseDate: film.ReleaseDate.Time().Format(time.DateOnly),
+		Rating:      film.Rating.Uint8(),
+		ActorIds:    actorIds,
+		Credits:     credits,
+		GenreIds:    genreIds,
+	}
+}
+
+func buildActorAuditSnapshot(actor *Actor) *actorAuditSnapshot {
+	return &actorAuditSnapshot{
+		Id:        actor.Id.Int64(),
+		Name:      actor.Name.String(),
+		Sex:       actor.Sex.Uint8(),
+		BirthDate: actor.BirthDate.Time().Format(time.DateOnly),
+	}
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"github.com/vaberof/vk-internship-task/pkg/logging/logs"
+	"log/slog"
+)
+
+var (
+	ErrInvalidAuditFilter = errors.New("invalid audit filter")
+)
+
+type AuditService interface {
+	List(ctx context.Context, filter *AuditFilter, limit, offset int) (*AuditEventsPage, error)
+}
+
+type auditServiceImpl struct {
+	auditStorage AuditStorage
+
+	logger *slog.Logger
+}
+
+func NewAuditService(auditStorage AuditStorage, logsBuilder *logs.Logs) AuditService {
+	logger := logsBuilder.WithName("domain.service.audit")
+	return &auditServiceImpl{
+		auditStorage: auditStorage,
+		logger:       logger,
+	}
+}
+
+func (a *auditServiceImpl) List(ctx context.Context, filter *AuditFilter, limit, offset int) (*AuditEventsPage, error) {
+	const operation = "List"
+
+	log := a.logger.With(
+		slog.String("operation", operation),
+		slog.Int("limit", limit),
+		slog.Int("offset", offset),
+	)
+
+	log.Info("listing audit events")
+
+	if filter.EntityType != nil && !filter.EntityType.IsValid() {
+		log.Warn("failed to list audit events", "error", "unexpected entity type '"+filter.EntityType.String()+"'")
+		return nil, ErrInvalidAuditFilter
+	}
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		log.Warn("failed to list audit events", "error", "'from' is after 'to'")
+		return nil, ErrInvalidAuditFilter
+	}
+
+	events, err := a.auditStorage.List(ctx, filter, limit, offset)
+	if err != nil {
+		log.Error("failed to list audit events", "error", err)
+		return nil, err
+	}
+
+	total, err := a.auditStorage.Count(ctx, filter)
+	if err != nil {
+		log.Error("failed to count audit events", "error", err)
+		return nil, err
+	}
+
+	log.Info("audit events have listed")
+
+	return &AuditEventsPage{
+		Events: events,
+		Total:  total,
+	}, nil
+}
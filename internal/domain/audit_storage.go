@@ -0,0 +1,8 @@
+package domain
+
+import "context"
+
+type AuditStorage interface {
+	List(ctx context.Context, filter *AuditFilter, limit, offset int) ([]*AuditEvent, error)
+	Count(ctx context.Context, filter *AuditFilter) (int64, error)
+}
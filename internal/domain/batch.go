@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+const MaxBatchOperations = 1000
+
+var ErrInvalidBatch = errors.New("invalid batch")
+
+// BatchMode tells what happens with the other operations of a batch when one of them fails
+type BatchMode string
+
+const (
+	// BatchModeTransactional applies all operations or none of them
+	BatchModeTransactional BatchMode = "transactional"
+	// BatchModeBestEffort applies every operation which succeeds
+	BatchModeBestEffort BatchMode = "best-effort"
+)
+
+func (batchMode BatchMode) IsValid() bool {
+	return batchMode == BatchModeTransactional || batchMode == BatchModeBestEffort
+}
+
+type BatchOperationType string
+
+const (
+	BatchOperationCreate BatchOperationType = "create"
+	BatchOperationUpdate BatchOperationType = "update"
+	BatchOperationDelete BatchOperationType = "delete"
+)
+
+func (batchOperationType BatchOperationType) IsValid() bool {
+	switch batchOperationType {
+	case BatchOperationCreate, BatchOperationUpdate, BatchOperationDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+type BatchItemStatus string
+
+const (
+	BatchItemSucceeded BatchItemStatus = "succeeded"
+	BatchItemFailed    BatchItemStatus = "failed"
+	// BatchItemAborted means that the operation is valid, but it has not been applied,
+	// because another operation of a transactional batch has failed
+	BatchItemAborted BatchItemStatus = "aborted"
+)
+
+func validateBatch(mode BatchMode, operationsCount int) error {
+	if !mode.IsValid() {
+		return fmt.Errorf("%w: unexpected mode '%s'. Allowed modes: '%s', '%s'", ErrInvalidBatch, mode, BatchModeTransactional, BatchModeBestEffort)
+	}
+	if operationsCount == 0 {
+		return fmt.Errorf("%w: no operations", ErrInvalidBatch)
+	}
+	if operationsCount > MaxBatchOperations {
+		return fmt.Errorf("%w: more than %d operations", ErrInvalidBatch, MaxBatchOperations)
+	}
+	return nil
+}
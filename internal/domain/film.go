@@ -34,11 +34,33 @@ func (filmRating *FilmRating) Uint8() uint8 {
 	return uint8(*filmRating)
 }
 
+// CommunityScore is the average rating of the film reviews, it is 0 if the film has no reviews
+type CommunityScore float64
+
+func (communityScore *CommunityScore) Float64() float64 {
+	return float64(*communityScore)
+}
+
+// Film is a catalog film. Actors are its credits with the 'actor' role.
+// Credits of all roles are filled only when a single film is read.
+// InWatchlist and Watched are set for the authenticated user the film is read by.
+// Match is set only when films are searched by a FilmSearchQuery.
+// Version grows with every update of the film and is filled only when a single film is read or written.
 type Film struct {
-	Id          FilmId
-	Title       FilmTitle
-	Description FilmDescription
-	ReleaseDate FilmReleaseDate
-	Rating      FilmRating
-	Actors      []*Actor
+	Id             FilmId
+	Title          FilmTitle
+	Description    FilmDescription
+	ReleaseDate    FilmReleaseDate
+	Rating         FilmRating
+	CommunityScore CommunityScore
+	VotesCount     int64
+	Actors         []*Actor
+	Credits        []*FilmCredit
+	Genres         []*Genre
+	InWatchlist    bool
+	Watched        bool
+	Match          *FilmMatch
+	CreatedBy      *int64
+	UpdatedBy      *int64
+	Version        int64
 }
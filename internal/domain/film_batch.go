@@ -0,0 +1,53 @@
+package domain
+
+// FilmBatchOperation creates, updates or deletes a film within a batch.
+// Id is the id of the updated or deleted film, Create and Update hold the values of the respective operations.
+// Version, like the 'If-Match' header of the single film requests, is the version the updated or deleted film must have,
+// the version is not checked if it is nil.
+type FilmBatchOperation struct {
+	Type    BatchOperationType
+	Id      FilmId
+	Version *int64
+	Create  *FilmCreateParams
+	Update  *FilmUpdateParams
+}
+
+// FilmCreateParams are the values of a created film, they mean the same as the parameters of FilmService.Create
+type FilmCreateParams struct {
+	Title       FilmTitle
+	Description FilmDescription
+	ReleaseDate FilmReleaseDate
+	Rating      FilmRating
+	ActorIds    []ActorId
+	Credits     []*FilmCreditParams
+	GenreIds    []GenreId
+}
+
+// FilmUpdateParams are the changed values of an updated film, they mean the same as the parameters of FilmService.Update
+type FilmUpdateParams struct {
+	Title       *FilmTitle
+	Description *FilmDescription
+	ReleaseDate *FilmReleaseDate
+	Rating      *FilmRating
+	ActorIds    *[]ActorId
+	Credits     *[]*FilmCreditParams
+	GenreIds    *[]GenreId
+}
+
+// FilmBatchItem is the outcome of an operation with the same index in the batch.
+// Film is the created or updated film, Err is set for failed operations.
+type FilmBatchItem struct {
+	Index  int
+	Type   BatchOperationType
+	Id     FilmId
+	Status BatchItemStatus
+	Film   *Film
+	Err    error
+}
+
+// FilmBatchReport describes the outcome of a batch. The changes are saved only if Committed is true.
+type FilmBatchReport struct {
+	Mode      BatchMode
+	Committed bool
+	Items     []*FilmBatchItem
+}
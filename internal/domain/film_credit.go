@@ -0,0 +1,74 @@
+package domain
+
+type CreditRole string
+
+func (creditRole *CreditRole) String() string {
+	return string(*creditRole)
+}
+
+func (creditRole *CreditRole) IsValid() bool {
+	switch *creditRole {
+	case CreditRoleActor, CreditRoleDirector, CreditRoleWriter, CreditRoleProducer, CreditRoleComposer:
+		return true
+	default:
+		return false
+	}
+}
+
+const (
+	CreditRoleActor    = CreditRole("actor")
+	CreditRoleDirector = CreditRole("director")
+	CreditRoleWriter   = CreditRole("writer")
+	CreditRoleProducer = CreditRole("producer")
+	CreditRoleComposer = CreditRole("composer")
+)
+
+type CharacterName string
+
+func (characterName *CharacterName) String() string {
+	return string(*characterName)
+}
+
+// FilmCredit is a person from the actors catalog attached to a film in some role.
+// CharacterName is set only for actors, credits are ordered by BillingOrder.
+type FilmCredit struct {
+	Actor         *Actor
+	Role          CreditRole
+	CharacterName CharacterName
+	BillingOrder  int
+}
+
+// FilmCreditParams describes a credit that needs to be attached to a film
+type FilmCreditParams struct {
+	ActorId       ActorId
+	Role          CreditRole
+	CharacterName CharacterName
+	BillingOrder  int
+}
+
+// NewActorCredits turns plain actor ids into credits with the 'actor' role billed in the order of ids
+func NewActorCredits(actorIds []ActorId) []*FilmCreditParams {
+	credits := make([]*FilmCreditParams, len(actorIds))
+	for i := range actorIds {
+		credits[i] = &FilmCreditParams{
+			ActorId:      actorIds[i],
+			Role:         CreditRoleActor,
+			BillingOrder: i,
+		}
+	}
+	return credits
+}
+
+// creditsActorIds returns distinct ids of the credited people in the order of their first appearance
+func creditsActorIds(credits []*FilmCreditParams) []ActorId {
+	actorIds := make([]ActorId, 0, len(credits))
+	seen := make(map[ActorId]struct{}, len(credits))
+	for _, credit := range credits {
+		if _, ok := seen[credit.ActorId]; ok {
+			continue
+		}
+		seen[credit.ActorId] = struct{}{}
+		actorIds = append(actorIds, credit.ActorId)
+	}
+	return actorIds
+}
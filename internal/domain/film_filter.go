@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidFilmFilter = errors.New("invalid film filter")
+
+// FilmActorsMatch tells whether a film must have any or all of the filtered actors
+type FilmActorsMatch string
+
+const (
+	FilmActorsMatchAny FilmActorsMatch = "any"
+	FilmActorsMatchAll FilmActorsMatch = "all"
+)
+
+func (match FilmActorsMatch) IsValid() bool {
+	return match == FilmActorsMatchAny || match == FilmActorsMatchAll
+}
+
+// FilmFilter narrows down a list of films. Zero fields do not filter, set fields are combined with AND.
+// Title, ActorName and DirectorName are case-insensitive fragments, ranges include their bounds.
+// ActorIds, ActorSex and ActorName are matched against the credits with the 'actor' role.
+type FilmFilter struct {
+	Query         FilmSearchQuery
+	Title         FilmTitle
+	ActorName     ActorName
+	DirectorName  ActorName
+	GenreId       *GenreId
+	ReleasedFrom  *FilmReleaseDate
+	ReleasedTo    *FilmReleaseDate
+	MinRating     *FilmRating
+	MaxRating     *FilmRating
+	ActorIds      []ActorId
+	ActorsMatch   FilmActorsMatch
+	ActorSex      *ActorSex
+	WithoutActors bool
+}
+
+// Validate checks that ranges are not reversed and that filters of actors do not contradict each other
+func (filter *FilmFilter) Validate() error {
+	if filter == nil {
+		return nil
+	}
+	if filter.ReleasedFrom != nil && filter.ReleasedTo != nil && filter.ReleasedFrom.Time().After(filter.ReleasedTo.Time()) {
+		return fmt.Errorf("%w: release date range is reversed", ErrInvalidFilmFilter)
+	}
+	if filter.MinRating != nil && filter.MaxRating != nil && *filter.MinRating > *filter.MaxRating {
+		return fmt.Errorf("%w: rating range is reversed", ErrInvalidFilmFilter)
+	}
+	if filter.ActorsMatch != "" && !filter.ActorsMatch.IsValid() {
+		return fmt.Errorf("%w: unexpected actors match '%s'", ErrInvalidFilmFilter, filter.ActorsMatch)
+	}
+	if filter.WithoutActors && (len(filter.ActorIds) > 0 || filter.ActorSex != nil || filter.ActorName != "") {
+		return fmt.Errorf("%w: films without actors cannot be filtered by actors", ErrInvalidFilmFilter)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package domain
+
+// FilmImportActor is an actor embedded into an imported film.
+// Actors are matched with the existing ones by name and birth date, the unmatched ones are created.
+type FilmImportActor struct {
+	Name      ActorName
+	Sex       ActorSex
+	BirthDate ActorBirthDate
+}
+
+// FilmImportRow is a film to import, Line is its line in the source used to report errors
+type FilmImportRow struct {
+	Line        int
+	Title       FilmTitle
+	Description FilmDescription
+	ReleaseDate FilmReleaseDate
+	Rating      FilmRating
+	Actors      []*FilmImportActor
+	GenreIds    []GenreId
+}
+
+type FilmImportRowError struct {
+	Line    int
+	Message string
+}
+
+// FilmImportReport describes the outcome of an import. The films are created only if Committed is true,
+// which happens when it is not a dry run and there are no errors in any row.
+type FilmImportReport struct {
+	DryRun        bool
+	Committed     bool
+	Films         int
+	FilmIds       []FilmId
+	CreatedActors int
+	MatchedActors int
+	Errors        []*FilmImportRowError
+}
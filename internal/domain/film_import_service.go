@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"github.com/vaberof/vk-internship-task/pkg/logging/logs"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+// FilmImportService imports films with embedded actors in bulk
+type FilmImportService interface {
+	// Import imports the rows all at once. rowErrors are the rows of the source that could not be decoded:
+	// if there are any, the rest of the rows are still checked, but nothing is committed.
+	Import(ctx context.Context, rows []*FilmImportRow, rowErrors []*FilmImportRowError, dryRun bool) (*FilmImportReport, error)
+}
+
+type filmImportServiceImpl struct {
+	filmImportStorage FilmImportStorage
+	genreStorage      GenreStorage
+
+	logger *slog.Logger
+}
+
+func NewFilmImportService(filmImportStorage FilmImportStorage, genreStorage GenreStorage, logsBuilder *logs.Logs) FilmImportService {
+	logger := logsBuilder.WithName("domain.service.filmimport")
+	return &filmImportServiceImpl{
+		filmImportStorage: filmImportStorage,
+		genreStorage:      genreStorage,
+		logger:            logger,
+	}
+}
+
+func (f *filmImportServiceImpl) Import(ctx context.Context, rows []*FilmImportRow, rowErrors []*FilmImportRowError, dryRun bool) (*FilmImportReport, error) {
+	const operation = "Import"
+
+	log := f.logger.With(
+		slog.String("operation", operation),
+		slog.Int("rows", len(rows)),
+		slog.Int("invalidRows", len(rowErrors)),
+		slog.Bool("dryRun", dryRun),
+	)
+
+	log.Info("importing films")
+
+	importErrors := append([]*FilmImportRowError{}, rowErrors...)
+	validRows := make([]*FilmImportRow, 0, len(rows))
+
+	for _, row := range rows {
+		rowError, err := f.checkRow(ctx, row)
+		if err != nil {
+			log.Error("failed to import films", "error", err)
+			return nil, err
+		}
+		if rowError != nil {
+			importErrors = append(importErrors, rowError)
+			continue
+		}
+		validRows = append(validRows, row)
+	}
+
+	commit := !dryRun && len(importErrors) == 0
+
+	report, err := f.filmImportStorage.Import(ctx, validRows, commit, principalId(ctx))
+	if err != nil {
+		log.Error("failed to import films", "error", err)
+		return nil, err
+	}
+
+	report.DryRun = dryRun
+	report.Errors = append(importErrors, report.Errors...)
+	sort.SliceStable(report.Errors, func(i, j int) bool {
+		return report.Errors[i].Line < report.Errors[j].Line
+	})
+
+	if len(report.Errors) > 0 {
+		log.Warn("films have not imported", "errors", len(report.Errors))
+	} else {
+		log.Info("films have imported", "committed", report.Committed, "films", report.Films)
+	}
+
+	return report, nil
+}
+
+// checkRow returns an error of the row if it cannot be imported, the same way as FilmService.Create checks a film
+func (f *filmImportServiceImpl) checkRow(ctx context.Context, row *FilmImportRow) (*FilmImportRowError, error) {
+	if len(row.Actors) == 0 {
+		return &FilmImportRowError{Line: row.Line, Message: "film must have actors"}, nil
+	}
+
+	type actorKey struct {
+		name      ActorName
+		birthDate string
+	}
+
+	seen := make(map[actorKey]bool, len(row.Actors))
+	for _, actor := range row.Actors {
+		key := actorKey{name: actor.Name, birthDate: actor.BirthDate.Time().Format(time.DateOnly)}
+		if seen[key] {
+			return &FilmImportRowError{Line: row.Line, Message: fmt.Sprintf("actor '%s' is repeated", actor.Name)}, nil
+		}
+		seen[key] = true
+	}
+
+	if len(row.GenreIds) > 0 {
+		exists, err := f.genreStorage.AreExists(ctx, row.GenreIds)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return &FilmImportRowError{Line: row.Line, Message: fmt.Sprintf("%s: genres with ids '%v' not found", ErrFilmGenresNotFound, row.GenreIds)}, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,9 @@
+package domain
+
+import "context"
+
+type FilmImportStorage interface {
+	// Import creates the films of all rows in a single transaction, which is committed only if commit is true
+	// and every row succeeds. Errors of single rows are reported in FilmImportReport.Errors.
+	Import(ctx context.Context, rows []*FilmImportRow, commit bool, importedBy *int64) (*FilmImportReport, error)
+}
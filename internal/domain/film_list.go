@@ -0,0 +1,13 @@
+package domain
+
+// FilmList is a personal list of films of a user
+type FilmList string
+
+func (filmList *FilmList) String() string {
+	return string(*filmList)
+}
+
+const (
+	FilmListWatchlist = FilmList("watchlist")
+	FilmListWatched   = FilmList("watched")
+)
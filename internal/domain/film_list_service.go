@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"github.com/vaberof/vk-internship-task/pkg/logging/logs"
+	"log/slog"
+)
+
+var (
+	ErrFilmNotInList     = errors.New("film is not in the list")
+	ErrFilmListForbidden = errors.New("film lists are available only to authenticated users")
+)
+
+// FilmListService manages personal film lists of the authenticated user
+type FilmListService interface {
+	Add(ctx context.Context, list FilmList, filmId FilmId) error
+	Remove(ctx context.Context, list FilmList, filmId FilmId) error
+	List(ctx context.Context, list FilmList, sort FilmSort, cursor *FilmCursor, withTotal bool, limit, offset int) (*FilmsPage, error)
+}
+
+type filmListServiceImpl struct {
+	filmListStorage FilmListStorage
+
+	logger *slog.Logger
+}
+
+func NewFilmListService(filmListStorage FilmListStorage, logsBuilder *logs.Logs) FilmListService {
+	logger := logsBuilder.WithName("domain.service.filmlist")
+	return &filmListServiceImpl{
+		filmListStorage: filmListStorage,
+		logger:          logger,
+	}
+}
+
+// Add adds a film to the list, adding a film that is already in the list does nothing
+func (f *filmListServiceImpl) Add(ctx context.Context, list FilmList, filmId FilmId) error {
+	const operation = "Add"
+
+	log := f.logger.With(
+		slog.String("operation", operation),
+		slog.String("list", list.String()),
+		slog.Int64("filmId", filmId.Int64()))
+
+	log.Info("adding a film to the list")
+
+	userId := principalId(ctx)
+	if userId == nil {
+		log.Warn("failed to add a film to the list", "error", "user is unknown")
+		return ErrFilmListForbidden
+	}
+
+	err := f.filmListStorage.Add(ctx, *userId, list, filmId)
+	if err != nil {
+		if errors.Is(err, storage.ErrFilmNotFound) {
+			log.Warn("failed to add a film to the list", "error", fmt.Sprintf("film with id '%d' not found", filmId.Int64()))
+			return ErrFilmNotFound
+		}
+
+		log.Error("failed to add a film to the list", "error", err)
+		return err
+	}
+
+	log.Info("film has added to the list")
+
+	return nil
+}
+
+func (f *filmListServiceImpl) Remove(ctx context.Context, list FilmList, filmId FilmId) error {
+	const operation = "Remove"
+
+	log := f.logger.With(
+		slog.String("operation", operation),
+		slog.String("list", list.String()),
+		slog.Int64("filmId", filmId.Int64()))
+
+	log.Info("removing a film from the list")
+
+	userId := principalId(ctx)
+	if userId == nil {
+		log.Warn("failed to remove a film from the list", "error", "user is unknown")
+		return ErrFilmListForbidden
+	}
+
+	err := f.filmListStorage.Remove(ctx, *userId, list, filmId)
+	if err != nil {
+		if errors.Is(err, storage.ErrFilmListItemNotFound) {
+			log.Warn("failed to remove a film from the list", "error", fmt.Sprintf("film with id '%d' is not in the list", filmId.Int64()))
+			return ErrFilmNotInList
+		}
+
+		log.Error("failed to remove a film from the list", "error", err)
+		return err
+	}
+
+	log.Info("film has removed from the list")
+
+	return nil
+}
+
+func (f *filmListServiceImpl) List(ctx context.Context, list FilmList, sort FilmSort, cursor *FilmCursor, withTotal bool, limit, offset int) (*FilmsPage, error) {
+	const operation = "List"
+
+	log := f.logger.With(
+		slog.String("operation", operation),
+		slog.String("list", list.String()),
+		slog.Int("limit", limit),
+		slog.Int("offset", offset))
+
+	log.Info("listing films of the list")
+
+	userId := principalId(ctx)
+	if userId == nil {
+		log.Warn("failed to list films of the list", "error", "user is unknown")
+		return nil, ErrFilmListForbidden
+	}
+
+	domainFilms, nextCursor, err := f.filmListStorage.ListFilms(ctx, *userId, list, sort, cursor, limit, offset)
+	if err != nil {
+		log.Error("failed to list films of the list", "error", err)
+		return nil, err
+	}
+
+	if err = attachFilmListFlags(ctx, f.filmListStorage, domainFilms); err != nil {
+		log.Error("failed to list films of the list", "error", err)
+		return nil, err
+	}
+
+	filmsPage := &FilmsPage{
+		Films:      domainFilms,
+		NextCursor: nextCursor,
+	}
+
+	if withTotal {
+		total, err := f.filmListStorage.CountFilms(ctx, *userId, list)
+		if err != nil {
+			log.Error("failed to count films of the list", "error", err)
+			return nil, err
+		}
+		filmsPage.Total = &total
+	}
+
+	log.Info("films of the list have listed")
+
+	return filmsPage, nil
+}
+
+// attachFilmListFlags marks the films that are in the lists of the authenticated user.
+// Without an authenticated user the films are left unmarked.
+func attachFilmListFlags(ctx context.Context, filmListStorage FilmListStorage, films []*Film) error {
+	userId := principalId(ctx)
+	if userId == nil || len(films) == 0 {
+		return nil
+	}
+
+	filmIds := make([]FilmId, len(films))
+	for i, film := range films {
+		filmIds[i] = film.Id
+	}
+
+	filmsLists, err := filmListStorage.GetFilmsLists(ctx, *userId, filmIds)
+	if err != nil {
+		return err
+	}
+
+	for _, film := range films {
+		for _, list := range filmsLists[film.Id] {
+			switch list {
+			case FilmListWatchlist:
+				film.InWatchlist = true
+			case FilmListWatched:
+				film.Watched = true
+			}
+		}
+	}
+
+	return nil
+}
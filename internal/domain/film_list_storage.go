@@ -0,0 +1,12 @@
+package domain
+
+import "context"
+
+type FilmListStorage interface {
+	Add(ctx context.Context, userId int64, list FilmList, filmId FilmId) error
+	Remove(ctx context.Context, userId int64, list FilmList, filmId FilmId) error
+	ListFilms(ctx context.Context, userId int64, list FilmList, sort FilmSort, cursor *FilmCursor, limit, offset int) ([]*Film, *FilmCursor, error)
+	CountFilms(ctx context.Context, userId int64, list FilmList) (int64, error)
+	// GetFilmsLists returns the lists of the user each of the given films is in
+	GetFilmsLists(ctx context.Context, userId int64, filmIds []FilmId) (map[FilmId][]FilmList, error)
+}
@@ -0,0 +1,34 @@
+package domain
+
+// FilmCursor points at the last film of a previously returned page.
+// It holds every sortable key of that film, so it stays valid for any sort order.
+type FilmCursor struct {
+	Id             FilmId
+	Title          FilmTitle
+	ReleaseDate    FilmReleaseDate
+	Rating         FilmRating
+	ActorsCount    int64
+	CommunityScore CommunityScore
+	Relevance      float64
+}
+
+type FilmsPage struct {
+	Films      []*Film
+	NextCursor *FilmCursor
+	Total      *int64
+}
+
+func NewFilmCursor(film *Film) *FilmCursor {
+	cursor := &FilmCursor{
+		Id:             film.Id,
+		Title:          film.Title,
+		ReleaseDate:    film.ReleaseDate,
+		Rating:         film.Rating,
+		ActorsCount:    int64(len(film.Actors)),
+		CommunityScore: film.CommunityScore,
+	}
+	if film.Match != nil {
+		cursor.Relevance = film.Match.Relevance
+	}
+	return cursor
+}
@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"github.com/vaberof/vk-internship-task/pkg/logging/logs"
+	"log/slog"
+)
+
+var (
+	ErrFilmRevisionNotFound = errors.New("film revision not found")
+)
+
+type FilmRevisionService interface {
+	List(ctx context.Context, filmId FilmId, limit, offset int) (*FilmRevisionsPage, error)
+	// Diff returns the fields of the film that differ between the revisions 'from' and 'to'
+	Diff(ctx context.Context, filmId FilmId, from, to int64) ([]*RevisionChange, error)
+	// Revert brings the film back to the state of the revision. It is an ordinary update of the film,
+	// so it is checked the same way, makes a new revision and fails if the film has not the given version.
+	Revert(ctx context.Context, filmId FilmId, revision int64, version *int64) (*Film, error)
+}
+
+type filmRevisionServiceImpl struct {
+	filmRevisionStorage FilmRevisionStorage
+	filmStorage         FilmStorage
+	filmService         FilmService
+
+	logger *slog.Logger
+}
+
+func NewFilmRevisionService(filmRevisionStorage FilmRevisionStorage, filmStorage FilmStorage, filmService FilmService, logsBuilder *logs.Logs) FilmRevisionService {
+	logger := logsBuilder.WithName("domain.service.filmrevision")
+	return &filmRevisionServiceImpl{
+		filmRevisionStorage: filmRevisionStorage,
+		filmStorage:         filmStorage,
+		filmService:         filmService,
+		logger:              logger,
+	}
+}
+
+func (f *filmRevisionServiceImpl) List(ctx context.Context, filmId FilmId, limit, offset int) (*FilmRevisionsPage, error) {
+	const operation = "List"
+
+	log := f.logger.With(
+		slog.String("operation", operation),
+		slog.Int64("filmId", filmId.Int64()),
+		slog.Int("limit", limit),
+		slog.Int("offset", offset))
+
+	log.Info("listing revisions of a film")
+
+	if err := f.checkFilm(ctx, log, filmId); err != nil {
+		return nil, err
+	}
+
+	revisions, err := f.filmRevisionStorage.List(ctx, filmId, limit, offset)
+	if err != nil {
+		log.Error("failed to list revisions", "error", err)
+		return nil, err
+	}
+
+	total, err := f.filmRevisionStorage.Count(ctx, filmId)
+	if err != nil {
+		log.Error("failed to count revisions", "error", err)
+		return nil, err
+	}
+
+	log.Info("revisions have listed")
+
+	return &FilmRevisionsPage{
+		Revisions: revisions,
+		Total:     total,
+	}, nil
+}
+
+func (f *filmRevisionServiceImpl) Diff(ctx context.Context, filmId FilmId, from, to int64) ([]*RevisionChange, error) {
+	const operation = "Diff"
+
+	log := f.logger.With(
+		slog.String("operation", operation),
+		slog.Int64("filmId", filmId.Int64()),
+		slog.Int64("from", from),
+		slog.Int64("to", to))
+
+	log.Info("comparing revisions of a film")
+
+	if err := f.checkFilm(ctx, log, filmId); err != nil {
+		return nil, err
+	}
+
+	fromRevision, err := f.getRevision(ctx, log, filmId, from)
+	if err != nil {
+		return nil, err
+	}
+
+	toRevision, err := f.getRevision(ctx, log, filmId, to)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := DiffFilmRevisions(fromRevision, toRevision)
+
+	log.Info("revisions have compared", "changes", len(changes))
+
+	return changes, nil
+}
+
+func (f *filmRevisionServiceImpl) Revert(ctx context.Context, filmId FilmId, revision int64, version *int64) (*Film, error) {
+	const operation = "Revert"
+
+	log := f.logger.With(
+		slog.String("operation", operation),
+		slog.Int64("filmId", filmId.Int64()),
+		slog.Int64("revision", revision))
+
+	log.Info("reverting a film")
+
+	if err := f.checkFilm(ctx, log, filmId); err != nil {
+		return nil, err
+	}
+
+	domainRevision, err := f.getRevision(ctx, log, filmId, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	domainFilm, err := f.filmService.Update(
+		ctx,
+		filmId,
+		version,
+		&domainRevision.Title,
+		&domainRevision.Description,
+		&domainRevision.ReleaseDate,
+		&domainRevision.Rating,
+		nil,
+		&domainRevision.Credits,
+		&domainRevision.GenreIds)
+	if err != nil {
+		log.Warn("failed to revert a film", "error", err)
+		return nil, err
+	}
+
+	log.Info("film has reverted", "version", domainFilm.Version)
+
+	return domainFilm, nil
+}
+
+func (f *filmRevisionServiceImpl) checkFilm(ctx context.Context, log *slog.Logger, filmId FilmId) error {
+	exists, err := f.filmStorage.IsExists(ctx, filmId)
+	if err != nil {
+		log.Error("failed to check a film", "error", err)
+		return err
+	}
+	if !exists {
+		log.Warn("film not found", "error", fmt.Sprintf("film with id '%d' not found", filmId.Int64()))
+		return ErrFilmNotFound
+	}
+	return nil
+}
+
+func (f *filmRevisionServiceImpl) getRevision(ctx context.Context, log *slog.Logger, filmId FilmId, revision int64) (*FilmRevision, error) {
+	domainRevision, err := f.filmRevisionStorage.Get(ctx, filmId, revision)
+	if err != nil {
+		if errors.Is(err, storage.ErrFilmRevisionNotFound) {
+			log.Warn("film revision not found", "error", fmt.Sprintf("film with id '%d' has not revision '%d'", filmId.Int64(), revision))
+			return nil, ErrFilmRevisionNotFound
+		}
+
+		log.Error("failed to get a film revision", "error", err)
+		return nil, err
+	}
+	return domainRevision, nil
+}
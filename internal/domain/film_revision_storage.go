@@ -0,0 +1,11 @@
+package domain
+
+import "context"
+
+// FilmRevisionStorage reads revisions of films, the latest first. FilmStorage records them along with the changes.
+// Get fails with storage.ErrFilmRevisionNotFound if the film has no such revision.
+type FilmRevisionStorage interface {
+	List(ctx context.Context, filmId FilmId, limit, offset int) ([]*FilmRevision, error)
+	Count(ctx context.Context, filmId FilmId) (int64, error)
+	Get(ctx context.Context, filmId FilmId, revision int64) (*FilmRevision, error)
+}
@@ -0,0 +1,16 @@
+package domain
+
+// FilmSearchQuery is a free text query matched against film titles, descriptions and names of film credits
+type FilmSearchQuery string
+
+func (filmSearchQuery *FilmSearchQuery) String() string {
+	return string(*filmSearchQuery)
+}
+
+// FilmMatch tells how a film matches a FilmSearchQuery.
+// Highlights are fragments of the title and the description with matched words wrapped in '<b>' and '</b>'.
+type FilmMatch struct {
+	Relevance            float64
+	TitleHighlight       string
+	DescriptionHighlight string
+}
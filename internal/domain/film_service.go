@@ -1,63 +1,102 @@
 package domain
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
 	"github.com/vaberof/vk-internship-task/pkg/logging/logs"
 	"log/slog"
 )
 
 var (
-	ErrFilmNotFound       = errors.New("film not found")
-	ErrFilmActorsNotFound = errors.New("actors not found")
+	ErrFilmNotFound        = errors.New("film not found")
+	ErrFilmVersionMismatch = errors.New("film version mismatch")
+	ErrFilmActorsNotFound  = errors.New("actors not found")
+	ErrFilmGenresNotFound  = errors.New("genres not found")
+	ErrInvalidFilmCredit   = errors.New("invalid film credit")
 )
 
 type FilmService interface {
-	Create(title FilmTitle, description FilmDescription, releaseDate FilmReleaseDate, rating FilmRating, actorIds []ActorId) (*Film, error)
-	Update(id FilmId, title *FilmTitle, description *FilmDescription, releaseDate *FilmReleaseDate, rating *FilmRating, actorIds *[]ActorId) (*Film, error)
-	Delete(id FilmId) error
-	ListWithSort(titleOrder, releaseDateOrder, ratingOrder string, limit, offset int) ([]*Film, error)
-	SearchByFilters(title FilmTitle, actorName ActorName, limit, offset int) ([]*Film, error)
+	Create(ctx context.Context, title FilmTitle, description FilmDescription, releaseDate FilmReleaseDate, rating FilmRating, actorIds []ActorId, credits []*FilmCreditParams, genreIds []GenreId) (*Film, error)
+	// Update and Delete check that the film has the given version, if it is set
+	Update(ctx context.Context, id FilmId, version *int64, title *FilmTitle, description *FilmDescription, releaseDate *FilmReleaseDate, rating *FilmRating, actorIds *[]ActorId, credits *[]*FilmCreditParams, genreIds *[]GenreId) (*Film, error)
+	Delete(ctx context.Context, id FilmId, version *int64) error
+	// Restore brings the film back from the trash
+	Restore(ctx context.Context, id FilmId) (*Film, error)
+	GetById(ctx context.Context, id FilmId) (*Film, error)
+	List(ctx context.Context, filter *FilmFilter, sort FilmSort, cursor *FilmCursor, withTotal bool, limit, offset int) (*FilmsPage, error)
+	// Export streams all films with their actors to fn one by one
+	Export(ctx context.Context, fn func(film *Film) error) error
+	// Batch applies the operations in a single transaction. Every operation is checked the same way as by Create,
+	// Update and Delete, the outcome of each of them is reported in the items of the report.
+	Batch(ctx context.Context, mode BatchMode, operations []*FilmBatchOperation) (*FilmBatchReport, error)
 }
 
 type filmServiceImpl struct {
-	filmStorage  FilmStorage
-	actorStorage ActorStorage
+	filmStorage     FilmStorage
+	actorStorage    ActorStorage
+	genreStorage    GenreStorage
+	filmListStorage FilmListStorage
 
 	logger *slog.Logger
 }
 
-func NewFilmService(filmStorage FilmStorage, actorStorage ActorStorage, logsBuilder *logs.Logs) FilmService {
+func NewFilmService(filmStorage FilmStorage, actorStorage ActorStorage, genreStorage GenreStorage, filmListStorage FilmListStorage, logsBuilder *logs.Logs) FilmService {
 	logger := logsBuilder.WithName("domain.service.film")
 	return &filmServiceImpl{
-		filmStorage:  filmStorage,
-		actorStorage: actorStorage,
-		logger:       logger,
+		filmStorage:     filmStorage,
+		actorStorage:    actorStorage,
+		genreStorage:    genreStorage,
+		filmListStorage: filmListStorage,
+		logger:          logger,
 	}
 }
 
-func (f *filmServiceImpl) Create(title FilmTitle, description FilmDescription, releaseDate FilmReleaseDate, rating FilmRating, actorIds []ActorId) (*Film, error) {
+func (f *filmServiceImpl) Create(ctx context.Context, title FilmTitle, description FilmDescription, releaseDate FilmReleaseDate, rating FilmRating, actorIds []ActorId, credits []*FilmCreditParams, genreIds []GenreId) (*Film, error) {
 	const operation = "Create"
 
 	log := f.logger.With(
 		slog.String("operation", operation),
 		slog.String("title", title.String()),
 		slog.Any("actorIds", actorIds),
+		slog.Int("credits", len(credits)),
+		slog.Any("genreIds", genreIds),
 	)
 
 	log.Info("creating a film")
 
-	exists, err := f.actorStorage.AreExists(actorIds)
+	// Plain actor ids are credits with the 'actor' role, billed before the explicit credits
+	allCredits := append(NewActorCredits(actorIds), credits...)
+
+	if err := validateFilmCredits(allCredits); err != nil {
+		log.Warn("failed to create a film", "error", err)
+		return nil, err
+	}
+
+	creditedActorIds := creditsActorIds(allCredits)
+
+	exists, err := f.actorStorage.AreExists(ctx, creditedActorIds)
 	if err != nil {
 		log.Error("failed to create a film", "error", err)
 		return nil, err
 	}
 	if !exists {
-		log.Warn("failed to create a film", "error", fmt.Sprintf("actors with ids '%v' not found", actorIds))
+		log.Warn("failed to create a film", "error", fmt.Sprintf("actors with ids '%v' not found", creditedActorIds))
 		return nil, ErrFilmActorsNotFound
 	}
 
-	domainFilm, err := f.filmStorage.Create(title, description, releaseDate, rating, actorIds)
+	exists, err = f.genreStorage.AreExists(ctx, genreIds)
+	if err != nil {
+		log.Error("failed to create a film", "error", err)
+		return nil, err
+	}
+	if !exists {
+		log.Warn("failed to create a film", "error", fmt.Sprintf("genres with ids '%v' not found", genreIds))
+		return nil, ErrFilmGenresNotFound
+	}
+
+	domainFilm, err := f.filmStorage.Create(ctx, title, description, releaseDate, rating, allCredits, genreIds, principalId(ctx))
 	if err != nil {
 		log.Error("failed to create a film", "error", err)
 		return nil, err
@@ -68,7 +107,7 @@ func (f *filmServiceImpl) Create(title FilmTitle, description FilmDescription, r
 	return domainFilm, nil
 }
 
-func (f *filmServiceImpl) Update(id FilmId, title *FilmTitle, description *FilmDescription, releaseDate *FilmReleaseDate, rating *FilmRating, actorIds *[]ActorId) (*Film, error) {
+func (f *filmServiceImpl) Update(ctx context.Context, id FilmId, version *int64, title *FilmTitle, description *FilmDescription, releaseDate *FilmReleaseDate, rating *FilmRating, actorIds *[]ActorId, credits *[]*FilmCreditParams, genreIds *[]GenreId) (*Film, error) {
 	const operation = "Update"
 
 	log := f.logger.With(
@@ -78,7 +117,7 @@ func (f *filmServiceImpl) Update(id FilmId, title *FilmTitle, description *FilmD
 
 	log.Info("updating a film")
 
-	exists, err := f.filmStorage.IsExists(id)
+	exists, err := f.filmStorage.IsExists(ctx, id)
 	if err != nil {
 		log.Error("failed to update a film", "error", err)
 		return nil, err
@@ -88,20 +127,58 @@ func (f *filmServiceImpl) Update(id FilmId, title *FilmTitle, description *FilmD
 		return nil, ErrFilmNotFound
 	}
 
-	if actorIds != nil {
-		exists, err = f.actorStorage.AreExists(*actorIds)
+	actorIds, credits = mergeFilmUpdateCredits(actorIds, credits)
+
+	var changedCredits []*FilmCreditParams
+	if credits != nil {
+		changedCredits = *credits
+	} else if actorIds != nil {
+		changedCredits = NewActorCredits(*actorIds)
+	}
+
+	if credits != nil || actorIds != nil {
+		if err = validateFilmCredits(changedCredits); err != nil {
+			log.Warn("failed to update a film", "error", err)
+			return nil, err
+		}
+
+		creditedActorIds := creditsActorIds(changedCredits)
+
+		exists, err = f.actorStorage.AreExists(ctx, creditedActorIds)
 		if err != nil {
 			log.Error("failed to update a film", "error", err)
 			return nil, err
 		}
 		if !exists {
-			log.Warn("failed to update a film", "error", fmt.Sprintf("actors with ids '%v' not found", actorIds))
+			log.Warn("failed to update a film", "error", fmt.Sprintf("actors with ids '%v' not found", creditedActorIds))
 			return nil, ErrFilmActorsNotFound
 		}
 	}
 
-	domainFilm, err := f.filmStorage.Update(id, title, description, releaseDate, rating, actorIds)
+	if genreIds != nil {
+		exists, err = f.genreStorage.AreExists(ctx, *genreIds)
+		if err != nil {
+			log.Error("failed to update a film", "error", err)
+			return nil, err
+		}
+		if !exists {
+			log.Warn("failed to update a film", "error", fmt.Sprintf("genres with ids '%v' not found", *genreIds))
+			return nil, ErrFilmGenresNotFound
+		}
+	}
+
+	domainFilm, err := f.filmStorage.Update(ctx, id, version, title, description, releaseDate, rating, actorIds, credits, genreIds, principalId(ctx))
 	if err != nil {
+		if errors.Is(err, storage.ErrFilmVersionMismatch) {
+			log.Warn("failed to update a film", "error", fmt.Sprintf("film with id '%d' has not version '%d'", id.Int64(), *version))
+			return nil, ErrFilmVersionMismatch
+		}
+
+		log.Error("failed to update a film", "error", err)
+		return nil, err
+	}
+
+	if err = attachFilmListFlags(ctx, f.filmListStorage, []*Film{domainFilm}); err != nil {
 		log.Error("failed to update a film", "error", err)
 		return nil, err
 	}
@@ -111,7 +188,7 @@ func (f *filmServiceImpl) Update(id FilmId, title *FilmTitle, description *FilmD
 	return domainFilm, nil
 }
 
-func (f *filmServiceImpl) Delete(id FilmId) error {
+func (f *filmServiceImpl) Delete(ctx context.Context, id FilmId, version *int64) error {
 	const operation = "Delete"
 
 	log := f.logger.With(
@@ -121,7 +198,7 @@ func (f *filmServiceImpl) Delete(id FilmId) error {
 
 	log.Info("deleting a film")
 
-	exists, err := f.filmStorage.IsExists(id)
+	exists, err := f.filmStorage.IsExists(ctx, id)
 	if err != nil {
 		log.Error("failed to delete a film", "error", err)
 		return err
@@ -131,8 +208,13 @@ func (f *filmServiceImpl) Delete(id FilmId) error {
 		return ErrFilmNotFound
 	}
 
-	err = f.filmStorage.Delete(id)
+	err = f.filmStorage.Delete(ctx, id, version, principalId(ctx))
 	if err != nil {
+		if errors.Is(err, storage.ErrFilmVersionMismatch) {
+			log.Warn("failed to delete a film", "error", fmt.Sprintf("film with id '%d' has not version '%d'", id.Int64(), *version))
+			return ErrFilmVersionMismatch
+		}
+
 		log.Error("failed to delete a film", "error", err)
 		return err
 	}
@@ -142,46 +224,329 @@ func (f *filmServiceImpl) Delete(id FilmId) error {
 	return nil
 }
 
-func (f *filmServiceImpl) ListWithSort(titleOrder, releaseDateOrder, ratingOrder string, limit, offset int) ([]*Film, error) {
-	const operation = "ListWithSort"
+func (f *filmServiceImpl) Restore(ctx context.Context, id FilmId) (*Film, error) {
+	const operation = "Restore"
+
+	log := f.logger.With(
+		slog.String("operation", operation),
+		slog.Int64("id", id.Int64()),
+	)
+
+	log.Info("restoring a film")
+
+	domainFilm, err := f.filmStorage.Restore(ctx, id, principalId(ctx))
+	if err != nil {
+		if errors.Is(err, storage.ErrFilmNotFound) {
+			log.Warn("failed to restore a film", "error", fmt.Sprintf("deleted film with id '%d' not found", id.Int64()))
+			return nil, ErrFilmNotFound
+		}
+
+		log.Error("failed to restore a film", "error", err)
+		return nil, err
+	}
+
+	if err = attachFilmListFlags(ctx, f.filmListStorage, []*Film{domainFilm}); err != nil {
+		log.Error("failed to restore a film", "error", err)
+		return nil, err
+	}
+
+	log.Info("film has restored")
+
+	return domainFilm, nil
+}
+
+func (f *filmServiceImpl) GetById(ctx context.Context, id FilmId) (*Film, error) {
+	const operation = "GetById"
+
+	log := f.logger.With(
+		slog.String("operation", operation),
+		slog.Int64("id", id.Int64()),
+	)
+
+	log.Info("getting a film")
+
+	domainFilm, err := f.filmStorage.GetById(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrFilmNotFound) {
+			log.Warn("failed to get a film", "error", fmt.Sprintf("film with id '%d' not found", id.Int64()))
+			return nil, ErrFilmNotFound
+		}
+
+		log.Error("failed to get a film", "error", err)
+		return nil, err
+	}
+
+	if err = attachFilmListFlags(ctx, f.filmListStorage, []*Film{domainFilm}); err != nil {
+		log.Error("failed to get a film", "error", err)
+		return nil, err
+	}
+
+	log.Info("film has got")
+
+	return domainFilm, nil
+}
+
+func (f *filmServiceImpl) List(ctx context.Context, filter *FilmFilter, sort FilmSort, cursor *FilmCursor, withTotal bool, limit, offset int) (*FilmsPage, error) {
+	const operation = "List"
 
 	log := f.logger.With(
 		slog.String("operation", operation),
 		slog.Int("limit", limit),
 		slog.Int("offset", offset),
+		slog.Any("filter", filter),
 	)
 
 	log.Info("listing films")
 
-	domainFilms, err := f.filmStorage.ListWithSort(titleOrder, releaseDateOrder, ratingOrder, limit, offset)
+	if err := filter.Validate(); err != nil {
+		log.Warn("failed to list films", "error", err)
+		return nil, err
+	}
+
+	domainFilms, nextCursor, err := f.filmStorage.List(ctx, filter, sort, cursor, limit, offset)
 	if err != nil {
 		log.Error("failed to list films", "error", err)
 		return nil, err
 	}
 
+	if err = attachFilmListFlags(ctx, f.filmListStorage, domainFilms); err != nil {
+		log.Error("failed to list films", "error", err)
+		return nil, err
+	}
+
+	filmsPage := &FilmsPage{
+		Films:      domainFilms,
+		NextCursor: nextCursor,
+	}
+
+	if withTotal {
+		total, err := f.filmStorage.Count(ctx, filter)
+		if err != nil {
+			log.Error("failed to count films", "error", err)
+			return nil, err
+		}
+		filmsPage.Total = &total
+	}
+
 	log.Info("films have listed")
 
-	return domainFilms, nil
+	return filmsPage, nil
 }
 
-func (f *filmServiceImpl) SearchByFilters(title FilmTitle, actorName ActorName, limit, offset int) ([]*Film, error) {
-	const operation = "SearchByFilters"
+func (f *filmServiceImpl) Export(ctx context.Context, fn func(film *Film) error) error {
+	const operation = "Export"
+
+	log := f.logger.With(slog.String("operation", operation))
+
+	log.Info("exporting films")
+
+	var exported int
+
+	err := f.filmStorage.Export(ctx, func(film *Film) error {
+		if err := fn(film); err != nil {
+			return err
+		}
+		exported++
+		return nil
+	})
+	if err != nil {
+		log.Error("failed to export films", "error", err, "exported", exported)
+		return err
+	}
+
+	log.Info("films have exported", "exported", exported)
+
+	return nil
+}
+
+func (f *filmServiceImpl) Batch(ctx context.Context, mode BatchMode, operations []*FilmBatchOperation) (*FilmBatchReport, error) {
+	const operation = "Batch"
 
 	log := f.logger.With(
 		slog.String("operation", operation),
-		slog.String("title", title.String()),
-		slog.String("actorName", actorName.String()),
+		slog.String("mode", string(mode)),
+		slog.Int("operations", len(operations)),
 	)
 
-	log.Info("searching films")
+	log.Info("applying a batch of films")
 
-	domainFilms, err := f.filmStorage.SearchByFilters(title, actorName, limit, offset)
-	if err != nil {
-		log.Error("failed to search films", "error", err)
+	if err := validateBatch(mode, len(operations)); err != nil {
+		log.Warn("failed to apply a batch of films", "error", err)
 		return nil, err
 	}
 
-	log.Info("films have searched")
+	report := &FilmBatchReport{
+		Mode:  mode,
+		Items: make([]*FilmBatchItem, len(operations)),
+	}
+
+	var checkedOperations []*FilmBatchOperation
+	var checkedItems []*FilmBatchItem
+	var failed bool
+
+	for i, batchOperation := range operations {
+		item := &FilmBatchItem{Index: i, Type: batchOperation.Type, Id: batchOperation.Id}
+		report.Items[i] = item
+
+		checkedOperation, operationErr, err := f.checkBatchOperation(ctx, batchOperation)
+		if err != nil {
+			log.Error("failed to apply a batch of films", "error", err)
+			return nil, err
+		}
+		if operationErr != nil {
+			item.Status = BatchItemFailed
+			item.Err = operationErr
+			failed = true
+			continue
+		}
+
+		checkedOperations = append(checkedOperations, checkedOperation)
+		checkedItems = append(checkedItems, item)
+	}
+
+	// Nothing of a transactional batch can be applied once any of its operations is invalid
+	if mode == BatchModeTransactional && failed {
+		for _, item := range checkedItems {
+			item.Status = BatchItemAborted
+		}
+
+		log.Warn("batch of films has not applied")
+
+		return report, nil
+	}
+
+	if len(checkedOperations) > 0 {
+		storedItems, committed, err := f.filmStorage.Batch(ctx, mode, checkedOperations, principalId(ctx))
+		if err != nil {
+			log.Error("failed to apply a batch of films", "error", err)
+			return nil, err
+		}
+		report.Committed = committed
+
+		for i, storedItem := range storedItems {
+			item := checkedItems[i]
+			item.Status = storedItem.Status
+			item.Film = storedItem.Film
+			item.Err = storedItem.Err
+
+			if errors.Is(item.Err, storage.ErrFilmNotFound) {
+				item.Err = ErrFilmNotFound
+			} else if errors.Is(item.Err, storage.ErrFilmVersionMismatch) {
+				item.Err = ErrFilmVersionMismatch
+			}
+			if item.Film != nil {
+				item.Id = item.Film.Id
+			}
+		}
+	}
+
+	if !report.Committed {
+		log.Warn("batch of films has not applied")
+		return report, nil
+	}
+
+	log.Info("batch of films has applied")
+
+	return report, nil
+}
+
+// checkBatchOperation checks the operation the same way as Create and Update do and returns it with the credits
+// merged as the storage expects them. The error of the operation is returned separately from a failure of the check.
+func (f *filmServiceImpl) checkBatchOperation(ctx context.Context, batchOperation *FilmBatchOperation) (*FilmBatchOperation, error, error) {
+	var credits []*FilmCreditParams
+	var genreIds []GenreId
+
+	checkedOperation := &FilmBatchOperation{Type: batchOperation.Type, Id: batchOperation.Id, Version: batchOperation.Version}
+
+	switch batchOperation.Type {
+	case BatchOperationCreate:
+		if batchOperation.Create == nil {
+			return nil, fmt.Errorf("%w: film values of the 'create' operation are missing", ErrInvalidBatch), nil
+		}
+		if batchOperation.Version != nil {
+			return nil, fmt.Errorf("%w: the 'create' operation cannot have a version", ErrInvalidBatch), nil
+		}
+
+		createParams := *batchOperation.Create
+		createParams.Credits = append(NewActorCredits(createParams.ActorIds), createParams.Credits...)
+		createParams.ActorIds = nil
+
+		credits = createParams.Credits
+		genreIds = createParams.GenreIds
+		checkedOperation.Create = &createParams
+	case BatchOperationUpdate:
+		if batchOperation.Update == nil {
+			return nil, fmt.Errorf("%w: film values of the 'update' operation are missing", ErrInvalidBatch), nil
+		}
+
+		updateParams := *batchOperation.Update
+		updateParams.ActorIds, updateParams.Credits = mergeFilmUpdateCredits(updateParams.ActorIds, updateParams.Credits)
+
+		if updateParams.Credits != nil {
+			credits = *updateParams.Credits
+		} else if updateParams.ActorIds != nil {
+			credits = NewActorCredits(*updateParams.ActorIds)
+		}
+		if updateParams.GenreIds != nil {
+			genreIds = *updateParams.GenreIds
+		}
+		checkedOperation.Update = &updateParams
+	case BatchOperationDelete:
+		return checkedOperation, nil, nil
+	default:
+		return nil, fmt.Errorf("%w: unexpected operation '%s'", ErrInvalidBatch, batchOperation.Type), nil
+	}
+
+	if err := validateFilmCredits(credits); err != nil {
+		return nil, err, nil
+	}
+
+	if creditedActorIds := creditsActorIds(credits); len(creditedActorIds) > 0 {
+		exists, err := f.actorStorage.AreExists(ctx, creditedActorIds)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !exists {
+			return nil, fmt.Errorf("%w: actors with ids '%v' not found", ErrFilmActorsNotFound, creditedActorIds), nil
+		}
+	}
+
+	if len(genreIds) > 0 {
+		exists, err := f.genreStorage.AreExists(ctx, genreIds)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !exists {
+			return nil, fmt.Errorf("%w: genres with ids '%v' not found", ErrFilmGenresNotFound, genreIds), nil
+		}
+	}
+
+	return checkedOperation, nil, nil
+}
 
-	return domainFilms, nil
+// mergeFilmUpdateCredits merges plain actor ids into explicit credits, because explicit credits replace all credits
+// of the film. Without explicit credits, plain actor ids replace only the credits with the 'actor' role.
+func mergeFilmUpdateCredits(actorIds *[]ActorId, credits *[]*FilmCreditParams) (*[]ActorId, *[]*FilmCreditParams) {
+	if credits == nil || actorIds == nil {
+		return actorIds, credits
+	}
+
+	mergedCredits := append(NewActorCredits(*actorIds), *credits...)
+
+	return nil, &mergedCredits
+}
+
+func validateFilmCredits(credits []*FilmCreditParams) error {
+	for _, credit := range credits {
+		if !credit.Role.IsValid() {
+			return fmt.Errorf("%w: unexpected role '%s'", ErrInvalidFilmCredit, credit.Role)
+		}
+		if credit.BillingOrder < 0 {
+			return fmt.Errorf("%w: billing order must not be negative", ErrInvalidFilmCredit)
+		}
+		if credit.CharacterName != "" && credit.Role != CreditRoleActor {
+			return fmt.Errorf("%w: character name is allowed only for the '%s' role", ErrInvalidFilmCredit, CreditRoleActor)
+		}
+	}
+	return nil
 }
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidFilmSort = errors.New("invalid film sort")
+
+type FilmSortField string
+
+const (
+	FilmSortFieldId             FilmSortField = "id"
+	FilmSortFieldTitle          FilmSortField = "title"
+	FilmSortFieldReleaseDate    FilmSortField = "release-date"
+	FilmSortFieldRating         FilmSortField = "rating"
+	FilmSortFieldActorsCount    FilmSortField = "actors-count"
+	FilmSortFieldCommunityScore FilmSortField = "community-score"
+)
+
+var filmSortFields = []FilmSortField{
+	FilmSortFieldId,
+	FilmSortFieldTitle,
+	FilmSortFieldReleaseDate,
+	FilmSortFieldRating,
+	FilmSortFieldActorsCount,
+	FilmSortFieldCommunityScore,
+}
+
+func (field FilmSortField) IsValid() bool {
+	for _, filmSortField := range filmSortFields {
+		if field == filmSortField {
+			return true
+		}
+	}
+	return false
+}
+
+type SortDirection string
+
+const (
+	SortDirectionAsc  SortDirection = "asc"
+	SortDirectionDesc SortDirection = "desc"
+)
+
+func (direction SortDirection) IsValid() bool {
+	return direction == SortDirectionAsc || direction == SortDirectionDesc
+}
+
+type FilmSortKey struct {
+	Field     FilmSortField
+	Direction SortDirection
+}
+
+// FilmSort is an ordered list of sort keys: films are sorted by the first key,
+// then by the second one, and so on. An empty FilmSort means the default order.
+type FilmSort []FilmSortKey
+
+// NewFilmSort validates the keys: every field must be known, every direction must be
+// either 'asc' or 'desc' and no field may be repeated.
+func NewFilmSort(keys ...FilmSortKey) (FilmSort, error) {
+	seen := make(map[FilmSortField]bool, len(keys))
+
+	for _, key := range keys {
+		if !key.Field.IsValid() {
+			return nil, fmt.Errorf("%w: unexpected sort field '%s'", ErrInvalidFilmSort, key.Field)
+		}
+		if !key.Direction.IsValid() {
+			return nil, fmt.Errorf("%w: unexpected sort direction '%s' of field '%s'", ErrInvalidFilmSort, key.Direction, key.Field)
+		}
+		if seen[key.Field] {
+			return nil, fmt.Errorf("%w: sort field '%s' is repeated", ErrInvalidFilmSort, key.Field)
+		}
+		seen[key.Field] = true
+	}
+
+	return keys, nil
+}
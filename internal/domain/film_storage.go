@@ -1,10 +1,26 @@
 package domain
 
+import "context"
+
+// FilmStorage keeps films with their credits and genres.
+// On update, non-nil credits replace all credits of the film, otherwise non-nil actorIds replace the credits with the 'actor' role.
+// Every update increments the version of the film, deleting and restoring keep it. Update and Delete with a non-nil version fail with
+// storage.ErrFilmVersionMismatch if the film has another one, and so do the update and delete operations of Batch.
+// Delete moves the film to the trash, other methods never see deleted films and credits of deleted actors.
+// Restore brings a deleted film back with its credits and genres, it fails with storage.ErrFilmNotFound if the film is not in the trash.
+// Without an explicit sort, films found by a search query of the filter are ordered by relevance, the most relevant first.
+// Export calls fn for every film with its actors in the order of ids and stops at the first error of fn.
+// Batch applies the operations in a single transaction and returns an item for each of them in the same order.
+// The transaction is committed in the best-effort mode or if all operations have succeeded.
 type FilmStorage interface {
-	Create(title FilmTitle, description FilmDescription, releaseDate FilmReleaseDate, rating FilmRating, actorIds []ActorId) (*Film, error)
-	Update(id FilmId, title *FilmTitle, description *FilmDescription, releaseDate *FilmReleaseDate, rating *FilmRating, actorIds *[]ActorId) (*Film, error)
-	Delete(id FilmId) error
-	ListWithSort(titleOrder, releaseDateOrder, ratingOrder string, limit, offset int) ([]*Film, error)
-	SearchByFilters(title FilmTitle, actorName ActorName, limit, offset int) ([]*Film, error)
-	IsExists(id FilmId) (bool, error)
+	Create(ctx context.Context, title FilmTitle, description FilmDescription, releaseDate FilmReleaseDate, rating FilmRating, credits []*FilmCreditParams, genreIds []GenreId, createdBy *int64) (*Film, error)
+	Update(ctx context.Context, id FilmId, version *int64, title *FilmTitle, description *FilmDescription, releaseDate *FilmReleaseDate, rating *FilmRating, actorIds *[]ActorId, credits *[]*FilmCreditParams, genreIds *[]GenreId, updatedBy *int64) (*Film, error)
+	Delete(ctx context.Context, id FilmId, version *int64, deletedBy *int64) error
+	Restore(ctx context.Context, id FilmId, restoredBy *int64) (*Film, error)
+	GetById(ctx context.Context, id FilmId) (*Film, error)
+	List(ctx context.Context, filter *FilmFilter, sort FilmSort, cursor *FilmCursor, limit, offset int) ([]*Film, *FilmCursor, error)
+	Count(ctx context.Context, filter *FilmFilter) (int64, error)
+	IsExists(ctx context.Context, id FilmId) (bool, error)
+	Export(ctx context.Context, fn func(film *Film) error) error
+	Batch(ctx context.Context, mode BatchMode, operations []*FilmBatchOperation, by *int64) ([]*FilmBatchItem, bool, error)
 }
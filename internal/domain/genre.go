@@ -0,0 +1,18 @@
+package domain
+
+type GenreId int64
+
+func (genreId *GenreId) Int64() int64 {
+	return int64(*genreId)
+}
+
+type GenreName string
+
+func (genreName *GenreName) String() string {
+	return string(*genreName)
+}
+
+type Genre struct {
+	Id   GenreId
+	Name GenreName
+}
@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"github.com/vaberof/vk-internship-task/pkg/logging/logs"
+	"log/slog"
+)
+
+var (
+	ErrGenreNotFound      = errors.New("genre not found")
+	ErrGenreAlreadyExists = errors.New("genre with such name already exists")
+)
+
+type GenreService interface {
+	Create(ctx context.Context, name GenreName) (*Genre, error)
+	Update(ctx context.Context, id GenreId, name GenreName) (*Genre, error)
+	Delete(ctx context.Context, id GenreId) error
+	GetById(ctx context.Context, id GenreId) (*Genre, error)
+	List(ctx context.Context) ([]*Genre, error)
+}
+
+type genreServiceImpl struct {
+	genreStorage GenreStorage
+
+	logger *slog.Logger
+}
+
+func NewGenreService(genreStorage GenreStorage, logsBuilder *logs.Logs) GenreService {
+	logger := logsBuilder.WithName("domain.service.genre")
+	return &genreServiceImpl{
+		genreStorage: genreStorage,
+		logger:       logger,
+	}
+}
+
+func (g *genreServiceImpl) Create(ctx context.Context, name GenreName) (*Genre, error) {
+	const operation = "Create"
+
+	log := g.logger.With(
+		slog.String("operation", operation),
+		slog.String("name", name.String()))
+
+	log.Info("creating a genre")
+
+	domainGenre, err := g.genreStorage.Create(ctx, name)
+	if err != nil {
+		if errors.Is(err, storage.ErrGenreAlreadyExists) {
+			log.Warn("failed to create a genre", "error", err)
+			return nil, ErrGenreAlreadyExists
+		}
+
+		log.Error("failed to create a genre", "error", err)
+		return nil, err
+	}
+
+	log.Info("genre has created")
+
+	return domainGenre, nil
+}
+
+func (g *genreServiceImpl) Update(ctx context.Context, id GenreId, name GenreName) (*Genre, error) {
+	const operation = "Update"
+
+	log := g.logger.With(
+		slog.String("operation", operation),
+		slog.Int64("id", id.Int64()))
+
+	log.Info("updating a genre")
+
+	domainGenre, err := g.genreStorage.Update(ctx, id, name)
+	if err != nil {
+		if errors.Is(err, storage.ErrGenreNotFound) {
+			log.Warn("failed to update a genre", "error", fmt.Sprintf("genre with id '%d' not found", id.Int64()))
+			return nil, ErrGenreNotFound
+		}
+		if errors.Is(err, storage.ErrGenreAlreadyExists) {
+			log.Warn("failed to update a genre", "error", err)
+			return nil, ErrGenreAlreadyExists
+		}
+
+		log.Error("failed to update a genre", "error", err)
+		return nil, err
+	}
+
+	log.Info("genre has updated")
+
+	return domainGenre, nil
+}
+
+func (g *genreServiceImpl) Delete(ctx context.Context, id GenreId) error {
+	const operation = "Delete"
+
+	log := g.logger.With(
+		slog.String("operation", operation),
+		slog.Int64("id", id.Int64()))
+
+	log.Info("deleting a genre")
+
+	err := g.genreStorage.Delete(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrGenreNotFound) {
+			log.Warn("failed to delete a genre", "error", fmt.Sprintf("genre with id '%d' not found", id.Int64()))
+			return ErrGenreNotFound
+		}
+
+		log.Error("failed to delete a genre", "error", err)
+		return err
+	}
+
+	log.Info("genre has deleted")
+
+	return nil
+}
+
+func (g *genreServiceImpl) GetById(ctx context.Context, id GenreId) (*Genre, error) {
+	const operation = "GetById"
+
+	log := g.logger.With(
+		slog.String("operation", operation),
+		slog.Int64("id", id.Int64()))
+
+	log.Info("getting a genre")
+
+	domainGenre, err := g.genreStorage.GetById(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrGenreNotFound) {
+			log.Warn("failed to get a genre", "error", fmt.Sprintf("genre with id '%d' not found", id.Int64()))
+			return nil, ErrGenreNotFound
+		}
+
+		log.Error("failed to get a genre", "error", err)
+		return nil, err
+	}
+
+	log.Info("genre has got")
+
+	return domainGenre, nil
+}
+
+func (g *genreServiceImpl) List(ctx context.Context) ([]*Genre, error) {
+	const operation = "List"
+
+	log := g.logger.With(slog.String("operation", operation))
+
+	log.Info("listing genres")
+
+	domainGenres, err := g.genreStorage.List(ctx)
+	if err != nil {
+		log.Error("failed to list genres", "error", err)
+		return nil, err
+	}
+
+	log.Info("genres have listed")
+
+	return domainGenres, nil
+}
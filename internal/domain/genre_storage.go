@@ -0,0 +1,12 @@
+package domain
+
+import "context"
+
+type GenreStorage interface {
+	Create(ctx context.Context, name GenreName) (*Genre, error)
+	Update(ctx context.Context, id GenreId, name GenreName) (*Genre, error)
+	Delete(ctx context.Context, id GenreId) error
+	GetById(ctx context.Context, id GenreId) (*Genre, error)
+	List(ctx context.Context) ([]*Genre, error)
+	AreExists(ctx context.Context, ids []GenreId) (bool, error)
+}
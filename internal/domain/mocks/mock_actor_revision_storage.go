@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/actor_revision_storage.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/actor_revision_storage.go -destination=internal/domain/mocks/mock_actor_revision_storage.go
+//
+
+// Package mock_domain is a generated GoMock package.
+package mock_domain
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/vaberof/vk-internship-task/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockActorRevisionStorage is a mock of ActorRevisionStorage interface.
+type MockActorRevisionStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockActorRevisionStorageMockRecorder
+}
+
+// MockActorRevisionStorageMockRecorder is the mock recorder for MockActorRevisionStorage.
+type MockActorRevisionStorageMockRecorder struct {
+	mock *MockActorRevisionStorage
+}
+
+// NewMockActorRevisionStorage creates a new mock instance.
+func NewMockActorRevisionStorage(ctrl *gomock.Controller) *MockActorRevisionStorage {
+	mock := &MockActorRevisionStorage{ctrl: ctrl}
+	mock.recorder = &MockActorRevisionStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockActorRevisionStorage) EXPECT() *MockActorRevisionStorageMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockActorRevisionStorage) Count(ctx context.Context, actorId domain.ActorId) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, actorId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockActorRevisionStorageMockRecorder) Count(ctx, actorId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockActorRevisionStorage)(nil).Count), ctx, actorId)
+}
+
+// Get mocks base method.
+func (m *MockActorRevisionStorage) Get(ctx context.Context, actorId domain.ActorId, revision int64) (*domain.ActorRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, actorId, revision)
+	ret0, _ := ret[0].(*domain.ActorRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockActorRevisionStorageMockRecorder) Get(ctx, actorId, revision any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockActorRevisionStorage)(nil).Get), ctx, actorId, revision)
+}
+
+// List mocks base method.
+func (m *MockActorRevisionStorage) List(ctx context.Context, actorId domain.ActorId, limit, offset int) ([]*domain.ActorRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, actorId, limit, offset)
+	ret0, _ := ret[0].([]*domain.ActorRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockActorRevisionStorageMockRecorder) List(ctx, actorId, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockActorRevisionStorage)(nil).List), ctx, actorId, limit, offset)
+}
@@ -10,6 +10,7 @@
 package mock_domain
 
 import (
+	context "context"
 	reflect "reflect"
 
 	domain "github.com/vaberof/vk-internship-task/internal/domain"
@@ -40,90 +41,166 @@ func (m *MockActorStorage) EXPECT() *MockActorStorageMockRecorder {
 }
 
 // AreExists mocks base method.
-func (m *MockActorStorage) AreExists(ids []domain.ActorId) (bool, error) {
+func (m *MockActorStorage) AreExists(ctx context.Context, ids []domain.ActorId) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AreExists", ids)
+	ret := m.ctrl.Call(m, "AreExists", ctx, ids)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AreExists indicates an expected call of AreExists.
-func (mr *MockActorStorageMockRecorder) AreExists(ids any) *gomock.Call {
+func (mr *MockActorStorageMockRecorder) AreExists(ctx, ids any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AreExists", reflect.TypeOf((*MockActorStorage)(nil).AreExists), ids)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AreExists", reflect.TypeOf((*MockActorStorage)(nil).AreExists), ctx, ids)
+}
+
+// Batch mocks base method.
+func (m *MockActorStorage) Batch(ctx context.Context, mode domain.BatchMode, operations []*domain.ActorBatchOperation, by *int64) ([]*domain.ActorBatchItem, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Batch", ctx, mode, operations, by)
+	ret0, _ := ret[0].([]*domain.ActorBatchItem)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Batch indicates an expected call of Batch.
+func (mr *MockActorStorageMockRecorder) Batch(ctx, mode, operations, by any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Batch", reflect.TypeOf((*MockActorStorage)(nil).Batch), ctx, mode, operations, by)
+}
+
+// Count mocks base method.
+func (m *MockActorStorage) Count(ctx context.Context, filter *domain.ActorFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockActorStorageMockRecorder) Count(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockActorStorage)(nil).Count), ctx, filter)
 }
 
 // Create mocks base method.
-func (m *MockActorStorage) Create(name domain.ActorName, sex domain.ActorSex, birthDate domain.ActorBirthDate) (*domain.Actor, error) {
+func (m *MockActorStorage) Create(ctx context.Context, name domain.ActorName, sex domain.ActorSex, birthDate domain.ActorBirthDate, createdBy *int64) (*domain.Actor, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", name, sex, birthDate)
+	ret := m.ctrl.Call(m, "Create", ctx, name, sex, birthDate, createdBy)
 	ret0, _ := ret[0].(*domain.Actor)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockActorStorageMockRecorder) Create(name, sex, birthDate any) *gomock.Call {
+func (mr *MockActorStorageMockRecorder) Create(ctx, name, sex, birthDate, createdBy any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockActorStorage)(nil).Create), name, sex, birthDate)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockActorStorage)(nil).Create), ctx, name, sex, birthDate, createdBy)
 }
 
 // Delete mocks base method.
-func (m *MockActorStorage) Delete(id domain.ActorId) error {
+func (m *MockActorStorage) Delete(ctx context.Context, id domain.ActorId, version, deletedBy *int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, version, deletedBy)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockActorStorageMockRecorder) Delete(id any) *gomock.Call {
+func (mr *MockActorStorageMockRecorder) Delete(ctx, id, version, deletedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockActorStorage)(nil).Delete), ctx, id, version, deletedBy)
+}
+
+// Export mocks base method.
+func (m *MockActorStorage) Export(ctx context.Context, fn func(*domain.Actor) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockActorStorageMockRecorder) Export(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockActorStorage)(nil).Export), ctx, fn)
+}
+
+// GetById mocks base method.
+func (m *MockActorStorage) GetById(ctx context.Context, id domain.ActorId) (*domain.Actor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*domain.Actor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockActorStorageMockRecorder) GetById(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockActorStorage)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockActorStorage)(nil).GetById), ctx, id)
 }
 
 // IsExists mocks base method.
-func (m *MockActorStorage) IsExists(id domain.ActorId) (bool, error) {
+func (m *MockActorStorage) IsExists(ctx context.Context, id domain.ActorId) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsExists", id)
+	ret := m.ctrl.Call(m, "IsExists", ctx, id)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // IsExists indicates an expected call of IsExists.
-func (mr *MockActorStorageMockRecorder) IsExists(id any) *gomock.Call {
+func (mr *MockActorStorageMockRecorder) IsExists(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsExists", reflect.TypeOf((*MockActorStorage)(nil).IsExists), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsExists", reflect.TypeOf((*MockActorStorage)(nil).IsExists), ctx, id)
 }
 
 // List mocks base method.
-func (m *MockActorStorage) List(limit, offset int) ([]*domain.Actor, error) {
+func (m *MockActorStorage) List(ctx context.Context, filter *domain.ActorFilter, sort domain.ActorSort, cursor *domain.ActorCursor, limit, offset int) ([]*domain.Actor, *domain.ActorCursor, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", limit, offset)
+	ret := m.ctrl.Call(m, "List", ctx, filter, sort, cursor, limit, offset)
 	ret0, _ := ret[0].([]*domain.Actor)
+	ret1, _ := ret[1].(*domain.ActorCursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockActorStorageMockRecorder) List(ctx, filter, sort, cursor, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockActorStorage)(nil).List), ctx, filter, sort, cursor, limit, offset)
+}
+
+// Restore mocks base method.
+func (m *MockActorStorage) Restore(ctx context.Context, id domain.ActorId, restoredBy *int64) (*domain.Actor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id, restoredBy)
+	ret0, _ := ret[0].(*domain.Actor)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// List indicates an expected call of List.
-func (mr *MockActorStorageMockRecorder) List(limit, offset any) *gomock.Call {
+// Restore indicates an expected call of Restore.
+func (mr *MockActorStorageMockRecorder) Restore(ctx, id, restoredBy any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockActorStorage)(nil).List), limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockActorStorage)(nil).Restore), ctx, id, restoredBy)
 }
 
 // Update mocks base method.
-func (m *MockActorStorage) Update(id domain.ActorId, name *domain.ActorName, sex *domain.ActorSex, birthDate *domain.ActorBirthDate) (*domain.Actor, error) {
+func (m *MockActorStorage) Update(ctx context.Context, id domain.ActorId, version *int64, name *domain.ActorName, sex *domain.ActorSex, birthDate *domain.ActorBirthDate, updatedBy *int64) (*domain.Actor, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", id, name, sex, birthDate)
+	ret := m.ctrl.Call(m, "Update", ctx, id, version, name, sex, birthDate, updatedBy)
 	ret0, _ := ret[0].(*domain.Actor)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockActorStorageMockRecorder) Update(id, name, sex, birthDate any) *gomock.Call {
+func (mr *MockActorStorageMockRecorder) Update(ctx, id, version, name, sex, birthDate, updatedBy any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockActorStorage)(nil).Update), id, name, sex, birthDate)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockActorStorage)(nil).Update), ctx, id, version, name, sex, birthDate, updatedBy)
 }
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/audit_storage.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/audit_storage.go -destination=internal/domain/mocks/mock_audit_storage.go
+//
+
+// Package mock_domain is a generated GoMock package.
+package mock_domain
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/vaberof/vk-internship-task/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuditStorage is a mock of AuditStorage interface.
+type MockAuditStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditStorageMockRecorder
+}
+
+// MockAuditStorageMockRecorder is the mock recorder for MockAuditStorage.
+type MockAuditStorageMockRecorder struct {
+	mock *MockAuditStorage
+}
+
+// NewMockAuditStorage creates a new mock instance.
+func NewMockAuditStorage(ctrl *gomock.Controller) *MockAuditStorage {
+	mock := &MockAuditStorage{ctrl: ctrl}
+	mock.recorder = &MockAuditStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditStorage) EXPECT() *MockAuditStorageMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockAuditStorage) Count(ctx context.Context, filter *domain.AuditFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockAuditStorageMockRecorder) Count(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockAuditStorage)(nil).Count), ctx, filter)
+}
+
+// List mocks base method.
+func (m *MockAuditStorage) List(ctx context.Context, filter *domain.AuditFilter, limit, offset int) ([]*domain.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter, limit, offset)
+	ret0, _ := ret[0].([]*domain.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockAuditStorageMockRecorder) List(ctx, filter, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAuditStorage)(nil).List), ctx, filter, limit, offset)
+}
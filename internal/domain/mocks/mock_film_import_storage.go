@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/film_import_storage.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/film_import_storage.go -destination=internal/domain/mocks/mock_film_import_storage.go
+//
+
+// Package mock_domain is a generated GoMock package.
+package mock_domain
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/vaberof/vk-internship-task/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFilmImportStorage is a mock of FilmImportStorage interface.
+type MockFilmImportStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockFilmImportStorageMockRecorder
+}
+
+// MockFilmImportStorageMockRecorder is the mock recorder for MockFilmImportStorage.
+type MockFilmImportStorageMockRecorder struct {
+	mock *MockFilmImportStorage
+}
+
+// NewMockFilmImportStorage creates a new mock instance.
+func NewMockFilmImportStorage(ctrl *gomock.Controller) *MockFilmImportStorage {
+	mock := &MockFilmImportStorage{ctrl: ctrl}
+	mock.recorder = &MockFilmImportStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFilmImportStorage) EXPECT() *MockFilmImportStorageMockRecorder {
+	return m.recorder
+}
+
+// Import mocks base method.
+func (m *MockFilmImportStorage) Import(ctx context.Context, rows []*domain.FilmImportRow, commit bool, importedBy *int64) (*domain.FilmImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, rows, commit, importedBy)
+	ret0, _ := ret[0].(*domain.FilmImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockFilmImportStorageMockRecorder) Import(ctx, rows, commit, importedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockFilmImportStorage)(nil).Import), ctx, rows, commit, importedBy)
+}
@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/film_list_storage.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/film_list_storage.go -destination=internal/domain/mocks/mock_film_list_storage.go
+//
+
+// Package mock_domain is a generated GoMock package.
+package mock_domain
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/vaberof/vk-internship-task/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFilmListStorage is a mock of FilmListStorage interface.
+type MockFilmListStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockFilmListStorageMockRecorder
+}
+
+// MockFilmListStorageMockRecorder is the mock recorder for MockFilmListStorage.
+type MockFilmListStorageMockRecorder struct {
+	mock *MockFilmListStorage
+}
+
+// NewMockFilmListStorage creates a new mock instance.
+func NewMockFilmListStorage(ctrl *gomock.Controller) *MockFilmListStorage {
+	mock := &MockFilmListStorage{ctrl: ctrl}
+	mock.recorder = &MockFilmListStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFilmListStorage) EXPECT() *MockFilmListStorageMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockFilmListStorage) Add(ctx context.Context, userId int64, list domain.FilmList, filmId domain.FilmId) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, userId, list, filmId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockFilmListStorageMockRecorder) Add(ctx, userId, list, filmId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockFilmListStorage)(nil).Add), ctx, userId, list, filmId)
+}
+
+// CountFilms mocks base method.
+func (m *MockFilmListStorage) CountFilms(ctx context.Context, userId int64, list domain.FilmList) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountFilms", ctx, userId, list)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountFilms indicates an expected call of CountFilms.
+func (mr *MockFilmListStorageMockRecorder) CountFilms(ctx, userId, list any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountFilms", reflect.TypeOf((*MockFilmListStorage)(nil).CountFilms), ctx, userId, list)
+}
+
+// GetFilmsLists mocks base method.
+func (m *MockFilmListStorage) GetFilmsLists(ctx context.Context, userId int64, filmIds []domain.FilmId) (map[domain.FilmId][]domain.FilmList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilmsLists", ctx, userId, filmIds)
+	ret0, _ := ret[0].(map[domain.FilmId][]domain.FilmList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilmsLists indicates an expected call of GetFilmsLists.
+func (mr *MockFilmListStorageMockRecorder) GetFilmsLists(ctx, userId, filmIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmsLists", reflect.TypeOf((*MockFilmListStorage)(nil).GetFilmsLists), ctx, userId, filmIds)
+}
+
+// ListFilms mocks base method.
+func (m *MockFilmListStorage) ListFilms(ctx context.Context, userId int64, list domain.FilmList, sort domain.FilmSort, cursor *domain.FilmCursor, limit, offset int) ([]*domain.Film, *domain.FilmCursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFilms", ctx, userId, list, sort, cursor, limit, offset)
+	ret0, _ := ret[0].([]*domain.Film)
+	ret1, _ := ret[1].(*domain.FilmCursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListFilms indicates an expected call of ListFilms.
+func (mr *MockFilmListStorageMockRecorder) ListFilms(ctx, userId, list, sort, cursor, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFilms", reflect.TypeOf((*MockFilmListStorage)(nil).ListFilms), ctx, userId, list, sort, cursor, limit, offset)
+}
+
+// Remove mocks base method.
+func (m *MockFilmListStorage) Remove(ctx context.Context, userId int64, list domain.FilmList, filmId domain.FilmId) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, userId, list, filmId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockFilmListStorageMockRecorder) Remove(ctx, userId, list, filmId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockFilmListStorage)(nil).Remove), ctx, userId, list, filmId)
+}
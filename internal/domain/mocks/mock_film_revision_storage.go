@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/film_revision_storage.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/film_revision_storage.go -destination=internal/domain/mocks/mock_film_revision_storage.go
+//
+
+// Package mock_domain is a generated GoMock package.
+package mock_domain
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/vaberof/vk-internship-task/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFilmRevisionStorage is a mock of FilmRevisionStorage interface.
+type MockFilmRevisionStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockFilmRevisionStorageMockRecorder
+}
+
+// MockFilmRevisionStorageMockRecorder is the mock recorder for MockFilmRevisionStorage.
+type MockFilmRevisionStorageMockRecorder struct {
+	mock *MockFilmRevisionStorage
+}
+
+// NewMockFilmRevisionStorage creates a new mock instance.
+func NewMockFilmRevisionStorage(ctrl *gomock.Controller) *MockFilmRevisionStorage {
+	mock := &MockFilmRevisionStorage{ctrl: ctrl}
+	mock.recorder = &MockFilmRevisionStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFilmRevisionStorage) EXPECT() *MockFilmRevisionStorageMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockFilmRevisionStorage) Count(ctx context.Context, filmId domain.FilmId) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, filmId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockFilmRevisionStorageMockRecorder) Count(ctx, filmId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockFilmRevisionStorage)(nil).Count), ctx, filmId)
+}
+
+// Get mocks base method.
+func (m *MockFilmRevisionStorage) Get(ctx context.Context, filmId domain.FilmId, revision int64) (*domain.FilmRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, filmId, revision)
+	ret0, _ := ret[0].(*domain.FilmRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockFilmRevisionStorageMockRecorder) Get(ctx, filmId, revision any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockFilmRevisionStorage)(nil).Get), ctx, filmId, revision)
+}
+
+// List mocks base method.
+func (m *MockFilmRevisionStorage) List(ctx context.Context, filmId domain.FilmId, limit, offset int) ([]*domain.FilmRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filmId, limit, offset)
+	ret0, _ := ret[0].([]*domain.FilmRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockFilmRevisionStorageMockRecorder) List(ctx, filmId, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockFilmRevisionStorage)(nil).List), ctx, filmId, limit, offset)
+}
@@ -10,6 +10,7 @@
 package mock_domain
 
 import (
+	context "context"
 	reflect "reflect"
 
 	domain "github.com/vaberof/vk-internship-task/internal/domain"
@@ -39,91 +40,152 @@ func (m *MockFilmStorage) EXPECT() *MockFilmStorageMockRecorder {
 	return m.recorder
 }
 
+// Batch mocks base method.
+func (m *MockFilmStorage) Batch(ctx context.Context, mode domain.BatchMode, operations []*domain.FilmBatchOperation, by *int64) ([]*domain.FilmBatchItem, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Batch", ctx, mode, operations, by)
+	ret0, _ := ret[0].([]*domain.FilmBatchItem)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Batch indicates an expected call of Batch.
+func (mr *MockFilmStorageMockRecorder) Batch(ctx, mode, operations, by any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Batch", reflect.TypeOf((*MockFilmStorage)(nil).Batch), ctx, mode, operations, by)
+}
+
+// Count mocks base method.
+func (m *MockFilmStorage) Count(ctx context.Context, filter *domain.FilmFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockFilmStorageMockRecorder) Count(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockFilmStorage)(nil).Count), ctx, filter)
+}
+
 // Create mocks base method.
-func (m *MockFilmStorage) Create(title domain.FilmTitle, description domain.FilmDescription, releaseDate domain.FilmReleaseDate, rating domain.FilmRating, actorIds []domain.ActorId) (*domain.Film, error) {
+func (m *MockFilmStorage) Create(ctx context.Context, title domain.FilmTitle, description domain.FilmDescription, releaseDate domain.FilmReleaseDate, rating domain.FilmRating, credits []*domain.FilmCreditParams, genreIds []domain.GenreId, createdBy *int64) (*domain.Film, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", title, description, releaseDate, rating, actorIds)
+	ret := m.ctrl.Call(m, "Create", ctx, title, description, releaseDate, rating, credits, genreIds, createdBy)
 	ret0, _ := ret[0].(*domain.Film)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockFilmStorageMockRecorder) Create(title, description, releaseDate, rating, actorIds any) *gomock.Call {
+func (mr *MockFilmStorageMockRecorder) Create(ctx, title, description, releaseDate, rating, credits, genreIds, createdBy any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockFilmStorage)(nil).Create), title, description, releaseDate, rating, actorIds)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockFilmStorage)(nil).Create), ctx, title, description, releaseDate, rating, credits, genreIds, createdBy)
 }
 
 // Delete mocks base method.
-func (m *MockFilmStorage) Delete(id domain.FilmId) error {
+func (m *MockFilmStorage) Delete(ctx context.Context, id domain.FilmId, version, deletedBy *int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, version, deletedBy)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockFilmStorageMockRecorder) Delete(id any) *gomock.Call {
+func (mr *MockFilmStorageMockRecorder) Delete(ctx, id, version, deletedBy any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFilmStorage)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFilmStorage)(nil).Delete), ctx, id, version, deletedBy)
+}
+
+// Export mocks base method.
+func (m *MockFilmStorage) Export(ctx context.Context, fn func(*domain.Film) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockFilmStorageMockRecorder) Export(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockFilmStorage)(nil).Export), ctx, fn)
+}
+
+// GetById mocks base method.
+func (m *MockFilmStorage) GetById(ctx context.Context, id domain.FilmId) (*domain.Film, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*domain.Film)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockFilmStorageMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockFilmStorage)(nil).GetById), ctx, id)
 }
 
 // IsExists mocks base method.
-func (m *MockFilmStorage) IsExists(id domain.FilmId) (bool, error) {
+func (m *MockFilmStorage) IsExists(ctx context.Context, id domain.FilmId) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsExists", id)
+	ret := m.ctrl.Call(m, "IsExists", ctx, id)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // IsExists indicates an expected call of IsExists.
-func (mr *MockFilmStorageMockRecorder) IsExists(id any) *gomock.Call {
+func (mr *MockFilmStorageMockRecorder) IsExists(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsExists", reflect.TypeOf((*MockFilmStorage)(nil).IsExists), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsExists", reflect.TypeOf((*MockFilmStorage)(nil).IsExists), ctx, id)
 }
 
-// ListWithSort mocks base method.
-func (m *MockFilmStorage) ListWithSort(titleOrder, releaseDateOrder, ratingOrder string, limit, offset int) ([]*domain.Film, error) {
+// List mocks base method.
+func (m *MockFilmStorage) List(ctx context.Context, filter *domain.FilmFilter, sort domain.FilmSort, cursor *domain.FilmCursor, limit, offset int) ([]*domain.Film, *domain.FilmCursor, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListWithSort", titleOrder, releaseDateOrder, ratingOrder, limit, offset)
+	ret := m.ctrl.Call(m, "List", ctx, filter, sort, cursor, limit, offset)
 	ret0, _ := ret[0].([]*domain.Film)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(*domain.FilmCursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// ListWithSort indicates an expected call of ListWithSort.
-func (mr *MockFilmStorageMockRecorder) ListWithSort(titleOrder, releaseDateOrder, ratingOrder, limit, offset any) *gomock.Call {
+// List indicates an expected call of List.
+func (mr *MockFilmStorageMockRecorder) List(ctx, filter, sort, cursor, limit, offset any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWithSort", reflect.TypeOf((*MockFilmStorage)(nil).ListWithSort), titleOrder, releaseDateOrder, ratingOrder, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockFilmStorage)(nil).List), ctx, filter, sort, cursor, limit, offset)
 }
 
-// SearchByFilters mocks base method.
-func (m *MockFilmStorage) SearchByFilters(title domain.FilmTitle, actorName domain.ActorName, limit, offset int) ([]*domain.Film, error) {
+// Restore mocks base method.
+func (m *MockFilmStorage) Restore(ctx context.Context, id domain.FilmId, restoredBy *int64) (*domain.Film, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchByFilters", title, actorName, limit, offset)
-	ret0, _ := ret[0].([]*domain.Film)
+	ret := m.ctrl.Call(m, "Restore", ctx, id, restoredBy)
+	ret0, _ := ret[0].(*domain.Film)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SearchByFilters indicates an expected call of SearchByFilters.
-func (mr *MockFilmStorageMockRecorder) SearchByFilters(title, actorName, limit, offset any) *gomock.Call {
+// Restore indicates an expected call of Restore.
+func (mr *MockFilmStorageMockRecorder) Restore(ctx, id, restoredBy any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByFilters", reflect.TypeOf((*MockFilmStorage)(nil).SearchByFilters), title, actorName, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockFilmStorage)(nil).Restore), ctx, id, restoredBy)
 }
 
 // Update mocks base method.
-func (m *MockFilmStorage) Update(id domain.FilmId, title *domain.FilmTitle, description *domain.FilmDescription, releaseDate *domain.FilmReleaseDate, rating *domain.FilmRating, actorIds *[]domain.ActorId) (*domain.Film, error) {
+func (m *MockFilmStorage) Update(ctx context.Context, id domain.FilmId, version *int64, title *domain.FilmTitle, description *domain.FilmDescription, releaseDate *domain.FilmReleaseDate, rating *domain.FilmRating, actorIds *[]domain.ActorId, credits *[]*domain.FilmCreditParams, genreIds *[]domain.GenreId, updatedBy *int64) (*domain.Film, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", id, title, description, releaseDate, rating, actorIds)
+	ret := m.ctrl.Call(m, "Update", ctx, id, version, title, description, releaseDate, rating, actorIds, credits, genreIds, updatedBy)
 	ret0, _ := ret[0].(*domain.Film)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockFilmStorageMockRecorder) Update(id, title, description, releaseDate, rating, actorIds any) *gomock.Call {
+func (mr *MockFilmStorageMockRecorder) Update(ctx, id, version, title, description, releaseDate, rating, actorIds, credits, genreIds, updatedBy any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockFilmStorage)(nil).Update), id, title, description, releaseDate, rating, actorIds)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockFilmStorage)(nil).Update), ctx, id, version, title, description, releaseDate, rating, actorIds, credits, genreIds, updatedBy)
 }
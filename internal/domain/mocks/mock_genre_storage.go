@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/genre_storage.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/genre_storage.go -destination=internal/domain/mocks/mock_genre_storage.go
+//
+
+// Package mock_domain is a generated GoMock package.
+package mock_domain
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/vaberof/vk-internship-task/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGenreStorage is a mock of GenreStorage interface.
+type MockGenreStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockGenreStorageMockRecorder
+}
+
+// MockGenreStorageMockRecorder is the mock recorder for MockGenreStorage.
+type MockGenreStorageMockRecorder struct {
+	mock *MockGenreStorage
+}
+
+// NewMockGenreStorage creates a new mock instance.
+func NewMockGenreStorage(ctrl *gomock.Controller) *MockGenreStorage {
+	mock := &MockGenreStorage{ctrl: ctrl}
+	mock.recorder = &MockGenreStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGenreStorage) EXPECT() *MockGenreStorageMockRecorder {
+	return m.recorder
+}
+
+// AreExists mocks base method.
+func (m *MockGenreStorage) AreExists(ctx context.Context, ids []domain.GenreId) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AreExists", ctx, ids)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AreExists indicates an expected call of AreExists.
+func (mr *MockGenreStorageMockRecorder) AreExists(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AreExists", reflect.TypeOf((*MockGenreStorage)(nil).AreExists), ctx, ids)
+}
+
+// Create mocks base method.
+func (m *MockGenreStorage) Create(ctx context.Context, name domain.GenreName) (*domain.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, name)
+	ret0, _ := ret[0].(*domain.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockGenreStorageMockRecorder) Create(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockGenreStorage)(nil).Create), ctx, name)
+}
+
+// Delete mocks base method.
+func (m *MockGenreStorage) Delete(ctx context.Context, id domain.GenreId) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockGenreStorageMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGenreStorage)(nil).Delete), ctx, id)
+}
+
+// GetById mocks base method.
+func (m *MockGenreStorage) GetById(ctx context.Context, id domain.GenreId) (*domain.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*domain.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockGenreStorageMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockGenreStorage)(nil).GetById), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockGenreStorage) List(ctx context.Context) ([]*domain.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]*domain.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockGenreStorageMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockGenreStorage)(nil).List), ctx)
+}
+
+// Update mocks base method.
+func (m *MockGenreStorage) Update(ctx context.Context, id domain.GenreId, name domain.GenreName) (*domain.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, name)
+	ret0, _ := ret[0].(*domain.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockGenreStorageMockRecorder) Update(ctx, id, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGenreStorage)(nil).Update), ctx, id, name)
+}
@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/review_storage.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/review_storage.go -destination=internal/domain/mocks/mock_review_storage.go
+//
+
+// Package mock_domain is a generated GoMock package.
+package mock_domain
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/vaberof/vk-internship-task/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReviewStorage is a mock of ReviewStorage interface.
+type MockReviewStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewStorageMockRecorder
+}
+
+// MockReviewStorageMockRecorder is the mock recorder for MockReviewStorage.
+type MockReviewStorageMockRecorder struct {
+	mock *MockReviewStorage
+}
+
+// NewMockReviewStorage creates a new mock instance.
+func NewMockReviewStorage(ctrl *gomock.Controller) *MockReviewStorage {
+	mock := &MockReviewStorage{ctrl: ctrl}
+	mock.recorder = &MockReviewStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewStorage) EXPECT() *MockReviewStorageMockRecorder {
+	return m.recorder
+}
+
+// CountByFilmId mocks base method.
+func (m *MockReviewStorage) CountByFilmId(ctx context.Context, filmId domain.FilmId) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByFilmId", ctx, filmId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByFilmId indicates an expected call of CountByFilmId.
+func (mr *MockReviewStorageMockRecorder) CountByFilmId(ctx, filmId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByFilmId", reflect.TypeOf((*MockReviewStorage)(nil).CountByFilmId), ctx, filmId)
+}
+
+// Create mocks base method.
+func (m *MockReviewStorage) Create(ctx context.Context, filmId domain.FilmId, userId int64, rating domain.ReviewRating, text domain.ReviewText) (*domain.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, filmId, userId, rating, text)
+	ret0, _ := ret[0].(*domain.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReviewStorageMockRecorder) Create(ctx, filmId, userId, rating, text any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReviewStorage)(nil).Create), ctx, filmId, userId, rating, text)
+}
+
+// Delete mocks base method.
+func (m *MockReviewStorage) Delete(ctx context.Context, id domain.ReviewId) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockReviewStorageMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockReviewStorage)(nil).Delete), ctx, id)
+}
+
+// GetById mocks base method.
+func (m *MockReviewStorage) GetById(ctx context.Context, id domain.ReviewId) (*domain.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*domain.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockReviewStorageMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockReviewStorage)(nil).GetById), ctx, id)
+}
+
+// ListByFilmId mocks base method.
+func (m *MockReviewStorage) ListByFilmId(ctx context.Context, filmId domain.FilmId, limit, offset int) ([]*domain.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByFilmId", ctx, filmId, limit, offset)
+	ret0, _ := ret[0].([]*domain.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByFilmId indicates an expected call of ListByFilmId.
+func (mr *MockReviewStorageMockRecorder) ListByFilmId(ctx, filmId, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByFilmId", reflect.TypeOf((*MockReviewStorage)(nil).ListByFilmId), ctx, filmId, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockReviewStorage) Update(ctx context.Context, id domain.ReviewId, rating *domain.ReviewRating, text *domain.ReviewText) (*domain.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, rating, text)
+	ret0, _ := ret[0].(*domain.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockReviewStorageMockRecorder) Update(ctx, id, rating, text any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockReviewStorage)(nil).Update), ctx, id, rating, text)
+}
@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/trash_storage.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/trash_storage.go -destination=internal/domain/mocks/mock_trash_storage.go
+//
+
+// Package mock_domain is a generated GoMock package.
+package mock_domain
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/vaberof/vk-internship-task/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTrashStorage is a mock of TrashStorage interface.
+type MockTrashStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrashStorageMockRecorder
+}
+
+// MockTrashStorageMockRecorder is the mock recorder for MockTrashStorage.
+type MockTrashStorageMockRecorder struct {
+	mock *MockTrashStorage
+}
+
+// NewMockTrashStorage creates a new mock instance.
+func NewMockTrashStorage(ctrl *gomock.Controller) *MockTrashStorage {
+	mock := &MockTrashStorage{ctrl: ctrl}
+	mock.recorder = &MockTrashStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrashStorage) EXPECT() *MockTrashStorageMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockTrashStorage) Count(ctx context.Context, filter *domain.TrashFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockTrashStorageMockRecorder) Count(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockTrashStorage)(nil).Count), ctx, filter)
+}
+
+// List mocks base method.
+func (m *MockTrashStorage) List(ctx context.Context, filter *domain.TrashFilter, limit, offset int) ([]*domain.TrashItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter, limit, offset)
+	ret0, _ := ret[0].([]*domain.TrashItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockTrashStorageMockRecorder) List(ctx, filter, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTrashStorage)(nil).List), ctx, filter, limit, offset)
+}
+
+// Purge mocks base method.
+func (m *MockTrashStorage) Purge(ctx context.Context, deletedBefore time.Time) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, deletedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockTrashStorageMockRecorder) Purge(ctx, deletedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockTrashStorage)(nil).Purge), ctx, deletedBefore)
+}
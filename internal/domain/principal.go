@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"context"
+	"github.com/vaberof/vk-internship-task/internal/service/user"
+)
+
+// principalId returns the id of the user a change is made by, or nil if it is made without an authenticated user
+func principalId(ctx context.Context) *int64 {
+	principal, ok := user.PrincipalFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return &principal.Id
+}
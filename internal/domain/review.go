@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+type ReviewId int64
+
+func (reviewId *ReviewId) Int64() int64 {
+	return int64(*reviewId)
+}
+
+type ReviewRating uint8
+
+func (reviewRating *ReviewRating) Uint8() uint8 {
+	return uint8(*reviewRating)
+}
+
+type ReviewText string
+
+func (reviewText *ReviewText) String() string {
+	return string(*reviewText)
+}
+
+// Review is a rating with an optional text a user gives to a film. A user has at most one review per film.
+type Review struct {
+	Id        ReviewId
+	FilmId    FilmId
+	UserId    int64
+	Rating    ReviewRating
+	Text      ReviewText
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type ReviewsPage struct {
+	Reviews []*Review
+	Total   int64
+}
@@ -0,0 +1,197 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"github.com/vaberof/vk-internship-task/pkg/logging/logs"
+	"log/slog"
+)
+
+var (
+	ErrReviewNotFound      = errors.New("review not found")
+	ErrReviewAlreadyExists = errors.New("user has already reviewed this film")
+	ErrReviewForbidden     = errors.New("review belongs to another user")
+)
+
+type ReviewService interface {
+	Create(ctx context.Context, filmId FilmId, rating ReviewRating, text ReviewText) (*Review, error)
+	Update(ctx context.Context, id ReviewId, rating *ReviewRating, text *ReviewText) (*Review, error)
+	Delete(ctx context.Context, id ReviewId) error
+	ListByFilmId(ctx context.Context, filmId FilmId, limit, offset int) (*ReviewsPage, error)
+}
+
+type reviewServiceImpl struct {
+	reviewStorage ReviewStorage
+	filmStorage   FilmStorage
+
+	logger *slog.Logger
+}
+
+func NewReviewService(reviewStorage ReviewStorage, filmStorage FilmStorage, logsBuilder *logs.Logs) ReviewService {
+	logger := logsBuilder.WithName("domain.service.review")
+	return &reviewServiceImpl{
+		reviewStorage: reviewStorage,
+		filmStorage:   filmStorage,
+		logger:        logger,
+	}
+}
+
+// Create posts a review of the authenticated user
+func (r *reviewServiceImpl) Create(ctx context.Context, filmId FilmId, rating ReviewRating, text ReviewText) (*Review, error) {
+	const operation = "Create"
+
+	log := r.logger.With(
+		slog.String("operation", operation),
+		slog.Int64("filmId", filmId.Int64()))
+
+	log.Info("creating a review")
+
+	userId := principalId(ctx)
+	if userId == nil {
+		log.Warn("failed to create a review", "error", "review author is unknown")
+		return nil, ErrReviewForbidden
+	}
+
+	domainReview, err := r.reviewStorage.Create(ctx, filmId, *userId, rating, text)
+	if err != nil {
+		if errors.Is(err, storage.ErrFilmNotFound) {
+			log.Warn("failed to create a review", "error", fmt.Sprintf("film with id '%d' not found", filmId.Int64()))
+			return nil, ErrFilmNotFound
+		}
+		if errors.Is(err, storage.ErrReviewAlreadyExists) {
+			log.Warn("failed to create a review", "error", err)
+			return nil, ErrReviewAlreadyExists
+		}
+
+		log.Error("failed to create a review", "error", err)
+		return nil, err
+	}
+
+	log.Info("review has created", "id", domainReview.Id.Int64())
+
+	return domainReview, nil
+}
+
+// Update changes a review, only its author is allowed to do it
+func (r *reviewServiceImpl) Update(ctx context.Context, id ReviewId, rating *ReviewRating, text *ReviewText) (*Review, error) {
+	const operation = "Update"
+
+	log := r.logger.With(
+		slog.String("operation", operation),
+		slog.Int64("id", id.Int64()))
+
+	log.Info("updating a review")
+
+	if err := r.checkOwner(ctx, log, id); err != nil {
+		return nil, err
+	}
+
+	domainReview, err := r.reviewStorage.Update(ctx, id, rating, text)
+	if err != nil {
+		if errors.Is(err, storage.ErrReviewNotFound) {
+			log.Warn("failed to update a review", "error", fmt.Sprintf("review with id '%d' not found", id.Int64()))
+			return nil, ErrReviewNotFound
+		}
+
+		log.Error("failed to update a review", "error", err)
+		return nil, err
+	}
+
+	log.Info("review has updated")
+
+	return domainReview, nil
+}
+
+// Delete deletes a review, only its author is allowed to do it
+func (r *reviewServiceImpl) Delete(ctx context.Context, id ReviewId) error {
+	const operation = "Delete"
+
+	log := r.logger.With(
+		slog.String("operation", operation),
+		slog.Int64("id", id.Int64()))
+
+	log.Info("deleting a review")
+
+	if err := r.checkOwner(ctx, log, id); err != nil {
+		return err
+	}
+
+	err := r.reviewStorage.Delete(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrReviewNotFound) {
+			log.Warn("failed to delete a review", "error", fmt.Sprintf("review with id '%d' not found", id.Int64()))
+			return ErrReviewNotFound
+		}
+
+		log.Error("failed to delete a review", "error", err)
+		return err
+	}
+
+	log.Info("review has deleted")
+
+	return nil
+}
+
+func (r *reviewServiceImpl) ListByFilmId(ctx context.Context, filmId FilmId, limit, offset int) (*ReviewsPage, error) {
+	const operation = "ListByFilmId"
+
+	log := r.logger.With(
+		slog.String("operation", operation),
+		slog.Int64("filmId", filmId.Int64()),
+		slog.Int("limit", limit),
+		slog.Int("offset", offset))
+
+	log.Info("listing reviews of a film")
+
+	exists, err := r.filmStorage.IsExists(ctx, filmId)
+	if err != nil {
+		log.Error("failed to list reviews", "error", err)
+		return nil, err
+	}
+	if !exists {
+		log.Warn("failed to list reviews", "error", fmt.Sprintf("film with id '%d' not found", filmId.Int64()))
+		return nil, ErrFilmNotFound
+	}
+
+	reviews, err := r.reviewStorage.ListByFilmId(ctx, filmId, limit, offset)
+	if err != nil {
+		log.Error("failed to list reviews", "error", err)
+		return nil, err
+	}
+
+	total, err := r.reviewStorage.CountByFilmId(ctx, filmId)
+	if err != nil {
+		log.Error("failed to count reviews", "error", err)
+		return nil, err
+	}
+
+	log.Info("reviews have listed")
+
+	return &ReviewsPage{
+		Reviews: reviews,
+		Total:   total,
+	}, nil
+}
+
+func (r *reviewServiceImpl) checkOwner(ctx context.Context, log *slog.Logger, id ReviewId) error {
+	domainReview, err := r.reviewStorage.GetById(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrReviewNotFound) {
+			log.Warn("review not found", "error", fmt.Sprintf("review with id '%d' not found", id.Int64()))
+			return ErrReviewNotFound
+		}
+
+		log.Error("failed to get a review", "error", err)
+		return err
+	}
+
+	userId := principalId(ctx)
+	if userId == nil || *userId != domainReview.UserId {
+		log.Warn("review belongs to another user", "userId", domainReview.UserId)
+		return ErrReviewForbidden
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package domain
+
+import "context"
+
+// ReviewStorage keeps reviews together with the community score and the votes count of their films
+type ReviewStorage interface {
+	Create(ctx context.Context, filmId FilmId, userId int64, rating ReviewRating, text ReviewText) (*Review, error)
+	Update(ctx context.Context, id ReviewId, rating *ReviewRating, text *ReviewText) (*Review, error)
+	Delete(ctx context.Context, id ReviewId) error
+	GetById(ctx context.Context, id ReviewId) (*Review, error)
+	ListByFilmId(ctx context.Context, filmId FilmId, limit, offset int) ([]*Review, error)
+	CountByFilmId(ctx context.Context, filmId FilmId) (int64, error)
+}
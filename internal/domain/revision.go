@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"reflect"
+	"sort"
+	"time"
+)
+
+// FilmRevision is the state of a film after a change made by UserId.
+// Revision is the version the change has given to the film, so the latest revision of a film is its current version:
+// deleting and restoring the film change neither its version nor its state.
+type FilmRevision struct {
+	FilmId      FilmId
+	Revision    int64
+	UserId      *int64
+	Title       FilmTitle
+	Description FilmDescription
+	ReleaseDate FilmReleaseDate
+	Rating      FilmRating
+	Credits     []*FilmCreditParams
+	GenreIds    []GenreId
+	CreatedAt   time.Time
+}
+
+// ActorRevision is the state of an actor after a change made by UserId, numbered like FilmRevision
+type ActorRevision struct {
+	ActorId   ActorId
+	Revision  int64
+	UserId    *int64
+	Name      ActorName
+	Sex       ActorSex
+	BirthDate ActorBirthDate
+	CreatedAt time.Time
+}
+
+type FilmRevisionsPage struct {
+	Revisions []*FilmRevision
+	Total     int64
+}
+
+type ActorRevisionsPage struct {
+	Revisions []*ActorRevision
+	Total     int64
+}
+
+// RevisionChange is a field whose value differs between two revisions.
+// From and To are plain values, dates are formatted as 'YYYY-MM-DD' and ids are integers.
+type RevisionChange struct {
+	Field string
+	From  any
+	To    any
+}
+
+type revisionField struct {
+	name  string
+	value any
+}
+
+// NewFilmRevision builds the revision of the film state made by userId.
+// Storages record it in the transaction of the change, so that there is no change without a revision.
+func NewFilmRevision(film *Film, userId *int64) *FilmRevision {
+	credits := make([]*FilmCreditParams, len(film.Credits))
+	for i, credit := range film.Credits {
+		credits[i] = &FilmCreditParams{
+			ActorId:       credit.Actor.Id,
+			Role:          credit.Role,
+			CharacterName: credit.CharacterName,
+			BillingOrder:  credit.BillingOrder,
+		}
+	}
+
+	genreIds := make([]GenreId, len(film.Genres))
+	for i := range film.Genres {
+		genreIds[i] = film.Genres[i].Id
+	}
+	sort.Slice(genreIds, func(i, j int) bool { return genreIds[i] < genreIds[j] })
+
+	return &FilmRevision{
+		FilmId:      film.Id,
+		Revision:    film.Version,
+		UserId:      userId,
+		Title:       film.Title,
+		Description: film.Description,
+		ReleaseDate: film.ReleaseDate,
+		Rating:      film.Rating,
+		Credits:     credits,
+		GenreIds:    genreIds,
+	}
+}
+
+// NewActorRevision builds the revision of the actor state made by userId, like NewFilmRevision
+func NewActorRevision(actor *Actor, userId *int64) *ActorRevision {
+	return &ActorRevision{
+		ActorId:   actor.Id,
+		Revision:  actor.Version,
+		UserId:    userId,
+		Name:      actor.Name,
+		Sex:       actor.Sex,
+		BirthDate: actor.BirthDate,
+	}
+}
+
+// DiffFilmRevisions returns the fields of the film that have changed from one revision to another
+func DiffFilmRevisions(from, to *FilmRevision) []*RevisionChange {
+	return diffRevisionFields(buildFilmRevisionFields(from), buildFilmRevisionFields(to))
+}
+
+// DiffActorRevisions returns the fields of the actor that have changed from one revision to another
+func DiffActorRevisions(from, to *ActorRevision) []*RevisionChange {
+	return diffRevisionFields(buildActorRevisionFields(from), buildActorRevisionFields(to))
+}
+
+func diffRevisionFields(from, to []revisionField) []*RevisionChange {
+	changes := make([]*RevisionChange, 0, len(from))
+	for i := range from {
+		if !reflect.DeepEqual(from[i].value, to[i].value) {
+			changes = append(changes, &RevisionChange{
+				Field: from[i].name,
+				From:  from[i].value,
+				To:    to[i].value,
+			})
+		}
+	}
+	return changes
+}
+
+func buildFilmRevisionFields(revision *FilmRevision) []revisionField {
+	credits := make([]*filmCreditAuditSnapshot, len(revision.Credits))
+	for i, credit := range revision.Credits {
+		credits[i] = &filmCreditAuditSnapshot{
+			ActorId:       credit.ActorId.Int64(),
+			Role:          credit.Role.String(),
+			CharacterName: credit.CharacterName.String(),
+			BillingOrder:  credit.BillingOrder,
+		}
+	}
+
+	genreIds := make([]int64, len(revision.GenreIds))
+	for i := range revision.GenreIds {
+		genreIds[i] = revision.GenreIds[i].Int64()
+	}
+
+	return []revisionField{
+		{name: "title", value: revision.Title.String()},
+		{name: "description", value: revision.Description.String()},
+		{name: "release_date", value: revision.ReleaseDate.Time().Format(time.DateOnly)},
+		{name: "rating", value: revision.Rating.Uint8()},
+		{name: "credits", value: credits},
+		{name: "genre_ids", value: genreIds},
+	}
+}
+
+func buildActorRevisionFields(revision *ActorRevision) []revisionField {
+	return []revisionField{
+		{name: "name", value: revision.Name.String()},
+		{name: "sex", value: revision.Sex.Uint8()},
+		{name: "birthdate", value: revision.BirthDate.Time().Format(time.DateOnly)},
+	}
+}
@@ -0,0 +1,235 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	mocks "github.com/vaberof/vk-internship-task/internal/domain/mocks"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"github.com/vaberof/vk-internship-task/pkg/logging/logs"
+	"go.uber.org/mock/gomock"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	actorRevisionStorage := mocks.NewMockActorRevisionStorage(ctrl)
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	actorId := domain.ActorId(1)
+	revisions := []*domain.ActorRevision{
+		{ActorId: actorId, Revision: 2, Name: "Actor_2"},
+		{ActorId: actorId, Revision: 1, Name: "Actor_1"},
+	}
+
+	actorStorage.EXPECT().IsExists(ctx, actorId).Return(true, nil).Times(1)
+	actorRevisionStorage.EXPECT().List(ctx, actorId, 20, 0).Return(revisions, nil).Times(1)
+	actorRevisionStorage.EXPECT().Count(ctx, actorId).Return(int64(2), nil).Times(1)
+
+	actorRevisionService := domain.NewActorRevisionService(actorRevisionStorage, actorStorage, nil, logsBuilder)
+	revisionsPage, err := actorRevisionService.List(ctx, actorId, 20, 0)
+	require.NoError(t, err)
+	require.Equal(t, revisions, revisionsPage.Revisions)
+	require.Equal(t, int64(2), revisionsPage.Total)
+}
+
+func TestListError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	actorRevisionStorage := mocks.NewMockActorRevisionStorage(ctrl)
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	actorId := domain.ActorId(1)
+
+	type tCase struct {
+		name     string
+		prepare  func()
+		expected error
+	}
+
+	tCases := []tCase{
+		{
+			name: "err_actor_not_found",
+			prepare: func() {
+				actorStorage.EXPECT().IsExists(ctx, actorId).Return(false, nil).Times(1)
+			},
+			expected: domain.ErrActorNotFound,
+		},
+		{
+			name: "err_count_revisions",
+			prepare: func() {
+				actorStorage.EXPECT().IsExists(ctx, actorId).Return(true, nil).Times(1)
+				actorRevisionStorage.EXPECT().List(ctx, actorId, 20, 0).Return(nil, nil).Times(1)
+				actorRevisionStorage.EXPECT().Count(ctx, actorId).Return(int64(0), errors.New("storage error")).Times(1)
+			},
+			expected: errors.New("storage error"),
+		},
+	}
+
+	actorRevisionService := domain.NewActorRevisionService(actorRevisionStorage, actorStorage, nil, logsBuilder)
+
+	for _, tc := range tCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.prepare()
+
+			revisionsPage, err := actorRevisionService.List(ctx, actorId, 20, 0)
+			require.Error(t, err)
+			require.Equal(t, tc.expected, err)
+			require.Nil(t, revisionsPage)
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	actorRevisionStorage := mocks.NewMockActorRevisionStorage(ctrl)
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	actorId := domain.ActorId(1)
+
+	from := &domain.ActorRevision{
+		ActorId:   actorId,
+		Revision:  1,
+		Name:      "Actor_1",
+		Sex:       1,
+		BirthDate: domain.ActorBirthDate(time.Date(1974, 11, 11, 0, 0, 0, 0, time.UTC)),
+	}
+	to := &domain.ActorRevision{
+		ActorId:   actorId,
+		Revision:  2,
+		Name:      "Actor_1",
+		Sex:       1,
+		BirthDate: domain.ActorBirthDate(time.Date(1974, 11, 12, 0, 0, 0, 0, time.UTC)),
+	}
+
+	actorStorage.EXPECT().IsExists(ctx, actorId).Return(true, nil).Times(1)
+	actorRevisionStorage.EXPECT().Get(ctx, actorId, int64(1)).Return(from, nil).Times(1)
+	actorRevisionStorage.EXPECT().Get(ctx, actorId, int64(2)).Return(to, nil).Times(1)
+
+	actorRevisionService := domain.NewActorRevisionService(actorRevisionStorage, actorStorage, nil, logsBuilder)
+	changes, err := actorRevisionService.Diff(ctx, actorId, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, []*domain.RevisionChange{
+		{Field: "birthdate", From: "1974-11-11", To: "1974-11-12"},
+	}, changes)
+}
+
+func TestRevert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	actorRevisionStorage := mocks.NewMockActorRevisionStorage(ctrl)
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	actorId := domain.ActorId(1)
+
+	revision := &domain.ActorRevision{
+		ActorId:   actorId,
+		Revision:  1,
+		Name:      "Actor_1",
+		Sex:       1,
+		BirthDate: domain.ActorBirthDate(time.Date(1974, 11, 11, 0, 0, 0, 0, time.UTC)),
+	}
+
+	expected := &domain.Actor{
+		Id:        actorId,
+		Name:      revision.Name,
+		Sex:       revision.Sex,
+		BirthDate: revision.BirthDate,
+		Version:   3,
+	}
+
+	actorStorage.EXPECT().IsExists(ctx, actorId).Return(true, nil).Times(2)
+	actorRevisionStorage.EXPECT().Get(ctx, actorId, int64(1)).Return(revision, nil).Times(1)
+	actorStorage.EXPECT().Update(ctx, actorId, nil, &revision.Name, &revision.Sex, &revision.BirthDate, nil).Return(expected, nil).Times(1)
+
+	actorService := domain.NewActorService(actorStorage, logsBuilder)
+	actorRevisionService := domain.NewActorRevisionService(actorRevisionStorage, actorStorage, actorService, logsBuilder)
+	actor, err := actorRevisionService.Revert(ctx, actorId, 1, nil)
+	require.NoError(t, err)
+	require.Equal(t, expected, actor)
+}
+
+func TestRevertError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	actorRevisionStorage := mocks.NewMockActorRevisionStorage(ctrl)
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	actorId := domain.ActorId(1)
+	version := int64(2)
+
+	revision := &domain.ActorRevision{ActorId: actorId, Revision: 1, Name: "Actor_1"}
+
+	type tCase struct {
+		name     string
+		prepare  func()
+		expected error
+	}
+
+	tCases := []tCase{
+		{
+			name: "err_actor_not_found",
+			prepare: func() {
+				actorStorage.EXPECT().IsExists(ctx, actorId).Return(false, nil).Times(1)
+			},
+			expected: domain.ErrActorNotFound,
+		},
+		{
+			name: "err_revision_not_found",
+			prepare: func() {
+				actorStorage.EXPECT().IsExists(ctx, actorId).Return(true, nil).Times(1)
+				actorRevisionStorage.EXPECT().Get(ctx, actorId, int64(1)).Return(nil, fmt.Errorf("failed to get an actor revision: %w", storage.ErrActorRevisionNotFound)).Times(1)
+			},
+			expected: domain.ErrActorRevisionNotFound,
+		},
+		{
+			name: "err_version_mismatch",
+			prepare: func() {
+				actorStorage.EXPECT().IsExists(ctx, actorId).Return(true, nil).Times(2)
+				actorRevisionStorage.EXPECT().Get(ctx, actorId, int64(1)).Return(revision, nil).Times(1)
+				actorStorage.EXPECT().Update(ctx, actorId, &version, &revision.Name, &revision.Sex, &revision.BirthDate, nil).Return(nil, fmt.Errorf("failed to update actor: %w", storage.ErrActorVersionMismatch)).Times(1)
+			},
+			expected: domain.ErrActorVersionMismatch,
+		},
+	}
+
+	actorService := domain.NewActorService(actorStorage, logsBuilder)
+	actorRevisionService := domain.NewActorRevisionService(actorRevisionStorage, actorStorage, actorService, logsBuilder)
+
+	for _, tc := range tCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.prepare()
+
+			actor, err := actorRevisionService.Revert(ctx, actorId, 1, &version)
+			require.Error(t, err)
+			require.Equal(t, tc.expected, err)
+			require.Nil(t, actor)
+		})
+	}
+}
@@ -1,11 +1,14 @@
 package domain_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/stretchr/testify/require"
 	"github.com/vaberof/vk-internship-task/internal/domain"
 	mocks "github.com/vaberof/vk-internship-task/internal/domain/mocks"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"github.com/vaberof/vk-internship-task/internal/service/user"
 	"github.com/vaberof/vk-internship-task/pkg/logging/logs"
 	"go.uber.org/mock/gomock"
 	"os"
@@ -20,6 +23,10 @@ func TestCreate(t *testing.T) {
 	actorStorage := mocks.NewMockActorStorage(ctrl)
 	logsBuilder := logs.New(os.Stdout, nil)
 
+	principal := &user.Principal{Id: 1, Email: "admin@example.com", Role: user.RoleAdmin}
+
+	ctx := user.ContextWithPrincipal(context.Background(), principal)
+
 	actorName := domain.ActorName("Actor")
 	actorSex := domain.ActorSex(0)
 	actorBirthdate := domain.ActorBirthDate(time.Now())
@@ -32,10 +39,10 @@ func TestCreate(t *testing.T) {
 		Films:     []*domain.Film{},
 	}
 
-	actorStorage.EXPECT().Create(actorName, actorSex, actorBirthdate).Return(expected, nil).Times(1)
+	actorStorage.EXPECT().Create(ctx, actorName, actorSex, actorBirthdate, &principal.Id).Return(expected, nil).Times(1)
 
 	actorService := domain.NewActorService(actorStorage, logsBuilder)
-	actor, err := actorService.Create(actorName, actorSex, actorBirthdate)
+	actor, err := actorService.Create(ctx, actorName, actorSex, actorBirthdate)
 	require.NoError(t, err)
 	require.Equal(t, expected, actor)
 }
@@ -47,6 +54,8 @@ func TestCreateError(t *testing.T) {
 	actorStorage := mocks.NewMockActorStorage(ctrl)
 	logsBuilder := logs.New(os.Stdout, nil)
 
+	ctx := context.Background()
+
 	actorName := domain.ActorName("Actor")
 	actorSex := domain.ActorSex(0)
 	parsedActorBirthdate, _ := time.Parse(time.DateOnly, "2024-03-18")
@@ -55,10 +64,10 @@ func TestCreateError(t *testing.T) {
 	fakeError := errors.New("database is down")
 	expectedErr := fmt.Errorf("failed to create an actor: %w", fakeError)
 
-	actorStorage.EXPECT().Create(actorName, actorSex, actorBirthdate).Return(nil, expectedErr).Times(1)
+	actorStorage.EXPECT().Create(ctx, actorName, actorSex, actorBirthdate, nil).Return(nil, expectedErr).Times(1)
 
 	actorService := domain.NewActorService(actorStorage, logsBuilder)
-	actor, err := actorService.Create(actorName, actorSex, actorBirthdate)
+	actor, err := actorService.Create(ctx, actorName, actorSex, actorBirthdate)
 	require.Error(t, err)
 	require.EqualError(t, expectedErr, err.Error())
 	require.Nil(t, actor)
@@ -71,6 +80,10 @@ func TestUpdate(t *testing.T) {
 	actorStorage := mocks.NewMockActorStorage(ctrl)
 	logsBuilder := logs.New(os.Stdout, nil)
 
+	principal := &user.Principal{Id: 1, Email: "admin@example.com", Role: user.RoleAdmin}
+
+	ctx := user.ContextWithPrincipal(context.Background(), principal)
+
 	actorId := domain.ActorId(1)
 	actorName := domain.ActorName("Actor")
 	actorSex := domain.ActorSex(0)
@@ -99,11 +112,11 @@ func TestUpdate(t *testing.T) {
 		},
 	}
 
-	actorStorage.EXPECT().IsExists(actorId).Return(true, nil).Times(1)
-	actorStorage.EXPECT().Update(actorId, &actorName, &actorSex, &actorBirthdate).Return(expected, nil).Times(1)
+	actorStorage.EXPECT().IsExists(ctx, actorId).Return(true, nil).Times(1)
+	actorStorage.EXPECT().Update(ctx, actorId, nil, &actorName, &actorSex, &actorBirthdate, &principal.Id).Return(expected, nil).Times(1)
 
 	actorService := domain.NewActorService(actorStorage, logsBuilder)
-	actor, err := actorService.Update(actorId, &actorName, &actorSex, &actorBirthdate)
+	actor, err := actorService.Update(ctx, actorId, nil, &actorName, &actorSex, &actorBirthdate)
 	require.NoError(t, err)
 	require.Equal(t, expected, actor)
 }
@@ -115,6 +128,8 @@ func TestUpdateError(t *testing.T) {
 	actorStorage := mocks.NewMockActorStorage(ctrl)
 	logsBuilder := logs.New(os.Stdout, nil)
 
+	ctx := context.Background()
+
 	actorService := domain.NewActorService(actorStorage, logsBuilder)
 
 	type in struct {
@@ -181,9 +196,9 @@ func TestUpdateError(t *testing.T) {
 
 	for _, tCase := range testCases {
 		t.Run(tCase.name, func(t *testing.T) {
-			actorStorage.EXPECT().IsExists(tCase.in.Id).Return(tCase.exists, tCase.existsExpErr).AnyTimes()
-			actorStorage.EXPECT().Update(tCase.in.Id, tCase.in.Name, tCase.in.Sex, tCase.in.Birthdate).Return(tCase.out, tCase.updateExpErr).AnyTimes()
-			actor, err := actorService.Update(tCase.in.Id, tCase.in.Name, tCase.in.Sex, tCase.in.Birthdate)
+			actorStorage.EXPECT().IsExists(ctx, tCase.in.Id).Return(tCase.exists, tCase.existsExpErr).AnyTimes()
+			actorStorage.EXPECT().Update(ctx, tCase.in.Id, nil, tCase.in.Name, tCase.in.Sex, tCase.in.Birthdate, nil).Return(tCase.out, tCase.updateExpErr).AnyTimes()
+			actor, err := actorService.Update(ctx, tCase.in.Id, nil, tCase.in.Name, tCase.in.Sex, tCase.in.Birthdate)
 			require.Error(t, err)
 			require.EqualError(t, tCase.updateExpErr, err.Error())
 			require.Nil(t, actor)
@@ -198,13 +213,15 @@ func TestDelete(t *testing.T) {
 	actorStorage := mocks.NewMockActorStorage(ctrl)
 	logsBuilder := logs.New(os.Stdout, nil)
 
+	ctx := context.Background()
+
 	actorId := domain.ActorId(1)
 
-	actorStorage.EXPECT().IsExists(actorId).Return(true, nil).Times(1)
-	actorStorage.EXPECT().Delete(actorId).Return(nil).Times(1)
+	actorStorage.EXPECT().IsExists(ctx, actorId).Return(true, nil).Times(1)
+	actorStorage.EXPECT().Delete(ctx, actorId, nil, nil).Return(nil).Times(1)
 
 	actorService := domain.NewActorService(actorStorage, logsBuilder)
-	err := actorService.Delete(actorId)
+	err := actorService.Delete(ctx, actorId, nil)
 	require.NoError(t, err)
 }
 
@@ -215,6 +232,8 @@ func TestDeleteError(t *testing.T) {
 	actorStorage := mocks.NewMockActorStorage(ctrl)
 	logsBuilder := logs.New(os.Stdout, nil)
 
+	ctx := context.Background()
+
 	actorService := domain.NewActorService(actorStorage, logsBuilder)
 
 	testCases := []struct {
@@ -245,14 +264,91 @@ func TestDeleteError(t *testing.T) {
 
 	for _, tCase := range testCases {
 		t.Run(tCase.name, func(t *testing.T) {
-			actorStorage.EXPECT().IsExists(tCase.in).Return(tCase.exists, tCase.existsExpErr).AnyTimes()
-			actorStorage.EXPECT().Delete(tCase.in).Return(tCase.deleteExpErr).AnyTimes()
-			err := actorService.Delete(tCase.in)
+			actorStorage.EXPECT().IsExists(ctx, tCase.in).Return(tCase.exists, tCase.existsExpErr).AnyTimes()
+			actorStorage.EXPECT().Delete(ctx, tCase.in, nil, nil).Return(tCase.deleteExpErr).AnyTimes()
+			err := actorService.Delete(ctx, tCase.in, nil)
 			require.EqualError(t, err, tCase.deleteExpErr.Error())
 		})
 	}
 }
 
+func TestGetById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	actorId := domain.ActorId(1)
+
+	expected := &domain.Actor{
+		Id:        actorId,
+		Name:      domain.ActorName("Actor"),
+		Sex:       domain.ActorSex(0),
+		BirthDate: domain.ActorBirthDate(time.Now()),
+		Films: []*domain.Film{
+			{
+				Id:          domain.FilmId(1),
+				Title:       domain.FilmTitle("Title_1"),
+				Description: domain.FilmDescription("Description_1"),
+				ReleaseDate: domain.FilmReleaseDate(time.Now()),
+				Rating:      domain.FilmRating(10),
+			},
+		},
+	}
+
+	actorStorage.EXPECT().GetById(ctx, actorId).Return(expected, nil).Times(1)
+
+	actorService := domain.NewActorService(actorStorage, logsBuilder)
+	actor, err := actorService.GetById(ctx, actorId)
+	require.NoError(t, err)
+	require.Equal(t, expected, actor)
+}
+
+func TestGetByIdError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	actorService := domain.NewActorService(actorStorage, logsBuilder)
+
+	testCases := []struct {
+		name       string
+		in         domain.ActorId
+		storageErr error
+		getExpErr  error
+	}{
+		{
+			name:       "err_actor_not_found",
+			in:         domain.ActorId(1),
+			storageErr: fmt.Errorf("failed to get actor: %w", storage.ErrActorNotFound),
+			getExpErr:  domain.ErrActorNotFound,
+		},
+		{
+			name:       "err_other",
+			in:         domain.ActorId(2),
+			storageErr: fmt.Errorf("failed to get actor: %w", errors.New("database is down")),
+			getExpErr:  fmt.Errorf("failed to get actor: %w", errors.New("database is down")),
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			actorStorage.EXPECT().GetById(ctx, tCase.in).Return(nil, tCase.storageErr).Times(1)
+			actor, err := actorService.GetById(ctx, tCase.in)
+			require.Error(t, err)
+			require.EqualError(t, tCase.getExpErr, err.Error())
+			require.Nil(t, actor)
+		})
+	}
+}
+
 func TestList(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -260,6 +356,8 @@ func TestList(t *testing.T) {
 	actorStorage := mocks.NewMockActorStorage(ctrl)
 	logsBuilder := logs.New(os.Stdout, nil)
 
+	ctx := context.Background()
+
 	expected := []*domain.Actor{
 		{
 			Id:        domain.ActorId(1),
@@ -317,12 +415,18 @@ func TestList(t *testing.T) {
 	limit := 100
 	offset := 0
 
-	actorStorage.EXPECT().List(limit, offset).Return(expected, nil).Times(1)
+	nextCursor := domain.NewActorCursor(expected[len(expected)-1])
+	total := int64(5)
+
+	actorStorage.EXPECT().List(ctx, nil, nil, nil, limit, offset).Return(expected, nextCursor, nil).Times(1)
+	actorStorage.EXPECT().Count(ctx, nil).Return(total, nil).Times(1)
 
 	actorService := domain.NewActorService(actorStorage, logsBuilder)
-	actors, err := actorService.List(limit, offset)
+	actorsPage, err := actorService.List(ctx, nil, nil, nil, true, limit, offset)
 	require.NoError(t, err)
-	require.Equal(t, expected, actors)
+	require.Equal(t, expected, actorsPage.Actors)
+	require.Equal(t, nextCursor, actorsPage.NextCursor)
+	require.Equal(t, &total, actorsPage.Total)
 }
 
 func TestListError(t *testing.T) {
@@ -332,17 +436,318 @@ func TestListError(t *testing.T) {
 	actorStorage := mocks.NewMockActorStorage(ctrl)
 	logsBuilder := logs.New(os.Stdout, nil)
 
+	ctx := context.Background()
+
 	fakeError := errors.New("database is down")
 	expectedErr := fmt.Errorf("failed to list actors: %w", fakeError)
 
 	limit := 100
 	offset := 0
 
-	actorStorage.EXPECT().List(limit, offset).Return(nil, expectedErr).Times(1)
+	actorStorage.EXPECT().List(ctx, nil, nil, nil, limit, offset).Return(nil, nil, expectedErr).Times(1)
 
 	actorService := domain.NewActorService(actorStorage, logsBuilder)
-	actors, err := actorService.List(limit, offset)
+	actors, err := actorService.List(ctx, nil, nil, nil, false, limit, offset)
 	require.Error(t, err)
 	require.EqualError(t, expectedErr, err.Error())
 	require.Nil(t, actors)
 }
+
+func TestListWithFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	expected := []*domain.Actor{
+		{
+			Id:        domain.ActorId(1),
+			Name:      domain.ActorName("Actor_1"),
+			Sex:       domain.ActorSex(1),
+			BirthDate: domain.ActorBirthDate(time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)),
+		},
+	}
+
+	sex := domain.ActorSex(1)
+	filmId := domain.FilmId(1)
+	filter := &domain.ActorFilter{Name: "actor", Sex: &sex, FilmId: &filmId}
+	sort := domain.ActorSort{{Field: domain.ActorSortFieldFilmsCount, Direction: domain.SortDirectionDesc}}
+
+	limit := 100
+	offset := 0
+
+	total := int64(1)
+
+	actorStorage.EXPECT().List(ctx, filter, sort, nil, limit, offset).Return(expected, nil, nil).Times(1)
+	actorStorage.EXPECT().Count(ctx, filter).Return(total, nil).Times(1)
+
+	actorService := domain.NewActorService(actorStorage, logsBuilder)
+	actorsPage, err := actorService.List(ctx, filter, sort, nil, true, limit, offset)
+	require.NoError(t, err)
+	require.Equal(t, expected, actorsPage.Actors)
+	require.Nil(t, actorsPage.NextCursor)
+	require.Equal(t, &total, actorsPage.Total)
+}
+
+func TestListWithFilterError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	bornFrom := domain.ActorBirthDate(time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC))
+	bornTo := domain.ActorBirthDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	actorService := domain.NewActorService(actorStorage, logsBuilder)
+	actors, err := actorService.List(ctx, &domain.ActorFilter{BornFrom: &bornFrom, BornTo: &bornTo}, nil, nil, false, 100, 0)
+	require.ErrorIs(t, err, domain.ErrInvalidActorFilter)
+	require.Nil(t, actors)
+}
+
+func TestExport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	storedActors := []*domain.Actor{
+		{Id: domain.ActorId(1), Name: domain.ActorName("Actor_1")},
+		{Id: domain.ActorId(2), Name: domain.ActorName("Actor_2")},
+	}
+
+	actorStorage.EXPECT().Export(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fn func(actor *domain.Actor) error) error {
+		for _, actor := range storedActors {
+			if err := fn(actor); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Times(1)
+
+	var exported []*domain.Actor
+
+	actorService := domain.NewActorService(actorStorage, logsBuilder)
+	err := actorService.Export(ctx, func(actor *domain.Actor) error {
+		exported = append(exported, actor)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, storedActors, exported)
+}
+
+func TestExportError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	fakeError := errors.New("database is down")
+	expectedErr := fmt.Errorf("failed to export actors: %w", fakeError)
+
+	actorStorage.EXPECT().Export(ctx, gomock.Any()).Return(expectedErr).Times(1)
+
+	actorService := domain.NewActorService(actorStorage, logsBuilder)
+	err := actorService.Export(ctx, func(actor *domain.Actor) error {
+		return nil
+	})
+	require.ErrorIs(t, err, fakeError)
+}
+
+func TestBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	name := domain.ActorName("Actor_2")
+	staleVersion := int64(2)
+
+	operations := []*domain.ActorBatchOperation{
+		{Type: domain.BatchOperationCreate, Create: &domain.ActorCreateParams{Name: "Actor_1"}},
+		{Type: domain.BatchOperationUpdate, Id: domain.ActorId(2), Update: &domain.ActorUpdateParams{Name: &name}},
+		{Type: domain.BatchOperationDelete, Id: domain.ActorId(3)},
+		{Type: domain.BatchOperationDelete, Id: domain.ActorId(4), Version: &staleVersion},
+	}
+
+	createdActor := &domain.Actor{Id: domain.ActorId(1), Name: "Actor_1"}
+
+	actorStorage.EXPECT().Batch(ctx, domain.BatchModeTransactional, operations, nil).Return([]*domain.ActorBatchItem{
+		{Index: 0, Type: domain.BatchOperationCreate, Status: domain.BatchItemAborted},
+		{Index: 1, Type: domain.BatchOperationUpdate, Id: domain.ActorId(2), Status: domain.BatchItemFailed, Err: fmt.Errorf("failed to update actor: %w", storage.ErrActorNotFound)},
+		{Index: 2, Type: domain.BatchOperationDelete, Id: domain.ActorId(3), Status: domain.BatchItemAborted},
+		{Index: 3, Type: domain.BatchOperationDelete, Id: domain.ActorId(4), Status: domain.BatchItemFailed, Err: fmt.Errorf("failed to delete actor: %w", storage.ErrActorVersionMismatch)},
+	}, false, nil).Times(1)
+
+	actorService := domain.NewActorService(actorStorage, logsBuilder)
+	report, err := actorService.Batch(ctx, domain.BatchModeTransactional, operations)
+	require.NoError(t, err)
+	require.False(t, report.Committed)
+	require.Len(t, report.Items, 4)
+
+	require.Equal(t, domain.BatchItemAborted, report.Items[0].Status)
+	require.Equal(t, domain.BatchItemFailed, report.Items[1].Status)
+	require.ErrorIs(t, report.Items[1].Err, domain.ErrActorNotFound)
+	require.Equal(t, domain.BatchItemAborted, report.Items[2].Status)
+	require.Equal(t, domain.BatchItemFailed, report.Items[3].Status)
+	require.ErrorIs(t, report.Items[3].Err, domain.ErrActorVersionMismatch)
+
+	actorStorage.EXPECT().Batch(ctx, domain.BatchModeBestEffort, operations[:1], nil).Return([]*domain.ActorBatchItem{
+		{Index: 0, Type: domain.BatchOperationCreate, Status: domain.BatchItemSucceeded, Actor: createdActor},
+	}, true, nil).Times(1)
+
+	report, err = actorService.Batch(ctx, domain.BatchModeBestEffort, operations[:1])
+	require.NoError(t, err)
+	require.True(t, report.Committed)
+	require.Equal(t, createdActor.Id, report.Items[0].Id)
+	require.Equal(t, createdActor, report.Items[0].Actor)
+}
+
+func TestBatchError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	actorService := domain.NewActorService(actorStorage, logsBuilder)
+
+	report, err := actorService.Batch(ctx, domain.BatchModeTransactional, nil)
+	require.ErrorIs(t, err, domain.ErrInvalidBatch)
+	require.Nil(t, report)
+
+	// An update without values fails alone and aborts the rest of the transactional batch
+	report, err = actorService.Batch(ctx, domain.BatchModeTransactional, []*domain.ActorBatchOperation{
+		{Type: domain.BatchOperationDelete, Id: domain.ActorId(1)},
+		{Type: domain.BatchOperationUpdate, Id: domain.ActorId(2)},
+	})
+	require.NoError(t, err)
+	require.False(t, report.Committed)
+	require.Equal(t, domain.BatchItemAborted, report.Items[0].Status)
+	require.Equal(t, domain.BatchItemFailed, report.Items[1].Status)
+	require.ErrorIs(t, report.Items[1].Err, domain.ErrInvalidBatch)
+
+	// A created actor has no version to check
+	version := int64(1)
+	report, err = actorService.Batch(ctx, domain.BatchModeBestEffort, []*domain.ActorBatchOperation{
+		{Type: domain.BatchOperationCreate, Version: &version, Create: &domain.ActorCreateParams{Name: "Actor_1"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, domain.BatchItemFailed, report.Items[0].Status)
+	require.ErrorIs(t, report.Items[0].Err, domain.ErrInvalidBatch)
+}
+
+func TestUpdateAndDeleteVersionMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	actorId := domain.ActorId(1)
+	actorName := domain.ActorName("Actor_1")
+	version := int64(2)
+
+	versionMismatchErr := fmt.Errorf("failed to update actor: %w", storage.ErrActorVersionMismatch)
+
+	actorStorage.EXPECT().IsExists(ctx, actorId).Return(true, nil).Times(2)
+	actorStorage.EXPECT().Update(ctx, actorId, &version, &actorName, nil, nil, nil).Return(nil, versionMismatchErr).Times(1)
+	actorStorage.EXPECT().Delete(ctx, actorId, &version, nil).Return(versionMismatchErr).Times(1)
+
+	actorService := domain.NewActorService(actorStorage, logsBuilder)
+
+	actor, err := actorService.Update(ctx, actorId, &version, &actorName, nil, nil)
+	require.ErrorIs(t, err, domain.ErrActorVersionMismatch)
+	require.Nil(t, actor)
+
+	err = actorService.Delete(ctx, actorId, &version)
+	require.ErrorIs(t, err, domain.ErrActorVersionMismatch)
+}
+
+func TestRestore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	actorId := domain.ActorId(1)
+
+	expected := &domain.Actor{
+		Id:        actorId,
+		Name:      domain.ActorName("Actor_1"),
+		Sex:       domain.ActorSex(1),
+		BirthDate: domain.ActorBirthDate(time.Now()),
+		Version:   3,
+	}
+
+	actorStorage.EXPECT().Restore(ctx, actorId, nil).Return(expected, nil).Times(1)
+
+	actorService := domain.NewActorService(actorStorage, logsBuilder)
+	actor, err := actorService.Restore(ctx, actorId)
+	require.NoError(t, err)
+	require.Equal(t, expected, actor)
+}
+
+func TestRestoreError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	actorService := domain.NewActorService(actorStorage, logsBuilder)
+
+	otherErr := fmt.Errorf("failed to restore actor: %w", errors.New("database is down"))
+
+	testCases := []struct {
+		name          string
+		in            domain.ActorId
+		restoreExpErr error
+		expectedErr   error
+	}{
+		{
+			name:          "err_actor_not_in_trash",
+			in:            domain.ActorId(1),
+			restoreExpErr: fmt.Errorf("failed to restore actor: %w", storage.ErrActorNotFound),
+			expectedErr:   domain.ErrActorNotFound,
+		},
+		{
+			name:          "err_other",
+			in:            domain.ActorId(2),
+			restoreExpErr: otherErr,
+			expectedErr:   otherErr,
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			actorStorage.EXPECT().Restore(ctx, tCase.in, nil).Return(nil, tCase.restoreExpErr).Times(1)
+			actor, err := actorService.Restore(ctx, tCase.in)
+			require.ErrorIs(t, err, tCase.expectedErr)
+			require.Nil(t, actor)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package domain_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	mocks "github.com/vaberof/vk-internship-task/internal/domain/mocks"
+	"github.com/vaberof/vk-internship-task/pkg/logging/logs"
+	"go.uber.org/mock/gomock"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	auditStorage := mocks.NewMockAuditStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	entityType := domain.AuditEntityTypeFilm
+	userId := int64(2)
+
+	filter := &domain.AuditFilter{
+		EntityType: &entityType,
+		UserId:     &userId,
+	}
+
+	expected := []*domain.AuditEvent{
+		{
+			Id:         domain.AuditEventId(1),
+			UserId:     &userId,
+			Operation:  domain.AuditOperationCreate,
+			EntityType: domain.AuditEntityTypeFilm,
+			EntityId:   1,
+			After:      json.RawMessage(`{"id":1}`),
+			CreatedAt:  time.Now(),
+		},
+	}
+
+	auditStorage.EXPECT().List(ctx, filter, 100, 0).Return(expected, nil).Times(1)
+	auditStorage.EXPECT().Count(ctx, filter).Return(int64(1), nil).Times(1)
+
+	auditService := domain.NewAuditService(auditStorage, logsBuilder)
+	auditEventsPage, err := auditService.List(ctx, filter, 100, 0)
+	require.NoError(t, err)
+	require.Equal(t, expected, auditEventsPage.Events)
+	require.Equal(t, int64(1), auditEventsPage.Total)
+}
+
+func TestListError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	auditStorage := mocks.NewMockAuditStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	unknownEntityType := domain.AuditEntityType("user")
+	from := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	expectedErr := fmt.Errorf("failed to list audit events: %w", errors.New("database is down"))
+
+	testCases := []struct {
+		name           string
+		filter         *domain.AuditFilter
+		listExpErr     error
+		expectedErr    error
+		expectsListing bool
+	}{
+		{
+			name:        "err_unknown_entity_type",
+			filter:      &domain.AuditFilter{EntityType: &unknownEntityType},
+			expectedErr: domain.ErrInvalidAuditFilter,
+		},
+		{
+			name:        "err_from_after_to",
+			filter:      &domain.AuditFilter{From: &from, To: &to},
+			expectedErr: domain.ErrInvalidAuditFilter,
+		},
+		{
+			name:           "err_other",
+			filter:         &domain.AuditFilter{},
+			listExpErr:     expectedErr,
+			expectedErr:    expectedErr,
+			expectsListing: true,
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			if tCase.expectsListing {
+				auditStorage.EXPECT().List(ctx, tCase.filter, 100, 0).Return(nil, tCase.listExpErr).Times(1)
+			}
+
+			auditService := domain.NewAuditService(auditStorage, logsBuilder)
+			auditEventsPage, err := auditService.List(ctx, tCase.filter, 100, 0)
+			require.ErrorIs(t, err, tCase.expectedErr)
+			require.Nil(t, auditEventsPage)
+		})
+	}
+}
+
+func TestNewFilmAuditEvent(t *testing.T) {
+	userId := int64(2)
+
+	before := &domain.Film{
+		Id:          domain.FilmId(1),
+		Title:       domain.FilmTitle("Title_1"),
+		ReleaseDate: domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)),
+		Rating:      domain.FilmRating(5),
+		Actors:      []*domain.Actor{{Id: domain.ActorId(3)}},
+		Genres:      []*domain.Genre{{Id: domain.GenreId(4)}},
+	}
+
+	event, err := domain.NewFilmAuditEvent(&userId, domain.AuditOperationDelete, before, nil)
+	require.NoError(t, err)
+	require.Equal(t, &userId, event.UserId)
+	require.Equal(t, domain.AuditOperationDelete, event.Operation)
+	require.Equal(t, domain.AuditEntityTypeFilm, event.EntityType)
+	require.Equal(t, int64(1), event.EntityId)
+	require.JSONEq(t, `{"id":1,"title":"Title_1","description":"","release_date":"2000-01-01","rating":5,"actor_ids":[3],"genre_ids":[4]}`, string(event.Before))
+	require.Nil(t, event.After)
+}
@@ -0,0 +1,158 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	mocks "github.com/vaberof/vk-internship-task/internal/domain/mocks"
+	"github.com/vaberof/vk-internship-task/internal/service/user"
+	"github.com/vaberof/vk-internship-task/pkg/logging/logs"
+	"go.uber.org/mock/gomock"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestImport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filmImportStorage := mocks.NewMockFilmImportStorage(ctrl)
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	principal := &user.Principal{Id: 2, Email: "admin@example.com", Role: user.RoleAdmin}
+
+	ctx := user.ContextWithPrincipal(context.Background(), principal)
+
+	rows := []*domain.FilmImportRow{
+		newFilmImportRow(1, "Title_1", []domain.GenreId{1}, "Actor_1", "Actor_2"),
+		newFilmImportRow(2, "Title_2", nil, "Actor_1"),
+	}
+
+	expected := &domain.FilmImportReport{
+		Committed:     true,
+		Films:         2,
+		FilmIds:       []domain.FilmId{1, 2},
+		CreatedActors: 2,
+		MatchedActors: 1,
+	}
+
+	genreStorage.EXPECT().AreExists(ctx, []domain.GenreId{1}).Return(true, nil).Times(1)
+	filmImportStorage.EXPECT().Import(ctx, rows, true, &principal.Id).Return(expected, nil).Times(1)
+
+	filmImportService := domain.NewFilmImportService(filmImportStorage, genreStorage, logsBuilder)
+	report, err := filmImportService.Import(ctx, rows, nil, false)
+	require.NoError(t, err)
+	require.True(t, report.Committed)
+	require.False(t, report.DryRun)
+	require.Equal(t, 2, report.Films)
+	require.Empty(t, report.Errors)
+}
+
+func TestImportDryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filmImportStorage := mocks.NewMockFilmImportStorage(ctrl)
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	rows := []*domain.FilmImportRow{
+		newFilmImportRow(1, "Title_1", nil, "Actor_1"),
+	}
+
+	filmImportStorage.EXPECT().Import(ctx, rows, false, nil).Return(&domain.FilmImportReport{Films: 1, CreatedActors: 1}, nil).Times(1)
+
+	filmImportService := domain.NewFilmImportService(filmImportStorage, genreStorage, logsBuilder)
+	report, err := filmImportService.Import(ctx, rows, nil, true)
+	require.NoError(t, err)
+	require.True(t, report.DryRun)
+	require.False(t, report.Committed)
+	require.Equal(t, 1, report.Films)
+}
+
+func TestImportWithRowErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filmImportStorage := mocks.NewMockFilmImportStorage(ctrl)
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	validRow := newFilmImportRow(2, "Title_2", nil, "Actor_1")
+	repeatedActorRow := newFilmImportRow(3, "Title_3", nil, "Actor_1", "Actor_1")
+	unknownGenreRow := newFilmImportRow(4, "Title_4", []domain.GenreId{100}, "Actor_1")
+	failedRow := newFilmImportRow(5, "Title_5", nil, "Actor_2")
+
+	rows := []*domain.FilmImportRow{validRow, repeatedActorRow, unknownGenreRow, failedRow}
+	decodeErrors := []*domain.FilmImportRowError{{Line: 6, Message: "invalid JSON"}, {Line: 1, Message: "invalid JSON"}}
+
+	genreStorage.EXPECT().AreExists(ctx, []domain.GenreId{100}).Return(false, nil).Times(1)
+	filmImportStorage.EXPECT().Import(ctx, []*domain.FilmImportRow{validRow, failedRow}, false, nil).
+		Return(&domain.FilmImportReport{Films: 1, Errors: []*domain.FilmImportRowError{{Line: 5, Message: "failed to create a film"}}}, nil).Times(1)
+
+	filmImportService := domain.NewFilmImportService(filmImportStorage, genreStorage, logsBuilder)
+	report, err := filmImportService.Import(ctx, rows, decodeErrors, false)
+	require.NoError(t, err)
+	require.False(t, report.Committed)
+
+	lines := make([]int, len(report.Errors))
+	for i, rowError := range report.Errors {
+		lines[i] = rowError.Line
+	}
+	require.Equal(t, []int{1, 3, 4, 5, 6}, lines)
+}
+
+func TestImportError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filmImportStorage := mocks.NewMockFilmImportStorage(ctrl)
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	rows := []*domain.FilmImportRow{
+		newFilmImportRow(1, "Title_1", nil, "Actor_1"),
+	}
+
+	fakeError := errors.New("database is down")
+	expectedErr := fmt.Errorf("failed to start transaction while importing films: %w", fakeError)
+
+	filmImportStorage.EXPECT().Import(ctx, rows, true, nil).Return(nil, expectedErr).Times(1)
+
+	filmImportService := domain.NewFilmImportService(filmImportStorage, genreStorage, logsBuilder)
+	report, err := filmImportService.Import(ctx, rows, nil, false)
+	require.Error(t, err)
+	require.EqualError(t, expectedErr, err.Error())
+	require.Nil(t, report)
+}
+
+func newFilmImportRow(line int, title domain.FilmTitle, genreIds []domain.GenreId, actorNames ...domain.ActorName) *domain.FilmImportRow {
+	actors := make([]*domain.FilmImportActor, len(actorNames))
+	for i, actorName := range actorNames {
+		actors[i] = &domain.FilmImportActor{
+			Name:      actorName,
+			Sex:       domain.ActorSex(1),
+			BirthDate: domain.ActorBirthDate(time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)),
+		}
+	}
+
+	return &domain.FilmImportRow{
+		Line:        line,
+		Title:       title,
+		Description: "Description",
+		ReleaseDate: domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)),
+		Rating:      domain.FilmRating(5),
+		Actors:      actors,
+		GenreIds:    genreIds,
+	}
+}
@@ -0,0 +1,163 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	mocks "github.com/vaberof/vk-internship-task/internal/domain/mocks"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"github.com/vaberof/vk-internship-task/internal/service/user"
+	"github.com/vaberof/vk-internship-task/pkg/logging/logs"
+	"go.uber.org/mock/gomock"
+	"os"
+	"testing"
+)
+
+func TestAdd(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filmListStorage := mocks.NewMockFilmListStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	principal := &user.Principal{Id: 1, Email: "user@example.com", Role: user.RoleUser}
+
+	ctx := user.ContextWithPrincipal(context.Background(), principal)
+
+	filmId := domain.FilmId(1)
+
+	filmListStorage.EXPECT().Add(ctx, principal.Id, domain.FilmListWatchlist, filmId).Return(nil).Times(1)
+
+	filmListService := domain.NewFilmListService(filmListStorage, logsBuilder)
+	err := filmListService.Add(ctx, domain.FilmListWatchlist, filmId)
+	require.NoError(t, err)
+}
+
+func TestAddError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filmListStorage := mocks.NewMockFilmListStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	principal := &user.Principal{Id: 1, Email: "user@example.com", Role: user.RoleUser}
+
+	ctx := user.ContextWithPrincipal(context.Background(), principal)
+
+	filmListService := domain.NewFilmListService(filmListStorage, logsBuilder)
+
+	testCases := []struct {
+		name      string
+		filmId    domain.FilmId
+		addErr    error
+		addExpErr error
+	}{
+		{
+			name:      "err_film_not_found",
+			filmId:    domain.FilmId(1),
+			addErr:    fmt.Errorf("failed to add a film to the list: %w", storage.ErrFilmNotFound),
+			addExpErr: domain.ErrFilmNotFound,
+		},
+		{
+			name:      "err_other",
+			filmId:    domain.FilmId(2),
+			addErr:    fmt.Errorf("failed to add a film to the list: %w", errors.New("database is down")),
+			addExpErr: fmt.Errorf("failed to add a film to the list: %w", errors.New("database is down")),
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			filmListStorage.EXPECT().Add(ctx, principal.Id, domain.FilmListWatched, tCase.filmId).Return(tCase.addErr).Times(1)
+			err := filmListService.Add(ctx, domain.FilmListWatched, tCase.filmId)
+			require.EqualError(t, err, tCase.addExpErr.Error())
+		})
+	}
+
+	t.Run("err_forbidden", func(t *testing.T) {
+		err := filmListService.Add(context.Background(), domain.FilmListWatched, domain.FilmId(1))
+		require.ErrorIs(t, err, domain.ErrFilmListForbidden)
+	})
+}
+
+func TestRemoveError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filmListStorage := mocks.NewMockFilmListStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	principal := &user.Principal{Id: 1, Email: "user@example.com", Role: user.RoleUser}
+
+	ctx := user.ContextWithPrincipal(context.Background(), principal)
+
+	filmListService := domain.NewFilmListService(filmListStorage, logsBuilder)
+
+	testCases := []struct {
+		name         string
+		filmId       domain.FilmId
+		removeErr    error
+		removeExpErr error
+	}{
+		{
+			name:         "err_film_not_in_list",
+			filmId:       domain.FilmId(1),
+			removeErr:    fmt.Errorf("failed to remove a film from the list: %w", storage.ErrFilmListItemNotFound),
+			removeExpErr: domain.ErrFilmNotInList,
+		},
+		{
+			name:         "err_other",
+			filmId:       domain.FilmId(2),
+			removeErr:    fmt.Errorf("failed to remove a film from the list: %w", errors.New("database is down")),
+			removeExpErr: fmt.Errorf("failed to remove a film from the list: %w", errors.New("database is down")),
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			filmListStorage.EXPECT().Remove(ctx, principal.Id, domain.FilmListWatchlist, tCase.filmId).Return(tCase.removeErr).Times(1)
+			err := filmListService.Remove(ctx, domain.FilmListWatchlist, tCase.filmId)
+			require.EqualError(t, err, tCase.removeExpErr.Error())
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filmListStorage := mocks.NewMockFilmListStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	principal := &user.Principal{Id: 1, Email: "user@example.com", Role: user.RoleUser}
+
+	ctx := user.ContextWithPrincipal(context.Background(), principal)
+
+	sort := domain.FilmSort{{Field: domain.FilmSortFieldTitle, Direction: domain.SortDirectionAsc}}
+	limit, offset := 10, 0
+
+	films := []*domain.Film{
+		{Id: domain.FilmId(1), Title: "Title_1"},
+		{Id: domain.FilmId(2), Title: "Title_2"},
+	}
+	total := int64(2)
+
+	filmListStorage.EXPECT().ListFilms(ctx, principal.Id, domain.FilmListWatchlist, sort, nil, limit, offset).Return(films, nil, nil).Times(1)
+	filmListStorage.EXPECT().GetFilmsLists(ctx, principal.Id, []domain.FilmId{1, 2}).Return(map[domain.FilmId][]domain.FilmList{
+		1: {domain.FilmListWatchlist},
+		2: {domain.FilmListWatchlist, domain.FilmListWatched},
+	}, nil).Times(1)
+	filmListStorage.EXPECT().CountFilms(ctx, principal.Id, domain.FilmListWatchlist).Return(total, nil).Times(1)
+
+	filmListService := domain.NewFilmListService(filmListStorage, logsBuilder)
+	filmsPage, err := filmListService.List(ctx, domain.FilmListWatchlist, sort, nil, true, limit, offset)
+	require.NoError(t, err)
+	require.Equal(t, &total, filmsPage.Total)
+	require.Len(t, filmsPage.Films, 2)
+	require.True(t, filmsPage.Films[0].InWatchlist)
+	require.False(t, filmsPage.Films[0].Watched)
+	require.True(t, filmsPage.Films[1].InWatchlist)
+	require.True(t, filmsPage.Films[1].Watched)
+}
@@ -0,0 +1,351 @@
+package domain_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	mocks "github.com/vaberof/vk-internship-task/internal/domain/mocks"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"github.com/vaberof/vk-internship-task/pkg/logging/logs"
+	"go.uber.org/mock/gomock"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filmRevisionStorage := mocks.NewMockFilmRevisionStorage(ctrl)
+	filmStorage := mocks.NewMockFilmStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	filmId := domain.FilmId(1)
+	revisions := []*domain.FilmRevision{
+		{FilmId: filmId, Revision: 2, Title: "Title_2"},
+		{FilmId: filmId, Revision: 1, Title: "Title_1"},
+	}
+
+	filmStorage.EXPECT().IsExists(ctx, filmId).Return(true, nil).Times(1)
+	filmRevisionStorage.EXPECT().List(ctx, filmId, 20, 0).Return(revisions, nil).Times(1)
+	filmRevisionStorage.EXPECT().Count(ctx, filmId).Return(int64(2), nil).Times(1)
+
+	filmRevisionService := domain.NewFilmRevisionService(filmRevisionStorage, filmStorage, nil, logsBuilder)
+	revisionsPage, err := filmRevisionService.List(ctx, filmId, 20, 0)
+	require.NoError(t, err)
+	require.Equal(t, revisions, revisionsPage.Revisions)
+	require.Equal(t, int64(2), revisionsPage.Total)
+}
+
+func TestListError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filmRevisionStorage := mocks.NewMockFilmRevisionStorage(ctrl)
+	filmStorage := mocks.NewMockFilmStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	filmId := domain.FilmId(1)
+
+	type tCase struct {
+		name     string
+		prepare  func()
+		expected error
+	}
+
+	tCases := []tCase{
+		{
+			name: "err_film_not_found",
+			prepare: func() {
+				filmStorage.EXPECT().IsExists(ctx, filmId).Return(false, nil).Times(1)
+			},
+			expected: domain.ErrFilmNotFound,
+		},
+		{
+			name: "err_list_revisions",
+			prepare: func() {
+				filmStorage.EXPECT().IsExists(ctx, filmId).Return(true, nil).Times(1)
+				filmRevisionStorage.EXPECT().List(ctx, filmId, 20, 0).Return(nil, errors.New("storage error")).Times(1)
+			},
+			expected: errors.New("storage error"),
+		},
+	}
+
+	filmRevisionService := domain.NewFilmRevisionService(filmRevisionStorage, filmStorage, nil, logsBuilder)
+
+	for _, tc := range tCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.prepare()
+
+			revisionsPage, err := filmRevisionService.List(ctx, filmId, 20, 0)
+			require.Error(t, err)
+			require.Equal(t, tc.expected, err)
+			require.Nil(t, revisionsPage)
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filmRevisionStorage := mocks.NewMockFilmRevisionStorage(ctrl)
+	filmStorage := mocks.NewMockFilmStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	filmId := domain.FilmId(1)
+	releaseDate := domain.FilmReleaseDate(time.Date(2010, 7, 16, 0, 0, 0, 0, time.UTC))
+
+	from := &domain.FilmRevision{
+		FilmId:      filmId,
+		Revision:    1,
+		Title:       "Title_1",
+		Description: "Description_1",
+		ReleaseDate: releaseDate,
+		Rating:      8,
+		Credits: []*domain.FilmCreditParams{
+			{ActorId: 1, Role: domain.CreditRoleActor, CharacterName: "Cobb", BillingOrder: 0},
+		},
+		GenreIds: []domain.GenreId{1},
+	}
+	to := &domain.FilmRevision{
+		FilmId:      filmId,
+		Revision:    3,
+		Title:       "Title_1",
+		Description: "Description_1",
+		ReleaseDate: releaseDate,
+		Rating:      9,
+		Credits: []*domain.FilmCreditParams{
+			{ActorId: 1, Role: domain.CreditRoleActor, CharacterName: "Cobb", BillingOrder: 0},
+			{ActorId: 2, Role: domain.CreditRoleDirector, BillingOrder: 1},
+		},
+		GenreIds: []domain.GenreId{1},
+	}
+
+	filmStorage.EXPECT().IsExists(ctx, filmId).Return(true, nil).Times(1)
+	filmRevisionStorage.EXPECT().Get(ctx, filmId, int64(1)).Return(from, nil).Times(1)
+	filmRevisionStorage.EXPECT().Get(ctx, filmId, int64(3)).Return(to, nil).Times(1)
+
+	filmRevisionService := domain.NewFilmRevisionService(filmRevisionStorage, filmStorage, nil, logsBuilder)
+	changes, err := filmRevisionService.Diff(ctx, filmId, 1, 3)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+
+	require.Equal(t, "rating", changes[0].Field)
+	require.Equal(t, uint8(8), changes[0].From)
+	require.Equal(t, uint8(9), changes[0].To)
+
+	require.Equal(t, "credits", changes[1].Field)
+	require.JSONEq(t,
+		`[{"actor_id":1,"role":"actor","character_name":"Cobb","billing_order":0}]`,
+		marshalJSON(t, changes[1].From))
+	require.JSONEq(t,
+		`[{"actor_id":1,"role":"actor","character_name":"Cobb","billing_order":0},{"actor_id":2,"role":"director","billing_order":1}]`,
+		marshalJSON(t, changes[1].To))
+}
+
+func TestDiffError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filmRevisionStorage := mocks.NewMockFilmRevisionStorage(ctrl)
+	filmStorage := mocks.NewMockFilmStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	filmId := domain.FilmId(1)
+
+	type tCase struct {
+		name     string
+		prepare  func()
+		expected error
+	}
+
+	tCases := []tCase{
+		{
+			name: "err_film_not_found",
+			prepare: func() {
+				filmStorage.EXPECT().IsExists(ctx, filmId).Return(false, nil).Times(1)
+			},
+			expected: domain.ErrFilmNotFound,
+		},
+		{
+			name: "err_revision_not_found",
+			prepare: func() {
+				filmStorage.EXPECT().IsExists(ctx, filmId).Return(true, nil).Times(1)
+				filmRevisionStorage.EXPECT().Get(ctx, filmId, int64(1)).Return(&domain.FilmRevision{FilmId: filmId, Revision: 1}, nil).Times(1)
+				filmRevisionStorage.EXPECT().Get(ctx, filmId, int64(2)).Return(nil, fmt.Errorf("failed to get a film revision: %w", storage.ErrFilmRevisionNotFound)).Times(1)
+			},
+			expected: domain.ErrFilmRevisionNotFound,
+		},
+	}
+
+	filmRevisionService := domain.NewFilmRevisionService(filmRevisionStorage, filmStorage, nil, logsBuilder)
+
+	for _, tc := range tCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.prepare()
+
+			changes, err := filmRevisionService.Diff(ctx, filmId, 1, 2)
+			require.Error(t, err)
+			require.Equal(t, tc.expected, err)
+			require.Nil(t, changes)
+		})
+	}
+}
+
+func TestRevert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filmRevisionStorage := mocks.NewMockFilmRevisionStorage(ctrl)
+	filmStorage := mocks.NewMockFilmStorage(ctrl)
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	filmListStorage := mocks.NewMockFilmListStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	filmId := domain.FilmId(1)
+	version := int64(4)
+
+	revision := &domain.FilmRevision{
+		FilmId:      filmId,
+		Revision:    2,
+		Title:       "Title_1",
+		Description: "Description_1",
+		ReleaseDate: domain.FilmReleaseDate(time.Date(2010, 7, 16, 0, 0, 0, 0, time.UTC)),
+		Rating:      8,
+		Credits: []*domain.FilmCreditParams{
+			{ActorId: 1, Role: domain.CreditRoleActor, BillingOrder: 0},
+		},
+		GenreIds: []domain.GenreId{1},
+	}
+
+	expected := &domain.Film{
+		Id:          filmId,
+		Title:       revision.Title,
+		Description: revision.Description,
+		ReleaseDate: revision.ReleaseDate,
+		Rating:      revision.Rating,
+		Version:     5,
+	}
+
+	filmStorage.EXPECT().IsExists(ctx, filmId).Return(true, nil).Times(2)
+	filmRevisionStorage.EXPECT().Get(ctx, filmId, int64(2)).Return(revision, nil).Times(1)
+	actorStorage.EXPECT().AreExists(ctx, []domain.ActorId{1}).Return(true, nil).Times(1)
+	genreStorage.EXPECT().AreExists(ctx, revision.GenreIds).Return(true, nil).Times(1)
+	filmStorage.EXPECT().Update(ctx, filmId, &version, &revision.Title, &revision.Description, &revision.ReleaseDate, &revision.Rating, nil, &revision.Credits, &revision.GenreIds, nil).Return(expected, nil).Times(1)
+
+	filmService := domain.NewFilmService(filmStorage, actorStorage, genreStorage, filmListStorage, logsBuilder)
+	filmRevisionService := domain.NewFilmRevisionService(filmRevisionStorage, filmStorage, filmService, logsBuilder)
+	film, err := filmRevisionService.Revert(ctx, filmId, 2, &version)
+	require.NoError(t, err)
+	require.Equal(t, expected, film)
+}
+
+func TestRevertError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filmRevisionStorage := mocks.NewMockFilmRevisionStorage(ctrl)
+	filmStorage := mocks.NewMockFilmStorage(ctrl)
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	filmListStorage := mocks.NewMockFilmListStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	filmId := domain.FilmId(1)
+	version := int64(4)
+
+	revision := &domain.FilmRevision{
+		FilmId:   filmId,
+		Revision: 2,
+		Title:    "Title_1",
+		Credits: []*domain.FilmCreditParams{
+			{ActorId: 1, Role: domain.CreditRoleActor, BillingOrder: 0},
+		},
+		GenreIds: []domain.GenreId{},
+	}
+
+	type tCase struct {
+		name     string
+		prepare  func()
+		expected error
+	}
+
+	tCases := []tCase{
+		{
+			name: "err_film_not_found",
+			prepare: func() {
+				filmStorage.EXPECT().IsExists(ctx, filmId).Return(false, nil).Times(1)
+			},
+			expected: domain.ErrFilmNotFound,
+		},
+		{
+			name: "err_revision_not_found",
+			prepare: func() {
+				filmStorage.EXPECT().IsExists(ctx, filmId).Return(true, nil).Times(1)
+				filmRevisionStorage.EXPECT().Get(ctx, filmId, int64(2)).Return(nil, fmt.Errorf("failed to get a film revision: %w", storage.ErrFilmRevisionNotFound)).Times(1)
+			},
+			expected: domain.ErrFilmRevisionNotFound,
+		},
+		{
+			name: "err_actors_not_found",
+			prepare: func() {
+				filmStorage.EXPECT().IsExists(ctx, filmId).Return(true, nil).Times(2)
+				filmRevisionStorage.EXPECT().Get(ctx, filmId, int64(2)).Return(revision, nil).Times(1)
+				actorStorage.EXPECT().AreExists(ctx, []domain.ActorId{1}).Return(false, nil).Times(1)
+			},
+			expected: domain.ErrFilmActorsNotFound,
+		},
+		{
+			name: "err_version_mismatch",
+			prepare: func() {
+				filmStorage.EXPECT().IsExists(ctx, filmId).Return(true, nil).Times(2)
+				filmRevisionStorage.EXPECT().Get(ctx, filmId, int64(2)).Return(revision, nil).Times(1)
+				actorStorage.EXPECT().AreExists(ctx, []domain.ActorId{1}).Return(true, nil).Times(1)
+				genreStorage.EXPECT().AreExists(ctx, revision.GenreIds).Return(true, nil).Times(1)
+				filmStorage.EXPECT().Update(ctx, filmId, &version, &revision.Title, &revision.Description, &revision.ReleaseDate, &revision.Rating, nil, &revision.Credits, &revision.GenreIds, nil).Return(nil, fmt.Errorf("failed to update a film: %w", storage.ErrFilmVersionMismatch)).Times(1)
+			},
+			expected: domain.ErrFilmVersionMismatch,
+		},
+	}
+
+	filmService := domain.NewFilmService(filmStorage, actorStorage, genreStorage, filmListStorage, logsBuilder)
+	filmRevisionService := domain.NewFilmRevisionService(filmRevisionStorage, filmStorage, filmService, logsBuilder)
+
+	for _, tc := range tCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.prepare()
+
+			film, err := filmRevisionService.Revert(ctx, filmId, 2, &version)
+			require.Error(t, err)
+			require.Equal(t, tc.expected, err)
+			require.Nil(t, film)
+		})
+	}
+}
+
+func marshalJSON(t *testing.T, value any) string {
+	t.Helper()
+
+	data, err := json.Marshal(value)
+	require.NoError(t, err)
+
+	return string(data)
+}
@@ -1,11 +1,14 @@
 package domain_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/stretchr/testify/require"
 	"github.com/vaberof/vk-internship-task/internal/domain"
 	mocks "github.com/vaberof/vk-internship-task/internal/domain/mocks"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"github.com/vaberof/vk-internship-task/internal/service/user"
 	"github.com/vaberof/vk-internship-task/pkg/logging/logs"
 	"go.uber.org/mock/gomock"
 	"os"
@@ -19,15 +22,22 @@ func TestCreate(t *testing.T) {
 
 	filmStorage := mocks.NewMockFilmStorage(ctrl)
 	actorStorage := mocks.NewMockActorStorage(ctrl)
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	filmListStorage := mocks.NewMockFilmListStorage(ctrl)
 
 	logsBuilder := logs.New(os.Stdout, nil)
 
+	principal := &user.Principal{Id: 1, Email: "admin@example.com", Role: user.RoleAdmin}
+
+	ctx := user.ContextWithPrincipal(context.Background(), principal)
+
 	filmId := domain.FilmId(1)
 	filmTitle := domain.FilmTitle("Title_1")
 	filmDescription := domain.FilmDescription("Description_1")
 	filmReleaseDate := domain.FilmReleaseDate(time.Now())
 	filmRating := domain.FilmRating(10)
 	actorIds := []domain.ActorId{1, 2}
+	genreIds := []domain.GenreId{1}
 
 	expected := &domain.Film{
 		Id:          filmId,
@@ -49,13 +59,20 @@ func TestCreate(t *testing.T) {
 				BirthDate: domain.ActorBirthDate(time.Now()),
 			},
 		},
+		Genres: []*domain.Genre{
+			{
+				Id:   domain.GenreId(1),
+				Name: domain.GenreName("Drama"),
+			},
+		},
 	}
 
-	actorStorage.EXPECT().AreExists(actorIds).Return(true, nil).Times(1)
-	filmStorage.EXPECT().Create(filmTitle, filmDescription, filmReleaseDate, filmRating, actorIds).Return(expected, nil).Times(1)
+	actorStorage.EXPECT().AreExists(ctx, actorIds).Return(true, nil).Times(1)
+	genreStorage.EXPECT().AreExists(ctx, genreIds).Return(true, nil).Times(1)
+	filmStorage.EXPECT().Create(ctx, filmTitle, filmDescription, filmReleaseDate, filmRating, domain.NewActorCredits(actorIds), genreIds, &principal.Id).Return(expected, nil).Times(1)
 
-	filmService := domain.NewFilmService(filmStorage, actorStorage, logsBuilder)
-	film, err := filmService.Create(filmTitle, filmDescription, filmReleaseDate, filmRating, actorIds)
+	filmService := domain.NewFilmService(filmStorage, actorStorage, genreStorage, filmListStorage, logsBuilder)
+	film, err := filmService.Create(ctx, filmTitle, filmDescription, filmReleaseDate, filmRating, actorIds, nil, genreIds)
 	require.NoError(t, err)
 	require.Equal(t, expected, film)
 }
@@ -66,10 +83,14 @@ func TestCreateError(t *testing.T) {
 
 	filmStorage := mocks.NewMockFilmStorage(ctrl)
 	actorStorage := mocks.NewMockActorStorage(ctrl)
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	filmListStorage := mocks.NewMockFilmListStorage(ctrl)
 
 	logsBuilder := logs.New(os.Stdout, nil)
 
-	filmService := domain.NewFilmService(filmStorage, actorStorage, logsBuilder)
+	ctx := context.Background()
+
+	filmService := domain.NewFilmService(filmStorage, actorStorage, genreStorage, filmListStorage, logsBuilder)
 
 	type in struct {
 		Title       domain.FilmTitle
@@ -77,6 +98,7 @@ func TestCreateError(t *testing.T) {
 		ReleaseDate domain.FilmReleaseDate
 		Rating      domain.FilmRating
 		ActorIds    []domain.ActorId
+		GenreIds    []domain.GenreId
 	}
 
 	var (
@@ -94,6 +116,11 @@ func TestCreateError(t *testing.T) {
 
 		actorIds1 = []domain.ActorId{1, 2}
 		actorIds2 = []domain.ActorId{1, 2, 3}
+		actorIds3 = []domain.ActorId{4}
+
+		genreIds1 = []domain.GenreId{1}
+		genreIds2 = []domain.GenreId{1, 2}
+		genreIds3 = []domain.GenreId{3}
 	)
 
 	testCases := []struct {
@@ -103,6 +130,7 @@ func TestCreateError(t *testing.T) {
 		createExpErr error
 		exists       bool
 		existsExpErr error
+		genresExists bool
 	}{
 		{
 			name: "err_film_actors_not_found",
@@ -112,11 +140,29 @@ func TestCreateError(t *testing.T) {
 				ReleaseDate: filmReleaseDate1,
 				Rating:      filmRating1,
 				ActorIds:    actorIds1,
+				GenreIds:    genreIds1,
 			},
 			out:          nil,
 			createExpErr: domain.ErrFilmActorsNotFound,
 			exists:       false,
 			existsExpErr: nil,
+			genresExists: true,
+		},
+		{
+			name: "err_film_genres_not_found",
+			in: in{
+				Title:       filmTitle1,
+				Description: filmDescription1,
+				ReleaseDate: filmReleaseDate1,
+				Rating:      filmRating1,
+				ActorIds:    actorIds3,
+				GenreIds:    genreIds3,
+			},
+			out:          nil,
+			createExpErr: domain.ErrFilmGenresNotFound,
+			exists:       true,
+			existsExpErr: nil,
+			genresExists: false,
 		},
 		{
 			name: "err_other",
@@ -126,19 +172,22 @@ func TestCreateError(t *testing.T) {
 				ReleaseDate: filmReleaseDate2,
 				Rating:      filmRating2,
 				ActorIds:    actorIds2,
+				GenreIds:    genreIds2,
 			},
 			out:          nil,
 			createExpErr: fmt.Errorf("failed to create a film: %w", errors.New("database is down")),
 			exists:       true,
 			existsExpErr: nil,
+			genresExists: true,
 		},
 	}
 
 	for _, tCase := range testCases {
 		t.Run(tCase.name, func(t *testing.T) {
-			actorStorage.EXPECT().AreExists(tCase.in.ActorIds).Return(tCase.exists, tCase.existsExpErr).AnyTimes()
-			filmStorage.EXPECT().Create(tCase.in.Title, tCase.in.Description, tCase.in.ReleaseDate, tCase.in.Rating, tCase.in.ActorIds).Return(tCase.out, tCase.createExpErr).AnyTimes()
-			film, err := filmService.Create(tCase.in.Title, tCase.in.Description, tCase.in.ReleaseDate, tCase.in.Rating, tCase.in.ActorIds)
+			actorStorage.EXPECT().AreExists(ctx, tCase.in.ActorIds).Return(tCase.exists, tCase.existsExpErr).AnyTimes()
+			genreStorage.EXPECT().AreExists(ctx, tCase.in.GenreIds).Return(tCase.genresExists, nil).AnyTimes()
+			filmStorage.EXPECT().Create(ctx, tCase.in.Title, tCase.in.Description, tCase.in.ReleaseDate, tCase.in.Rating, domain.NewActorCredits(tCase.in.ActorIds), tCase.in.GenreIds, nil).Return(tCase.out, tCase.createExpErr).AnyTimes()
+			film, err := filmService.Create(ctx, tCase.in.Title, tCase.in.Description, tCase.in.ReleaseDate, tCase.in.Rating, tCase.in.ActorIds, nil, tCase.in.GenreIds)
 			require.Error(t, err)
 			require.EqualError(t, tCase.createExpErr, err.Error())
 			require.Nil(t, film)
@@ -146,21 +195,107 @@ func TestCreateError(t *testing.T) {
 	}
 }
 
+func TestCreateWithCredits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filmStorage := mocks.NewMockFilmStorage(ctrl)
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	filmListStorage := mocks.NewMockFilmListStorage(ctrl)
+
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	filmTitle := domain.FilmTitle("Title_1")
+	filmDescription := domain.FilmDescription("Description_1")
+	filmReleaseDate := domain.FilmReleaseDate(time.Now())
+	filmRating := domain.FilmRating(10)
+	actorIds := []domain.ActorId{1, 2}
+	credits := []*domain.FilmCreditParams{
+		{ActorId: 3, Role: domain.CreditRoleDirector},
+		{ActorId: 1, Role: domain.CreditRoleWriter, BillingOrder: 1},
+	}
+
+	expectedCredits := append(domain.NewActorCredits(actorIds), credits...)
+
+	expected := &domain.Film{Id: domain.FilmId(1), Title: filmTitle}
+
+	actorStorage.EXPECT().AreExists(ctx, []domain.ActorId{1, 2, 3}).Return(true, nil).Times(1)
+	genreStorage.EXPECT().AreExists(ctx, nil).Return(true, nil).Times(1)
+	filmStorage.EXPECT().Create(ctx, filmTitle, filmDescription, filmReleaseDate, filmRating, expectedCredits, nil, nil).Return(expected, nil).Times(1)
+
+	filmService := domain.NewFilmService(filmStorage, actorStorage, genreStorage, filmListStorage, logsBuilder)
+	film, err := filmService.Create(ctx, filmTitle, filmDescription, filmReleaseDate, filmRating, actorIds, credits, nil)
+	require.NoError(t, err)
+	require.Equal(t, expected, film)
+}
+
+func TestCreateInvalidCreditError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filmStorage := mocks.NewMockFilmStorage(ctrl)
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	filmListStorage := mocks.NewMockFilmListStorage(ctrl)
+
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	filmService := domain.NewFilmService(filmStorage, actorStorage, genreStorage, filmListStorage, logsBuilder)
+
+	testCases := []struct {
+		name   string
+		credit *domain.FilmCreditParams
+	}{
+		{
+			name:   "err_unexpected_role",
+			credit: &domain.FilmCreditParams{ActorId: 1, Role: domain.CreditRole("stuntman")},
+		},
+		{
+			name:   "err_negative_billing_order",
+			credit: &domain.FilmCreditParams{ActorId: 1, Role: domain.CreditRoleDirector, BillingOrder: -1},
+		},
+		{
+			name:   "err_character_name_for_not_actor",
+			credit: &domain.FilmCreditParams{ActorId: 1, Role: domain.CreditRoleDirector, CharacterName: "Neo"},
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			film, err := filmService.Create(ctx, "Title_1", "Description_1", domain.FilmReleaseDate(time.Now()), 5, nil, []*domain.FilmCreditParams{tCase.credit}, nil)
+			require.ErrorIs(t, err, domain.ErrInvalidFilmCredit)
+			require.Nil(t, film)
+		})
+	}
+}
+
 func TestUpdate(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	filmStorage := mocks.NewMockFilmStorage(ctrl)
 	actorStorage := mocks.NewMockActorStorage(ctrl)
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	filmListStorage := mocks.NewMockFilmListStorage(ctrl)
 
 	logsBuilder := logs.New(os.Stdout, nil)
 
+	principal := &user.Principal{Id: 1, Email: "admin@example.com", Role: user.RoleAdmin}
+
+	ctx := user.ContextWithPrincipal(context.Background(), principal)
+
 	filmId := domain.FilmId(1)
 	filmTitle := domain.FilmTitle("Title_1")
 	filmDescription := domain.FilmDescription("Description_1")
 	filmReleaseDate := domain.FilmReleaseDate(time.Now())
 	filmRating := domain.FilmRating(10)
 	actorIds := []domain.ActorId{1, 2}
+	genreIds := []domain.GenreId{1}
 
 	expected := &domain.Film{
 		Id:          filmId,
@@ -184,14 +319,18 @@ func TestUpdate(t *testing.T) {
 		},
 	}
 
-	filmStorage.EXPECT().IsExists(filmId).Return(true, nil).Times(1)
-	actorStorage.EXPECT().AreExists(actorIds).Return(true, nil).Times(1)
-	filmStorage.EXPECT().Update(filmId, &filmTitle, &filmDescription, &filmReleaseDate, &filmRating, &actorIds).Return(expected, nil).Times(1)
+	filmStorage.EXPECT().IsExists(ctx, filmId).Return(true, nil).Times(1)
+	actorStorage.EXPECT().AreExists(ctx, actorIds).Return(true, nil).Times(1)
+	genreStorage.EXPECT().AreExists(ctx, genreIds).Return(true, nil).Times(1)
+	filmStorage.EXPECT().Update(ctx, filmId, nil, &filmTitle, &filmDescription, &filmReleaseDate, &filmRating, &actorIds, nil, &genreIds, &principal.Id).Return(expected, nil).Times(1)
+	filmListStorage.EXPECT().GetFilmsLists(ctx, principal.Id, []domain.FilmId{filmId}).Return(map[domain.FilmId][]domain.FilmList{filmId: {domain.FilmListWatchlist}}, nil).Times(1)
 
-	filmService := domain.NewFilmService(filmStorage, actorStorage, logsBuilder)
-	film, err := filmService.Update(filmId, &filmTitle, &filmDescription, &filmReleaseDate, &filmRating, &actorIds)
+	filmService := domain.NewFilmService(filmStorage, actorStorage, genreStorage, filmListStorage, logsBuilder)
+	film, err := filmService.Update(ctx, filmId, nil, &filmTitle, &filmDescription, &filmReleaseDate, &filmRating, &actorIds, nil, &genreIds)
 	require.NoError(t, err)
 	require.Equal(t, expected, film)
+	require.True(t, film.InWatchlist)
+	require.False(t, film.Watched)
 }
 
 func TestUpdateError(t *testing.T) {
@@ -200,10 +339,14 @@ func TestUpdateError(t *testing.T) {
 
 	filmStorage := mocks.NewMockFilmStorage(ctrl)
 	actorStorage := mocks.NewMockActorStorage(ctrl)
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	filmListStorage := mocks.NewMockFilmListStorage(ctrl)
 
 	logsBuilder := logs.New(os.Stdout, nil)
 
-	filmService := domain.NewFilmService(filmStorage, actorStorage, logsBuilder)
+	ctx := context.Background()
+
+	filmService := domain.NewFilmService(filmStorage, actorStorage, genreStorage, filmListStorage, logsBuilder)
 
 	type in struct {
 		Id          domain.FilmId
@@ -302,10 +445,10 @@ func TestUpdateError(t *testing.T) {
 
 	for _, tCase := range testCases {
 		t.Run(tCase.name, func(t *testing.T) {
-			filmStorage.EXPECT().IsExists(tCase.in.Id).Return(tCase.isFilmExists, tCase.isFilmExistsExpErr).AnyTimes()
-			actorStorage.EXPECT().AreExists(*tCase.in.ActorIds).Return(tCase.areActorsExists, tCase.areActorsExistsExpErr).AnyTimes()
-			filmStorage.EXPECT().Update(tCase.in.Id, tCase.in.Title, tCase.in.Description, tCase.in.ReleaseDate, tCase.in.Rating, tCase.in.ActorIds).Return(tCase.out, tCase.updateExpErr).AnyTimes()
-			film, err := filmService.Update(tCase.in.Id, tCase.in.Title, tCase.in.Description, tCase.in.ReleaseDate, tCase.in.Rating, tCase.in.ActorIds)
+			filmStorage.EXPECT().IsExists(ctx, tCase.in.Id).Return(tCase.isFilmExists, tCase.isFilmExistsExpErr).AnyTimes()
+			actorStorage.EXPECT().AreExists(ctx, *tCase.in.ActorIds).Return(tCase.areActorsExists, tCase.areActorsExistsExpErr).AnyTimes()
+			filmStorage.EXPECT().Update(ctx, tCase.in.Id, nil, tCase.in.Title, tCase.in.Description, tCase.in.ReleaseDate, tCase.in.Rating, tCase.in.ActorIds, nil, nil, nil).Return(tCase.out, tCase.updateExpErr).AnyTimes()
+			film, err := filmService.Update(ctx, tCase.in.Id, nil, tCase.in.Title, tCase.in.Description, tCase.in.ReleaseDate, tCase.in.Rating, tCase.in.ActorIds, nil, nil)
 			require.Error(t, err)
 			require.EqualError(t, tCase.updateExpErr, err.Error())
 			require.Nil(t, film)
@@ -319,16 +462,20 @@ func TestDelete(t *testing.T) {
 
 	filmsStorage := mocks.NewMockFilmStorage(ctrl)
 	actorStorage := mocks.NewMockActorStorage(ctrl)
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	filmListStorage := mocks.NewMockFilmListStorage(ctrl)
 
 	logsBuilder := logs.New(os.Stdout, nil)
 
+	ctx := context.Background()
+
 	filmId := domain.FilmId(1)
 
-	filmsStorage.EXPECT().IsExists(filmId).Return(true, nil).Times(1)
-	filmsStorage.EXPECT().Delete(filmId).Return(nil).Times(1)
+	filmsStorage.EXPECT().IsExists(ctx, filmId).Return(true, nil).Times(1)
+	filmsStorage.EXPECT().Delete(ctx, filmId, nil, nil).Return(nil).Times(1)
 
-	filmService := domain.NewFilmService(filmsStorage, actorStorage, logsBuilder)
-	err := filmService.Delete(filmId)
+	filmService := domain.NewFilmService(filmsStorage, actorStorage, genreStorage, filmListStorage, logsBuilder)
+	err := filmService.Delete(ctx, filmId, nil)
 	require.NoError(t, err)
 }
 
@@ -338,10 +485,14 @@ func TestDeleteError(t *testing.T) {
 
 	filmStorage := mocks.NewMockFilmStorage(ctrl)
 	actorStorage := mocks.NewMockActorStorage(ctrl)
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	filmListStorage := mocks.NewMockFilmListStorage(ctrl)
 
 	logsBuilder := logs.New(os.Stdout, nil)
 
-	filmService := domain.NewFilmService(filmStorage, actorStorage, logsBuilder)
+	ctx := context.Background()
+
+	filmService := domain.NewFilmService(filmStorage, actorStorage, genreStorage, filmListStorage, logsBuilder)
 
 	type in struct {
 		Id domain.FilmId
@@ -381,24 +532,113 @@ func TestDeleteError(t *testing.T) {
 
 	for _, tCase := range testCases {
 		t.Run(tCase.name, func(t *testing.T) {
-			filmStorage.EXPECT().IsExists(tCase.in.Id).Return(tCase.exists, tCase.existsExpErr).AnyTimes()
-			filmStorage.EXPECT().Delete(tCase.in.Id).Return(tCase.deleteExpErr).AnyTimes()
-			err := filmService.Delete(tCase.in.Id)
+			filmStorage.EXPECT().IsExists(ctx, tCase.in.Id).Return(tCase.exists, tCase.existsExpErr).AnyTimes()
+			filmStorage.EXPECT().Delete(ctx, tCase.in.Id, nil, nil).Return(tCase.deleteExpErr).AnyTimes()
+			err := filmService.Delete(ctx, tCase.in.Id, nil)
 			require.Error(t, err)
 			require.EqualError(t, tCase.deleteExpErr, err.Error())
 		})
 	}
 }
 
+func TestGetById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filmStorage := mocks.NewMockFilmStorage(ctrl)
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	filmListStorage := mocks.NewMockFilmListStorage(ctrl)
+
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	filmId := domain.FilmId(1)
+
+	expected := &domain.Film{
+		Id:          filmId,
+		Title:       domain.FilmTitle("Title_1"),
+		Description: domain.FilmDescription("Description_1"),
+		ReleaseDate: domain.FilmReleaseDate(time.Now()),
+		Rating:      domain.FilmRating(10),
+		Actors: []*domain.Actor{
+			{
+				Id:        domain.ActorId(1),
+				Name:      domain.ActorName("Actor_1"),
+				Sex:       domain.ActorSex(0),
+				BirthDate: domain.ActorBirthDate(time.Now()),
+			},
+		},
+	}
+
+	filmStorage.EXPECT().GetById(ctx, filmId).Return(expected, nil).Times(1)
+
+	filmService := domain.NewFilmService(filmStorage, actorStorage, genreStorage, filmListStorage, logsBuilder)
+	film, err := filmService.GetById(ctx, filmId)
+	require.NoError(t, err)
+	require.Equal(t, expected, film)
+}
+
+func TestGetByIdError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filmStorage := mocks.NewMockFilmStorage(ctrl)
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	filmListStorage := mocks.NewMockFilmListStorage(ctrl)
+
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	filmService := domain.NewFilmService(filmStorage, actorStorage, genreStorage, filmListStorage, logsBuilder)
+
+	testCases := []struct {
+		name       string
+		in         domain.FilmId
+		storageErr error
+		getExpErr  error
+	}{
+		{
+			name:       "err_film_not_found",
+			in:         domain.FilmId(1),
+			storageErr: fmt.Errorf("failed to get a film: %w", storage.ErrFilmNotFound),
+			getExpErr:  domain.ErrFilmNotFound,
+		},
+		{
+			name:       "err_other",
+			in:         domain.FilmId(2),
+			storageErr: fmt.Errorf("failed to get a film: %w", errors.New("database is down")),
+			getExpErr:  fmt.Errorf("failed to get a film: %w", errors.New("database is down")),
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			filmStorage.EXPECT().GetById(ctx, tCase.in).Return(nil, tCase.storageErr).Times(1)
+			film, err := filmService.GetById(ctx, tCase.in)
+			require.Error(t, err)
+			require.EqualError(t, tCase.getExpErr, err.Error())
+			require.Nil(t, film)
+		})
+	}
+}
+
 func TestList(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	filmStorage := mocks.NewMockFilmStorage(ctrl)
 	actorStorage := mocks.NewMockActorStorage(ctrl)
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	filmListStorage := mocks.NewMockFilmListStorage(ctrl)
 
 	logsBuilder := logs.New(os.Stdout, nil)
 
+	ctx := context.Background()
+
 	expected := []*domain.Film{
 		{
 			Id:          domain.FilmId(2),
@@ -438,19 +678,23 @@ func TestList(t *testing.T) {
 		},
 	}
 
-	titleOrder := ""
-	releaseDateOrder := ""
-	ratingOrder := "asc"
+	sort := domain.FilmSort{{Field: domain.FilmSortFieldRating, Direction: domain.SortDirectionAsc}}
 
 	limit := 100
 	offset := 0
 
-	filmStorage.EXPECT().ListWithSort(titleOrder, releaseDateOrder, ratingOrder, limit, offset).Return(expected, nil).Times(1)
+	nextCursor := domain.NewFilmCursor(expected[len(expected)-1])
+	total := int64(3)
+
+	filmStorage.EXPECT().List(ctx, nil, sort, nil, limit, offset).Return(expected, nextCursor, nil).Times(1)
+	filmStorage.EXPECT().Count(ctx, nil).Return(total, nil).Times(1)
 
-	filmService := domain.NewFilmService(filmStorage, actorStorage, logsBuilder)
-	films, err := filmService.ListWithSort(titleOrder, releaseDateOrder, ratingOrder, limit, offset)
+	filmService := domain.NewFilmService(filmStorage, actorStorage, genreStorage, filmListStorage, logsBuilder)
+	filmsPage, err := filmService.List(ctx, nil, sort, nil, true, limit, offset)
 	require.NoError(t, err)
-	require.Equal(t, expected, films)
+	require.Equal(t, expected, filmsPage.Films)
+	require.Equal(t, nextCursor, filmsPage.NextCursor)
+	require.Equal(t, &total, filmsPage.Total)
 }
 
 func TestListError(t *testing.T) {
@@ -459,37 +703,43 @@ func TestListError(t *testing.T) {
 
 	filmStorage := mocks.NewMockFilmStorage(ctrl)
 	actorStorage := mocks.NewMockActorStorage(ctrl)
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	filmListStorage := mocks.NewMockFilmListStorage(ctrl)
 
 	logsBuilder := logs.New(os.Stdout, nil)
 
+	ctx := context.Background()
+
 	fakeError := errors.New("database is down")
 	expectedErr := fmt.Errorf("failed to list actors: %w", fakeError)
 
-	titleOrder := ""
-	releaseDateOrder := ""
-	ratingOrder := "asc"
+	sort := domain.FilmSort{{Field: domain.FilmSortFieldRating, Direction: domain.SortDirectionAsc}}
 
 	limit := 100
 	offset := 0
 
-	filmStorage.EXPECT().ListWithSort(titleOrder, releaseDateOrder, ratingOrder, limit, offset).Return(nil, expectedErr).Times(1)
+	filmStorage.EXPECT().List(ctx, nil, sort, nil, limit, offset).Return(nil, nil, expectedErr).Times(1)
 
-	filmService := domain.NewFilmService(filmStorage, actorStorage, logsBuilder)
-	actors, err := filmService.ListWithSort(titleOrder, releaseDateOrder, ratingOrder, limit, offset)
+	filmService := domain.NewFilmService(filmStorage, actorStorage, genreStorage, filmListStorage, logsBuilder)
+	actors, err := filmService.List(ctx, nil, sort, nil, false, limit, offset)
 	require.Error(t, err)
 	require.EqualError(t, expectedErr, err.Error())
 	require.Nil(t, actors)
 }
 
-func TestSearchByFilters(t *testing.T) {
+func TestListWithFilter(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	filmStorage := mocks.NewMockFilmStorage(ctrl)
 	actorStorage := mocks.NewMockActorStorage(ctrl)
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	filmListStorage := mocks.NewMockFilmListStorage(ctrl)
 
 	logsBuilder := logs.New(os.Stdout, nil)
 
+	ctx := context.Background()
+
 	expected := []*domain.Film{
 		{
 			Id:          domain.FilmId(1),
@@ -529,43 +779,491 @@ func TestSearchByFilters(t *testing.T) {
 		},
 	}
 
-	title := domain.FilmTitle("Title")
-	actorName := domain.ActorName("Actor")
+	minRating := domain.FilmRating(1)
+
+	filter := &domain.FilmFilter{
+		Title:       domain.FilmTitle("Title"),
+		ActorName:   domain.ActorName("Actor"),
+		MinRating:   &minRating,
+		ActorIds:    []domain.ActorId{1},
+		ActorsMatch: domain.FilmActorsMatchAll,
+	}
 
 	limit := 100
 	offset := 0
 
-	filmStorage.EXPECT().SearchByFilters(title, actorName, limit, offset).Return(expected, nil).Times(1)
+	cursor := &domain.FilmCursor{Id: domain.FilmId(0)}
+
+	filmStorage.EXPECT().List(ctx, filter, nil, cursor, limit, offset).Return(expected, nil, nil).Times(1)
 
-	filmService := domain.NewFilmService(filmStorage, actorStorage, logsBuilder)
-	films, err := filmService.SearchByFilters(title, actorName, limit, offset)
+	filmService := domain.NewFilmService(filmStorage, actorStorage, genreStorage, filmListStorage, logsBuilder)
+	filmsPage, err := filmService.List(ctx, filter, nil, cursor, false, limit, offset)
 	require.NoError(t, err)
-	require.Equal(t, expected, films)
+	require.Equal(t, expected, filmsPage.Films)
+	require.Nil(t, filmsPage.NextCursor)
+	require.Nil(t, filmsPage.Total)
 }
 
-func TestSearchByFiltersError(t *testing.T) {
+func TestListWithFilterError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	filmStorage := mocks.NewMockFilmStorage(ctrl)
 	actorStorage := mocks.NewMockActorStorage(ctrl)
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	filmListStorage := mocks.NewMockFilmListStorage(ctrl)
 
 	logsBuilder := logs.New(os.Stdout, nil)
 
-	fakeError := errors.New("database is down")
-	expectedErr := fmt.Errorf("failed to search films: %w", fakeError)
+	ctx := context.Background()
 
-	title := domain.FilmTitle("Title")
-	actorName := domain.ActorName("Actor")
+	filmService := domain.NewFilmService(filmStorage, actorStorage, genreStorage, filmListStorage, logsBuilder)
 
-	limit := 100
-	offset := 0
+	minRating, maxRating := domain.FilmRating(8), domain.FilmRating(5)
+	releasedFrom := domain.FilmReleaseDate(time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC))
+	releasedTo := domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+	actorSex := domain.ActorSex(1)
 
-	filmStorage.EXPECT().SearchByFilters(title, actorName, limit, offset).Return(nil, expectedErr).Times(1)
+	testCases := []struct {
+		name   string
+		filter *domain.FilmFilter
+	}{
+		{
+			name:   "err_reversed_rating_range",
+			filter: &domain.FilmFilter{MinRating: &minRating, MaxRating: &maxRating},
+		},
+		{
+			name:   "err_reversed_release_date_range",
+			filter: &domain.FilmFilter{ReleasedFrom: &releasedFrom, ReleasedTo: &releasedTo},
+		},
+		{
+			name:   "err_unexpected_actors_match",
+			filter: &domain.FilmFilter{ActorIds: []domain.ActorId{1}, ActorsMatch: "some"},
+		},
+		{
+			name:   "err_without_actors_by_actors",
+			filter: &domain.FilmFilter{WithoutActors: true, ActorSex: &actorSex},
+		},
+	}
 
-	filmService := domain.NewFilmService(filmStorage, actorStorage, logsBuilder)
-	films, err := filmService.SearchByFilters(title, actorName, limit, offset)
-	require.Error(t, err)
-	require.EqualError(t, expectedErr, err.Error())
-	require.Nil(t, films)
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			films, err := filmService.List(ctx, tCase.filter, nil, nil, false, 100, 0)
+			require.ErrorIs(t, err, domain.ErrInvalidFilmFilter)
+			require.Nil(t, films)
+		})
+	}
+}
+
+func TestExport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filmStorage := mocks.NewMockFilmStorage(ctrl)
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	filmListStorage := mocks.NewMockFilmListStorage(ctrl)
+
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	storedFilms := []*domain.Film{
+		{Id: domain.FilmId(1), Title: domain.FilmTitle("Title_1")},
+		{Id: domain.FilmId(2), Title: domain.FilmTitle("Title_2")},
+	}
+
+	filmStorage.EXPECT().Export(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fn func(film *domain.Film) error) error {
+		for _, film := range storedFilms {
+			if err := fn(film); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Times(1)
+
+	var exported []*domain.Film
+
+	filmService := domain.NewFilmService(filmStorage, actorStorage, genreStorage, filmListStorage, logsBuilder)
+	err := filmService.Export(ctx, func(film *domain.Film) error {
+		exported = append(exported, film)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, storedFilms, exported)
+}
+
+func TestExportError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filmStorage := mocks.NewMockFilmStorage(ctrl)
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	filmListStorage := mocks.NewMockFilmListStorage(ctrl)
+
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	writeErr := errors.New("broken pipe")
+
+	filmStorage.EXPECT().Export(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fn func(film *domain.Film) error) error {
+		return fmt.Errorf("failed to export films: %w", fn(&domain.Film{Id: domain.FilmId(1)}))
+	}).Times(1)
+
+	filmService := domain.NewFilmService(filmStorage, actorStorage, genreStorage, filmListStorage, logsBuilder)
+	err := filmService.Export(ctx, func(film *domain.Film) error {
+		return writeErr
+	})
+	require.ErrorIs(t, err, writeErr)
+}
+
+func TestBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filmStorage := mocks.NewMockFilmStorage(ctrl)
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	filmListStorage := mocks.NewMockFilmListStorage(ctrl)
+
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	principal := &user.Principal{Id: 1, Email: "admin@example.com", Role: user.RoleAdmin}
+
+	ctx := user.ContextWithPrincipal(context.Background(), principal)
+
+	rating := domain.FilmRating(9)
+	updateActorIds := []domain.ActorId{3}
+	updateCredits := []*domain.FilmCreditParams{{ActorId: 4, Role: domain.CreditRoleDirector}}
+	staleVersion := int64(2)
+
+	operations := []*domain.FilmBatchOperation{
+		{
+			Type: domain.BatchOperationCreate,
+			Create: &domain.FilmCreateParams{
+				Title:    "Title_1",
+				Rating:   5,
+				ActorIds: []domain.ActorId{1, 2},
+				GenreIds: []domain.GenreId{1},
+			},
+		},
+		{
+			Type:   domain.BatchOperationUpdate,
+			Id:     domain.FilmId(2),
+			Update: &domain.FilmUpdateParams{Rating: &rating, ActorIds: &updateActorIds, Credits: &updateCredits},
+		},
+		{
+			Type: domain.BatchOperationDelete,
+			Id:   domain.FilmId(3),
+		},
+		{
+			Type:    domain.BatchOperationDelete,
+			Id:      domain.FilmId(4),
+			Version: &staleVersion,
+		},
+	}
+
+	// The credits are merged with the actor ids the same way as by Create and Update
+	expectedCreateCredits := domain.NewActorCredits([]domain.ActorId{1, 2})
+	expectedUpdateCredits := append(domain.NewActorCredits(updateActorIds), updateCredits...)
+
+	createdFilm := &domain.Film{Id: domain.FilmId(1), Title: "Title_1"}
+	updatedFilm := &domain.Film{Id: domain.FilmId(2), Rating: rating}
+
+	actorStorage.EXPECT().AreExists(ctx, []domain.ActorId{1, 2}).Return(true, nil).Times(1)
+	actorStorage.EXPECT().AreExists(ctx, []domain.ActorId{3, 4}).Return(true, nil).Times(1)
+	genreStorage.EXPECT().AreExists(ctx, []domain.GenreId{1}).Return(true, nil).Times(1)
+
+	filmStorage.EXPECT().Batch(ctx, domain.BatchModeBestEffort, gomock.Any(), &principal.Id).DoAndReturn(
+		func(_ context.Context, _ domain.BatchMode, storedOperations []*domain.FilmBatchOperation, _ *int64) ([]*domain.FilmBatchItem, bool, error) {
+			require.Len(t, storedOperations, 4)
+			require.Equal(t, expectedCreateCredits, storedOperations[0].Create.Credits)
+			require.Nil(t, storedOperations[0].Create.ActorIds)
+			require.Equal(t, &expectedUpdateCredits, storedOperations[1].Update.Credits)
+			require.Nil(t, storedOperations[1].Update.ActorIds)
+			require.Equal(t, &staleVersion, storedOperations[3].Version)
+
+			return []*domain.FilmBatchItem{
+				{Index: 0, Type: domain.BatchOperationCreate, Status: domain.BatchItemSucceeded, Film: createdFilm},
+				{Index: 1, Type: domain.BatchOperationUpdate, Id: domain.FilmId(2), Status: domain.BatchItemSucceeded, Film: updatedFilm},
+				{Index: 2, Type: domain.BatchOperationDelete, Id: domain.FilmId(3), Status: domain.BatchItemFailed, Err: fmt.Errorf("failed to delete film: %w", storage.ErrFilmNotFound)},
+				{Index: 3, Type: domain.BatchOperationDelete, Id: domain.FilmId(4), Status: domain.BatchItemFailed, Err: fmt.Errorf("failed to delete film: %w", storage.ErrFilmVersionMismatch)},
+			}, true, nil
+		}).Times(1)
+
+	filmService := domain.NewFilmService(filmStorage, actorStorage, genreStorage, filmListStorage, logsBuilder)
+	report, err := filmService.Batch(ctx, domain.BatchModeBestEffort, operations)
+	require.NoError(t, err)
+	require.True(t, report.Committed)
+	require.Len(t, report.Items, 4)
+
+	require.Equal(t, domain.BatchItemSucceeded, report.Items[0].Status)
+	require.Equal(t, createdFilm.Id, report.Items[0].Id)
+	require.Equal(t, createdFilm, report.Items[0].Film)
+
+	require.Equal(t, domain.BatchItemSucceeded, report.Items[1].Status)
+	require.Equal(t, updatedFilm, report.Items[1].Film)
+
+	require.Equal(t, domain.BatchItemFailed, report.Items[2].Status)
+	require.ErrorIs(t, report.Items[2].Err, domain.ErrFilmNotFound)
+
+	require.Equal(t, domain.BatchItemFailed, report.Items[3].Status)
+	require.ErrorIs(t, report.Items[3].Err, domain.ErrFilmVersionMismatch)
+}
+
+func TestBatchBestEffortSkipsInvalidOperations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filmStorage := mocks.NewMockFilmStorage(ctrl)
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	filmListStorage := mocks.NewMockFilmListStorage(ctrl)
+
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	operations := []*domain.FilmBatchOperation{
+		{Type: domain.BatchOperationCreate, Create: &domain.FilmCreateParams{Title: "Title_1", ActorIds: []domain.ActorId{1}}},
+		{Type: domain.BatchOperationDelete, Id: domain.FilmId(2)},
+	}
+
+	actorStorage.EXPECT().AreExists(ctx, []domain.ActorId{1}).Return(false, nil).Times(1)
+	filmStorage.EXPECT().Batch(ctx, domain.BatchModeBestEffort, []*domain.FilmBatchOperation{{Type: domain.BatchOperationDelete, Id: domain.FilmId(2)}}, nil).Return(
+		[]*domain.FilmBatchItem{{Index: 0, Type: domain.BatchOperationDelete, Id: domain.FilmId(2), Status: domain.BatchItemSucceeded}}, true, nil,
+	).Times(1)
+
+	filmService := domain.NewFilmService(filmStorage, actorStorage, genreStorage, filmListStorage, logsBuilder)
+	report, err := filmService.Batch(ctx, domain.BatchModeBestEffort, operations)
+	require.NoError(t, err)
+	require.True(t, report.Committed)
+
+	require.Equal(t, domain.BatchItemFailed, report.Items[0].Status)
+	require.ErrorIs(t, report.Items[0].Err, domain.ErrFilmActorsNotFound)
+
+	require.Equal(t, 1, report.Items[1].Index)
+	require.Equal(t, domain.BatchItemSucceeded, report.Items[1].Status)
+}
+
+func TestBatchTransactionalAbortsOnInvalidOperation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filmStorage := mocks.NewMockFilmStorage(ctrl)
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	filmListStorage := mocks.NewMockFilmListStorage(ctrl)
+
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	version := int64(1)
+	operations := []*domain.FilmBatchOperation{
+		{Type: domain.BatchOperationDelete, Id: domain.FilmId(1)},
+		{Type: domain.BatchOperationUpdate, Id: domain.FilmId(2), Update: &domain.FilmUpdateParams{
+			Credits: &[]*domain.FilmCreditParams{{ActorId: 1, Role: domain.CreditRoleDirector, CharacterName: "Character"}},
+		}},
+		{Type: domain.BatchOperationCreate, Version: &version, Create: &domain.FilmCreateParams{Title: "Title_1"}},
+	}
+
+	filmService := domain.NewFilmService(filmStorage, actorStorage, genreStorage, filmListStorage, logsBuilder)
+	report, err := filmService.Batch(ctx, domain.BatchModeTransactional, operations)
+	require.NoError(t, err)
+	require.False(t, report.Committed)
+
+	require.Equal(t, domain.BatchItemAborted, report.Items[0].Status)
+	require.NoError(t, report.Items[0].Err)
+
+	require.Equal(t, domain.BatchItemFailed, report.Items[1].Status)
+	require.ErrorIs(t, report.Items[1].Err, domain.ErrInvalidFilmCredit)
+
+	// A created film has no version to check
+	require.Equal(t, domain.BatchItemFailed, report.Items[2].Status)
+	require.ErrorIs(t, report.Items[2].Err, domain.ErrInvalidBatch)
+}
+
+func TestBatchError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filmStorage := mocks.NewMockFilmStorage(ctrl)
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	filmListStorage := mocks.NewMockFilmListStorage(ctrl)
+
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	deleteOperation := []*domain.FilmBatchOperation{{Type: domain.BatchOperationDelete, Id: domain.FilmId(1)}}
+
+	tooManyOperations := make([]*domain.FilmBatchOperation, domain.MaxBatchOperations+1)
+	for i := range tooManyOperations {
+		tooManyOperations[i] = deleteOperation[0]
+	}
+
+	filmService := domain.NewFilmService(filmStorage, actorStorage, genreStorage, filmListStorage, logsBuilder)
+
+	testCases := []struct {
+		name       string
+		mode       domain.BatchMode
+		operations []*domain.FilmBatchOperation
+	}{
+		{
+			name:       "err_unexpected_mode",
+			mode:       domain.BatchMode("some"),
+			operations: deleteOperation,
+		},
+		{
+			name: "err_no_operations",
+			mode: domain.BatchModeTransactional,
+		},
+		{
+			name:       "err_too_many_operations",
+			mode:       domain.BatchModeTransactional,
+			operations: tooManyOperations,
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			report, err := filmService.Batch(ctx, tCase.mode, tCase.operations)
+			require.ErrorIs(t, err, domain.ErrInvalidBatch)
+			require.Nil(t, report)
+		})
+	}
+
+	t.Run("err_storage", func(t *testing.T) {
+		fakeError := errors.New("database is down")
+
+		filmStorage.EXPECT().Batch(ctx, domain.BatchModeTransactional, deleteOperation, nil).Return(nil, false, fakeError).Times(1)
+
+		report, err := filmService.Batch(ctx, domain.BatchModeTransactional, deleteOperation)
+		require.ErrorIs(t, err, fakeError)
+		require.Nil(t, report)
+	})
+}
+
+func TestUpdateAndDeleteVersionMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filmStorage := mocks.NewMockFilmStorage(ctrl)
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	filmListStorage := mocks.NewMockFilmListStorage(ctrl)
+
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	filmId := domain.FilmId(1)
+	filmRating := domain.FilmRating(9)
+	version := int64(2)
+
+	versionMismatchErr := fmt.Errorf("failed to update a film: %w", storage.ErrFilmVersionMismatch)
+
+	filmStorage.EXPECT().IsExists(ctx, filmId).Return(true, nil).Times(2)
+	filmStorage.EXPECT().Update(ctx, filmId, &version, nil, nil, nil, &filmRating, nil, nil, nil, nil).Return(nil, versionMismatchErr).Times(1)
+	filmStorage.EXPECT().Delete(ctx, filmId, &version, nil).Return(versionMismatchErr).Times(1)
+
+	filmService := domain.NewFilmService(filmStorage, actorStorage, genreStorage, filmListStorage, logsBuilder)
+
+	film, err := filmService.Update(ctx, filmId, &version, nil, nil, nil, &filmRating, nil, nil, nil)
+	require.ErrorIs(t, err, domain.ErrFilmVersionMismatch)
+	require.Nil(t, film)
+
+	err = filmService.Delete(ctx, filmId, &version)
+	require.ErrorIs(t, err, domain.ErrFilmVersionMismatch)
+}
+
+func TestRestore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filmStorage := mocks.NewMockFilmStorage(ctrl)
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	filmListStorage := mocks.NewMockFilmListStorage(ctrl)
+
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	filmId := domain.FilmId(1)
+
+	expected := &domain.Film{
+		Id:          filmId,
+		Title:       domain.FilmTitle("Title_1"),
+		Description: domain.FilmDescription("Description_1"),
+		ReleaseDate: domain.FilmReleaseDate(time.Now()),
+		Rating:      domain.FilmRating(10),
+		Actors: []*domain.Actor{
+			{
+				Id:        domain.ActorId(1),
+				Name:      domain.ActorName("Actor_1"),
+				Sex:       domain.ActorSex(0),
+				BirthDate: domain.ActorBirthDate(time.Now()),
+			},
+		},
+		Version: 3,
+	}
+
+	filmStorage.EXPECT().Restore(ctx, filmId, nil).Return(expected, nil).Times(1)
+
+	filmService := domain.NewFilmService(filmStorage, actorStorage, genreStorage, filmListStorage, logsBuilder)
+	film, err := filmService.Restore(ctx, filmId)
+	require.NoError(t, err)
+	require.Equal(t, expected, film)
+}
+
+func TestRestoreError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filmStorage := mocks.NewMockFilmStorage(ctrl)
+	actorStorage := mocks.NewMockActorStorage(ctrl)
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	filmListStorage := mocks.NewMockFilmListStorage(ctrl)
+
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	filmService := domain.NewFilmService(filmStorage, actorStorage, genreStorage, filmListStorage, logsBuilder)
+
+	otherErr := fmt.Errorf("failed to restore film: %w", errors.New("database is down"))
+
+	testCases := []struct {
+		name          string
+		in            domain.FilmId
+		restoreExpErr error
+		expectedErr   error
+	}{
+		{
+			name:          "err_film_not_in_trash",
+			in:            domain.FilmId(1),
+			restoreExpErr: fmt.Errorf("failed to restore film: %w", storage.ErrFilmNotFound),
+			expectedErr:   domain.ErrFilmNotFound,
+		},
+		{
+			name:          "err_other",
+			in:            domain.FilmId(2),
+			restoreExpErr: otherErr,
+			expectedErr:   otherErr,
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			filmStorage.EXPECT().Restore(ctx, tCase.in, nil).Return(nil, tCase.restoreExpErr).Times(1)
+			film, err := filmService.Restore(ctx, tCase.in)
+			require.ErrorIs(t, err, tCase.expectedErr)
+			require.Nil(t, film)
+		})
+	}
 }
@@ -0,0 +1,57 @@
+package domain_test
+
+import (
+	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"testing"
+)
+
+func TestNewFilmSort(t *testing.T) {
+	keys := []domain.FilmSortKey{
+		{Field: domain.FilmSortFieldRating, Direction: domain.SortDirectionDesc},
+		{Field: domain.FilmSortFieldTitle, Direction: domain.SortDirectionAsc},
+		{Field: domain.FilmSortFieldActorsCount, Direction: domain.SortDirectionDesc},
+		{Field: domain.FilmSortFieldReleaseDate, Direction: domain.SortDirectionAsc},
+		{Field: domain.FilmSortFieldCommunityScore, Direction: domain.SortDirectionDesc},
+		{Field: domain.FilmSortFieldId, Direction: domain.SortDirectionDesc},
+	}
+
+	sort, err := domain.NewFilmSort(keys...)
+	require.NoError(t, err)
+	require.Equal(t, domain.FilmSort(keys), sort)
+}
+
+func TestNewFilmSortError(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []domain.FilmSortKey
+	}{
+		{
+			name: "err_unknown_field",
+			keys: []domain.FilmSortKey{
+				{Field: domain.FilmSortField("title;DROP TABLE films"), Direction: domain.SortDirectionAsc},
+			},
+		},
+		{
+			name: "err_unknown_direction",
+			keys: []domain.FilmSortKey{
+				{Field: domain.FilmSortFieldTitle, Direction: domain.SortDirection("desc;DROP TABLE films")},
+			},
+		},
+		{
+			name: "err_repeated_field",
+			keys: []domain.FilmSortKey{
+				{Field: domain.FilmSortFieldTitle, Direction: domain.SortDirectionAsc},
+				{Field: domain.FilmSortFieldTitle, Direction: domain.SortDirectionDesc},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sort, err := domain.NewFilmSort(tt.keys...)
+			require.ErrorIs(t, err, domain.ErrInvalidFilmSort)
+			require.Nil(t, sort)
+		})
+	}
+}
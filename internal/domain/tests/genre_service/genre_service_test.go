@@ -0,0 +1,279 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	mocks "github.com/vaberof/vk-internship-task/internal/domain/mocks"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"github.com/vaberof/vk-internship-task/pkg/logging/logs"
+	"go.uber.org/mock/gomock"
+	"os"
+	"testing"
+)
+
+func TestCreate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	genreName := domain.GenreName("Drama")
+
+	expected := &domain.Genre{
+		Id:   domain.GenreId(1),
+		Name: genreName,
+	}
+
+	genreStorage.EXPECT().Create(ctx, genreName).Return(expected, nil).Times(1)
+
+	genreService := domain.NewGenreService(genreStorage, logsBuilder)
+	genre, err := genreService.Create(ctx, genreName)
+	require.NoError(t, err)
+	require.Equal(t, expected, genre)
+}
+
+func TestCreateError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	genreService := domain.NewGenreService(genreStorage, logsBuilder)
+
+	testCases := []struct {
+		name         string
+		in           domain.GenreName
+		createErr    error
+		createExpErr error
+	}{
+		{
+			name:         "err_genre_already_exists",
+			in:           domain.GenreName("Drama"),
+			createErr:    fmt.Errorf("failed to create a genre: %w", storage.ErrGenreAlreadyExists),
+			createExpErr: domain.ErrGenreAlreadyExists,
+		},
+		{
+			name:         "err_other",
+			in:           domain.GenreName("Comedy"),
+			createErr:    fmt.Errorf("failed to create a genre: %w", errors.New("database is down")),
+			createExpErr: fmt.Errorf("failed to create a genre: %w", errors.New("database is down")),
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			genreStorage.EXPECT().Create(ctx, tCase.in).Return(nil, tCase.createErr).Times(1)
+			genre, err := genreService.Create(ctx, tCase.in)
+			require.EqualError(t, err, tCase.createExpErr.Error())
+			require.Nil(t, genre)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	genreId := domain.GenreId(1)
+	genreName := domain.GenreName("Drama")
+
+	expected := &domain.Genre{
+		Id:   genreId,
+		Name: genreName,
+	}
+
+	genreStorage.EXPECT().Update(ctx, genreId, genreName).Return(expected, nil).Times(1)
+
+	genreService := domain.NewGenreService(genreStorage, logsBuilder)
+	genre, err := genreService.Update(ctx, genreId, genreName)
+	require.NoError(t, err)
+	require.Equal(t, expected, genre)
+}
+
+func TestUpdateError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	genreService := domain.NewGenreService(genreStorage, logsBuilder)
+
+	testCases := []struct {
+		name         string
+		id           domain.GenreId
+		updateErr    error
+		updateExpErr error
+	}{
+		{
+			name:         "err_genre_not_found",
+			id:           domain.GenreId(1),
+			updateErr:    fmt.Errorf("failed to update a genre: %w", storage.ErrGenreNotFound),
+			updateExpErr: domain.ErrGenreNotFound,
+		},
+		{
+			name:         "err_genre_already_exists",
+			id:           domain.GenreId(2),
+			updateErr:    fmt.Errorf("failed to update a genre: %w", storage.ErrGenreAlreadyExists),
+			updateExpErr: domain.ErrGenreAlreadyExists,
+		},
+		{
+			name:         "err_other",
+			id:           domain.GenreId(3),
+			updateErr:    fmt.Errorf("failed to update a genre: %w", errors.New("database is down")),
+			updateExpErr: fmt.Errorf("failed to update a genre: %w", errors.New("database is down")),
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			genreStorage.EXPECT().Update(ctx, tCase.id, domain.GenreName("Drama")).Return(nil, tCase.updateErr).Times(1)
+			genre, err := genreService.Update(ctx, tCase.id, domain.GenreName("Drama"))
+			require.EqualError(t, err, tCase.updateExpErr.Error())
+			require.Nil(t, genre)
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	genreId := domain.GenreId(1)
+
+	genreStorage.EXPECT().Delete(ctx, genreId).Return(nil).Times(1)
+
+	genreService := domain.NewGenreService(genreStorage, logsBuilder)
+	err := genreService.Delete(ctx, genreId)
+	require.NoError(t, err)
+}
+
+func TestDeleteError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	genreService := domain.NewGenreService(genreStorage, logsBuilder)
+
+	testCases := []struct {
+		name         string
+		in           domain.GenreId
+		deleteErr    error
+		deleteExpErr error
+	}{
+		{
+			name:         "err_genre_not_found",
+			in:           domain.GenreId(1),
+			deleteErr:    fmt.Errorf("failed to delete genre: %w", storage.ErrGenreNotFound),
+			deleteExpErr: domain.ErrGenreNotFound,
+		},
+		{
+			name:         "err_other",
+			in:           domain.GenreId(2),
+			deleteErr:    fmt.Errorf("failed to delete genre: %w", errors.New("database is down")),
+			deleteExpErr: fmt.Errorf("failed to delete genre: %w", errors.New("database is down")),
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			genreStorage.EXPECT().Delete(ctx, tCase.in).Return(tCase.deleteErr).Times(1)
+			err := genreService.Delete(ctx, tCase.in)
+			require.EqualError(t, err, tCase.deleteExpErr.Error())
+		})
+	}
+}
+
+func TestGetById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	expected := &domain.Genre{
+		Id:   domain.GenreId(1),
+		Name: domain.GenreName("Drama"),
+	}
+
+	genreStorage.EXPECT().GetById(ctx, expected.Id).Return(expected, nil).Times(1)
+
+	genreService := domain.NewGenreService(genreStorage, logsBuilder)
+	genre, err := genreService.GetById(ctx, expected.Id)
+	require.NoError(t, err)
+	require.Equal(t, expected, genre)
+}
+
+func TestGetByIdError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	genreId := domain.GenreId(1)
+
+	genreStorage.EXPECT().GetById(ctx, genreId).Return(nil, fmt.Errorf("failed to get genre: %w", storage.ErrGenreNotFound)).Times(1)
+
+	genreService := domain.NewGenreService(genreStorage, logsBuilder)
+	genre, err := genreService.GetById(ctx, genreId)
+	require.ErrorIs(t, err, domain.ErrGenreNotFound)
+	require.Nil(t, genre)
+}
+
+func TestList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	genreStorage := mocks.NewMockGenreStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	expected := []*domain.Genre{
+		{
+			Id:   domain.GenreId(2),
+			Name: domain.GenreName("Comedy"),
+		},
+		{
+			Id:   domain.GenreId(1),
+			Name: domain.GenreName("Drama"),
+		},
+	}
+
+	genreStorage.EXPECT().List(ctx).Return(expected, nil).Times(1)
+
+	genreService := domain.NewGenreService(genreStorage, logsBuilder)
+	genres, err := genreService.List(ctx)
+	require.NoError(t, err)
+	require.Equal(t, expected, genres)
+}
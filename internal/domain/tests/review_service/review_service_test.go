@@ -0,0 +1,273 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	mocks "github.com/vaberof/vk-internship-task/internal/domain/mocks"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"github.com/vaberof/vk-internship-task/internal/service/user"
+	"github.com/vaberof/vk-internship-task/pkg/logging/logs"
+	"go.uber.org/mock/gomock"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestCreate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reviewStorage := mocks.NewMockReviewStorage(ctrl)
+	filmStorage := mocks.NewMockFilmStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	principal := &user.Principal{Id: 1, Email: "user@example.com", Role: user.RoleUser}
+
+	ctx := user.ContextWithPrincipal(context.Background(), principal)
+
+	filmId := domain.FilmId(1)
+	rating := domain.ReviewRating(8)
+	text := domain.ReviewText("Great film")
+
+	expected := &domain.Review{
+		Id:        domain.ReviewId(1),
+		FilmId:    filmId,
+		UserId:    principal.Id,
+		Rating:    rating,
+		Text:      text,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	reviewStorage.EXPECT().Create(ctx, filmId, principal.Id, rating, text).Return(expected, nil).Times(1)
+
+	reviewService := domain.NewReviewService(reviewStorage, filmStorage, logsBuilder)
+	review, err := reviewService.Create(ctx, filmId, rating, text)
+	require.NoError(t, err)
+	require.Equal(t, expected, review)
+}
+
+func TestCreateError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reviewStorage := mocks.NewMockReviewStorage(ctrl)
+	filmStorage := mocks.NewMockFilmStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	principal := &user.Principal{Id: 1, Email: "user@example.com", Role: user.RoleUser}
+
+	ctx := user.ContextWithPrincipal(context.Background(), principal)
+
+	reviewService := domain.NewReviewService(reviewStorage, filmStorage, logsBuilder)
+
+	testCases := []struct {
+		name         string
+		filmId       domain.FilmId
+		createErr    error
+		createExpErr error
+	}{
+		{
+			name:         "err_film_not_found",
+			filmId:       domain.FilmId(1),
+			createErr:    fmt.Errorf("failed to create a review: %w", storage.ErrFilmNotFound),
+			createExpErr: domain.ErrFilmNotFound,
+		},
+		{
+			name:         "err_review_already_exists",
+			filmId:       domain.FilmId(2),
+			createErr:    fmt.Errorf("failed to create a review: %w", storage.ErrReviewAlreadyExists),
+			createExpErr: domain.ErrReviewAlreadyExists,
+		},
+		{
+			name:         "err_other",
+			filmId:       domain.FilmId(3),
+			createErr:    fmt.Errorf("failed to create a review: %w", errors.New("database is down")),
+			createExpErr: fmt.Errorf("failed to create a review: %w", errors.New("database is down")),
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			reviewStorage.EXPECT().Create(ctx, tCase.filmId, principal.Id, domain.ReviewRating(5), domain.ReviewText("")).Return(nil, tCase.createErr).Times(1)
+			review, err := reviewService.Create(ctx, tCase.filmId, 5, "")
+			require.EqualError(t, err, tCase.createExpErr.Error())
+			require.Nil(t, review)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reviewStorage := mocks.NewMockReviewStorage(ctrl)
+	filmStorage := mocks.NewMockFilmStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	principal := &user.Principal{Id: 1, Email: "user@example.com", Role: user.RoleUser}
+
+	ctx := user.ContextWithPrincipal(context.Background(), principal)
+
+	reviewId := domain.ReviewId(1)
+	rating := domain.ReviewRating(9)
+
+	existing := &domain.Review{Id: reviewId, FilmId: 1, UserId: principal.Id, Rating: 8}
+	expected := &domain.Review{Id: reviewId, FilmId: 1, UserId: principal.Id, Rating: rating}
+
+	reviewStorage.EXPECT().GetById(ctx, reviewId).Return(existing, nil).Times(1)
+	reviewStorage.EXPECT().Update(ctx, reviewId, &rating, nil).Return(expected, nil).Times(1)
+
+	reviewService := domain.NewReviewService(reviewStorage, filmStorage, logsBuilder)
+	review, err := reviewService.Update(ctx, reviewId, &rating, nil)
+	require.NoError(t, err)
+	require.Equal(t, expected, review)
+}
+
+func TestUpdateError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reviewStorage := mocks.NewMockReviewStorage(ctrl)
+	filmStorage := mocks.NewMockFilmStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	principal := &user.Principal{Id: 1, Email: "user@example.com", Role: user.RoleUser}
+
+	ctx := user.ContextWithPrincipal(context.Background(), principal)
+
+	reviewService := domain.NewReviewService(reviewStorage, filmStorage, logsBuilder)
+
+	testCases := []struct {
+		name         string
+		id           domain.ReviewId
+		review       *domain.Review
+		getErr       error
+		updateErr    error
+		updateExpErr error
+	}{
+		{
+			name:         "err_review_not_found",
+			id:           domain.ReviewId(1),
+			getErr:       fmt.Errorf("failed to get review: %w", storage.ErrReviewNotFound),
+			updateExpErr: domain.ErrReviewNotFound,
+		},
+		{
+			name:         "err_review_forbidden",
+			id:           domain.ReviewId(2),
+			review:       &domain.Review{Id: 2, FilmId: 1, UserId: principal.Id + 1},
+			updateExpErr: domain.ErrReviewForbidden,
+		},
+		{
+			name:         "err_other",
+			id:           domain.ReviewId(3),
+			review:       &domain.Review{Id: 3, FilmId: 1, UserId: principal.Id},
+			updateErr:    fmt.Errorf("failed to update a review: %w", errors.New("database is down")),
+			updateExpErr: fmt.Errorf("failed to update a review: %w", errors.New("database is down")),
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			reviewStorage.EXPECT().GetById(ctx, tCase.id).Return(tCase.review, tCase.getErr).Times(1)
+			reviewStorage.EXPECT().Update(ctx, tCase.id, nil, nil).Return(nil, tCase.updateErr).AnyTimes()
+			review, err := reviewService.Update(ctx, tCase.id, nil, nil)
+			require.EqualError(t, err, tCase.updateExpErr.Error())
+			require.Nil(t, review)
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reviewStorage := mocks.NewMockReviewStorage(ctrl)
+	filmStorage := mocks.NewMockFilmStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	principal := &user.Principal{Id: 1, Email: "user@example.com", Role: user.RoleUser}
+
+	ctx := user.ContextWithPrincipal(context.Background(), principal)
+
+	reviewId := domain.ReviewId(1)
+
+	reviewStorage.EXPECT().GetById(ctx, reviewId).Return(&domain.Review{Id: reviewId, FilmId: 1, UserId: principal.Id}, nil).Times(1)
+	reviewStorage.EXPECT().Delete(ctx, reviewId).Return(nil).Times(1)
+
+	reviewService := domain.NewReviewService(reviewStorage, filmStorage, logsBuilder)
+	err := reviewService.Delete(ctx, reviewId)
+	require.NoError(t, err)
+}
+
+func TestDeleteForbiddenError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reviewStorage := mocks.NewMockReviewStorage(ctrl)
+	filmStorage := mocks.NewMockFilmStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	principal := &user.Principal{Id: 1, Email: "admin@example.com", Role: user.RoleAdmin}
+
+	ctx := user.ContextWithPrincipal(context.Background(), principal)
+
+	reviewId := domain.ReviewId(1)
+
+	reviewStorage.EXPECT().GetById(ctx, reviewId).Return(&domain.Review{Id: reviewId, FilmId: 1, UserId: principal.Id + 1}, nil).Times(1)
+
+	reviewService := domain.NewReviewService(reviewStorage, filmStorage, logsBuilder)
+	err := reviewService.Delete(ctx, reviewId)
+	require.ErrorIs(t, err, domain.ErrReviewForbidden)
+}
+
+func TestListByFilmId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reviewStorage := mocks.NewMockReviewStorage(ctrl)
+	filmStorage := mocks.NewMockFilmStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	filmId := domain.FilmId(1)
+	limit, offset := 10, 0
+
+	expected := []*domain.Review{
+		{Id: 2, FilmId: filmId, UserId: 2, Rating: 7},
+		{Id: 1, FilmId: filmId, UserId: 1, Rating: 9},
+	}
+
+	filmStorage.EXPECT().IsExists(ctx, filmId).Return(true, nil).Times(1)
+	reviewStorage.EXPECT().ListByFilmId(ctx, filmId, limit, offset).Return(expected, nil).Times(1)
+	reviewStorage.EXPECT().CountByFilmId(ctx, filmId).Return(int64(2), nil).Times(1)
+
+	reviewService := domain.NewReviewService(reviewStorage, filmStorage, logsBuilder)
+	reviewsPage, err := reviewService.ListByFilmId(ctx, filmId, limit, offset)
+	require.NoError(t, err)
+	require.Equal(t, &domain.ReviewsPage{Reviews: expected, Total: 2}, reviewsPage)
+}
+
+func TestListByFilmIdFilmNotFoundError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reviewStorage := mocks.NewMockReviewStorage(ctrl)
+	filmStorage := mocks.NewMockFilmStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	filmId := domain.FilmId(1)
+
+	filmStorage.EXPECT().IsExists(ctx, filmId).Return(false, nil).Times(1)
+
+	reviewService := domain.NewReviewService(reviewStorage, filmStorage, logsBuilder)
+	reviewsPage, err := reviewService.ListByFilmId(ctx, filmId, 10, 0)
+	require.ErrorIs(t, err, domain.ErrFilmNotFound)
+	require.Nil(t, reviewsPage)
+}
@@ -0,0 +1,178 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	mocks "github.com/vaberof/vk-internship-task/internal/domain/mocks"
+	"github.com/vaberof/vk-internship-task/pkg/logging/logs"
+	"go.uber.org/mock/gomock"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	trashStorage := mocks.NewMockTrashStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	entityType := domain.TrashEntityTypeFilm
+	adminId := int64(2)
+	deletedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	filter := &domain.TrashFilter{EntityType: &entityType}
+
+	trashStorage.EXPECT().List(ctx, filter, 100, 0).Return([]*domain.TrashItem{
+		{
+			EntityType: domain.TrashEntityTypeFilm,
+			EntityId:   1,
+			Name:       "Title_1",
+			DeletedAt:  deletedAt,
+			DeletedBy:  &adminId,
+		},
+	}, nil).Times(1)
+	trashStorage.EXPECT().Count(ctx, filter).Return(int64(1), nil).Times(1)
+
+	trashService := domain.NewTrashService(trashStorage, &domain.TrashConfig{Retention: 720 * time.Hour, PurgeInterval: time.Hour}, logsBuilder)
+	trashPage, err := trashService.List(ctx, filter, 100, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), trashPage.Total)
+	require.Len(t, trashPage.Items, 1)
+	require.Equal(t, int64(1), trashPage.Items[0].EntityId)
+	require.Equal(t, &adminId, trashPage.Items[0].DeletedBy)
+	require.NotNil(t, trashPage.Items[0].PurgeAt)
+	require.Equal(t, deletedAt.Add(720*time.Hour), *trashPage.Items[0].PurgeAt)
+}
+
+func TestListWithoutRetention(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	trashStorage := mocks.NewMockTrashStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	filter := &domain.TrashFilter{}
+
+	trashStorage.EXPECT().List(ctx, filter, 10, 0).Return([]*domain.TrashItem{
+		{
+			EntityType: domain.TrashEntityTypeActor,
+			EntityId:   1,
+			Name:       "Actor_1",
+			DeletedAt:  time.Now(),
+		},
+	}, nil).Times(1)
+	trashStorage.EXPECT().Count(ctx, filter).Return(int64(1), nil).Times(1)
+
+	trashService := domain.NewTrashService(trashStorage, &domain.TrashConfig{}, logsBuilder)
+	trashPage, err := trashService.List(ctx, filter, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, trashPage.Items, 1)
+	require.Nil(t, trashPage.Items[0].PurgeAt)
+}
+
+func TestListError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	trashStorage := mocks.NewMockTrashStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	unknownEntityType := domain.TrashEntityType("genre")
+
+	expectedErr := fmt.Errorf("failed to list trash: %w", errors.New("database is down"))
+
+	testCases := []struct {
+		name           string
+		filter         *domain.TrashFilter
+		listExpErr     error
+		expectedErr    error
+		expectsListing bool
+	}{
+		{
+			name:        "err_unknown_entity_type",
+			filter:      &domain.TrashFilter{EntityType: &unknownEntityType},
+			expectedErr: domain.ErrInvalidTrashFilter,
+		},
+		{
+			name:           "err_other",
+			filter:         &domain.TrashFilter{},
+			listExpErr:     expectedErr,
+			expectedErr:    expectedErr,
+			expectsListing: true,
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			if tCase.expectsListing {
+				trashStorage.EXPECT().List(ctx, tCase.filter, 100, 0).Return(nil, tCase.listExpErr).Times(1)
+			}
+
+			trashService := domain.NewTrashService(trashStorage, &domain.TrashConfig{}, logsBuilder)
+			trashPage, err := trashService.List(ctx, tCase.filter, 100, 0)
+			require.ErrorIs(t, err, tCase.expectedErr)
+			require.Nil(t, trashPage)
+		})
+	}
+}
+
+func TestPurge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	trashStorage := mocks.NewMockTrashStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	retention := 720 * time.Hour
+
+	var deletedBefore time.Time
+	trashStorage.EXPECT().Purge(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, before time.Time) (int64, int64, error) {
+		deletedBefore = before
+		return 2, 1, nil
+	}).Times(1)
+
+	trashService := domain.NewTrashService(trashStorage, &domain.TrashConfig{Retention: retention, PurgeInterval: time.Hour}, logsBuilder)
+	require.NoError(t, trashService.Purge(ctx))
+	require.WithinDuration(t, time.Now().Add(-retention), deletedBefore, time.Minute)
+}
+
+func TestPurgeWithoutRetention(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	trashStorage := mocks.NewMockTrashStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	trashService := domain.NewTrashService(trashStorage, &domain.TrashConfig{}, logsBuilder)
+	require.NoError(t, trashService.Purge(context.Background()))
+}
+
+func TestPurgeError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	trashStorage := mocks.NewMockTrashStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	expectedErr := fmt.Errorf("failed to purge films: %w", errors.New("database is down"))
+
+	trashStorage.EXPECT().Purge(ctx, gomock.Any()).Return(int64(0), int64(0), expectedErr).Times(1)
+
+	trashService := domain.NewTrashService(trashStorage, &domain.TrashConfig{Retention: time.Hour, PurgeInterval: time.Hour}, logsBuilder)
+	require.ErrorIs(t, trashService.Purge(ctx), expectedErr)
+}
@@ -0,0 +1,51 @@
+package domain
+
+import "time"
+
+type TrashEntityType string
+
+func (trashEntityType *TrashEntityType) String() string {
+	return string(*trashEntityType)
+}
+
+func (trashEntityType *TrashEntityType) IsValid() bool {
+	switch *trashEntityType {
+	case TrashEntityTypeFilm, TrashEntityTypeActor:
+		return true
+	default:
+		return false
+	}
+}
+
+const (
+	TrashEntityTypeFilm  = TrashEntityType("film")
+	TrashEntityTypeActor = TrashEntityType("actor")
+)
+
+// TrashItem is a deleted film or actor. Name is the title of the film or the name of the actor.
+// PurgeAt is the time after which the retention job deletes the item for good, it is nil if the trash is never purged.
+type TrashItem struct {
+	EntityType TrashEntityType
+	EntityId   int64
+	Name       string
+	DeletedAt  time.Time
+	DeletedBy  *int64
+	PurgeAt    *time.Time
+}
+
+// TrashFilter narrows down the listed trash items. Nil fields are not filtered by.
+type TrashFilter struct {
+	EntityType *TrashEntityType
+}
+
+type TrashPage struct {
+	Items []*TrashItem
+	Total int64
+}
+
+// TrashConfig sets how long deleted films and actors stay in the trash and how often the trash is purged.
+// Zero retention keeps them forever.
+type TrashConfig struct {
+	Retention     time.Duration `yaml:"retention"`
+	PurgeInterval time.Duration `yaml:"purge-interval"`
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"github.com/vaberof/vk-internship-task/pkg/logging/logs"
+	"log/slog"
+	"time"
+)
+
+var (
+	ErrInvalidTrashFilter = errors.New("invalid trash filter")
+)
+
+type TrashService interface {
+	List(ctx context.Context, filter *TrashFilter, limit, offset int) (*TrashPage, error)
+	// Purge deletes for good films and actors that have been in the trash longer than the retention
+	Purge(ctx context.Context) error
+}
+
+type trashServiceImpl struct {
+	trashStorage TrashStorage
+	config       *TrashConfig
+
+	logger *slog.Logger
+}
+
+func NewTrashService(trashStorage TrashStorage, config *TrashConfig, logsBuilder *logs.Logs) TrashService {
+	logger := logsBuilder.WithName("domain.service.trash")
+	return &trashServiceImpl{
+		trashStorage: trashStorage,
+		config:       config,
+		logger:       logger,
+	}
+}
+
+func (t *trashServiceImpl) List(ctx context.Context, filter *TrashFilter, limit, offset int) (*TrashPage, error) {
+	const operation = "List"
+
+	log := t.logger.With(
+		slog.String("operation", operation),
+		slog.Int("limit", limit),
+		slog.Int("offset", offset),
+	)
+
+	log.Info("listing trash")
+
+	if filter.EntityType != nil && !filter.EntityType.IsValid() {
+		log.Warn("failed to list trash", "error", "unexpected entity type '"+filter.EntityType.String()+"'")
+		return nil, ErrInvalidTrashFilter
+	}
+
+	items, err := t.trashStorage.List(ctx, filter, limit, offset)
+	if err != nil {
+		log.Error("failed to list trash", "error", err)
+		return nil, err
+	}
+
+	total, err := t.trashStorage.Count(ctx, filter)
+	if err != nil {
+		log.Error("failed to count trash", "error", err)
+		return nil, err
+	}
+
+	if t.config.Retention > 0 {
+		for _, item := range items {
+			purgeAt := item.DeletedAt.Add(t.config.Retention)
+			item.PurgeAt = &purgeAt
+		}
+	}
+
+	log.Info("trash has listed")
+
+	return &TrashPage{
+		Items: items,
+		Total: total,
+	}, nil
+}
+
+func (t *trashServiceImpl) Purge(ctx context.Context) error {
+	const operation = "Purge"
+
+	log := t.logger.With(
+		slog.String("operation", operation),
+		slog.Duration("retention", t.config.Retention),
+	)
+
+	if t.config.Retention <= 0 {
+		return nil
+	}
+
+	log.Info("purging trash")
+
+	purgedFilms, purgedActors, err := t.trashStorage.Purge(ctx, time.Now().Add(-t.config.Retention))
+	if err != nil {
+		log.Error("failed to purge trash", "error", err)
+		return err
+	}
+
+	log.Info("trash has purged", "films", purgedFilms, "actors", purgedActors)
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// TrashStorage keeps deleted films and actors, the most recently deleted are listed first.
+// Purge deletes films and actors deleted before the given time for good with all their relations
+// and returns the number of purged films and actors.
+type TrashStorage interface {
+	List(ctx context.Context, filter *TrashFilter, limit, offset int) ([]*TrashItem, error)
+	Count(ctx context.Context, filter *TrashFilter) (int64, error)
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, int64, error)
+}
@@ -3,8 +3,25 @@ package storage
 import "errors"
 
 var (
-	ErrUserNotFound = errors.New("user not found")
+	ErrUserNotFound      = errors.New("user not found")
+	ErrUserAlreadyExists = errors.New("user already exists")
+	ErrRoleNotFound      = errors.New("role not found")
 
-	ErrActorNotFound = errors.New("actor not found")
-	ErrFilmNotFound  = errors.New("film not found")
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+
+	ErrActorNotFound        = errors.New("actor not found")
+	ErrActorVersionMismatch = errors.New("actor version mismatch")
+	ErrFilmNotFound         = errors.New("film not found")
+	ErrFilmVersionMismatch  = errors.New("film version mismatch")
+
+	ErrActorRevisionNotFound = errors.New("actor revision not found")
+	ErrFilmRevisionNotFound  = errors.New("film revision not found")
+
+	ErrGenreNotFound      = errors.New("genre not found")
+	ErrGenreAlreadyExists = errors.New("genre already exists")
+
+	ErrFilmListItemNotFound = errors.New("film is not in the list")
+
+	ErrReviewNotFound      = errors.New("review not found")
+	ErrReviewAlreadyExists = errors.New("review already exists")
 )
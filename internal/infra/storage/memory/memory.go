@@ -0,0 +1,180 @@
+// Package memory keeps the catalog, its users and everything related to them in the memory of the process.
+// The storages have the same semantics as the postgres ones, so that the application runs without a database
+// and its tests need no Postgres server. Nothing is persisted: the data is lost when the process stops.
+package memory
+
+import (
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/service/user"
+	"sync"
+	"time"
+)
+
+// Db is an in-memory database. Storages created over the same Db share its tables, like storages over the same
+// Postgres database, so that changes of films and actors cascade across all storages.
+// A single lock guards the tables: readers share it and writers hold it exclusively.
+type Db struct {
+	mu     sync.RWMutex
+	tables *tables
+}
+
+// NewDb creates a database seeded like migrations/postgres: the roles with their permissions and two users,
+// 1 - user@example.com with the 'user' role and 2 - admin@example.com with the 'admin' role, both with the password 'asdf1234'
+func NewDb() *Db {
+	return &Db{tables: newSeededTables()}
+}
+
+type filmGenreKey struct {
+	filmId  int64
+	genreId int64
+}
+
+type userFilmListKey struct {
+	userId int64
+	list   domain.FilmList
+	filmId int64
+}
+
+// sequences hold the last ids given to the rows of the tables
+type sequences struct {
+	films         int64
+	actors        int64
+	filmsActors   int64
+	genres        int64
+	reviews       int64
+	auditEvents   int64
+	users         int64
+	refreshTokens int64
+}
+
+// tables mirror the tables of migrations/postgres. Rows are kept by value, so that a copy of the maps and slices
+// is a snapshot of the whole database, which transactional batches and imports are rolled back to.
+// Audit events and revisions are never changed once inserted, so snapshots share them.
+type tables struct {
+	films            map[int64]MemFilm
+	actors           map[int64]MemActor
+	filmsActors      []MemFilmCredit
+	genres           map[int64]MemGenre
+	filmsGenres      map[filmGenreKey]struct{}
+	reviews          map[int64]MemReview
+	userFilmLists    map[userFilmListKey]time.Time
+	auditEvents      []*domain.AuditEvent
+	filmRevisions    []*domain.FilmRevision
+	actorRevisions   []*domain.ActorRevision
+	users            map[int64]MemUser
+	refreshTokens    map[string]MemRefreshToken
+	rolesPermissions map[user.UserRole][]user.Permission
+	sequences        sequences
+}
+
+// seedPasswordHash is the bcrypt hash of 'asdf1234'
+const seedPasswordHash = "$2a$10$JT0HAAksN7kvv6m0TXAvIejUzNOs19uRA7Ae8qIjn5lLa2hP1isNK"
+
+func newSeededTables() *tables {
+	t := &tables{
+		films:         make(map[int64]MemFilm),
+		actors:        make(map[int64]MemActor),
+		genres:        make(map[int64]MemGenre),
+		filmsGenres:   make(map[filmGenreKey]struct{}),
+		reviews:       make(map[int64]MemReview),
+		userFilmLists: make(map[userFilmListKey]time.Time),
+		users:         make(map[int64]MemUser),
+		refreshTokens: make(map[string]MemRefreshToken),
+		rolesPermissions: map[user.UserRole][]user.Permission{
+			user.RoleUser: {
+				user.PermissionFilmsRead,
+				user.PermissionActorsRead,
+				user.PermissionGenresRead,
+				user.PermissionReviewsWrite,
+			},
+			user.RoleEditor: {
+				user.PermissionFilmsRead,
+				user.PermissionFilmsWrite,
+				user.PermissionActorsRead,
+				user.PermissionActorsWrite,
+				user.PermissionGenresRead,
+				user.PermissionGenresWrite,
+				user.PermissionReviewsWrite,
+			},
+			user.RoleAdmin: {
+				user.PermissionFilmsRead,
+				user.PermissionFilmsWrite,
+				user.PermissionFilmsDelete,
+				user.PermissionActorsRead,
+				user.PermissionActorsWrite,
+				user.PermissionActorsDelete,
+				user.PermissionUsersManage,
+				user.PermissionAuditRead,
+				user.PermissionGenresRead,
+				user.PermissionGenresWrite,
+				user.PermissionGenresDelete,
+				user.PermissionReviewsWrite,
+				user.PermissionCatalogImport,
+				user.PermissionCatalogExport,
+				user.PermissionTrashManage,
+			},
+		},
+	}
+
+	t.insertUser("user@example.com", seedPasswordHash, user.RoleUser)
+	t.insertUser("admin@example.com", seedPasswordHash, user.RoleAdmin)
+
+	return t
+}
+
+// snapshot copies the tables, so that the copy stays the same whatever happens with the tables afterwards
+func (t *tables) snapshot() *tables {
+	snapshot := *t
+
+	snapshot.films = make(map[int64]MemFilm, len(t.films))
+	for id, film := range t.films {
+		snapshot.films[id] = film
+	}
+	snapshot.actors = make(map[int64]MemActor, len(t.actors))
+	for id, actor := range t.actors {
+		snapshot.actors[id] = actor
+	}
+	snapshot.filmsActors = append([]MemFilmCredit(nil), t.filmsActors...)
+	snapshot.genres = make(map[int64]MemGenre, len(t.genres))
+	for id, genre := range t.genres {
+		snapshot.genres[id] = genre
+	}
+	snapshot.filmsGenres = make(map[filmGenreKey]struct{}, len(t.filmsGenres))
+	for key := range t.filmsGenres {
+		snapshot.filmsGenres[key] = struct{}{}
+	}
+	snapshot.reviews = make(map[int64]MemReview, len(t.reviews))
+	for id, review := range t.reviews {
+		snapshot.reviews[id] = review
+	}
+	snapshot.userFilmLists = make(map[userFilmListKey]time.Time, len(t.userFilmLists))
+	for key, addedAt := range t.userFilmLists {
+		snapshot.userFilmLists[key] = addedAt
+	}
+	snapshot.auditEvents = append([]*domain.AuditEvent(nil), t.auditEvents...)
+	snapshot.filmRevisions = append([]*domain.FilmRevision(nil), t.filmRevisions...)
+	snapshot.actorRevisions = append([]*domain.ActorRevision(nil), t.actorRevisions...)
+	snapshot.users = make(map[int64]MemUser, len(t.users))
+	for id, storedUser := range t.users {
+		snapshot.users[id] = storedUser
+	}
+	snapshot.refreshTokens = make(map[string]MemRefreshToken, len(t.refreshTokens))
+	for tokenHash, token := range t.refreshTokens {
+		snapshot.refreshTokens[tokenHash] = token
+	}
+
+	return &snapshot
+}
+
+// dateOf drops the time of day, like the 'DATE' columns do
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func copyInt64Ptr(value *int64) *int64 {
+	if value == nil {
+		return nil
+	}
+	copied := *value
+	return &copied
+}
@@ -0,0 +1,17 @@
+package memory
+
+import (
+	"time"
+)
+
+type MemActor struct {
+	Id        int64
+	Name      string
+	Sex       uint8
+	BirthDate time.Time
+	CreatedBy *int64
+	UpdatedBy *int64
+	Version   int64
+	DeletedAt *time.Time
+	DeletedBy *int64
+}
@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"sort"
+	"time"
+)
+
+type MemActorRevisionStorage struct {
+	db *Db
+}
+
+func NewMemActorRevisionStorage(db *Db) *MemActorRevisionStorage {
+	return &MemActorRevisionStorage{db: db}
+}
+
+// List lists revisions of an actor starting with the latest one
+func (s *MemActorRevisionStorage) List(ctx context.Context, actorId domain.ActorId, limit, offset int) ([]*domain.ActorRevision, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var revisions []*domain.ActorRevision
+	for _, revision := range s.db.tables.actorRevisions {
+		if revision.ActorId == actorId {
+			revisions = append(revisions, revision)
+		}
+	}
+
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision > revisions[j].Revision
+	})
+
+	start := min(offset, len(revisions))
+	end := min(start+limit, len(revisions))
+
+	var page []*domain.ActorRevision
+	for _, revision := range revisions[start:end] {
+		page = append(page, copyActorRevision(revision))
+	}
+
+	return page, nil
+}
+
+func (s *MemActorRevisionStorage) Count(ctx context.Context, actorId domain.ActorId) (int64, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var count int64
+	for _, revision := range s.db.tables.actorRevisions {
+		if revision.ActorId == actorId {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (s *MemActorRevisionStorage) Get(ctx context.Context, actorId domain.ActorId, revision int64) (*domain.ActorRevision, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	for _, actorRevision := range s.db.tables.actorRevisions {
+		if actorRevision.ActorId == actorId && actorRevision.Revision == revision {
+			return copyActorRevision(actorRevision), nil
+		}
+	}
+
+	return nil, fmt.Errorf("failed to get an actor revision: %w", storage.ErrActorRevisionNotFound)
+}
+
+// insertActorRevision is called by MemActorStorage along with the change that has produced the revision
+func (t *tables) insertActorRevision(revision *domain.ActorRevision) {
+	insertedRevision := copyActorRevision(revision)
+	insertedRevision.CreatedAt = time.Now()
+
+	t.actorRevisions = append(t.actorRevisions, insertedRevision)
+}
+
+func copyActorRevision(revision *domain.ActorRevision) *domain.ActorRevision {
+	copied := *revision
+	copied.UserId = copyInt64Ptr(revision.UserId)
+	return &copied
+}
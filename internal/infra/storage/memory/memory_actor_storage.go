@@ -0,0 +1,471 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"sort"
+	"strings"
+	"time"
+)
+
+type MemActorStorage struct {
+	db *Db
+}
+
+func NewMemActorStorage(db *Db) *MemActorStorage {
+	return &MemActorStorage{db: db}
+}
+
+func (s *MemActorStorage) Create(ctx context.Context, name domain.ActorName, sex domain.ActorSex, birthDate domain.ActorBirthDate, createdBy *int64) (*domain.Actor, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	return s.db.tables.createActor(name, sex, birthDate, createdBy)
+}
+
+func (s *MemActorStorage) Update(ctx context.Context, id domain.ActorId, version *int64, name *domain.ActorName, sex *domain.ActorSex, birthDate *domain.ActorBirthDate, updatedBy *int64) (*domain.Actor, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	return s.db.tables.updateActor(id, version, name, sex, birthDate, updatedBy)
+}
+
+func (s *MemActorStorage) Delete(ctx context.Context, id domain.ActorId, version *int64, deletedBy *int64) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	return s.db.tables.deleteActor(id, version, deletedBy)
+}
+
+// Restore brings the actor back from the trash into the films they had, and records the audit event
+func (s *MemActorStorage) Restore(ctx context.Context, id domain.ActorId, restoredBy *int64) (*domain.Actor, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	t := s.db.tables
+
+	actor, ok := t.actors[id.Int64()]
+	if !ok || actor.DeletedAt == nil {
+		return nil, fmt.Errorf("failed to restore actor: %w", storage.ErrActorNotFound)
+	}
+	if err := t.checkUser(restoredBy); err != nil {
+		return nil, fmt.Errorf("failed to restore actor: %w", err)
+	}
+
+	actor.DeletedAt = nil
+	actor.DeletedBy = nil
+	actor.UpdatedBy = copyInt64Ptr(restoredBy)
+	t.actors[actor.Id] = actor
+
+	domainActor := t.buildActor(&actor)
+
+	auditEvent, err := domain.NewActorAuditEvent(restoredBy, domain.AuditOperationRestore, nil, domainActor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore actor: %w", err)
+	}
+	t.insertAuditEvent(auditEvent)
+
+	return domainActor, nil
+}
+
+func (s *MemActorStorage) GetById(ctx context.Context, id domain.ActorId) (*domain.Actor, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	actor, ok := s.db.tables.actors[id.Int64()]
+	if !ok || actor.DeletedAt != nil {
+		return nil, fmt.Errorf("failed to get actor: %w", storage.ErrActorNotFound)
+	}
+
+	return s.db.tables.buildActor(&actor), nil
+}
+
+func (s *MemActorStorage) List(ctx context.Context, filter *domain.ActorFilter, sort domain.ActorSort, cursor *domain.ActorCursor, limit, offset int) ([]*domain.Actor, *domain.ActorCursor, error) {
+	order, err := buildActorOrder(sort)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list actors: %w", err)
+	}
+
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	actors, nextCursor := s.db.tables.listActors(filter, order, cursor, limit, offset)
+
+	return actors, nextCursor, nil
+}
+
+func (s *MemActorStorage) Count(ctx context.Context, filter *domain.ActorFilter) (int64, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	t := s.db.tables
+
+	var count int64
+	for _, actor := range t.actors {
+		if actor.DeletedAt == nil && t.matchActor(&actor, filter) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (s *MemActorStorage) IsExists(ctx context.Context, id domain.ActorId) (bool, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	actor, ok := s.db.tables.actors[id.Int64()]
+
+	return ok && actor.DeletedAt == nil, nil
+}
+
+func (s *MemActorStorage) AreExists(ctx context.Context, ids []domain.ActorId) (bool, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	// Ids are counted once each, like rows matching 'id=ANY($1)' are
+	existingIds := make(map[int64]struct{})
+	for _, id := range ids {
+		if actor, ok := s.db.tables.actors[id.Int64()]; ok && actor.DeletedAt == nil {
+			existingIds[id.Int64()] = struct{}{}
+		}
+	}
+
+	return len(existingIds) == len(ids), nil
+}
+
+// Export takes the actors with their films at once and calls fn after releasing the lock, like MemFilmStorage.Export
+func (s *MemActorStorage) Export(ctx context.Context, fn func(actor *domain.Actor) error) error {
+	s.db.mu.RLock()
+
+	t := s.db.tables
+
+	var actors []*domain.Actor
+	for _, id := range t.actorIds() {
+		actor := t.actors[id]
+		if actor.DeletedAt != nil {
+			continue
+		}
+		actors = append(actors, t.buildActor(&actor))
+	}
+
+	s.db.mu.RUnlock()
+
+	for _, actor := range actors {
+		if err := fn(actor); err != nil {
+			return fmt.Errorf("failed to export actors: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Batch applies every operation on its own, like MemFilmStorage.Batch
+func (s *MemActorStorage) Batch(ctx context.Context, mode domain.BatchMode, operations []*domain.ActorBatchOperation, by *int64) ([]*domain.ActorBatchItem, bool, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	var snapshot *tables
+	if mode == domain.BatchModeTransactional {
+		snapshot = s.db.tables.snapshot()
+	}
+
+	items := make([]*domain.ActorBatchItem, len(operations))
+	var failed bool
+
+	for i, operation := range operations {
+		item := &domain.ActorBatchItem{Index: i, Type: operation.Type, Id: operation.Id}
+		items[i] = item
+
+		item.Actor, item.Err = s.applyBatchOperation(s.db.tables, operation, by)
+		if item.Err != nil {
+			item.Status = domain.BatchItemFailed
+			item.Actor = nil
+			failed = true
+			continue
+		}
+		item.Status = domain.BatchItemSucceeded
+	}
+
+	if mode == domain.BatchModeTransactional && failed {
+		s.db.tables = snapshot
+		for _, item := range items {
+			if item.Status == domain.BatchItemSucceeded {
+				item.Status = domain.BatchItemAborted
+				item.Actor = nil
+			}
+		}
+		return items, false, nil
+	}
+
+	return items, true, nil
+}
+
+func (s *MemActorStorage) applyBatchOperation(t *tables, operation *domain.ActorBatchOperation, by *int64) (*domain.Actor, error) {
+	switch operation.Type {
+	case domain.BatchOperationCreate:
+		params := operation.Create
+		return t.createActor(params.Name, params.Sex, params.BirthDate, by)
+	case domain.BatchOperationUpdate:
+		params := operation.Update
+		return t.updateActor(operation.Id, operation.Version, params.Name, params.Sex, params.BirthDate, by)
+	case domain.BatchOperationDelete:
+		return nil, t.deleteActor(operation.Id, operation.Version, by)
+	default:
+		return nil, fmt.Errorf("unexpected batch operation '%s'", operation.Type)
+	}
+}
+
+// createActor creates the actor and records the audit event and the revision
+func (t *tables) createActor(name domain.ActorName, sex domain.ActorSex, birthDate domain.ActorBirthDate, createdBy *int64) (*domain.Actor, error) {
+	if err := t.checkUser(createdBy); err != nil {
+		return nil, fmt.Errorf("failed to create an actor: %w", err)
+	}
+
+	t.sequences.actors++
+	actor := MemActor{
+		Id:        t.sequences.actors,
+		Name:      name.String(),
+		Sex:       sex.Uint8(),
+		BirthDate: dateOf(birthDate.Time()),
+		CreatedBy: copyInt64Ptr(createdBy),
+		Version:   1,
+	}
+	t.actors[actor.Id] = actor
+
+	domainActor := t.buildActor(&actor)
+
+	auditEvent, err := domain.NewActorAuditEvent(createdBy, domain.AuditOperationCreate, nil, domainActor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create an actor: %w", err)
+	}
+	t.insertAuditEvent(auditEvent)
+	t.insertActorRevision(domain.NewActorRevision(domainActor, createdBy))
+
+	return domainActor, nil
+}
+
+// updateActor updates the actor and records the audit event and the revision
+func (t *tables) updateActor(id domain.ActorId, version *int64, name *domain.ActorName, sex *domain.ActorSex, birthDate *domain.ActorBirthDate, updatedBy *int64) (*domain.Actor, error) {
+	actor, err := t.checkActor(id, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update actor: %w", err)
+	}
+	if err = t.checkUser(updatedBy); err != nil {
+		return nil, fmt.Errorf("failed to update actor: %w", err)
+	}
+
+	actorBefore := t.buildActor(&actor)
+
+	if name != nil {
+		actor.Name = name.String()
+	}
+	if sex != nil {
+		actor.Sex = sex.Uint8()
+	}
+	if birthDate != nil {
+		actor.BirthDate = dateOf(birthDate.Time())
+	}
+	actor.UpdatedBy = copyInt64Ptr(updatedBy)
+	actor.Version++
+	t.actors[actor.Id] = actor
+
+	domainActor := t.buildActor(&actor)
+
+	auditEvent, err := domain.NewActorAuditEvent(updatedBy, domain.AuditOperationUpdate, actorBefore, domainActor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update actor: %w", err)
+	}
+	t.insertAuditEvent(auditEvent)
+	t.insertActorRevision(domain.NewActorRevision(domainActor, updatedBy))
+
+	return domainActor, nil
+}
+
+// deleteActor moves the actor to the trash and records the audit event.
+// Credits of the actor are kept, so that the actor is restored back into the films
+func (t *tables) deleteActor(id domain.ActorId, version *int64, deletedBy *int64) error {
+	actor, err := t.checkActor(id, version)
+	if err != nil {
+		return fmt.Errorf("failed to delete actor: %w", err)
+	}
+	if err = t.checkUser(deletedBy); err != nil {
+		return fmt.Errorf("failed to delete actor: %w", err)
+	}
+
+	auditEvent, err := domain.NewActorAuditEvent(deletedBy, domain.AuditOperationDelete, t.buildActor(&actor), nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete actor: %w", err)
+	}
+
+	deletedAt := time.Now()
+	actor.DeletedAt = &deletedAt
+	actor.DeletedBy = copyInt64Ptr(deletedBy)
+	t.actors[actor.Id] = actor
+
+	t.insertAuditEvent(auditEvent)
+
+	return nil
+}
+
+// checkActor returns the actor, if it is not deleted and has the given version, if the version is set
+func (t *tables) checkActor(id domain.ActorId, version *int64) (MemActor, error) {
+	actor, ok := t.actors[id.Int64()]
+	if !ok || actor.DeletedAt != nil {
+		return MemActor{}, storage.ErrActorNotFound
+	}
+	if version != nil && *version != actor.Version {
+		return MemActor{}, storage.ErrActorVersionMismatch
+	}
+	return actor, nil
+}
+
+// actorFilms returns the films the actor is credited in with the 'actor' role ordered by id, deleted films are skipped
+func (t *tables) actorFilms(actorId int64) []*domain.Film {
+	films := make([]*domain.Film, 0)
+	for _, credit := range t.filmsActors {
+		if credit.ActorId != actorId || domain.CreditRole(credit.Role) != domain.CreditRoleActor {
+			continue
+		}
+		if film := t.films[credit.FilmId]; film.DeletedAt == nil {
+			films = append(films, buildDomainActorFilm(&film))
+		}
+	}
+
+	sort.SliceStable(films, func(i, j int) bool {
+		return films[i].Id < films[j].Id
+	})
+
+	return films
+}
+
+// listActors lists the actors that are not deleted and match the filter, in the order and after the cursor, with their films
+func (t *tables) listActors(filter *domain.ActorFilter, order []actorOrderKey, cursor *domain.ActorCursor, limit, offset int) ([]*domain.Actor, *domain.ActorCursor) {
+	type listedActor struct {
+		actor  MemActor
+		values *actorKeyValues
+	}
+
+	var cursorValues *actorKeyValues
+	if cursor != nil {
+		cursorValues = buildActorCursorValues(cursor)
+	}
+
+	var listedActors []*listedActor
+	for _, actor := range t.actors {
+		if actor.DeletedAt != nil || !t.matchActor(&actor, filter) {
+			continue
+		}
+
+		values := &actorKeyValues{
+			id:         actor.Id,
+			name:       actor.Name,
+			birthDate:  actor.BirthDate,
+			filmsCount: int64(len(t.actorFilms(actor.Id))),
+		}
+		if cursorValues != nil && compareActors(order, values, cursorValues) <= 0 {
+			continue
+		}
+
+		listedActors = append(listedActors, &listedActor{actor: actor, values: values})
+	}
+
+	sort.Slice(listedActors, func(i, j int) bool {
+		return compareActors(order, listedActors[i].values, listedActors[j].values) < 0
+	})
+
+	start, end := pageBounds(len(listedActors), limit, offset)
+
+	actors := make([]*domain.Actor, 0, end-start)
+	for _, listed := range listedActors[start:end] {
+		actors = append(actors, t.buildActor(&listed.actor))
+	}
+
+	return buildActorsPage(actors, limit)
+}
+
+// actorIds returns ids of all actors in ascending order
+func (t *tables) actorIds() []int64 {
+	ids := make([]int64, 0, len(t.actors))
+	for id := range t.actors {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func (t *tables) matchActor(actor *MemActor, filter *domain.ActorFilter) bool {
+	if filter == nil {
+		return true
+	}
+
+	if filter.Name != "" && !containsFold(actor.Name, filter.Name.String()) {
+		return false
+	}
+	if filter.Sex != nil && actor.Sex != filter.Sex.Uint8() {
+		return false
+	}
+	if filter.BornFrom != nil && actor.BirthDate.Before(filter.BornFrom.Time()) {
+		return false
+	}
+	if filter.BornTo != nil && actor.BirthDate.After(filter.BornTo.Time()) {
+		return false
+	}
+	if filter.FilmId != nil {
+		var credited bool
+		for _, film := range t.actorFilms(actor.Id) {
+			credited = credited || film.Id == *filter.FilmId
+		}
+		if !credited {
+			return false
+		}
+	}
+
+	return true
+}
+
+// buildActor builds the actor with their films, the same way whether the actor is read, listed or exported
+func (t *tables) buildActor(actor *MemActor) *domain.Actor {
+	return &domain.Actor{
+		Id:        domain.ActorId(actor.Id),
+		Name:      domain.ActorName(actor.Name),
+		Sex:       domain.ActorSex(actor.Sex),
+		BirthDate: domain.ActorBirthDate(actor.BirthDate),
+		Films:     t.actorFilms(actor.Id),
+		CreatedBy: copyInt64Ptr(actor.CreatedBy),
+		UpdatedBy: copyInt64Ptr(actor.UpdatedBy),
+		Version:   actor.Version,
+	}
+}
+
+// buildActorsPage expects up to limit+1 actors and cuts the extra one off, like buildFilmsPage
+func buildActorsPage(actors []*domain.Actor, limit int) ([]*domain.Actor, *domain.ActorCursor) {
+	if len(actors) <= limit {
+		return actors, nil
+	}
+
+	actors = actors[:limit]
+	if len(actors) == 0 {
+		return actors, nil
+	}
+
+	return actors, domain.NewActorCursor(actors[len(actors)-1])
+}
+
+// buildDomainCreditedActor builds an actor credited in a film, which has only its own columns, like in the postgres storage
+func buildDomainCreditedActor(actor *MemActor) *domain.Actor {
+	return &domain.Actor{
+		Id:        domain.ActorId(actor.Id),
+		Name:      domain.ActorName(actor.Name),
+		Sex:       domain.ActorSex(actor.Sex),
+		BirthDate: domain.ActorBirthDate(actor.BirthDate),
+		Films:     make([]*domain.Film, 0),
+	}
+}
+
+// containsFold tells whether substr is a case-insensitive fragment of s, like 'ILIKE' with a pattern of '%substr%'
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
@@ -0,0 +1,96 @@
+package memory
+
+import (
+	"context"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"sort"
+	"time"
+)
+
+type MemAuditStorage struct {
+	db *Db
+}
+
+func NewMemAuditStorage(db *Db) *MemAuditStorage {
+	return &MemAuditStorage{db: db}
+}
+
+// List lists the audit events matching the filter, the latest first
+func (s *MemAuditStorage) List(ctx context.Context, filter *domain.AuditFilter, limit, offset int) ([]*domain.AuditEvent, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var events []*domain.AuditEvent
+	for _, event := range s.db.tables.auditEvents {
+		if matchAuditEvent(event, filter) {
+			events = append(events, event)
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].CreatedAt.Equal(events[j].CreatedAt) {
+			return events[i].CreatedAt.After(events[j].CreatedAt)
+		}
+		return events[i].Id > events[j].Id
+	})
+
+	start := min(offset, len(events))
+	end := min(start+limit, len(events))
+
+	var page []*domain.AuditEvent
+	for _, event := range events[start:end] {
+		page = append(page, copyAuditEvent(event))
+	}
+
+	return page, nil
+}
+
+func (s *MemAuditStorage) Count(ctx context.Context, filter *domain.AuditFilter) (int64, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var count int64
+	for _, event := range s.db.tables.auditEvents {
+		if matchAuditEvent(event, filter) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// insertAuditEvent is called by the catalog storages along with the audited mutation
+func (t *tables) insertAuditEvent(event *domain.AuditEvent) {
+	t.sequences.auditEvents++
+
+	insertedEvent := copyAuditEvent(event)
+	insertedEvent.Id = domain.AuditEventId(t.sequences.auditEvents)
+	insertedEvent.CreatedAt = time.Now()
+
+	t.auditEvents = append(t.auditEvents, insertedEvent)
+}
+
+func matchAuditEvent(event *domain.AuditEvent, filter *domain.AuditFilter) bool {
+	if filter.EntityType != nil && event.EntityType != *filter.EntityType {
+		return false
+	}
+	if filter.EntityId != nil && event.EntityId != *filter.EntityId {
+		return false
+	}
+	if filter.UserId != nil && (event.UserId == nil || *event.UserId != *filter.UserId) {
+		return false
+	}
+	if filter.From != nil && event.CreatedAt.Before(*filter.From) {
+		return false
+	}
+	if filter.To != nil && !event.CreatedAt.Before(*filter.To) {
+		return false
+	}
+	return true
+}
+
+func copyAuditEvent(event *domain.AuditEvent) *domain.AuditEvent {
+	copied := *event
+	copied.UserId = copyInt64Ptr(event.UserId)
+	return &copied
+}
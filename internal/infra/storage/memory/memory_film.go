@@ -0,0 +1,30 @@
+package memory
+
+import (
+	"time"
+)
+
+type MemFilm struct {
+	Id             int64
+	Title          string
+	Description    string
+	ReleaseDate    time.Time
+	Rating         uint8
+	CommunityScore float64
+	VotesCount     int64
+	CreatedBy      *int64
+	UpdatedBy      *int64
+	Version        int64
+	DeletedAt      *time.Time
+	DeletedBy      *int64
+}
+
+// MemFilmCredit is a row of 'films_actors': a person attached to a film in some role
+type MemFilmCredit struct {
+	Id            int64
+	FilmId        int64
+	ActorId       int64
+	Role          string
+	CharacterName string
+	BillingOrder  int
+}
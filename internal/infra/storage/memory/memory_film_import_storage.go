@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"context"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+)
+
+type MemFilmImportStorage struct {
+	db *Db
+}
+
+func NewMemFilmImportStorage(db *Db) *MemFilmImportStorage {
+	return &MemFilmImportStorage{db: db}
+}
+
+// Import checks the references of every row before changing anything, so that a failed row leaves no changes behind
+// and the rest of the rows are still checked. The import is rolled back to the snapshot of the tables taken before it,
+// unless it is committed.
+func (s *MemFilmImportStorage) Import(ctx context.Context, rows []*domain.FilmImportRow, commit bool, importedBy *int64) (*domain.FilmImportReport, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	snapshot := s.db.tables.snapshot()
+
+	report := &domain.FilmImportReport{}
+	var filmIds []domain.FilmId
+
+	for _, row := range rows {
+		filmId, createdActors, err := s.importRow(s.db.tables, row, importedBy)
+		if err != nil {
+			report.Errors = append(report.Errors, &domain.FilmImportRowError{Line: row.Line, Message: err.Error()})
+			continue
+		}
+
+		filmIds = append(filmIds, filmId)
+		report.CreatedActors += createdActors
+		report.MatchedActors += len(row.Actors) - createdActors
+	}
+
+	report.Films = len(filmIds)
+
+	if !commit || len(report.Errors) > 0 {
+		s.db.tables = snapshot
+		return report, nil
+	}
+
+	report.Committed = true
+	report.FilmIds = filmIds
+
+	return report, nil
+}
+
+// importRow creates the film of the row and returns its id with the number of actors created for it
+func (s *MemFilmImportStorage) importRow(t *tables, row *domain.FilmImportRow, importedBy *int64) (domain.FilmId, int, error) {
+	if err := t.checkFilmReferences(nil, row.GenreIds, importedBy); err != nil {
+		return 0, 0, err
+	}
+
+	var createdActors int
+
+	actorIds := make([]domain.ActorId, 0, len(row.Actors))
+	for _, importActor := range row.Actors {
+		actorId := t.findActor(importActor.Name, importActor.BirthDate)
+
+		if actorId == nil {
+			actor, err := t.createActor(importActor.Name, importActor.Sex, importActor.BirthDate, importedBy)
+			if err != nil {
+				return 0, 0, err
+			}
+			actorId = &actor.Id
+			createdActors++
+		}
+
+		actorIds = append(actorIds, *actorId)
+	}
+
+	film, err := t.createFilm(row.Title, row.Description, row.ReleaseDate, row.Rating, domain.NewActorCredits(actorIds), row.GenreIds, importedBy)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return film.Id, createdActors, nil
+}
+
+// findActor returns the id of the first actor with the given name and birth date, or nil if there is no such actor
+func (t *tables) findActor(name domain.ActorName, birthDate domain.ActorBirthDate) *domain.ActorId {
+	for _, id := range t.actorIds() {
+		actor := t.actors[id]
+		if actor.DeletedAt == nil && actor.Name == name.String() && actor.BirthDate.Equal(dateOf(birthDate.Time())) {
+			actorId := domain.ActorId(actor.Id)
+			return &actorId
+		}
+	}
+	return nil
+}
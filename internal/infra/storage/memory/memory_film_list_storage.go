@@ -0,0 +1,113 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"sort"
+	"time"
+)
+
+type MemFilmListStorage struct {
+	db *Db
+}
+
+func NewMemFilmListStorage(db *Db) *MemFilmListStorage {
+	return &MemFilmListStorage{db: db}
+}
+
+// Add adds the film to the list of the user, a film already in the list stays there.
+// A deleted film may be added, its row still exists, like with the foreign key of the postgres table.
+func (s *MemFilmListStorage) Add(ctx context.Context, userId int64, list domain.FilmList, filmId domain.FilmId) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	t := s.db.tables
+
+	_, filmExists := t.films[filmId.Int64()]
+	_, userExists := t.users[userId]
+	if !filmExists || !userExists {
+		return fmt.Errorf("failed to add a film to the list: %w", storage.ErrFilmNotFound)
+	}
+
+	key := userFilmListKey{userId: userId, list: list, filmId: filmId.Int64()}
+	if _, ok := t.userFilmLists[key]; !ok {
+		t.userFilmLists[key] = time.Now()
+	}
+
+	return nil
+}
+
+func (s *MemFilmListStorage) Remove(ctx context.Context, userId int64, list domain.FilmList, filmId domain.FilmId) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	key := userFilmListKey{userId: userId, list: list, filmId: filmId.Int64()}
+	if _, ok := s.db.tables.userFilmLists[key]; !ok {
+		return fmt.Errorf("failed to remove a film from the list: %w", storage.ErrFilmListItemNotFound)
+	}
+
+	delete(s.db.tables.userFilmLists, key)
+
+	return nil
+}
+
+// ListFilms lists films of the user list in the same orders as MemFilmStorage.List
+func (s *MemFilmListStorage) ListFilms(ctx context.Context, userId int64, list domain.FilmList, sort domain.FilmSort, cursor *domain.FilmCursor, limit, offset int) ([]*domain.Film, *domain.FilmCursor, error) {
+	order, err := buildFilmOrder(sort, false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list films of the list: %w", err)
+	}
+
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	t := s.db.tables
+
+	films, nextCursor := t.listFilms(func(film *MemFilm) bool {
+		_, ok := t.userFilmLists[userFilmListKey{userId: userId, list: list, filmId: film.Id}]
+		return ok
+	}, "", order, cursor, limit, offset)
+
+	return films, nextCursor, nil
+}
+
+func (s *MemFilmListStorage) CountFilms(ctx context.Context, userId int64, list domain.FilmList) (int64, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	t := s.db.tables
+
+	var count int64
+	for key := range t.userFilmLists {
+		if key.userId == userId && key.list == list && t.films[key.filmId].DeletedAt == nil {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (s *MemFilmListStorage) GetFilmsLists(ctx context.Context, userId int64, filmIds []domain.FilmId) (map[domain.FilmId][]domain.FilmList, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	requestedIds := make(map[int64]struct{}, len(filmIds))
+	for _, filmId := range filmIds {
+		requestedIds[filmId.Int64()] = struct{}{}
+	}
+
+	filmsLists := make(map[domain.FilmId][]domain.FilmList)
+	for key := range s.db.tables.userFilmLists {
+		if _, ok := requestedIds[key.filmId]; ok && key.userId == userId {
+			filmsLists[domain.FilmId(key.filmId)] = append(filmsLists[domain.FilmId(key.filmId)], key.list)
+		}
+	}
+
+	for _, lists := range filmsLists {
+		sort.Slice(lists, func(i, j int) bool { return lists[i] < lists[j] })
+	}
+
+	return filmsLists, nil
+}
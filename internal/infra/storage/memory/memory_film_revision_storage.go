@@ -0,0 +1,96 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"sort"
+	"time"
+)
+
+type MemFilmRevisionStorage struct {
+	db *Db
+}
+
+func NewMemFilmRevisionStorage(db *Db) *MemFilmRevisionStorage {
+	return &MemFilmRevisionStorage{db: db}
+}
+
+// List lists revisions of a film starting with the latest one
+func (s *MemFilmRevisionStorage) List(ctx context.Context, filmId domain.FilmId, limit, offset int) ([]*domain.FilmRevision, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var revisions []*domain.FilmRevision
+	for _, revision := range s.db.tables.filmRevisions {
+		if revision.FilmId == filmId {
+			revisions = append(revisions, revision)
+		}
+	}
+
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision > revisions[j].Revision
+	})
+
+	start := min(offset, len(revisions))
+	end := min(start+limit, len(revisions))
+
+	var page []*domain.FilmRevision
+	for _, revision := range revisions[start:end] {
+		page = append(page, copyFilmRevision(revision))
+	}
+
+	return page, nil
+}
+
+func (s *MemFilmRevisionStorage) Count(ctx context.Context, filmId domain.FilmId) (int64, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var count int64
+	for _, revision := range s.db.tables.filmRevisions {
+		if revision.FilmId == filmId {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (s *MemFilmRevisionStorage) Get(ctx context.Context, filmId domain.FilmId, revision int64) (*domain.FilmRevision, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	for _, filmRevision := range s.db.tables.filmRevisions {
+		if filmRevision.FilmId == filmId && filmRevision.Revision == revision {
+			return copyFilmRevision(filmRevision), nil
+		}
+	}
+
+	return nil, fmt.Errorf("failed to get a film revision: %w", storage.ErrFilmRevisionNotFound)
+}
+
+// insertFilmRevision is called by MemFilmStorage along with the change that has produced the revision
+func (t *tables) insertFilmRevision(revision *domain.FilmRevision) {
+	insertedRevision := copyFilmRevision(revision)
+	insertedRevision.CreatedAt = time.Now()
+
+	t.filmRevisions = append(t.filmRevisions, insertedRevision)
+}
+
+// copyFilmRevision copies the revision along with its credits, so that the stored revision never changes
+func copyFilmRevision(revision *domain.FilmRevision) *domain.FilmRevision {
+	copied := *revision
+	copied.UserId = copyInt64Ptr(revision.UserId)
+
+	copied.Credits = make([]*domain.FilmCreditParams, len(revision.Credits))
+	for i, credit := range revision.Credits {
+		copiedCredit := *credit
+		copied.Credits[i] = &copiedCredit
+	}
+
+	copied.GenreIds = append(make([]domain.GenreId, 0, len(revision.GenreIds)), revision.GenreIds...)
+
+	return &copied
+}
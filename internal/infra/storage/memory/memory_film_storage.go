@@ -0,0 +1,708 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"sort"
+	"time"
+)
+
+type MemFilmStorage struct {
+	db *Db
+}
+
+func NewMemFilmStorage(db *Db) *MemFilmStorage {
+	return &MemFilmStorage{db: db}
+}
+
+func (s *MemFilmStorage) Create(ctx context.Context, title domain.FilmTitle, description domain.FilmDescription, releaseDate domain.FilmReleaseDate, rating domain.FilmRating, credits []*domain.FilmCreditParams, genreIds []domain.GenreId, createdBy *int64) (*domain.Film, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	return s.db.tables.createFilm(title, description, releaseDate, rating, credits, genreIds, createdBy)
+}
+
+func (s *MemFilmStorage) Update(ctx context.Context, id domain.FilmId, version *int64, title *domain.FilmTitle, description *domain.FilmDescription, releaseDate *domain.FilmReleaseDate, rating *domain.FilmRating, actorIds *[]domain.ActorId, credits *[]*domain.FilmCreditParams, genreIds *[]domain.GenreId, updatedBy *int64) (*domain.Film, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	return s.db.tables.updateFilm(id, version, title, description, releaseDate, rating, actorIds, credits, genreIds, updatedBy)
+}
+
+func (s *MemFilmStorage) Delete(ctx context.Context, id domain.FilmId, version *int64, deletedBy *int64) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	return s.db.tables.deleteFilm(id, version, deletedBy)
+}
+
+// Restore brings the film back from the trash with the credits and genres it had, and records the audit event
+func (s *MemFilmStorage) Restore(ctx context.Context, id domain.FilmId, restoredBy *int64) (*domain.Film, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	t := s.db.tables
+
+	film, ok := t.films[id.Int64()]
+	if !ok || film.DeletedAt == nil {
+		return nil, fmt.Errorf("failed to restore film: %w", storage.ErrFilmNotFound)
+	}
+	if err := t.checkUser(restoredBy); err != nil {
+		return nil, fmt.Errorf("failed to restore film: %w", err)
+	}
+
+	film.DeletedAt = nil
+	film.DeletedBy = nil
+	film.UpdatedBy = copyInt64Ptr(restoredBy)
+	t.films[film.Id] = film
+
+	domainFilm := t.buildFilm(&film)
+
+	auditEvent, err := domain.NewFilmAuditEvent(restoredBy, domain.AuditOperationRestore, nil, domainFilm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore film: %w", err)
+	}
+	t.insertAuditEvent(auditEvent)
+
+	return domainFilm, nil
+}
+
+func (s *MemFilmStorage) GetById(ctx context.Context, id domain.FilmId) (*domain.Film, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	film, err := s.db.tables.getFilm(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a film: %w", err)
+	}
+
+	return film, nil
+}
+
+// List lists films matching the filter. Without an explicit sort, films found by a search query are ordered by relevance
+func (s *MemFilmStorage) List(ctx context.Context, filter *domain.FilmFilter, sort domain.FilmSort, cursor *domain.FilmCursor, limit, offset int) ([]*domain.Film, *domain.FilmCursor, error) {
+	var query domain.FilmSearchQuery
+	if filter != nil {
+		query = filter.Query
+	}
+
+	order, err := buildFilmOrder(sort, query != "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list films: %w", err)
+	}
+
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	t := s.db.tables
+
+	films, nextCursor := t.listFilms(func(film *MemFilm) bool {
+		return t.matchFilm(film, filter)
+	}, query, order, cursor, limit, offset)
+
+	return films, nextCursor, nil
+}
+
+func (s *MemFilmStorage) Count(ctx context.Context, filter *domain.FilmFilter) (int64, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	t := s.db.tables
+
+	var count int64
+	for _, film := range t.films {
+		if film.DeletedAt == nil && t.matchFilm(&film, filter) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (s *MemFilmStorage) IsExists(ctx context.Context, id domain.FilmId) (bool, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	film, ok := s.db.tables.films[id.Int64()]
+
+	return ok && film.DeletedAt == nil, nil
+}
+
+// Export takes the films with their actors at once and calls fn after releasing the lock,
+// so that a slow consumer does not hold up writers
+func (s *MemFilmStorage) Export(ctx context.Context, fn func(film *domain.Film) error) error {
+	s.db.mu.RLock()
+
+	t := s.db.tables
+
+	var films []*domain.Film
+	for _, id := range t.filmIds() {
+		film := t.films[id]
+		if film.DeletedAt != nil {
+			continue
+		}
+		films = append(films, t.buildListedFilm(&film, false))
+	}
+
+	s.db.mu.RUnlock()
+
+	for _, film := range films {
+		if err := fn(film); err != nil {
+			return fmt.Errorf("failed to export films: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Batch applies every operation on its own, a failed operation leaves no changes behind and the rest of the operations still run.
+// A transactional batch with a failed operation is rolled back to the snapshot of the tables taken before the batch.
+func (s *MemFilmStorage) Batch(ctx context.Context, mode domain.BatchMode, operations []*domain.FilmBatchOperation, by *int64) ([]*domain.FilmBatchItem, bool, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	var snapshot *tables
+	if mode == domain.BatchModeTransactional {
+		snapshot = s.db.tables.snapshot()
+	}
+
+	items := make([]*domain.FilmBatchItem, len(operations))
+	var failed bool
+
+	for i, operation := range operations {
+		item := &domain.FilmBatchItem{Index: i, Type: operation.Type, Id: operation.Id}
+		items[i] = item
+
+		item.Film, item.Err = s.applyBatchOperation(s.db.tables, operation, by)
+		if item.Err != nil {
+			item.Status = domain.BatchItemFailed
+			item.Film = nil
+			failed = true
+			continue
+		}
+		item.Status = domain.BatchItemSucceeded
+	}
+
+	if mode == domain.BatchModeTransactional && failed {
+		s.db.tables = snapshot
+		for _, item := range items {
+			if item.Status == domain.BatchItemSucceeded {
+				item.Status = domain.BatchItemAborted
+				item.Film = nil
+			}
+		}
+		return items, false, nil
+	}
+
+	return items, true, nil
+}
+
+func (s *MemFilmStorage) applyBatchOperation(t *tables, operation *domain.FilmBatchOperation, by *int64) (*domain.Film, error) {
+	switch operation.Type {
+	case domain.BatchOperationCreate:
+		params := operation.Create
+		return t.createFilm(params.Title, params.Description, params.ReleaseDate, params.Rating, params.Credits, params.GenreIds, by)
+	case domain.BatchOperationUpdate:
+		params := operation.Update
+		return t.updateFilm(operation.Id, operation.Version, params.Title, params.Description, params.ReleaseDate, params.Rating, params.ActorIds, params.Credits, params.GenreIds, by)
+	case domain.BatchOperationDelete:
+		return nil, t.deleteFilm(operation.Id, operation.Version, by)
+	default:
+		return nil, fmt.Errorf("unexpected batch operation '%s'", operation.Type)
+	}
+}
+
+// createFilm creates the film with its credits and genres and records the audit event and the revision.
+// References are checked before anything is changed, so that a failed creation leaves no changes behind
+func (t *tables) createFilm(title domain.FilmTitle, description domain.FilmDescription, releaseDate domain.FilmReleaseDate, rating domain.FilmRating, credits []*domain.FilmCreditParams, genreIds []domain.GenreId, createdBy *int64) (*domain.Film, error) {
+	if err := t.checkFilmReferences(credits, genreIds, createdBy); err != nil {
+		return nil, fmt.Errorf("failed to create a film: %w", err)
+	}
+
+	t.sequences.films++
+	film := MemFilm{
+		Id:          t.sequences.films,
+		Title:       title.String(),
+		Description: description.String(),
+		ReleaseDate: dateOf(releaseDate.Time()),
+		Rating:      rating.Uint8(),
+		CreatedBy:   copyInt64Ptr(createdBy),
+		Version:     1,
+	}
+	t.films[film.Id] = film
+
+	t.insertFilmCredits(film.Id, credits)
+	t.insertFilmGenres(film.Id, genreIds)
+
+	domainFilm := t.buildFilm(&film)
+
+	auditEvent, err := domain.NewFilmAuditEvent(createdBy, domain.AuditOperationCreate, nil, domainFilm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a film: %w", err)
+	}
+	t.insertAuditEvent(auditEvent)
+	t.insertFilmRevision(domain.NewFilmRevision(domainFilm, createdBy))
+
+	return domainFilm, nil
+}
+
+// updateFilm updates the film with its credits and genres and records the audit event and the revision, like createFilm
+func (t *tables) updateFilm(id domain.FilmId, version *int64, title *domain.FilmTitle, description *domain.FilmDescription, releaseDate *domain.FilmReleaseDate, rating *domain.FilmRating, actorIds *[]domain.ActorId, credits *[]*domain.FilmCreditParams, genreIds *[]domain.GenreId, updatedBy *int64) (*domain.Film, error) {
+	film, err := t.checkFilm(id, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update a film: %w", err)
+	}
+
+	var newCredits []*domain.FilmCreditParams
+	if credits != nil {
+		newCredits = *credits
+	} else if actorIds != nil {
+		newCredits = domain.NewActorCredits(*actorIds)
+	}
+
+	var newGenreIds []domain.GenreId
+	if genreIds != nil {
+		newGenreIds = *genreIds
+	}
+
+	if err = t.checkFilmReferences(newCredits, newGenreIds, updatedBy); err != nil {
+		return nil, fmt.Errorf("failed to update a film: %w", err)
+	}
+
+	filmBefore := t.buildFilm(&film)
+
+	if title != nil {
+		film.Title = title.String()
+	}
+	if description != nil {
+		film.Description = description.String()
+	}
+	if releaseDate != nil {
+		film.ReleaseDate = dateOf(releaseDate.Time())
+	}
+	if rating != nil {
+		film.Rating = rating.Uint8()
+	}
+	film.UpdatedBy = copyInt64Ptr(updatedBy)
+	film.Version++
+	t.films[film.Id] = film
+
+	if credits != nil {
+		t.deleteFilmCredits(film.Id, false)
+		t.insertFilmCredits(film.Id, newCredits)
+	} else if actorIds != nil {
+		t.deleteFilmCredits(film.Id, true)
+		t.insertFilmCredits(film.Id, newCredits)
+	}
+
+	if genreIds != nil {
+		for key := range t.filmsGenres {
+			if key.filmId == film.Id {
+				delete(t.filmsGenres, key)
+			}
+		}
+		t.insertFilmGenres(film.Id, newGenreIds)
+	}
+
+	domainFilm := t.buildFilm(&film)
+
+	auditEvent, err := domain.NewFilmAuditEvent(updatedBy, domain.AuditOperationUpdate, filmBefore, domainFilm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update a film: %w", err)
+	}
+	t.insertAuditEvent(auditEvent)
+	t.insertFilmRevision(domain.NewFilmRevision(domainFilm, updatedBy))
+
+	return domainFilm, nil
+}
+
+// deleteFilm moves the film to the trash and records the audit event.
+// Credits, genres and reviews of the film are kept, so that it is restored with them
+func (t *tables) deleteFilm(id domain.FilmId, version *int64, deletedBy *int64) error {
+	film, err := t.checkFilm(id, version)
+	if err != nil {
+		return fmt.Errorf("failed to delete film: %w", err)
+	}
+	if err = t.checkUser(deletedBy); err != nil {
+		return fmt.Errorf("failed to delete film: %w", err)
+	}
+
+	auditEvent, err := domain.NewFilmAuditEvent(deletedBy, domain.AuditOperationDelete, t.buildFilm(&film), nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete film: %w", err)
+	}
+
+	deletedAt := time.Now()
+	film.DeletedAt = &deletedAt
+	film.DeletedBy = copyInt64Ptr(deletedBy)
+	t.films[film.Id] = film
+
+	t.insertAuditEvent(auditEvent)
+
+	return nil
+}
+
+// getFilm returns the film with credits of all roles, its actors and genres
+func (t *tables) getFilm(id domain.FilmId) (*domain.Film, error) {
+	film, ok := t.films[id.Int64()]
+	if !ok || film.DeletedAt != nil {
+		return nil, storage.ErrFilmNotFound
+	}
+	return t.buildFilm(&film), nil
+}
+
+// checkFilm returns the film, if it is not deleted and has the given version, if the version is set
+func (t *tables) checkFilm(id domain.FilmId, version *int64) (MemFilm, error) {
+	film, ok := t.films[id.Int64()]
+	if !ok || film.DeletedAt != nil {
+		return MemFilm{}, storage.ErrFilmNotFound
+	}
+	if version != nil && *version != film.Version {
+		return MemFilm{}, storage.ErrFilmVersionMismatch
+	}
+	return film, nil
+}
+
+// checkFilmReferences checks the references of a film row, like the foreign keys of the postgres tables do.
+// Deleted actors may be credited, they are still in the table
+func (t *tables) checkFilmReferences(credits []*domain.FilmCreditParams, genreIds []domain.GenreId, userId *int64) error {
+	for _, credit := range credits {
+		if _, ok := t.actors[credit.ActorId.Int64()]; !ok {
+			return fmt.Errorf("failed to insert values to 'films_actors' table: actor %d does not exist", credit.ActorId)
+		}
+	}
+	for _, genreId := range genreIds {
+		if _, ok := t.genres[genreId.Int64()]; !ok {
+			return fmt.Errorf("failed to insert values to 'films_genres' table: genre %d does not exist", genreId)
+		}
+	}
+	return t.checkUser(userId)
+}
+
+func (t *tables) insertFilmCredits(filmId int64, credits []*domain.FilmCreditParams) {
+	for _, credit := range credits {
+		t.sequences.filmsActors++
+		t.filmsActors = append(t.filmsActors, MemFilmCredit{
+			Id:            t.sequences.filmsActors,
+			FilmId:        filmId,
+			ActorId:       credit.ActorId.Int64(),
+			Role:          credit.Role.String(),
+			CharacterName: credit.CharacterName.String(),
+			BillingOrder:  credit.BillingOrder,
+		})
+	}
+}
+
+// deleteFilmCredits deletes credits of all roles, or only the credits with the 'actor' role, so that the crew of the film stays.
+// Credits of deleted actors stay as well, so that they are back in the film once the actor is restored
+func (t *tables) deleteFilmCredits(filmId int64, onlyActors bool) {
+	keptCredits := t.filmsActors[:0:0]
+	for _, credit := range t.filmsActors {
+		deleted := credit.FilmId == filmId &&
+			(!onlyActors || domain.CreditRole(credit.Role) == domain.CreditRoleActor) &&
+			t.actors[credit.ActorId].DeletedAt == nil
+		if !deleted {
+			keptCredits = append(keptCredits, credit)
+		}
+	}
+	t.filmsActors = keptCredits
+}
+
+func (t *tables) insertFilmGenres(filmId int64, genreIds []domain.GenreId) {
+	for _, genreId := range genreIds {
+		t.filmsGenres[filmGenreKey{filmId: filmId, genreId: genreId.Int64()}] = struct{}{}
+	}
+}
+
+// filmCredits returns credits of the film ordered by billing, credits of deleted actors are skipped
+func (t *tables) filmCredits(filmId int64) []MemFilmCredit {
+	var credits []MemFilmCredit
+	for _, credit := range t.filmsActors {
+		if credit.FilmId == filmId && t.actors[credit.ActorId].DeletedAt == nil {
+			credits = append(credits, credit)
+		}
+	}
+
+	// Credits are kept in the order of their ids, so the stable sort leaves credits with the same billing in that order
+	sort.SliceStable(credits, func(i, j int) bool {
+		return credits[i].BillingOrder < credits[j].BillingOrder
+	})
+
+	return credits
+}
+
+// filmActors returns the actors credited in the film with the 'actor' role, deleted actors are skipped
+func (t *tables) filmActors(filmId int64) []*domain.Actor {
+	actors := make([]*domain.Actor, 0)
+	for _, credit := range t.filmCredits(filmId) {
+		if domain.CreditRole(credit.Role) == domain.CreditRoleActor {
+			actor := t.actors[credit.ActorId]
+			actors = append(actors, buildDomainCreditedActor(&actor))
+		}
+	}
+	return actors
+}
+
+// filmGenres returns genres of the film ordered by name
+func (t *tables) filmGenres(filmId int64) []*domain.Genre {
+	genres := make([]*domain.Genre, 0)
+	for key := range t.filmsGenres {
+		if key.filmId == filmId {
+			genre := t.genres[key.genreId]
+			genres = append(genres, buildDomainGenre(&genre))
+		}
+	}
+
+	sort.Slice(genres, func(i, j int) bool {
+		if genres[i].Name != genres[j].Name {
+			return genres[i].Name < genres[j].Name
+		}
+		return genres[i].Id < genres[j].Id
+	})
+
+	return genres
+}
+
+// filmIds returns ids of all films in ascending order
+func (t *tables) filmIds() []int64 {
+	ids := make([]int64, 0, len(t.films))
+	for id := range t.films {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// matchFilm tells whether the film matches the filter. Credits of deleted actors are ignored
+func (t *tables) matchFilm(film *MemFilm, filter *domain.FilmFilter) bool {
+	if filter == nil {
+		return true
+	}
+
+	if filter.Query != "" {
+		if _, ok := t.filmRelevance(film, filter.Query.String(), searchWords(filter.Query.String())); !ok {
+			return false
+		}
+	}
+	if filter.Title != "" && !containsFold(film.Title, filter.Title.String()) {
+		return false
+	}
+	if filter.GenreId != nil {
+		if _, ok := t.filmsGenres[filmGenreKey{filmId: film.Id, genreId: filter.GenreId.Int64()}]; !ok {
+			return false
+		}
+	}
+	if filter.ReleasedFrom != nil && film.ReleaseDate.Before(filter.ReleasedFrom.Time()) {
+		return false
+	}
+	if filter.ReleasedTo != nil && film.ReleaseDate.After(filter.ReleasedTo.Time()) {
+		return false
+	}
+	if filter.MinRating != nil && film.Rating < filter.MinRating.Uint8() {
+		return false
+	}
+	if filter.MaxRating != nil && film.Rating > filter.MaxRating.Uint8() {
+		return false
+	}
+
+	credits := t.filmCredits(film.Id)
+
+	var hasActors, hasActorName, hasDirectorName, hasActorSex bool
+	matchedActorIds := make(map[domain.ActorId]struct{})
+
+	for _, credit := range credits {
+		actor := t.actors[credit.ActorId]
+
+		switch domain.CreditRole(credit.Role) {
+		case domain.CreditRoleActor:
+			hasActors = true
+			hasActorName = hasActorName || containsFold(actor.Name, filter.ActorName.String())
+			hasActorSex = hasActorSex || (filter.ActorSex != nil && actor.Sex == filter.ActorSex.Uint8())
+			matchedActorIds[domain.ActorId(actor.Id)] = struct{}{}
+		case domain.CreditRoleDirector:
+			hasDirectorName = hasDirectorName || containsFold(actor.Name, filter.DirectorName.String())
+		}
+	}
+
+	if filter.ActorName != "" && !hasActorName {
+		return false
+	}
+	if filter.DirectorName != "" && !hasDirectorName {
+		return false
+	}
+	if filter.ActorSex != nil && !hasActorSex {
+		return false
+	}
+	if filter.WithoutActors && hasActors {
+		return false
+	}
+	if len(filter.ActorIds) > 0 {
+		var matched, all = false, true
+		for _, actorId := range filter.ActorIds {
+			if _, ok := matchedActorIds[actorId]; ok {
+				matched = true
+			} else {
+				all = false
+			}
+		}
+		if filter.ActorsMatch == domain.FilmActorsMatchAll && !all || !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// filmRelevance returns the relevance of the film to the search query and its words and whether the film is found by them.
+// Names of all credits of the film are searched, except the names of deleted actors
+func (t *tables) filmRelevance(film *MemFilm, query string, words []string) (float64, bool) {
+	var names []string
+	for _, credit := range t.filmCredits(film.Id) {
+		names = append(names, t.actors[credit.ActorId].Name)
+	}
+	return searchRelevance(query, words, film.Title, film.Description, names)
+}
+
+// listFilms lists the films that are not deleted and match, in the order and after the cursor, with their actors and genres.
+// Films are searched by the query, if it is set, and get their matches
+func (t *tables) listFilms(match func(film *MemFilm) bool, query domain.FilmSearchQuery, order []filmOrderKey, cursor *domain.FilmCursor, limit, offset int) ([]*domain.Film, *domain.FilmCursor) {
+	type listedFilm struct {
+		film   MemFilm
+		values *filmKeyValues
+	}
+
+	words := searchWords(query.String())
+
+	var cursorValues *filmKeyValues
+	if cursor != nil {
+		cursorValues = buildFilmCursorValues(cursor)
+	}
+
+	var listedFilms []*listedFilm
+	for _, film := range t.films {
+		if film.DeletedAt != nil || !match(&film) {
+			continue
+		}
+
+		values := &filmKeyValues{
+			id:             film.Id,
+			title:          film.Title,
+			releaseDate:    film.ReleaseDate,
+			rating:         film.Rating,
+			actorsCount:    int64(len(t.filmActors(film.Id))),
+			communityScore: film.CommunityScore,
+		}
+		if query != "" {
+			values.relevance, _ = t.filmRelevance(&film, query.String(), words)
+		}
+
+		if cursorValues != nil && compareFilms(order, values, cursorValues) <= 0 {
+			continue
+		}
+
+		listedFilms = append(listedFilms, &listedFilm{film: film, values: values})
+	}
+
+	sort.Slice(listedFilms, func(i, j int) bool {
+		return compareFilms(order, listedFilms[i].values, listedFilms[j].values) < 0
+	})
+
+	start, end := pageBounds(len(listedFilms), limit, offset)
+
+	films := make([]*domain.Film, 0, end-start)
+	for _, listed := range listedFilms[start:end] {
+		film := t.buildListedFilm(&listed.film, true)
+		if query != "" {
+			film.Match = &domain.FilmMatch{
+				Relevance:            listed.values.relevance,
+				TitleHighlight:       highlight(listed.film.Title, words),
+				DescriptionHighlight: highlight(listed.film.Description, words),
+			}
+		}
+		films = append(films, film)
+	}
+
+	return buildFilmsPage(films, limit)
+}
+
+// buildFilm builds the film with credits of all roles, its actors and genres, as a single film is read
+func (t *tables) buildFilm(film *MemFilm) *domain.Film {
+	domainFilm := buildDomainFilm(film)
+
+	for _, credit := range t.filmCredits(film.Id) {
+		actor := t.actors[credit.ActorId]
+		domainActor := buildDomainCreditedActor(&actor)
+
+		domainFilm.Credits = append(domainFilm.Credits, &domain.FilmCredit{
+			Actor:         domainActor,
+			Role:          domain.CreditRole(credit.Role),
+			CharacterName: domain.CharacterName(credit.CharacterName),
+			BillingOrder:  credit.BillingOrder,
+		})
+
+		if domain.CreditRole(credit.Role) == domain.CreditRoleActor {
+			domainFilm.Actors = append(domainFilm.Actors, domainActor)
+		}
+	}
+
+	domainFilm.Genres = t.filmGenres(film.Id)
+
+	return domainFilm
+}
+
+// buildListedFilm builds the film with its actors and, optionally, genres, as films are listed and exported
+func (t *tables) buildListedFilm(film *MemFilm, withGenres bool) *domain.Film {
+	domainFilm := buildDomainFilm(film)
+	domainFilm.Actors = t.filmActors(film.Id)
+	if withGenres {
+		domainFilm.Genres = t.filmGenres(film.Id)
+	}
+	return domainFilm
+}
+
+// buildFilmsPage expects up to limit+1 films and cuts the extra one off.
+// The extra film means that there is a next page, which starts after the last returned film.
+func buildFilmsPage(films []*domain.Film, limit int) ([]*domain.Film, *domain.FilmCursor) {
+	if len(films) <= limit {
+		return films, nil
+	}
+
+	films = films[:limit]
+	if len(films) == 0 {
+		return films, nil
+	}
+
+	return films, domain.NewFilmCursor(films[len(films)-1])
+}
+
+func buildDomainFilm(film *MemFilm) *domain.Film {
+	return &domain.Film{
+		Id:             domain.FilmId(film.Id),
+		Title:          domain.FilmTitle(film.Title),
+		Description:    domain.FilmDescription(film.Description),
+		ReleaseDate:    domain.FilmReleaseDate(film.ReleaseDate),
+		Rating:         domain.FilmRating(film.Rating),
+		CommunityScore: domain.CommunityScore(film.CommunityScore),
+		VotesCount:     film.VotesCount,
+		Actors:         make([]*domain.Actor, 0),
+		Genres:         make([]*domain.Genre, 0),
+		CreatedBy:      copyInt64Ptr(film.CreatedBy),
+		UpdatedBy:      copyInt64Ptr(film.UpdatedBy),
+		Version:        film.Version,
+	}
+}
+
+// buildDomainActorFilm builds a film of an actor, which has only its own columns, like in the postgres storage
+func buildDomainActorFilm(film *MemFilm) *domain.Film {
+	return &domain.Film{
+		Id:          domain.FilmId(film.Id),
+		Title:       domain.FilmTitle(film.Title),
+		Description: domain.FilmDescription(film.Description),
+		ReleaseDate: domain.FilmReleaseDate(film.ReleaseDate),
+		Rating:      domain.FilmRating(film.Rating),
+		Actors:      make([]*domain.Actor, 0),
+		Genres:      make([]*domain.Genre, 0),
+	}
+}
@@ -0,0 +1,6 @@
+package memory
+
+type MemGenre struct {
+	Id   int64
+	Name string
+}
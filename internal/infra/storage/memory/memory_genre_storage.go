@@ -0,0 +1,135 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"sort"
+)
+
+type MemGenreStorage struct {
+	db *Db
+}
+
+func NewMemGenreStorage(db *Db) *MemGenreStorage {
+	return &MemGenreStorage{db: db}
+}
+
+func (s *MemGenreStorage) Create(ctx context.Context, name domain.GenreName) (*domain.Genre, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	t := s.db.tables
+
+	if t.isGenreNameTaken(name.String(), 0) {
+		return nil, fmt.Errorf("failed to create a genre: %w", storage.ErrGenreAlreadyExists)
+	}
+
+	t.sequences.genres++
+	genre := MemGenre{Id: t.sequences.genres, Name: name.String()}
+	t.genres[genre.Id] = genre
+
+	return buildDomainGenre(&genre), nil
+}
+
+func (s *MemGenreStorage) Update(ctx context.Context, id domain.GenreId, name domain.GenreName) (*domain.Genre, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	t := s.db.tables
+
+	genre, ok := t.genres[id.Int64()]
+	if !ok {
+		return nil, fmt.Errorf("failed to update a genre: %w", storage.ErrGenreNotFound)
+	}
+	if t.isGenreNameTaken(name.String(), genre.Id) {
+		return nil, fmt.Errorf("failed to update a genre: %w", storage.ErrGenreAlreadyExists)
+	}
+
+	genre.Name = name.String()
+	t.genres[genre.Id] = genre
+
+	return buildDomainGenre(&genre), nil
+}
+
+// Delete deletes the genre along with the genres of the films, like the cascading foreign key does
+func (s *MemGenreStorage) Delete(ctx context.Context, id domain.GenreId) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	t := s.db.tables
+
+	if _, ok := t.genres[id.Int64()]; !ok {
+		return fmt.Errorf("failed to delete genre: %w", storage.ErrGenreNotFound)
+	}
+
+	delete(t.genres, id.Int64())
+	for key := range t.filmsGenres {
+		if key.genreId == id.Int64() {
+			delete(t.filmsGenres, key)
+		}
+	}
+
+	return nil
+}
+
+func (s *MemGenreStorage) GetById(ctx context.Context, id domain.GenreId) (*domain.Genre, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	genre, ok := s.db.tables.genres[id.Int64()]
+	if !ok {
+		return nil, fmt.Errorf("failed to get genre: %w", storage.ErrGenreNotFound)
+	}
+
+	return buildDomainGenre(&genre), nil
+}
+
+func (s *MemGenreStorage) List(ctx context.Context) ([]*domain.Genre, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	genres := make([]*domain.Genre, 0, len(s.db.tables.genres))
+	for _, genre := range s.db.tables.genres {
+		genres = append(genres, buildDomainGenre(&genre))
+	}
+
+	sort.Slice(genres, func(i, j int) bool {
+		return genres[i].Name < genres[j].Name
+	})
+
+	return genres, nil
+}
+
+func (s *MemGenreStorage) AreExists(ctx context.Context, ids []domain.GenreId) (bool, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	// Ids are counted once each, like rows matching 'id=ANY($1)' are
+	existingIds := make(map[int64]struct{})
+	for _, id := range ids {
+		if _, ok := s.db.tables.genres[id.Int64()]; ok {
+			existingIds[id.Int64()] = struct{}{}
+		}
+	}
+
+	return len(existingIds) == len(ids), nil
+}
+
+// isGenreNameTaken tells whether a genre other than the one with exceptId has the name, like the unique index does
+func (t *tables) isGenreNameTaken(name string, exceptId int64) bool {
+	for _, genre := range t.genres {
+		if genre.Name == name && genre.Id != exceptId {
+			return true
+		}
+	}
+	return false
+}
+
+func buildDomainGenre(genre *MemGenre) *domain.Genre {
+	return &domain.Genre{
+		Id:   domain.GenreId(genre.Id),
+		Name: domain.GenreName(genre.Name),
+	}
+}
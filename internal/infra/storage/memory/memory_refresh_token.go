@@ -0,0 +1,12 @@
+package memory
+
+import "time"
+
+type MemRefreshToken struct {
+	Id        int64
+	UserId    int64
+	TokenHash string
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"time"
+)
+
+type MemRefreshTokenStorage struct {
+	db *Db
+}
+
+func NewMemRefreshTokenStorage(db *Db) *MemRefreshTokenStorage {
+	return &MemRefreshTokenStorage{db: db}
+}
+
+func (s *MemRefreshTokenStorage) Create(ctx context.Context, userId int64, tokenHash string, expiresAt time.Time) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	t := s.db.tables
+
+	if err := t.checkUser(&userId); err != nil {
+		return fmt.Errorf("failed to create a refresh token: %w", err)
+	}
+	if _, ok := t.refreshTokens[tokenHash]; ok {
+		return fmt.Errorf("failed to create a refresh token: token hash is already taken")
+	}
+
+	t.sequences.refreshTokens++
+	t.refreshTokens[tokenHash] = MemRefreshToken{
+		Id:        t.sequences.refreshTokens,
+		UserId:    userId,
+		TokenHash: tokenHash,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	}
+
+	return nil
+}
+
+func (s *MemRefreshTokenStorage) Revoke(ctx context.Context, tokenHash string) (int64, error) {
+	// The exclusive lock guarantees that concurrent requests cannot revoke the same token twice
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	now := time.Now()
+
+	token, ok := s.db.tables.refreshTokens[tokenHash]
+	if !ok || token.RevokedAt != nil || !token.ExpiresAt.After(now) {
+		return 0, fmt.Errorf("failed to revoke a refresh token: %w", storage.ErrRefreshTokenNotFound)
+	}
+
+	token.RevokedAt = &now
+	s.db.tables.refreshTokens[tokenHash] = token
+
+	return token.UserId, nil
+}
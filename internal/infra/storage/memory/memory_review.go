@@ -0,0 +1,13 @@
+package memory
+
+import "time"
+
+type MemReview struct {
+	Id        int64
+	FilmId    int64
+	UserId    int64
+	Rating    uint8
+	Text      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
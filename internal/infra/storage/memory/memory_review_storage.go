@@ -0,0 +1,189 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"math"
+	"sort"
+	"time"
+)
+
+type MemReviewStorage struct {
+	db *Db
+}
+
+func NewMemReviewStorage(db *Db) *MemReviewStorage {
+	return &MemReviewStorage{db: db}
+}
+
+func (s *MemReviewStorage) Create(ctx context.Context, filmId domain.FilmId, userId int64, rating domain.ReviewRating, text domain.ReviewText) (*domain.Review, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	t := s.db.tables
+
+	if _, err := t.checkFilm(filmId, nil); err != nil {
+		return nil, fmt.Errorf("failed to create a review: %w", err)
+	}
+	if err := t.checkUser(&userId); err != nil {
+		return nil, fmt.Errorf("failed to create a review: %w", err)
+	}
+	for _, review := range t.reviews {
+		if review.FilmId == filmId.Int64() && review.UserId == userId {
+			return nil, fmt.Errorf("failed to create a review: %w", storage.ErrReviewAlreadyExists)
+		}
+	}
+
+	now := time.Now()
+
+	t.sequences.reviews++
+	review := MemReview{
+		Id:        t.sequences.reviews,
+		FilmId:    filmId.Int64(),
+		UserId:    userId,
+		Rating:    rating.Uint8(),
+		Text:      text.String(),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	t.reviews[review.Id] = review
+
+	t.updateFilmScore(review.FilmId)
+
+	return buildDomainReview(&review), nil
+}
+
+// Update changes the given fields of a review, an empty text removes the text of the review
+func (s *MemReviewStorage) Update(ctx context.Context, id domain.ReviewId, rating *domain.ReviewRating, text *domain.ReviewText) (*domain.Review, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	t := s.db.tables
+
+	review, ok := t.reviews[id.Int64()]
+	if !ok {
+		return nil, fmt.Errorf("failed to update a review: %w", storage.ErrReviewNotFound)
+	}
+	if _, err := t.checkFilm(domain.FilmId(review.FilmId), nil); err != nil {
+		return nil, fmt.Errorf("failed to update a review: %w", err)
+	}
+
+	if rating != nil {
+		review.Rating = rating.Uint8()
+	}
+	if text != nil {
+		review.Text = text.String()
+	}
+	review.UpdatedAt = time.Now()
+	t.reviews[review.Id] = review
+
+	t.updateFilmScore(review.FilmId)
+
+	return buildDomainReview(&review), nil
+}
+
+func (s *MemReviewStorage) Delete(ctx context.Context, id domain.ReviewId) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	t := s.db.tables
+
+	review, ok := t.reviews[id.Int64()]
+	if !ok {
+		return fmt.Errorf("failed to delete review: %w", storage.ErrReviewNotFound)
+	}
+	if _, err := t.checkFilm(domain.FilmId(review.FilmId), nil); err != nil {
+		return fmt.Errorf("failed to delete review: %w", err)
+	}
+
+	delete(t.reviews, review.Id)
+
+	t.updateFilmScore(review.FilmId)
+
+	return nil
+}
+
+func (s *MemReviewStorage) GetById(ctx context.Context, id domain.ReviewId) (*domain.Review, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	review, ok := s.db.tables.reviews[id.Int64()]
+	if !ok {
+		return nil, fmt.Errorf("failed to get review: %w", storage.ErrReviewNotFound)
+	}
+
+	return buildDomainReview(&review), nil
+}
+
+// ListByFilmId lists reviews of a film starting with the latest ones
+func (s *MemReviewStorage) ListByFilmId(ctx context.Context, filmId domain.FilmId, limit, offset int) ([]*domain.Review, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	reviews := make([]*domain.Review, 0)
+	for _, review := range s.db.tables.reviews {
+		if review.FilmId == filmId.Int64() {
+			reviews = append(reviews, buildDomainReview(&review))
+		}
+	}
+
+	sort.Slice(reviews, func(i, j int) bool {
+		if !reviews[i].CreatedAt.Equal(reviews[j].CreatedAt) {
+			return reviews[i].CreatedAt.After(reviews[j].CreatedAt)
+		}
+		return reviews[i].Id > reviews[j].Id
+	})
+
+	start := min(offset, len(reviews))
+	end := min(start+limit, len(reviews))
+
+	return reviews[start:end], nil
+}
+
+func (s *MemReviewStorage) CountByFilmId(ctx context.Context, filmId domain.FilmId) (int64, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var count int64
+	for _, review := range s.db.tables.reviews {
+		if review.FilmId == filmId.Int64() {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// updateFilmScore recalculates the community score and the votes count of the film from its reviews,
+// the score is rounded to two decimal places like 'ROUND(AVG(rating), 2)'
+func (t *tables) updateFilmScore(filmId int64) {
+	var ratingsSum, votesCount int64
+	for _, review := range t.reviews {
+		if review.FilmId == filmId {
+			ratingsSum += int64(review.Rating)
+			votesCount++
+		}
+	}
+
+	film := t.films[filmId]
+	film.VotesCount = votesCount
+	film.CommunityScore = 0
+	if votesCount > 0 {
+		film.CommunityScore = math.Round(float64(ratingsSum)/float64(votesCount)*100) / 100
+	}
+	t.films[filmId] = film
+}
+
+func buildDomainReview(review *MemReview) *domain.Review {
+	return &domain.Review{
+		Id:        domain.ReviewId(review.Id),
+		FilmId:    domain.FilmId(review.FilmId),
+		UserId:    review.UserId,
+		Rating:    domain.ReviewRating(review.Rating),
+		Text:      domain.ReviewText(review.Text),
+		CreatedAt: review.CreatedAt,
+		UpdatedAt: review.UpdatedAt,
+	}
+}
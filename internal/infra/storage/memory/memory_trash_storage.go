@@ -0,0 +1,154 @@
+package memory
+
+import (
+	"context"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"sort"
+	"time"
+)
+
+type MemTrashStorage struct {
+	db *Db
+}
+
+func NewMemTrashStorage(db *Db) *MemTrashStorage {
+	return &MemTrashStorage{db: db}
+}
+
+func (s *MemTrashStorage) List(ctx context.Context, filter *domain.TrashFilter, limit, offset int) ([]*domain.TrashItem, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	items := s.db.tables.trashItems(filter)
+
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].DeletedAt.Equal(items[j].DeletedAt) {
+			return items[i].DeletedAt.After(items[j].DeletedAt)
+		}
+		if items[i].EntityType != items[j].EntityType {
+			return items[i].EntityType < items[j].EntityType
+		}
+		return items[i].EntityId > items[j].EntityId
+	})
+
+	start := min(offset, len(items))
+	end := min(start+limit, len(items))
+
+	return items[start:end], nil
+}
+
+func (s *MemTrashStorage) Count(ctx context.Context, filter *domain.TrashFilter) (int64, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	return int64(len(s.db.tables.trashItems(filter))), nil
+}
+
+// Purge deletes the films and the actors for good along with their credits, genres, reviews, list entries and revisions,
+// like the cascading foreign keys of the postgres tables do
+func (s *MemTrashStorage) Purge(ctx context.Context, deletedBefore time.Time) (int64, int64, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	t := s.db.tables
+
+	purgedFilmIds := make(map[int64]struct{})
+	for id, film := range t.films {
+		if film.DeletedAt != nil && film.DeletedAt.Before(deletedBefore) {
+			purgedFilmIds[id] = struct{}{}
+			delete(t.films, id)
+		}
+	}
+
+	purgedActorIds := make(map[int64]struct{})
+	for id, actor := range t.actors {
+		if actor.DeletedAt != nil && actor.DeletedAt.Before(deletedBefore) {
+			purgedActorIds[id] = struct{}{}
+			delete(t.actors, id)
+		}
+	}
+
+	keptCredits := t.filmsActors[:0:0]
+	for _, credit := range t.filmsActors {
+		_, filmPurged := purgedFilmIds[credit.FilmId]
+		_, actorPurged := purgedActorIds[credit.ActorId]
+		if !filmPurged && !actorPurged {
+			keptCredits = append(keptCredits, credit)
+		}
+	}
+	t.filmsActors = keptCredits
+
+	for key := range t.filmsGenres {
+		if _, ok := purgedFilmIds[key.filmId]; ok {
+			delete(t.filmsGenres, key)
+		}
+	}
+	for id, review := range t.reviews {
+		if _, ok := purgedFilmIds[review.FilmId]; ok {
+			delete(t.reviews, id)
+		}
+	}
+	for key := range t.userFilmLists {
+		if _, ok := purgedFilmIds[key.filmId]; ok {
+			delete(t.userFilmLists, key)
+		}
+	}
+
+	var keptFilmRevisions []*domain.FilmRevision
+	for _, revision := range t.filmRevisions {
+		if _, ok := purgedFilmIds[revision.FilmId.Int64()]; !ok {
+			keptFilmRevisions = append(keptFilmRevisions, revision)
+		}
+	}
+	t.filmRevisions = keptFilmRevisions
+
+	var keptActorRevisions []*domain.ActorRevision
+	for _, revision := range t.actorRevisions {
+		if _, ok := purgedActorIds[revision.ActorId.Int64()]; !ok {
+			keptActorRevisions = append(keptActorRevisions, revision)
+		}
+	}
+	t.actorRevisions = keptActorRevisions
+
+	return int64(len(purgedFilmIds)), int64(len(purgedActorIds)), nil
+}
+
+// trashItems returns deleted films and actors as trash items of both kinds
+func (t *tables) trashItems(filter *domain.TrashFilter) []*domain.TrashItem {
+	var entityType *domain.TrashEntityType
+	if filter != nil {
+		entityType = filter.EntityType
+	}
+
+	items := make([]*domain.TrashItem, 0)
+
+	if entityType == nil || *entityType == domain.TrashEntityTypeFilm {
+		for _, film := range t.films {
+			if film.DeletedAt != nil {
+				items = append(items, &domain.TrashItem{
+					EntityType: domain.TrashEntityTypeFilm,
+					EntityId:   film.Id,
+					Name:       film.Title,
+					DeletedAt:  *film.DeletedAt,
+					DeletedBy:  copyInt64Ptr(film.DeletedBy),
+				})
+			}
+		}
+	}
+
+	if entityType == nil || *entityType == domain.TrashEntityTypeActor {
+		for _, actor := range t.actors {
+			if actor.DeletedAt != nil {
+				items = append(items, &domain.TrashItem{
+					EntityType: domain.TrashEntityTypeActor,
+					EntityId:   actor.Id,
+					Name:       actor.Name,
+					DeletedAt:  *actor.DeletedAt,
+					DeletedBy:  copyInt64Ptr(actor.DeletedBy),
+				})
+			}
+		}
+	}
+
+	return items
+}
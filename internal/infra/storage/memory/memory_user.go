@@ -0,0 +1,9 @@
+package memory
+
+type MemUser struct {
+	Id       int64
+	Email    string
+	Password string
+	Role     string
+	Disabled bool
+}
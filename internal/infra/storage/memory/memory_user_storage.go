@@ -0,0 +1,215 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"github.com/vaberof/vk-internship-task/internal/service/user"
+	"sort"
+	"time"
+)
+
+type MemUserStorage struct {
+	db *Db
+}
+
+func NewMemUserStorage(db *Db) *MemUserStorage {
+	return &MemUserStorage{db: db}
+}
+
+func (s *MemUserStorage) Create(ctx context.Context, email, passwordHash string, role user.UserRole) (*user.User, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	t := s.db.tables
+
+	if _, err := t.findUserByEmail(email); err == nil {
+		return nil, fmt.Errorf("failed to create a user: %w", storage.ErrUserAlreadyExists)
+	}
+	if _, ok := t.rolesPermissions[role]; !ok {
+		return nil, fmt.Errorf("failed to create a user: %w", storage.ErrRoleNotFound)
+	}
+
+	storedUser := t.insertUser(email, passwordHash, role)
+
+	return buildUser(&storedUser), nil
+}
+
+func (s *MemUserStorage) FindById(ctx context.Context, id int64) (*user.User, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	storedUser, ok := s.db.tables.users[id]
+	if !ok {
+		return nil, fmt.Errorf("failed to find user by id: %w", storage.ErrUserNotFound)
+	}
+
+	return buildUser(&storedUser), nil
+}
+
+func (s *MemUserStorage) FindByEmail(ctx context.Context, email string) (*user.User, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	storedUser, err := s.db.tables.findUserByEmail(email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by email: %w", err)
+	}
+
+	return buildUser(&storedUser), nil
+}
+
+func (s *MemUserStorage) List(ctx context.Context, limit, offset int) ([]*user.User, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	users := make([]*user.User, 0, len(s.db.tables.users))
+	for _, storedUser := range s.db.tables.users {
+		users = append(users, buildUser(&storedUser))
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].Id < users[j].Id
+	})
+
+	start := min(offset, len(users))
+	end := min(start+limit, len(users))
+
+	return users[start:end], nil
+}
+
+func (s *MemUserStorage) Count(ctx context.Context) (int64, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	return int64(len(s.db.tables.users)), nil
+}
+
+func (s *MemUserStorage) UpdateRole(ctx context.Context, id int64, role user.UserRole) (*user.User, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	t := s.db.tables
+
+	storedUser, ok := t.users[id]
+	if !ok {
+		return nil, fmt.Errorf("failed to update user role: %w", storage.ErrUserNotFound)
+	}
+	if _, ok = t.rolesPermissions[role]; !ok {
+		return nil, fmt.Errorf("failed to update user role: %w", storage.ErrRoleNotFound)
+	}
+
+	storedUser.Role = role.String()
+	t.users[id] = storedUser
+
+	return buildUser(&storedUser), nil
+}
+
+// Disable disables the user and revokes all of their refresh tokens
+func (s *MemUserStorage) Disable(ctx context.Context, id int64) (*user.User, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	t := s.db.tables
+
+	storedUser, ok := t.users[id]
+	if !ok {
+		return nil, fmt.Errorf("failed to disable a user: %w", storage.ErrUserNotFound)
+	}
+
+	storedUser.Disabled = true
+	t.users[id] = storedUser
+
+	t.revokeRefreshTokens(id)
+
+	return buildUser(&storedUser), nil
+}
+
+// UpdatePassword sets the new password hash and revokes all of the user's refresh tokens
+func (s *MemUserStorage) UpdatePassword(ctx context.Context, id int64, passwordHash string) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	t := s.db.tables
+
+	storedUser, ok := t.users[id]
+	if !ok {
+		return fmt.Errorf("failed to update user password: %w", storage.ErrUserNotFound)
+	}
+
+	storedUser.Password = passwordHash
+	t.users[id] = storedUser
+
+	t.revokeRefreshTokens(id)
+
+	return nil
+}
+
+func (s *MemUserStorage) ListRolePermissions(ctx context.Context) (user.RolePermissions, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	rolePermissions := make(user.RolePermissions)
+	for role, permissions := range s.db.tables.rolesPermissions {
+		// A role without permissions is still known, so that it can be assigned to users
+		rolePermissions[role] = make(map[user.Permission]struct{})
+		for _, permission := range permissions {
+			rolePermissions.Grant(role, permission)
+		}
+	}
+
+	return rolePermissions, nil
+}
+
+func (t *tables) insertUser(email, passwordHash string, role user.UserRole) MemUser {
+	t.sequences.users++
+	storedUser := MemUser{
+		Id:       t.sequences.users,
+		Email:    email,
+		Password: passwordHash,
+		Role:     role.String(),
+	}
+	t.users[storedUser.Id] = storedUser
+	return storedUser
+}
+
+func (t *tables) findUserByEmail(email string) (MemUser, error) {
+	for _, storedUser := range t.users {
+		if storedUser.Email == email {
+			return storedUser, nil
+		}
+	}
+	return MemUser{}, storage.ErrUserNotFound
+}
+
+// checkUser checks that the user referenced by a row exists, like the foreign keys to the users table do.
+// A row may reference no user.
+func (t *tables) checkUser(userId *int64) error {
+	if userId == nil {
+		return nil
+	}
+	if _, ok := t.users[*userId]; !ok {
+		return fmt.Errorf("user %d does not exist", *userId)
+	}
+	return nil
+}
+
+func (t *tables) revokeRefreshTokens(userId int64) {
+	now := time.Now()
+	for tokenHash, token := range t.refreshTokens {
+		if token.UserId == userId && token.RevokedAt == nil {
+			token.RevokedAt = &now
+			t.refreshTokens[tokenHash] = token
+		}
+	}
+}
+
+func buildUser(storedUser *MemUser) *user.User {
+	return &user.User{
+		Id:       storedUser.Id,
+		Email:    storedUser.Email,
+		Password: storedUser.Password,
+		Role:     user.UserRole(storedUser.Role),
+		Disabled: storedUser.Disabled,
+	}
+}
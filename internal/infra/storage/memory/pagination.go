@@ -0,0 +1,196 @@
+package memory
+
+import (
+	"cmp"
+	"fmt"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"time"
+)
+
+// filmSortFieldRelevance orders films found by a search query, it is never accepted from a FilmSort
+const filmSortFieldRelevance = domain.FilmSortField("relevance")
+
+type filmOrderKey struct {
+	field domain.FilmSortField
+	desc  bool
+}
+
+// filmKeyValues are the values of a film films are ordered by, a film cursor holds the same values
+type filmKeyValues struct {
+	id             int64
+	title          string
+	releaseDate    time.Time
+	rating         uint8
+	actorsCount    int64
+	communityScore float64
+	relevance      float64
+}
+
+var filmSortFields = map[domain.FilmSortField]bool{
+	domain.FilmSortFieldId:             true,
+	domain.FilmSortFieldTitle:          true,
+	domain.FilmSortFieldReleaseDate:    true,
+	domain.FilmSortFieldRating:         true,
+	domain.FilmSortFieldActorsCount:    true,
+	domain.FilmSortFieldCommunityScore: true,
+}
+
+// buildFilmOrder turns the sort into order keys, like the postgres storages do. An empty sort means the most relevant
+// films first, if films are searched by a query, and the films with the highest rating first otherwise.
+func buildFilmOrder(sort domain.FilmSort, byRelevance bool) ([]filmOrderKey, error) {
+	var order []filmOrderKey
+	var hasIdKey bool
+
+	for _, key := range sort {
+		if !filmSortFields[key.Field] {
+			return nil, fmt.Errorf("%w: unexpected sort field '%s'", domain.ErrInvalidFilmSort, key.Field)
+		}
+		if !key.Direction.IsValid() {
+			return nil, fmt.Errorf("%w: unexpected sort direction '%s'", domain.ErrInvalidFilmSort, key.Direction)
+		}
+		order = append(order, filmOrderKey{field: key.Field, desc: key.Direction == domain.SortDirectionDesc})
+		hasIdKey = hasIdKey || key.Field == domain.FilmSortFieldId
+	}
+
+	if len(order) == 0 {
+		if byRelevance {
+			order = append(order, filmOrderKey{field: filmSortFieldRelevance, desc: true})
+		} else {
+			order = append(order, filmOrderKey{field: domain.FilmSortFieldRating, desc: true})
+		}
+	}
+
+	// Film id makes the order total, so that pagination by a cursor never skips or repeats films
+	if !hasIdKey {
+		order = append(order, filmOrderKey{field: domain.FilmSortFieldId})
+	}
+
+	return order, nil
+}
+
+// compareFilms tells whether the film with values a goes before (-1) or after (1) the film with values b in the order
+func compareFilms(order []filmOrderKey, a, b *filmKeyValues) int {
+	for _, key := range order {
+		var result int
+		switch key.field {
+		case domain.FilmSortFieldId:
+			result = cmp.Compare(a.id, b.id)
+		case domain.FilmSortFieldTitle:
+			result = cmp.Compare(a.title, b.title)
+		case domain.FilmSortFieldReleaseDate:
+			result = a.releaseDate.Compare(b.releaseDate)
+		case domain.FilmSortFieldRating:
+			result = cmp.Compare(a.rating, b.rating)
+		case domain.FilmSortFieldActorsCount:
+			result = cmp.Compare(a.actorsCount, b.actorsCount)
+		case domain.FilmSortFieldCommunityScore:
+			result = cmp.Compare(a.communityScore, b.communityScore)
+		case filmSortFieldRelevance:
+			result = cmp.Compare(a.relevance, b.relevance)
+		}
+		if key.desc {
+			result = -result
+		}
+		if result != 0 {
+			return result
+		}
+	}
+	return 0
+}
+
+func buildFilmCursorValues(cursor *domain.FilmCursor) *filmKeyValues {
+	return &filmKeyValues{
+		id:             cursor.Id.Int64(),
+		title:          cursor.Title.String(),
+		releaseDate:    cursor.ReleaseDate.Time(),
+		rating:         cursor.Rating.Uint8(),
+		actorsCount:    cursor.ActorsCount,
+		communityScore: cursor.CommunityScore.Float64(),
+		relevance:      cursor.Relevance,
+	}
+}
+
+type actorOrderKey struct {
+	field domain.ActorSortField
+	desc  bool
+}
+
+// actorKeyValues are the values of an actor actors are ordered by, like filmKeyValues
+type actorKeyValues struct {
+	id         int64
+	name       string
+	birthDate  time.Time
+	filmsCount int64
+}
+
+var actorSortFields = map[domain.ActorSortField]bool{
+	domain.ActorSortFieldId:         true,
+	domain.ActorSortFieldName:       true,
+	domain.ActorSortFieldBirthDate:  true,
+	domain.ActorSortFieldFilmsCount: true,
+}
+
+// buildActorOrder turns the sort into order keys. An empty sort means actors ordered by id.
+func buildActorOrder(sort domain.ActorSort) ([]actorOrderKey, error) {
+	var order []actorOrderKey
+	var hasIdKey bool
+
+	for _, key := range sort {
+		if !actorSortFields[key.Field] {
+			return nil, fmt.Errorf("%w: unexpected sort field '%s'", domain.ErrInvalidActorSort, key.Field)
+		}
+		if !key.Direction.IsValid() {
+			return nil, fmt.Errorf("%w: unexpected sort direction '%s'", domain.ErrInvalidActorSort, key.Direction)
+		}
+		order = append(order, actorOrderKey{field: key.Field, desc: key.Direction == domain.SortDirectionDesc})
+		hasIdKey = hasIdKey || key.Field == domain.ActorSortFieldId
+	}
+
+	// Actor id makes the order total, so that pagination by a cursor never skips or repeats actors
+	if !hasIdKey {
+		order = append(order, actorOrderKey{field: domain.ActorSortFieldId})
+	}
+
+	return order, nil
+}
+
+// compareActors tells whether the actor with values a goes before (-1) or after (1) the actor with values b in the order
+func compareActors(order []actorOrderKey, a, b *actorKeyValues) int {
+	for _, key := range order {
+		var result int
+		switch key.field {
+		case domain.ActorSortFieldId:
+			result = cmp.Compare(a.id, b.id)
+		case domain.ActorSortFieldName:
+			result = cmp.Compare(a.name, b.name)
+		case domain.ActorSortFieldBirthDate:
+			result = a.birthDate.Compare(b.birthDate)
+		case domain.ActorSortFieldFilmsCount:
+			result = cmp.Compare(a.filmsCount, b.filmsCount)
+		}
+		if key.desc {
+			result = -result
+		}
+		if result != 0 {
+			return result
+		}
+	}
+	return 0
+}
+
+func buildActorCursorValues(cursor *domain.ActorCursor) *actorKeyValues {
+	return &actorKeyValues{
+		id:         cursor.Id.Int64(),
+		name:       cursor.Name.String(),
+		birthDate:  cursor.BirthDate.Time(),
+		filmsCount: cursor.FilmsCount,
+	}
+}
+
+// pageBounds returns the bounds of the rows left after skipping offset rows and taking up to limit+1 of the rest.
+// The extra row tells that there is a next page, like in the postgres storages.
+func pageBounds(rowsCount, limit, offset int) (int, int) {
+	start := min(offset, rowsCount)
+	end := min(start+limit+1, rowsCount)
+	return start, end
+}
@@ -0,0 +1,133 @@
+package memory
+
+import (
+	"github.com/vaberof/vk-internship-task/pkg/trigram"
+	"strings"
+	"unicode"
+)
+
+// Weights of the words found in the fields of a film. Like the weights of the postgres search vector,
+// title words weigh more than names of credits, and names weigh more than description words.
+const (
+	titleWordWeight       = 1.0
+	nameWordWeight        = 0.4
+	descriptionWordWeight = 0.2
+)
+
+// searchWords splits a search query into lower-cased words, punctuation separates words
+func searchWords(query string) []string {
+	return strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !isWordRune(r)
+	})
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// wordSimilarityThreshold is the least similarity of a text to the search query for the text to match it,
+// the default threshold of the '<%' operator of the Postgres pg_trgm extension
+const wordSimilarityThreshold = 0.6
+
+// searchRelevance returns the relevance of a film to the search query and whether the film is found by it.
+// A word is found in a field if it is a case-insensitive fragment of the field. Like the Postgres search, a film whose title
+// or name of a credit is similar to the query is found, even if some words are not, so typos are tolerated.
+// The similarity of the title to the query adds to the relevance
+func searchRelevance(query string, words []string, title, description string, names []string) (float64, bool) {
+	if len(words) == 0 {
+		return 0, false
+	}
+
+	titleSimilarity := trigram.WordSimilarity(query, title)
+
+	relevance, found := wordsRelevance(words, title, description, names)
+	if !found {
+		if titleSimilarity < wordSimilarityThreshold && !isSimilarToAny(query, names) {
+			return 0, false
+		}
+		relevance = 0
+	}
+
+	return relevance + titleSimilarity, true
+}
+
+// wordsRelevance returns the weight of the fields containing the words, averaged over the words,
+// and whether every word is found in the fields
+func wordsRelevance(words []string, title, description string, names []string) (float64, bool) {
+	title = strings.ToLower(title)
+	description = strings.ToLower(description)
+	lowerNames := strings.ToLower(strings.Join(names, " "))
+
+	var relevance float64
+
+	for _, word := range words {
+		var found bool
+		if strings.Contains(title, word) {
+			relevance += titleWordWeight
+			found = true
+		}
+		if strings.Contains(lowerNames, word) {
+			relevance += nameWordWeight
+			found = true
+		}
+		if strings.Contains(description, word) {
+			relevance += descriptionWordWeight
+			found = true
+		}
+		if !found {
+			return 0, false
+		}
+	}
+
+	return relevance / float64(len(words)), true
+}
+
+func isSimilarToAny(query string, texts []string) bool {
+	for _, text := range texts {
+		if trigram.WordSimilarity(query, text) >= wordSimilarityThreshold {
+			return true
+		}
+	}
+	return false
+}
+
+// highlight wraps the words of the text containing any of the search words in '<b>' and '</b>'
+func highlight(text string, words []string) string {
+	var highlighted strings.Builder
+
+	runes := []rune(text)
+	for start := 0; start < len(runes); {
+		end := start + 1
+		if !isWordRune(runes[start]) {
+			for end < len(runes) && !isWordRune(runes[end]) {
+				end++
+			}
+			highlighted.WriteString(string(runes[start:end]))
+			start = end
+			continue
+		}
+
+		for end < len(runes) && isWordRune(runes[end]) {
+			end++
+		}
+
+		textWord := string(runes[start:end])
+		if containsAnyWord(strings.ToLower(textWord), words) {
+			highlighted.WriteString("<b>" + textWord + "</b>")
+		} else {
+			highlighted.WriteString(textWord)
+		}
+		start = end
+	}
+
+	return highlighted.String()
+}
+
+func containsAnyWord(text string, words []string) bool {
+	for _, word := range words {
+		if strings.Contains(text, word) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+package memory_test
+
+import (
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/memory"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/tests/storagetest"
+	"testing"
+)
+
+func TestStorageContract(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) *storagetest.Storages {
+		db := memory.NewDb()
+
+		return &storagetest.Storages{
+			Films:          memory.NewMemFilmStorage(db),
+			Actors:         memory.NewMemActorStorage(db),
+			Genres:         memory.NewMemGenreStorage(db),
+			Users:          memory.NewMemUserStorage(db),
+			RefreshTokens:  memory.NewMemRefreshTokenStorage(db),
+			Trash:          memory.NewMemTrashStorage(db),
+			FilmRevisions:  memory.NewMemFilmRevisionStorage(db),
+			ActorRevisions: memory.NewMemActorRevisionStorage(db),
+			Audit:          memory.NewMemAuditStorage(db),
+			FilmImport:     memory.NewMemFilmImportStorage(db),
+			FilmLists:      memory.NewMemFilmListStorage(db),
+			Reviews:        memory.NewMemReviewStorage(db),
+		}
+	})
+}
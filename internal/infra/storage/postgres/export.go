@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+const exportFetchSize = 1000
+
+// fetchCursor declares a server-side cursor for the query within the transaction and passes its rows to scan
+// in batches of exportFetchSize, so that only a single batch of rows is held in memory however many rows there are
+func fetchCursor(ctx context.Context, tx *sql.Tx, cursorName, query string, scan func(rows *sql.Rows) error) error {
+	if _, err := tx.ExecContext(ctx, "DECLARE "+cursorName+" NO SCROLL CURSOR FOR "+query); err != nil {
+		return fmt.Errorf("failed to declare cursor '%s': %w", cursorName, err)
+	}
+
+	fetchQuery := fmt.Sprintf("FETCH FORWARD %d FROM %s", exportFetchSize, cursorName)
+
+	for {
+		fetched, err := fetchCursorBatch(ctx, tx, fetchQuery, scan)
+		if err != nil {
+			return err
+		}
+
+		// A batch smaller than requested is the last one
+		if fetched < exportFetchSize {
+			return nil
+		}
+	}
+}
+
+func fetchCursorBatch(ctx context.Context, tx *sql.Tx, fetchQuery string, scan func(rows *sql.Rows) error) (int, error) {
+	rows, err := tx.QueryContext(ctx, fetchQuery)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch cursor: %w", err)
+	}
+	defer rows.Close()
+
+	var fetched int
+	for rows.Next() {
+		fetched++
+		if err = scan(rows); err != nil {
+			return 0, err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to fetch cursor: %w", err)
+	}
+
+	return fetched, nil
+}
@@ -0,0 +1,52 @@
+package pgauth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"time"
+)
+
+type PgRefreshTokenStorage struct {
+	db *sqlx.DB
+}
+
+func NewPgRefreshTokenStorage(db *sqlx.DB) *PgRefreshTokenStorage {
+	return &PgRefreshTokenStorage{db: db}
+}
+
+func (s *PgRefreshTokenStorage) Create(ctx context.Context, userId int64, tokenHash string, expiresAt time.Time) error {
+	query := `
+			INSERT INTO refresh_tokens (
+			                    user_id,
+			                    token_hash,
+			                    expires_at
+				) VALUES ($1, $2, $3)
+`
+	if _, err := s.db.ExecContext(ctx, query, userId, tokenHash, expiresAt); err != nil {
+		return fmt.Errorf("failed to create a refresh token: %w", err)
+	}
+	return nil
+}
+
+func (s *PgRefreshTokenStorage) Revoke(ctx context.Context, tokenHash string) (int64, error) {
+	// A single statement guarantees that concurrent requests cannot revoke the same token twice
+	query := `
+			UPDATE refresh_tokens
+			SET revoked_at = NOW()
+			WHERE token_hash = $1 AND revoked_at IS NULL AND expires_at > NOW()
+			RETURNING user_id
+`
+	var userId int64
+	row := s.db.QueryRowContext(ctx, query, tokenHash)
+	if err := row.Scan(&userId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("failed to revoke a refresh token: %w", storage.ErrRefreshTokenNotFound)
+		}
+		return 0, fmt.Errorf("failed to revoke a refresh token: %w", err)
+	}
+	return userId, nil
+}
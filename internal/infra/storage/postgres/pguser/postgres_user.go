@@ -5,4 +5,5 @@ type PgUser struct {
 	Email    string
 	Password string
 	Role     string
+	Disabled bool
 }
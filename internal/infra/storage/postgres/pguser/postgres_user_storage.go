@@ -1,14 +1,21 @@
 package pguser
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/vaberof/vk-internship-task/internal/infra/storage"
 	"github.com/vaberof/vk-internship-task/internal/service/user"
 )
 
+const (
+	foreignKeyViolationErrCode = "23503"
+	uniqueViolationErrCode     = "23505"
+)
+
 type PgUserStorage struct {
 	db *sqlx.DB
 }
@@ -17,23 +24,66 @@ func NewPgUserStorage(db *sqlx.DB) *PgUserStorage {
 	return &PgUserStorage{db: db}
 }
 
-func (s *PgUserStorage) FindByEmail(email string) (*user.User, error) {
+func (s *PgUserStorage) Create(ctx context.Context, email, passwordHash string, role user.UserRole) (*user.User, error) {
+	query := `
+			INSERT INTO users (
+			                    email,
+			                    password,
+			                    role
+				) VALUES ($1, $2, $3)
+				RETURNING
+					id,
+					email,
+					password,
+					role,
+					disabled
+`
+	var user PgUser
+	row := s.db.QueryRowContext(ctx, query, email, passwordHash, role.String())
+	if err := scanUser(row, &user); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolationErrCode {
+			return nil, fmt.Errorf("failed to create a user: %w", storage.ErrUserAlreadyExists)
+		}
+		return nil, fmt.Errorf("failed to create a user: %w", err)
+	}
+	return buildUser(&user), nil
+}
+
+func (s *PgUserStorage) FindById(ctx context.Context, id int64) (*user.User, error) {
 	query := `
 			SELECT id,
 			       email,
 			       password,
-			       role 
+			       role,
+			       disabled
+			FROM users
+			WHERE id=$1
+`
+	var user PgUser
+	row := s.db.QueryRowContext(ctx, query, id)
+	if err := scanUser(row, &user); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to find user by id: %w", storage.ErrUserNotFound)
+		}
+		return nil, fmt.Errorf("failed to find user by id: %w", err)
+	}
+	return buildUser(&user), nil
+}
+
+func (s *PgUserStorage) FindByEmail(ctx context.Context, email string) (*user.User, error) {
+	query := `
+			SELECT id,
+			       email,
+			       password,
+			       role,
+			       disabled
 			FROM users
 			WHERE email=$1
 `
 	var user PgUser
-	row := s.db.QueryRow(query, email)
-	if err := row.Scan(
-		&user.Id,
-		&user.Email,
-		&user.Password,
-		&user.Role,
-	); err != nil {
+	row := s.db.QueryRowContext(ctx, query, email)
+	if err := scanUser(row, &user); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("failed to find user by email: %w", storage.ErrUserNotFound)
 		}
@@ -42,11 +92,222 @@ func (s *PgUserStorage) FindByEmail(email string) (*user.User, error) {
 	return buildUser(&user), nil
 }
 
+func (s *PgUserStorage) List(ctx context.Context, limit, offset int) ([]*user.User, error) {
+	query := `
+			SELECT id,
+			       email,
+			       password,
+			       role,
+			       disabled
+			FROM users
+			ORDER BY id
+			LIMIT $1 OFFSET $2
+`
+	rows, err := s.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*user.User
+	for rows.Next() {
+		var user PgUser
+		if err = scanUser(rows, &user); err != nil {
+			return nil, fmt.Errorf("failed to list users: %w", err)
+		}
+		users = append(users, buildUser(&user))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	return users, nil
+}
+
+func (s *PgUserStorage) Count(ctx context.Context) (int64, error) {
+	query := `
+			SELECT COUNT(*) FROM users
+`
+	var count int64
+	if err := s.db.QueryRowContext(ctx, query).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count users: %w", err)
+	}
+	return count, nil
+}
+
+func (s *PgUserStorage) UpdateRole(ctx context.Context, id int64, role user.UserRole) (*user.User, error) {
+	query := `
+			UPDATE users
+			SET role = $1
+			WHERE id = $2
+			RETURNING
+				id,
+				email,
+				password,
+				role,
+				disabled
+`
+	var user PgUser
+	row := s.db.QueryRowContext(ctx, query, role.String(), id)
+	if err := scanUser(row, &user); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to update user role: %w", storage.ErrUserNotFound)
+		}
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolationErrCode {
+			return nil, fmt.Errorf("failed to update user role: %w", storage.ErrRoleNotFound)
+		}
+		return nil, fmt.Errorf("failed to update user role: %w", err)
+	}
+	return buildUser(&user), nil
+}
+
+// Disable disables the user and revokes all of their refresh tokens
+func (s *PgUserStorage) Disable(ctx context.Context, id int64) (*user.User, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction while disabling user: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+			UPDATE users
+			SET disabled = TRUE
+			WHERE id = $1
+			RETURNING
+				id,
+				email,
+				password,
+				role,
+				disabled
+`
+	var user PgUser
+	row := tx.QueryRowContext(ctx, query, id)
+	if err = scanUser(row, &user); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to disable a user: %w", storage.ErrUserNotFound)
+		}
+		return nil, fmt.Errorf("failed to disable a user: %w", err)
+	}
+
+	if err = s.revokeRefreshTokens(ctx, tx, id); err != nil {
+		return nil, fmt.Errorf("failed to disable a user: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction while disabling user: %w", err)
+	}
+
+	return buildUser(&user), nil
+}
+
+// UpdatePassword sets the new password hash and revokes all of the user's refresh tokens
+func (s *PgUserStorage) UpdatePassword(ctx context.Context, id int64, passwordHash string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction while updating user password: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+			UPDATE users
+			SET password = $1
+			WHERE id = $2
+`
+	result, err := tx.ExecContext(ctx, query, passwordHash, id)
+	if err != nil {
+		return fmt.Errorf("failed to update user password: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update user password: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("failed to update user password: %w", storage.ErrUserNotFound)
+	}
+
+	if err = s.revokeRefreshTokens(ctx, tx, id); err != nil {
+		return fmt.Errorf("failed to update user password: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction while updating user password: %w", err)
+	}
+
+	return nil
+}
+
+func (s *PgUserStorage) ListRolePermissions(ctx context.Context) (user.RolePermissions, error) {
+	query := `
+			SELECT r.name,
+			       rp.permission
+			FROM roles AS r
+			    LEFT JOIN roles_permissions AS rp ON r.name = rp.role
+`
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list role permissions: %w", err)
+	}
+	defer rows.Close()
+
+	rolePermissions := make(user.RolePermissions)
+	for rows.Next() {
+		var role string
+		var permission sql.NullString
+		if err = rows.Scan(&role, &permission); err != nil {
+			return nil, fmt.Errorf("failed to list role permissions: %w", err)
+		}
+
+		// A role without permissions is still known, so that it can be assigned to users
+		if _, ok := rolePermissions[user.UserRole(role)]; !ok {
+			rolePermissions[user.UserRole(role)] = make(map[user.Permission]struct{})
+		}
+		if permission.Valid {
+			rolePermissions.Grant(user.UserRole(role), user.Permission(permission.String))
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list role permissions: %w", err)
+	}
+
+	return rolePermissions, nil
+}
+
+func (s *PgUserStorage) revokeRefreshTokens(ctx context.Context, tx *sql.Tx, userId int64) error {
+	query := `
+			UPDATE refresh_tokens
+			SET revoked_at = NOW()
+			WHERE user_id = $1 AND revoked_at IS NULL
+`
+	if _, err := tx.ExecContext(ctx, query, userId); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanUser(row rowScanner, user *PgUser) error {
+	return row.Scan(
+		&user.Id,
+		&user.Email,
+		&user.Password,
+		&user.Role,
+		&user.Disabled,
+	)
+}
+
 func buildUser(postgresUser *PgUser) *user.User {
 	return &user.User{
 		Id:       postgresUser.Id,
 		Email:    postgresUser.Email,
 		Password: postgresUser.Password,
 		Role:     user.UserRole(postgresUser.Role),
+		Disabled: postgresUser.Disabled,
 	}
 }
@@ -1,6 +1,9 @@
 package postgres
 
-import "time"
+import (
+	"database/sql"
+	"time"
+)
 
 type PgActor struct {
 	Id        int64
@@ -8,4 +11,24 @@ type PgActor struct {
 	Sex       uint8
 	BirthDate time.Time
 	Films     []*PgFilm
+	CreatedBy sql.NullInt64
+	UpdatedBy sql.NullInt64
+	Version   int64
+}
+
+// PgNullableActor is an actor read through an outer join, so all of its columns may be NULL
+type PgNullableActor struct {
+	Id        sql.NullInt64
+	Name      sql.NullString
+	Sex       sql.NullInt16
+	BirthDate sql.NullTime
+}
+
+func (a *PgNullableActor) PgActor() *PgActor {
+	return &PgActor{
+		Id:        a.Id.Int64,
+		Name:      a.Name.String,
+		Sex:       uint8(a.Sex.Int16),
+		BirthDate: a.BirthDate.Time,
+	}
 }
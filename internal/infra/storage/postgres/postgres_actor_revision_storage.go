@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+)
+
+type PgActorRevisionStorage struct {
+	db *sqlx.DB
+}
+
+func NewPgActorRevisionStorage(db *sqlx.DB) *PgActorRevisionStorage {
+	return &PgActorRevisionStorage{db: db}
+}
+
+// List lists revisions of an actor starting with the latest one
+func (s *PgActorRevisionStorage) List(ctx context.Context, actorId domain.ActorId, limit, offset int) ([]*domain.ActorRevision, error) {
+	query := `
+			SELECT actor_id,
+			       revision,
+			       user_id,
+			       name,
+			       sex,
+			       birthdate,
+			       created_at
+			FROM actor_revisions
+			WHERE actor_id=$1
+			ORDER BY revision DESC
+			LIMIT $2 OFFSET $3
+`
+	rows, err := s.db.QueryContext(ctx, query, actorId, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list actor revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []*domain.ActorRevision
+
+	for rows.Next() {
+		var revision PgActorRevision
+		if err = scanActorRevision(rows, &revision); err != nil {
+			return nil, fmt.Errorf("failed to list actor revisions: %w", err)
+		}
+		revisions = append(revisions, buildDomainActorRevision(&revision))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list actor revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+func (s *PgActorRevisionStorage) Count(ctx context.Context, actorId domain.ActorId) (int64, error) {
+	query := `
+			SELECT COUNT(*) FROM actor_revisions
+			WHERE actor_id=$1
+`
+	var count int64
+	if err := s.db.QueryRowContext(ctx, query, actorId).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count actor revisions: %w", err)
+	}
+	return count, nil
+}
+
+func (s *PgActorRevisionStorage) Get(ctx context.Context, actorId domain.ActorId, revision int64) (*domain.ActorRevision, error) {
+	query := `
+			SELECT actor_id,
+			       revision,
+			       user_id,
+			       name,
+			       sex,
+			       birthdate,
+			       created_at
+			FROM actor_revisions
+			WHERE actor_id=$1 AND revision=$2
+`
+	var actorRevision PgActorRevision
+
+	row := s.db.QueryRowContext(ctx, query, actorId, revision)
+	if err := scanActorRevision(row, &actorRevision); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to get an actor revision: %w", storage.ErrActorRevisionNotFound)
+		}
+		return nil, fmt.Errorf("failed to get an actor revision: %w", err)
+	}
+
+	return buildDomainActorRevision(&actorRevision), nil
+}
+
+// insertActorRevision is called by PgActorStorage within the transaction of the change that has produced the revision
+func insertActorRevision(ctx context.Context, tx *sql.Tx, revision *domain.ActorRevision) error {
+	query := `
+			INSERT INTO actor_revisions (
+			                             actor_id,
+			                             revision,
+			                             user_id,
+			                             name,
+			                             sex,
+			                             birthdate
+				) VALUES ($1, $2, $3, $4, $5, $6)
+`
+	_, err := tx.ExecContext(ctx, query,
+		revision.ActorId,
+		revision.Revision,
+		revision.UserId,
+		revision.Name,
+		revision.Sex,
+		revision.BirthDate.Time(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert an actor revision: %w", err)
+	}
+	return nil
+}
+
+func scanActorRevision(row rowScanner, revision *PgActorRevision) error {
+	return row.Scan(
+		&revision.ActorId,
+		&revision.Revision,
+		&revision.UserId,
+		&revision.Name,
+		&revision.Sex,
+		&revision.BirthDate,
+		&revision.CreatedAt,
+	)
+}
+
+func buildDomainActorRevision(postgresRevision *PgActorRevision) *domain.ActorRevision {
+	return &domain.ActorRevision{
+		ActorId:   domain.ActorId(postgresRevision.ActorId),
+		Revision:  postgresRevision.Revision,
+		UserId:    nullInt64Ptr(postgresRevision.UserId),
+		Name:      domain.ActorName(postgresRevision.Name),
+		Sex:       domain.ActorSex(postgresRevision.Sex),
+		BirthDate: domain.ActorBirthDate(postgresRevision.BirthDate),
+		CreatedAt: postgresRevision.CreatedAt,
+	}
+}
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -8,6 +9,8 @@ import (
 	"github.com/lib/pq"
 	"github.com/vaberof/vk-internship-task/internal/domain"
 	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"github.com/vaberof/vk-internship-task/pkg/database/savepoint"
+	"strings"
 	"time"
 )
 
@@ -19,42 +22,101 @@ func NewPgActorStorage(db *sqlx.DB) *PgActorStorage {
 	return &PgActorStorage{db: db}
 }
 
-func (s *PgActorStorage) Create(name domain.ActorName, sex domain.ActorSex, birthDate domain.ActorBirthDate) (*domain.Actor, error) {
+func (s *PgActorStorage) Create(ctx context.Context, name domain.ActorName, sex domain.ActorSex, birthDate domain.ActorBirthDate, createdBy *int64) (*domain.Actor, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction while creating actor: %w", err)
+	}
+	defer tx.Rollback()
+
+	domainActor, err := s.createActor(ctx, tx, name, sex, birthDate, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction while creating actor: %w", err)
+	}
+
+	return domainActor, nil
+}
+
+// createActor creates the actor and records the audit event within the given transaction
+func (s *PgActorStorage) createActor(ctx context.Context, tx *sql.Tx, name domain.ActorName, sex domain.ActorSex, birthDate domain.ActorBirthDate, createdBy *int64) (*domain.Actor, error) {
 	var actor PgActor
 	query := `
 			INSERT INTO actors (
 			                    name,
 			                    sex,
-			                    birthdate
-				) VALUES ($1, $2, $3)
+			                    birthdate,
+			                    created_by
+				) VALUES ($1, $2, $3, $4)
 				RETURNING 
 					id, 
 					name,
 					sex, 
-				    birthdate
+				    birthdate,
+				    created_by,
+				    updated_by,
+				    version
 `
-	row := s.db.QueryRow(query, name, sex, birthDate.Time())
+	row := tx.QueryRowContext(ctx, query, name, sex, birthDate.Time(), createdBy)
 	if err := row.Scan(
 		&actor.Id,
 		&actor.Name,
 		&actor.Sex,
 		&actor.BirthDate,
+		&actor.CreatedBy,
+		&actor.UpdatedBy,
+		&actor.Version,
 	); err != nil {
 		return nil, fmt.Errorf("failed to create an actor: %w", err)
 	}
-	return buildDomainActor(&actor), nil
+
+	domainActor := buildDomainActor(&actor)
+
+	auditEvent, err := domain.NewActorAuditEvent(createdBy, domain.AuditOperationCreate, nil, domainActor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create an actor: %w", err)
+	}
+	if err = insertAuditEvent(ctx, tx, auditEvent); err != nil {
+		return nil, fmt.Errorf("failed to create an actor: %w", err)
+	}
+	if err = insertActorRevision(ctx, tx, domain.NewActorRevision(domainActor, createdBy)); err != nil {
+		return nil, fmt.Errorf("failed to create an actor: %w", err)
+	}
+
+	return domainActor, nil
 }
 
-func (s *PgActorStorage) Update(id domain.ActorId, name *domain.ActorName, sex *domain.ActorSex, birthDate *domain.ActorBirthDate) (*domain.Actor, error) {
-	tx, err := s.db.Begin()
+func (s *PgActorStorage) Update(ctx context.Context, id domain.ActorId, version *int64, name *domain.ActorName, sex *domain.ActorSex, birthDate *domain.ActorBirthDate, updatedBy *int64) (*domain.Actor, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start transaction while updating actor: %w", err)
 	}
 	defer tx.Rollback()
 
-	_, err = tx.Exec("LOCK TABLE actors IN SHARE ROW EXCLUSIVE MODE")
+	domainActor, err := s.updateActor(ctx, tx, id, version, name, sex, birthDate, updatedBy)
 	if err != nil {
-		return nil, fmt.Errorf("failed to lock 'actors' table while updating actor: %w", err)
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction while updating actor: %w", err)
+	}
+
+	return domainActor, nil
+}
+
+// updateActor updates the actor and records the audit event within the given transaction
+func (s *PgActorStorage) updateActor(ctx context.Context, tx *sql.Tx, id domain.ActorId, version *int64, name *domain.ActorName, sex *domain.ActorSex, birthDate *domain.ActorBirthDate, updatedBy *int64) (*domain.Actor, error) {
+	if err := s.lockActor(ctx, tx, id, version); err != nil {
+		return nil, fmt.Errorf("failed to update actor: %w", err)
+	}
+
+	actorBefore, err := s.getActor(ctx, tx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update actor: %w", err)
 	}
 
 	var actor PgActor
@@ -63,13 +125,18 @@ func (s *PgActorStorage) Update(id domain.ActorId, name *domain.ActorName, sex *
 		UPDATE actors
 					SET	name=COALESCE($1, name),
 						sex=COALESCE($2, sex),
-						birthdate=COALESCE($3, birthdate)
-					WHERE id=$4
+						birthdate=COALESCE($3, birthdate),
+						updated_by=$4,
+						version=version + 1
+					WHERE id=$5
 		RETURNING
 			id,
 			name,
 			sex,
-			birthdate
+			birthdate,
+			created_by,
+			updated_by,
+			version
 `
 
 	var convBirthdate *time.Time
@@ -78,13 +145,16 @@ func (s *PgActorStorage) Update(id domain.ActorId, name *domain.ActorName, sex *
 		convBirthdate = &convDomainBirthdate
 	}
 
-	row := tx.QueryRow(query, name, sex, convBirthdate, id)
+	row := tx.QueryRowContext(ctx, query, name, sex, convBirthdate, updatedBy, id)
 
 	if err = row.Scan(
 		&actor.Id,
 		&actor.Name,
 		&actor.Sex,
 		&actor.BirthDate,
+		&actor.CreatedBy,
+		&actor.UpdatedBy,
+		&actor.Version,
 	); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("failed to update actor in database: %w", storage.ErrActorNotFound)
@@ -92,126 +162,246 @@ func (s *PgActorStorage) Update(id domain.ActorId, name *domain.ActorName, sex *
 		return nil, fmt.Errorf("failed to update actor in database: %w", err)
 	}
 
-	queryFilms := `
-		SELECT f.id,
-		       f.title,
-		       f.description,
-		       f.release_date,
-		       f.rating
-		       FROM films AS f
-		INNER JOIN films_actors AS fa ON f.id = fa.film_id
-		WHERE fa.actor_id=$1
-`
+	actorFilms, err := s.getActorFilms(ctx, tx, actor.Id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update actor: %w", err)
+	}
+	actor.Films = actorFilms
+
+	domainActor := buildDomainActor(&actor)
 
-	rows, err := tx.Query(queryFilms, actor.Id)
+	auditEvent, err := domain.NewActorAuditEvent(updatedBy, domain.AuditOperationUpdate, buildDomainActor(actorBefore), domainActor)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update actor: %w", err)
 	}
-	defer rows.Close()
+	if err = insertAuditEvent(ctx, tx, auditEvent); err != nil {
+		return nil, fmt.Errorf("failed to update actor: %w", err)
+	}
+	if err = insertActorRevision(ctx, tx, domain.NewActorRevision(domainActor, updatedBy)); err != nil {
+		return nil, fmt.Errorf("failed to update actor: %w", err)
+	}
 
-	for rows.Next() {
-		var film PgFilm
+	return domainActor, nil
+}
 
-		if err = rows.Scan(
-			&film.Id,
-			&film.Title,
-			&film.Description,
-			&film.ReleaseDate,
-			&film.Rating,
-		); err != nil {
-			return nil, fmt.Errorf("failed to create a film: %w", err)
-		}
+func (s *PgActorStorage) Delete(ctx context.Context, id domain.ActorId, version *int64, deletedBy *int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction while deleting actor: %w", err)
+	}
+	defer tx.Rollback()
 
-		actor.Films = append(actor.Films, &film)
+	if err = s.deleteActor(ctx, tx, id, version, deletedBy); err != nil {
+		return err
 	}
 
 	if err = tx.Commit(); err != nil {
-		return nil, fmt.Errorf("failed to commit transaction while updating actor: %w", err)
+		return fmt.Errorf("failed to commit transaction while deleting actor: %w", err)
 	}
 
-	return buildDomainActor(&actor), nil
+	return nil
 }
 
-func (s *PgActorStorage) Delete(id domain.ActorId) error {
-	query := `DELETE FROM actors WHERE id=$1`
-	result, err := s.db.Exec(query, id)
+// deleteActor moves the actor to the trash and records the audit event within the given transaction.
+// Credits of the actor are kept, so that the actor is restored back into the films
+func (s *PgActorStorage) deleteActor(ctx context.Context, tx *sql.Tx, id domain.ActorId, version *int64, deletedBy *int64) error {
+	if err := s.lockActor(ctx, tx, id, version); err != nil {
+		return fmt.Errorf("failed to delete actor: %w", err)
+	}
+
+	actorBefore, err := s.getActor(ctx, tx, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete actor: %w", err)
+	}
+
+	query := `UPDATE actors SET deleted_at=NOW(), deleted_by=$2 WHERE id=$1 AND deleted_at IS NULL`
+	result, err := tx.ExecContext(ctx, query, id, deletedBy)
 	if err != nil {
 		return fmt.Errorf("failed to delete actor: %w", err)
 	}
 	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
 		return fmt.Errorf("failed to delete actor: %w", storage.ErrActorNotFound)
 	}
+
+	auditEvent, err := domain.NewActorAuditEvent(deletedBy, domain.AuditOperationDelete, buildDomainActor(actorBefore), nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete actor: %w", err)
+	}
+	if err = insertAuditEvent(ctx, tx, auditEvent); err != nil {
+		return fmt.Errorf("failed to delete actor: %w", err)
+	}
+
 	return nil
 }
 
-func (s *PgActorStorage) List(limit, offset int) ([]*domain.Actor, error) {
-	limitOffsetParams := fmt.Sprintf(" LIMIT %d OFFSET %d ", limit, offset)
+// Restore brings the actor back from the trash into the films they had, and records the audit event
+func (s *PgActorStorage) Restore(ctx context.Context, id domain.ActorId, restoredBy *int64) (*domain.Actor, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction while restoring actor: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE actors SET deleted_at=NULL, deleted_by=NULL, updated_by=$2 WHERE id=$1 AND deleted_at IS NOT NULL`
+	result, err := tx.ExecContext(ctx, query, id, restoredBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore actor: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return nil, fmt.Errorf("failed to restore actor: %w", storage.ErrActorNotFound)
+	}
+
+	actor, err := s.getActor(ctx, tx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore actor: %w", err)
+	}
+
+	domainActor := buildDomainActor(actor)
+
+	auditEvent, err := domain.NewActorAuditEvent(restoredBy, domain.AuditOperationRestore, nil, domainActor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore actor: %w", err)
+	}
+	if err = insertAuditEvent(ctx, tx, auditEvent); err != nil {
+		return nil, fmt.Errorf("failed to restore actor: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction while restoring actor: %w", err)
+	}
+
+	return domainActor, nil
+}
+
+func (s *PgActorStorage) GetById(ctx context.Context, id domain.ActorId) (*domain.Actor, error) {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction while getting actor: %w", err)
+	}
+	defer tx.Rollback()
+
+	actor, err := s.getActor(ctx, tx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get actor: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction while getting actor: %w", err)
+	}
+
+	return buildDomainActor(actor), nil
+}
+
+func (s *PgActorStorage) List(ctx context.Context, filter *domain.ActorFilter, sort domain.ActorSort, cursor *domain.ActorCursor, limit, offset int) ([]*domain.Actor, *domain.ActorCursor, error) {
+	orderColumns, err := buildActorOrderColumns(sort)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list actors: %w", err)
+	}
+	orderParam := buildOrderParam(orderColumns)
+	limitOffsetParams := fmt.Sprintf(" LIMIT %d OFFSET %d ", limit+1, offset)
+
+	whereParam, args := buildActorFilterCondition(filter)
+
+	var keysetParam string
+	if cursor != nil {
+		keysetCondition, keysetArgs := buildKeysetCondition(orderColumns, buildActorCursorValues(orderColumns, cursor), len(args)+1)
+		keysetParam = " WHERE " + keysetCondition
+		args = append(args, keysetArgs...)
+	}
 
 	query := `
 			SELECT a.id,
 			       a.name,
 			       a.sex,
 			       a.birthdate,
+			       a.created_by,
+			       a.updated_by,
+			       a.version,
 			       f.id,
 			       f.title,
 			       f.description,
 			       f.release_date,
 			       f.rating
-		    FROM (SELECT * FROM actors` + limitOffsetParams + `) AS a
-		    INNER JOIN films_actors AS fa ON a.id = fa.actor_id
-		    INNER JOIN films AS f ON f.id = fa.film_id
-`
+		    FROM (SELECT *
+		          FROM (SELECT a.*,
+		                       (SELECT COUNT(*) FROM films_actors AS fa INNER JOIN films AS f ON f.id = fa.film_id WHERE fa.actor_id = a.id AND fa.role = 'actor' AND f.deleted_at IS NULL) AS films_count
+		                FROM actors AS a` + whereParam + `) AS a` + keysetParam + orderParam + limitOffsetParams + `) AS a
+		    LEFT JOIN films_actors AS fa ON a.id = fa.actor_id AND fa.role = 'actor'
+		    LEFT JOIN films AS f ON f.id = fa.film_id AND f.deleted_at IS NULL
+` + orderParam
 
 	var actors []*PgActor
 
-	rows, err := s.db.Query(query)
+	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list actors: %w", err)
+		return nil, nil, fmt.Errorf("failed to list actors: %w", err)
 	}
 	defer rows.Close()
 
 	for rows.Next() {
 		var actor PgActor
-		var film PgFilm
+		var film PgNullableFilm
 
 		if err = rows.Scan(
 			&actor.Id,
 			&actor.Name,
 			&actor.Sex,
 			&actor.BirthDate,
+			&actor.CreatedBy,
+			&actor.UpdatedBy,
+			&actor.Version,
 			&film.Id,
 			&film.Title,
 			&film.Description,
 			&film.ReleaseDate,
 			&film.Rating,
 		); err != nil {
-			return nil, fmt.Errorf("failed to list actors: %w", err)
+			return nil, nil, fmt.Errorf("failed to list actors: %w", err)
 		}
 
-		var actorExists bool
-		for _, pgActor := range actors {
-			if pgActor.Id == actor.Id {
-				pgActor.Films = append(pgActor.Films, &film)
-				actorExists = true
-				break
-			}
-		}
-		if !actorExists {
-			actor.Films = append(actor.Films, &film)
+		// Rows of the same actor follow each other, because the order of actors always ends with their id
+		if len(actors) == 0 || actors[len(actors)-1].Id != actor.Id {
 			actors = append(actors, &actor)
 		}
+
+		// An actor without films is joined with a single row of NULL film columns, deleted films are joined as NULL columns too
+		if film.Id.Valid {
+			lastActor := actors[len(actors)-1]
+			lastActor.Films = append(lastActor.Films, film.PgFilm())
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to list actors: %w", err)
 	}
 
-	return buildDomainActors(actors), nil
+	domainActors, nextCursor := buildActorsPage(actors, limit)
+
+	return domainActors, nextCursor, nil
 }
 
-func (s *PgActorStorage) IsExists(id domain.ActorId) (bool, error) {
+func (s *PgActorStorage) Count(ctx context.Context, filter *domain.ActorFilter) (int64, error) {
+	whereParam, args := buildActorFilterCondition(filter)
+
+	query := `SELECT COUNT(*) FROM actors AS a` + whereParam
+
+	var count int64
+
+	err := s.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count actors: %w", err)
+	}
+
+	return count, nil
+}
+
+func (s *PgActorStorage) IsExists(ctx context.Context, id domain.ActorId) (bool, error) {
 	query := `
 			SELECT id FROM actors 
-			WHERE id=$1
+			WHERE id=$1 AND deleted_at IS NULL
 `
 	var actorId int64
-	err := s.db.QueryRow(query, id).Scan(&actorId)
+	err := s.db.QueryRowContext(ctx, query, id).Scan(&actorId)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return false, nil
@@ -221,15 +411,15 @@ func (s *PgActorStorage) IsExists(id domain.ActorId) (bool, error) {
 	return true, nil
 }
 
-func (s *PgActorStorage) AreExists(ids []domain.ActorId) (bool, error) {
+func (s *PgActorStorage) AreExists(ctx context.Context, ids []domain.ActorId) (bool, error) {
 	query := `
 			SELECT COUNT(*) FROM actors 
-			WHERE id=ANY($1)
+			WHERE id=ANY($1) AND deleted_at IS NULL
 `
 
 	var idsCount int64
 
-	err := s.db.QueryRow(query, pq.Array(ids)).Scan(&idsCount)
+	err := s.db.QueryRowContext(ctx, query, pq.Array(ids)).Scan(&idsCount)
 	if err != nil {
 		return false, fmt.Errorf("failed to check whether actors exists or not: %w", err)
 	}
@@ -241,6 +431,358 @@ func (s *PgActorStorage) AreExists(ids []domain.ActorId) (bool, error) {
 	return true, nil
 }
 
+// Export reads actors with their films through a server-side cursor, so that only a batch of rows is held in memory
+func (s *PgActorStorage) Export(ctx context.Context, fn func(actor *domain.Actor) error) error {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("failed to start transaction while exporting actors: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+			SELECT a.id,
+			       a.name,
+			       a.sex,
+			       a.birthdate,
+			       a.created_by,
+			       a.updated_by,
+			       a.version,
+			       f.id,
+			       f.title,
+			       f.description,
+			       f.release_date,
+			       f.rating
+		    FROM actors AS a
+		    LEFT JOIN films_actors AS fa ON a.id = fa.actor_id AND fa.role = 'actor'
+		    LEFT JOIN films AS f ON f.id = fa.film_id AND f.deleted_at IS NULL
+		    WHERE a.deleted_at IS NULL
+		    ORDER BY a.id, f.id
+`
+
+	var actor *PgActor
+
+	err = fetchCursor(ctx, tx, "actors_export", query, func(rows *sql.Rows) error {
+		var rowActor PgActor
+		var film PgNullableFilm
+
+		if err := rows.Scan(
+			&rowActor.Id,
+			&rowActor.Name,
+			&rowActor.Sex,
+			&rowActor.BirthDate,
+			&rowActor.CreatedBy,
+			&rowActor.UpdatedBy,
+			&rowActor.Version,
+			&film.Id,
+			&film.Title,
+			&film.Description,
+			&film.ReleaseDate,
+			&film.Rating,
+		); err != nil {
+			return err
+		}
+
+		// Rows of the same actor follow each other, so the previous actor is complete once the id changes
+		if actor == nil || actor.Id != rowActor.Id {
+			if actor != nil {
+				if err := fn(buildDomainActor(actor)); err != nil {
+					return err
+				}
+			}
+			actor = &rowActor
+		}
+
+		// An actor without films is joined with a single row of NULL film columns, deleted films are joined as NULL columns too
+		if film.Id.Valid {
+			actor.Films = append(actor.Films, film.PgFilm())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export actors: %w", err)
+	}
+
+	if actor != nil {
+		if err = fn(buildDomainActor(actor)); err != nil {
+			return fmt.Errorf("failed to export actors: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction while exporting actors: %w", err)
+	}
+
+	return nil
+}
+
+// Batch runs every operation within its own savepoint, so that a failed operation is rolled back alone
+// and the rest of the operations still run
+func (s *PgActorStorage) Batch(ctx context.Context, mode domain.BatchMode, operations []*domain.ActorBatchOperation, by *int64) ([]*domain.ActorBatchItem, bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to start transaction while applying batch of actors: %w", err)
+	}
+	defer tx.Rollback()
+
+	items := make([]*domain.ActorBatchItem, len(operations))
+	var failed bool
+
+	for i, operation := range operations {
+		item := &domain.ActorBatchItem{Index: i, Type: operation.Type, Id: operation.Id}
+		items[i] = item
+
+		err = savepoint.Run(ctx, tx, "batch_operation", func() error {
+			var err error
+			item.Actor, err = s.applyBatchOperation(ctx, tx, operation, by)
+			return err
+		})
+
+		var itemErr *savepoint.ItemError
+		if errors.As(err, &itemErr) {
+			item.Status = domain.BatchItemFailed
+			item.Err = itemErr.Err
+			item.Actor = nil
+			failed = true
+			continue
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to apply batch of actors: %w", err)
+		}
+		item.Status = domain.BatchItemSucceeded
+	}
+
+	if mode == domain.BatchModeTransactional && failed {
+		for _, item := range items {
+			if item.Status == domain.BatchItemSucceeded {
+				item.Status = domain.BatchItemAborted
+				item.Actor = nil
+			}
+		}
+		return items, false, nil
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, false, fmt.Errorf("failed to commit transaction while applying batch of actors: %w", err)
+	}
+
+	return items, true, nil
+}
+
+func (s *PgActorStorage) applyBatchOperation(ctx context.Context, tx *sql.Tx, operation *domain.ActorBatchOperation, by *int64) (*domain.Actor, error) {
+	switch operation.Type {
+	case domain.BatchOperationCreate:
+		params := operation.Create
+		return s.createActor(ctx, tx, params.Name, params.Sex, params.BirthDate, by)
+	case domain.BatchOperationUpdate:
+		params := operation.Update
+		return s.updateActor(ctx, tx, operation.Id, operation.Version, params.Name, params.Sex, params.BirthDate, by)
+	case domain.BatchOperationDelete:
+		return nil, s.deleteActor(ctx, tx, operation.Id, operation.Version, by)
+	default:
+		return nil, fmt.Errorf("unexpected batch operation '%s'", operation.Type)
+	}
+}
+
+func (s *PgActorStorage) getActor(ctx context.Context, tx *sql.Tx, id domain.ActorId) (*PgActor, error) {
+	var actor PgActor
+
+	query := `
+			SELECT id,
+			       name,
+			       sex,
+			       birthdate,
+			       created_by,
+			       updated_by,
+			       version
+			FROM actors
+			WHERE id=$1 AND deleted_at IS NULL
+`
+
+	row := tx.QueryRowContext(ctx, query, id)
+	if err := row.Scan(
+		&actor.Id,
+		&actor.Name,
+		&actor.Sex,
+		&actor.BirthDate,
+		&actor.CreatedBy,
+		&actor.UpdatedBy,
+		&actor.Version,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrActorNotFound
+		}
+		return nil, err
+	}
+
+	actorFilms, err := s.getActorFilms(ctx, tx, actor.Id)
+	if err != nil {
+		return nil, err
+	}
+	actor.Films = actorFilms
+
+	return &actor, nil
+}
+
+// lockActor locks the row of the actor until the end of the transaction, so that concurrent writers of the actor
+// wait for each other, and checks that the actor has the given version, if it is set
+func (s *PgActorStorage) lockActor(ctx context.Context, tx *sql.Tx, id domain.ActorId, version *int64) error {
+	query := `SELECT version FROM actors WHERE id=$1 AND deleted_at IS NULL FOR UPDATE`
+
+	var currentVersion int64
+	if err := tx.QueryRowContext(ctx, query, id).Scan(&currentVersion); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.ErrActorNotFound
+		}
+		return fmt.Errorf("failed to lock actor: %w", err)
+	}
+
+	if version != nil && *version != currentVersion {
+		return storage.ErrActorVersionMismatch
+	}
+
+	return nil
+}
+
+func (s *PgActorStorage) getActorFilms(ctx context.Context, tx *sql.Tx, actorId int64) ([]*PgFilm, error) {
+	queryFilms := `
+		SELECT f.id,
+		       f.title,
+		       f.description,
+		       f.release_date,
+		       f.rating
+		       FROM films AS f
+		INNER JOIN films_actors AS fa ON f.id = fa.film_id
+		WHERE fa.actor_id=$1 AND fa.role='actor' AND f.deleted_at IS NULL
+`
+
+	rows, err := tx.QueryContext(ctx, queryFilms, actorId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get actor films: %w", err)
+	}
+	defer rows.Close()
+
+	var actorFilms []*PgFilm
+
+	for rows.Next() {
+		var film PgFilm
+
+		if err = rows.Scan(
+			&film.Id,
+			&film.Title,
+			&film.Description,
+			&film.ReleaseDate,
+			&film.Rating,
+		); err != nil {
+			return nil, fmt.Errorf("failed to get actor films: %w", err)
+		}
+
+		actorFilms = append(actorFilms, &film)
+	}
+
+	return actorFilms, nil
+}
+
+// buildActorFilterCondition turns the filter into a parameterized 'WHERE' clause over actors aliased as 'a'.
+// Deleted actors are never matched, and credits in deleted films are ignored
+func buildActorFilterCondition(filter *domain.ActorFilter) (string, []any) {
+	conditions := []string{"a.deleted_at IS NULL"}
+	var args []any
+
+	if filter == nil {
+		return " WHERE " + strings.Join(conditions, " AND "), args
+	}
+
+	addCondition := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Name != "" {
+		addCondition("a.name ILIKE '%%' || $%d || '%%'", filter.Name.String())
+	}
+	if filter.Sex != nil {
+		addCondition("a.sex = $%d", filter.Sex.Uint8())
+	}
+	if filter.BornFrom != nil {
+		addCondition("a.birthdate >= $%d", filter.BornFrom.Time())
+	}
+	if filter.BornTo != nil {
+		addCondition("a.birthdate <= $%d", filter.BornTo.Time())
+	}
+	if filter.FilmId != nil {
+		addCondition("EXISTS (SELECT 1 FROM films_actors AS fa INNER JOIN films AS f ON f.id = fa.film_id WHERE fa.actor_id = a.id AND fa.role = 'actor' AND f.deleted_at IS NULL AND fa.film_id = $%d)", filter.FilmId.Int64())
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// actorSortColumns is the whitelist of columns actors can be sorted by, like filmSortColumns
+var actorSortColumns = map[domain.ActorSortField]string{
+	domain.ActorSortFieldId:         "a.id",
+	domain.ActorSortFieldName:       "a.name",
+	domain.ActorSortFieldBirthDate:  "a.birthdate",
+	domain.ActorSortFieldFilmsCount: "a.films_count",
+}
+
+// buildActorOrderColumns turns the sort into order columns. An empty sort means actors ordered by id.
+func buildActorOrderColumns(sort domain.ActorSort) ([]orderColumn, error) {
+	var orderColumns []orderColumn
+	var hasIdColumn bool
+
+	for _, key := range sort {
+		columnName, ok := actorSortColumns[key.Field]
+		if !ok {
+			return nil, fmt.Errorf("%w: unexpected sort field '%s'", domain.ErrInvalidActorSort, key.Field)
+		}
+		order, ok := sortDirections[key.Direction]
+		if !ok {
+			return nil, fmt.Errorf("%w: unexpected sort direction '%s'", domain.ErrInvalidActorSort, key.Direction)
+		}
+		orderColumns = append(orderColumns, orderColumn{name: columnName, order: order})
+		hasIdColumn = hasIdColumn || key.Field == domain.ActorSortFieldId
+	}
+
+	// Actor id makes the order total, so that keyset pagination never skips or repeats actors
+	if !hasIdColumn {
+		orderColumns = append(orderColumns, orderColumn{name: "a.id", order: "ASC"})
+	}
+
+	return orderColumns, nil
+}
+
+func buildActorCursorValues(orderColumns []orderColumn, cursor *domain.ActorCursor) []any {
+	values := make([]any, len(orderColumns))
+	for i, column := range orderColumns {
+		switch column.name {
+		case "a.name":
+			values[i] = cursor.Name.String()
+		case "a.birthdate":
+			values[i] = cursor.BirthDate.Time()
+		case "a.films_count":
+			values[i] = cursor.FilmsCount
+		case "a.id":
+			values[i] = cursor.Id.Int64()
+		}
+	}
+	return values
+}
+
+// buildActorsPage expects up to limit+1 actors and cuts the extra one off.
+// The extra actor means that there is a next page, which starts after the last returned actor.
+func buildActorsPage(postgresActors []*PgActor, limit int) ([]*domain.Actor, *domain.ActorCursor) {
+	if len(postgresActors) <= limit {
+		return buildDomainActors(postgresActors), nil
+	}
+
+	domainActors := buildDomainActors(postgresActors[:limit])
+	if len(domainActors) == 0 {
+		return domainActors, nil
+	}
+
+	return domainActors, domain.NewActorCursor(domainActors[len(domainActors)-1])
+}
+
 func buildDomainActors(postgresActors []*PgActor) []*domain.Actor {
 	domainActors := make([]*domain.Actor, len(postgresActors))
 	for i := range postgresActors {
@@ -256,5 +798,8 @@ func buildDomainActor(postgresActor *PgActor) *domain.Actor {
 		Sex:       domain.ActorSex(postgresActor.Sex),
 		BirthDate: domain.ActorBirthDate(postgresActor.BirthDate),
 		Films:     buildDomainFilms(postgresActor.Films),
+		CreatedBy: nullInt64Ptr(postgresActor.CreatedBy),
+		UpdatedBy: nullInt64Ptr(postgresActor.UpdatedBy),
+		Version:   postgresActor.Version,
 	}
 }
@@ -6,10 +6,50 @@ import (
 )
 
 type PgFilm struct {
-	Id          int64
-	Title       string
+	Id             int64
+	Title          string
+	Description    sql.NullString
+	ReleaseDate    time.Time
+	Rating         uint8
+	CommunityScore float64
+	VotesCount     int64
+	Actors         []*PgActor
+	Credits        []*PgFilmCredit
+	Genres         []*PgGenre
+	CreatedBy      sql.NullInt64
+	UpdatedBy      sql.NullInt64
+	Version        int64
+	Match          *PgFilmMatch
+}
+
+type PgFilmMatch struct {
+	Relevance            float64
+	TitleHighlight       string
+	DescriptionHighlight string
+}
+
+type PgFilmCredit struct {
+	Actor         *PgActor
+	Role          string
+	CharacterName sql.NullString
+	BillingOrder  int
+}
+
+// PgNullableFilm is a film read through an outer join, so all of its columns may be NULL
+type PgNullableFilm struct {
+	Id          sql.NullInt64
+	Title       sql.NullString
 	Description sql.NullString
-	ReleaseDate time.Time
-	Rating      uint8
-	Actors      []*PgActor
+	ReleaseDate sql.NullTime
+	Rating      sql.NullInt16
+}
+
+func (f *PgNullableFilm) PgFilm() *PgFilm {
+	return &PgFilm{
+		Id:          f.Id.Int64,
+		Title:       f.Title.String,
+		Description: f.Description,
+		ReleaseDate: f.ReleaseDate.Time,
+		Rating:      uint8(f.Rating.Int16),
+	}
 }
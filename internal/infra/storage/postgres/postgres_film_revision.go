@@ -0,0 +1,27 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+)
+
+type PgFilmRevision struct {
+	FilmId      int64
+	Revision    int64
+	UserId      sql.NullInt64
+	Title       string
+	Description sql.NullString
+	ReleaseDate time.Time
+	Rating      uint8
+	Credits     []byte
+	GenreIds    []int64
+	CreatedAt   time.Time
+}
+
+// PgFilmRevisionCredit is a credit stored in the 'credits' JSON array of a film revision
+type PgFilmRevisionCredit struct {
+	ActorId       int64  `json:"actor_id"`
+	Role          string `json:"role"`
+	CharacterName string `json:"character_name"`
+	BillingOrder  int    `json:"billing_order"`
+}
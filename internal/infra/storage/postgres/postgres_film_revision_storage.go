@@ -0,0 +1,215 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+)
+
+type PgFilmRevisionStorage struct {
+	db *sqlx.DB
+}
+
+func NewPgFilmRevisionStorage(db *sqlx.DB) *PgFilmRevisionStorage {
+	return &PgFilmRevisionStorage{db: db}
+}
+
+// List lists revisions of a film starting with the latest one
+func (s *PgFilmRevisionStorage) List(ctx context.Context, filmId domain.FilmId, limit, offset int) ([]*domain.FilmRevision, error) {
+	query := `
+			SELECT film_id,
+			       revision,
+			       user_id,
+			       title,
+			       description,
+			       release_date,
+			       rating,
+			       credits,
+			       genre_ids,
+			       created_at
+			FROM film_revisions
+			WHERE film_id=$1
+			ORDER BY revision DESC
+			LIMIT $2 OFFSET $3
+`
+	rows, err := s.db.QueryContext(ctx, query, filmId, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list film revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []*domain.FilmRevision
+
+	for rows.Next() {
+		var revision PgFilmRevision
+		if err = scanFilmRevision(rows, &revision); err != nil {
+			return nil, fmt.Errorf("failed to list film revisions: %w", err)
+		}
+
+		domainRevision, err := buildDomainFilmRevision(&revision)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list film revisions: %w", err)
+		}
+
+		revisions = append(revisions, domainRevision)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list film revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+func (s *PgFilmRevisionStorage) Count(ctx context.Context, filmId domain.FilmId) (int64, error) {
+	query := `
+			SELECT COUNT(*) FROM film_revisions
+			WHERE film_id=$1
+`
+	var count int64
+	if err := s.db.QueryRowContext(ctx, query, filmId).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count film revisions: %w", err)
+	}
+	return count, nil
+}
+
+func (s *PgFilmRevisionStorage) Get(ctx context.Context, filmId domain.FilmId, revision int64) (*domain.FilmRevision, error) {
+	query := `
+			SELECT film_id,
+			       revision,
+			       user_id,
+			       title,
+			       description,
+			       release_date,
+			       rating,
+			       credits,
+			       genre_ids,
+			       created_at
+			FROM film_revisions
+			WHERE film_id=$1 AND revision=$2
+`
+	var filmRevision PgFilmRevision
+
+	row := s.db.QueryRowContext(ctx, query, filmId, revision)
+	if err := scanFilmRevision(row, &filmRevision); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to get a film revision: %w", storage.ErrFilmRevisionNotFound)
+		}
+		return nil, fmt.Errorf("failed to get a film revision: %w", err)
+	}
+
+	domainRevision, err := buildDomainFilmRevision(&filmRevision)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a film revision: %w", err)
+	}
+
+	return domainRevision, nil
+}
+
+// insertFilmRevision is called by PgFilmStorage within the transaction of the change that has produced the revision
+func insertFilmRevision(ctx context.Context, tx *sql.Tx, revision *domain.FilmRevision) error {
+	credits := make([]*PgFilmRevisionCredit, len(revision.Credits))
+	for i, credit := range revision.Credits {
+		credits[i] = &PgFilmRevisionCredit{
+			ActorId:       credit.ActorId.Int64(),
+			Role:          credit.Role.String(),
+			CharacterName: credit.CharacterName.String(),
+			BillingOrder:  credit.BillingOrder,
+		}
+	}
+
+	creditsJSON, err := json.Marshal(credits)
+	if err != nil {
+		return fmt.Errorf("failed to insert a film revision: %w", err)
+	}
+
+	genreIds := make([]int64, len(revision.GenreIds))
+	for i := range revision.GenreIds {
+		genreIds[i] = revision.GenreIds[i].Int64()
+	}
+
+	query := `
+			INSERT INTO film_revisions (
+			                            film_id,
+			                            revision,
+			                            user_id,
+			                            title,
+			                            description,
+			                            release_date,
+			                            rating,
+			                            credits,
+			                            genre_ids
+				) VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8, $9)
+`
+	_, err = tx.ExecContext(ctx, query,
+		revision.FilmId,
+		revision.Revision,
+		revision.UserId,
+		revision.Title,
+		revision.Description,
+		revision.ReleaseDate.Time(),
+		revision.Rating,
+		string(creditsJSON),
+		pq.Array(genreIds),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert a film revision: %w", err)
+	}
+	return nil
+}
+
+func scanFilmRevision(row rowScanner, revision *PgFilmRevision) error {
+	return row.Scan(
+		&revision.FilmId,
+		&revision.Revision,
+		&revision.UserId,
+		&revision.Title,
+		&revision.Description,
+		&revision.ReleaseDate,
+		&revision.Rating,
+		&revision.Credits,
+		pq.Array(&revision.GenreIds),
+		&revision.CreatedAt,
+	)
+}
+
+func buildDomainFilmRevision(postgresRevision *PgFilmRevision) (*domain.FilmRevision, error) {
+	var credits []*PgFilmRevisionCredit
+	if err := json.Unmarshal(postgresRevision.Credits, &credits); err != nil {
+		return nil, fmt.Errorf("failed to decode film revision credits: %w", err)
+	}
+
+	domainCredits := make([]*domain.FilmCreditParams, len(credits))
+	for i, credit := range credits {
+		domainCredits[i] = &domain.FilmCreditParams{
+			ActorId:       domain.ActorId(credit.ActorId),
+			Role:          domain.CreditRole(credit.Role),
+			CharacterName: domain.CharacterName(credit.CharacterName),
+			BillingOrder:  credit.BillingOrder,
+		}
+	}
+
+	genreIds := make([]domain.GenreId, len(postgresRevision.GenreIds))
+	for i := range postgresRevision.GenreIds {
+		genreIds[i] = domain.GenreId(postgresRevision.GenreIds[i])
+	}
+
+	return &domain.FilmRevision{
+		FilmId:      domain.FilmId(postgresRevision.FilmId),
+		Revision:    postgresRevision.Revision,
+		UserId:      nullInt64Ptr(postgresRevision.UserId),
+		Title:       domain.FilmTitle(postgresRevision.Title),
+		Description: domain.FilmDescription(postgresRevision.Description.String),
+		ReleaseDate: domain.FilmReleaseDate(postgresRevision.ReleaseDate),
+		Rating:      domain.FilmRating(postgresRevision.Rating),
+		Credits:     domainCredits,
+		GenreIds:    genreIds,
+		CreatedAt:   postgresRevision.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,6 @@
+package postgres
+
+type PgGenre struct {
+	Id   int64
+	Name string
+}
@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+)
+
+const uniqueViolationErrCode = "23505"
+
+type PgGenreStorage struct {
+	db *sqlx.DB
+}
+
+func NewPgGenreStorage(db *sqlx.DB) *PgGenreStorage {
+	return &PgGenreStorage{db: db}
+}
+
+func (s *PgGenreStorage) Create(ctx context.Context, name domain.GenreName) (*domain.Genre, error) {
+	var genre PgGenre
+	query := `
+			INSERT INTO genres (name) VALUES ($1)
+			RETURNING
+				id,
+				name
+`
+	row := s.db.QueryRowContext(ctx, query, name)
+	if err := row.Scan(&genre.Id, &genre.Name); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolationErrCode {
+			return nil, fmt.Errorf("failed to create a genre: %w", storage.ErrGenreAlreadyExists)
+		}
+		return nil, fmt.Errorf("failed to create a genre: %w", err)
+	}
+	return buildDomainGenre(&genre), nil
+}
+
+func (s *PgGenreStorage) Update(ctx context.Context, id domain.GenreId, name domain.GenreName) (*domain.Genre, error) {
+	var genre PgGenre
+	query := `
+			UPDATE genres
+			SET name=$1
+			WHERE id=$2
+			RETURNING
+				id,
+				name
+`
+	row := s.db.QueryRowContext(ctx, query, name, id)
+	if err := row.Scan(&genre.Id, &genre.Name); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to update a genre: %w", storage.ErrGenreNotFound)
+		}
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolationErrCode {
+			return nil, fmt.Errorf("failed to update a genre: %w", storage.ErrGenreAlreadyExists)
+		}
+		return nil, fmt.Errorf("failed to update a genre: %w", err)
+	}
+	return buildDomainGenre(&genre), nil
+}
+
+func (s *PgGenreStorage) Delete(ctx context.Context, id domain.GenreId) error {
+	query := `DELETE FROM genres WHERE id=$1`
+	result, err := s.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete genre: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return fmt.Errorf("failed to delete genre: %w", storage.ErrGenreNotFound)
+	}
+	return nil
+}
+
+func (s *PgGenreStorage) GetById(ctx context.Context, id domain.GenreId) (*domain.Genre, error) {
+	var genre PgGenre
+	query := `
+			SELECT id,
+			       name
+			FROM genres
+			WHERE id=$1
+`
+	row := s.db.QueryRowContext(ctx, query, id)
+	if err := row.Scan(&genre.Id, &genre.Name); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to get genre: %w", storage.ErrGenreNotFound)
+		}
+		return nil, fmt.Errorf("failed to get genre: %w", err)
+	}
+	return buildDomainGenre(&genre), nil
+}
+
+func (s *PgGenreStorage) List(ctx context.Context) ([]*domain.Genre, error) {
+	query := `
+			SELECT id,
+			       name
+			FROM genres
+			ORDER BY name
+`
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list genres: %w", err)
+	}
+	defer rows.Close()
+
+	var genres []*PgGenre
+
+	for rows.Next() {
+		var genre PgGenre
+		if err = rows.Scan(&genre.Id, &genre.Name); err != nil {
+			return nil, fmt.Errorf("failed to list genres: %w", err)
+		}
+		genres = append(genres, &genre)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list genres: %w", err)
+	}
+
+	return buildDomainGenres(genres), nil
+}
+
+func (s *PgGenreStorage) AreExists(ctx context.Context, ids []domain.GenreId) (bool, error) {
+	query := `
+			SELECT COUNT(*) FROM genres 
+			WHERE id=ANY($1)
+`
+
+	var idsCount int64
+
+	err := s.db.QueryRowContext(ctx, query, pq.Array(ids)).Scan(&idsCount)
+	if err != nil {
+		return false, fmt.Errorf("failed to check whether genres exists or not: %w", err)
+	}
+
+	if int(idsCount) != len(ids) {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func buildDomainGenres(postgresGenres []*PgGenre) []*domain.Genre {
+	domainGenres := make([]*domain.Genre, len(postgresGenres))
+	for i := range postgresGenres {
+		domainGenres[i] = buildDomainGenre(postgresGenres[i])
+	}
+	return domainGenres
+}
+
+func buildDomainGenre(postgresGenre *PgGenre) *domain.Genre {
+	return &domain.Genre{
+		Id:   domain.GenreId(postgresGenre.Id),
+		Name: domain.GenreName(postgresGenre.Name),
+	}
+}
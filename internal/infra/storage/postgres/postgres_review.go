@@ -0,0 +1,16 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+)
+
+type PgReview struct {
+	Id        int64
+	FilmId    int64
+	UserId    int64
+	Rating    uint8
+	Text      sql.NullString
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,14 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+)
+
+type PgTrashItem struct {
+	EntityType string
+	EntityId   int64
+	Name       string
+	DeletedAt  time.Time
+	DeletedBy  sql.NullInt64
+}
@@ -0,0 +1,425 @@
+package postgres_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/postgres"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/postgres/tests/pgtest"
+	"testing"
+	"time"
+)
+
+func TestListIncludesActorsWithoutFilms(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+	actorStorage := postgres.NewPgActorStorage(db)
+
+	ctx := context.Background()
+
+	actorWithFilm := createActor(t, actorStorage, "Actor_1")
+	actorWithoutFilms := createActor(t, actorStorage, "Actor_2")
+
+	film, err := filmStorage.Create(ctx, "Title_1", "Description", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, domain.NewActorCredits([]domain.ActorId{actorWithFilm.Id}), nil, nil)
+	require.NoError(t, err)
+
+	actors, _, err := actorStorage.List(ctx, nil, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, actors, 2)
+
+	require.Equal(t, actorWithFilm.Id, actors[0].Id)
+	require.Len(t, actors[0].Films, 1)
+	require.Equal(t, film.Id, actors[0].Films[0].Id)
+
+	require.Equal(t, actorWithoutFilms.Id, actors[1].Id)
+	require.Empty(t, actors[1].Films)
+}
+
+func TestListRespectsLimitOffset(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+	actorStorage := postgres.NewPgActorStorage(db)
+
+	ctx := context.Background()
+
+	actor1 := createActor(t, actorStorage, "Actor_1")
+	actor2 := createActor(t, actorStorage, "Actor_2")
+	actor3 := createActor(t, actorStorage, "Actor_3")
+
+	_, err := filmStorage.Create(ctx, "Title_1", "Description", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, domain.NewActorCredits([]domain.ActorId{actor1.Id, actor3.Id}), nil, nil)
+	require.NoError(t, err)
+
+	actors, nextCursor, err := actorStorage.List(ctx, nil, nil, nil, 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.ActorId{actor1.Id, actor2.Id}, actorIds(actors))
+	require.NotNil(t, nextCursor)
+
+	actors, nextCursor, err = actorStorage.List(ctx, nil, nil, nextCursor, 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.ActorId{actor3.Id}, actorIds(actors))
+	require.Len(t, actors[0].Films, 1)
+	require.Nil(t, nextCursor)
+
+	actors, _, err = actorStorage.List(ctx, nil, nil, nil, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, []domain.ActorId{actor2.Id}, actorIds(actors))
+	require.Empty(t, actors[0].Films)
+}
+
+func TestListWithFilterAndSort(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+	actorStorage := postgres.NewPgActorStorage(db)
+
+	ctx := context.Background()
+
+	actor1, err := actorStorage.Create(ctx, "John Smith", domain.ActorSex(1), domain.ActorBirthDate(time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)), nil)
+	require.NoError(t, err)
+	actor2, err := actorStorage.Create(ctx, "Anna Smith", domain.ActorSex(2), domain.ActorBirthDate(time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)), nil)
+	require.NoError(t, err)
+	actor3, err := actorStorage.Create(ctx, "Bob Brown", domain.ActorSex(1), domain.ActorBirthDate(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)), nil)
+	require.NoError(t, err)
+
+	film1, err := filmStorage.Create(ctx, "Title_1", "Description", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, domain.NewActorCredits([]domain.ActorId{actor1.Id, actor2.Id}), nil, nil)
+	require.NoError(t, err)
+	_, err = filmStorage.Create(ctx, "Title_2", "Description", domain.FilmReleaseDate(time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)), 5, domain.NewActorCredits([]domain.ActorId{actor2.Id}), nil, nil)
+	require.NoError(t, err)
+
+	sex := domain.ActorSex(1)
+	bornFrom := domain.ActorBirthDate(time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC))
+	bornTo := domain.ActorBirthDate(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	testCases := []struct {
+		name     string
+		filter   *domain.ActorFilter
+		sort     domain.ActorSort
+		expected []domain.ActorId
+	}{
+		{
+			name:     "name",
+			filter:   &domain.ActorFilter{Name: "smith"},
+			expected: []domain.ActorId{actor1.Id, actor2.Id},
+		},
+		{
+			name:     "sex",
+			filter:   &domain.ActorFilter{Sex: &sex},
+			expected: []domain.ActorId{actor1.Id, actor3.Id},
+		},
+		{
+			name:     "birth_date_range",
+			filter:   &domain.ActorFilter{BornFrom: &bornFrom, BornTo: &bornTo},
+			expected: []domain.ActorId{actor2.Id, actor3.Id},
+		},
+		{
+			name:     "film",
+			filter:   &domain.ActorFilter{FilmId: &film1.Id},
+			expected: []domain.ActorId{actor1.Id, actor2.Id},
+		},
+		{
+			name:     "sort_by_name",
+			sort:     domain.ActorSort{{Field: domain.ActorSortFieldName, Direction: domain.SortDirectionAsc}},
+			expected: []domain.ActorId{actor2.Id, actor3.Id, actor1.Id},
+		},
+		{
+			name:     "sort_by_birth_date",
+			sort:     domain.ActorSort{{Field: domain.ActorSortFieldBirthDate, Direction: domain.SortDirectionDesc}},
+			expected: []domain.ActorId{actor3.Id, actor2.Id, actor1.Id},
+		},
+		{
+			name:     "filter_with_sort_by_films_count",
+			filter:   &domain.ActorFilter{Name: "smith"},
+			sort:     domain.ActorSort{{Field: domain.ActorSortFieldFilmsCount, Direction: domain.SortDirectionDesc}},
+			expected: []domain.ActorId{actor2.Id, actor1.Id},
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			actors, _, err := actorStorage.List(ctx, tCase.filter, tCase.sort, nil, 10, 0)
+			require.NoError(t, err)
+			require.Equal(t, tCase.expected, actorIds(actors))
+
+			total, err := actorStorage.Count(ctx, tCase.filter)
+			require.NoError(t, err)
+			require.Equal(t, int64(len(tCase.expected)), total)
+		})
+	}
+}
+
+func TestListWithSortRespectsCursor(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+	actorStorage := postgres.NewPgActorStorage(db)
+
+	ctx := context.Background()
+
+	actor1 := createActor(t, actorStorage, "Actor_1")
+	actor2 := createActor(t, actorStorage, "Actor_2")
+	actor3 := createActor(t, actorStorage, "Actor_3")
+
+	_, err := filmStorage.Create(ctx, "Title_1", "Description", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, domain.NewActorCredits([]domain.ActorId{actor2.Id, actor3.Id}), nil, nil)
+	require.NoError(t, err)
+	_, err = filmStorage.Create(ctx, "Title_2", "Description", domain.FilmReleaseDate(time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)), 5, domain.NewActorCredits([]domain.ActorId{actor3.Id}), nil, nil)
+	require.NoError(t, err)
+
+	sort := domain.ActorSort{{Field: domain.ActorSortFieldFilmsCount, Direction: domain.SortDirectionDesc}}
+
+	actors, nextCursor, err := actorStorage.List(ctx, nil, sort, nil, 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.ActorId{actor3.Id, actor2.Id}, actorIds(actors))
+	require.NotNil(t, nextCursor)
+
+	actors, nextCursor, err = actorStorage.List(ctx, nil, sort, nextCursor, 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.ActorId{actor1.Id}, actorIds(actors))
+	require.Nil(t, nextCursor)
+}
+
+func TestExportStreamsActorsWithFilms(t *testing.T) {
+	db := pgtest.New(t)
+
+	actorStorage := postgres.NewPgActorStorage(db)
+	filmStorage := postgres.NewPgFilmStorage(db)
+
+	ctx := context.Background()
+
+	actor1 := createActor(t, actorStorage, "Actor_1")
+	actor2 := createActor(t, actorStorage, "Actor_2")
+
+	film1, err := filmStorage.Create(ctx, "Title_1", "", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, domain.NewActorCredits([]domain.ActorId{actor1.Id}), nil, nil)
+	require.NoError(t, err)
+	film2, err := filmStorage.Create(ctx, "Title_2", "", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, domain.NewActorCredits([]domain.ActorId{actor1.Id}), nil, nil)
+	require.NoError(t, err)
+
+	var actors []*domain.Actor
+
+	err = actorStorage.Export(ctx, func(actor *domain.Actor) error {
+		actors = append(actors, actor)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []domain.ActorId{actor1.Id, actor2.Id}, actorIds(actors))
+
+	require.Len(t, actors[0].Films, 2)
+	require.Equal(t, film1.Id, actors[0].Films[0].Id)
+	require.Equal(t, film2.Id, actors[0].Films[1].Id)
+	require.Empty(t, actors[1].Films)
+}
+
+func TestBatchTransactionalRollsBackAll(t *testing.T) {
+	db := pgtest.New(t)
+
+	actorStorage := postgres.NewPgActorStorage(db)
+
+	ctx := context.Background()
+
+	actor := createActor(t, actorStorage, "Actor_1")
+	name := domain.ActorName("Actor_2")
+
+	items, committed, err := actorStorage.Batch(ctx, domain.BatchModeTransactional, []*domain.ActorBatchOperation{
+		{Type: domain.BatchOperationUpdate, Id: actor.Id, Update: &domain.ActorUpdateParams{Name: &name}},
+		{Type: domain.BatchOperationDelete, Id: actor.Id + 100},
+		{Type: domain.BatchOperationCreate, Create: &domain.ActorCreateParams{Name: "Actor_3", Sex: domain.ActorSex(1)}},
+	}, nil)
+	require.NoError(t, err)
+	require.False(t, committed)
+
+	require.Equal(t, domain.BatchItemAborted, items[0].Status)
+	require.Equal(t, domain.BatchItemFailed, items[1].Status)
+	require.ErrorIs(t, items[1].Err, storage.ErrActorNotFound)
+	require.Equal(t, domain.BatchItemAborted, items[2].Status)
+
+	actors, _, err := actorStorage.List(ctx, nil, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.ActorId{actor.Id}, actorIds(actors))
+	require.Equal(t, actor.Name, actors[0].Name)
+}
+
+func TestBatchBestEffortAppliesSucceeded(t *testing.T) {
+	db := pgtest.New(t)
+
+	actorStorage := postgres.NewPgActorStorage(db)
+
+	ctx := context.Background()
+
+	actor1 := createActor(t, actorStorage, "Actor_1")
+	actor2 := createActor(t, actorStorage, "Actor_2")
+	name := domain.ActorName("Actor_4")
+
+	items, committed, err := actorStorage.Batch(ctx, domain.BatchModeBestEffort, []*domain.ActorBatchOperation{
+		{Type: domain.BatchOperationCreate, Create: &domain.ActorCreateParams{Name: "Actor_3", Sex: domain.ActorSex(1)}},
+		{Type: domain.BatchOperationDelete, Id: actor2.Id + 100},
+		{Type: domain.BatchOperationUpdate, Id: actor1.Id, Update: &domain.ActorUpdateParams{Name: &name}},
+		{Type: domain.BatchOperationDelete, Id: actor2.Id},
+	}, nil)
+	require.NoError(t, err)
+	require.True(t, committed)
+
+	require.Equal(t, domain.BatchItemSucceeded, items[0].Status)
+	require.Equal(t, domain.BatchItemFailed, items[1].Status)
+	require.ErrorIs(t, items[1].Err, storage.ErrActorNotFound)
+	require.Equal(t, domain.BatchItemSucceeded, items[2].Status)
+	require.Equal(t, name, items[2].Actor.Name)
+	require.Equal(t, domain.BatchItemSucceeded, items[3].Status)
+
+	actors, _, err := actorStorage.List(ctx, nil, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.ActorId{actor1.Id, items[0].Actor.Id}, actorIds(actors))
+}
+
+func TestUpdateAndDeleteCheckVersion(t *testing.T) {
+	db := pgtest.New(t)
+
+	actorStorage := postgres.NewPgActorStorage(db)
+
+	ctx := context.Background()
+
+	actor := createActor(t, actorStorage, "Actor_1")
+	require.Equal(t, int64(1), actor.Version)
+
+	staleVersion := actor.Version
+	name := domain.ActorName("Actor_2")
+
+	updatedActor, err := actorStorage.Update(ctx, actor.Id, &actor.Version, &name, nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), updatedActor.Version)
+
+	_, err = actorStorage.Update(ctx, actor.Id, &staleVersion, &name, nil, nil, nil)
+	require.ErrorIs(t, err, storage.ErrActorVersionMismatch)
+
+	require.ErrorIs(t, actorStorage.Delete(ctx, actor.Id, &staleVersion, nil), storage.ErrActorVersionMismatch)
+
+	gotActor, err := actorStorage.GetById(ctx, actor.Id)
+	require.NoError(t, err)
+	require.Equal(t, updatedActor.Version, gotActor.Version)
+
+	require.NoError(t, actorStorage.Delete(ctx, actor.Id, &gotActor.Version, nil))
+}
+
+func TestBatchChecksVersion(t *testing.T) {
+	db := pgtest.New(t)
+
+	actorStorage := postgres.NewPgActorStorage(db)
+
+	ctx := context.Background()
+
+	actor1 := createActor(t, actorStorage, "Actor_1")
+	actor2 := createActor(t, actorStorage, "Actor_2")
+
+	name := domain.ActorName("Actor_3")
+	staleVersion := actor1.Version + 1
+
+	items, committed, err := actorStorage.Batch(ctx, domain.BatchModeBestEffort, []*domain.ActorBatchOperation{
+		{Type: domain.BatchOperationUpdate, Id: actor1.Id, Version: &staleVersion, Update: &domain.ActorUpdateParams{Name: &name}},
+		{Type: domain.BatchOperationDelete, Id: actor2.Id, Version: &staleVersion},
+		{Type: domain.BatchOperationUpdate, Id: actor1.Id, Version: &actor1.Version, Update: &domain.ActorUpdateParams{Name: &name}},
+		{Type: domain.BatchOperationDelete, Id: actor2.Id, Version: &actor2.Version},
+	}, nil)
+	require.NoError(t, err)
+	require.True(t, committed)
+	require.Len(t, items, 4)
+
+	require.Equal(t, domain.BatchItemFailed, items[0].Status)
+	require.ErrorIs(t, items[0].Err, storage.ErrActorVersionMismatch)
+	require.Equal(t, domain.BatchItemFailed, items[1].Status)
+	require.ErrorIs(t, items[1].Err, storage.ErrActorVersionMismatch)
+
+	require.Equal(t, domain.BatchItemSucceeded, items[2].Status)
+	require.Equal(t, actor1.Version+1, items[2].Actor.Version)
+	require.Equal(t, domain.BatchItemSucceeded, items[3].Status)
+
+	// Listed actors have their versions, so that they can be used in the 'If-Match' header and batch operations
+	actors, _, err := actorStorage.List(ctx, nil, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.ActorId{actor1.Id}, actorIds(actors))
+	require.Equal(t, items[2].Actor.Version, actors[0].Version)
+}
+
+func createActor(t *testing.T, actorStorage *postgres.PgActorStorage, name domain.ActorName) *domain.Actor {
+	t.Helper()
+
+	actor, err := actorStorage.Create(context.Background(), name, domain.ActorSex(1), domain.ActorBirthDate(time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)), nil)
+	require.NoError(t, err)
+
+	return actor
+}
+
+func actorIds(actors []*domain.Actor) []domain.ActorId {
+	ids := make([]domain.ActorId, len(actors))
+	for i, actor := range actors {
+		ids[i] = actor.Id
+	}
+	return ids
+}
+
+func TestDeleteMovesActorToTrashAndRestoreBringsThemBack(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+	actorStorage := postgres.NewPgActorStorage(db)
+
+	ctx := context.Background()
+
+	actor := createActor(t, actorStorage, "Actor_1")
+
+	film, err := filmStorage.Create(ctx, "Title_1", "Description", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, domain.NewActorCredits([]domain.ActorId{actor.Id}), nil, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, actorStorage.Delete(ctx, actor.Id, nil, nil))
+
+	_, err = actorStorage.GetById(ctx, actor.Id)
+	require.ErrorIs(t, err, storage.ErrActorNotFound)
+
+	exists, err := actorStorage.AreExists(ctx, []domain.ActorId{actor.Id})
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	actors, _, err := actorStorage.List(ctx, nil, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Empty(t, actors)
+
+	restoredActor, err := actorStorage.Restore(ctx, actor.Id, nil)
+	require.NoError(t, err)
+	require.Equal(t, actor.Id, restoredActor.Id)
+	require.Len(t, restoredActor.Films, 1)
+	require.Equal(t, film.Id, restoredActor.Films[0].Id)
+	require.Equal(t, actor.Version, restoredActor.Version)
+
+	_, err = actorStorage.Restore(ctx, actor.Id, nil)
+	require.ErrorIs(t, err, storage.ErrActorNotFound)
+}
+
+func TestListExcludesDeletedFilms(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+	actorStorage := postgres.NewPgActorStorage(db)
+
+	ctx := context.Background()
+
+	actor := createActor(t, actorStorage, "Actor_1")
+
+	releaseDate := domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+	keptFilm, err := filmStorage.Create(ctx, "Title_1", "Description", releaseDate, 5, domain.NewActorCredits([]domain.ActorId{actor.Id}), nil, nil)
+	require.NoError(t, err)
+	deletedFilm, err := filmStorage.Create(ctx, "Title_2", "Description", releaseDate, 5, domain.NewActorCredits([]domain.ActorId{actor.Id}), nil, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, filmStorage.Delete(ctx, deletedFilm.Id, nil, nil))
+
+	actors, _, err := actorStorage.List(ctx, nil, domain.ActorSort{{Field: domain.ActorSortFieldFilmsCount, Direction: domain.SortDirectionDesc}}, nil, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, actors, 1)
+	require.Len(t, actors[0].Films, 1)
+	require.Equal(t, keptFilm.Id, actors[0].Films[0].Id)
+
+	filmId := deletedFilm.Id
+	count, err := actorStorage.Count(ctx, &domain.ActorFilter{FilmId: &filmId})
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
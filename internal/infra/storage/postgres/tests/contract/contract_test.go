@@ -0,0 +1,31 @@
+package postgres_test
+
+import (
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/postgres"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/postgres/pgauth"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/postgres/pguser"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/postgres/tests/pgtest"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/tests/storagetest"
+	"testing"
+)
+
+func TestStorageContract(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) *storagetest.Storages {
+		db := pgtest.New(t)
+
+		return &storagetest.Storages{
+			Films:          postgres.NewPgFilmStorage(db),
+			Actors:         postgres.NewPgActorStorage(db),
+			Genres:         postgres.NewPgGenreStorage(db),
+			Users:          pguser.NewPgUserStorage(db),
+			RefreshTokens:  pgauth.NewPgRefreshTokenStorage(db),
+			Trash:          postgres.NewPgTrashStorage(db),
+			FilmRevisions:  postgres.NewPgFilmRevisionStorage(db),
+			ActorRevisions: postgres.NewPgActorRevisionStorage(db),
+			Audit:          postgres.NewPgAuditStorage(db),
+			FilmImport:     postgres.NewPgFilmImportStorage(db),
+			FilmLists:      postgres.NewPgFilmListStorage(db),
+			Reviews:        postgres.NewPgReviewStorage(db),
+		}
+	})
+}
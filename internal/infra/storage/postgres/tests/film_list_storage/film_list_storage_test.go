@@ -0,0 +1,75 @@
+package postgres_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/postgres"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/postgres/tests/pgtest"
+	"testing"
+	"time"
+)
+
+func TestFilmLists(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+	filmListStorage := postgres.NewPgFilmListStorage(db)
+
+	ctx := context.Background()
+
+	// Users seeded by migrations: 1 - user@example.com, 2 - admin@example.com
+	userId, adminId := int64(1), int64(2)
+
+	releaseDate := domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	film1, err := filmStorage.Create(ctx, "Title_1", "Description", releaseDate, 5, nil, nil, nil)
+	require.NoError(t, err)
+	film2, err := filmStorage.Create(ctx, "Title_2", "Description", releaseDate, 5, nil, nil, nil)
+	require.NoError(t, err)
+	film3, err := filmStorage.Create(ctx, "Title_3", "Description", releaseDate, 5, nil, nil, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, filmListStorage.Add(ctx, userId, domain.FilmListWatchlist, film1.Id))
+	require.NoError(t, filmListStorage.Add(ctx, userId, domain.FilmListWatchlist, film2.Id))
+	require.NoError(t, filmListStorage.Add(ctx, userId, domain.FilmListWatched, film2.Id))
+	require.NoError(t, filmListStorage.Add(ctx, adminId, domain.FilmListWatchlist, film3.Id))
+
+	// Adding a film that is already in the list does nothing
+	require.NoError(t, filmListStorage.Add(ctx, userId, domain.FilmListWatchlist, film1.Id))
+
+	err = filmListStorage.Add(ctx, userId, domain.FilmListWatchlist, film3.Id+100)
+	require.ErrorIs(t, err, storage.ErrFilmNotFound)
+
+	sort := domain.FilmSort{{Field: domain.FilmSortFieldTitle, Direction: domain.SortDirectionAsc}}
+
+	films, nextCursor, err := filmListStorage.ListFilms(ctx, userId, domain.FilmListWatchlist, sort, nil, 1, 0)
+	require.NoError(t, err)
+	require.Len(t, films, 1)
+	require.Equal(t, film1.Id, films[0].Id)
+
+	films, _, err = filmListStorage.ListFilms(ctx, userId, domain.FilmListWatchlist, sort, nextCursor, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, films, 1)
+	require.Equal(t, film2.Id, films[0].Id)
+
+	count, err := filmListStorage.CountFilms(ctx, userId, domain.FilmListWatchlist)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	filmsLists, err := filmListStorage.GetFilmsLists(ctx, userId, []domain.FilmId{film1.Id, film2.Id, film3.Id})
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmList{domain.FilmListWatchlist}, filmsLists[film1.Id])
+	require.ElementsMatch(t, []domain.FilmList{domain.FilmListWatchlist, domain.FilmListWatched}, filmsLists[film2.Id])
+	require.Empty(t, filmsLists[film3.Id])
+
+	require.NoError(t, filmListStorage.Remove(ctx, userId, domain.FilmListWatchlist, film1.Id))
+
+	err = filmListStorage.Remove(ctx, userId, domain.FilmListWatchlist, film1.Id)
+	require.ErrorIs(t, err, storage.ErrFilmListItemNotFound)
+
+	count, err = filmListStorage.CountFilms(ctx, userId, domain.FilmListWatchlist)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+}
@@ -0,0 +1,141 @@
+package postgres_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/postgres"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/postgres/tests/pgtest"
+	"testing"
+	"time"
+)
+
+func TestCreateAndUpdateRecordRevisions(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+	actorStorage := postgres.NewPgActorStorage(db)
+	genreStorage := postgres.NewPgGenreStorage(db)
+	filmRevisionStorage := postgres.NewPgFilmRevisionStorage(db)
+
+	ctx := context.Background()
+
+	// Users seeded by migrations: 1 - user@example.com, 2 - admin@example.com
+	userId := int64(1)
+	adminId := int64(2)
+
+	actor, err := actorStorage.Create(ctx, "Actor_1", domain.ActorSex(1), domain.ActorBirthDate(time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)), nil)
+	require.NoError(t, err)
+	genre, err := genreStorage.Create(ctx, "Genre_1")
+	require.NoError(t, err)
+
+	credits := []*domain.FilmCreditParams{
+		{ActorId: actor.Id, Role: domain.CreditRoleActor, CharacterName: "Character_1", BillingOrder: 0},
+	}
+
+	film, err := filmStorage.Create(ctx, "Title_1", "Description_1", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, credits, []domain.GenreId{genre.Id}, &userId)
+	require.NoError(t, err)
+
+	title := domain.FilmTitle("Title_2")
+	rating := domain.FilmRating(7)
+	emptyCredits := []*domain.FilmCreditParams{}
+
+	film, err = filmStorage.Update(ctx, film.Id, nil, &title, nil, nil, &rating, nil, &emptyCredits, nil, &adminId)
+	require.NoError(t, err)
+
+	revisions, err := filmRevisionStorage.List(ctx, film.Id, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+
+	// The latest revision comes first and is numbered by the version of the film
+	require.Equal(t, film.Version, revisions[0].Revision)
+	require.Equal(t, &adminId, revisions[0].UserId)
+	require.Equal(t, title, revisions[0].Title)
+	require.Equal(t, rating, revisions[0].Rating)
+	require.Empty(t, revisions[0].Credits)
+	require.Equal(t, []domain.GenreId{genre.Id}, revisions[0].GenreIds)
+
+	require.Equal(t, int64(1), revisions[1].Revision)
+	require.Equal(t, &userId, revisions[1].UserId)
+	require.Equal(t, domain.FilmTitle("Title_1"), revisions[1].Title)
+	require.Equal(t, domain.FilmDescription("Description_1"), revisions[1].Description)
+	require.Equal(t, credits, revisions[1].Credits)
+
+	count, err := filmRevisionStorage.Count(ctx, film.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	revision, err := filmRevisionStorage.Get(ctx, film.Id, 1)
+	require.NoError(t, err)
+	require.Equal(t, revisions[1], revision)
+}
+
+func TestGetUnknownRevision(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+	filmRevisionStorage := postgres.NewPgFilmRevisionStorage(db)
+
+	ctx := context.Background()
+
+	film, err := filmStorage.Create(ctx, "Title_1", "Description_1", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, nil, nil, nil)
+	require.NoError(t, err)
+
+	_, err = filmRevisionStorage.Get(ctx, film.Id, 2)
+	require.ErrorIs(t, err, storage.ErrFilmRevisionNotFound)
+}
+
+func TestFailedUpdateRecordsNoRevision(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+	filmRevisionStorage := postgres.NewPgFilmRevisionStorage(db)
+
+	ctx := context.Background()
+
+	film, err := filmStorage.Create(ctx, "Title_1", "Description_1", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, nil, nil, nil)
+	require.NoError(t, err)
+
+	title := domain.FilmTitle("Title_2")
+	staleVersion := film.Version + 1
+
+	_, err = filmStorage.Update(ctx, film.Id, &staleVersion, &title, nil, nil, nil, nil, nil, nil, nil)
+	require.ErrorIs(t, err, storage.ErrFilmVersionMismatch)
+
+	count, err := filmRevisionStorage.Count(ctx, film.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+}
+
+func TestRestoredFilmKeepsRevisionOfVersion(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+	filmRevisionStorage := postgres.NewPgFilmRevisionStorage(db)
+
+	ctx := context.Background()
+
+	film, err := filmStorage.Create(ctx, "Title_1", "Description_1", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, nil, nil, nil)
+	require.NoError(t, err)
+
+	title := domain.FilmTitle("Title_2")
+
+	film, err = filmStorage.Update(ctx, film.Id, nil, &title, nil, nil, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, filmStorage.Delete(ctx, film.Id, &film.Version, nil))
+
+	restoredFilm, err := filmStorage.Restore(ctx, film.Id, nil)
+	require.NoError(t, err)
+	require.Equal(t, film.Version, restoredFilm.Version)
+
+	// The revision of the current version is the one to diff against
+	revision, err := filmRevisionStorage.Get(ctx, film.Id, restoredFilm.Version)
+	require.NoError(t, err)
+	require.Equal(t, title, revision.Title)
+
+	count, err := filmRevisionStorage.Count(ctx, film.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+}
@@ -0,0 +1,863 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/postgres"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/postgres/tests/pgtest"
+	"testing"
+	"time"
+)
+
+func TestListIncludesFilmsWithoutActors(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+	actorStorage := postgres.NewPgActorStorage(db)
+
+	ctx := context.Background()
+
+	actor := createActor(t, actorStorage, "Actor_1")
+
+	filmWithActor := createFilm(t, filmStorage, "Title_1", 5, []domain.ActorId{actor.Id})
+	filmWithoutActors := createFilm(t, filmStorage, "Title_2", 7, nil)
+
+	films, _, err := filmStorage.List(ctx, nil, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, films, 2)
+
+	require.Equal(t, filmWithoutActors.Id, films[0].Id)
+	require.Empty(t, films[0].Actors)
+
+	require.Equal(t, filmWithActor.Id, films[1].Id)
+	require.Len(t, films[1].Actors, 1)
+	require.Equal(t, actor.Id, films[1].Actors[0].Id)
+}
+
+func TestListIncludesFilmsWhoseActorsWereDeleted(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+	actorStorage := postgres.NewPgActorStorage(db)
+
+	ctx := context.Background()
+
+	actor := createActor(t, actorStorage, "Actor_1")
+	film := createFilm(t, filmStorage, "Title_1", 5, []domain.ActorId{actor.Id})
+
+	require.NoError(t, actorStorage.Delete(ctx, actor.Id, nil, nil))
+
+	films, _, err := filmStorage.List(ctx, nil, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, films, 1)
+	require.Equal(t, film.Id, films[0].Id)
+	require.Empty(t, films[0].Actors)
+}
+
+func TestListRespectsLimitOffsetAndOrder(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+	actorStorage := postgres.NewPgActorStorage(db)
+
+	ctx := context.Background()
+
+	actor1 := createActor(t, actorStorage, "Actor_1")
+	actor2 := createActor(t, actorStorage, "Actor_2")
+
+	filmC := createFilm(t, filmStorage, "C", 1, []domain.ActorId{actor1.Id, actor2.Id})
+	filmA := createFilm(t, filmStorage, "A", 2, nil)
+	filmD := createFilm(t, filmStorage, "D", 3, []domain.ActorId{actor1.Id})
+	filmB := createFilm(t, filmStorage, "B", 4, nil)
+
+	titleAsc := domain.FilmSort{{Field: domain.FilmSortFieldTitle, Direction: domain.SortDirectionAsc}}
+	titleDesc := domain.FilmSort{{Field: domain.FilmSortFieldTitle, Direction: domain.SortDirectionDesc}}
+
+	films, nextCursor, err := filmStorage.List(ctx, nil, titleAsc, nil, 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{filmA.Id, filmB.Id}, filmIds(films))
+	require.NotNil(t, nextCursor)
+
+	films, nextCursor, err = filmStorage.List(ctx, nil, titleAsc, nextCursor, 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{filmC.Id, filmD.Id}, filmIds(films))
+	require.Len(t, films[0].Actors, 2)
+	require.Len(t, films[1].Actors, 1)
+	require.Nil(t, nextCursor)
+
+	films, _, err = filmStorage.List(ctx, nil, titleDesc, nil, 2, 1)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{filmC.Id, filmB.Id}, filmIds(films))
+	require.Len(t, films[0].Actors, 2)
+	require.Empty(t, films[1].Actors)
+}
+
+func TestListFollowsSortKeysOrder(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+	actorStorage := postgres.NewPgActorStorage(db)
+
+	ctx := context.Background()
+
+	actor1 := createActor(t, actorStorage, "Actor_1")
+	actor2 := createActor(t, actorStorage, "Actor_2")
+
+	film1 := createFilm(t, filmStorage, "Title_1", 5, []domain.ActorId{actor1.Id})
+	film2 := createFilm(t, filmStorage, "Title_2", 7, []domain.ActorId{actor1.Id, actor2.Id})
+	film3 := createFilm(t, filmStorage, "Title_3", 7, nil)
+	film4 := createFilm(t, filmStorage, "Title_4", 5, []domain.ActorId{actor2.Id})
+
+	sort := domain.FilmSort{
+		{Field: domain.FilmSortFieldActorsCount, Direction: domain.SortDirectionDesc},
+		{Field: domain.FilmSortFieldRating, Direction: domain.SortDirectionAsc},
+		{Field: domain.FilmSortFieldId, Direction: domain.SortDirectionDesc},
+	}
+
+	films, nextCursor, err := filmStorage.List(ctx, nil, sort, nil, 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film2.Id, film4.Id}, filmIds(films))
+	require.NotNil(t, nextCursor)
+
+	films, nextCursor, err = filmStorage.List(ctx, nil, sort, nextCursor, 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film1.Id, film3.Id}, filmIds(films))
+	require.Nil(t, nextCursor)
+
+	sort = domain.FilmSort{
+		{Field: domain.FilmSortFieldRating, Direction: domain.SortDirectionAsc},
+		{Field: domain.FilmSortFieldActorsCount, Direction: domain.SortDirectionDesc},
+	}
+
+	films, _, err = filmStorage.List(ctx, nil, sort, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film1.Id, film4.Id, film2.Id, film3.Id}, filmIds(films))
+}
+
+func TestListRejectsUnknownSortField(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+
+	ctx := context.Background()
+
+	sort := domain.FilmSort{{Field: domain.FilmSortField("title;DROP TABLE films"), Direction: domain.SortDirectionAsc}}
+
+	_, _, err := filmStorage.List(ctx, nil, sort, nil, 10, 0)
+	require.ErrorIs(t, err, domain.ErrInvalidFilmSort)
+}
+
+func TestListWithFilterIncludesFilmsWithoutActors(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+	actorStorage := postgres.NewPgActorStorage(db)
+
+	ctx := context.Background()
+
+	actor := createActor(t, actorStorage, "Actor_1")
+
+	filmWithActor := createFilm(t, filmStorage, "Title_1", 5, []domain.ActorId{actor.Id})
+	filmWithoutActors := createFilm(t, filmStorage, "Title_2", 7, nil)
+	createFilm(t, filmStorage, "Other", 7, nil)
+
+	films, _, err := filmStorage.List(ctx, &domain.FilmFilter{Title: "Title"}, idAsc, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{filmWithActor.Id, filmWithoutActors.Id}, filmIds(films))
+	require.Len(t, films[0].Actors, 1)
+	require.Empty(t, films[1].Actors)
+
+	total, err := filmStorage.Count(ctx, &domain.FilmFilter{Title: "Title"})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), total)
+
+	films, _, err = filmStorage.List(ctx, &domain.FilmFilter{Title: "Title", ActorName: "Actor"}, idAsc, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{filmWithActor.Id}, filmIds(films))
+
+	total, err = filmStorage.Count(ctx, &domain.FilmFilter{Title: "Title", ActorName: "Actor"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+}
+
+func TestListWithFilterRespectsLimitOffset(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+	actorStorage := postgres.NewPgActorStorage(db)
+
+	ctx := context.Background()
+
+	actor := createActor(t, actorStorage, "Actor_1")
+
+	film1 := createFilm(t, filmStorage, "Title_1", 5, []domain.ActorId{actor.Id})
+	film2 := createFilm(t, filmStorage, "Title_2", 5, nil)
+	film3 := createFilm(t, filmStorage, "Title_3", 5, []domain.ActorId{actor.Id})
+
+	films, nextCursor, err := filmStorage.List(ctx, &domain.FilmFilter{Title: "Title"}, idAsc, nil, 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film1.Id, film2.Id}, filmIds(films))
+	require.NotNil(t, nextCursor)
+
+	films, nextCursor, err = filmStorage.List(ctx, &domain.FilmFilter{Title: "Title"}, idAsc, nextCursor, 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film3.Id}, filmIds(films))
+	require.Nil(t, nextCursor)
+
+	films, _, err = filmStorage.List(ctx, &domain.FilmFilter{Title: "Title"}, idAsc, nil, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film2.Id}, filmIds(films))
+	require.Empty(t, films[0].Actors)
+}
+
+func TestCreateAndUpdateRecordAuthors(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+
+	ctx := context.Background()
+
+	// Users seeded by migrations: 1 - user@example.com, 2 - admin@example.com
+	adminId := int64(2)
+	userId := int64(1)
+
+	film, err := filmStorage.Create(ctx, "Title_1", "Description", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, nil, nil, &adminId)
+	require.NoError(t, err)
+	require.Equal(t, &adminId, film.CreatedBy)
+	require.Nil(t, film.UpdatedBy)
+
+	title := domain.FilmTitle("Title_2")
+
+	updatedFilm, err := filmStorage.Update(ctx, film.Id, nil, &title, nil, nil, nil, nil, nil, nil, &userId)
+	require.NoError(t, err)
+	require.Equal(t, &adminId, updatedFilm.CreatedBy)
+	require.Equal(t, &userId, updatedFilm.UpdatedBy)
+
+	gotFilm, err := filmStorage.GetById(ctx, film.Id)
+	require.NoError(t, err)
+	require.Equal(t, &adminId, gotFilm.CreatedBy)
+	require.Equal(t, &userId, gotFilm.UpdatedBy)
+}
+
+func TestExportStreamsFilmsWithActorsAcrossBatches(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+	actorStorage := postgres.NewPgActorStorage(db)
+
+	ctx := context.Background()
+
+	// Rows of the film with actors are split between the first and the second batch of the cursor
+	_, err := db.Exec(`
+		INSERT INTO films (title, description, release_date, rating)
+		SELECT 'Title_' || i, 'Description', '2000-01-01', 5 FROM generate_series(1, 998) AS i
+`)
+	require.NoError(t, err)
+
+	actor1 := createActor(t, actorStorage, "Actor_1")
+	actor2 := createActor(t, actorStorage, "Actor_2")
+	actor3 := createActor(t, actorStorage, "Actor_3")
+
+	filmWithActors := createFilm(t, filmStorage, "Title_999", 5, []domain.ActorId{actor3.Id, actor1.Id, actor2.Id})
+	lastFilm := createFilm(t, filmStorage, "Title_1000", 5, nil)
+
+	var films []*domain.Film
+
+	err = filmStorage.Export(ctx, func(film *domain.Film) error {
+		films = append(films, film)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, films, 1000)
+
+	for i := 1; i < len(films); i++ {
+		require.Less(t, films[i-1].Id, films[i].Id)
+	}
+
+	require.Empty(t, films[0].Actors)
+	require.Equal(t, filmWithActors.Id, films[998].Id)
+	require.Equal(t, []domain.ActorId{actor3.Id, actor1.Id, actor2.Id}, actorIds(films[998].Actors))
+	require.Equal(t, lastFilm.Id, films[999].Id)
+	require.Empty(t, films[999].Actors)
+}
+
+func TestExportStopsAtCallbackError(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+
+	ctx := context.Background()
+
+	createFilm(t, filmStorage, "Title_1", 5, nil)
+	createFilm(t, filmStorage, "Title_2", 5, nil)
+
+	writeErr := errors.New("broken pipe")
+
+	var exported int
+
+	err := filmStorage.Export(ctx, func(film *domain.Film) error {
+		exported++
+		return writeErr
+	})
+	require.ErrorIs(t, err, writeErr)
+	require.Equal(t, 1, exported)
+}
+
+var idAsc = domain.FilmSort{{Field: domain.FilmSortFieldId, Direction: domain.SortDirectionAsc}}
+
+func TestBatchTransactionalRollsBackAll(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+
+	ctx := context.Background()
+
+	film := createFilm(t, filmStorage, "Title_1", 5, nil)
+	title := domain.FilmTitle("Title_2")
+
+	items, committed, err := filmStorage.Batch(ctx, domain.BatchModeTransactional, []*domain.FilmBatchOperation{
+		{Type: domain.BatchOperationCreate, Create: &domain.FilmCreateParams{Title: "Title_3", Rating: 7}},
+		{Type: domain.BatchOperationUpdate, Id: film.Id, Update: &domain.FilmUpdateParams{Title: &title}},
+		{Type: domain.BatchOperationDelete, Id: film.Id + 100},
+	}, nil)
+	require.NoError(t, err)
+	require.False(t, committed)
+	require.Len(t, items, 3)
+
+	require.Equal(t, domain.BatchItemAborted, items[0].Status)
+	require.Nil(t, items[0].Film)
+	require.Equal(t, domain.BatchItemAborted, items[1].Status)
+	require.Equal(t, domain.BatchItemFailed, items[2].Status)
+	require.ErrorIs(t, items[2].Err, storage.ErrFilmNotFound)
+
+	films, _, err := filmStorage.List(ctx, nil, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film.Id}, filmIds(films))
+	require.Equal(t, film.Title, films[0].Title)
+}
+
+func TestBatchBestEffortAppliesSucceeded(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+	actorStorage := postgres.NewPgActorStorage(db)
+
+	ctx := context.Background()
+
+	actor := createActor(t, actorStorage, "Actor_1")
+	film1 := createFilm(t, filmStorage, "Title_1", 5, nil)
+	film2 := createFilm(t, filmStorage, "Title_2", 6, nil)
+
+	rating := domain.FilmRating(9)
+	credits := domain.NewActorCredits([]domain.ActorId{actor.Id})
+
+	items, committed, err := filmStorage.Batch(ctx, domain.BatchModeBestEffort, []*domain.FilmBatchOperation{
+		{Type: domain.BatchOperationCreate, Create: &domain.FilmCreateParams{Title: "Title_3", Rating: 7, Credits: credits}},
+		{Type: domain.BatchOperationUpdate, Id: film2.Id + 100, Update: &domain.FilmUpdateParams{Rating: &rating}},
+		{Type: domain.BatchOperationUpdate, Id: film1.Id, Update: &domain.FilmUpdateParams{Rating: &rating}},
+		{Type: domain.BatchOperationDelete, Id: film2.Id},
+	}, nil)
+	require.NoError(t, err)
+	require.True(t, committed)
+	require.Len(t, items, 4)
+
+	require.Equal(t, domain.BatchItemSucceeded, items[0].Status)
+	require.Equal(t, domain.FilmTitle("Title_3"), items[0].Film.Title)
+	require.Equal(t, []domain.ActorId{actor.Id}, actorIds(items[0].Film.Actors))
+
+	require.Equal(t, domain.BatchItemFailed, items[1].Status)
+	require.ErrorIs(t, items[1].Err, storage.ErrFilmNotFound)
+
+	require.Equal(t, domain.BatchItemSucceeded, items[2].Status)
+	require.Equal(t, rating, items[2].Film.Rating)
+
+	require.Equal(t, domain.BatchItemSucceeded, items[3].Status)
+
+	films, _, err := filmStorage.List(ctx, nil, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []domain.FilmId{film1.Id, items[0].Film.Id}, filmIds(films))
+}
+
+func TestUpdateAndDeleteCheckVersion(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+
+	ctx := context.Background()
+
+	film := createFilm(t, filmStorage, "Title_1", 5, nil)
+	require.Equal(t, int64(1), film.Version)
+
+	staleVersion := film.Version
+	rating := domain.FilmRating(8)
+
+	updatedFilm, err := filmStorage.Update(ctx, film.Id, &film.Version, nil, nil, nil, &rating, nil, nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), updatedFilm.Version)
+
+	_, err = filmStorage.Update(ctx, film.Id, &staleVersion, nil, nil, nil, &rating, nil, nil, nil, nil)
+	require.ErrorIs(t, err, storage.ErrFilmVersionMismatch)
+
+	require.ErrorIs(t, filmStorage.Delete(ctx, film.Id, &staleVersion, nil), storage.ErrFilmVersionMismatch)
+
+	gotFilm, err := filmStorage.GetById(ctx, film.Id)
+	require.NoError(t, err)
+	require.Equal(t, updatedFilm.Version, gotFilm.Version)
+
+	require.NoError(t, filmStorage.Delete(ctx, film.Id, &gotFilm.Version, nil))
+}
+
+func TestBatchChecksVersion(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+
+	ctx := context.Background()
+
+	film1 := createFilm(t, filmStorage, "Title_1", 5, nil)
+	film2 := createFilm(t, filmStorage, "Title_2", 5, nil)
+
+	title := domain.FilmTitle("Title_3")
+	staleVersion := film1.Version + 1
+
+	items, committed, err := filmStorage.Batch(ctx, domain.BatchModeBestEffort, []*domain.FilmBatchOperation{
+		{Type: domain.BatchOperationUpdate, Id: film1.Id, Version: &staleVersion, Update: &domain.FilmUpdateParams{Title: &title}},
+		{Type: domain.BatchOperationDelete, Id: film2.Id, Version: &staleVersion},
+		{Type: domain.BatchOperationUpdate, Id: film1.Id, Version: &film1.Version, Update: &domain.FilmUpdateParams{Title: &title}},
+		{Type: domain.BatchOperationDelete, Id: film2.Id, Version: &film2.Version},
+	}, nil)
+	require.NoError(t, err)
+	require.True(t, committed)
+	require.Len(t, items, 4)
+
+	require.Equal(t, domain.BatchItemFailed, items[0].Status)
+	require.ErrorIs(t, items[0].Err, storage.ErrFilmVersionMismatch)
+	require.Equal(t, domain.BatchItemFailed, items[1].Status)
+	require.ErrorIs(t, items[1].Err, storage.ErrFilmVersionMismatch)
+
+	require.Equal(t, domain.BatchItemSucceeded, items[2].Status)
+	require.Equal(t, film1.Version+1, items[2].Film.Version)
+	require.Equal(t, domain.BatchItemSucceeded, items[3].Status)
+
+	// Listed films have their versions, so that they can be used in the 'If-Match' header and batch operations
+	films, _, err := filmStorage.List(ctx, nil, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film1.Id}, filmIds(films))
+	require.Equal(t, items[2].Film.Version, films[0].Version)
+}
+
+func TestConcurrentUpdatesWithSameVersion(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+
+	ctx := context.Background()
+
+	film := createFilm(t, filmStorage, "Title_1", 5, nil)
+
+	const writers = 5
+
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		go func(rating domain.FilmRating) {
+			_, err := filmStorage.Update(ctx, film.Id, &film.Version, nil, nil, nil, &rating, nil, nil, nil, nil)
+			errs <- err
+		}(domain.FilmRating(i + 1))
+	}
+
+	var succeeded int
+	for i := 0; i < writers; i++ {
+		if err := <-errs; err == nil {
+			succeeded++
+		} else {
+			require.ErrorIs(t, err, storage.ErrFilmVersionMismatch)
+		}
+	}
+	require.Equal(t, 1, succeeded)
+
+	gotFilm, err := filmStorage.GetById(ctx, film.Id)
+	require.NoError(t, err)
+	require.Equal(t, film.Version+1, gotFilm.Version)
+}
+
+func createActor(t *testing.T, actorStorage *postgres.PgActorStorage, name domain.ActorName) *domain.Actor {
+	t.Helper()
+
+	actor, err := actorStorage.Create(context.Background(), name, domain.ActorSex(1), domain.ActorBirthDate(time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)), nil)
+	require.NoError(t, err)
+
+	return actor
+}
+
+func createFilm(t *testing.T, filmStorage *postgres.PgFilmStorage, title domain.FilmTitle, rating domain.FilmRating, actorIds []domain.ActorId) *domain.Film {
+	t.Helper()
+
+	film, err := filmStorage.Create(context.Background(), title, "Description", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), rating, domain.NewActorCredits(actorIds), nil, nil)
+	require.NoError(t, err)
+
+	return film
+}
+
+func filmIds(films []*domain.Film) []domain.FilmId {
+	ids := make([]domain.FilmId, len(films))
+	for i, film := range films {
+		ids[i] = film.Id
+	}
+	return ids
+}
+
+func TestFilmsGenres(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+	genreStorage := postgres.NewPgGenreStorage(db)
+
+	ctx := context.Background()
+
+	drama, err := genreStorage.Create(ctx, "Drama")
+	require.NoError(t, err)
+	comedy, err := genreStorage.Create(ctx, "Comedy")
+	require.NoError(t, err)
+
+	film1, err := filmStorage.Create(ctx, "Title_1", "Description", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, nil, []domain.GenreId{drama.Id, comedy.Id}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []*domain.Genre{comedy, drama}, film1.Genres)
+
+	film2 := createFilm(t, filmStorage, "Title_2", 7, nil)
+
+	films, _, err := filmStorage.List(ctx, &domain.FilmFilter{GenreId: &drama.Id}, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film1.Id}, filmIds(films))
+	require.Equal(t, []*domain.Genre{comedy, drama}, films[0].Genres)
+
+	total, err := filmStorage.Count(ctx, &domain.FilmFilter{GenreId: &drama.Id})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+
+	films, _, err = filmStorage.List(ctx, &domain.FilmFilter{Title: "Title", GenreId: &comedy.Id}, idAsc, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film1.Id}, filmIds(films))
+
+	total, err = filmStorage.Count(ctx, &domain.FilmFilter{Title: "Title", GenreId: &comedy.Id})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+
+	genreIds := []domain.GenreId{comedy.Id}
+	updatedFilm, err := filmStorage.Update(ctx, film2.Id, nil, nil, nil, nil, nil, nil, nil, &genreIds, nil)
+	require.NoError(t, err)
+	require.Equal(t, []*domain.Genre{comedy}, updatedFilm.Genres)
+
+	// Deleting a genre removes it from all of its films
+	require.NoError(t, genreStorage.Delete(ctx, comedy.Id))
+
+	gotFilm, err := filmStorage.GetById(ctx, film1.Id)
+	require.NoError(t, err)
+	require.Equal(t, []*domain.Genre{drama}, gotFilm.Genres)
+}
+
+func TestFilmsCredits(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+	actorStorage := postgres.NewPgActorStorage(db)
+
+	ctx := context.Background()
+
+	actor := createActor(t, actorStorage, "Actor_1")
+	director := createActor(t, actorStorage, "Director_1")
+
+	credits := []*domain.FilmCreditParams{
+		{ActorId: director.Id, Role: domain.CreditRoleDirector},
+		{ActorId: actor.Id, Role: domain.CreditRoleActor, CharacterName: "Hero", BillingOrder: 1},
+	}
+	film, err := filmStorage.Create(ctx, "Title_1", "Description", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, credits, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, film.Credits, 2)
+	require.Equal(t, director.Id, film.Credits[0].Actor.Id)
+	require.Equal(t, domain.CreditRoleDirector, film.Credits[0].Role)
+	require.Equal(t, domain.CharacterName("Hero"), film.Credits[1].CharacterName)
+	require.Equal(t, []domain.ActorId{actor.Id}, actorIds(film.Actors))
+
+	createFilm(t, filmStorage, "Title_2", 7, []domain.ActorId{director.Id})
+
+	// A director is found only by the films they directed
+	films, _, err := filmStorage.List(ctx, &domain.FilmFilter{DirectorName: "Director"}, idAsc, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film.Id}, filmIds(films))
+
+	total, err := filmStorage.Count(ctx, &domain.FilmFilter{DirectorName: "Director"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+
+	// Plain actor ids replace only the credits with the 'actor' role
+	newActorIds := []domain.ActorId{director.Id}
+	updatedFilm, err := filmStorage.Update(ctx, film.Id, nil, nil, nil, nil, nil, &newActorIds, nil, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, updatedFilm.Credits, 2)
+	require.Equal(t, []domain.ActorId{director.Id}, actorIds(updatedFilm.Actors))
+
+	// Explicit credits replace all credits
+	newCredits := []*domain.FilmCreditParams{{ActorId: actor.Id, Role: domain.CreditRoleComposer}}
+	updatedFilm, err = filmStorage.Update(ctx, film.Id, nil, nil, nil, nil, nil, nil, &newCredits, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, updatedFilm.Credits, 1)
+	require.Equal(t, domain.CreditRoleComposer, updatedFilm.Credits[0].Role)
+	require.Empty(t, updatedFilm.Actors)
+}
+
+func actorIds(actors []*domain.Actor) []domain.ActorId {
+	ids := make([]domain.ActorId, len(actors))
+	for i, actor := range actors {
+		ids[i] = actor.Id
+	}
+	return ids
+}
+
+func TestSearchByQuery(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+	actorStorage := postgres.NewPgActorStorage(db)
+
+	ctx := context.Background()
+
+	releaseDate := domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	actor := createActor(t, actorStorage, "Keanu Reeves")
+
+	matrix, err := filmStorage.Create(ctx, "The Matrix", "A hacker learns the truth about his reality", releaseDate, 9, domain.NewActorCredits([]domain.ActorId{actor.Id}), nil, nil)
+	require.NoError(t, err)
+	documentary, err := filmStorage.Create(ctx, "Documentary", "How the matrix was filmed", releaseDate, 5, nil, nil, nil)
+	require.NoError(t, err)
+	createFilm(t, filmStorage, "Heat", 8, nil)
+
+	// Titles weigh more than descriptions, case does not matter
+	films, nextCursor, err := filmStorage.List(ctx, &domain.FilmFilter{Query: "matrix"}, nil, nil, 1, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{matrix.Id}, filmIds(films))
+	require.NotNil(t, films[0].Match)
+	require.Contains(t, films[0].Match.TitleHighlight, "<b>Matrix</b>")
+	require.NotNil(t, nextCursor)
+
+	films, nextCursor, err = filmStorage.List(ctx, &domain.FilmFilter{Query: "matrix"}, nil, nextCursor, 1, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{documentary.Id}, filmIds(films))
+	require.Contains(t, films[0].Match.DescriptionHighlight, "<b>matrix</b>")
+	require.Nil(t, nextCursor)
+
+	total, err := filmStorage.Count(ctx, &domain.FilmFilter{Query: "matrix"})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), total)
+
+	// Typos are tolerated
+	films, _, err = filmStorage.List(ctx, &domain.FilmFilter{Query: "matrx"}, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{matrix.Id}, filmIds(films))
+
+	// Names of credits are searched and kept up to date when an actor is renamed
+	films, _, err = filmStorage.List(ctx, &domain.FilmFilter{Query: "keanu"}, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{matrix.Id}, filmIds(films))
+
+	name := domain.ActorName("Carrie-Anne Moss")
+	_, err = actorStorage.Update(ctx, actor.Id, nil, &name, nil, nil, nil)
+	require.NoError(t, err)
+
+	films, _, err = filmStorage.List(ctx, &domain.FilmFilter{Query: "moss"}, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{matrix.Id}, filmIds(films))
+
+	// Fragment filters are case-insensitive
+	films, _, err = filmStorage.List(ctx, &domain.FilmFilter{Title: "the matrix"}, idAsc, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{matrix.Id}, filmIds(films))
+	require.Nil(t, films[0].Match)
+}
+
+func TestListWithRichFilter(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+	actorStorage := postgres.NewPgActorStorage(db)
+
+	ctx := context.Background()
+
+	actor1 := createActor(t, actorStorage, "Actor_1")
+	actor2, err := actorStorage.Create(ctx, "Actor_2", domain.ActorSex(2), domain.ActorBirthDate(time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)), nil)
+	require.NoError(t, err)
+
+	film1, err := filmStorage.Create(ctx, "Title_1", "Description", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, domain.NewActorCredits([]domain.ActorId{actor1.Id, actor2.Id}), nil, nil)
+	require.NoError(t, err)
+	film2, err := filmStorage.Create(ctx, "Title_2", "Description", domain.FilmReleaseDate(time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)), 8, domain.NewActorCredits([]domain.ActorId{actor1.Id}), nil, nil)
+	require.NoError(t, err)
+	film3, err := filmStorage.Create(ctx, "Title_3", "Description", domain.FilmReleaseDate(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)), 3, nil, nil, nil)
+	require.NoError(t, err)
+
+	releasedFrom := domain.FilmReleaseDate(time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC))
+	releasedTo := domain.FilmReleaseDate(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	minRating, maxRating := domain.FilmRating(4), domain.FilmRating(10)
+	actorSex := domain.ActorSex(2)
+
+	ratingDesc := domain.FilmSort{{Field: domain.FilmSortFieldRating, Direction: domain.SortDirectionDesc}}
+
+	testCases := []struct {
+		name     string
+		filter   *domain.FilmFilter
+		sort     domain.FilmSort
+		expected []domain.FilmId
+	}{
+		{
+			name:     "release_date_range",
+			filter:   &domain.FilmFilter{ReleasedFrom: &releasedFrom, ReleasedTo: &releasedTo},
+			sort:     idAsc,
+			expected: []domain.FilmId{film2.Id, film3.Id},
+		},
+		{
+			name:     "rating_range",
+			filter:   &domain.FilmFilter{MinRating: &minRating, MaxRating: &maxRating},
+			sort:     idAsc,
+			expected: []domain.FilmId{film1.Id, film2.Id},
+		},
+		{
+			name:     "any_actors",
+			filter:   &domain.FilmFilter{ActorIds: []domain.ActorId{actor1.Id, actor2.Id}, ActorsMatch: domain.FilmActorsMatchAny},
+			sort:     idAsc,
+			expected: []domain.FilmId{film1.Id, film2.Id},
+		},
+		{
+			name:     "all_actors",
+			filter:   &domain.FilmFilter{ActorIds: []domain.ActorId{actor1.Id, actor2.Id, actor2.Id}, ActorsMatch: domain.FilmActorsMatchAll},
+			sort:     idAsc,
+			expected: []domain.FilmId{film1.Id},
+		},
+		{
+			name:     "actor_sex",
+			filter:   &domain.FilmFilter{ActorSex: &actorSex},
+			sort:     idAsc,
+			expected: []domain.FilmId{film1.Id},
+		},
+		{
+			name:     "without_actors",
+			filter:   &domain.FilmFilter{WithoutActors: true},
+			sort:     idAsc,
+			expected: []domain.FilmId{film3.Id},
+		},
+		{
+			name:     "filter_with_sort",
+			filter:   &domain.FilmFilter{ActorIds: []domain.ActorId{actor1.Id}},
+			sort:     ratingDesc,
+			expected: []domain.FilmId{film2.Id, film1.Id},
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			films, _, err := filmStorage.List(ctx, tCase.filter, tCase.sort, nil, 10, 0)
+			require.NoError(t, err)
+			require.Equal(t, tCase.expected, filmIds(films))
+
+			total, err := filmStorage.Count(ctx, tCase.filter)
+			require.NoError(t, err)
+			require.Equal(t, int64(len(tCase.expected)), total)
+		})
+	}
+}
+
+func TestDeleteMovesFilmToTrashAndRestoreBringsItBack(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+	actorStorage := postgres.NewPgActorStorage(db)
+	genreStorage := postgres.NewPgGenreStorage(db)
+
+	ctx := context.Background()
+
+	actor := createActor(t, actorStorage, "Actor_1")
+	comedy, err := genreStorage.Create(ctx, "Comedy")
+	require.NoError(t, err)
+
+	film, err := filmStorage.Create(ctx, "Title_1", "Description", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, domain.NewActorCredits([]domain.ActorId{actor.Id}), []domain.GenreId{comedy.Id}, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, filmStorage.Delete(ctx, film.Id, nil, nil))
+
+	_, err = filmStorage.GetById(ctx, film.Id)
+	require.ErrorIs(t, err, storage.ErrFilmNotFound)
+
+	exists, err := filmStorage.IsExists(ctx, film.Id)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	films, _, err := filmStorage.List(ctx, nil, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Empty(t, films)
+
+	count, err := filmStorage.Count(ctx, &domain.FilmFilter{Title: "Title"})
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	actorFilms, _, err := actorStorage.List(ctx, nil, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, actorFilms, 1)
+	require.Empty(t, actorFilms[0].Films)
+
+	require.ErrorIs(t, filmStorage.Delete(ctx, film.Id, nil, nil), storage.ErrFilmNotFound)
+
+	restoredFilm, err := filmStorage.Restore(ctx, film.Id, nil)
+	require.NoError(t, err)
+	require.Equal(t, film.Id, restoredFilm.Id)
+	require.Equal(t, []domain.ActorId{actor.Id}, actorIds(restoredFilm.Actors))
+	require.Len(t, restoredFilm.Genres, 1)
+	require.Equal(t, comedy.Id, restoredFilm.Genres[0].Id)
+	require.Equal(t, film.Version, restoredFilm.Version)
+
+	films, _, err = filmStorage.List(ctx, nil, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film.Id}, filmIds(films))
+
+	_, err = filmStorage.Restore(ctx, film.Id, nil)
+	require.ErrorIs(t, err, storage.ErrFilmNotFound)
+}
+
+func TestRestoredActorIsBackInFilms(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+	actorStorage := postgres.NewPgActorStorage(db)
+
+	ctx := context.Background()
+
+	deletedActor := createActor(t, actorStorage, "Keanu")
+	otherActor := createActor(t, actorStorage, "Actor_2")
+	film := createFilm(t, filmStorage, "Title_1", 5, []domain.ActorId{deletedActor.Id})
+
+	require.NoError(t, actorStorage.Delete(ctx, deletedActor.Id, nil, nil))
+
+	films, _, err := filmStorage.List(ctx, &domain.FilmFilter{ActorName: "Keanu"}, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Empty(t, films)
+
+	films, _, err = filmStorage.List(ctx, &domain.FilmFilter{WithoutActors: true}, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film.Id}, filmIds(films))
+
+	// Replacing the actors of the film keeps the credit of the deleted actor
+	newActorIds := []domain.ActorId{otherActor.Id}
+	updatedFilm, err := filmStorage.Update(ctx, film.Id, nil, nil, nil, nil, nil, &newActorIds, nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, newActorIds, actorIds(updatedFilm.Actors))
+
+	_, err = actorStorage.Restore(ctx, deletedActor.Id, nil)
+	require.NoError(t, err)
+
+	gotFilm, err := filmStorage.GetById(ctx, film.Id)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []domain.ActorId{deletedActor.Id, otherActor.Id}, actorIds(gotFilm.Actors))
+
+	films, _, err = filmStorage.List(ctx, &domain.FilmFilter{ActorName: "Keanu"}, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film.Id}, filmIds(films))
+}
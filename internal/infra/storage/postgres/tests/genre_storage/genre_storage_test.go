@@ -0,0 +1,43 @@
+package postgres_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/postgres"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/postgres/tests/pgtest"
+	"testing"
+)
+
+func TestGenreNameIsUnique(t *testing.T) {
+	db := pgtest.New(t)
+
+	genreStorage := postgres.NewPgGenreStorage(db)
+
+	ctx := context.Background()
+
+	drama, err := genreStorage.Create(ctx, "Drama")
+	require.NoError(t, err)
+
+	_, err = genreStorage.Create(ctx, "Drama")
+	require.ErrorIs(t, err, storage.ErrGenreAlreadyExists)
+
+	comedy, err := genreStorage.Create(ctx, "Comedy")
+	require.NoError(t, err)
+
+	_, err = genreStorage.Update(ctx, comedy.Id, "Drama")
+	require.ErrorIs(t, err, storage.ErrGenreAlreadyExists)
+
+	genres, err := genreStorage.List(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []*domain.Genre{comedy, drama}, genres)
+
+	exists, err := genreStorage.AreExists(ctx, []domain.GenreId{drama.Id, comedy.Id})
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	exists, err = genreStorage.AreExists(ctx, []domain.GenreId{drama.Id, comedy.Id + 100})
+	require.NoError(t, err)
+	require.False(t, exists)
+}
@@ -0,0 +1,86 @@
+package postgres_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/postgres"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/postgres/tests/pgtest"
+	"testing"
+	"time"
+)
+
+func TestReviewsUpdateCommunityScore(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+	reviewStorage := postgres.NewPgReviewStorage(db)
+
+	ctx := context.Background()
+
+	// Users seeded by migrations: 1 - user@example.com, 2 - admin@example.com
+	userId, adminId := int64(1), int64(2)
+
+	film1, err := filmStorage.Create(ctx, "Title_1", "Description", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, nil, nil, nil)
+	require.NoError(t, err)
+	film2, err := filmStorage.Create(ctx, "Title_2", "Description", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, nil, nil, nil)
+	require.NoError(t, err)
+
+	userReview, err := reviewStorage.Create(ctx, film1.Id, userId, 8, "Great film")
+	require.NoError(t, err)
+	require.Equal(t, domain.ReviewText("Great film"), userReview.Text)
+
+	_, err = reviewStorage.Create(ctx, film1.Id, userId, 9, "")
+	require.ErrorIs(t, err, storage.ErrReviewAlreadyExists)
+
+	_, err = reviewStorage.Create(ctx, film1.Id+100, userId, 9, "")
+	require.ErrorIs(t, err, storage.ErrFilmNotFound)
+
+	adminReview, err := reviewStorage.Create(ctx, film1.Id, adminId, 3, "")
+	require.NoError(t, err)
+
+	_, err = reviewStorage.Create(ctx, film2.Id, adminId, 7, "")
+	require.NoError(t, err)
+
+	gotFilm, err := filmStorage.GetById(ctx, film1.Id)
+	require.NoError(t, err)
+	require.Equal(t, domain.CommunityScore(5.5), gotFilm.CommunityScore)
+	require.Equal(t, int64(2), gotFilm.VotesCount)
+
+	// Films with a higher community score go first
+	sort, err := domain.NewFilmSort(domain.FilmSortKey{Field: domain.FilmSortFieldCommunityScore, Direction: domain.SortDirectionDesc})
+	require.NoError(t, err)
+
+	films, nextCursor, err := filmStorage.List(ctx, nil, sort, nil, 1, 0)
+	require.NoError(t, err)
+	require.Equal(t, film2.Id, films[0].Id)
+
+	films, _, err = filmStorage.List(ctx, nil, sort, nextCursor, 1, 0)
+	require.NoError(t, err)
+	require.Equal(t, film1.Id, films[0].Id)
+
+	rating := domain.ReviewRating(10)
+	emptyText := domain.ReviewText("")
+	updatedReview, err := reviewStorage.Update(ctx, userReview.Id, &rating, &emptyText)
+	require.NoError(t, err)
+	require.Equal(t, rating, updatedReview.Rating)
+	require.Empty(t, updatedReview.Text)
+
+	require.NoError(t, reviewStorage.Delete(ctx, adminReview.Id))
+	require.ErrorIs(t, reviewStorage.Delete(ctx, adminReview.Id), storage.ErrReviewNotFound)
+
+	gotFilm, err = filmStorage.GetById(ctx, film1.Id)
+	require.NoError(t, err)
+	require.Equal(t, domain.CommunityScore(10), gotFilm.CommunityScore)
+	require.Equal(t, int64(1), gotFilm.VotesCount)
+
+	reviews, err := reviewStorage.ListByFilmId(ctx, film1.Id, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, reviews, 1)
+	require.Equal(t, userReview.Id, reviews[0].Id)
+
+	total, err := reviewStorage.CountByFilmId(ctx, film1.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+}
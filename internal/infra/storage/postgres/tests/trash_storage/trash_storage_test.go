@@ -0,0 +1,109 @@
+package postgres_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/postgres"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/postgres/tests/pgtest"
+	"testing"
+	"time"
+)
+
+func TestListDeletedFilmsAndActors(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+	actorStorage := postgres.NewPgActorStorage(db)
+	trashStorage := postgres.NewPgTrashStorage(db)
+
+	ctx := context.Background()
+
+	// Users seeded by migrations: 1 - user@example.com, 2 - admin@example.com
+	adminId := int64(2)
+
+	actor, err := actorStorage.Create(ctx, "Actor_1", domain.ActorSex(1), domain.ActorBirthDate(time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)), nil)
+	require.NoError(t, err)
+	film, err := filmStorage.Create(ctx, "Title_1", "Description", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, domain.NewActorCredits([]domain.ActorId{actor.Id}), nil, nil)
+	require.NoError(t, err)
+	_, err = filmStorage.Create(ctx, "Title_2", "Description", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, nil, nil, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, filmStorage.Delete(ctx, film.Id, nil, &adminId))
+	require.NoError(t, actorStorage.Delete(ctx, actor.Id, nil, &adminId))
+
+	items, err := trashStorage.List(ctx, nil, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	// The most recently deleted items come first
+	require.Equal(t, domain.TrashEntityTypeActor, items[0].EntityType)
+	require.Equal(t, actor.Id.Int64(), items[0].EntityId)
+	require.Equal(t, "Actor_1", items[0].Name)
+	require.Equal(t, &adminId, items[0].DeletedBy)
+
+	require.Equal(t, domain.TrashEntityTypeFilm, items[1].EntityType)
+	require.Equal(t, film.Id.Int64(), items[1].EntityId)
+	require.Equal(t, "Title_1", items[1].Name)
+
+	entityType := domain.TrashEntityTypeFilm
+	filter := &domain.TrashFilter{EntityType: &entityType}
+
+	items, err = trashStorage.List(ctx, filter, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, film.Id.Int64(), items[0].EntityId)
+
+	count, err := trashStorage.Count(ctx, filter)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	_, err = filmStorage.Restore(ctx, film.Id, &adminId)
+	require.NoError(t, err)
+
+	count, err = trashStorage.Count(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+}
+
+func TestPurgeDeletesOnlyExpiredItems(t *testing.T) {
+	db := pgtest.New(t)
+
+	filmStorage := postgres.NewPgFilmStorage(db)
+	actorStorage := postgres.NewPgActorStorage(db)
+	trashStorage := postgres.NewPgTrashStorage(db)
+
+	ctx := context.Background()
+
+	actor, err := actorStorage.Create(ctx, "Actor_1", domain.ActorSex(1), domain.ActorBirthDate(time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)), nil)
+	require.NoError(t, err)
+	expiredFilm, err := filmStorage.Create(ctx, "Title_1", "Description", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, domain.NewActorCredits([]domain.ActorId{actor.Id}), nil, nil)
+	require.NoError(t, err)
+	recentFilm, err := filmStorage.Create(ctx, "Title_2", "Description", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, nil, nil, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, filmStorage.Delete(ctx, expiredFilm.Id, nil, nil))
+	require.NoError(t, filmStorage.Delete(ctx, recentFilm.Id, nil, nil))
+	require.NoError(t, actorStorage.Delete(ctx, actor.Id, nil, nil))
+
+	_, err = db.ExecContext(ctx, `UPDATE films SET deleted_at = NOW() - INTERVAL '31 days' WHERE id = $1`, expiredFilm.Id)
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, `UPDATE actors SET deleted_at = NOW() - INTERVAL '31 days' WHERE id = $1`, actor.Id)
+	require.NoError(t, err)
+
+	purgedFilms, purgedActors, err := trashStorage.Purge(ctx, time.Now().Add(-30*24*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), purgedFilms)
+	require.Equal(t, int64(1), purgedActors)
+
+	_, err = filmStorage.Restore(ctx, expiredFilm.Id, nil)
+	require.ErrorIs(t, err, storage.ErrFilmNotFound)
+	_, err = actorStorage.Restore(ctx, actor.Id, nil)
+	require.ErrorIs(t, err, storage.ErrActorNotFound)
+
+	items, err := trashStorage.List(ctx, nil, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, recentFilm.Id.Int64(), items[0].EntityId)
+}
@@ -0,0 +1,15 @@
+package sqlite
+
+import (
+	"strconv"
+	"strings"
+)
+
+// jsonIdArray encodes ids as a JSON array. SQLite has no array parameters, so queries expand the array with json_each
+func jsonIdArray[T ~int64](ids []T) string {
+	encodedIds := make([]string, len(ids))
+	for i, id := range ids {
+		encodedIds[i] = strconv.FormatInt(int64(id), 10)
+	}
+	return "[" + strings.Join(encodedIds, ",") + "]"
+}
@@ -0,0 +1,5 @@
+// Package sqlite implements the storages over an embedded SQLite database with the schema of migrations/sqlite,
+// behaving the same way as the Postgres storages.
+//
+// SQLite keeps times as text and compares them as text, so every time passed to a query is converted to UTC first.
+package sqlite
@@ -0,0 +1,29 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// fetchRows passes rows of the query within the transaction to scan one by one.
+// SQLite steps through the result of a query as its rows are read, so that rows are never held in memory all at once
+func fetchRows(ctx context.Context, tx *sql.Tx, query string, scan func(rows *sql.Rows) error) error {
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to query rows: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err = scan(rows); err != nil {
+			return err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return nil
+}
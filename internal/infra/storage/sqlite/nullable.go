@@ -0,0 +1,18 @@
+package sqlite
+
+import "database/sql"
+
+func nullInt64Ptr(value sql.NullInt64) *int64 {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Int64
+}
+
+// nullJSON makes an empty JSON snapshot NULL instead of an invalid empty 'jsonb' value
+func nullJSON(value []byte) any {
+	if len(value) == 0 {
+		return nil
+	}
+	return string(value)
+}
@@ -0,0 +1,49 @@
+package sqlite
+
+import (
+	"fmt"
+	"strings"
+)
+
+type orderColumn struct {
+	name  string
+	order string
+}
+
+func (c *orderColumn) isDesc() bool {
+	return strings.EqualFold(c.order, "desc")
+}
+
+func buildOrderParam(columns []orderColumn) string {
+	orderByClauses := make([]string, len(columns))
+	for i, column := range columns {
+		orderByClauses[i] = fmt.Sprintf("%s %s", column.name, column.order)
+	}
+	return " ORDER BY " + strings.Join(orderByClauses, ",") + " "
+}
+
+// buildKeysetCondition builds a condition that selects rows placed strictly after
+// the row with the given column values in the order defined by columns, e.g.
+// '(f.rating < $1) OR (f.rating = $1 AND f.id > $2)'.
+// Placeholders are numbered starting with firstParamIndex.
+func buildKeysetCondition(columns []orderColumn, values []any, firstParamIndex int) (string, []any) {
+	var orConditions []string
+
+	for i, column := range columns {
+		var andConditions []string
+
+		for j := 0; j < i; j++ {
+			andConditions = append(andConditions, fmt.Sprintf("%s = $%d", columns[j].name, firstParamIndex+j))
+		}
+
+		operator := ">"
+		if column.isDesc() {
+			operator = "<"
+		}
+		andConditions = append(andConditions, fmt.Sprintf("%s %s $%d", column.name, operator, firstParamIndex+i))
+
+		orConditions = append(orConditions, "("+strings.Join(andConditions, " AND ")+")")
+	}
+
+	return "(" + strings.Join(orConditions, " OR ") + ")", values
+}
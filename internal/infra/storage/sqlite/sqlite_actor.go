@@ -0,0 +1,34 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+)
+
+type SqliteActor struct {
+	Id        int64
+	Name      string
+	Sex       uint8
+	BirthDate time.Time
+	Films     []*SqliteFilm
+	CreatedBy sql.NullInt64
+	UpdatedBy sql.NullInt64
+	Version   int64
+}
+
+// SqliteNullableActor is an actor read through an outer join, so all of its columns may be NULL
+type SqliteNullableActor struct {
+	Id        sql.NullInt64
+	Name      sql.NullString
+	Sex       sql.NullInt16
+	BirthDate sql.NullTime
+}
+
+func (a *SqliteNullableActor) SqliteActor() *SqliteActor {
+	return &SqliteActor{
+		Id:        a.Id.Int64,
+		Name:      a.Name.String,
+		Sex:       uint8(a.Sex.Int16),
+		BirthDate: a.BirthDate.Time,
+	}
+}
@@ -0,0 +1,16 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+)
+
+type SqliteActorRevision struct {
+	ActorId   int64
+	Revision  int64
+	UserId    sql.NullInt64
+	Name      string
+	Sex       uint8
+	BirthDate time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,144 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+)
+
+type SqliteActorRevisionStorage struct {
+	db *sqlx.DB
+}
+
+func NewSqliteActorRevisionStorage(db *sqlx.DB) *SqliteActorRevisionStorage {
+	return &SqliteActorRevisionStorage{db: db}
+}
+
+// List lists revisions of an actor starting with the latest one
+func (s *SqliteActorRevisionStorage) List(ctx context.Context, actorId domain.ActorId, limit, offset int) ([]*domain.ActorRevision, error) {
+	query := `
+			SELECT actor_id,
+			       revision,
+			       user_id,
+			       name,
+			       sex,
+			       birthdate,
+			       created_at
+			FROM actor_revisions
+			WHERE actor_id=$1
+			ORDER BY revision DESC
+			LIMIT $2 OFFSET $3
+`
+	rows, err := s.db.QueryContext(ctx, query, actorId, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list actor revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []*domain.ActorRevision
+
+	for rows.Next() {
+		var revision SqliteActorRevision
+		if err = scanActorRevision(rows, &revision); err != nil {
+			return nil, fmt.Errorf("failed to list actor revisions: %w", err)
+		}
+		revisions = append(revisions, buildDomainActorRevision(&revision))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list actor revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+func (s *SqliteActorRevisionStorage) Count(ctx context.Context, actorId domain.ActorId) (int64, error) {
+	query := `
+			SELECT COUNT(*) FROM actor_revisions
+			WHERE actor_id=$1
+`
+	var count int64
+	if err := s.db.QueryRowContext(ctx, query, actorId).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count actor revisions: %w", err)
+	}
+	return count, nil
+}
+
+func (s *SqliteActorRevisionStorage) Get(ctx context.Context, actorId domain.ActorId, revision int64) (*domain.ActorRevision, error) {
+	query := `
+			SELECT actor_id,
+			       revision,
+			       user_id,
+			       name,
+			       sex,
+			       birthdate,
+			       created_at
+			FROM actor_revisions
+			WHERE actor_id=$1 AND revision=$2
+`
+	var actorRevision SqliteActorRevision
+
+	row := s.db.QueryRowContext(ctx, query, actorId, revision)
+	if err := scanActorRevision(row, &actorRevision); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to get an actor revision: %w", storage.ErrActorRevisionNotFound)
+		}
+		return nil, fmt.Errorf("failed to get an actor revision: %w", err)
+	}
+
+	return buildDomainActorRevision(&actorRevision), nil
+}
+
+// insertActorRevision is called by SqliteActorStorage within the transaction of the change that has produced the revision
+func insertActorRevision(ctx context.Context, tx *sql.Tx, revision *domain.ActorRevision) error {
+	query := `
+			INSERT INTO actor_revisions (
+			                             actor_id,
+			                             revision,
+			                             user_id,
+			                             name,
+			                             sex,
+			                             birthdate
+				) VALUES ($1, $2, $3, $4, $5, $6)
+`
+	_, err := tx.ExecContext(ctx, query,
+		revision.ActorId,
+		revision.Revision,
+		revision.UserId,
+		revision.Name,
+		revision.Sex,
+		revision.BirthDate.Time().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert an actor revision: %w", err)
+	}
+	return nil
+}
+
+func scanActorRevision(row rowScanner, revision *SqliteActorRevision) error {
+	return row.Scan(
+		&revision.ActorId,
+		&revision.Revision,
+		&revision.UserId,
+		&revision.Name,
+		&revision.Sex,
+		&revision.BirthDate,
+		&revision.CreatedAt,
+	)
+}
+
+func buildDomainActorRevision(sqliteRevision *SqliteActorRevision) *domain.ActorRevision {
+	return &domain.ActorRevision{
+		ActorId:   domain.ActorId(sqliteRevision.ActorId),
+		Revision:  sqliteRevision.Revision,
+		UserId:    nullInt64Ptr(sqliteRevision.UserId),
+		Name:      domain.ActorName(sqliteRevision.Name),
+		Sex:       domain.ActorSex(sqliteRevision.Sex),
+		BirthDate: domain.ActorBirthDate(sqliteRevision.BirthDate),
+		CreatedAt: sqliteRevision.CreatedAt,
+	}
+}
@@ -0,0 +1,17 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+)
+
+type SqliteAuditEvent struct {
+	Id         int64
+	UserId     sql.NullInt64
+	Operation  string
+	EntityType string
+	EntityId   int64
+	Before     []byte
+	After      []byte
+	CreatedAt  time.Time
+}
@@ -0,0 +1,154 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"strings"
+)
+
+type SqliteAuditStorage struct {
+	db *sqlx.DB
+}
+
+func NewSqliteAuditStorage(db *sqlx.DB) *SqliteAuditStorage {
+	return &SqliteAuditStorage{db: db}
+}
+
+func (s *SqliteAuditStorage) List(ctx context.Context, filter *domain.AuditFilter, limit, offset int) ([]*domain.AuditEvent, error) {
+	whereParam, args := buildAuditFilterCondition(filter)
+	limitOffsetParams := fmt.Sprintf(" LIMIT %d OFFSET %d ", limit, offset)
+
+	query := `
+			SELECT id,
+			       user_id,
+			       operation,
+			       entity_type,
+			       entity_id,
+			       before,
+			       after,
+			       created_at
+			FROM audit_events` + whereParam + `
+			ORDER BY created_at DESC, id DESC` + limitOffsetParams
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*domain.AuditEvent
+
+	for rows.Next() {
+		var event SqliteAuditEvent
+
+		if err = rows.Scan(
+			&event.Id,
+			&event.UserId,
+			&event.Operation,
+			&event.EntityType,
+			&event.EntityId,
+			&event.Before,
+			&event.After,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to list audit events: %w", err)
+		}
+
+		events = append(events, buildDomainAuditEvent(&event))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+
+	return events, nil
+}
+
+func (s *SqliteAuditStorage) Count(ctx context.Context, filter *domain.AuditFilter) (int64, error) {
+	whereParam, args := buildAuditFilterCondition(filter)
+
+	query := `SELECT COUNT(*) FROM audit_events` + whereParam
+
+	var count int64
+
+	err := s.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count audit events: %w", err)
+	}
+
+	return count, nil
+}
+
+// insertAuditEvent is called by the catalog storages within the transaction of the audited mutation
+func insertAuditEvent(ctx context.Context, tx *sql.Tx, event *domain.AuditEvent) error {
+	query := `
+			INSERT INTO audit_events (
+			                          user_id,
+			                          operation,
+			                          entity_type,
+			                          entity_id,
+			                          before,
+			                          after
+				) VALUES ($1, $2, $3, $4, $5, $6)
+`
+	_, err := tx.ExecContext(ctx, query,
+		event.UserId,
+		event.Operation,
+		event.EntityType,
+		event.EntityId,
+		nullJSON(event.Before),
+		nullJSON(event.After),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert an audit event: %w", err)
+	}
+	return nil
+}
+
+func buildAuditFilterCondition(filter *domain.AuditFilter) (string, []any) {
+	var conditions []string
+	var args []any
+
+	addCondition := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.EntityType != nil {
+		addCondition("entity_type = $%d", filter.EntityType.String())
+	}
+	if filter.EntityId != nil {
+		addCondition("entity_id = $%d", *filter.EntityId)
+	}
+	if filter.UserId != nil {
+		addCondition("user_id = $%d", *filter.UserId)
+	}
+	if filter.From != nil {
+		addCondition("created_at >= $%d", filter.From.UTC())
+	}
+	if filter.To != nil {
+		addCondition("created_at < $%d", filter.To.UTC())
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func buildDomainAuditEvent(sqliteEvent *SqliteAuditEvent) *domain.AuditEvent {
+	return &domain.AuditEvent{
+		Id:         domain.AuditEventId(sqliteEvent.Id),
+		UserId:     nullInt64Ptr(sqliteEvent.UserId),
+		Operation:  domain.AuditOperation(sqliteEvent.Operation),
+		EntityType: domain.AuditEntityType(sqliteEvent.EntityType),
+		EntityId:   sqliteEvent.EntityId,
+		Before:     sqliteEvent.Before,
+		After:      sqliteEvent.After,
+		CreatedAt:  sqliteEvent.CreatedAt,
+	}
+}
@@ -0,0 +1,55 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+)
+
+type SqliteFilm struct {
+	Id             int64
+	Title          string
+	Description    sql.NullString
+	ReleaseDate    time.Time
+	Rating         uint8
+	CommunityScore float64
+	VotesCount     int64
+	Actors         []*SqliteActor
+	Credits        []*SqliteFilmCredit
+	Genres         []*SqliteGenre
+	CreatedBy      sql.NullInt64
+	UpdatedBy      sql.NullInt64
+	Version        int64
+	Match          *SqliteFilmMatch
+}
+
+type SqliteFilmMatch struct {
+	Relevance            float64
+	TitleHighlight       string
+	DescriptionHighlight string
+}
+
+type SqliteFilmCredit struct {
+	Actor         *SqliteActor
+	Role          string
+	CharacterName sql.NullString
+	BillingOrder  int
+}
+
+// SqliteNullableFilm is a film read through an outer join, so all of its columns may be NULL
+type SqliteNullableFilm struct {
+	Id          sql.NullInt64
+	Title       sql.NullString
+	Description sql.NullString
+	ReleaseDate sql.NullTime
+	Rating      sql.NullInt16
+}
+
+func (f *SqliteNullableFilm) SqliteFilm() *SqliteFilm {
+	return &SqliteFilm{
+		Id:          f.Id.Int64,
+		Title:       f.Title.String,
+		Description: f.Description,
+		ReleaseDate: f.ReleaseDate.Time,
+		Rating:      uint8(f.Rating.Int16),
+	}
+}
@@ -0,0 +1,128 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/pkg/database/savepoint"
+)
+
+type SqliteFilmImportStorage struct {
+	db *sqlx.DB
+
+	filmStorage  *SqliteFilmStorage
+	actorStorage *SqliteActorStorage
+}
+
+func NewSqliteFilmImportStorage(db *sqlx.DB) *SqliteFilmImportStorage {
+	return &SqliteFilmImportStorage{
+		db:           db,
+		filmStorage:  NewSqliteFilmStorage(db),
+		actorStorage: NewSqliteActorStorage(db),
+	}
+}
+
+// Import runs every row within its own savepoint, so that a failed row is rolled back alone
+// and the rest of the rows are still checked
+func (s *SqliteFilmImportStorage) Import(ctx context.Context, rows []*domain.FilmImportRow, commit bool, importedBy *int64) (*domain.FilmImportReport, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction while importing films: %w", err)
+	}
+	defer tx.Rollback()
+
+	report := &domain.FilmImportReport{}
+	var filmIds []domain.FilmId
+
+	for _, row := range rows {
+		var filmId domain.FilmId
+		var createdActors int
+
+		err = savepoint.Run(ctx, tx, "import_row", func() error {
+			var err error
+			filmId, createdActors, err = s.importRow(ctx, tx, row, importedBy)
+			return err
+		})
+
+		var rowErr *savepoint.ItemError
+		if errors.As(err, &rowErr) {
+			report.Errors = append(report.Errors, &domain.FilmImportRowError{Line: row.Line, Message: rowErr.Error()})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to import films: %w", err)
+		}
+
+		filmIds = append(filmIds, filmId)
+		report.CreatedActors += createdActors
+		report.MatchedActors += len(row.Actors) - createdActors
+	}
+
+	report.Films = len(filmIds)
+
+	if !commit || len(report.Errors) > 0 {
+		return report, nil
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction while importing films: %w", err)
+	}
+
+	report.Committed = true
+	report.FilmIds = filmIds
+
+	return report, nil
+}
+
+// importRow creates the film of the row and returns its id with the number of actors created for it
+func (s *SqliteFilmImportStorage) importRow(ctx context.Context, tx *sql.Tx, row *domain.FilmImportRow, importedBy *int64) (domain.FilmId, int, error) {
+	var createdActors int
+
+	actorIds := make([]domain.ActorId, 0, len(row.Actors))
+	for _, importActor := range row.Actors {
+		actorId, err := s.findActor(ctx, tx, importActor.Name, importActor.BirthDate)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if actorId == nil {
+			actor, err := s.actorStorage.createActor(ctx, tx, importActor.Name, importActor.Sex, importActor.BirthDate, importedBy)
+			if err != nil {
+				return 0, 0, err
+			}
+			actorId = &actor.Id
+			createdActors++
+		}
+
+		actorIds = append(actorIds, *actorId)
+	}
+
+	film, err := s.filmStorage.createFilm(ctx, tx, row.Title, row.Description, row.ReleaseDate, row.Rating, domain.NewActorCredits(actorIds), row.GenreIds, importedBy)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return film.Id, createdActors, nil
+}
+
+// findActor returns the id of the actor with the given name and birth date, or nil if there is no such actor
+func (s *SqliteFilmImportStorage) findActor(ctx context.Context, tx *sql.Tx, name domain.ActorName, birthDate domain.ActorBirthDate) (*domain.ActorId, error) {
+	query := `
+			SELECT id FROM actors
+			WHERE name = $1 AND birthdate = $2 AND deleted_at IS NULL
+			ORDER BY id
+			LIMIT 1
+`
+	var actorId domain.ActorId
+	err := tx.QueryRowContext(ctx, query, name, birthDate.Time().UTC()).Scan(&actorId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find actor: %w", err)
+	}
+	return &actorId, nil
+}
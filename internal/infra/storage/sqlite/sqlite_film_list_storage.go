@@ -0,0 +1,152 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	sqlitedriver "modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+const foreignKeyViolationErrCode = sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY
+
+type SqliteFilmListStorage struct {
+	db *sqlx.DB
+}
+
+func NewSqliteFilmListStorage(db *sqlx.DB) *SqliteFilmListStorage {
+	return &SqliteFilmListStorage{db: db}
+}
+
+func (s *SqliteFilmListStorage) Add(ctx context.Context, userId int64, list domain.FilmList, filmId domain.FilmId) error {
+	query := `
+			INSERT INTO user_film_lists (
+			                    user_id,
+			                    list,
+			                    film_id
+				) VALUES ($1, $2, $3)
+				ON CONFLICT DO NOTHING
+`
+	if _, err := s.db.ExecContext(ctx, query, userId, list, filmId); err != nil {
+		var sqliteErr *sqlitedriver.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.Code() == foreignKeyViolationErrCode {
+			return fmt.Errorf("failed to add a film to the list: %w", storage.ErrFilmNotFound)
+		}
+		return fmt.Errorf("failed to add a film to the list: %w", err)
+	}
+	return nil
+}
+
+func (s *SqliteFilmListStorage) Remove(ctx context.Context, userId int64, list domain.FilmList, filmId domain.FilmId) error {
+	query := `DELETE FROM user_film_lists WHERE user_id=$1 AND list=$2 AND film_id=$3`
+	result, err := s.db.ExecContext(ctx, query, userId, list, filmId)
+	if err != nil {
+		return fmt.Errorf("failed to remove a film from the list: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return fmt.Errorf("failed to remove a film from the list: %w", storage.ErrFilmListItemNotFound)
+	}
+	return nil
+}
+
+// ListFilms lists films of the user list in the same orders as SqliteFilmStorage.List
+func (s *SqliteFilmListStorage) ListFilms(ctx context.Context, userId int64, list domain.FilmList, sort domain.FilmSort, cursor *domain.FilmCursor, limit, offset int) ([]*domain.Film, *domain.FilmCursor, error) {
+	orderColumns, err := buildFilmOrderColumns(sort, false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list films of the list: %w", err)
+	}
+	orderParam := buildOrderParam(orderColumns)
+	limitOffsetParams := fmt.Sprintf(" LIMIT %d OFFSET %d ", limit+1, offset)
+
+	whereParam := " WHERE f.deleted_at IS NULL AND EXISTS (SELECT 1 FROM user_film_lists AS ufl WHERE ufl.film_id = f.id AND ufl.user_id = $1 AND ufl.list = $2)"
+	args := []any{userId, list}
+
+	if cursor != nil {
+		keysetCondition, keysetArgs := buildKeysetCondition(orderColumns, buildFilmCursorValues(orderColumns, cursor), len(args)+1)
+		whereParam += " AND " + keysetCondition
+		args = append(args, keysetArgs...)
+	}
+
+	query := `
+			SELECT f.id,
+			       f.title,
+			       f.description,
+			       f.release_date,
+			       f.rating,
+			       f.community_score,
+			       f.votes_count,
+			       f.created_by,
+			       f.updated_by,
+			       f.version,
+			       a.id,
+			       a.name,
+			       a.sex,
+			       a.birthdate
+			FROM (SELECT *
+			      FROM (SELECT f.*,
+			                   (SELECT COUNT(*) FROM films_actors AS fa INNER JOIN actors AS a ON a.id = fa.actor_id WHERE fa.film_id = f.id AND fa.role = 'actor' AND a.deleted_at IS NULL) AS actors_count
+			            FROM films AS f) AS f` + whereParam + orderParam + limitOffsetParams + `) AS f
+			LEFT JOIN films_actors AS fa ON f.id = fa.film_id AND fa.role = 'actor'
+			LEFT JOIN actors AS a ON a.id = fa.actor_id AND a.deleted_at IS NULL
+` + orderParam + `, fa.billing_order, fa.id`
+
+	films, err := queryFilmsWithActors(ctx, s.db, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list films of the list: %w", err)
+	}
+
+	if err = attachFilmsGenres(ctx, s.db, films); err != nil {
+		return nil, nil, fmt.Errorf("failed to list films of the list: %w", err)
+	}
+
+	domainFilms, nextCursor := buildFilmsPage(films, limit)
+
+	return domainFilms, nextCursor, nil
+}
+
+func (s *SqliteFilmListStorage) CountFilms(ctx context.Context, userId int64, list domain.FilmList) (int64, error) {
+	query := `
+			SELECT COUNT(*) FROM user_film_lists AS ufl
+			INNER JOIN films AS f ON f.id = ufl.film_id
+			WHERE ufl.user_id=$1 AND ufl.list=$2 AND f.deleted_at IS NULL
+`
+	var count int64
+	if err := s.db.QueryRowContext(ctx, query, userId, list).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count films of the list: %w", err)
+	}
+	return count, nil
+}
+
+func (s *SqliteFilmListStorage) GetFilmsLists(ctx context.Context, userId int64, filmIds []domain.FilmId) (map[domain.FilmId][]domain.FilmList, error) {
+	query := `
+			SELECT film_id,
+			       list
+			FROM user_film_lists
+			WHERE user_id=$1 AND film_id IN (SELECT value FROM json_each($2))
+`
+	rows, err := s.db.QueryContext(ctx, query, userId, jsonIdArray(filmIds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lists of films: %w", err)
+	}
+	defer rows.Close()
+
+	filmsLists := make(map[domain.FilmId][]domain.FilmList)
+
+	for rows.Next() {
+		var filmId int64
+		var list string
+		if err = rows.Scan(&filmId, &list); err != nil {
+			return nil, fmt.Errorf("failed to get lists of films: %w", err)
+		}
+		filmsLists[domain.FilmId(filmId)] = append(filmsLists[domain.FilmId(filmId)], domain.FilmList(list))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get lists of films: %w", err)
+	}
+
+	return filmsLists, nil
+}
@@ -0,0 +1,27 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+)
+
+type SqliteFilmRevision struct {
+	FilmId      int64
+	Revision    int64
+	UserId      sql.NullInt64
+	Title       string
+	Description sql.NullString
+	ReleaseDate time.Time
+	Rating      uint8
+	Credits     []byte
+	GenreIds    []byte
+	CreatedAt   time.Time
+}
+
+// SqliteFilmRevisionCredit is a credit stored in the 'credits' JSON array of a film revision
+type SqliteFilmRevisionCredit struct {
+	ActorId       int64  `json:"actor_id"`
+	Role          string `json:"role"`
+	CharacterName string `json:"character_name"`
+	BillingOrder  int    `json:"billing_order"`
+}
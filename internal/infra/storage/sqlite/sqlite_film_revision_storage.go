@@ -0,0 +1,209 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+)
+
+type SqliteFilmRevisionStorage struct {
+	db *sqlx.DB
+}
+
+func NewSqliteFilmRevisionStorage(db *sqlx.DB) *SqliteFilmRevisionStorage {
+	return &SqliteFilmRevisionStorage{db: db}
+}
+
+// List lists revisions of a film starting with the latest one
+func (s *SqliteFilmRevisionStorage) List(ctx context.Context, filmId domain.FilmId, limit, offset int) ([]*domain.FilmRevision, error) {
+	query := `
+			SELECT film_id,
+			       revision,
+			       user_id,
+			       title,
+			       description,
+			       release_date,
+			       rating,
+			       credits,
+			       genre_ids,
+			       created_at
+			FROM film_revisions
+			WHERE film_id=$1
+			ORDER BY revision DESC
+			LIMIT $2 OFFSET $3
+`
+	rows, err := s.db.QueryContext(ctx, query, filmId, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list film revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []*domain.FilmRevision
+
+	for rows.Next() {
+		var revision SqliteFilmRevision
+		if err = scanFilmRevision(rows, &revision); err != nil {
+			return nil, fmt.Errorf("failed to list film revisions: %w", err)
+		}
+
+		domainRevision, err := buildDomainFilmRevision(&revision)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list film revisions: %w", err)
+		}
+
+		revisions = append(revisions, domainRevision)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list film revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+func (s *SqliteFilmRevisionStorage) Count(ctx context.Context, filmId domain.FilmId) (int64, error) {
+	query := `
+			SELECT COUNT(*) FROM film_revisions
+			WHERE film_id=$1
+`
+	var count int64
+	if err := s.db.QueryRowContext(ctx, query, filmId).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count film revisions: %w", err)
+	}
+	return count, nil
+}
+
+func (s *SqliteFilmRevisionStorage) Get(ctx context.Context, filmId domain.FilmId, revision int64) (*domain.FilmRevision, error) {
+	query := `
+			SELECT film_id,
+			       revision,
+			       user_id,
+			       title,
+			       description,
+			       release_date,
+			       rating,
+			       credits,
+			       genre_ids,
+			       created_at
+			FROM film_revisions
+			WHERE film_id=$1 AND revision=$2
+`
+	var filmRevision SqliteFilmRevision
+
+	row := s.db.QueryRowContext(ctx, query, filmId, revision)
+	if err := scanFilmRevision(row, &filmRevision); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to get a film revision: %w", storage.ErrFilmRevisionNotFound)
+		}
+		return nil, fmt.Errorf("failed to get a film revision: %w", err)
+	}
+
+	domainRevision, err := buildDomainFilmRevision(&filmRevision)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a film revision: %w", err)
+	}
+
+	return domainRevision, nil
+}
+
+// insertFilmRevision is called by SqliteFilmStorage within the transaction of the change that has produced the revision
+func insertFilmRevision(ctx context.Context, tx *sql.Tx, revision *domain.FilmRevision) error {
+	credits := make([]*SqliteFilmRevisionCredit, len(revision.Credits))
+	for i, credit := range revision.Credits {
+		credits[i] = &SqliteFilmRevisionCredit{
+			ActorId:       credit.ActorId.Int64(),
+			Role:          credit.Role.String(),
+			CharacterName: credit.CharacterName.String(),
+			BillingOrder:  credit.BillingOrder,
+		}
+	}
+
+	creditsJSON, err := json.Marshal(credits)
+	if err != nil {
+		return fmt.Errorf("failed to insert a film revision: %w", err)
+	}
+
+	query := `
+			INSERT INTO film_revisions (
+			                            film_id,
+			                            revision,
+			                            user_id,
+			                            title,
+			                            description,
+			                            release_date,
+			                            rating,
+			                            credits,
+			                            genre_ids
+				) VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8, $9)
+`
+	_, err = tx.ExecContext(ctx, query,
+		revision.FilmId,
+		revision.Revision,
+		revision.UserId,
+		revision.Title,
+		revision.Description,
+		revision.ReleaseDate.Time().UTC(),
+		revision.Rating,
+		string(creditsJSON),
+		jsonIdArray(revision.GenreIds),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert a film revision: %w", err)
+	}
+	return nil
+}
+
+func scanFilmRevision(row rowScanner, revision *SqliteFilmRevision) error {
+	return row.Scan(
+		&revision.FilmId,
+		&revision.Revision,
+		&revision.UserId,
+		&revision.Title,
+		&revision.Description,
+		&revision.ReleaseDate,
+		&revision.Rating,
+		&revision.Credits,
+		&revision.GenreIds,
+		&revision.CreatedAt,
+	)
+}
+
+func buildDomainFilmRevision(sqliteRevision *SqliteFilmRevision) (*domain.FilmRevision, error) {
+	var credits []*SqliteFilmRevisionCredit
+	if err := json.Unmarshal(sqliteRevision.Credits, &credits); err != nil {
+		return nil, fmt.Errorf("failed to decode film revision credits: %w", err)
+	}
+
+	domainCredits := make([]*domain.FilmCreditParams, len(credits))
+	for i, credit := range credits {
+		domainCredits[i] = &domain.FilmCreditParams{
+			ActorId:       domain.ActorId(credit.ActorId),
+			Role:          domain.CreditRole(credit.Role),
+			CharacterName: domain.CharacterName(credit.CharacterName),
+			BillingOrder:  credit.BillingOrder,
+		}
+	}
+
+	var genreIds []domain.GenreId
+	if err := json.Unmarshal(sqliteRevision.GenreIds, &genreIds); err != nil {
+		return nil, fmt.Errorf("failed to decode film revision genre ids: %w", err)
+	}
+
+	return &domain.FilmRevision{
+		FilmId:      domain.FilmId(sqliteRevision.FilmId),
+		Revision:    sqliteRevision.Revision,
+		UserId:      nullInt64Ptr(sqliteRevision.UserId),
+		Title:       domain.FilmTitle(sqliteRevision.Title),
+		Description: domain.FilmDescription(sqliteRevision.Description.String),
+		ReleaseDate: domain.FilmReleaseDate(sqliteRevision.ReleaseDate),
+		Rating:      domain.FilmRating(sqliteRevision.Rating),
+		Credits:     domainCredits,
+		GenreIds:    genreIds,
+		CreatedAt:   sqliteRevision.CreatedAt,
+	}, nil
+}
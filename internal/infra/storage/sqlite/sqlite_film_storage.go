@@ -0,0 +1,1220 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"github.com/vaberof/vk-internship-task/pkg/database/savepoint"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type SqliteFilmStorage struct {
+	db *sqlx.DB
+}
+
+func NewSqliteFilmStorage(db *sqlx.DB) *SqliteFilmStorage {
+	return &SqliteFilmStorage{db: db}
+}
+
+func (s *SqliteFilmStorage) Create(ctx context.Context, title domain.FilmTitle, description domain.FilmDescription, releaseDate domain.FilmReleaseDate, rating domain.FilmRating, credits []*domain.FilmCreditParams, genreIds []domain.GenreId, createdBy *int64) (*domain.Film, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction while creating film: %w", err)
+	}
+	defer tx.Rollback()
+
+	domainFilm, err := s.createFilm(ctx, tx, title, description, releaseDate, rating, credits, genreIds, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction while creating film: %w", err)
+	}
+
+	return domainFilm, nil
+}
+
+// createFilm creates the film with its credits and genres and records the audit event within the given transaction
+func (s *SqliteFilmStorage) createFilm(ctx context.Context, tx *sql.Tx, title domain.FilmTitle, description domain.FilmDescription, releaseDate domain.FilmReleaseDate, rating domain.FilmRating, credits []*domain.FilmCreditParams, genreIds []domain.GenreId, createdBy *int64) (*domain.Film, error) {
+	var film SqliteFilm
+
+	query := `
+			INSERT INTO films (
+			                    title,
+			                    description,
+			                    release_date,
+			                   	rating,
+			                    created_by
+				) VALUES ($1, $2, $3, $4, $5)
+				RETURNING 
+					id, 
+				 	title,
+					description,
+					release_date,
+					rating,
+					community_score,
+					votes_count,
+					created_by,
+					updated_by,
+					version
+`
+
+	row := tx.QueryRowContext(ctx, query, title, description, releaseDate.Time().UTC(), rating, createdBy)
+	if err := row.Scan(
+		&film.Id,
+		&film.Title,
+		&film.Description,
+		&film.ReleaseDate,
+		&film.Rating,
+		&film.CommunityScore,
+		&film.VotesCount,
+		&film.CreatedBy,
+		&film.UpdatedBy,
+		&film.Version,
+	); err != nil {
+		return nil, fmt.Errorf("failed to create a film: %w", err)
+	}
+
+	err := s.createFilmCredits(ctx, tx, film.Id, credits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a film: %w", err)
+	}
+
+	err = s.createFilmGenres(ctx, tx, film.Id, genreIds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a film: %w", err)
+	}
+
+	if err = s.attachFilmCredits(ctx, tx, &film); err != nil {
+		return nil, fmt.Errorf("failed to create a film: %w", err)
+	}
+
+	if err = attachFilmsGenres(ctx, tx, []*SqliteFilm{&film}); err != nil {
+		return nil, fmt.Errorf("failed to create a film: %w", err)
+	}
+
+	domainFilm := buildDomainFilm(&film)
+
+	auditEvent, err := domain.NewFilmAuditEvent(createdBy, domain.AuditOperationCreate, nil, domainFilm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a film: %w", err)
+	}
+	if err = insertAuditEvent(ctx, tx, auditEvent); err != nil {
+		return nil, fmt.Errorf("failed to create a film: %w", err)
+	}
+	if err = insertFilmRevision(ctx, tx, domain.NewFilmRevision(domainFilm, createdBy)); err != nil {
+		return nil, fmt.Errorf("failed to create a film: %w", err)
+	}
+
+	return domainFilm, nil
+}
+
+func (s *SqliteFilmStorage) Update(ctx context.Context, id domain.FilmId, version *int64, title *domain.FilmTitle, description *domain.FilmDescription, releaseDate *domain.FilmReleaseDate, rating *domain.FilmRating, actorIds *[]domain.ActorId, credits *[]*domain.FilmCreditParams, genreIds *[]domain.GenreId, updatedBy *int64) (*domain.Film, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction while updating film: %w", err)
+	}
+	defer tx.Rollback()
+
+	domainFilm, err := s.updateFilm(ctx, tx, id, version, title, description, releaseDate, rating, actorIds, credits, genreIds, updatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction while updating film: %w", err)
+	}
+
+	return domainFilm, nil
+}
+
+// updateFilm updates the film with its credits and genres and records the audit event within the given transaction
+func (s *SqliteFilmStorage) updateFilm(ctx context.Context, tx *sql.Tx, id domain.FilmId, version *int64, title *domain.FilmTitle, description *domain.FilmDescription, releaseDate *domain.FilmReleaseDate, rating *domain.FilmRating, actorIds *[]domain.ActorId, credits *[]*domain.FilmCreditParams, genreIds *[]domain.GenreId, updatedBy *int64) (*domain.Film, error) {
+	if err := s.checkFilmVersion(ctx, tx, id, version); err != nil {
+		return nil, fmt.Errorf("failed to update a film: %w", err)
+	}
+
+	filmBefore, err := s.getFilm(ctx, tx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update a film: %w", err)
+	}
+
+	var film SqliteFilm
+
+	query := `
+			UPDATE films 
+						SET title=COALESCE($1, title),
+							description=COALESCE($2, description),
+							release_date=COALESCE($3, release_date),
+							rating=COALESCE($4, rating),
+							updated_by=$5,
+							version=version + 1
+					 	WHERE id=$6
+			RETURNING
+			    id, 
+				title,
+				description,
+				release_date,
+				rating,
+				community_score,
+				votes_count,
+				created_by,
+				updated_by,
+				version
+`
+
+	var convReleaseDate *time.Time
+	if releaseDate != nil {
+		convDomainReleaseDate := releaseDate.Time().UTC()
+		convReleaseDate = &convDomainReleaseDate
+	}
+
+	row := tx.QueryRowContext(ctx, query, title, description, convReleaseDate, rating, updatedBy, id)
+	if err = row.Scan(
+		&film.Id,
+		&film.Title,
+		&film.Description,
+		&film.ReleaseDate,
+		&film.Rating,
+		&film.CommunityScore,
+		&film.VotesCount,
+		&film.CreatedBy,
+		&film.UpdatedBy,
+		&film.Version,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to update a film: %w", storage.ErrFilmNotFound)
+		}
+		return nil, fmt.Errorf("failed to update a film: %w", err)
+	}
+
+	if credits != nil {
+		err = s.deleteFilmCredits(ctx, tx, film.Id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update a film: %w", err)
+		}
+
+		err = s.createFilmCredits(ctx, tx, film.Id, *credits)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update a film: %w", err)
+		}
+	} else if actorIds != nil {
+		err = s.deleteFilmActors(ctx, tx, film.Id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update a film: %w", err)
+		}
+
+		err = s.createFilmCredits(ctx, tx, film.Id, domain.NewActorCredits(*actorIds))
+		if err != nil {
+			return nil, fmt.Errorf("failed to update a film: %w", err)
+		}
+	}
+
+	if genreIds != nil {
+		err = s.deleteFilmGenres(ctx, tx, film.Id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update a film: %w", err)
+		}
+
+		err = s.createFilmGenres(ctx, tx, film.Id, *genreIds)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update a film: %w", err)
+		}
+	}
+
+	if err = s.attachFilmCredits(ctx, tx, &film); err != nil {
+		return nil, fmt.Errorf("failed to update a film: %w", err)
+	}
+
+	if err = attachFilmsGenres(ctx, tx, []*SqliteFilm{&film}); err != nil {
+		return nil, fmt.Errorf("failed to update a film: %w", err)
+	}
+
+	domainFilm := buildDomainFilm(&film)
+
+	auditEvent, err := domain.NewFilmAuditEvent(updatedBy, domain.AuditOperationUpdate, buildDomainFilm(filmBefore), domainFilm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update a film: %w", err)
+	}
+	if err = insertAuditEvent(ctx, tx, auditEvent); err != nil {
+		return nil, fmt.Errorf("failed to update a film: %w", err)
+	}
+	if err = insertFilmRevision(ctx, tx, domain.NewFilmRevision(domainFilm, updatedBy)); err != nil {
+		return nil, fmt.Errorf("failed to update a film: %w", err)
+	}
+
+	return domainFilm, nil
+}
+
+func (s *SqliteFilmStorage) Delete(ctx context.Context, id domain.FilmId, version *int64, deletedBy *int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction while deleting film: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err = s.deleteFilm(ctx, tx, id, version, deletedBy); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction while deleting film: %w", err)
+	}
+
+	return nil
+}
+
+// deleteFilm moves the film to the trash and records the audit event within the given transaction.
+// Credits, genres and reviews of the film are kept, so that it is restored with them
+func (s *SqliteFilmStorage) deleteFilm(ctx context.Context, tx *sql.Tx, id domain.FilmId, version *int64, deletedBy *int64) error {
+	if err := s.checkFilmVersion(ctx, tx, id, version); err != nil {
+		return fmt.Errorf("failed to delete film: %w", err)
+	}
+
+	filmBefore, err := s.getFilm(ctx, tx, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete film: %w", err)
+	}
+
+	query := `UPDATE films SET deleted_at=$3, deleted_by=$2 WHERE id=$1 AND deleted_at IS NULL`
+	result, err := tx.ExecContext(ctx, query, id, deletedBy, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to delete film: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return fmt.Errorf("failed to delete film: %w", storage.ErrFilmNotFound)
+	}
+
+	auditEvent, err := domain.NewFilmAuditEvent(deletedBy, domain.AuditOperationDelete, buildDomainFilm(filmBefore), nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete film: %w", err)
+	}
+	if err = insertAuditEvent(ctx, tx, auditEvent); err != nil {
+		return fmt.Errorf("failed to delete film: %w", err)
+	}
+
+	return nil
+}
+
+// Restore brings the film back from the trash with the credits and genres it had, and records the audit event
+func (s *SqliteFilmStorage) Restore(ctx context.Context, id domain.FilmId, restoredBy *int64) (*domain.Film, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction while restoring film: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE films SET deleted_at=NULL, deleted_by=NULL, updated_by=$2 WHERE id=$1 AND deleted_at IS NOT NULL`
+	result, err := tx.ExecContext(ctx, query, id, restoredBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore film: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return nil, fmt.Errorf("failed to restore film: %w", storage.ErrFilmNotFound)
+	}
+
+	film, err := s.getFilm(ctx, tx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore film: %w", err)
+	}
+
+	domainFilm := buildDomainFilm(film)
+
+	auditEvent, err := domain.NewFilmAuditEvent(restoredBy, domain.AuditOperationRestore, nil, domainFilm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore film: %w", err)
+	}
+	if err = insertAuditEvent(ctx, tx, auditEvent); err != nil {
+		return nil, fmt.Errorf("failed to restore film: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction while restoring film: %w", err)
+	}
+
+	return domainFilm, nil
+}
+
+func (s *SqliteFilmStorage) GetById(ctx context.Context, id domain.FilmId) (*domain.Film, error) {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction while getting film: %w", err)
+	}
+	defer tx.Rollback()
+
+	film, err := s.getFilm(ctx, tx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a film: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction while getting film: %w", err)
+	}
+
+	return buildDomainFilm(film), nil
+}
+
+// List lists films matching the filter. Without an explicit sort, films found by a search query are ordered by relevance
+func (s *SqliteFilmStorage) List(ctx context.Context, filter *domain.FilmFilter, sort domain.FilmSort, cursor *domain.FilmCursor, limit, offset int) ([]*domain.Film, *domain.FilmCursor, error) {
+	byQuery := filter != nil && filter.Query != ""
+
+	orderColumns, err := buildFilmOrderColumns(sort, byQuery)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list films: %w", err)
+	}
+	orderParam := buildOrderParam(orderColumns)
+	limitOffsetParams := fmt.Sprintf(" LIMIT %d OFFSET %d ", limit+1, offset)
+
+	whereParam, args := buildFilmFilterCondition(filter)
+
+	var relevanceColumn string
+	if byQuery {
+		relevanceColumn = ",\n" + buildFilmRelevanceExpression(1, 2) + " AS relevance"
+	}
+
+	var keysetParam string
+	if cursor != nil {
+		keysetCondition, keysetArgs := buildKeysetCondition(orderColumns, buildFilmCursorValues(orderColumns, cursor), len(args)+1)
+		keysetParam = " WHERE " + keysetCondition
+		args = append(args, keysetArgs...)
+	}
+
+	query := `
+			SELECT f.id,
+			       f.title,
+			       f.description,
+			       f.release_date,
+			       f.rating,
+			       f.community_score,
+			       f.votes_count,
+			       f.created_by,
+			       f.updated_by,
+			       f.version,
+			       a.id,
+			       a.name,
+			       a.sex,
+			       a.birthdate
+			FROM (SELECT *
+			      FROM (SELECT f.*,
+			                   (SELECT COUNT(*) FROM films_actors AS fa INNER JOIN actors AS a ON a.id = fa.actor_id WHERE fa.film_id = f.id AND fa.role = 'actor' AND a.deleted_at IS NULL) AS actors_count` + relevanceColumn + `
+			            FROM films AS f` + whereParam + `) AS f` + keysetParam + orderParam + limitOffsetParams + `) AS f
+			LEFT JOIN films_actors AS fa ON f.id = fa.film_id AND fa.role = 'actor'
+			LEFT JOIN actors AS a ON a.id = fa.actor_id AND a.deleted_at IS NULL
+` + orderParam + `, fa.billing_order, fa.id`
+
+	films, err := queryFilmsWithActors(ctx, s.db, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list films: %w", err)
+	}
+
+	if err = attachFilmsGenres(ctx, s.db, films); err != nil {
+		return nil, nil, fmt.Errorf("failed to list films: %w", err)
+	}
+
+	if byQuery {
+		if err = attachFilmsMatches(ctx, s.db, filter.Query, films); err != nil {
+			return nil, nil, fmt.Errorf("failed to list films: %w", err)
+		}
+	}
+
+	domainFilms, nextCursor := buildFilmsPage(films, limit)
+
+	return domainFilms, nextCursor, nil
+}
+
+func (s *SqliteFilmStorage) Count(ctx context.Context, filter *domain.FilmFilter) (int64, error) {
+	whereParam, args := buildFilmFilterCondition(filter)
+
+	query := `SELECT COUNT(*) FROM films AS f` + whereParam
+
+	var count int64
+
+	err := s.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count films: %w", err)
+	}
+
+	return count, nil
+}
+
+func (s *SqliteFilmStorage) IsExists(ctx context.Context, id domain.FilmId) (bool, error) {
+	query := `
+			SELECT id FROM films 
+			WHERE id=$1 AND deleted_at IS NULL
+`
+	var filmdId int64
+	err := s.db.QueryRowContext(ctx, query, id).Scan(&filmdId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check whether film exists or not: %w", err)
+	}
+	return true, nil
+}
+
+// Export reads films with their actors row by row, so that only the film being read is held in memory
+func (s *SqliteFilmStorage) Export(ctx context.Context, fn func(film *domain.Film) error) error {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("failed to start transaction while exporting films: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+			SELECT f.id,
+			       f.title,
+			       f.description,
+			       f.release_date,
+			       f.rating,
+			       f.community_score,
+			       f.votes_count,
+			       f.created_by,
+			       f.updated_by,
+			       f.version,
+			       a.id,
+			       a.name,
+			       a.sex,
+			       a.birthdate
+			FROM films AS f
+			LEFT JOIN films_actors AS fa ON f.id = fa.film_id AND fa.role = 'actor'
+			LEFT JOIN actors AS a ON a.id = fa.actor_id AND a.deleted_at IS NULL
+			WHERE f.deleted_at IS NULL
+			ORDER BY f.id, fa.billing_order, fa.id
+`
+
+	var film *SqliteFilm
+
+	err = fetchRows(ctx, tx, query, func(rows *sql.Rows) error {
+		var rowFilm SqliteFilm
+		var actor SqliteNullableActor
+
+		if err := rows.Scan(
+			&rowFilm.Id,
+			&rowFilm.Title,
+			&rowFilm.Description,
+			&rowFilm.ReleaseDate,
+			&rowFilm.Rating,
+			&rowFilm.CommunityScore,
+			&rowFilm.VotesCount,
+			&rowFilm.CreatedBy,
+			&rowFilm.UpdatedBy,
+			&rowFilm.Version,
+			&actor.Id,
+			&actor.Name,
+			&actor.Sex,
+			&actor.BirthDate,
+		); err != nil {
+			return err
+		}
+
+		// Rows of the same film follow each other, so the previous film is complete once the id changes
+		if film == nil || film.Id != rowFilm.Id {
+			if film != nil {
+				if err := fn(buildDomainFilm(film)); err != nil {
+					return err
+				}
+			}
+			film = &rowFilm
+		}
+
+		// A film without actors is joined with a single row of NULL actor columns
+		if actor.Id.Valid {
+			film.Actors = append(film.Actors, actor.SqliteActor())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export films: %w", err)
+	}
+
+	if film != nil {
+		if err = fn(buildDomainFilm(film)); err != nil {
+			return fmt.Errorf("failed to export films: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction while exporting films: %w", err)
+	}
+
+	return nil
+}
+
+// Batch runs every operation within its own savepoint, so that a failed operation is rolled back alone
+// and the rest of the operations still run
+func (s *SqliteFilmStorage) Batch(ctx context.Context, mode domain.BatchMode, operations []*domain.FilmBatchOperation, by *int64) ([]*domain.FilmBatchItem, bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to start transaction while applying batch of films: %w", err)
+	}
+	defer tx.Rollback()
+
+	items := make([]*domain.FilmBatchItem, len(operations))
+	var failed bool
+
+	for i, operation := range operations {
+		item := &domain.FilmBatchItem{Index: i, Type: operation.Type, Id: operation.Id}
+		items[i] = item
+
+		err = savepoint.Run(ctx, tx, "batch_operation", func() error {
+			var err error
+			item.Film, err = s.applyBatchOperation(ctx, tx, operation, by)
+			return err
+		})
+
+		var itemErr *savepoint.ItemError
+		if errors.As(err, &itemErr) {
+			item.Status = domain.BatchItemFailed
+			item.Err = itemErr.Err
+			item.Film = nil
+			failed = true
+			continue
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to apply batch of films: %w", err)
+		}
+		item.Status = domain.BatchItemSucceeded
+	}
+
+	if mode == domain.BatchModeTransactional && failed {
+		for _, item := range items {
+			if item.Status == domain.BatchItemSucceeded {
+				item.Status = domain.BatchItemAborted
+				item.Film = nil
+			}
+		}
+		return items, false, nil
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, false, fmt.Errorf("failed to commit transaction while applying batch of films: %w", err)
+	}
+
+	return items, true, nil
+}
+
+func (s *SqliteFilmStorage) applyBatchOperation(ctx context.Context, tx *sql.Tx, operation *domain.FilmBatchOperation, by *int64) (*domain.Film, error) {
+	switch operation.Type {
+	case domain.BatchOperationCreate:
+		params := operation.Create
+		return s.createFilm(ctx, tx, params.Title, params.Description, params.ReleaseDate, params.Rating, params.Credits, params.GenreIds, by)
+	case domain.BatchOperationUpdate:
+		params := operation.Update
+		return s.updateFilm(ctx, tx, operation.Id, operation.Version, params.Title, params.Description, params.ReleaseDate, params.Rating, params.ActorIds, params.Credits, params.GenreIds, by)
+	case domain.BatchOperationDelete:
+		return nil, s.deleteFilm(ctx, tx, operation.Id, operation.Version, by)
+	default:
+		return nil, fmt.Errorf("unexpected batch operation '%s'", operation.Type)
+	}
+}
+
+func (s *SqliteFilmStorage) getFilm(ctx context.Context, tx *sql.Tx, id domain.FilmId) (*SqliteFilm, error) {
+	var film SqliteFilm
+
+	query := `
+			SELECT id,
+			       title,
+			       description,
+			       release_date,
+			       rating,
+			       community_score,
+			       votes_count,
+			       created_by,
+			       updated_by,
+			       version
+			FROM films
+			WHERE id=$1 AND deleted_at IS NULL
+`
+
+	row := tx.QueryRowContext(ctx, query, id)
+	if err := row.Scan(
+		&film.Id,
+		&film.Title,
+		&film.Description,
+		&film.ReleaseDate,
+		&film.Rating,
+		&film.CommunityScore,
+		&film.VotesCount,
+		&film.CreatedBy,
+		&film.UpdatedBy,
+		&film.Version,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrFilmNotFound
+		}
+		return nil, err
+	}
+
+	if err := s.attachFilmCredits(ctx, tx, &film); err != nil {
+		return nil, err
+	}
+
+	if err := attachFilmsGenres(ctx, tx, []*SqliteFilm{&film}); err != nil {
+		return nil, err
+	}
+
+	return &film, nil
+}
+
+// checkFilmVersion checks that the film exists and has the given version, if it is set.
+// Write transactions hold the write lock of the database from their start, so concurrent writers of the film
+// wait for each other without locking its row
+func (s *SqliteFilmStorage) checkFilmVersion(ctx context.Context, tx *sql.Tx, id domain.FilmId, version *int64) error {
+	query := `SELECT version FROM films WHERE id=$1 AND deleted_at IS NULL`
+
+	var currentVersion int64
+	if err := tx.QueryRowContext(ctx, query, id).Scan(&currentVersion); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.ErrFilmNotFound
+		}
+		return fmt.Errorf("failed to check film version: %w", err)
+	}
+
+	if version != nil && *version != currentVersion {
+		return storage.ErrFilmVersionMismatch
+	}
+
+	return nil
+}
+
+func (s *SqliteFilmStorage) createFilmCredits(ctx context.Context, tx *sql.Tx, filmId int64, credits []*domain.FilmCreditParams) error {
+	queryInsertFilmsActors := `
+						INSERT INTO films_actors(film_id, actor_id, role, character_name, billing_order)
+						VALUES ($1, $2, $3, NULLIF($4, ''), $5)
+`
+	for _, credit := range credits {
+		_, err := tx.ExecContext(ctx, queryInsertFilmsActors, filmId, credit.ActorId, credit.Role, credit.CharacterName, credit.BillingOrder)
+		if err != nil {
+			return fmt.Errorf("failed to insert values to 'films_actors' table %w", err)
+		}
+	}
+	return nil
+}
+
+// deleteFilmActors deletes only the credits with the 'actor' role, so that the crew of the film stays.
+// Credits of deleted actors stay as well, so that they are back in the film once the actor is restored
+func (s *SqliteFilmStorage) deleteFilmActors(ctx context.Context, tx *sql.Tx, filmId int64) error {
+	queryDeleteFilmsActors := `
+						DELETE FROM films_actors
+						WHERE film_id=$1 AND role='actor' AND actor_id IN (SELECT id FROM actors WHERE deleted_at IS NULL)
+`
+	_, err := tx.ExecContext(ctx, queryDeleteFilmsActors, filmId)
+	if err != nil {
+		return fmt.Errorf("failed to delete values from 'films_actors' table %w", err)
+	}
+	return nil
+}
+
+// deleteFilmCredits deletes credits of all roles, except the credits of deleted actors, like deleteFilmActors
+func (s *SqliteFilmStorage) deleteFilmCredits(ctx context.Context, tx *sql.Tx, filmId int64) error {
+	queryDeleteFilmsActors := `
+						DELETE FROM films_actors
+						WHERE film_id=$1 AND actor_id IN (SELECT id FROM actors WHERE deleted_at IS NULL)
+`
+	_, err := tx.ExecContext(ctx, queryDeleteFilmsActors, filmId)
+	if err != nil {
+		return fmt.Errorf("failed to delete values from 'films_actors' table %w", err)
+	}
+	return nil
+}
+
+// attachFilmCredits loads credits of all roles of the film, its actors are the credits with the 'actor' role.
+// Credits of deleted actors are skipped
+func (s *SqliteFilmStorage) attachFilmCredits(ctx context.Context, tx *sql.Tx, film *SqliteFilm) error {
+	queryCredits := `
+		SELECT a.id,
+		       a.name,
+		       a.sex,
+		       a.birthdate,
+		       fa.role,
+		       fa.character_name,
+		       fa.billing_order
+		       FROM actors AS a
+		INNER JOIN films_actors AS fa ON a.id = fa.actor_id
+		WHERE fa.film_id=$1 AND a.deleted_at IS NULL
+		ORDER BY fa.billing_order, fa.id
+`
+
+	rows, err := tx.QueryContext(ctx, queryCredits, film.Id)
+	if err != nil {
+		return fmt.Errorf("failed to get film credits: %w", err)
+	}
+	defer rows.Close()
+
+	var filmCredits []*SqliteFilmCredit
+	var filmActors []*SqliteActor
+
+	for rows.Next() {
+		var actor SqliteActor
+		var credit SqliteFilmCredit
+
+		if err = rows.Scan(
+			&actor.Id,
+			&actor.Name,
+			&actor.Sex,
+			&actor.BirthDate,
+			&credit.Role,
+			&credit.CharacterName,
+			&credit.BillingOrder,
+		); err != nil {
+			return fmt.Errorf("failed to get film credits: %w", err)
+		}
+
+		credit.Actor = &actor
+		filmCredits = append(filmCredits, &credit)
+
+		if domain.CreditRole(credit.Role) == domain.CreditRoleActor {
+			filmActors = append(filmActors, &actor)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("failed to get film credits: %w", err)
+	}
+
+	film.Credits = filmCredits
+	film.Actors = filmActors
+
+	return nil
+}
+
+func (s *SqliteFilmStorage) createFilmGenres(ctx context.Context, tx *sql.Tx, filmId int64, genreIds []domain.GenreId) error {
+	queryInsertFilmsGenres := `
+						INSERT INTO films_genres(film_id, genre_id)
+						VALUES ($1, $2)
+						ON CONFLICT DO NOTHING
+`
+	for _, genreId := range genreIds {
+		_, err := tx.ExecContext(ctx, queryInsertFilmsGenres, filmId, genreId)
+		if err != nil {
+			return fmt.Errorf("failed to insert values to 'films_genres' table %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *SqliteFilmStorage) deleteFilmGenres(ctx context.Context, tx *sql.Tx, filmId int64) error {
+	queryDeleteFilmsGenres := `
+						DELETE FROM films_genres WHERE film_id=$1
+`
+	_, err := tx.ExecContext(ctx, queryDeleteFilmsGenres, filmId)
+	if err != nil {
+		return fmt.Errorf("failed to delete values from 'films_genres' table %w", err)
+	}
+	return nil
+}
+
+// buildFilmFilterCondition turns the filter into a parameterized 'WHERE' clause over films aliased as 'f'.
+// Deleted films are never matched, and credits of deleted actors are ignored.
+// The match expression of the search query, if any, is always the first argument, so that the relevance expression can refer to it.
+func buildFilmFilterCondition(filter *domain.FilmFilter) (string, []any) {
+	conditions := []string{"f.deleted_at IS NULL"}
+	var args []any
+
+	if filter == nil {
+		return " WHERE " + strings.Join(conditions, " AND "), args
+	}
+
+	addCondition := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Query != "" {
+		// Besides full-text matches, films with a title or a credit name similar to the query are kept, so that queries with typos still find films
+		args = append(args, buildFilmMatchExpression(filter.Query), filter.Query.String())
+		conditions = append(conditions, fmt.Sprintf("(f.id IN (SELECT rowid FROM films_search WHERE films_search MATCH $%[1]d) OR word_similarity($%[2]d, f.title) >= %[3]s OR EXISTS (SELECT 1 FROM films_actors AS fq INNER JOIN actors AS q ON q.id = fq.actor_id WHERE fq.film_id = f.id AND q.deleted_at IS NULL AND word_similarity($%[2]d, q.name) >= %[3]s))", len(args)-1, len(args), wordSimilarityThreshold))
+	}
+	if filter.Title != "" {
+		addCondition("unicode_lower(f.title) LIKE '%%' || unicode_lower($%d) || '%%'", filter.Title.String())
+	}
+	if filter.ActorName != "" {
+		addCondition("EXISTS (SELECT 1 FROM films_actors AS fa INNER JOIN actors AS a ON a.id = fa.actor_id WHERE fa.film_id = f.id AND fa.role = 'actor' AND a.deleted_at IS NULL AND unicode_lower(a.name) LIKE '%%' || unicode_lower($%d) || '%%')", filter.ActorName.String())
+	}
+	if filter.DirectorName != "" {
+		addCondition("EXISTS (SELECT 1 FROM films_actors AS fd INNER JOIN actors AS d ON d.id = fd.actor_id WHERE fd.film_id = f.id AND fd.role = 'director' AND d.deleted_at IS NULL AND unicode_lower(d.name) LIKE '%%' || unicode_lower($%d) || '%%')", filter.DirectorName.String())
+	}
+	if filter.GenreId != nil {
+		addCondition("EXISTS (SELECT 1 FROM films_genres AS fg WHERE fg.film_id = f.id AND fg.genre_id = $%d)", filter.GenreId.Int64())
+	}
+	if filter.ReleasedFrom != nil {
+		addCondition("f.release_date >= $%d", filter.ReleasedFrom.Time().UTC())
+	}
+	if filter.ReleasedTo != nil {
+		addCondition("f.release_date <= $%d", filter.ReleasedTo.Time().UTC())
+	}
+	if filter.MinRating != nil {
+		addCondition("f.rating >= $%d", filter.MinRating.Uint8())
+	}
+	if filter.MaxRating != nil {
+		addCondition("f.rating <= $%d", filter.MaxRating.Uint8())
+	}
+	if len(filter.ActorIds) > 0 {
+		actorIds := distinctActorIds(filter.ActorIds)
+		if filter.ActorsMatch == domain.FilmActorsMatchAll {
+			addCondition("(SELECT COUNT(DISTINCT fa.actor_id) FROM films_actors AS fa INNER JOIN actors AS a ON a.id = fa.actor_id WHERE fa.film_id = f.id AND fa.role = 'actor' AND a.deleted_at IS NULL AND fa.actor_id IN (SELECT value FROM json_each($%d))) = "+strconv.Itoa(len(actorIds)), jsonIdArray(actorIds))
+		} else {
+			addCondition("EXISTS (SELECT 1 FROM films_actors AS fa INNER JOIN actors AS a ON a.id = fa.actor_id WHERE fa.film_id = f.id AND fa.role = 'actor' AND a.deleted_at IS NULL AND fa.actor_id IN (SELECT value FROM json_each($%d)))", jsonIdArray(actorIds))
+		}
+	}
+	if filter.ActorSex != nil {
+		addCondition("EXISTS (SELECT 1 FROM films_actors AS fa INNER JOIN actors AS a ON a.id = fa.actor_id WHERE fa.film_id = f.id AND fa.role = 'actor' AND a.deleted_at IS NULL AND a.sex = $%d)", filter.ActorSex.Uint8())
+	}
+	if filter.WithoutActors {
+		conditions = append(conditions, "NOT EXISTS (SELECT 1 FROM films_actors AS fa INNER JOIN actors AS a ON a.id = fa.actor_id WHERE fa.film_id = f.id AND fa.role = 'actor' AND a.deleted_at IS NULL)")
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// filmSearchRank is the rank of a film in the search index, where title words weigh more than names and description words.
+// The 'bm25' function gives better matches lower values, so it is negated to make the more relevant films rank higher
+const filmSearchRank = "-bm25(films_search, 1.0, 0.4, 0.2)"
+
+// wordSimilarityThreshold is the least similarity of a text to the search query for the text to match it,
+// the default threshold of the '<%' operator of the Postgres pg_trgm extension
+const wordSimilarityThreshold = "0.6"
+
+// buildFilmRelevanceExpression ranks films by the match expression and the search query passed as the given parameters:
+// the rank in the search index plus the similarity of the title to the query
+func buildFilmRelevanceExpression(matchParamNumber, queryParamNumber int) string {
+	return fmt.Sprintf(
+		"(COALESCE((SELECT "+filmSearchRank+" FROM films_search WHERE films_search MATCH $%d AND films_search.rowid = f.id), 0) + word_similarity($%d, f.title))",
+		matchParamNumber, queryParamNumber)
+}
+
+// buildFilmMatchExpression turns the search query into a match expression of the search index, which finds films
+// having every word of the query or a word starting with it, e.g. 'matrix reload' into '"matrix"* AND "reload"*'.
+// A query without words matches no films
+func buildFilmMatchExpression(searchQuery domain.FilmSearchQuery) string {
+	words := strings.FieldsFunc(searchQuery.String(), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	if len(words) == 0 {
+		return `""`
+	}
+
+	terms := make([]string, len(words))
+	for i, word := range words {
+		terms[i] = `"` + word + `"*`
+	}
+	return strings.Join(terms, " AND ")
+}
+
+func distinctActorIds(actorIds []domain.ActorId) []int64 {
+	seen := make(map[domain.ActorId]bool, len(actorIds))
+	distinctIds := make([]int64, 0, len(actorIds))
+	for _, actorId := range actorIds {
+		if !seen[actorId] {
+			seen[actorId] = true
+			distinctIds = append(distinctIds, actorId.Int64())
+		}
+	}
+	return distinctIds
+}
+
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// attachFilmsGenres loads genres of all given films with a single query
+func attachFilmsGenres(ctx context.Context, q queryer, films []*SqliteFilm) error {
+	if len(films) == 0 {
+		return nil
+	}
+
+	filmIds := make([]int64, len(films))
+	for i, film := range films {
+		filmIds[i] = film.Id
+	}
+
+	query := `
+		SELECT fg.film_id,
+		       g.id,
+		       g.name
+		FROM genres AS g
+		INNER JOIN films_genres AS fg ON g.id = fg.genre_id
+		WHERE fg.film_id IN (SELECT value FROM json_each($1))
+		ORDER BY g.name
+`
+
+	rows, err := q.QueryContext(ctx, query, jsonIdArray(filmIds))
+	if err != nil {
+		return fmt.Errorf("failed to get films genres: %w", err)
+	}
+	defer rows.Close()
+
+	filmsGenres := make(map[int64][]*SqliteGenre)
+
+	for rows.Next() {
+		var filmId int64
+		var genre SqliteGenre
+
+		if err = rows.Scan(&filmId, &genre.Id, &genre.Name); err != nil {
+			return fmt.Errorf("failed to get films genres: %w", err)
+		}
+
+		filmsGenres[filmId] = append(filmsGenres[filmId], &genre)
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("failed to get films genres: %w", err)
+	}
+
+	for _, film := range films {
+		film.Genres = filmsGenres[film.Id]
+	}
+
+	return nil
+}
+
+// attachFilmsMatches sets relevance of all given films to the search query and highlights the matched words of their titles and descriptions
+func attachFilmsMatches(ctx context.Context, q queryer, searchQuery domain.FilmSearchQuery, films []*SqliteFilm) error {
+	if len(films) == 0 {
+		return nil
+	}
+
+	filmIds := make([]int64, len(films))
+	for i, film := range films {
+		filmIds[i] = film.Id
+	}
+
+	// Films found only by the similarity of their titles or names have no matched words to highlight
+	query := `
+		SELECT f.id,
+		       ` + buildFilmRelevanceExpression(1, 2) + `,
+		       COALESCE((SELECT highlight(films_search, 0, '<b>', '</b>') FROM films_search WHERE films_search MATCH $1 AND films_search.rowid = f.id), f.title),
+		       COALESCE((SELECT snippet(films_search, 2, '<b>', '</b>', ' ... ', 35) FROM films_search WHERE films_search MATCH $1 AND films_search.rowid = f.id), f.description, '')
+		FROM films AS f
+		WHERE f.id IN (SELECT value FROM json_each($3))
+`
+
+	rows, err := q.QueryContext(ctx, query, buildFilmMatchExpression(searchQuery), searchQuery.String(), jsonIdArray(filmIds))
+	if err != nil {
+		return fmt.Errorf("failed to get films matches: %w", err)
+	}
+	defer rows.Close()
+
+	filmsMatches := make(map[int64]*SqliteFilmMatch)
+
+	for rows.Next() {
+		var filmId int64
+		var match SqliteFilmMatch
+
+		if err = rows.Scan(&filmId, &match.Relevance, &match.TitleHighlight, &match.DescriptionHighlight); err != nil {
+			return fmt.Errorf("failed to get films matches: %w", err)
+		}
+
+		filmsMatches[filmId] = &match
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("failed to get films matches: %w", err)
+	}
+
+	for _, film := range films {
+		film.Match = filmsMatches[film.Id]
+	}
+
+	return nil
+}
+
+// filmSortColumns is the whitelist of columns films can be sorted by.
+// Only these names and the fixed directions below ever get into the 'ORDER BY' clause.
+var filmSortColumns = map[domain.FilmSortField]string{
+	domain.FilmSortFieldId:             "f.id",
+	domain.FilmSortFieldTitle:          "f.title",
+	domain.FilmSortFieldReleaseDate:    "f.release_date",
+	domain.FilmSortFieldRating:         "f.rating",
+	domain.FilmSortFieldActorsCount:    "f.actors_count",
+	domain.FilmSortFieldCommunityScore: "f.community_score",
+}
+
+var sortDirections = map[domain.SortDirection]string{
+	domain.SortDirectionAsc:  "ASC",
+	domain.SortDirectionDesc: "DESC",
+}
+
+// buildFilmOrderColumns turns the sort into order columns. An empty sort means the most relevant films first,
+// if films are searched by a query, and the films with the highest rating first otherwise.
+func buildFilmOrderColumns(sort domain.FilmSort, byRelevance bool) ([]orderColumn, error) {
+	var orderColumns []orderColumn
+	var hasIdColumn bool
+
+	for _, key := range sort {
+		columnName, ok := filmSortColumns[key.Field]
+		if !ok {
+			return nil, fmt.Errorf("%w: unexpected sort field '%s'", domain.ErrInvalidFilmSort, key.Field)
+		}
+		order, ok := sortDirections[key.Direction]
+		if !ok {
+			return nil, fmt.Errorf("%w: unexpected sort direction '%s'", domain.ErrInvalidFilmSort, key.Direction)
+		}
+		orderColumns = append(orderColumns, orderColumn{name: columnName, order: order})
+		hasIdColumn = hasIdColumn || key.Field == domain.FilmSortFieldId
+	}
+
+	if len(orderColumns) == 0 {
+		if byRelevance {
+			orderColumns = append(orderColumns, orderColumn{name: "f.relevance", order: "DESC"})
+		} else {
+			orderColumns = append(orderColumns, orderColumn{name: "f.rating", order: "DESC"})
+		}
+	}
+
+	// Film id makes the order total, so that keyset pagination never skips or repeats films
+	if !hasIdColumn {
+		orderColumns = append(orderColumns, orderColumn{name: "f.id", order: "ASC"})
+	}
+
+	return orderColumns, nil
+}
+
+func queryFilmsWithActors(ctx context.Context, q queryer, query string, args ...any) ([]*SqliteFilm, error) {
+	var films []*SqliteFilm
+
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var film SqliteFilm
+		var actor SqliteNullableActor
+
+		if err = rows.Scan(
+			&film.Id,
+			&film.Title,
+			&film.Description,
+			&film.ReleaseDate,
+			&film.Rating,
+			&film.CommunityScore,
+			&film.VotesCount,
+			&film.CreatedBy,
+			&film.UpdatedBy,
+			&film.Version,
+			&actor.Id,
+			&actor.Name,
+			&actor.Sex,
+			&actor.BirthDate,
+		); err != nil {
+			return nil, err
+		}
+
+		// Rows of the same film follow each other, because films are ordered by a unique key
+		if len(films) == 0 || films[len(films)-1].Id != film.Id {
+			films = append(films, &film)
+		}
+
+		// A film without actors is joined with a single row of NULL actor columns
+		if actor.Id.Valid {
+			lastFilm := films[len(films)-1]
+			lastFilm.Actors = append(lastFilm.Actors, actor.SqliteActor())
+		}
+	}
+
+	return films, rows.Err()
+}
+
+func buildFilmCursorValues(orderColumns []orderColumn, cursor *domain.FilmCursor) []any {
+	values := make([]any, len(orderColumns))
+	for i, column := range orderColumns {
+		switch column.name {
+		case "f.title":
+			values[i] = cursor.Title.String()
+		case "f.release_date":
+			values[i] = cursor.ReleaseDate.Time().UTC()
+		case "f.rating":
+			values[i] = cursor.Rating.Uint8()
+		case "f.actors_count":
+			values[i] = cursor.ActorsCount
+		case "f.community_score":
+			values[i] = cursor.CommunityScore.Float64()
+		case "f.relevance":
+			values[i] = cursor.Relevance
+		case "f.id":
+			values[i] = cursor.Id.Int64()
+		}
+	}
+	return values
+}
+
+// buildFilmsPage expects up to limit+1 films and cuts the extra one off.
+// The extra film means that there is a next page, which starts after the last returned film.
+func buildFilmsPage(sqliteFilms []*SqliteFilm, limit int) ([]*domain.Film, *domain.FilmCursor) {
+	if len(sqliteFilms) <= limit {
+		return buildDomainFilms(sqliteFilms), nil
+	}
+
+	domainFilms := buildDomainFilms(sqliteFilms[:limit])
+	if len(domainFilms) == 0 {
+		return domainFilms, nil
+	}
+
+	return domainFilms, domain.NewFilmCursor(domainFilms[len(domainFilms)-1])
+}
+
+func buildDomainFilms(sqliteFilms []*SqliteFilm) []*domain.Film {
+	domainFilms := make([]*domain.Film, len(sqliteFilms))
+	for i := range sqliteFilms {
+		domainFilms[i] = buildDomainFilm(sqliteFilms[i])
+	}
+	return domainFilms
+}
+
+func buildDomainFilm(sqliteFilm *SqliteFilm) *domain.Film {
+	return &domain.Film{
+		Id:             domain.FilmId(sqliteFilm.Id),
+		Title:          domain.FilmTitle(sqliteFilm.Title),
+		Description:    domain.FilmDescription(sqliteFilm.Description.String),
+		ReleaseDate:    domain.FilmReleaseDate(sqliteFilm.ReleaseDate),
+		Rating:         domain.FilmRating(sqliteFilm.Rating),
+		CommunityScore: domain.CommunityScore(sqliteFilm.CommunityScore),
+		VotesCount:     sqliteFilm.VotesCount,
+		Actors:         buildDomainActors(sqliteFilm.Actors),
+		Credits:        buildDomainFilmCredits(sqliteFilm.Credits),
+		Genres:         buildDomainGenres(sqliteFilm.Genres),
+		CreatedBy:      nullInt64Ptr(sqliteFilm.CreatedBy),
+		UpdatedBy:      nullInt64Ptr(sqliteFilm.UpdatedBy),
+		Version:        sqliteFilm.Version,
+		Match:          buildDomainFilmMatch(sqliteFilm.Match),
+	}
+}
+
+func buildDomainFilmMatch(sqliteMatch *SqliteFilmMatch) *domain.FilmMatch {
+	if sqliteMatch == nil {
+		return nil
+	}
+	return &domain.FilmMatch{
+		Relevance:            sqliteMatch.Relevance,
+		TitleHighlight:       sqliteMatch.TitleHighlight,
+		DescriptionHighlight: sqliteMatch.DescriptionHighlight,
+	}
+}
+
+func buildDomainFilmCredits(sqliteCredits []*SqliteFilmCredit) []*domain.FilmCredit {
+	if sqliteCredits == nil {
+		return nil
+	}
+	domainCredits := make([]*domain.FilmCredit, len(sqliteCredits))
+	for i, credit := range sqliteCredits {
+		domainCredits[i] = &domain.FilmCredit{
+			Actor:         buildDomainActor(credit.Actor),
+			Role:          domain.CreditRole(credit.Role),
+			CharacterName: domain.CharacterName(credit.CharacterName.String),
+			BillingOrder:  credit.BillingOrder,
+		}
+	}
+	return domainCredits
+}
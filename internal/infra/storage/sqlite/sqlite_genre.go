@@ -0,0 +1,6 @@
+package sqlite
+
+type SqliteGenre struct {
+	Id   int64
+	Name string
+}
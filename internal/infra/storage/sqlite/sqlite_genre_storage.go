@@ -0,0 +1,161 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	sqlitedriver "modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+const uniqueViolationErrCode = sqlite3.SQLITE_CONSTRAINT_UNIQUE
+
+type SqliteGenreStorage struct {
+	db *sqlx.DB
+}
+
+func NewSqliteGenreStorage(db *sqlx.DB) *SqliteGenreStorage {
+	return &SqliteGenreStorage{db: db}
+}
+
+func (s *SqliteGenreStorage) Create(ctx context.Context, name domain.GenreName) (*domain.Genre, error) {
+	var genre SqliteGenre
+	query := `
+			INSERT INTO genres (name) VALUES ($1)
+			RETURNING
+				id,
+				name
+`
+	row := s.db.QueryRowContext(ctx, query, name)
+	if err := row.Scan(&genre.Id, &genre.Name); err != nil {
+		var sqliteErr *sqlitedriver.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.Code() == uniqueViolationErrCode {
+			return nil, fmt.Errorf("failed to create a genre: %w", storage.ErrGenreAlreadyExists)
+		}
+		return nil, fmt.Errorf("failed to create a genre: %w", err)
+	}
+	return buildDomainGenre(&genre), nil
+}
+
+func (s *SqliteGenreStorage) Update(ctx context.Context, id domain.GenreId, name domain.GenreName) (*domain.Genre, error) {
+	var genre SqliteGenre
+	query := `
+			UPDATE genres
+			SET name=$1
+			WHERE id=$2
+			RETURNING
+				id,
+				name
+`
+	row := s.db.QueryRowContext(ctx, query, name, id)
+	if err := row.Scan(&genre.Id, &genre.Name); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to update a genre: %w", storage.ErrGenreNotFound)
+		}
+		var sqliteErr *sqlitedriver.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.Code() == uniqueViolationErrCode {
+			return nil, fmt.Errorf("failed to update a genre: %w", storage.ErrGenreAlreadyExists)
+		}
+		return nil, fmt.Errorf("failed to update a genre: %w", err)
+	}
+	return buildDomainGenre(&genre), nil
+}
+
+func (s *SqliteGenreStorage) Delete(ctx context.Context, id domain.GenreId) error {
+	query := `DELETE FROM genres WHERE id=$1`
+	result, err := s.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete genre: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return fmt.Errorf("failed to delete genre: %w", storage.ErrGenreNotFound)
+	}
+	return nil
+}
+
+func (s *SqliteGenreStorage) GetById(ctx context.Context, id domain.GenreId) (*domain.Genre, error) {
+	var genre SqliteGenre
+	query := `
+			SELECT id,
+			       name
+			FROM genres
+			WHERE id=$1
+`
+	row := s.db.QueryRowContext(ctx, query, id)
+	if err := row.Scan(&genre.Id, &genre.Name); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to get genre: %w", storage.ErrGenreNotFound)
+		}
+		return nil, fmt.Errorf("failed to get genre: %w", err)
+	}
+	return buildDomainGenre(&genre), nil
+}
+
+func (s *SqliteGenreStorage) List(ctx context.Context) ([]*domain.Genre, error) {
+	query := `
+			SELECT id,
+			       name
+			FROM genres
+			ORDER BY name
+`
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list genres: %w", err)
+	}
+	defer rows.Close()
+
+	var genres []*SqliteGenre
+
+	for rows.Next() {
+		var genre SqliteGenre
+		if err = rows.Scan(&genre.Id, &genre.Name); err != nil {
+			return nil, fmt.Errorf("failed to list genres: %w", err)
+		}
+		genres = append(genres, &genre)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list genres: %w", err)
+	}
+
+	return buildDomainGenres(genres), nil
+}
+
+func (s *SqliteGenreStorage) AreExists(ctx context.Context, ids []domain.GenreId) (bool, error) {
+	query := `
+			SELECT COUNT(*) FROM genres 
+			WHERE id IN (SELECT value FROM json_each($1))
+`
+
+	var idsCount int64
+
+	err := s.db.QueryRowContext(ctx, query, jsonIdArray(ids)).Scan(&idsCount)
+	if err != nil {
+		return false, fmt.Errorf("failed to check whether genres exists or not: %w", err)
+	}
+
+	if int(idsCount) != len(ids) {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func buildDomainGenres(sqliteGenres []*SqliteGenre) []*domain.Genre {
+	domainGenres := make([]*domain.Genre, len(sqliteGenres))
+	for i := range sqliteGenres {
+		domainGenres[i] = buildDomainGenre(sqliteGenres[i])
+	}
+	return domainGenres
+}
+
+func buildDomainGenre(sqliteGenre *SqliteGenre) *domain.Genre {
+	return &domain.Genre{
+		Id:   domain.GenreId(sqliteGenre.Id),
+		Name: domain.GenreName(sqliteGenre.Name),
+	}
+}
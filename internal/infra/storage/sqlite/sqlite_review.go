@@ -0,0 +1,16 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+)
+
+type SqliteReview struct {
+	Id        int64
+	FilmId    int64
+	UserId    int64
+	Rating    uint8
+	Text      sql.NullString
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
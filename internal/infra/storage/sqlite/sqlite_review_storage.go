@@ -0,0 +1,316 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	sqlitedriver "modernc.org/sqlite"
+	"time"
+)
+
+type SqliteReviewStorage struct {
+	db *sqlx.DB
+}
+
+func NewSqliteReviewStorage(db *sqlx.DB) *SqliteReviewStorage {
+	return &SqliteReviewStorage{db: db}
+}
+
+func (s *SqliteReviewStorage) Create(ctx context.Context, filmId domain.FilmId, userId int64, rating domain.ReviewRating, text domain.ReviewText) (*domain.Review, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction while creating review: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err = s.checkFilm(ctx, tx, int64(filmId)); err != nil {
+		return nil, fmt.Errorf("failed to create a review: %w", err)
+	}
+
+	var review SqliteReview
+
+	query := `
+			INSERT INTO reviews (
+			                    film_id,
+			                    user_id,
+			                    rating,
+			                    text
+				) VALUES ($1, $2, $3, NULLIF($4, ''))
+				RETURNING
+					id,
+					film_id,
+					user_id,
+					rating,
+					text,
+					created_at,
+					updated_at
+`
+
+	row := tx.QueryRowContext(ctx, query, filmId, userId, rating, text)
+	if err = scanReview(row, &review); err != nil {
+		var sqliteErr *sqlitedriver.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.Code() == uniqueViolationErrCode {
+			return nil, fmt.Errorf("failed to create a review: %w", storage.ErrReviewAlreadyExists)
+		}
+		return nil, fmt.Errorf("failed to create a review: %w", err)
+	}
+
+	if err = s.updateFilmScore(ctx, tx, review.FilmId); err != nil {
+		return nil, fmt.Errorf("failed to create a review: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction while creating review: %w", err)
+	}
+
+	return buildDomainReview(&review), nil
+}
+
+// Update changes the given fields of a review, an empty text removes the text of the review
+func (s *SqliteReviewStorage) Update(ctx context.Context, id domain.ReviewId, rating *domain.ReviewRating, text *domain.ReviewText) (*domain.Review, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction while updating review: %w", err)
+	}
+	defer tx.Rollback()
+
+	filmId, err := s.getReviewFilmId(ctx, tx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update a review: %w", err)
+	}
+
+	if err = s.checkFilm(ctx, tx, filmId); err != nil {
+		return nil, fmt.Errorf("failed to update a review: %w", err)
+	}
+
+	var review SqliteReview
+
+	query := `
+			UPDATE reviews
+						SET rating=COALESCE($1, rating),
+							text=CASE WHEN $2 IS NULL THEN text ELSE NULLIF($2, '') END,
+							updated_at=$4
+						WHERE id=$3
+			RETURNING
+				id,
+				film_id,
+				user_id,
+				rating,
+				text,
+				created_at,
+				updated_at
+`
+
+	row := tx.QueryRowContext(ctx, query, rating, text, id, time.Now().UTC())
+	if err = scanReview(row, &review); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to update a review: %w", storage.ErrReviewNotFound)
+		}
+		return nil, fmt.Errorf("failed to update a review: %w", err)
+	}
+
+	if err = s.updateFilmScore(ctx, tx, review.FilmId); err != nil {
+		return nil, fmt.Errorf("failed to update a review: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction while updating review: %w", err)
+	}
+
+	return buildDomainReview(&review), nil
+}
+
+func (s *SqliteReviewStorage) Delete(ctx context.Context, id domain.ReviewId) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction while deleting review: %w", err)
+	}
+	defer tx.Rollback()
+
+	filmId, err := s.getReviewFilmId(ctx, tx, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete review: %w", err)
+	}
+
+	if err = s.checkFilm(ctx, tx, filmId); err != nil {
+		return fmt.Errorf("failed to delete review: %w", err)
+	}
+
+	query := `DELETE FROM reviews WHERE id=$1`
+	result, err := tx.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete review: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return fmt.Errorf("failed to delete review: %w", storage.ErrReviewNotFound)
+	}
+
+	if err = s.updateFilmScore(ctx, tx, filmId); err != nil {
+		return fmt.Errorf("failed to delete review: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction while deleting review: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SqliteReviewStorage) GetById(ctx context.Context, id domain.ReviewId) (*domain.Review, error) {
+	var review SqliteReview
+	query := `
+			SELECT id,
+			       film_id,
+			       user_id,
+			       rating,
+			       text,
+			       created_at,
+			       updated_at
+			FROM reviews
+			WHERE id=$1
+`
+	row := s.db.QueryRowContext(ctx, query, id)
+	if err := scanReview(row, &review); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to get review: %w", storage.ErrReviewNotFound)
+		}
+		return nil, fmt.Errorf("failed to get review: %w", err)
+	}
+	return buildDomainReview(&review), nil
+}
+
+// ListByFilmId lists reviews of a film starting with the latest ones
+func (s *SqliteReviewStorage) ListByFilmId(ctx context.Context, filmId domain.FilmId, limit, offset int) ([]*domain.Review, error) {
+	query := `
+			SELECT id,
+			       film_id,
+			       user_id,
+			       rating,
+			       text,
+			       created_at,
+			       updated_at
+			FROM reviews
+			WHERE film_id=$1
+			ORDER BY created_at DESC, id DESC
+			LIMIT $2 OFFSET $3
+`
+	rows, err := s.db.QueryContext(ctx, query, filmId, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviews: %w", err)
+	}
+	defer rows.Close()
+
+	var reviews []*SqliteReview
+
+	for rows.Next() {
+		var review SqliteReview
+		if err = scanReview(rows, &review); err != nil {
+			return nil, fmt.Errorf("failed to list reviews: %w", err)
+		}
+		reviews = append(reviews, &review)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list reviews: %w", err)
+	}
+
+	return buildDomainReviews(reviews), nil
+}
+
+func (s *SqliteReviewStorage) CountByFilmId(ctx context.Context, filmId domain.FilmId) (int64, error) {
+	query := `
+			SELECT COUNT(*) FROM reviews
+			WHERE film_id=$1
+`
+	var count int64
+	if err := s.db.QueryRowContext(ctx, query, filmId).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count reviews: %w", err)
+	}
+	return count, nil
+}
+
+// checkFilm checks that the film exists. Concurrent changes of its reviews never lose each other in the film score,
+// because write transactions hold the write lock of the database from their start
+func (s *SqliteReviewStorage) checkFilm(ctx context.Context, tx *sql.Tx, filmId int64) error {
+	query := `
+			SELECT id FROM films
+			WHERE id=$1 AND deleted_at IS NULL
+`
+	var checkedFilmId int64
+	if err := tx.QueryRowContext(ctx, query, filmId).Scan(&checkedFilmId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.ErrFilmNotFound
+		}
+		return fmt.Errorf("failed to check film: %w", err)
+	}
+	return nil
+}
+
+func (s *SqliteReviewStorage) getReviewFilmId(ctx context.Context, tx *sql.Tx, id domain.ReviewId) (int64, error) {
+	query := `
+			SELECT film_id FROM reviews
+			WHERE id=$1
+`
+	var filmId int64
+	if err := tx.QueryRowContext(ctx, query, id).Scan(&filmId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, storage.ErrReviewNotFound
+		}
+		return 0, err
+	}
+	return filmId, nil
+}
+
+// updateFilmScore recalculates the community score and the votes count of the film from its reviews
+func (s *SqliteReviewStorage) updateFilmScore(ctx context.Context, tx *sql.Tx, filmId int64) error {
+	query := `
+			UPDATE films
+			SET community_score=COALESCE((SELECT ROUND(AVG(r.rating), 2) FROM reviews AS r WHERE r.film_id = $1), 0),
+			    votes_count=(SELECT COUNT(*) FROM reviews AS r WHERE r.film_id = $1)
+			WHERE id=$1
+`
+	if _, err := tx.ExecContext(ctx, query, filmId); err != nil {
+		return fmt.Errorf("failed to update film score: %w", err)
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanReview(row rowScanner, review *SqliteReview) error {
+	return row.Scan(
+		&review.Id,
+		&review.FilmId,
+		&review.UserId,
+		&review.Rating,
+		&review.Text,
+		&review.CreatedAt,
+		&review.UpdatedAt,
+	)
+}
+
+func buildDomainReviews(sqliteReviews []*SqliteReview) []*domain.Review {
+	domainReviews := make([]*domain.Review, len(sqliteReviews))
+	for i := range sqliteReviews {
+		domainReviews[i] = buildDomainReview(sqliteReviews[i])
+	}
+	return domainReviews
+}
+
+func buildDomainReview(sqliteReview *SqliteReview) *domain.Review {
+	return &domain.Review{
+		Id:        domain.ReviewId(sqliteReview.Id),
+		FilmId:    domain.FilmId(sqliteReview.FilmId),
+		UserId:    sqliteReview.UserId,
+		Rating:    domain.ReviewRating(sqliteReview.Rating),
+		Text:      domain.ReviewText(sqliteReview.Text.String),
+		CreatedAt: sqliteReview.CreatedAt,
+		UpdatedAt: sqliteReview.UpdatedAt,
+	}
+}
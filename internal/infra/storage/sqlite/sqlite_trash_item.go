@@ -0,0 +1,14 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+)
+
+type SqliteTrashItem struct {
+	EntityType string
+	EntityId   int64
+	Name       string
+	DeletedAt  time.Time
+	DeletedBy  sql.NullInt64
+}
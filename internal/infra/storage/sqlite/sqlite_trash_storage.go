@@ -0,0 +1,132 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"time"
+)
+
+// trashItemsQuery lists deleted films and actors as trash items of both kinds
+const trashItemsQuery = `
+			SELECT 'film' AS entity_type, id AS entity_id, title AS name, deleted_at, deleted_by
+			FROM films
+			WHERE deleted_at IS NOT NULL
+			UNION ALL
+			SELECT 'actor' AS entity_type, id AS entity_id, name, deleted_at, deleted_by
+			FROM actors
+			WHERE deleted_at IS NOT NULL
+`
+
+type SqliteTrashStorage struct {
+	db *sqlx.DB
+}
+
+func NewSqliteTrashStorage(db *sqlx.DB) *SqliteTrashStorage {
+	return &SqliteTrashStorage{db: db}
+}
+
+func (s *SqliteTrashStorage) List(ctx context.Context, filter *domain.TrashFilter, limit, offset int) ([]*domain.TrashItem, error) {
+	whereParam, args := buildTrashFilterCondition(filter)
+	limitOffsetParams := fmt.Sprintf(" LIMIT %d OFFSET %d ", limit, offset)
+
+	query := `
+			SELECT entity_type,
+			       entity_id,
+			       name,
+			       deleted_at,
+			       deleted_by
+			FROM (` + trashItemsQuery + `) AS t` + whereParam + `
+			ORDER BY deleted_at DESC, entity_type, entity_id DESC` + limitOffsetParams
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list trash: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*domain.TrashItem
+
+	for rows.Next() {
+		var item SqliteTrashItem
+
+		if err = rows.Scan(
+			&item.EntityType,
+			&item.EntityId,
+			&item.Name,
+			&item.DeletedAt,
+			&item.DeletedBy,
+		); err != nil {
+			return nil, fmt.Errorf("failed to list trash: %w", err)
+		}
+
+		items = append(items, buildDomainTrashItem(&item))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list trash: %w", err)
+	}
+
+	return items, nil
+}
+
+func (s *SqliteTrashStorage) Count(ctx context.Context, filter *domain.TrashFilter) (int64, error) {
+	whereParam, args := buildTrashFilterCondition(filter)
+
+	query := `SELECT COUNT(*) FROM (` + trashItemsQuery + `) AS t` + whereParam
+
+	var count int64
+
+	err := s.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count trash: %w", err)
+	}
+
+	return count, nil
+}
+
+// Purge deletes the films and the actors in a single transaction, their credits, genres, reviews and list entries are deleted by cascade
+func (s *SqliteTrashStorage) Purge(ctx context.Context, deletedBefore time.Time) (int64, int64, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to start transaction while purging trash: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM films WHERE deleted_at < $1`, deletedBefore.UTC())
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to purge films: %w", err)
+	}
+	purgedFilms, _ := result.RowsAffected()
+
+	result, err = tx.ExecContext(ctx, `DELETE FROM actors WHERE deleted_at < $1`, deletedBefore.UTC())
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to purge actors: %w", err)
+	}
+	purgedActors, _ := result.RowsAffected()
+
+	if err = tx.Commit(); err != nil {
+		return 0, 0, fmt.Errorf("failed to commit transaction while purging trash: %w", err)
+	}
+
+	return purgedFilms, purgedActors, nil
+}
+
+func buildTrashFilterCondition(filter *domain.TrashFilter) (string, []any) {
+	if filter == nil || filter.EntityType == nil {
+		return "", nil
+	}
+
+	return " WHERE entity_type = $1", []any{filter.EntityType.String()}
+}
+
+func buildDomainTrashItem(sqliteItem *SqliteTrashItem) *domain.TrashItem {
+	return &domain.TrashItem{
+		EntityType: domain.TrashEntityType(sqliteItem.EntityType),
+		EntityId:   sqliteItem.EntityId,
+		Name:       sqliteItem.Name,
+		DeletedAt:  sqliteItem.DeletedAt,
+		DeletedBy:  nullInt64Ptr(sqliteItem.DeletedBy),
+	}
+}
@@ -0,0 +1,52 @@
+package sqliteauth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"time"
+)
+
+type SqliteRefreshTokenStorage struct {
+	db *sqlx.DB
+}
+
+func NewSqliteRefreshTokenStorage(db *sqlx.DB) *SqliteRefreshTokenStorage {
+	return &SqliteRefreshTokenStorage{db: db}
+}
+
+func (s *SqliteRefreshTokenStorage) Create(ctx context.Context, userId int64, tokenHash string, expiresAt time.Time) error {
+	query := `
+			INSERT INTO refresh_tokens (
+			                    user_id,
+			                    token_hash,
+			                    expires_at
+				) VALUES ($1, $2, $3)
+`
+	if _, err := s.db.ExecContext(ctx, query, userId, tokenHash, expiresAt.UTC()); err != nil {
+		return fmt.Errorf("failed to create a refresh token: %w", err)
+	}
+	return nil
+}
+
+func (s *SqliteRefreshTokenStorage) Revoke(ctx context.Context, tokenHash string) (int64, error) {
+	// A single statement guarantees that concurrent requests cannot revoke the same token twice
+	query := `
+			UPDATE refresh_tokens
+			SET revoked_at = $2
+			WHERE token_hash = $1 AND revoked_at IS NULL AND expires_at > $2
+			RETURNING user_id
+`
+	var userId int64
+	row := s.db.QueryRowContext(ctx, query, tokenHash, time.Now().UTC())
+	if err := row.Scan(&userId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("failed to revoke a refresh token: %w", storage.ErrRefreshTokenNotFound)
+		}
+		return 0, fmt.Errorf("failed to revoke a refresh token: %w", err)
+	}
+	return userId, nil
+}
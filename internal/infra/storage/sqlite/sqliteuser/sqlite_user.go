@@ -0,0 +1,9 @@
+package sqliteuser
+
+type SqliteUser struct {
+	Id       int64
+	Email    string
+	Password string
+	Role     string
+	Disabled bool
+}
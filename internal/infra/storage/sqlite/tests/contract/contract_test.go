@@ -0,0 +1,31 @@
+package sqlite_test
+
+import (
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/sqlite"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/sqlite/sqliteauth"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/sqlite/sqliteuser"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/sqlite/tests/sqlitetest"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/tests/storagetest"
+	"testing"
+)
+
+func TestStorageContract(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) *storagetest.Storages {
+		db := sqlitetest.New(t)
+
+		return &storagetest.Storages{
+			Films:          sqlite.NewSqliteFilmStorage(db),
+			Actors:         sqlite.NewSqliteActorStorage(db),
+			Genres:         sqlite.NewSqliteGenreStorage(db),
+			Users:          sqliteuser.NewSqliteUserStorage(db),
+			RefreshTokens:  sqliteauth.NewSqliteRefreshTokenStorage(db),
+			Trash:          sqlite.NewSqliteTrashStorage(db),
+			FilmRevisions:  sqlite.NewSqliteFilmRevisionStorage(db),
+			ActorRevisions: sqlite.NewSqliteActorRevisionStorage(db),
+			Audit:          sqlite.NewSqliteAuditStorage(db),
+			FilmImport:     sqlite.NewSqliteFilmImportStorage(db),
+			FilmLists:      sqlite.NewSqliteFilmListStorage(db),
+			Reviews:        sqlite.NewSqliteReviewStorage(db),
+		}
+	})
+}
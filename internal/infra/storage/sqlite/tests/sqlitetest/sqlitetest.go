@@ -0,0 +1,80 @@
+// Package sqlitetest provides disposable SQLite databases for storage tests.
+//
+// Every call of New creates a fresh database file with all migrations applied in a temporary directory of the test.
+package sqlitetest
+
+import (
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/vaberof/vk-internship-task/pkg/database/sqlite"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func New(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	dbPath := filepath.Join(t.TempDir(), "film_library.db")
+
+	if err := migrateUp(dbPath); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+
+	sqliteManagedDb, err := sqlite.New(&sqlite.Config{Path: dbPath})
+	if err != nil {
+		t.Fatalf("failed to open database '%s': %v", dbPath, err)
+	}
+
+	t.Cleanup(func() {
+		sqliteManagedDb.Disconnect()
+	})
+
+	return sqliteManagedDb.SqliteDb
+}
+
+// migrateUp applies migrations with foreign keys not enforced, the same way the migrate tool does,
+// since some of them rebuild tables referenced by other tables
+func migrateUp(dbPath string) error {
+	db, err := sqlx.Connect("sqlite", "file:"+dbPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	_, currentFile, _, _ := runtime.Caller(0)
+	migrationsDir := filepath.Join(filepath.Dir(currentFile), "..", "..", "..", "..", "..", "..", "migrations", "sqlite")
+
+	files, err := filepath.Glob(filepath.Join(migrationsDir, "*.up.sql"))
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no migrations found in '%s'", migrationsDir)
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return migrationVersion(files[i]) < migrationVersion(files[j])
+	})
+
+	for _, file := range files {
+		migration, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		if _, err = db.Exec(string(migration)); err != nil {
+			return fmt.Errorf("failed to apply migration '%s': %w", filepath.Base(file), err)
+		}
+	}
+
+	return nil
+}
+
+func migrationVersion(file string) int {
+	version, _ := strconv.Atoi(strings.SplitN(filepath.Base(file), "_", 2)[0])
+	return version
+}
@@ -0,0 +1,443 @@
+package storagetest
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"testing"
+	"time"
+)
+
+var actorContractTests = []contractTest{
+	{name: "ActorCreateAndGetWithFilms", run: testActorCreateAndGetWithFilms},
+	{name: "ActorNotFound", run: testActorNotFound},
+	{name: "ActorUpdateChecksVersion", run: testActorUpdateChecksVersion},
+	{name: "ActorBatchChecksVersion", run: testActorBatchChecksVersion},
+	{name: "DeletedActorIsHiddenUntilRestored", run: testDeletedActorIsHiddenUntilRestored},
+	{name: "ActorListSortsAndPagesByCursor", run: testActorListSortsAndPagesByCursor},
+	{name: "ActorListRejectsUnknownSortField", run: testActorListRejectsUnknownSortField},
+	{name: "ActorListIncludesActorsWithoutFilms", run: testActorListIncludesActorsWithoutFilms},
+	{name: "ActorListRespectsLimitOffset", run: testActorListRespectsLimitOffset},
+	{name: "ActorListFiltersAndSorts", run: testActorListFiltersAndSorts},
+	{name: "ActorListExcludesDeletedFilms", run: testActorListExcludesDeletedFilms},
+	{name: "ActorBatchTransactionalRollsBackAll", run: testActorBatchTransactionalRollsBackAll},
+	{name: "ActorBatchBestEffortAppliesSucceeded", run: testActorBatchBestEffortAppliesSucceeded},
+	{name: "ActorExportStreamsActorsWithFilms", run: testActorExportStreamsActorsWithFilms},
+}
+
+func testActorCreateAndGetWithFilms(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	birthDate := domain.ActorBirthDate(time.Date(1964, 9, 2, 0, 0, 0, 0, time.UTC))
+
+	actor, err := storages.Actors.Create(ctx, "Keanu Reeves", domain.ActorSex(1), birthDate, nil)
+	require.NoError(t, err)
+	require.Equal(t, domain.ActorName("Keanu Reeves"), actor.Name)
+	require.Equal(t, domain.ActorSex(1), actor.Sex)
+	require.True(t, birthDate.Time().Equal(actor.BirthDate.Time()))
+	require.Equal(t, int64(1), actor.Version)
+	require.Empty(t, actor.Films)
+
+	director := createActor(t, storages.Actors, "Director_1")
+
+	film2 := createFilm(t, storages.Films, "Title_2", 5, []domain.ActorId{actor.Id})
+	film1 := createFilm(t, storages.Films, "Title_1", 5, []domain.ActorId{actor.Id})
+
+	// Only films credited with the 'actor' role are films of the actor
+	credits := []*domain.FilmCreditParams{{ActorId: actor.Id, Role: domain.CreditRoleDirector}}
+	_, err = storages.Films.Create(ctx, "Title_3", "Description", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, credits, nil, nil)
+	require.NoError(t, err)
+
+	gotActor := mustGetActor(t, storages.Actors, actor.Id)
+	require.Equal(t, actor.Name, gotActor.Name)
+	require.Equal(t, []domain.FilmId{film2.Id, film1.Id}, filmIds(gotActor.Films))
+
+	require.Empty(t, mustGetActor(t, storages.Actors, director.Id).Films)
+
+	exists, err := storages.Actors.IsExists(ctx, actor.Id)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	exist, err := storages.Actors.AreExists(ctx, []domain.ActorId{actor.Id, director.Id})
+	require.NoError(t, err)
+	require.True(t, exist)
+}
+
+func testActorNotFound(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	const missingId = domain.ActorId(1000)
+	name := domain.ActorName("Actor_2")
+
+	_, err := storages.Actors.GetById(ctx, missingId)
+	require.ErrorIs(t, err, storage.ErrActorNotFound)
+
+	_, err = storages.Actors.Update(ctx, missingId, nil, &name, nil, nil, nil)
+	require.ErrorIs(t, err, storage.ErrActorNotFound)
+
+	err = storages.Actors.Delete(ctx, missingId, nil, nil)
+	require.ErrorIs(t, err, storage.ErrActorNotFound)
+
+	exists, err := storages.Actors.IsExists(ctx, missingId)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	actor := createActor(t, storages.Actors, "Actor_1")
+
+	exist, err := storages.Actors.AreExists(ctx, []domain.ActorId{actor.Id, missingId})
+	require.NoError(t, err)
+	require.False(t, exist)
+
+	// Only a deleted actor can be restored
+	_, err = storages.Actors.Restore(ctx, actor.Id, nil)
+	require.ErrorIs(t, err, storage.ErrActorNotFound)
+}
+
+func testActorUpdateChecksVersion(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	actor := createActor(t, storages.Actors, "Actor_1")
+
+	name := domain.ActorName("Actor_2")
+	staleVersion := actor.Version + 1
+
+	_, err := storages.Actors.Update(ctx, actor.Id, &staleVersion, &name, nil, nil, nil)
+	require.ErrorIs(t, err, storage.ErrActorVersionMismatch)
+
+	updatedActor, err := storages.Actors.Update(ctx, actor.Id, &actor.Version, &name, nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, name, updatedActor.Name)
+	require.Equal(t, actor.Sex, updatedActor.Sex)
+	require.Equal(t, actor.Version+1, updatedActor.Version)
+
+	err = storages.Actors.Delete(ctx, actor.Id, &actor.Version, nil)
+	require.ErrorIs(t, err, storage.ErrActorVersionMismatch)
+
+	require.NoError(t, storages.Actors.Delete(ctx, actor.Id, &updatedActor.Version, nil))
+}
+
+func testActorBatchChecksVersion(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	actor1 := createActor(t, storages.Actors, "Actor_1")
+	actor2 := createActor(t, storages.Actors, "Actor_2")
+
+	name := domain.ActorName("Actor_3")
+	staleVersion := actor1.Version + 1
+
+	items, committed, err := storages.Actors.Batch(ctx, domain.BatchModeBestEffort, []*domain.ActorBatchOperation{
+		{Type: domain.BatchOperationUpdate, Id: actor1.Id, Version: &staleVersion, Update: &domain.ActorUpdateParams{Name: &name}},
+		{Type: domain.BatchOperationDelete, Id: actor2.Id, Version: &staleVersion},
+		{Type: domain.BatchOperationUpdate, Id: actor1.Id, Version: &actor1.Version, Update: &domain.ActorUpdateParams{Name: &name}},
+		{Type: domain.BatchOperationDelete, Id: actor2.Id, Version: &actor2.Version},
+	}, nil)
+	require.NoError(t, err)
+	require.True(t, committed)
+	require.Len(t, items, 4)
+
+	require.Equal(t, domain.BatchItemFailed, items[0].Status)
+	require.ErrorIs(t, items[0].Err, storage.ErrActorVersionMismatch)
+	require.Equal(t, domain.BatchItemFailed, items[1].Status)
+	require.ErrorIs(t, items[1].Err, storage.ErrActorVersionMismatch)
+
+	require.Equal(t, domain.BatchItemSucceeded, items[2].Status)
+	require.Equal(t, name, items[2].Actor.Name)
+	require.Equal(t, actor1.Version+1, items[2].Actor.Version)
+	require.Equal(t, domain.BatchItemSucceeded, items[3].Status)
+
+	// Listed actors have their versions, so that they can be used in the 'If-Match' header and batch operations
+	actors, _, err := storages.Actors.List(ctx, nil, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.ActorId{actor1.Id}, actorIds(actors))
+	require.Equal(t, items[2].Actor.Version, actors[0].Version)
+
+	exists, err := storages.Actors.IsExists(ctx, actor2.Id)
+	require.NoError(t, err)
+	require.False(t, exists)
+}
+
+func testDeletedActorIsHiddenUntilRestored(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	actor := createActor(t, storages.Actors, "Actor_1")
+	film := createFilm(t, storages.Films, "Title_1", 5, []domain.ActorId{actor.Id})
+
+	require.NoError(t, storages.Actors.Delete(ctx, actor.Id, nil, nil))
+
+	_, err := storages.Actors.GetById(ctx, actor.Id)
+	require.ErrorIs(t, err, storage.ErrActorNotFound)
+
+	err = storages.Actors.Delete(ctx, actor.Id, nil, nil)
+	require.ErrorIs(t, err, storage.ErrActorNotFound)
+
+	exists, err := storages.Actors.IsExists(ctx, actor.Id)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	exist, err := storages.Actors.AreExists(ctx, []domain.ActorId{actor.Id})
+	require.NoError(t, err)
+	require.False(t, exist)
+
+	actors, _, err := storages.Actors.List(ctx, nil, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Empty(t, actors)
+
+	count, err := storages.Actors.Count(ctx, nil)
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	restoredActor, err := storages.Actors.Restore(ctx, actor.Id, nil)
+	require.NoError(t, err)
+	require.Equal(t, actor.Version, restoredActor.Version)
+	require.Equal(t, []domain.FilmId{film.Id}, filmIds(restoredActor.Films))
+}
+
+func testActorListSortsAndPagesByCursor(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	actor1 := createActor(t, storages.Actors, "Anna Smith")
+	actor2 := createActor(t, storages.Actors, "Bob Stone")
+	actor3 := createActor(t, storages.Actors, "Carl Smithson")
+
+	createFilm(t, storages.Films, "Title_1", 5, []domain.ActorId{actor2.Id, actor3.Id})
+	film2 := createFilm(t, storages.Films, "Title_2", 5, []domain.ActorId{actor2.Id})
+
+	filmsCountDesc := domain.ActorSort{{Field: domain.ActorSortFieldFilmsCount, Direction: domain.SortDirectionDesc}}
+
+	actors, nextCursor, err := storages.Actors.List(ctx, nil, filmsCountDesc, nil, 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.ActorId{actor2.Id, actor3.Id}, actorIds(actors))
+	require.NotNil(t, nextCursor)
+
+	actors, nextCursor, err = storages.Actors.List(ctx, nil, filmsCountDesc, nextCursor, 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.ActorId{actor1.Id}, actorIds(actors))
+	require.Nil(t, nextCursor)
+
+	// Deleted films are not counted
+	require.NoError(t, storages.Films.Delete(ctx, film2.Id, nil, nil))
+
+	sort := domain.ActorSort{
+		{Field: domain.ActorSortFieldFilmsCount, Direction: domain.SortDirectionDesc},
+		{Field: domain.ActorSortFieldName, Direction: domain.SortDirectionDesc},
+	}
+
+	actors, _, err = storages.Actors.List(ctx, nil, sort, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.ActorId{actor3.Id, actor2.Id, actor1.Id}, actorIds(actors))
+}
+
+func testActorListRejectsUnknownSortField(t *testing.T, storages *Storages) {
+	sort := domain.ActorSort{{Field: "unknown", Direction: domain.SortDirectionAsc}}
+
+	_, _, err := storages.Actors.List(context.Background(), nil, sort, nil, 10, 0)
+	require.ErrorIs(t, err, domain.ErrInvalidActorSort)
+}
+
+func testActorListIncludesActorsWithoutFilms(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	actorWithFilm := createActor(t, storages.Actors, "Actor_1")
+	actorWithoutFilms := createActor(t, storages.Actors, "Actor_2")
+
+	film := createFilm(t, storages.Films, "Title_1", 5, []domain.ActorId{actorWithFilm.Id})
+
+	actors, _, err := storages.Actors.List(ctx, nil, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.ActorId{actorWithFilm.Id, actorWithoutFilms.Id}, actorIds(actors))
+
+	require.Equal(t, []domain.FilmId{film.Id}, filmIds(actors[0].Films))
+	require.Empty(t, actors[1].Films)
+}
+
+func testActorListRespectsLimitOffset(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	actor1 := createActor(t, storages.Actors, "Actor_1")
+	actor2 := createActor(t, storages.Actors, "Actor_2")
+	actor3 := createActor(t, storages.Actors, "Actor_3")
+
+	createFilm(t, storages.Films, "Title_1", 5, []domain.ActorId{actor1.Id, actor3.Id})
+
+	actors, nextCursor, err := storages.Actors.List(ctx, nil, nil, nil, 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.ActorId{actor1.Id, actor2.Id}, actorIds(actors))
+	require.NotNil(t, nextCursor)
+
+	actors, nextCursor, err = storages.Actors.List(ctx, nil, nil, nextCursor, 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.ActorId{actor3.Id}, actorIds(actors))
+	require.Len(t, actors[0].Films, 1)
+	require.Nil(t, nextCursor)
+
+	actors, _, err = storages.Actors.List(ctx, nil, nil, nil, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, []domain.ActorId{actor2.Id}, actorIds(actors))
+	require.Empty(t, actors[0].Films)
+}
+
+func testActorListFiltersAndSorts(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	actor1, err := storages.Actors.Create(ctx, "John Smith", domain.ActorSex(1), domain.ActorBirthDate(time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)), nil)
+	require.NoError(t, err)
+	actor2, err := storages.Actors.Create(ctx, "Anna Smith", domain.ActorSex(2), domain.ActorBirthDate(time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)), nil)
+	require.NoError(t, err)
+	actor3, err := storages.Actors.Create(ctx, "Bob Brown", domain.ActorSex(1), domain.ActorBirthDate(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)), nil)
+	require.NoError(t, err)
+
+	film1 := createFilm(t, storages.Films, "Title_1", 5, []domain.ActorId{actor1.Id, actor2.Id})
+	createFilm(t, storages.Films, "Title_2", 5, []domain.ActorId{actor2.Id})
+
+	sex := domain.ActorSex(1)
+	bornFrom := domain.ActorBirthDate(time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC))
+	bornTo := domain.ActorBirthDate(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	testCases := []struct {
+		name     string
+		filter   *domain.ActorFilter
+		sort     domain.ActorSort
+		actorIds []domain.ActorId
+	}{
+		{name: "name", filter: &domain.ActorFilter{Name: "smith"}, actorIds: []domain.ActorId{actor1.Id, actor2.Id}},
+		{name: "sex", filter: &domain.ActorFilter{Sex: &sex}, actorIds: []domain.ActorId{actor1.Id, actor3.Id}},
+		{name: "birth date range", filter: &domain.ActorFilter{BornFrom: &bornFrom, BornTo: &bornTo}, actorIds: []domain.ActorId{actor2.Id, actor3.Id}},
+		{name: "film", filter: &domain.ActorFilter{FilmId: &film1.Id}, actorIds: []domain.ActorId{actor1.Id, actor2.Id}},
+		{name: "combined", filter: &domain.ActorFilter{Name: "smith", Sex: &sex, FilmId: &film1.Id}, actorIds: []domain.ActorId{actor1.Id}},
+		{
+			name:     "sort by name",
+			sort:     domain.ActorSort{{Field: domain.ActorSortFieldName, Direction: domain.SortDirectionAsc}},
+			actorIds: []domain.ActorId{actor2.Id, actor3.Id, actor1.Id},
+		},
+		{
+			name:     "sort by birth date",
+			sort:     domain.ActorSort{{Field: domain.ActorSortFieldBirthDate, Direction: domain.SortDirectionDesc}},
+			actorIds: []domain.ActorId{actor3.Id, actor2.Id, actor1.Id},
+		},
+		{
+			name:     "filter with sort by films count",
+			filter:   &domain.ActorFilter{Name: "smith"},
+			sort:     domain.ActorSort{{Field: domain.ActorSortFieldFilmsCount, Direction: domain.SortDirectionDesc}},
+			actorIds: []domain.ActorId{actor2.Id, actor1.Id},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actors, _, err := storages.Actors.List(ctx, testCase.filter, testCase.sort, nil, 10, 0)
+			require.NoError(t, err)
+			require.Equal(t, testCase.actorIds, actorIds(actors))
+
+			count, err := storages.Actors.Count(ctx, testCase.filter)
+			require.NoError(t, err)
+			require.Equal(t, int64(len(testCase.actorIds)), count)
+		})
+	}
+}
+
+func testActorListExcludesDeletedFilms(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	actor := createActor(t, storages.Actors, "Actor_1")
+
+	keptFilm := createFilm(t, storages.Films, "Title_1", 5, []domain.ActorId{actor.Id})
+	deletedFilm := createFilm(t, storages.Films, "Title_2", 5, []domain.ActorId{actor.Id})
+
+	require.NoError(t, storages.Films.Delete(ctx, deletedFilm.Id, nil, nil))
+
+	filmsCountDesc := domain.ActorSort{{Field: domain.ActorSortFieldFilmsCount, Direction: domain.SortDirectionDesc}}
+
+	actors, _, err := storages.Actors.List(ctx, nil, filmsCountDesc, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.ActorId{actor.Id}, actorIds(actors))
+	require.Equal(t, []domain.FilmId{keptFilm.Id}, filmIds(actors[0].Films))
+
+	count, err := storages.Actors.Count(ctx, &domain.ActorFilter{FilmId: &deletedFilm.Id})
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func testActorBatchTransactionalRollsBackAll(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	actor := createActor(t, storages.Actors, "Actor_1")
+	name := domain.ActorName("Actor_2")
+
+	items, committed, err := storages.Actors.Batch(ctx, domain.BatchModeTransactional, []*domain.ActorBatchOperation{
+		{Type: domain.BatchOperationUpdate, Id: actor.Id, Update: &domain.ActorUpdateParams{Name: &name}},
+		{Type: domain.BatchOperationDelete, Id: actor.Id + 100},
+		{Type: domain.BatchOperationCreate, Create: &domain.ActorCreateParams{Name: "Actor_3", Sex: domain.ActorSex(1)}},
+	}, nil)
+	require.NoError(t, err)
+	require.False(t, committed)
+
+	require.Equal(t, domain.BatchItemAborted, items[0].Status)
+	require.Equal(t, domain.BatchItemFailed, items[1].Status)
+	require.ErrorIs(t, items[1].Err, storage.ErrActorNotFound)
+	require.Equal(t, domain.BatchItemAborted, items[2].Status)
+
+	actors, _, err := storages.Actors.List(ctx, nil, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.ActorId{actor.Id}, actorIds(actors))
+	require.Equal(t, actor.Name, actors[0].Name)
+}
+
+func testActorBatchBestEffortAppliesSucceeded(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	actor1 := createActor(t, storages.Actors, "Actor_1")
+	actor2 := createActor(t, storages.Actors, "Actor_2")
+	name := domain.ActorName("Actor_4")
+
+	items, committed, err := storages.Actors.Batch(ctx, domain.BatchModeBestEffort, []*domain.ActorBatchOperation{
+		{Type: domain.BatchOperationCreate, Create: &domain.ActorCreateParams{Name: "Actor_3", Sex: domain.ActorSex(1)}},
+		{Type: domain.BatchOperationDelete, Id: actor2.Id + 100},
+		{Type: domain.BatchOperationUpdate, Id: actor1.Id, Update: &domain.ActorUpdateParams{Name: &name}},
+		{Type: domain.BatchOperationDelete, Id: actor2.Id},
+	}, nil)
+	require.NoError(t, err)
+	require.True(t, committed)
+
+	require.Equal(t, domain.BatchItemSucceeded, items[0].Status)
+	require.Equal(t, domain.BatchItemFailed, items[1].Status)
+	require.ErrorIs(t, items[1].Err, storage.ErrActorNotFound)
+	require.Equal(t, domain.BatchItemSucceeded, items[2].Status)
+	require.Equal(t, name, items[2].Actor.Name)
+	require.Equal(t, domain.BatchItemSucceeded, items[3].Status)
+
+	actors, _, err := storages.Actors.List(ctx, nil, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.ActorId{actor1.Id, items[0].Actor.Id}, actorIds(actors))
+}
+
+func testActorExportStreamsActorsWithFilms(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	actor1 := createActor(t, storages.Actors, "Actor_1")
+	actor2 := createActor(t, storages.Actors, "Actor_2")
+
+	film1 := createFilm(t, storages.Films, "Title_1", 5, []domain.ActorId{actor1.Id})
+	film2 := createFilm(t, storages.Films, "Title_2", 5, []domain.ActorId{actor1.Id})
+
+	var actors []*domain.Actor
+
+	err := storages.Actors.Export(ctx, func(actor *domain.Actor) error {
+		actors = append(actors, actor)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []domain.ActorId{actor1.Id, actor2.Id}, actorIds(actors))
+
+	require.Equal(t, []domain.FilmId{film1.Id, film2.Id}, filmIds(actors[0].Films))
+	require.Empty(t, actors[1].Films)
+}
+
+func mustGetActor(t *testing.T, actorStorage domain.ActorStorage, id domain.ActorId) *domain.Actor {
+	t.Helper()
+
+	actor, err := actorStorage.GetById(context.Background(), id)
+	require.NoError(t, err)
+
+	return actor
+}
@@ -0,0 +1,80 @@
+package storagetest
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"testing"
+	"time"
+)
+
+var actorRevisionContractTests = []contractTest{
+	{name: "ActorCreateAndUpdateRecordRevisions", run: testActorCreateAndUpdateRecordRevisions},
+	{name: "ActorRestoredKeepsRevisionOfVersion", run: testActorRestoredKeepsRevisionOfVersion},
+}
+
+func testActorCreateAndUpdateRecordRevisions(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	// Users seeded by migrations: 1 - user@example.com, 2 - admin@example.com
+	adminId := int64(2)
+
+	actor, err := storages.Actors.Create(ctx, "Actor_1", domain.ActorSex(1), domain.ActorBirthDate(time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)), &adminId)
+	require.NoError(t, err)
+
+	name := domain.ActorName("Actor_2")
+
+	actor, err = storages.Actors.Update(ctx, actor.Id, nil, &name, nil, nil, nil)
+	require.NoError(t, err)
+
+	revisions, err := storages.ActorRevisions.List(ctx, actor.Id, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+
+	require.Equal(t, actor.Version, revisions[0].Revision)
+	require.Nil(t, revisions[0].UserId)
+	require.Equal(t, name, revisions[0].Name)
+
+	require.Equal(t, int64(1), revisions[1].Revision)
+	require.Equal(t, &adminId, revisions[1].UserId)
+	require.Equal(t, domain.ActorName("Actor_1"), revisions[1].Name)
+	require.Equal(t, domain.ActorSex(1), revisions[1].Sex)
+
+	count, err := storages.ActorRevisions.Count(ctx, actor.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	revision, err := storages.ActorRevisions.Get(ctx, actor.Id, 1)
+	require.NoError(t, err)
+	require.Equal(t, revisions[1], revision)
+
+	_, err = storages.ActorRevisions.Get(ctx, actor.Id, 3)
+	require.ErrorIs(t, err, storage.ErrActorRevisionNotFound)
+}
+
+func testActorRestoredKeepsRevisionOfVersion(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	actor := createActor(t, storages.Actors, "Actor_1")
+
+	name := domain.ActorName("Actor_2")
+
+	actor, err := storages.Actors.Update(ctx, actor.Id, nil, &name, nil, nil, nil)
+	require.NoError(t, err)
+
+	err = storages.Actors.Delete(ctx, actor.Id, &actor.Version, nil)
+	require.NoError(t, err)
+
+	restoredActor, err := storages.Actors.Restore(ctx, actor.Id, nil)
+	require.NoError(t, err)
+	require.Equal(t, actor.Version, restoredActor.Version)
+
+	revision, err := storages.ActorRevisions.Get(ctx, actor.Id, restoredActor.Version)
+	require.NoError(t, err)
+	require.Equal(t, name, revision.Name)
+
+	count, err := storages.ActorRevisions.Count(ctx, actor.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+}
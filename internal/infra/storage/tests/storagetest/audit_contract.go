@@ -0,0 +1,73 @@
+package storagetest
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"strconv"
+	"testing"
+	"time"
+)
+
+var auditContractTests = []contractTest{
+	{name: "MutationsAreAudited", run: testMutationsAreAudited},
+	{name: "FailedMutationIsNotAudited", run: testFailedMutationIsNotAudited},
+}
+
+func testMutationsAreAudited(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	// Users seeded by migrations: 1 - user@example.com, 2 - admin@example.com
+	adminId := int64(2)
+
+	actor, err := storages.Actors.Create(ctx, "Actor_1", domain.ActorSex(1), domain.ActorBirthDate(time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)), &adminId)
+	require.NoError(t, err)
+
+	film, err := storages.Films.Create(ctx, "Title_1", "Description", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, domain.NewActorCredits([]domain.ActorId{actor.Id}), nil, &adminId)
+	require.NoError(t, err)
+
+	rating := domain.FilmRating(7)
+	_, err = storages.Films.Update(ctx, film.Id, nil, nil, nil, nil, &rating, nil, nil, nil, &adminId)
+	require.NoError(t, err)
+
+	require.NoError(t, storages.Films.Delete(ctx, film.Id, nil, &adminId))
+
+	entityType := domain.AuditEntityTypeFilm
+	entityId := film.Id.Int64()
+	filter := &domain.AuditFilter{EntityType: &entityType, EntityId: &entityId}
+
+	events, err := storages.Audit.List(ctx, filter, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	// Events are listed from the newest to the oldest
+	require.Equal(t, domain.AuditOperationDelete, events[0].Operation)
+	require.NotEmpty(t, events[0].Before)
+	require.Empty(t, events[0].After)
+
+	require.Equal(t, domain.AuditOperationUpdate, events[1].Operation)
+	require.JSONEq(t, `{"id":`+jsonInt(entityId)+`,"title":"Title_1","description":"Description","release_date":"2000-01-01","rating":5,"actor_ids":[`+jsonInt(actor.Id.Int64())+`],"credits":[{"actor_id":`+jsonInt(actor.Id.Int64())+`,"role":"actor","billing_order":0}],"genre_ids":[]}`, string(events[1].Before))
+	require.JSONEq(t, `{"id":`+jsonInt(entityId)+`,"title":"Title_1","description":"Description","release_date":"2000-01-01","rating":7,"actor_ids":[`+jsonInt(actor.Id.Int64())+`],"credits":[{"actor_id":`+jsonInt(actor.Id.Int64())+`,"role":"actor","billing_order":0}],"genre_ids":[]}`, string(events[1].After))
+
+	require.Equal(t, domain.AuditOperationCreate, events[2].Operation)
+	require.Empty(t, events[2].Before)
+	require.Equal(t, &adminId, events[2].UserId)
+
+	count, err := storages.Audit.Count(ctx, &domain.AuditFilter{UserId: &adminId})
+	require.NoError(t, err)
+	require.Equal(t, int64(4), count)
+}
+
+func testFailedMutationIsNotAudited(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	require.Error(t, storages.Films.Delete(ctx, domain.FilmId(1), nil, nil))
+
+	count, err := storages.Audit.Count(ctx, &domain.AuditFilter{})
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func jsonInt(value int64) string {
+	return strconv.FormatInt(value, 10)
+}
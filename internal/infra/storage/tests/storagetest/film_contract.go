@@ -0,0 +1,923 @@
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"testing"
+	"time"
+)
+
+var filmContractTests = []contractTest{
+	{name: "FilmCreateReturnsCreditsActorsAndGenres", run: testFilmCreateReturnsCreditsActorsAndGenres},
+	{name: "FilmNotFound", run: testFilmNotFound},
+	{name: "FilmUpdateChecksVersion", run: testFilmUpdateChecksVersion},
+	{name: "FilmUpdateOfActorIdsKeepsCrew", run: testFilmUpdateOfActorIdsKeepsCrew},
+	{name: "DeletedFilmIsHiddenUntilRestored", run: testDeletedFilmIsHiddenUntilRestored},
+	{name: "DeletedActorIsHiddenInFilmsUntilRestored", run: testDeletedActorIsHiddenInFilmsUntilRestored},
+	{name: "GenreDeleteCascadesToFilms", run: testGenreDeleteCascadesToFilms},
+	{name: "FilmListSortsAndPagesByCursor", run: testFilmListSortsAndPagesByCursor},
+	{name: "FilmListRejectsUnknownSortField", run: testFilmListRejectsUnknownSortField},
+	{name: "FilmListFiltersByFragments", run: testFilmListFiltersByFragments},
+	{name: "FilmListFiltersByActorsAndRanges", run: testFilmListFiltersByActorsAndRanges},
+	{name: "FilmListSearchesByQuery", run: testFilmListSearchesByQuery},
+	{name: "FilmBatchChecksVersion", run: testFilmBatchChecksVersion},
+	{name: "FilmBatchTransactionalRollsBackAll", run: testFilmBatchTransactionalRollsBackAll},
+	{name: "FilmBatchBestEffortAppliesSucceeded", run: testFilmBatchBestEffortAppliesSucceeded},
+	{name: "FilmListIncludesFilmsWithoutActors", run: testFilmListIncludesFilmsWithoutActors},
+	{name: "FilmListFollowsSortKeysOrder", run: testFilmListFollowsSortKeysOrder},
+	{name: "FilmListWithFilterIncludesFilmsWithoutActors", run: testFilmListWithFilterIncludesFilmsWithoutActors},
+	{name: "FilmListWithFilterRespectsLimitOffset", run: testFilmListWithFilterRespectsLimitOffset},
+	{name: "FilmListSearchesByQueryWithTyposAndCredits", run: testFilmListSearchesByQueryWithTyposAndCredits},
+	{name: "FilmGenres", run: testFilmGenres},
+	{name: "FilmCreditsAndDirectorFilter", run: testFilmCreditsAndDirectorFilter},
+	{name: "FilmCreateAndUpdateRecordAuthors", run: testFilmCreateAndUpdateRecordAuthors},
+	{name: "FilmConcurrentUpdatesWithSameVersion", run: testFilmConcurrentUpdatesWithSameVersion},
+	{name: "RestoredActorIsBackInFilms", run: testRestoredActorIsBackInFilms},
+	{name: "FilmExportStreamsFilmsWithActors", run: testFilmExportStreamsFilmsWithActors},
+	{name: "FilmExportStopsAtCallbackError", run: testFilmExportStopsAtCallbackError},
+}
+
+var filmIdAsc = domain.FilmSort{{Field: domain.FilmSortFieldId, Direction: domain.SortDirectionAsc}}
+
+func testFilmCreateReturnsCreditsActorsAndGenres(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	actor := createActor(t, storages.Actors, "Actor_1")
+	director := createActor(t, storages.Actors, "Director_1")
+
+	drama, err := storages.Genres.Create(ctx, "Drama")
+	require.NoError(t, err)
+	comedy, err := storages.Genres.Create(ctx, "Comedy")
+	require.NoError(t, err)
+
+	credits := []*domain.FilmCreditParams{
+		{ActorId: director.Id, Role: domain.CreditRoleDirector, BillingOrder: 1},
+		{ActorId: actor.Id, Role: domain.CreditRoleActor, CharacterName: "Character_1", BillingOrder: 0},
+	}
+	releaseDate := domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	film, err := storages.Films.Create(ctx, "Title_1", "Description_1", releaseDate, 8, credits, []domain.GenreId{drama.Id, comedy.Id}, nil)
+	require.NoError(t, err)
+
+	for _, checkedFilm := range []*domain.Film{film, mustGetFilm(t, storages.Films, film.Id)} {
+		require.Equal(t, domain.FilmTitle("Title_1"), checkedFilm.Title)
+		require.Equal(t, domain.FilmDescription("Description_1"), checkedFilm.Description)
+		require.True(t, releaseDate.Time().Equal(checkedFilm.ReleaseDate.Time()))
+		require.Equal(t, domain.FilmRating(8), checkedFilm.Rating)
+		require.Equal(t, int64(1), checkedFilm.Version)
+
+		require.Equal(t, []domain.ActorId{actor.Id}, actorIds(checkedFilm.Actors))
+
+		require.Len(t, checkedFilm.Credits, 2)
+		require.Equal(t, actor.Id, checkedFilm.Credits[0].Actor.Id)
+		require.Equal(t, domain.CreditRoleActor, checkedFilm.Credits[0].Role)
+		require.Equal(t, domain.CharacterName("Character_1"), checkedFilm.Credits[0].CharacterName)
+		require.Equal(t, director.Id, checkedFilm.Credits[1].Actor.Id)
+		require.Equal(t, domain.CreditRoleDirector, checkedFilm.Credits[1].Role)
+
+		require.Len(t, checkedFilm.Genres, 2)
+		require.Equal(t, comedy.Id, checkedFilm.Genres[0].Id)
+		require.Equal(t, drama.Id, checkedFilm.Genres[1].Id)
+	}
+
+	exists, err := storages.Films.IsExists(ctx, film.Id)
+	require.NoError(t, err)
+	require.True(t, exists)
+}
+
+func testFilmNotFound(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	const missingId = domain.FilmId(1000)
+	title := domain.FilmTitle("Title_2")
+
+	_, err := storages.Films.GetById(ctx, missingId)
+	require.ErrorIs(t, err, storage.ErrFilmNotFound)
+
+	_, err = storages.Films.Update(ctx, missingId, nil, &title, nil, nil, nil, nil, nil, nil, nil)
+	require.ErrorIs(t, err, storage.ErrFilmNotFound)
+
+	err = storages.Films.Delete(ctx, missingId, nil, nil)
+	require.ErrorIs(t, err, storage.ErrFilmNotFound)
+
+	exists, err := storages.Films.IsExists(ctx, missingId)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	// Only a deleted film can be restored
+	film := createFilm(t, storages.Films, "Title_1", 5, nil)
+
+	_, err = storages.Films.Restore(ctx, film.Id, nil)
+	require.ErrorIs(t, err, storage.ErrFilmNotFound)
+}
+
+func testFilmUpdateChecksVersion(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	film := createFilm(t, storages.Films, "Title_1", 5, nil)
+
+	title := domain.FilmTitle("Title_2")
+	staleVersion := film.Version + 1
+
+	_, err := storages.Films.Update(ctx, film.Id, &staleVersion, &title, nil, nil, nil, nil, nil, nil, nil)
+	require.ErrorIs(t, err, storage.ErrFilmVersionMismatch)
+
+	updatedFilm, err := storages.Films.Update(ctx, film.Id, &film.Version, &title, nil, nil, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, title, updatedFilm.Title)
+	require.Equal(t, film.Description, updatedFilm.Description)
+	require.Equal(t, film.Version+1, updatedFilm.Version)
+
+	err = storages.Films.Delete(ctx, film.Id, &film.Version, nil)
+	require.ErrorIs(t, err, storage.ErrFilmVersionMismatch)
+
+	require.NoError(t, storages.Films.Delete(ctx, film.Id, &updatedFilm.Version, nil))
+}
+
+func testFilmUpdateOfActorIdsKeepsCrew(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	actor1 := createActor(t, storages.Actors, "Actor_1")
+	actor2 := createActor(t, storages.Actors, "Actor_2")
+	director := createActor(t, storages.Actors, "Director_1")
+
+	credits := []*domain.FilmCreditParams{
+		{ActorId: actor1.Id, Role: domain.CreditRoleActor, BillingOrder: 0},
+		{ActorId: director.Id, Role: domain.CreditRoleDirector, BillingOrder: 1},
+	}
+
+	film, err := storages.Films.Create(ctx, "Title_1", "Description", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, credits, nil, nil)
+	require.NoError(t, err)
+
+	newActorIds := []domain.ActorId{actor2.Id}
+
+	updatedFilm, err := storages.Films.Update(ctx, film.Id, nil, nil, nil, nil, nil, &newActorIds, nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, newActorIds, actorIds(updatedFilm.Actors))
+
+	require.Len(t, updatedFilm.Credits, 2)
+	require.Equal(t, actor2.Id, updatedFilm.Credits[0].Actor.Id)
+	require.Equal(t, director.Id, updatedFilm.Credits[1].Actor.Id)
+	require.Equal(t, domain.CreditRoleDirector, updatedFilm.Credits[1].Role)
+
+	// Credits replace the whole cast and crew
+	newCredits := []*domain.FilmCreditParams{{ActorId: actor1.Id, Role: domain.CreditRoleWriter}}
+
+	updatedFilm, err = storages.Films.Update(ctx, film.Id, nil, nil, nil, nil, nil, nil, &newCredits, nil, nil)
+	require.NoError(t, err)
+	require.Empty(t, updatedFilm.Actors)
+	require.Len(t, updatedFilm.Credits, 1)
+	require.Equal(t, actor1.Id, updatedFilm.Credits[0].Actor.Id)
+	require.Equal(t, domain.CreditRoleWriter, updatedFilm.Credits[0].Role)
+}
+
+func testDeletedFilmIsHiddenUntilRestored(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	actor := createActor(t, storages.Actors, "Actor_1")
+
+	genre, err := storages.Genres.Create(ctx, "Drama")
+	require.NoError(t, err)
+
+	film, err := storages.Films.Create(ctx, "Title_1", "Description", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, domain.NewActorCredits([]domain.ActorId{actor.Id}), []domain.GenreId{genre.Id}, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, storages.Films.Delete(ctx, film.Id, nil, nil))
+
+	_, err = storages.Films.GetById(ctx, film.Id)
+	require.ErrorIs(t, err, storage.ErrFilmNotFound)
+
+	err = storages.Films.Delete(ctx, film.Id, nil, nil)
+	require.ErrorIs(t, err, storage.ErrFilmNotFound)
+
+	exists, err := storages.Films.IsExists(ctx, film.Id)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	films, _, err := storages.Films.List(ctx, nil, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Empty(t, films)
+
+	count, err := storages.Films.Count(ctx, nil)
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	require.Empty(t, mustGetActor(t, storages.Actors, actor.Id).Films)
+
+	restoredFilm, err := storages.Films.Restore(ctx, film.Id, nil)
+	require.NoError(t, err)
+	require.Equal(t, film.Version, restoredFilm.Version)
+	require.Equal(t, []domain.ActorId{actor.Id}, actorIds(restoredFilm.Actors))
+	require.Len(t, restoredFilm.Genres, 1)
+	require.Equal(t, genre.Id, restoredFilm.Genres[0].Id)
+
+	actorFilms := mustGetActor(t, storages.Actors, actor.Id).Films
+	require.Equal(t, []domain.FilmId{film.Id}, filmIds(actorFilms))
+}
+
+func testDeletedActorIsHiddenInFilmsUntilRestored(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	actor1 := createActor(t, storages.Actors, "Actor_1")
+	actor2 := createActor(t, storages.Actors, "Actor_2")
+
+	film := createFilm(t, storages.Films, "Title_1", 5, []domain.ActorId{actor1.Id, actor2.Id})
+
+	require.NoError(t, storages.Actors.Delete(ctx, actor1.Id, nil, nil))
+
+	require.Equal(t, []domain.ActorId{actor2.Id}, actorIds(mustGetFilm(t, storages.Films, film.Id).Actors))
+
+	films, _, err := storages.Films.List(ctx, &domain.FilmFilter{ActorIds: []domain.ActorId{actor1.Id}}, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Empty(t, films)
+
+	actorsCountDesc := domain.FilmSort{{Field: domain.FilmSortFieldActorsCount, Direction: domain.SortDirectionDesc}}
+
+	films, nextCursor, err := storages.Films.List(ctx, nil, actorsCountDesc, nil, 1, 0)
+	require.NoError(t, err)
+	require.Len(t, films, 1)
+	require.Equal(t, []domain.ActorId{actor2.Id}, actorIds(films[0].Actors))
+	require.Nil(t, nextCursor)
+
+	_, err = storages.Actors.Restore(ctx, actor1.Id, nil)
+	require.NoError(t, err)
+
+	require.Equal(t, []domain.ActorId{actor1.Id, actor2.Id}, actorIds(mustGetFilm(t, storages.Films, film.Id).Actors))
+}
+
+func testGenreDeleteCascadesToFilms(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	drama, err := storages.Genres.Create(ctx, "Drama")
+	require.NoError(t, err)
+
+	_, err = storages.Genres.Create(ctx, "Drama")
+	require.ErrorIs(t, err, storage.ErrGenreAlreadyExists)
+
+	film, err := storages.Films.Create(ctx, "Title_1", "Description", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, nil, []domain.GenreId{drama.Id}, nil)
+	require.NoError(t, err)
+
+	films, _, err := storages.Films.List(ctx, &domain.FilmFilter{GenreId: &drama.Id}, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film.Id}, filmIds(films))
+
+	require.NoError(t, storages.Genres.Delete(ctx, drama.Id))
+
+	require.Empty(t, mustGetFilm(t, storages.Films, film.Id).Genres)
+
+	films, _, err = storages.Films.List(ctx, &domain.FilmFilter{GenreId: &drama.Id}, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Empty(t, films)
+
+	_, err = storages.Genres.GetById(ctx, drama.Id)
+	require.ErrorIs(t, err, storage.ErrGenreNotFound)
+
+	err = storages.Genres.Delete(ctx, drama.Id)
+	require.ErrorIs(t, err, storage.ErrGenreNotFound)
+}
+
+func testFilmListSortsAndPagesByCursor(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	actor1 := createActor(t, storages.Actors, "Actor_1")
+	actor2 := createActor(t, storages.Actors, "Actor_2")
+
+	filmC := createFilm(t, storages.Films, "C", 1, []domain.ActorId{actor1.Id, actor2.Id})
+	filmA := createFilm(t, storages.Films, "A", 2, nil)
+	filmD := createFilm(t, storages.Films, "D", 3, []domain.ActorId{actor1.Id})
+	filmB := createFilm(t, storages.Films, "B", 4, nil)
+
+	// Films with the highest rating go first by default
+	films, _, err := storages.Films.List(ctx, nil, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{filmB.Id, filmD.Id, filmA.Id, filmC.Id}, filmIds(films))
+
+	titleAsc := domain.FilmSort{{Field: domain.FilmSortFieldTitle, Direction: domain.SortDirectionAsc}}
+
+	films, nextCursor, err := storages.Films.List(ctx, nil, titleAsc, nil, 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{filmA.Id, filmB.Id}, filmIds(films))
+	require.NotNil(t, nextCursor)
+
+	films, nextCursor, err = storages.Films.List(ctx, nil, titleAsc, nextCursor, 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{filmC.Id, filmD.Id}, filmIds(films))
+	require.Nil(t, nextCursor)
+
+	sort := domain.FilmSort{
+		{Field: domain.FilmSortFieldActorsCount, Direction: domain.SortDirectionDesc},
+		{Field: domain.FilmSortFieldTitle, Direction: domain.SortDirectionDesc},
+	}
+
+	films, _, err = storages.Films.List(ctx, nil, sort, nil, 10, 1)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{filmD.Id, filmB.Id, filmA.Id}, filmIds(films))
+}
+
+func testFilmListRejectsUnknownSortField(t *testing.T, storages *Storages) {
+	sort := domain.FilmSort{{Field: "unknown", Direction: domain.SortDirectionAsc}}
+
+	_, _, err := storages.Films.List(context.Background(), nil, sort, nil, 10, 0)
+	require.ErrorIs(t, err, domain.ErrInvalidFilmSort)
+}
+
+func testFilmListFiltersByFragments(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	actor1 := createActor(t, storages.Actors, "Keanu Reeves")
+	actor2 := createActor(t, storages.Actors, "Carrie-Anne Moss")
+	actor3 := createActor(t, storages.Actors, "Humphrey Bogart")
+	director := createActor(t, storages.Actors, "Lana Wachowski")
+
+	credits := []*domain.FilmCreditParams{
+		{ActorId: actor1.Id, Role: domain.CreditRoleActor, BillingOrder: 0},
+		{ActorId: director.Id, Role: domain.CreditRoleDirector, BillingOrder: 1},
+	}
+
+	film1, err := storages.Films.Create(ctx, "The Matrix", "Description", domain.FilmReleaseDate(time.Date(1999, 3, 31, 0, 0, 0, 0, time.UTC)), 8, credits, nil, nil)
+	require.NoError(t, err)
+	film2 := createFilm(t, storages.Films, "Matrix Reloaded", 7, []domain.ActorId{actor2.Id})
+	createFilm(t, storages.Films, "Casablanca", 9, []domain.ActorId{actor3.Id})
+
+	testCases := []struct {
+		name    string
+		filter  *domain.FilmFilter
+		filmIds []domain.FilmId
+	}{
+		{name: "title", filter: &domain.FilmFilter{Title: "matrix"}, filmIds: []domain.FilmId{film1.Id, film2.Id}},
+		{name: "actor name", filter: &domain.FilmFilter{ActorName: "REEVES"}, filmIds: []domain.FilmId{film1.Id}},
+		{name: "director name", filter: &domain.FilmFilter{DirectorName: "wachow"}, filmIds: []domain.FilmId{film1.Id}},
+		{name: "director is not an actor", filter: &domain.FilmFilter{ActorName: "wachow"}, filmIds: []domain.FilmId{}},
+		{name: "combined", filter: &domain.FilmFilter{Title: "matrix", ActorName: "moss"}, filmIds: []domain.FilmId{film2.Id}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			films, _, err := storages.Films.List(ctx, testCase.filter, nil, nil, 10, 0)
+			require.NoError(t, err)
+			require.Equal(t, testCase.filmIds, filmIds(films))
+
+			count, err := storages.Films.Count(ctx, testCase.filter)
+			require.NoError(t, err)
+			require.Equal(t, int64(len(testCase.filmIds)), count)
+		})
+	}
+}
+
+func testFilmListFiltersByActorsAndRanges(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	actor1 := createActor(t, storages.Actors, "Actor_1")
+	actor2 := createActor(t, storages.Actors, "Actor_2")
+
+	film1 := createFilm(t, storages.Films, "Title_1", 5, []domain.ActorId{actor1.Id, actor2.Id})
+	film2 := createFilm(t, storages.Films, "Title_2", 7, []domain.ActorId{actor1.Id})
+	film3 := createFilm(t, storages.Films, "Title_3", 9, nil)
+
+	minRating := domain.FilmRating(5)
+	maxRating := domain.FilmRating(7)
+	releaseDate := domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+	laterReleaseDate := domain.FilmReleaseDate(time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC))
+	actorSex := domain.ActorSex(1)
+	otherActorSex := domain.ActorSex(2)
+
+	testCases := []struct {
+		name    string
+		filter  *domain.FilmFilter
+		filmIds []domain.FilmId
+	}{
+		{name: "any actor", filter: &domain.FilmFilter{ActorIds: []domain.ActorId{actor1.Id, actor2.Id}, ActorsMatch: domain.FilmActorsMatchAny}, filmIds: []domain.FilmId{film2.Id, film1.Id}},
+		{name: "all actors", filter: &domain.FilmFilter{ActorIds: []domain.ActorId{actor1.Id, actor2.Id}, ActorsMatch: domain.FilmActorsMatchAll}, filmIds: []domain.FilmId{film1.Id}},
+		{name: "without actors", filter: &domain.FilmFilter{WithoutActors: true}, filmIds: []domain.FilmId{film3.Id}},
+		{name: "rating range", filter: &domain.FilmFilter{MinRating: &minRating, MaxRating: &maxRating}, filmIds: []domain.FilmId{film2.Id, film1.Id}},
+		{name: "release date range", filter: &domain.FilmFilter{ReleasedFrom: &releaseDate, ReleasedTo: &releaseDate}, filmIds: []domain.FilmId{film3.Id, film2.Id, film1.Id}},
+		{name: "later release date", filter: &domain.FilmFilter{ReleasedFrom: &laterReleaseDate}, filmIds: []domain.FilmId{}},
+		{name: "actor sex", filter: &domain.FilmFilter{ActorSex: &actorSex}, filmIds: []domain.FilmId{film2.Id, film1.Id}},
+		{name: "other actor sex", filter: &domain.FilmFilter{ActorSex: &otherActorSex}, filmIds: []domain.FilmId{}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			films, _, err := storages.Films.List(ctx, testCase.filter, nil, nil, 10, 0)
+			require.NoError(t, err)
+			require.Equal(t, testCase.filmIds, filmIds(films))
+
+			count, err := storages.Films.Count(ctx, testCase.filter)
+			require.NoError(t, err)
+			require.Equal(t, int64(len(testCase.filmIds)), count)
+		})
+	}
+}
+
+func testFilmListSearchesByQuery(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	releaseDate := domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	film1, err := storages.Films.Create(ctx, "Matrix", "Hackers", releaseDate, 5, nil, nil, nil)
+	require.NoError(t, err)
+	film2, err := storages.Films.Create(ctx, "Hackers", "About the matrix", releaseDate, 9, nil, nil, nil)
+	require.NoError(t, err)
+	film3, err := storages.Films.Create(ctx, "Casablanca", "Romance", releaseDate, 7, nil, nil, nil)
+	require.NoError(t, err)
+
+	// Films found by the title go before the films found by the description, whatever their rating is
+	films, _, err := storages.Films.List(ctx, &domain.FilmFilter{Query: "matrix"}, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film1.Id, film2.Id}, filmIds(films))
+	require.NotNil(t, films[0].Match)
+	require.NotNil(t, films[1].Match)
+	require.Greater(t, films[0].Match.Relevance, films[1].Match.Relevance)
+	require.Equal(t, "<b>Matrix</b>", films[0].Match.TitleHighlight)
+
+	// Every word of the query must be found
+	films, _, err = storages.Films.List(ctx, &domain.FilmFilter{Query: "casablanca romance"}, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film3.Id}, filmIds(films))
+
+	// An explicit sort overrides the relevance
+	ratingDesc := domain.FilmSort{{Field: domain.FilmSortFieldRating, Direction: domain.SortDirectionDesc}}
+
+	films, _, err = storages.Films.List(ctx, &domain.FilmFilter{Query: "matrix"}, ratingDesc, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film2.Id, film1.Id}, filmIds(films))
+
+	count, err := storages.Films.Count(ctx, &domain.FilmFilter{Query: "matrix"})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+}
+
+func testFilmBatchChecksVersion(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	film1 := createFilm(t, storages.Films, "Title_1", 5, nil)
+	film2 := createFilm(t, storages.Films, "Title_2", 5, nil)
+
+	title := domain.FilmTitle("Title_3")
+	staleVersion := film1.Version + 1
+
+	items, committed, err := storages.Films.Batch(ctx, domain.BatchModeBestEffort, []*domain.FilmBatchOperation{
+		{Type: domain.BatchOperationUpdate, Id: film1.Id, Version: &staleVersion, Update: &domain.FilmUpdateParams{Title: &title}},
+		{Type: domain.BatchOperationDelete, Id: film2.Id, Version: &staleVersion},
+		{Type: domain.BatchOperationUpdate, Id: film1.Id, Version: &film1.Version, Update: &domain.FilmUpdateParams{Title: &title}},
+		{Type: domain.BatchOperationDelete, Id: film2.Id, Version: &film2.Version},
+	}, nil)
+	require.NoError(t, err)
+	require.True(t, committed)
+	require.Len(t, items, 4)
+
+	require.Equal(t, domain.BatchItemFailed, items[0].Status)
+	require.ErrorIs(t, items[0].Err, storage.ErrFilmVersionMismatch)
+	require.Equal(t, domain.BatchItemFailed, items[1].Status)
+	require.ErrorIs(t, items[1].Err, storage.ErrFilmVersionMismatch)
+
+	require.Equal(t, domain.BatchItemSucceeded, items[2].Status)
+	require.Equal(t, title, items[2].Film.Title)
+	require.Equal(t, film1.Version+1, items[2].Film.Version)
+	require.Equal(t, domain.BatchItemSucceeded, items[3].Status)
+
+	// Listed films have their versions, so that they can be used in the 'If-Match' header and batch operations
+	films, _, err := storages.Films.List(ctx, nil, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film1.Id}, filmIds(films))
+	require.Equal(t, items[2].Film.Version, films[0].Version)
+
+	exists, err := storages.Films.IsExists(ctx, film2.Id)
+	require.NoError(t, err)
+	require.False(t, exists)
+}
+
+func testFilmBatchTransactionalRollsBackAll(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	film := createFilm(t, storages.Films, "Title_1", 5, nil)
+	title := domain.FilmTitle("Title_2")
+
+	items, committed, err := storages.Films.Batch(ctx, domain.BatchModeTransactional, []*domain.FilmBatchOperation{
+		{Type: domain.BatchOperationCreate, Create: &domain.FilmCreateParams{Title: "Title_3", Rating: 7}},
+		{Type: domain.BatchOperationUpdate, Id: film.Id, Update: &domain.FilmUpdateParams{Title: &title}},
+		{Type: domain.BatchOperationDelete, Id: film.Id + 100},
+	}, nil)
+	require.NoError(t, err)
+	require.False(t, committed)
+	require.Len(t, items, 3)
+
+	require.Equal(t, domain.BatchItemAborted, items[0].Status)
+	require.Nil(t, items[0].Film)
+	require.Equal(t, domain.BatchItemAborted, items[1].Status)
+	require.Equal(t, domain.BatchItemFailed, items[2].Status)
+	require.ErrorIs(t, items[2].Err, storage.ErrFilmNotFound)
+
+	films, _, err := storages.Films.List(ctx, nil, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film.Id}, filmIds(films))
+	require.Equal(t, film.Title, films[0].Title)
+}
+
+func testFilmBatchBestEffortAppliesSucceeded(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	actor := createActor(t, storages.Actors, "Actor_1")
+	film1 := createFilm(t, storages.Films, "Title_1", 5, nil)
+	film2 := createFilm(t, storages.Films, "Title_2", 6, nil)
+
+	rating := domain.FilmRating(9)
+	credits := domain.NewActorCredits([]domain.ActorId{actor.Id})
+
+	items, committed, err := storages.Films.Batch(ctx, domain.BatchModeBestEffort, []*domain.FilmBatchOperation{
+		{Type: domain.BatchOperationCreate, Create: &domain.FilmCreateParams{Title: "Title_3", Rating: 7, Credits: credits}},
+		{Type: domain.BatchOperationUpdate, Id: film2.Id + 100, Update: &domain.FilmUpdateParams{Rating: &rating}},
+		{Type: domain.BatchOperationUpdate, Id: film1.Id, Update: &domain.FilmUpdateParams{Rating: &rating}},
+		{Type: domain.BatchOperationDelete, Id: film2.Id},
+	}, nil)
+	require.NoError(t, err)
+	require.True(t, committed)
+	require.Len(t, items, 4)
+
+	require.Equal(t, domain.BatchItemSucceeded, items[0].Status)
+	require.Equal(t, domain.FilmTitle("Title_3"), items[0].Film.Title)
+	require.Equal(t, []domain.ActorId{actor.Id}, actorIds(items[0].Film.Actors))
+
+	require.Equal(t, domain.BatchItemFailed, items[1].Status)
+	require.ErrorIs(t, items[1].Err, storage.ErrFilmNotFound)
+
+	require.Equal(t, domain.BatchItemSucceeded, items[2].Status)
+	require.Equal(t, rating, items[2].Film.Rating)
+
+	require.Equal(t, domain.BatchItemSucceeded, items[3].Status)
+
+	films, _, err := storages.Films.List(ctx, nil, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []domain.FilmId{film1.Id, items[0].Film.Id}, filmIds(films))
+}
+
+func testFilmListIncludesFilmsWithoutActors(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	actor := createActor(t, storages.Actors, "Actor_1")
+	deletedActor := createActor(t, storages.Actors, "Actor_2")
+
+	filmWithActor := createFilm(t, storages.Films, "Title_1", 5, []domain.ActorId{actor.Id})
+	filmWithoutActors := createFilm(t, storages.Films, "Title_2", 7, nil)
+	filmWithDeletedActor := createFilm(t, storages.Films, "Title_3", 3, []domain.ActorId{deletedActor.Id})
+
+	require.NoError(t, storages.Actors.Delete(ctx, deletedActor.Id, nil, nil))
+
+	films, _, err := storages.Films.List(ctx, nil, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{filmWithoutActors.Id, filmWithActor.Id, filmWithDeletedActor.Id}, filmIds(films))
+
+	require.Empty(t, films[0].Actors)
+	require.Equal(t, []domain.ActorId{actor.Id}, actorIds(films[1].Actors))
+	require.Empty(t, films[2].Actors)
+}
+
+func testFilmListFollowsSortKeysOrder(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	actor1 := createActor(t, storages.Actors, "Actor_1")
+	actor2 := createActor(t, storages.Actors, "Actor_2")
+
+	film1 := createFilm(t, storages.Films, "Title_1", 5, []domain.ActorId{actor1.Id})
+	film2 := createFilm(t, storages.Films, "Title_2", 7, []domain.ActorId{actor1.Id, actor2.Id})
+	film3 := createFilm(t, storages.Films, "Title_3", 7, nil)
+	film4 := createFilm(t, storages.Films, "Title_4", 5, []domain.ActorId{actor2.Id})
+
+	sort := domain.FilmSort{
+		{Field: domain.FilmSortFieldActorsCount, Direction: domain.SortDirectionDesc},
+		{Field: domain.FilmSortFieldRating, Direction: domain.SortDirectionAsc},
+		{Field: domain.FilmSortFieldId, Direction: domain.SortDirectionDesc},
+	}
+
+	films, nextCursor, err := storages.Films.List(ctx, nil, sort, nil, 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film2.Id, film4.Id}, filmIds(films))
+	require.NotNil(t, nextCursor)
+
+	films, nextCursor, err = storages.Films.List(ctx, nil, sort, nextCursor, 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film1.Id, film3.Id}, filmIds(films))
+	require.Nil(t, nextCursor)
+
+	sort = domain.FilmSort{
+		{Field: domain.FilmSortFieldRating, Direction: domain.SortDirectionAsc},
+		{Field: domain.FilmSortFieldActorsCount, Direction: domain.SortDirectionDesc},
+	}
+
+	films, _, err = storages.Films.List(ctx, nil, sort, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film1.Id, film4.Id, film2.Id, film3.Id}, filmIds(films))
+}
+
+func testFilmListWithFilterIncludesFilmsWithoutActors(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	actor := createActor(t, storages.Actors, "Actor_1")
+
+	filmWithActor := createFilm(t, storages.Films, "Title_1", 5, []domain.ActorId{actor.Id})
+	filmWithoutActors := createFilm(t, storages.Films, "Title_2", 7, nil)
+	createFilm(t, storages.Films, "Other", 7, nil)
+
+	films, _, err := storages.Films.List(ctx, &domain.FilmFilter{Title: "Title"}, filmIdAsc, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{filmWithActor.Id, filmWithoutActors.Id}, filmIds(films))
+	require.Len(t, films[0].Actors, 1)
+	require.Empty(t, films[1].Actors)
+
+	count, err := storages.Films.Count(ctx, &domain.FilmFilter{Title: "Title"})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	films, _, err = storages.Films.List(ctx, &domain.FilmFilter{Title: "Title", ActorName: "Actor"}, filmIdAsc, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{filmWithActor.Id}, filmIds(films))
+
+	count, err = storages.Films.Count(ctx, &domain.FilmFilter{Title: "Title", ActorName: "Actor"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+}
+
+func testFilmListWithFilterRespectsLimitOffset(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	actor := createActor(t, storages.Actors, "Actor_1")
+
+	film1 := createFilm(t, storages.Films, "Title_1", 5, []domain.ActorId{actor.Id})
+	film2 := createFilm(t, storages.Films, "Title_2", 5, nil)
+	film3 := createFilm(t, storages.Films, "Title_3", 5, []domain.ActorId{actor.Id})
+
+	films, nextCursor, err := storages.Films.List(ctx, &domain.FilmFilter{Title: "Title"}, filmIdAsc, nil, 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film1.Id, film2.Id}, filmIds(films))
+	require.NotNil(t, nextCursor)
+
+	films, nextCursor, err = storages.Films.List(ctx, &domain.FilmFilter{Title: "Title"}, filmIdAsc, nextCursor, 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film3.Id}, filmIds(films))
+	require.Nil(t, nextCursor)
+
+	films, _, err = storages.Films.List(ctx, &domain.FilmFilter{Title: "Title"}, filmIdAsc, nil, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film2.Id}, filmIds(films))
+	require.Empty(t, films[0].Actors)
+}
+
+func testFilmListSearchesByQueryWithTyposAndCredits(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	releaseDate := domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	actor := createActor(t, storages.Actors, "Keanu Reeves")
+
+	matrix, err := storages.Films.Create(ctx, "The Matrix", "A hacker learns the truth about his reality", releaseDate, 9, domain.NewActorCredits([]domain.ActorId{actor.Id}), nil, nil)
+	require.NoError(t, err)
+	documentary, err := storages.Films.Create(ctx, "Documentary", "How the matrix was filmed", releaseDate, 5, nil, nil, nil)
+	require.NoError(t, err)
+	createFilm(t, storages.Films, "Heat", 8, nil)
+
+	// Titles weigh more than descriptions, case does not matter
+	films, nextCursor, err := storages.Films.List(ctx, &domain.FilmFilter{Query: "matrix"}, nil, nil, 1, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{matrix.Id}, filmIds(films))
+	require.NotNil(t, films[0].Match)
+	require.Contains(t, films[0].Match.TitleHighlight, "<b>Matrix</b>")
+	require.NotNil(t, nextCursor)
+
+	films, nextCursor, err = storages.Films.List(ctx, &domain.FilmFilter{Query: "matrix"}, nil, nextCursor, 1, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{documentary.Id}, filmIds(films))
+	require.Contains(t, films[0].Match.DescriptionHighlight, "<b>matrix</b>")
+	require.Nil(t, nextCursor)
+
+	// Typos are tolerated
+	films, _, err = storages.Films.List(ctx, &domain.FilmFilter{Query: "matrx"}, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{matrix.Id}, filmIds(films))
+
+	// Names of credits are searched and kept up to date when an actor is renamed
+	films, _, err = storages.Films.List(ctx, &domain.FilmFilter{Query: "keanu"}, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{matrix.Id}, filmIds(films))
+
+	name := domain.ActorName("Carrie-Anne Moss")
+	_, err = storages.Actors.Update(ctx, actor.Id, nil, &name, nil, nil, nil)
+	require.NoError(t, err)
+
+	films, _, err = storages.Films.List(ctx, &domain.FilmFilter{Query: "moss"}, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{matrix.Id}, filmIds(films))
+
+	// Fragment filters are case-insensitive
+	films, _, err = storages.Films.List(ctx, &domain.FilmFilter{Title: "the matrix"}, filmIdAsc, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{matrix.Id}, filmIds(films))
+	require.Nil(t, films[0].Match)
+}
+
+func testFilmGenres(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	drama, err := storages.Genres.Create(ctx, "Drama")
+	require.NoError(t, err)
+	comedy, err := storages.Genres.Create(ctx, "Comedy")
+	require.NoError(t, err)
+
+	film1, err := storages.Films.Create(ctx, "Title_1", "Description", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, nil, []domain.GenreId{drama.Id, comedy.Id}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []*domain.Genre{comedy, drama}, film1.Genres)
+
+	film2 := createFilm(t, storages.Films, "Title_2", 7, nil)
+
+	films, _, err := storages.Films.List(ctx, &domain.FilmFilter{GenreId: &drama.Id}, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film1.Id}, filmIds(films))
+	require.Equal(t, []*domain.Genre{comedy, drama}, films[0].Genres)
+
+	count, err := storages.Films.Count(ctx, &domain.FilmFilter{GenreId: &drama.Id})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	films, _, err = storages.Films.List(ctx, &domain.FilmFilter{Title: "Title", GenreId: &comedy.Id}, filmIdAsc, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film1.Id}, filmIds(films))
+
+	count, err = storages.Films.Count(ctx, &domain.FilmFilter{Title: "Title", GenreId: &comedy.Id})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	genreIds := []domain.GenreId{comedy.Id}
+	updatedFilm, err := storages.Films.Update(ctx, film2.Id, nil, nil, nil, nil, nil, nil, nil, &genreIds, nil)
+	require.NoError(t, err)
+	require.Equal(t, []*domain.Genre{comedy}, updatedFilm.Genres)
+}
+
+func testFilmCreditsAndDirectorFilter(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	actor := createActor(t, storages.Actors, "Actor_1")
+	director := createActor(t, storages.Actors, "Director_1")
+
+	credits := []*domain.FilmCreditParams{
+		{ActorId: director.Id, Role: domain.CreditRoleDirector},
+		{ActorId: actor.Id, Role: domain.CreditRoleActor, CharacterName: "Hero", BillingOrder: 1},
+	}
+	film, err := storages.Films.Create(ctx, "Title_1", "Description", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, credits, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, film.Credits, 2)
+	require.Equal(t, director.Id, film.Credits[0].Actor.Id)
+	require.Equal(t, domain.CreditRoleDirector, film.Credits[0].Role)
+	require.Equal(t, domain.CharacterName("Hero"), film.Credits[1].CharacterName)
+	require.Equal(t, []domain.ActorId{actor.Id}, actorIds(film.Actors))
+
+	createFilm(t, storages.Films, "Title_2", 7, []domain.ActorId{director.Id})
+
+	// A director is found only by the films they directed
+	films, _, err := storages.Films.List(ctx, &domain.FilmFilter{DirectorName: "Director"}, filmIdAsc, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film.Id}, filmIds(films))
+
+	count, err := storages.Films.Count(ctx, &domain.FilmFilter{DirectorName: "Director"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+}
+
+func testFilmCreateAndUpdateRecordAuthors(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	// Users seeded by migrations: 1 - user@example.com, 2 - admin@example.com
+	userId, adminId := int64(1), int64(2)
+
+	film, err := storages.Films.Create(ctx, "Title_1", "Description", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, nil, nil, &adminId)
+	require.NoError(t, err)
+	require.Equal(t, &adminId, film.CreatedBy)
+	require.Nil(t, film.UpdatedBy)
+
+	title := domain.FilmTitle("Title_2")
+
+	updatedFilm, err := storages.Films.Update(ctx, film.Id, nil, &title, nil, nil, nil, nil, nil, nil, &userId)
+	require.NoError(t, err)
+	require.Equal(t, &adminId, updatedFilm.CreatedBy)
+	require.Equal(t, &userId, updatedFilm.UpdatedBy)
+
+	gotFilm := mustGetFilm(t, storages.Films, film.Id)
+	require.Equal(t, &adminId, gotFilm.CreatedBy)
+	require.Equal(t, &userId, gotFilm.UpdatedBy)
+}
+
+func testFilmConcurrentUpdatesWithSameVersion(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	film := createFilm(t, storages.Films, "Title_1", 5, nil)
+
+	const writers = 5
+
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		go func(rating domain.FilmRating) {
+			_, err := storages.Films.Update(ctx, film.Id, &film.Version, nil, nil, nil, &rating, nil, nil, nil, nil)
+			errs <- err
+		}(domain.FilmRating(i + 1))
+	}
+
+	var succeeded int
+	for i := 0; i < writers; i++ {
+		if err := <-errs; err == nil {
+			succeeded++
+		} else {
+			require.ErrorIs(t, err, storage.ErrFilmVersionMismatch)
+		}
+	}
+	require.Equal(t, 1, succeeded)
+
+	require.Equal(t, film.Version+1, mustGetFilm(t, storages.Films, film.Id).Version)
+}
+
+func testRestoredActorIsBackInFilms(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	deletedActor := createActor(t, storages.Actors, "Keanu")
+	otherActor := createActor(t, storages.Actors, "Actor_2")
+	film := createFilm(t, storages.Films, "Title_1", 5, []domain.ActorId{deletedActor.Id})
+
+	require.NoError(t, storages.Actors.Delete(ctx, deletedActor.Id, nil, nil))
+
+	films, _, err := storages.Films.List(ctx, &domain.FilmFilter{ActorName: "Keanu"}, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Empty(t, films)
+
+	films, _, err = storages.Films.List(ctx, &domain.FilmFilter{WithoutActors: true}, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film.Id}, filmIds(films))
+
+	// Replacing the actors of the film keeps the credit of the deleted actor
+	newActorIds := []domain.ActorId{otherActor.Id}
+	updatedFilm, err := storages.Films.Update(ctx, film.Id, nil, nil, nil, nil, nil, &newActorIds, nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, newActorIds, actorIds(updatedFilm.Actors))
+
+	_, err = storages.Actors.Restore(ctx, deletedActor.Id, nil)
+	require.NoError(t, err)
+
+	require.ElementsMatch(t, []domain.ActorId{deletedActor.Id, otherActor.Id}, actorIds(mustGetFilm(t, storages.Films, film.Id).Actors))
+
+	films, _, err = storages.Films.List(ctx, &domain.FilmFilter{ActorName: "Keanu"}, nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film.Id}, filmIds(films))
+}
+
+func testFilmExportStreamsFilmsWithActors(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	actor1 := createActor(t, storages.Actors, "Actor_1")
+	actor2 := createActor(t, storages.Actors, "Actor_2")
+	actor3 := createActor(t, storages.Actors, "Actor_3")
+
+	firstFilm := createFilm(t, storages.Films, "Title_1", 9, nil)
+	filmWithActors := createFilm(t, storages.Films, "Title_2", 5, []domain.ActorId{actor3.Id, actor1.Id, actor2.Id})
+	deletedFilm := createFilm(t, storages.Films, "Title_3", 5, nil)
+	lastFilm := createFilm(t, storages.Films, "Title_4", 1, nil)
+
+	require.NoError(t, storages.Films.Delete(ctx, deletedFilm.Id, nil, nil))
+
+	var films []*domain.Film
+
+	err := storages.Films.Export(ctx, func(film *domain.Film) error {
+		films = append(films, film)
+		return nil
+	})
+	require.NoError(t, err)
+
+	// Films are exported in the order of their ids, actors in the order of their billing
+	require.Equal(t, []domain.FilmId{firstFilm.Id, filmWithActors.Id, lastFilm.Id}, filmIds(films))
+	require.Empty(t, films[0].Actors)
+	require.Equal(t, []domain.ActorId{actor3.Id, actor1.Id, actor2.Id}, actorIds(films[1].Actors))
+	require.Empty(t, films[2].Actors)
+}
+
+func testFilmExportStopsAtCallbackError(t *testing.T, storages *Storages) {
+	createFilm(t, storages.Films, "Title_1", 5, nil)
+	createFilm(t, storages.Films, "Title_2", 5, nil)
+
+	writeErr := errors.New("broken pipe")
+
+	var exported int
+
+	err := storages.Films.Export(context.Background(), func(film *domain.Film) error {
+		exported++
+		return writeErr
+	})
+	require.ErrorIs(t, err, writeErr)
+	require.Equal(t, 1, exported)
+}
+
+func mustGetFilm(t *testing.T, filmStorage domain.FilmStorage, id domain.FilmId) *domain.Film {
+	t.Helper()
+
+	film, err := filmStorage.GetById(context.Background(), id)
+	require.NoError(t, err)
+
+	return film
+}
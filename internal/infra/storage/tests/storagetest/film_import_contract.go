@@ -0,0 +1,124 @@
+package storagetest
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"testing"
+	"time"
+)
+
+var filmImportContractTests = []contractTest{
+	{name: "FilmImportMatchesActors", run: testFilmImportMatchesActors},
+	{name: "FilmImportWithoutCommit", run: testFilmImportWithoutCommit},
+	{name: "FilmImportWithRowErrorRollsBack", run: testFilmImportWithRowErrorRollsBack},
+}
+
+func testFilmImportMatchesActors(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	existingActor, err := storages.Actors.Create(ctx, "Actor_1", domain.ActorSex(1), birthDate(1980), nil)
+	require.NoError(t, err)
+
+	rows := []*domain.FilmImportRow{
+		newFilmImportRow(2, "Title_1", nil, importActor("Actor_1", 1980), importActor("Actor_2", 1990)),
+		// Actor_2 is created by the previous row and must be matched, not created once more
+		newFilmImportRow(3, "Title_2", nil, importActor("Actor_2", 1990)),
+		// An actor with the same name, but another birth date is another actor
+		newFilmImportRow(4, "Title_3", nil, importActor("Actor_1", 1970)),
+	}
+
+	report, err := storages.FilmImport.Import(ctx, rows, true, nil)
+	require.NoError(t, err)
+	require.Empty(t, report.Errors)
+	require.True(t, report.Committed)
+	require.Equal(t, 3, report.Films)
+	require.Len(t, report.FilmIds, 3)
+	require.Equal(t, 2, report.CreatedActors)
+	require.Equal(t, 2, report.MatchedActors)
+
+	film := mustGetFilm(t, storages.Films, report.FilmIds[0])
+	require.Equal(t, domain.FilmTitle("Title_1"), film.Title)
+	require.Len(t, film.Actors, 2)
+	require.Equal(t, existingActor.Id, film.Actors[0].Id)
+
+	secondFilm := mustGetFilm(t, storages.Films, report.FilmIds[1])
+	require.Equal(t, film.Actors[1].Id, secondFilm.Actors[0].Id)
+
+	actorsCount, err := storages.Actors.Count(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), actorsCount)
+}
+
+func testFilmImportWithoutCommit(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	rows := []*domain.FilmImportRow{
+		newFilmImportRow(1, "Title_1", nil, importActor("Actor_1", 1980)),
+	}
+
+	report, err := storages.FilmImport.Import(ctx, rows, false, nil)
+	require.NoError(t, err)
+	require.Empty(t, report.Errors)
+	require.False(t, report.Committed)
+	require.Equal(t, 1, report.Films)
+	require.Equal(t, 1, report.CreatedActors)
+	require.Empty(t, report.FilmIds)
+
+	filmsCount, err := storages.Films.Count(ctx, nil)
+	require.NoError(t, err)
+	require.Zero(t, filmsCount)
+
+	actorsCount, err := storages.Actors.Count(ctx, nil)
+	require.NoError(t, err)
+	require.Zero(t, actorsCount)
+}
+
+func testFilmImportWithRowErrorRollsBack(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	rows := []*domain.FilmImportRow{
+		newFilmImportRow(1, "Title_1", nil, importActor("Actor_1", 1980)),
+		newFilmImportRow(2, "Title_2", []domain.GenreId{100}, importActor("Actor_2", 1980)),
+		newFilmImportRow(3, "Title_3", nil, importActor("Actor_3", 1980)),
+	}
+
+	report, err := storages.FilmImport.Import(ctx, rows, true, nil)
+	require.NoError(t, err)
+	require.False(t, report.Committed)
+	require.Len(t, report.Errors, 1)
+	require.Equal(t, 2, report.Errors[0].Line)
+	require.Equal(t, 2, report.Films)
+
+	filmsCount, err := storages.Films.Count(ctx, nil)
+	require.NoError(t, err)
+	require.Zero(t, filmsCount)
+
+	actorsCount, err := storages.Actors.Count(ctx, nil)
+	require.NoError(t, err)
+	require.Zero(t, actorsCount)
+}
+
+func newFilmImportRow(line int, title domain.FilmTitle, genreIds []domain.GenreId, actors ...*domain.FilmImportActor) *domain.FilmImportRow {
+	return &domain.FilmImportRow{
+		Line:        line,
+		Title:       title,
+		Description: "Description",
+		ReleaseDate: domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)),
+		Rating:      domain.FilmRating(5),
+		Actors:      actors,
+		GenreIds:    genreIds,
+	}
+}
+
+func importActor(name domain.ActorName, birthYear int) *domain.FilmImportActor {
+	return &domain.FilmImportActor{
+		Name:      name,
+		Sex:       domain.ActorSex(1),
+		BirthDate: birthDate(birthYear),
+	}
+}
+
+func birthDate(year int) domain.ActorBirthDate {
+	return domain.ActorBirthDate(time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC))
+}
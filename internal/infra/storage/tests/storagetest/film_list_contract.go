@@ -0,0 +1,67 @@
+package storagetest
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"testing"
+)
+
+var filmListContractTests = []contractTest{
+	{name: "FilmListsOfUsers", run: testFilmListsOfUsers},
+}
+
+func testFilmListsOfUsers(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	// Users seeded by migrations: 1 - user@example.com, 2 - admin@example.com
+	userId, adminId := int64(1), int64(2)
+
+	film1 := createFilm(t, storages.Films, "Title_1", 5, nil)
+	film2 := createFilm(t, storages.Films, "Title_2", 5, nil)
+	film3 := createFilm(t, storages.Films, "Title_3", 5, nil)
+
+	require.NoError(t, storages.FilmLists.Add(ctx, userId, domain.FilmListWatchlist, film1.Id))
+	require.NoError(t, storages.FilmLists.Add(ctx, userId, domain.FilmListWatchlist, film2.Id))
+	require.NoError(t, storages.FilmLists.Add(ctx, userId, domain.FilmListWatched, film2.Id))
+	require.NoError(t, storages.FilmLists.Add(ctx, adminId, domain.FilmListWatchlist, film3.Id))
+
+	// Adding a film that is already in the list does nothing
+	require.NoError(t, storages.FilmLists.Add(ctx, userId, domain.FilmListWatchlist, film1.Id))
+
+	err := storages.FilmLists.Add(ctx, userId, domain.FilmListWatchlist, film3.Id+100)
+	require.ErrorIs(t, err, storage.ErrFilmNotFound)
+
+	sort := domain.FilmSort{{Field: domain.FilmSortFieldTitle, Direction: domain.SortDirectionAsc}}
+
+	films, nextCursor, err := storages.FilmLists.ListFilms(ctx, userId, domain.FilmListWatchlist, sort, nil, 1, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film1.Id}, filmIds(films))
+
+	films, _, err = storages.FilmLists.ListFilms(ctx, userId, domain.FilmListWatchlist, sort, nextCursor, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film2.Id}, filmIds(films))
+
+	count, err := storages.FilmLists.CountFilms(ctx, userId, domain.FilmListWatchlist)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	filmsLists, err := storages.FilmLists.GetFilmsLists(ctx, userId, []domain.FilmId{film1.Id, film2.Id, film3.Id})
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmList{domain.FilmListWatchlist}, filmsLists[film1.Id])
+	require.ElementsMatch(t, []domain.FilmList{domain.FilmListWatchlist, domain.FilmListWatched}, filmsLists[film2.Id])
+	require.Empty(t, filmsLists[film3.Id])
+
+	require.NoError(t, storages.FilmLists.Remove(ctx, userId, domain.FilmListWatchlist, film1.Id))
+
+	err = storages.FilmLists.Remove(ctx, userId, domain.FilmListWatchlist, film1.Id)
+	require.ErrorIs(t, err, storage.ErrFilmListItemNotFound)
+
+	// Deleted films are not listed
+	require.NoError(t, storages.Films.Delete(ctx, film2.Id, nil, nil))
+
+	count, err = storages.FilmLists.CountFilms(ctx, userId, domain.FilmListWatchlist)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
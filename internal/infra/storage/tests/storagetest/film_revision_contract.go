@@ -0,0 +1,118 @@
+package storagetest
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"testing"
+	"time"
+)
+
+var filmRevisionContractTests = []contractTest{
+	{name: "FilmCreateAndUpdateRecordRevisions", run: testFilmCreateAndUpdateRecordRevisions},
+	{name: "FilmRevisionNotFound", run: testFilmRevisionNotFound},
+	{name: "FilmFailedUpdateRecordsNoRevision", run: testFilmFailedUpdateRecordsNoRevision},
+	{name: "FilmRestoredKeepsRevisionOfVersion", run: testFilmRestoredKeepsRevisionOfVersion},
+}
+
+func testFilmCreateAndUpdateRecordRevisions(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	// Users seeded by migrations: 1 - user@example.com, 2 - admin@example.com
+	userId, adminId := int64(1), int64(2)
+
+	actor := createActor(t, storages.Actors, "Actor_1")
+	genre, err := storages.Genres.Create(ctx, "Genre_1")
+	require.NoError(t, err)
+
+	credits := []*domain.FilmCreditParams{
+		{ActorId: actor.Id, Role: domain.CreditRoleActor, CharacterName: "Character_1", BillingOrder: 0},
+	}
+
+	film, err := storages.Films.Create(ctx, "Title_1", "Description_1", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), 5, credits, []domain.GenreId{genre.Id}, &userId)
+	require.NoError(t, err)
+
+	title := domain.FilmTitle("Title_2")
+	rating := domain.FilmRating(7)
+	emptyCredits := []*domain.FilmCreditParams{}
+
+	film, err = storages.Films.Update(ctx, film.Id, nil, &title, nil, nil, &rating, nil, &emptyCredits, nil, &adminId)
+	require.NoError(t, err)
+
+	revisions, err := storages.FilmRevisions.List(ctx, film.Id, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+
+	// The latest revision comes first and is numbered by the version of the film
+	require.Equal(t, film.Version, revisions[0].Revision)
+	require.Equal(t, &adminId, revisions[0].UserId)
+	require.Equal(t, title, revisions[0].Title)
+	require.Equal(t, rating, revisions[0].Rating)
+	require.Empty(t, revisions[0].Credits)
+	require.Equal(t, []domain.GenreId{genre.Id}, revisions[0].GenreIds)
+
+	require.Equal(t, int64(1), revisions[1].Revision)
+	require.Equal(t, &userId, revisions[1].UserId)
+	require.Equal(t, domain.FilmTitle("Title_1"), revisions[1].Title)
+	require.Equal(t, domain.FilmDescription("Description_1"), revisions[1].Description)
+	require.Equal(t, credits, revisions[1].Credits)
+
+	count, err := storages.FilmRevisions.Count(ctx, film.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	revision, err := storages.FilmRevisions.Get(ctx, film.Id, 1)
+	require.NoError(t, err)
+	require.Equal(t, revisions[1], revision)
+}
+
+func testFilmRevisionNotFound(t *testing.T, storages *Storages) {
+	film := createFilm(t, storages.Films, "Title_1", 5, nil)
+
+	_, err := storages.FilmRevisions.Get(context.Background(), film.Id, 2)
+	require.ErrorIs(t, err, storage.ErrFilmRevisionNotFound)
+}
+
+func testFilmFailedUpdateRecordsNoRevision(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	film := createFilm(t, storages.Films, "Title_1", 5, nil)
+
+	title := domain.FilmTitle("Title_2")
+	staleVersion := film.Version + 1
+
+	_, err := storages.Films.Update(ctx, film.Id, &staleVersion, &title, nil, nil, nil, nil, nil, nil, nil)
+	require.ErrorIs(t, err, storage.ErrFilmVersionMismatch)
+
+	count, err := storages.FilmRevisions.Count(ctx, film.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+}
+
+func testFilmRestoredKeepsRevisionOfVersion(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	film := createFilm(t, storages.Films, "Title_1", 5, nil)
+
+	title := domain.FilmTitle("Title_2")
+
+	film, err := storages.Films.Update(ctx, film.Id, nil, &title, nil, nil, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+
+	err = storages.Films.Delete(ctx, film.Id, &film.Version, nil)
+	require.NoError(t, err)
+
+	restoredFilm, err := storages.Films.Restore(ctx, film.Id, nil)
+	require.NoError(t, err)
+	require.Equal(t, film.Version, restoredFilm.Version)
+
+	// The revision of the current version is the one to diff against
+	revision, err := storages.FilmRevisions.Get(ctx, film.Id, restoredFilm.Version)
+	require.NoError(t, err)
+	require.Equal(t, title, revision.Title)
+
+	count, err := storages.FilmRevisions.Count(ctx, film.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+}
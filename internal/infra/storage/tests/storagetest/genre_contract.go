@@ -0,0 +1,41 @@
+package storagetest
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"testing"
+)
+
+var genreContractTests = []contractTest{
+	{name: "GenreNameIsUnique", run: testGenreNameIsUnique},
+}
+
+func testGenreNameIsUnique(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	drama, err := storages.Genres.Create(ctx, "Drama")
+	require.NoError(t, err)
+
+	_, err = storages.Genres.Create(ctx, "Drama")
+	require.ErrorIs(t, err, storage.ErrGenreAlreadyExists)
+
+	comedy, err := storages.Genres.Create(ctx, "Comedy")
+	require.NoError(t, err)
+
+	_, err = storages.Genres.Update(ctx, comedy.Id, "Drama")
+	require.ErrorIs(t, err, storage.ErrGenreAlreadyExists)
+
+	genres, err := storages.Genres.List(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []*domain.Genre{comedy, drama}, genres)
+
+	exist, err := storages.Genres.AreExists(ctx, []domain.GenreId{drama.Id, comedy.Id})
+	require.NoError(t, err)
+	require.True(t, exist)
+
+	exist, err = storages.Genres.AreExists(ctx, []domain.GenreId{drama.Id, comedy.Id + 100})
+	require.NoError(t, err)
+	require.False(t, exist)
+}
@@ -0,0 +1,78 @@
+package storagetest
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"testing"
+)
+
+var reviewContractTests = []contractTest{
+	{name: "ReviewsUpdateCommunityScore", run: testReviewsUpdateCommunityScore},
+}
+
+func testReviewsUpdateCommunityScore(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	// Users seeded by migrations: 1 - user@example.com, 2 - admin@example.com
+	userId, adminId := int64(1), int64(2)
+
+	film1 := createFilm(t, storages.Films, "Title_1", 5, nil)
+	film2 := createFilm(t, storages.Films, "Title_2", 5, nil)
+
+	userReview, err := storages.Reviews.Create(ctx, film1.Id, userId, 8, "Great film")
+	require.NoError(t, err)
+	require.Equal(t, domain.ReviewText("Great film"), userReview.Text)
+
+	_, err = storages.Reviews.Create(ctx, film1.Id, userId, 9, "")
+	require.ErrorIs(t, err, storage.ErrReviewAlreadyExists)
+
+	_, err = storages.Reviews.Create(ctx, film1.Id+100, userId, 9, "")
+	require.ErrorIs(t, err, storage.ErrFilmNotFound)
+
+	adminReview, err := storages.Reviews.Create(ctx, film1.Id, adminId, 3, "")
+	require.NoError(t, err)
+
+	_, err = storages.Reviews.Create(ctx, film2.Id, adminId, 7, "")
+	require.NoError(t, err)
+
+	gotFilm := mustGetFilm(t, storages.Films, film1.Id)
+	require.Equal(t, domain.CommunityScore(5.5), gotFilm.CommunityScore)
+	require.Equal(t, int64(2), gotFilm.VotesCount)
+
+	// Films with a higher community score go first
+	sort, err := domain.NewFilmSort(domain.FilmSortKey{Field: domain.FilmSortFieldCommunityScore, Direction: domain.SortDirectionDesc})
+	require.NoError(t, err)
+
+	films, nextCursor, err := storages.Films.List(ctx, nil, sort, nil, 1, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film2.Id}, filmIds(films))
+
+	films, _, err = storages.Films.List(ctx, nil, sort, nextCursor, 1, 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.FilmId{film1.Id}, filmIds(films))
+
+	rating := domain.ReviewRating(10)
+	emptyText := domain.ReviewText("")
+	updatedReview, err := storages.Reviews.Update(ctx, userReview.Id, &rating, &emptyText)
+	require.NoError(t, err)
+	require.Equal(t, rating, updatedReview.Rating)
+	require.Empty(t, updatedReview.Text)
+
+	require.NoError(t, storages.Reviews.Delete(ctx, adminReview.Id))
+	require.ErrorIs(t, storages.Reviews.Delete(ctx, adminReview.Id), storage.ErrReviewNotFound)
+
+	gotFilm = mustGetFilm(t, storages.Films, film1.Id)
+	require.Equal(t, domain.CommunityScore(10), gotFilm.CommunityScore)
+	require.Equal(t, int64(1), gotFilm.VotesCount)
+
+	reviews, err := storages.Reviews.ListByFilmId(ctx, film1.Id, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, reviews, 1)
+	require.Equal(t, userReview.Id, reviews[0].Id)
+
+	count, err := storages.Reviews.CountByFilmId(ctx, film1.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+}
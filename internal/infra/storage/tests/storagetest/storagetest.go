@@ -0,0 +1,94 @@
+// Package storagetest is the contract of the storages: the behaviour every storage implementation shares,
+// whatever keeps its data. Storage implementations run the contract from their own tests with Run.
+package storagetest
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/service/auth"
+	"github.com/vaberof/vk-internship-task/internal/service/user"
+	"testing"
+	"time"
+)
+
+// Storages are the storages under test. All of them keep their data in the same database,
+// which holds nothing but the seeded roles and users, like a freshly migrated database.
+type Storages struct {
+	Films          domain.FilmStorage
+	Actors         domain.ActorStorage
+	Genres         domain.GenreStorage
+	Users          user.UserStorage
+	RefreshTokens  auth.RefreshTokenStorage
+	Trash          domain.TrashStorage
+	FilmRevisions  domain.FilmRevisionStorage
+	ActorRevisions domain.ActorRevisionStorage
+	Audit          domain.AuditStorage
+	FilmImport     domain.FilmImportStorage
+	FilmLists      domain.FilmListStorage
+	Reviews        domain.ReviewStorage
+}
+
+// NewStorages creates storages over a fresh database, which lives until the test finishes
+type NewStorages func(t *testing.T) *Storages
+
+type contractTest struct {
+	name string
+	run  func(t *testing.T, storages *Storages)
+}
+
+// Run runs every test of the contract as a subtest with its own storages
+func Run(t *testing.T, newStorages NewStorages) {
+	var tests []contractTest
+	tests = append(tests, filmContractTests...)
+	tests = append(tests, actorContractTests...)
+	tests = append(tests, genreContractTests...)
+	tests = append(tests, userContractTests...)
+	tests = append(tests, trashContractTests...)
+	tests = append(tests, filmRevisionContractTests...)
+	tests = append(tests, actorRevisionContractTests...)
+	tests = append(tests, auditContractTests...)
+	tests = append(tests, filmImportContractTests...)
+	tests = append(tests, filmListContractTests...)
+	tests = append(tests, reviewContractTests...)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t, newStorages(t))
+		})
+	}
+}
+
+func createActor(t *testing.T, actorStorage domain.ActorStorage, name domain.ActorName) *domain.Actor {
+	t.Helper()
+
+	actor, err := actorStorage.Create(context.Background(), name, domain.ActorSex(1), domain.ActorBirthDate(time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)), nil)
+	require.NoError(t, err)
+
+	return actor
+}
+
+func createFilm(t *testing.T, filmStorage domain.FilmStorage, title domain.FilmTitle, rating domain.FilmRating, actorIds []domain.ActorId) *domain.Film {
+	t.Helper()
+
+	film, err := filmStorage.Create(context.Background(), title, "Description", domain.FilmReleaseDate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), rating, domain.NewActorCredits(actorIds), nil, nil)
+	require.NoError(t, err)
+
+	return film
+}
+
+func filmIds(films []*domain.Film) []domain.FilmId {
+	ids := make([]domain.FilmId, len(films))
+	for i, film := range films {
+		ids[i] = film.Id
+	}
+	return ids
+}
+
+func actorIds(actors []*domain.Actor) []domain.ActorId {
+	ids := make([]domain.ActorId, len(actors))
+	for i, actor := range actors {
+		ids[i] = actor.Id
+	}
+	return ids
+}
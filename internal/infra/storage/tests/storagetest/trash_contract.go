@@ -0,0 +1,98 @@
+package storagetest
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/domain"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"testing"
+	"time"
+)
+
+var trashContractTests = []contractTest{
+	{name: "TrashListsDeletedFilmsAndActors", run: testTrashListsDeletedFilmsAndActors},
+	{name: "TrashPurgeDeletesItemsDeletedBeforeCutoff", run: testTrashPurgeDeletesItemsDeletedBeforeCutoff},
+}
+
+func testTrashListsDeletedFilmsAndActors(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	// Users seeded by migrations: 1 - user@example.com, 2 - admin@example.com
+	adminId := int64(2)
+
+	actor := createActor(t, storages.Actors, "Actor_1")
+	film := createFilm(t, storages.Films, "Title_1", 5, []domain.ActorId{actor.Id})
+	createFilm(t, storages.Films, "Title_2", 5, nil)
+
+	require.NoError(t, storages.Films.Delete(ctx, film.Id, nil, &adminId))
+	require.NoError(t, storages.Actors.Delete(ctx, actor.Id, nil, &adminId))
+
+	items, err := storages.Trash.List(ctx, nil, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	// The most recently deleted items come first
+	require.Equal(t, domain.TrashEntityTypeActor, items[0].EntityType)
+	require.Equal(t, actor.Id.Int64(), items[0].EntityId)
+	require.Equal(t, "Actor_1", items[0].Name)
+	require.Equal(t, &adminId, items[0].DeletedBy)
+
+	require.Equal(t, domain.TrashEntityTypeFilm, items[1].EntityType)
+	require.Equal(t, film.Id.Int64(), items[1].EntityId)
+	require.Equal(t, "Title_1", items[1].Name)
+
+	entityType := domain.TrashEntityTypeFilm
+	filter := &domain.TrashFilter{EntityType: &entityType}
+
+	items, err = storages.Trash.List(ctx, filter, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, film.Id.Int64(), items[0].EntityId)
+
+	count, err := storages.Trash.Count(ctx, filter)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	_, err = storages.Films.Restore(ctx, film.Id, &adminId)
+	require.NoError(t, err)
+
+	count, err = storages.Trash.Count(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+}
+
+func testTrashPurgeDeletesItemsDeletedBeforeCutoff(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	actor := createActor(t, storages.Actors, "Actor_1")
+	deletedFilm := createFilm(t, storages.Films, "Title_1", 5, []domain.ActorId{actor.Id})
+	keptFilm := createFilm(t, storages.Films, "Title_2", 5, []domain.ActorId{actor.Id})
+
+	require.NoError(t, storages.Films.Delete(ctx, deletedFilm.Id, nil, nil))
+	require.NoError(t, storages.Actors.Delete(ctx, actor.Id, nil, nil))
+
+	// Nothing was deleted before an hour ago
+	purgedFilms, purgedActors, err := storages.Trash.Purge(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Zero(t, purgedFilms)
+	require.Zero(t, purgedActors)
+
+	purgedFilms, purgedActors, err = storages.Trash.Purge(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), purgedFilms)
+	require.Equal(t, int64(1), purgedActors)
+
+	_, err = storages.Films.Restore(ctx, deletedFilm.Id, nil)
+	require.ErrorIs(t, err, storage.ErrFilmNotFound)
+	_, err = storages.Actors.Restore(ctx, actor.Id, nil)
+	require.ErrorIs(t, err, storage.ErrActorNotFound)
+
+	count, err := storages.Trash.Count(ctx, nil)
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	// Films which are not deleted are kept
+	exists, err := storages.Films.IsExists(ctx, keptFilm.Id)
+	require.NoError(t, err)
+	require.True(t, exists)
+}
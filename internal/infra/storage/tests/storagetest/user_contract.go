@@ -0,0 +1,149 @@
+package storagetest
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"github.com/vaberof/vk-internship-task/internal/service/user"
+	"testing"
+	"time"
+)
+
+var userContractTests = []contractTest{
+	{name: "SeededUsersAndRolePermissions", run: testSeededUsersAndRolePermissions},
+	{name: "UserCreateAndFind", run: testUserCreateAndFind},
+	{name: "UserNotFound", run: testUserNotFound},
+	{name: "UserUpdateRoleAndDisable", run: testUserUpdateRoleAndDisable},
+	{name: "UserDisableAndUpdatePasswordRevokeRefreshTokens", run: testUserDisableAndUpdatePasswordRevokeRefreshTokens},
+}
+
+func testSeededUsersAndRolePermissions(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	users, err := storages.Users.List(ctx, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	require.Equal(t, "user@example.com", users[0].Email)
+	require.Equal(t, user.RoleUser, users[0].Role)
+	require.Equal(t, "admin@example.com", users[1].Email)
+	require.Equal(t, user.RoleAdmin, users[1].Role)
+
+	count, err := storages.Users.Count(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	rolePermissions, err := storages.Users.ListRolePermissions(ctx)
+	require.NoError(t, err)
+	require.True(t, rolePermissions.Has(user.RoleAdmin, user.PermissionUsersManage))
+	require.True(t, rolePermissions.Has(user.RoleEditor, user.PermissionFilmsWrite))
+	require.True(t, rolePermissions.Has(user.RoleUser, user.PermissionFilmsRead))
+	require.False(t, rolePermissions.Has(user.RoleUser, user.PermissionFilmsWrite))
+}
+
+func testUserCreateAndFind(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	createdUser, err := storages.Users.Create(ctx, "editor@example.com", "password_hash", user.RoleEditor)
+	require.NoError(t, err)
+	require.Equal(t, "editor@example.com", createdUser.Email)
+	require.Equal(t, user.RoleEditor, createdUser.Role)
+	require.False(t, createdUser.Disabled)
+
+	_, err = storages.Users.Create(ctx, "editor@example.com", "password_hash", user.RoleUser)
+	require.ErrorIs(t, err, storage.ErrUserAlreadyExists)
+
+	foundUser, err := storages.Users.FindById(ctx, createdUser.Id)
+	require.NoError(t, err)
+	require.Equal(t, createdUser.Email, foundUser.Email)
+	require.Equal(t, "password_hash", foundUser.Password)
+
+	foundUser, err = storages.Users.FindByEmail(ctx, "editor@example.com")
+	require.NoError(t, err)
+	require.Equal(t, createdUser.Id, foundUser.Id)
+
+	require.NoError(t, storages.Users.UpdatePassword(ctx, createdUser.Id, "new_password_hash"))
+
+	foundUser, err = storages.Users.FindById(ctx, createdUser.Id)
+	require.NoError(t, err)
+	require.Equal(t, "new_password_hash", foundUser.Password)
+}
+
+func testUserNotFound(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	const missingId = int64(1000)
+
+	_, err := storages.Users.FindById(ctx, missingId)
+	require.ErrorIs(t, err, storage.ErrUserNotFound)
+
+	_, err = storages.Users.FindByEmail(ctx, "missing@example.com")
+	require.ErrorIs(t, err, storage.ErrUserNotFound)
+
+	_, err = storages.Users.UpdateRole(ctx, missingId, user.RoleEditor)
+	require.ErrorIs(t, err, storage.ErrUserNotFound)
+
+	_, err = storages.Users.Disable(ctx, missingId)
+	require.ErrorIs(t, err, storage.ErrUserNotFound)
+
+	err = storages.Users.UpdatePassword(ctx, missingId, "password_hash")
+	require.ErrorIs(t, err, storage.ErrUserNotFound)
+}
+
+func testUserUpdateRoleAndDisable(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	createdUser, err := storages.Users.Create(ctx, "new@example.com", "password_hash", user.RoleUser)
+	require.NoError(t, err)
+
+	_, err = storages.Users.UpdateRole(ctx, createdUser.Id, user.UserRole("unknown"))
+	require.ErrorIs(t, err, storage.ErrRoleNotFound)
+
+	updatedUser, err := storages.Users.UpdateRole(ctx, createdUser.Id, user.RoleEditor)
+	require.NoError(t, err)
+	require.Equal(t, user.RoleEditor, updatedUser.Role)
+
+	disabledUser, err := storages.Users.Disable(ctx, createdUser.Id)
+	require.NoError(t, err)
+	require.True(t, disabledUser.Disabled)
+	require.Equal(t, user.RoleEditor, disabledUser.Role)
+
+	foundUser, err := storages.Users.FindById(ctx, createdUser.Id)
+	require.NoError(t, err)
+	require.True(t, foundUser.Disabled)
+}
+
+// testUserDisableAndUpdatePasswordRevokeRefreshTokens checks that the refresh tokens issued before a password change
+// or disabling cannot be used to refresh the tokens afterwards
+func testUserDisableAndUpdatePasswordRevokeRefreshTokens(t *testing.T, storages *Storages) {
+	ctx := context.Background()
+
+	createdUser, err := storages.Users.Create(ctx, "new@example.com", "password_hash", user.RoleUser)
+	require.NoError(t, err)
+	otherUser, err := storages.Users.Create(ctx, "other@example.com", "password_hash", user.RoleUser)
+	require.NoError(t, err)
+
+	expiresAt := time.Now().Add(time.Hour)
+
+	require.NoError(t, storages.RefreshTokens.Create(ctx, createdUser.Id, "token_hash_1", expiresAt))
+	require.NoError(t, storages.RefreshTokens.Create(ctx, createdUser.Id, "token_hash_2", expiresAt))
+	require.NoError(t, storages.RefreshTokens.Create(ctx, otherUser.Id, "other_token_hash", expiresAt))
+
+	require.NoError(t, storages.Users.UpdatePassword(ctx, createdUser.Id, "new_password_hash"))
+
+	_, err = storages.RefreshTokens.Revoke(ctx, "token_hash_1")
+	require.ErrorIs(t, err, storage.ErrRefreshTokenNotFound)
+	_, err = storages.RefreshTokens.Revoke(ctx, "token_hash_2")
+	require.ErrorIs(t, err, storage.ErrRefreshTokenNotFound)
+
+	require.NoError(t, storages.RefreshTokens.Create(ctx, createdUser.Id, "token_hash_3", expiresAt))
+
+	_, err = storages.Users.Disable(ctx, createdUser.Id)
+	require.NoError(t, err)
+
+	_, err = storages.RefreshTokens.Revoke(ctx, "token_hash_3")
+	require.ErrorIs(t, err, storage.ErrRefreshTokenNotFound)
+
+	userId, err := storages.RefreshTokens.Revoke(ctx, "other_token_hash")
+	require.NoError(t, err)
+	require.Equal(t, otherUser.Id, userId)
+}
@@ -0,0 +1,12 @@
+package auth
+
+import "time"
+
+type Config struct {
+	AccessTokenSecret string        `yaml:"access-token-secret"`
+	AccessTokenTTL    time.Duration `yaml:"access-token-ttl"`
+	RefreshTokenTTL   time.Duration `yaml:"refresh-token-ttl"`
+
+	// BasicAuthEnabled allows authenticating requests with email and password instead of an access token
+	BasicAuthEnabled bool `yaml:"basic-auth-enabled"`
+}
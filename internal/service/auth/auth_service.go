@@ -1,36 +1,55 @@
 package auth
 
 import (
+	"context"
 	"errors"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
 	"github.com/vaberof/vk-internship-task/internal/service/user"
 	"github.com/vaberof/vk-internship-task/pkg/logging/logs"
+	"github.com/vaberof/vk-internship-task/pkg/xjwt"
 	"github.com/vaberof/vk-internship-task/pkg/xpassword"
 	"log/slog"
+	"strconv"
+	"time"
 )
 
 var (
 	ErrInvalidEmailOrPassword = errors.New("invalid email or password")
+	ErrInvalidAccessToken     = errors.New("invalid access token")
+	ErrInvalidRefreshToken    = errors.New("invalid refresh token")
+	ErrBasicAuthDisabled      = errors.New("basic authentication is disabled, use access token")
+	ErrUserDisabled           = errors.New("user is disabled")
 )
 
 type AuthService interface {
-	AuthenticateUser(email, password string) (*user.User, error)
+	AuthenticateUser(ctx context.Context, email, password string) (*user.User, error)
+	AuthenticateAccessToken(ctx context.Context, accessToken string) (*user.User, error)
+	Login(ctx context.Context, email, password string) (*Tokens, error)
+	Refresh(ctx context.Context, refreshToken string) (*Tokens, error)
+	Logout(ctx context.Context, refreshToken string) error
 }
 
 type authServiceImpl struct {
-	userFinder UserFinder
+	userFinder          UserFinder
+	refreshTokenStorage RefreshTokenStorage
+
+	config *Config
 
 	logger *slog.Logger
 }
 
-func NewAuthService(userFinder UserFinder, logsBuilder *logs.Logs) AuthService {
+func NewAuthService(userFinder UserFinder, refreshTokenStorage RefreshTokenStorage, config *Config, logsBuilder *logs.Logs) AuthService {
 	logger := logsBuilder.WithName("domain.service.auth")
 	return &authServiceImpl{
-		userFinder: userFinder,
-		logger:     logger,
+		userFinder:          userFinder,
+		refreshTokenStorage: refreshTokenStorage,
+		config:              config,
+		logger:              logger,
 	}
 }
 
-func (a *authServiceImpl) AuthenticateUser(email, password string) (*user.User, error) {
+func (a *authServiceImpl) AuthenticateUser(ctx context.Context, email, password string) (*user.User, error) {
 	const operation = "AuthenticateUser"
 
 	log := a.logger.With(
@@ -39,7 +58,159 @@ func (a *authServiceImpl) AuthenticateUser(email, password string) (*user.User,
 
 	log.Info("authenticating a user")
 
-	usr, err := a.userFinder.FindByEmail(email)
+	if !a.config.BasicAuthEnabled {
+		log.Error("failed to authenticate a user", "error", ErrBasicAuthDisabled)
+		return nil, ErrBasicAuthDisabled
+	}
+
+	usr, err := a.checkCredentials(ctx, log, email, password)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("user has authenticated")
+
+	return usr, nil
+}
+
+func (a *authServiceImpl) AuthenticateAccessToken(ctx context.Context, accessToken string) (*user.User, error) {
+	const operation = "AuthenticateAccessToken"
+
+	log := a.logger.With(slog.String("operation", operation))
+
+	log.Info("authenticating a user by access token")
+
+	var claims accessTokenClaims
+	if err := xjwt.Parse(accessToken, &claims, []byte(a.config.AccessTokenSecret)); err != nil {
+		log.Error("failed to authenticate a user by access token", "error", err)
+		return nil, ErrInvalidAccessToken
+	}
+
+	userId, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil {
+		log.Error("failed to authenticate a user by access token", "error", err)
+		return nil, ErrInvalidAccessToken
+	}
+
+	// The claims are issued for the access token TTL, so the user is found on each request to stop
+	// disabled users at once and to apply the changes of the role
+	usr, err := a.userFinder.FindById(ctx, userId)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			log.Error("failed to authenticate a user by access token", "error", err)
+			return nil, ErrInvalidAccessToken
+		}
+
+		log.Error("failed to find a user", "error", err)
+		return nil, err
+	}
+
+	if usr.Disabled {
+		log.Error("failed to authenticate a user by access token", "error", ErrUserDisabled)
+		return nil, ErrUserDisabled
+	}
+
+	log.Info("user has authenticated by access token", "userId", userId)
+
+	return usr, nil
+}
+
+func (a *authServiceImpl) Login(ctx context.Context, email, password string) (*Tokens, error) {
+	const operation = "Login"
+
+	log := a.logger.With(
+		slog.String("operation", operation),
+		slog.String("email", email))
+
+	log.Info("logging in a user")
+
+	usr, err := a.checkCredentials(ctx, log, email, password)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := a.issueTokens(ctx, usr)
+	if err != nil {
+		log.Error("failed to issue tokens", "error", err)
+		return nil, err
+	}
+
+	log.Info("user has logged in")
+
+	return tokens, nil
+}
+
+func (a *authServiceImpl) Refresh(ctx context.Context, refreshToken string) (*Tokens, error) {
+	const operation = "Refresh"
+
+	log := a.logger.With(slog.String("operation", operation))
+
+	log.Info("refreshing tokens")
+
+	// The refresh token is single-use: it is revoked before the new pair is issued
+	userId, err := a.refreshTokenStorage.Revoke(ctx, hashRefreshToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, storage.ErrRefreshTokenNotFound) {
+			log.Error("failed to refresh tokens", "error", err)
+			return nil, ErrInvalidRefreshToken
+		}
+
+		log.Error("failed to revoke a refresh token", "error", err)
+		return nil, err
+	}
+
+	usr, err := a.userFinder.FindById(ctx, userId)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			log.Error("failed to refresh tokens", "error", err)
+			return nil, ErrInvalidRefreshToken
+		}
+
+		log.Error("failed to find a user", "error", err)
+		return nil, err
+	}
+
+	if usr.Disabled {
+		log.Error("failed to refresh tokens", "error", ErrUserDisabled)
+		return nil, ErrUserDisabled
+	}
+
+	tokens, err := a.issueTokens(ctx, usr)
+	if err != nil {
+		log.Error("failed to issue tokens", "error", err)
+		return nil, err
+	}
+
+	log.Info("tokens have refreshed", "userId", userId)
+
+	return tokens, nil
+}
+
+func (a *authServiceImpl) Logout(ctx context.Context, refreshToken string) error {
+	const operation = "Logout"
+
+	log := a.logger.With(slog.String("operation", operation))
+
+	log.Info("logging out a user")
+
+	userId, err := a.refreshTokenStorage.Revoke(ctx, hashRefreshToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, storage.ErrRefreshTokenNotFound) {
+			log.Error("failed to log out a user", "error", err)
+			return ErrInvalidRefreshToken
+		}
+
+		log.Error("failed to revoke a refresh token", "error", err)
+		return err
+	}
+
+	log.Info("user has logged out", "userId", userId)
+
+	return nil
+}
+
+func (a *authServiceImpl) checkCredentials(ctx context.Context, log *slog.Logger, email, password string) (*user.User, error) {
+	usr, err := a.userFinder.FindByEmail(ctx, email)
 	if err != nil {
 		if errors.Is(err, user.ErrUserNotFound) {
 			log.Error("failed to authenticate a user", "error", user.ErrUserNotFound)
@@ -55,7 +226,47 @@ func (a *authServiceImpl) AuthenticateUser(email, password string) (*user.User,
 		return nil, ErrInvalidEmailOrPassword
 	}
 
-	log.Info("user has authenticated")
+	if usr.Disabled {
+		log.Error("failed to authenticate a user", "error", ErrUserDisabled)
+		return nil, ErrUserDisabled
+	}
 
 	return usr, nil
 }
+
+func (a *authServiceImpl) issueTokens(ctx context.Context, usr *user.User) (*Tokens, error) {
+	now := time.Now()
+
+	accessTokenExpiresAt := now.Add(a.config.AccessTokenTTL)
+
+	accessToken, err := xjwt.Sign(&accessTokenClaims{
+		Email: usr.Email,
+		Role:  usr.Role.String(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.FormatInt(usr.Id, 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(accessTokenExpiresAt),
+		},
+	}, []byte(a.config.AccessTokenSecret))
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := newRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	refreshTokenExpiresAt := now.Add(a.config.RefreshTokenTTL)
+
+	if err = a.refreshTokenStorage.Create(ctx, usr.Id, hashRefreshToken(refreshToken), refreshTokenExpiresAt); err != nil {
+		return nil, err
+	}
+
+	return &Tokens{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessTokenExpiresAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: refreshTokenExpiresAt,
+	}, nil
+}
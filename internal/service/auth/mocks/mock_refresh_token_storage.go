@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/auth/refresh_token_storage.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/auth/refresh_token_storage.go -destination=internal/service/auth/mocks/mock_refresh_token_storage.go
+//
+
+// Package mock_auth is a generated GoMock package.
+package mock_auth
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRefreshTokenStorage is a mock of RefreshTokenStorage interface.
+type MockRefreshTokenStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefreshTokenStorageMockRecorder
+}
+
+// MockRefreshTokenStorageMockRecorder is the mock recorder for MockRefreshTokenStorage.
+type MockRefreshTokenStorageMockRecorder struct {
+	mock *MockRefreshTokenStorage
+}
+
+// NewMockRefreshTokenStorage creates a new mock instance.
+func NewMockRefreshTokenStorage(ctrl *gomock.Controller) *MockRefreshTokenStorage {
+	mock := &MockRefreshTokenStorage{ctrl: ctrl}
+	mock.recorder = &MockRefreshTokenStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefreshTokenStorage) EXPECT() *MockRefreshTokenStorageMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRefreshTokenStorage) Create(ctx context.Context, userId int64, tokenHash string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userId, tokenHash, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRefreshTokenStorageMockRecorder) Create(ctx, userId, tokenHash, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRefreshTokenStorage)(nil).Create), ctx, userId, tokenHash, expiresAt)
+}
+
+// Revoke mocks base method.
+func (m *MockRefreshTokenStorage) Revoke(ctx context.Context, tokenHash string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, tokenHash)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockRefreshTokenStorageMockRecorder) Revoke(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockRefreshTokenStorage)(nil).Revoke), ctx, tokenHash)
+}
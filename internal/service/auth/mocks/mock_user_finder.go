@@ -10,6 +10,7 @@
 package mock_auth
 
 import (
+	context "context"
 	reflect "reflect"
 
 	user "github.com/vaberof/vk-internship-task/internal/service/user"
@@ -40,16 +41,31 @@ func (m *MockUserFinder) EXPECT() *MockUserFinderMockRecorder {
 }
 
 // FindByEmail mocks base method.
-func (m *MockUserFinder) FindByEmail(email string) (*user.User, error) {
+func (m *MockUserFinder) FindByEmail(ctx context.Context, email string) (*user.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByEmail", email)
+	ret := m.ctrl.Call(m, "FindByEmail", ctx, email)
 	ret0, _ := ret[0].(*user.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindByEmail indicates an expected call of FindByEmail.
-func (mr *MockUserFinderMockRecorder) FindByEmail(email any) *gomock.Call {
+func (mr *MockUserFinderMockRecorder) FindByEmail(ctx, email any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmail", reflect.TypeOf((*MockUserFinder)(nil).FindByEmail), email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmail", reflect.TypeOf((*MockUserFinder)(nil).FindByEmail), ctx, email)
+}
+
+// FindById mocks base method.
+func (m *MockUserFinder) FindById(ctx context.Context, id int64) (*user.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(*user.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockUserFinderMockRecorder) FindById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockUserFinder)(nil).FindById), ctx, id)
 }
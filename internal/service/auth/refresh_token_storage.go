@@ -0,0 +1,13 @@
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+type RefreshTokenStorage interface {
+	Create(ctx context.Context, userId int64, tokenHash string, expiresAt time.Time) error
+
+	// Revoke revokes the active (not revoked and not expired) refresh token and returns its owner id
+	Revoke(ctx context.Context, tokenHash string) (int64, error)
+}
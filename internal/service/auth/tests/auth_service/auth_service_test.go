@@ -1,9 +1,11 @@
 package auth_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
 	"github.com/vaberof/vk-internship-task/internal/service/auth"
 	mocks "github.com/vaberof/vk-internship-task/internal/service/auth/mocks"
 	"github.com/vaberof/vk-internship-task/internal/service/user"
@@ -11,15 +13,26 @@ import (
 	"go.uber.org/mock/gomock"
 	"os"
 	"testing"
+	"time"
 )
 
+var authConfig = &auth.Config{
+	AccessTokenSecret: "secret",
+	AccessTokenTTL:    15 * time.Minute,
+	RefreshTokenTTL:   24 * time.Hour,
+	BasicAuthEnabled:  true,
+}
+
 func TestAuthenticateUser(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	userFinder := mocks.NewMockUserFinder(ctrl)
+	refreshTokenStorage := mocks.NewMockRefreshTokenStorage(ctrl)
 	logsBuilder := logs.New(os.Stdout, nil)
 
+	ctx := context.Background()
+
 	id := int64(1)
 	email := "user@example.com"
 	password := "asdf1234"
@@ -33,10 +46,10 @@ func TestAuthenticateUser(t *testing.T) {
 		Role:     role,
 	}
 
-	userFinder.EXPECT().FindByEmail(email).Return(expected, nil).Times(1)
+	userFinder.EXPECT().FindByEmail(ctx, email).Return(expected, nil).Times(1)
 
-	authService := auth.NewAuthService(userFinder, logsBuilder)
-	usr, err := authService.AuthenticateUser(email, password)
+	authService := auth.NewAuthService(userFinder, refreshTokenStorage, authConfig, logsBuilder)
+	usr, err := authService.AuthenticateUser(ctx, email, password)
 	require.NoError(t, err)
 	require.Equal(t, expected, usr)
 }
@@ -46,9 +59,12 @@ func TestAuthenticateUserError(t *testing.T) {
 	defer ctrl.Finish()
 
 	userFinder := mocks.NewMockUserFinder(ctrl)
+	refreshTokenStorage := mocks.NewMockRefreshTokenStorage(ctrl)
 	logsBuilder := logs.New(os.Stdout, nil)
 
-	authService := auth.NewAuthService(userFinder, logsBuilder)
+	ctx := context.Background()
+
+	authService := auth.NewAuthService(userFinder, refreshTokenStorage, authConfig, logsBuilder)
 
 	type in struct {
 		Email    string
@@ -81,11 +97,377 @@ func TestAuthenticateUserError(t *testing.T) {
 
 	for _, tCase := range testCases {
 		t.Run(tCase.name, func(t *testing.T) {
-			userFinder.EXPECT().FindByEmail(tCase.in.Email).Return(tCase.out, tCase.authExpErr).AnyTimes()
-			usr, err := authService.AuthenticateUser(tCase.in.Email, tCase.in.Password)
+			userFinder.EXPECT().FindByEmail(ctx, tCase.in.Email).Return(tCase.out, tCase.authExpErr).AnyTimes()
+			usr, err := authService.AuthenticateUser(ctx, tCase.in.Email, tCase.in.Password)
 			require.Error(t, err)
 			require.EqualError(t, tCase.authExpErr, err.Error())
 			require.Nil(t, usr)
 		})
 	}
 }
+
+func TestAuthenticateUserBasicAuthDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userFinder := mocks.NewMockUserFinder(ctrl)
+	refreshTokenStorage := mocks.NewMockRefreshTokenStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	config := *authConfig
+	config.BasicAuthEnabled = false
+
+	authService := auth.NewAuthService(userFinder, refreshTokenStorage, &config, logsBuilder)
+	usr, err := authService.AuthenticateUser(ctx, "user@example.com", "asdf1234")
+	require.ErrorIs(t, err, auth.ErrBasicAuthDisabled)
+	require.Nil(t, usr)
+}
+
+func TestLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userFinder := mocks.NewMockUserFinder(ctrl)
+	refreshTokenStorage := mocks.NewMockRefreshTokenStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	usr := &user.User{
+		Id:       1,
+		Email:    "user@example.com",
+		Password: "$2a$10$JT0HAAksN7kvv6m0TXAvIejUzNOs19uRA7Ae8qIjn5lLa2hP1isNK",
+		Role:     user.RoleUser,
+	}
+
+	userFinder.EXPECT().FindByEmail(ctx, usr.Email).Return(usr, nil).Times(1)
+	refreshTokenStorage.EXPECT().Create(ctx, usr.Id, gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	userFinder.EXPECT().FindById(ctx, usr.Id).Return(usr, nil).Times(1)
+
+	authService := auth.NewAuthService(userFinder, refreshTokenStorage, authConfig, logsBuilder)
+	tokens, err := authService.Login(ctx, usr.Email, "asdf1234")
+	require.NoError(t, err)
+	require.NotEmpty(t, tokens.AccessToken)
+	require.NotEmpty(t, tokens.RefreshToken)
+	require.True(t, tokens.AccessTokenExpiresAt.Before(tokens.RefreshTokenExpiresAt))
+
+	authenticatedUser, err := authService.AuthenticateAccessToken(ctx, tokens.AccessToken)
+	require.NoError(t, err)
+	require.Equal(t, usr, authenticatedUser)
+}
+
+func TestAuthenticateAccessTokenChecksUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userFinder := mocks.NewMockUserFinder(ctrl)
+	refreshTokenStorage := mocks.NewMockRefreshTokenStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	usr := &user.User{
+		Id:       1,
+		Email:    "user@example.com",
+		Password: "$2a$10$JT0HAAksN7kvv6m0TXAvIejUzNOs19uRA7Ae8qIjn5lLa2hP1isNK",
+		Role:     user.RoleAdmin,
+	}
+
+	userFinder.EXPECT().FindByEmail(ctx, usr.Email).Return(usr, nil).Times(1)
+	refreshTokenStorage.EXPECT().Create(ctx, usr.Id, gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	authService := auth.NewAuthService(userFinder, refreshTokenStorage, authConfig, logsBuilder)
+	tokens, err := authService.Login(ctx, usr.Email, "asdf1234")
+	require.NoError(t, err)
+
+	t.Run("role_changed", func(t *testing.T) {
+		demotedUser := *usr
+		demotedUser.Role = user.RoleUser
+
+		userFinder.EXPECT().FindById(ctx, usr.Id).Return(&demotedUser, nil).Times(1)
+
+		authenticatedUser, err := authService.AuthenticateAccessToken(ctx, tokens.AccessToken)
+		require.NoError(t, err)
+		require.Equal(t, user.RoleUser, authenticatedUser.Role)
+	})
+
+	t.Run("err_user_disabled", func(t *testing.T) {
+		disabledUser := *usr
+		disabledUser.Disabled = true
+
+		userFinder.EXPECT().FindById(ctx, usr.Id).Return(&disabledUser, nil).Times(1)
+
+		authenticatedUser, err := authService.AuthenticateAccessToken(ctx, tokens.AccessToken)
+		require.ErrorIs(t, err, auth.ErrUserDisabled)
+		require.Nil(t, authenticatedUser)
+	})
+
+	t.Run("err_user_not_found", func(t *testing.T) {
+		userFinder.EXPECT().FindById(ctx, usr.Id).Return(nil, user.ErrUserNotFound).Times(1)
+
+		authenticatedUser, err := authService.AuthenticateAccessToken(ctx, tokens.AccessToken)
+		require.ErrorIs(t, err, auth.ErrInvalidAccessToken)
+		require.Nil(t, authenticatedUser)
+	})
+}
+
+func TestLoginUserDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userFinder := mocks.NewMockUserFinder(ctrl)
+	refreshTokenStorage := mocks.NewMockRefreshTokenStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	usr := &user.User{
+		Id:       1,
+		Email:    "user@example.com",
+		Password: "$2a$10$JT0HAAksN7kvv6m0TXAvIejUzNOs19uRA7Ae8qIjn5lLa2hP1isNK",
+		Role:     user.RoleUser,
+		Disabled: true,
+	}
+
+	userFinder.EXPECT().FindByEmail(ctx, usr.Email).Return(usr, nil).Times(1)
+
+	authService := auth.NewAuthService(userFinder, refreshTokenStorage, authConfig, logsBuilder)
+	tokens, err := authService.Login(ctx, usr.Email, "asdf1234")
+	require.ErrorIs(t, err, auth.ErrUserDisabled)
+	require.Nil(t, tokens)
+}
+
+func TestLoginError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userFinder := mocks.NewMockUserFinder(ctrl)
+	refreshTokenStorage := mocks.NewMockRefreshTokenStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	authService := auth.NewAuthService(userFinder, refreshTokenStorage, authConfig, logsBuilder)
+
+	usr := &user.User{
+		Id:       1,
+		Email:    "user@example.com",
+		Password: "$2a$10$JT0HAAksN7kvv6m0TXAvIejUzNOs19uRA7Ae8qIjn5lLa2hP1isNK",
+		Role:     user.RoleUser,
+	}
+
+	t.Run("err_invalid_email_or_password", func(t *testing.T) {
+		userFinder.EXPECT().FindByEmail(ctx, usr.Email).Return(usr, nil).Times(1)
+
+		tokens, err := authService.Login(ctx, usr.Email, "111")
+		require.ErrorIs(t, err, auth.ErrInvalidEmailOrPassword)
+		require.Nil(t, tokens)
+	})
+
+	t.Run("err_other", func(t *testing.T) {
+		expectedErr := fmt.Errorf("failed to create a refresh token: %w", errors.New("database is down"))
+
+		userFinder.EXPECT().FindByEmail(ctx, usr.Email).Return(usr, nil).Times(1)
+		refreshTokenStorage.EXPECT().Create(ctx, usr.Id, gomock.Any(), gomock.Any()).Return(expectedErr).Times(1)
+
+		tokens, err := authService.Login(ctx, usr.Email, "asdf1234")
+		require.EqualError(t, expectedErr, err.Error())
+		require.Nil(t, tokens)
+	})
+}
+
+func TestAuthenticateAccessTokenError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userFinder := mocks.NewMockUserFinder(ctrl)
+	refreshTokenStorage := mocks.NewMockRefreshTokenStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	usr := &user.User{
+		Id:       1,
+		Email:    "user@example.com",
+		Password: "$2a$10$JT0HAAksN7kvv6m0TXAvIejUzNOs19uRA7Ae8qIjn5lLa2hP1isNK",
+		Role:     user.RoleUser,
+	}
+
+	userFinder.EXPECT().FindByEmail(ctx, usr.Email).Return(usr, nil).AnyTimes()
+	refreshTokenStorage.EXPECT().Create(ctx, usr.Id, gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	otherSecretConfig := *authConfig
+	otherSecretConfig.AccessTokenSecret = "other_secret"
+
+	expiredConfig := *authConfig
+	expiredConfig.AccessTokenTTL = -time.Minute
+
+	testCases := []struct {
+		name   string
+		config *auth.Config
+	}{
+		{
+			name:   "err_invalid_signature",
+			config: &otherSecretConfig,
+		},
+		{
+			name:   "err_expired",
+			config: &expiredConfig,
+		},
+	}
+
+	authService := auth.NewAuthService(userFinder, refreshTokenStorage, authConfig, logsBuilder)
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			issuingAuthService := auth.NewAuthService(userFinder, refreshTokenStorage, tCase.config, logsBuilder)
+			tokens, err := issuingAuthService.Login(ctx, usr.Email, "asdf1234")
+			require.NoError(t, err)
+
+			authenticatedUser, err := authService.AuthenticateAccessToken(ctx, tokens.AccessToken)
+			require.ErrorIs(t, err, auth.ErrInvalidAccessToken)
+			require.Nil(t, authenticatedUser)
+		})
+	}
+
+	t.Run("err_malformed", func(t *testing.T) {
+		authenticatedUser, err := authService.AuthenticateAccessToken(ctx, "not.a.token")
+		require.ErrorIs(t, err, auth.ErrInvalidAccessToken)
+		require.Nil(t, authenticatedUser)
+	})
+}
+
+func TestRefresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userFinder := mocks.NewMockUserFinder(ctrl)
+	refreshTokenStorage := mocks.NewMockRefreshTokenStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	usr := &user.User{
+		Id:    1,
+		Email: "user@example.com",
+		Role:  user.RoleAdmin,
+	}
+
+	refreshTokenStorage.EXPECT().Revoke(ctx, gomock.Any()).Return(usr.Id, nil).Times(1)
+	userFinder.EXPECT().FindById(ctx, usr.Id).Return(usr, nil).Times(2)
+	refreshTokenStorage.EXPECT().Create(ctx, usr.Id, gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	authService := auth.NewAuthService(userFinder, refreshTokenStorage, authConfig, logsBuilder)
+	tokens, err := authService.Refresh(ctx, "refresh_token")
+	require.NoError(t, err)
+	require.NotEqual(t, "refresh_token", tokens.RefreshToken)
+
+	authenticatedUser, err := authService.AuthenticateAccessToken(ctx, tokens.AccessToken)
+	require.NoError(t, err)
+	require.Equal(t, usr, authenticatedUser)
+}
+
+func TestRefreshError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userFinder := mocks.NewMockUserFinder(ctrl)
+	refreshTokenStorage := mocks.NewMockRefreshTokenStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	authService := auth.NewAuthService(userFinder, refreshTokenStorage, authConfig, logsBuilder)
+
+	t.Run("err_invalid_refresh_token", func(t *testing.T) {
+		refreshTokenStorage.EXPECT().Revoke(ctx, gomock.Any()).Return(int64(0), fmt.Errorf("failed to revoke a refresh token: %w", storage.ErrRefreshTokenNotFound)).Times(1)
+
+		tokens, err := authService.Refresh(ctx, "refresh_token")
+		require.ErrorIs(t, err, auth.ErrInvalidRefreshToken)
+		require.Nil(t, tokens)
+	})
+
+	t.Run("err_user_not_found", func(t *testing.T) {
+		refreshTokenStorage.EXPECT().Revoke(ctx, gomock.Any()).Return(int64(1), nil).Times(1)
+		userFinder.EXPECT().FindById(ctx, int64(1)).Return(nil, user.ErrUserNotFound).Times(1)
+
+		tokens, err := authService.Refresh(ctx, "refresh_token")
+		require.ErrorIs(t, err, auth.ErrInvalidRefreshToken)
+		require.Nil(t, tokens)
+	})
+
+	t.Run("err_other", func(t *testing.T) {
+		expectedErr := fmt.Errorf("failed to revoke a refresh token: %w", errors.New("database is down"))
+
+		refreshTokenStorage.EXPECT().Revoke(ctx, gomock.Any()).Return(int64(0), expectedErr).Times(1)
+
+		tokens, err := authService.Refresh(ctx, "refresh_token")
+		require.EqualError(t, expectedErr, err.Error())
+		require.Nil(t, tokens)
+	})
+}
+
+func TestLogout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userFinder := mocks.NewMockUserFinder(ctrl)
+	refreshTokenStorage := mocks.NewMockRefreshTokenStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	var revokedTokenHash string
+
+	refreshTokenStorage.EXPECT().Revoke(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, tokenHash string) (int64, error) {
+		revokedTokenHash = tokenHash
+		return int64(1), nil
+	}).Times(1)
+
+	authService := auth.NewAuthService(userFinder, refreshTokenStorage, authConfig, logsBuilder)
+	err := authService.Logout(ctx, "refresh_token")
+	require.NoError(t, err)
+	require.NotEqual(t, "refresh_token", revokedTokenHash)
+	require.Len(t, revokedTokenHash, 64)
+}
+
+func TestLogoutError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userFinder := mocks.NewMockUserFinder(ctrl)
+	refreshTokenStorage := mocks.NewMockRefreshTokenStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	authService := auth.NewAuthService(userFinder, refreshTokenStorage, authConfig, logsBuilder)
+
+	testCases := []struct {
+		name       string
+		storageErr error
+		expErr     error
+	}{
+		{
+			name:       "err_invalid_refresh_token",
+			storageErr: fmt.Errorf("failed to revoke a refresh token: %w", storage.ErrRefreshTokenNotFound),
+			expErr:     auth.ErrInvalidRefreshToken,
+		},
+		{
+			name:       "err_other",
+			storageErr: fmt.Errorf("failed to revoke a refresh token: %w", errors.New("database is down")),
+			expErr:     fmt.Errorf("failed to revoke a refresh token: %w", errors.New("database is down")),
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			refreshTokenStorage.EXPECT().Revoke(ctx, gomock.Any()).Return(int64(0), tCase.storageErr).Times(1)
+			err := authService.Logout(ctx, "refresh_token")
+			require.Error(t, err)
+			require.EqualError(t, tCase.expErr, err.Error())
+		})
+	}
+}
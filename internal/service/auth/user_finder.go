@@ -1,9 +1,11 @@
 package auth
 
 import (
+	"context"
 	"github.com/vaberof/vk-internship-task/internal/service/user"
 )
 
 type UserFinder interface {
-	FindByEmail(email string) (*user.User, error)
+	FindById(ctx context.Context, id int64) (*user.User, error)
+	FindByEmail(ctx context.Context, email string) (*user.User, error)
 }
@@ -0,0 +1,41 @@
+package user
+
+type Permission string
+
+func (permission *Permission) String() string {
+	return string(*permission)
+}
+
+const (
+	PermissionFilmsRead     = Permission("films:read")
+	PermissionFilmsWrite    = Permission("films:write")
+	PermissionFilmsDelete   = Permission("films:delete")
+	PermissionActorsRead    = Permission("actors:read")
+	PermissionActorsWrite   = Permission("actors:write")
+	PermissionActorsDelete  = Permission("actors:delete")
+	PermissionGenresRead    = Permission("genres:read")
+	PermissionGenresWrite   = Permission("genres:write")
+	PermissionGenresDelete  = Permission("genres:delete")
+	PermissionReviewsWrite  = Permission("reviews:write")
+	PermissionUsersManage   = Permission("users:manage")
+	PermissionAuditRead     = Permission("audit:read")
+	PermissionCatalogImport = Permission("catalog:import")
+	PermissionCatalogExport = Permission("catalog:export")
+	PermissionTrashManage   = Permission("trash:manage")
+)
+
+// RolePermissions maps every role to the set of permissions granted to it.
+// It is loaded from the storage at startup, so roles can be added without code changes.
+type RolePermissions map[UserRole]map[Permission]struct{}
+
+func (rolePermissions RolePermissions) Grant(role UserRole, permission Permission) {
+	if rolePermissions[role] == nil {
+		rolePermissions[role] = make(map[Permission]struct{})
+	}
+	rolePermissions[role][permission] = struct{}{}
+}
+
+func (rolePermissions RolePermissions) Has(role UserRole, permission Permission) bool {
+	_, ok := rolePermissions[role][permission]
+	return ok
+}
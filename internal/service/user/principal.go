@@ -0,0 +1,27 @@
+package user
+
+import "context"
+
+// Principal is the authenticated user on whose behalf a request is made
+type Principal struct {
+	Id    int64
+	Email string
+	Role  UserRole
+}
+
+type principalCtxKeyType struct{}
+
+var principalCtxKey = &principalCtxKeyType{}
+
+func ContextWithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalCtxKey, principal)
+}
+
+// PrincipalFromContext returns the principal put into the context by the authentication middleware
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalCtxKey).(*Principal)
+	if !ok || principal == nil {
+		return nil, false
+	}
+	return principal, true
+}
@@ -1,17 +1,102 @@
 package user_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/stretchr/testify/require"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage"
+	"github.com/vaberof/vk-internship-task/internal/infra/storage/memory"
+	"github.com/vaberof/vk-internship-task/internal/service/auth"
 	"github.com/vaberof/vk-internship-task/internal/service/user"
 	mocks "github.com/vaberof/vk-internship-task/internal/service/user/mocks"
 	"github.com/vaberof/vk-internship-task/pkg/logging/logs"
+	"github.com/vaberof/vk-internship-task/pkg/xpassword"
 	"go.uber.org/mock/gomock"
 	"os"
 	"testing"
+	"time"
 )
 
+func TestFindById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userStorage := mocks.NewMockUserStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	id := int64(1)
+	email := "user@example.com"
+	passwordHash := "$2a$10$JT0HAAksN7kvv6m0TXAvIejUzNOs19uRA7Ae8qIjn5lLa2hP1isNK"
+	role := user.RoleUser
+
+	expected := &user.User{
+		Id:       id,
+		Email:    email,
+		Password: passwordHash,
+		Role:     role,
+	}
+
+	userStorage.EXPECT().FindById(ctx, id).Return(expected, nil).Times(1)
+
+	userService := user.NewUserService(userStorage, logsBuilder)
+	usr, err := userService.FindById(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, expected, usr)
+}
+
+func TestFindByIdError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userStorage := mocks.NewMockUserStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	userService := user.NewUserService(userStorage, logsBuilder)
+
+	type in struct {
+		Id int64
+	}
+
+	testCases := []struct {
+		name       string
+		in         in
+		storageErr error
+		expErr     error
+	}{
+		{
+			name: "err_user_not_found",
+			in: in{
+				Id: 1,
+			},
+			storageErr: fmt.Errorf("failed to find user by id: %w", storage.ErrUserNotFound),
+			expErr:     user.ErrUserNotFound,
+		},
+		{
+			name: "err_other",
+			in: in{
+				Id: 2,
+			},
+			storageErr: fmt.Errorf("failed to find user by id: %w", errors.New("database is down")),
+			expErr:     fmt.Errorf("failed to find user by id: %w", errors.New("database is down")),
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			userStorage.EXPECT().FindById(ctx, tCase.in.Id).Return(nil, tCase.storageErr).Times(1)
+			usr, err := userService.FindById(ctx, tCase.in.Id)
+			require.Error(t, err)
+			require.EqualError(t, tCase.expErr, err.Error())
+			require.Nil(t, usr)
+		})
+	}
+}
+
 func TestFindByEmail(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -19,6 +104,8 @@ func TestFindByEmail(t *testing.T) {
 	userStorage := mocks.NewMockUserStorage(ctrl)
 	logsBuilder := logs.New(os.Stdout, nil)
 
+	ctx := context.Background()
+
 	id := int64(1)
 	email := "user@example.com"
 	passwordHash := "$2a$10$JT0HAAksN7kvv6m0TXAvIejUzNOs19uRA7Ae8qIjn5lLa2hP1isNK"
@@ -31,10 +118,10 @@ func TestFindByEmail(t *testing.T) {
 		Role:     role,
 	}
 
-	userStorage.EXPECT().FindByEmail(email).Return(expected, nil).Times(1)
+	userStorage.EXPECT().FindByEmail(ctx, email).Return(expected, nil).Times(1)
 
 	userService := user.NewUserService(userStorage, logsBuilder)
-	usr, err := userService.FindByEmail(email)
+	usr, err := userService.FindByEmail(ctx, email)
 	require.NoError(t, err)
 	require.Equal(t, expected, usr)
 }
@@ -46,6 +133,8 @@ func TestFindByEmailError(t *testing.T) {
 	userStorage := mocks.NewMockUserStorage(ctrl)
 	logsBuilder := logs.New(os.Stdout, nil)
 
+	ctx := context.Background()
+
 	userService := user.NewUserService(userStorage, logsBuilder)
 
 	type in struct {
@@ -76,11 +165,393 @@ func TestFindByEmailError(t *testing.T) {
 
 	for _, tCase := range testCases {
 		t.Run(tCase.name, func(t *testing.T) {
-			userStorage.EXPECT().FindByEmail(tCase.in.Email).Return(tCase.out, tCase.findExpErr).AnyTimes()
-			usr, err := userService.FindByEmail(tCase.in.Email)
+			userStorage.EXPECT().FindByEmail(ctx, tCase.in.Email).Return(tCase.out, tCase.findExpErr).AnyTimes()
+			usr, err := userService.FindByEmail(ctx, tCase.in.Email)
 			require.Error(t, err)
 			require.EqualError(t, tCase.findExpErr, err.Error())
 			require.Nil(t, usr)
 		})
 	}
 }
+
+func TestRegister(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userStorage := mocks.NewMockUserStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	email := "new_user@example.com"
+	password := "asdf1234"
+
+	var createdPasswordHash string
+
+	userStorage.EXPECT().Create(ctx, email, gomock.Any(), user.RoleUser).DoAndReturn(func(_ context.Context, email, passwordHash string, role user.UserRole) (*user.User, error) {
+		createdPasswordHash = passwordHash
+		return &user.User{Id: 3, Email: email, Password: passwordHash, Role: role}, nil
+	}).Times(1)
+
+	userService := user.NewUserService(userStorage, logsBuilder)
+	usr, err := userService.Register(ctx, email, password)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), usr.Id)
+	require.Equal(t, user.RoleUser, usr.Role)
+	require.NotEqual(t, password, createdPasswordHash)
+	require.NoError(t, xpassword.Check(password, createdPasswordHash))
+}
+
+func TestRegisterError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userStorage := mocks.NewMockUserStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	userService := user.NewUserService(userStorage, logsBuilder)
+
+	testCases := []struct {
+		name       string
+		storageErr error
+		expErr     error
+	}{
+		{
+			name:       "err_user_already_exists",
+			storageErr: fmt.Errorf("failed to create a user: %w", storage.ErrUserAlreadyExists),
+			expErr:     user.ErrUserAlreadyExists,
+		},
+		{
+			name:       "err_other",
+			storageErr: fmt.Errorf("failed to create a user: %w", errors.New("database is down")),
+			expErr:     fmt.Errorf("failed to create a user: %w", errors.New("database is down")),
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			userStorage.EXPECT().Create(ctx, "user@example.com", gomock.Any(), user.RoleUser).Return(nil, tCase.storageErr).Times(1)
+			usr, err := userService.Register(ctx, "user@example.com", "asdf1234")
+			require.Error(t, err)
+			require.EqualError(t, tCase.expErr, err.Error())
+			require.Nil(t, usr)
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userStorage := mocks.NewMockUserStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	expected := []*user.User{
+		{Id: 1, Email: "user@example.com", Role: user.RoleUser},
+		{Id: 2, Email: "admin@example.com", Role: user.RoleAdmin},
+	}
+
+	limit := 100
+	offset := 0
+	total := int64(2)
+
+	userStorage.EXPECT().List(ctx, limit, offset).Return(expected, nil).Times(1)
+	userStorage.EXPECT().Count(ctx).Return(total, nil).Times(1)
+
+	userService := user.NewUserService(userStorage, logsBuilder)
+	usersPage, err := userService.List(ctx, limit, offset)
+	require.NoError(t, err)
+	require.Equal(t, expected, usersPage.Users)
+	require.Equal(t, total, usersPage.Total)
+}
+
+func TestListError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userStorage := mocks.NewMockUserStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	expectedErr := fmt.Errorf("failed to list users: %w", errors.New("database is down"))
+
+	userStorage.EXPECT().List(ctx, 100, 0).Return(nil, expectedErr).Times(1)
+
+	userService := user.NewUserService(userStorage, logsBuilder)
+	usersPage, err := userService.List(ctx, 100, 0)
+	require.Error(t, err)
+	require.EqualError(t, expectedErr, err.Error())
+	require.Nil(t, usersPage)
+}
+
+func TestUpdateRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userStorage := mocks.NewMockUserStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	expected := &user.User{Id: 1, Email: "user@example.com", Role: user.RoleAdmin}
+
+	userStorage.EXPECT().UpdateRole(ctx, expected.Id, user.RoleAdmin).Return(expected, nil).Times(1)
+
+	userService := user.NewUserService(userStorage, logsBuilder)
+	usr, err := userService.UpdateRole(ctx, expected.Id, user.RoleAdmin)
+	require.NoError(t, err)
+	require.Equal(t, expected, usr)
+}
+
+func TestUpdateRoleError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userStorage := mocks.NewMockUserStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	userService := user.NewUserService(userStorage, logsBuilder)
+
+	t.Run("err_invalid_user_role", func(t *testing.T) {
+		userStorage.EXPECT().UpdateRole(ctx, int64(1), user.UserRole("superuser")).Return(nil, fmt.Errorf("failed to update user role: %w", storage.ErrRoleNotFound)).Times(1)
+
+		usr, err := userService.UpdateRole(ctx, 1, user.UserRole("superuser"))
+		require.ErrorIs(t, err, user.ErrInvalidUserRole)
+		require.Nil(t, usr)
+	})
+
+	t.Run("err_user_not_found", func(t *testing.T) {
+		userStorage.EXPECT().UpdateRole(ctx, int64(1), user.RoleAdmin).Return(nil, fmt.Errorf("failed to update user role: %w", storage.ErrUserNotFound)).Times(1)
+
+		usr, err := userService.UpdateRole(ctx, 1, user.RoleAdmin)
+		require.ErrorIs(t, err, user.ErrUserNotFound)
+		require.Nil(t, usr)
+	})
+}
+
+func TestDisable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userStorage := mocks.NewMockUserStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	expected := &user.User{Id: 1, Email: "user@example.com", Role: user.RoleUser, Disabled: true}
+
+	userStorage.EXPECT().Disable(ctx, expected.Id).Return(expected, nil).Times(1)
+
+	userService := user.NewUserService(userStorage, logsBuilder)
+	usr, err := userService.Disable(ctx, expected.Id)
+	require.NoError(t, err)
+	require.Equal(t, expected, usr)
+}
+
+func TestDisableError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userStorage := mocks.NewMockUserStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	userStorage.EXPECT().Disable(ctx, int64(1)).Return(nil, fmt.Errorf("failed to disable a user: %w", storage.ErrUserNotFound)).Times(1)
+
+	userService := user.NewUserService(userStorage, logsBuilder)
+	usr, err := userService.Disable(ctx, 1)
+	require.ErrorIs(t, err, user.ErrUserNotFound)
+	require.Nil(t, usr)
+}
+
+func TestResetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userStorage := mocks.NewMockUserStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	newPassword := "new_password"
+
+	userStorage.EXPECT().UpdatePassword(ctx, int64(1), gomock.Any()).DoAndReturn(func(_ context.Context, _ int64, passwordHash string) error {
+		return xpassword.Check(newPassword, passwordHash)
+	}).Times(1)
+
+	userService := user.NewUserService(userStorage, logsBuilder)
+	err := userService.ResetPassword(ctx, 1, newPassword)
+	require.NoError(t, err)
+}
+
+func TestResetPasswordError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userStorage := mocks.NewMockUserStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	userStorage.EXPECT().UpdatePassword(ctx, int64(1), gomock.Any()).Return(fmt.Errorf("failed to update user password: %w", storage.ErrUserNotFound)).Times(1)
+
+	userService := user.NewUserService(userStorage, logsBuilder)
+	err := userService.ResetPassword(ctx, 1, "new_password")
+	require.ErrorIs(t, err, user.ErrUserNotFound)
+}
+
+func TestChangePassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userStorage := mocks.NewMockUserStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	usr := &user.User{
+		Id:       1,
+		Email:    "user@example.com",
+		Password: "$2a$10$JT0HAAksN7kvv6m0TXAvIejUzNOs19uRA7Ae8qIjn5lLa2hP1isNK",
+		Role:     user.RoleUser,
+	}
+
+	userStorage.EXPECT().FindById(ctx, usr.Id).Return(usr, nil).Times(1)
+	userStorage.EXPECT().UpdatePassword(ctx, usr.Id, gomock.Any()).Return(nil).Times(1)
+
+	userService := user.NewUserService(userStorage, logsBuilder)
+	err := userService.ChangePassword(ctx, usr.Id, "asdf1234", "new_password")
+	require.NoError(t, err)
+}
+
+func TestChangePasswordError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userStorage := mocks.NewMockUserStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	usr := &user.User{
+		Id:       1,
+		Email:    "user@example.com",
+		Password: "$2a$10$JT0HAAksN7kvv6m0TXAvIejUzNOs19uRA7Ae8qIjn5lLa2hP1isNK",
+		Role:     user.RoleUser,
+	}
+
+	userStorage.EXPECT().FindById(ctx, usr.Id).Return(usr, nil).Times(1)
+
+	userService := user.NewUserService(userStorage, logsBuilder)
+	err := userService.ChangePassword(ctx, usr.Id, "wrong_password", "new_password")
+	require.ErrorIs(t, err, user.ErrInvalidPassword)
+}
+
+func TestListRolePermissions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userStorage := mocks.NewMockUserStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	expected := make(user.RolePermissions)
+	expected.Grant(user.RoleUser, user.PermissionFilmsRead)
+	expected.Grant(user.RoleEditor, user.PermissionFilmsRead)
+	expected.Grant(user.RoleEditor, user.PermissionFilmsWrite)
+
+	userStorage.EXPECT().ListRolePermissions(ctx).Return(expected, nil).Times(1)
+
+	userService := user.NewUserService(userStorage, logsBuilder)
+	rolePermissions, err := userService.ListRolePermissions(ctx)
+	require.NoError(t, err)
+	require.Equal(t, expected, rolePermissions)
+	require.True(t, rolePermissions.Has(user.RoleEditor, user.PermissionFilmsWrite))
+	require.False(t, rolePermissions.Has(user.RoleEditor, user.PermissionFilmsDelete))
+	require.False(t, rolePermissions.Has(user.RoleAdmin, user.PermissionFilmsRead))
+}
+
+func TestListRolePermissionsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userStorage := mocks.NewMockUserStorage(ctrl)
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	expectedErr := fmt.Errorf("failed to list role permissions: %w", errors.New("database is down"))
+
+	userStorage.EXPECT().ListRolePermissions(ctx).Return(nil, expectedErr).Times(1)
+
+	userService := user.NewUserService(userStorage, logsBuilder)
+	rolePermissions, err := userService.ListRolePermissions(ctx)
+	require.Error(t, err)
+	require.EqualError(t, expectedErr, err.Error())
+	require.Nil(t, rolePermissions)
+}
+
+func TestPasswordChangeAndDisableRevokeRefreshTokens(t *testing.T) {
+	logsBuilder := logs.New(os.Stdout, nil)
+
+	ctx := context.Background()
+
+	authConfig := &auth.Config{
+		AccessTokenSecret: "secret",
+		AccessTokenTTL:    15 * time.Minute,
+		RefreshTokenTTL:   24 * time.Hour,
+	}
+
+	testCases := []struct {
+		name   string
+		action func(userService user.UserService, id int64) error
+	}{
+		{
+			name: "reset_password",
+			action: func(userService user.UserService, id int64) error {
+				return userService.ResetPassword(ctx, id, "new_password")
+			},
+		},
+		{
+			name: "change_password",
+			action: func(userService user.UserService, id int64) error {
+				return userService.ChangePassword(ctx, id, "asdf1234", "new_password")
+			},
+		},
+		{
+			name: "disable",
+			action: func(userService user.UserService, id int64) error {
+				_, err := userService.Disable(ctx, id)
+				return err
+			},
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			db := memory.NewDb()
+			userStorage := memory.NewMemUserStorage(db)
+
+			userService := user.NewUserService(userStorage, logsBuilder)
+			authService := auth.NewAuthService(userStorage, memory.NewMemRefreshTokenStorage(db), authConfig, logsBuilder)
+
+			tokens, err := authService.Login(ctx, "user@example.com", "asdf1234")
+			require.NoError(t, err)
+
+			require.NoError(t, tCase.action(userService, 1))
+
+			refreshedTokens, err := authService.Refresh(ctx, tokens.RefreshToken)
+			require.ErrorIs(t, err, auth.ErrInvalidRefreshToken)
+			require.Nil(t, refreshedTokens)
+		})
+	}
+}
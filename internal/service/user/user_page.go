@@ -0,0 +1,6 @@
+package user
+
+type UsersPage struct {
+	Users []*User
+	Total int64
+}
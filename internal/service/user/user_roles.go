@@ -1,6 +1,7 @@
 package user
 
 const (
-	RoleUser           = UserRole("user")
-	RoleAdmin UserRole = UserRole("admin")
+	RoleUser            = UserRole("user")
+	RoleEditor UserRole = UserRole("editor")
+	RoleAdmin  UserRole = UserRole("admin")
 )
@@ -1,18 +1,31 @@
 package user
 
 import (
+	"context"
 	"errors"
 	"github.com/vaberof/vk-internship-task/internal/infra/storage"
 	"github.com/vaberof/vk-internship-task/pkg/logging/logs"
+	"github.com/vaberof/vk-internship-task/pkg/xpassword"
 	"log/slog"
 )
 
 var (
-	ErrUserNotFound = errors.New("user not found")
+	ErrUserNotFound      = errors.New("user not found")
+	ErrUserAlreadyExists = errors.New("user with such email already exists")
+	ErrInvalidUserRole   = errors.New("invalid user role")
+	ErrInvalidPassword   = errors.New("invalid password")
 )
 
 type UserService interface {
-	FindByEmail(email string) (*User, error)
+	Register(ctx context.Context, email, password string) (*User, error)
+	FindById(ctx context.Context, id int64) (*User, error)
+	FindByEmail(ctx context.Context, email string) (*User, error)
+	List(ctx context.Context, limit, offset int) (*UsersPage, error)
+	UpdateRole(ctx context.Context, id int64, role UserRole) (*User, error)
+	Disable(ctx context.Context, id int64) (*User, error)
+	ResetPassword(ctx context.Context, id int64, newPassword string) error
+	ChangePassword(ctx context.Context, id int64, currentPassword, newPassword string) error
+	ListRolePermissions(ctx context.Context) (RolePermissions, error)
 }
 
 type userServiceImpl struct {
@@ -29,7 +42,68 @@ func NewUserService(userStorage UserStorage, logsBuilder *logs.Logs) UserService
 	}
 }
 
-func (u *userServiceImpl) FindByEmail(email string) (*User, error) {
+func (u *userServiceImpl) Register(ctx context.Context, email, password string) (*User, error) {
+	const operation = "Register"
+
+	log := u.logger.With(
+		slog.String("operation", operation),
+		slog.String("email", email))
+
+	log.Info("registering a user")
+
+	passwordHash, err := xpassword.Hash(password)
+	if err != nil {
+		log.Error("failed to hash a password", "error", err)
+
+		return nil, err
+	}
+
+	user, err := u.userStorage.Create(ctx, email, passwordHash, RoleUser)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserAlreadyExists) {
+			log.Warn("user with such email already exists", "error", err)
+
+			return nil, ErrUserAlreadyExists
+		}
+
+		log.Error("failed to register a user", "error", err)
+
+		return nil, err
+	}
+
+	log.Info("user has registered", "id", user.Id)
+
+	return user, nil
+}
+
+func (u *userServiceImpl) FindById(ctx context.Context, id int64) (*User, error) {
+	const operation = "FindById"
+
+	log := u.logger.With(
+		slog.String("operation", operation),
+		slog.Int64("id", id))
+
+	log.Info("finding a user by id")
+
+	user, err := u.userStorage.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Warn("user not found by id", "error", err)
+
+			return nil, ErrUserNotFound
+		}
+
+		log.Error("failed to find a user", "error", err)
+
+		return nil, err
+	}
+
+	log.Info("user has found")
+
+	return user, nil
+}
+
+func (u *userServiceImpl) FindByEmail(ctx context.Context, email string) (*User, error) {
 	const operation = "FindByEmail"
 
 	log := u.logger.With(
@@ -38,7 +112,7 @@ func (u *userServiceImpl) FindByEmail(email string) (*User, error) {
 
 	log.Info("finding a user by email")
 
-	user, err := u.userStorage.FindByEmail(email)
+	user, err := u.userStorage.FindByEmail(ctx, email)
 	if err != nil {
 		if errors.Is(err, storage.ErrUserNotFound) {
 			log.Warn("user not found by email", "error", err)
@@ -55,3 +129,192 @@ func (u *userServiceImpl) FindByEmail(email string) (*User, error) {
 
 	return user, nil
 }
+
+func (u *userServiceImpl) List(ctx context.Context, limit, offset int) (*UsersPage, error) {
+	const operation = "List"
+
+	log := u.logger.With(
+		slog.String("operation", operation),
+		slog.Int("limit", limit),
+		slog.Int("offset", offset))
+
+	log.Info("listing users")
+
+	users, err := u.userStorage.List(ctx, limit, offset)
+	if err != nil {
+		log.Error("failed to list users", "error", err)
+
+		return nil, err
+	}
+
+	total, err := u.userStorage.Count(ctx)
+	if err != nil {
+		log.Error("failed to count users", "error", err)
+
+		return nil, err
+	}
+
+	log.Info("users have listed")
+
+	return &UsersPage{
+		Users: users,
+		Total: total,
+	}, nil
+}
+
+func (u *userServiceImpl) UpdateRole(ctx context.Context, id int64, role UserRole) (*User, error) {
+	const operation = "UpdateRole"
+
+	log := u.logger.With(
+		slog.String("operation", operation),
+		slog.Int64("id", id),
+		slog.String("role", role.String()))
+
+	log.Info("updating a user role")
+
+	user, err := u.userStorage.UpdateRole(ctx, id, role)
+	if err != nil {
+		if errors.Is(err, storage.ErrRoleNotFound) {
+			log.Warn("role not found", "error", err)
+
+			return nil, ErrInvalidUserRole
+		}
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Warn("user not found by id", "error", err)
+
+			return nil, ErrUserNotFound
+		}
+
+		log.Error("failed to update a user role", "error", err)
+
+		return nil, err
+	}
+
+	log.Info("user role has updated")
+
+	return user, nil
+}
+
+func (u *userServiceImpl) Disable(ctx context.Context, id int64) (*User, error) {
+	const operation = "Disable"
+
+	log := u.logger.With(
+		slog.String("operation", operation),
+		slog.Int64("id", id))
+
+	log.Info("disabling a user")
+
+	user, err := u.userStorage.Disable(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Warn("user not found by id", "error", err)
+
+			return nil, ErrUserNotFound
+		}
+
+		log.Error("failed to disable a user", "error", err)
+
+		return nil, err
+	}
+
+	log.Info("user has disabled")
+
+	return user, nil
+}
+
+func (u *userServiceImpl) ResetPassword(ctx context.Context, id int64, newPassword string) error {
+	const operation = "ResetPassword"
+
+	log := u.logger.With(
+		slog.String("operation", operation),
+		slog.Int64("id", id))
+
+	log.Info("resetting a user password")
+
+	if err := u.updatePassword(ctx, log, id, newPassword); err != nil {
+		return err
+	}
+
+	log.Info("user password has reset")
+
+	return nil
+}
+
+func (u *userServiceImpl) ChangePassword(ctx context.Context, id int64, currentPassword, newPassword string) error {
+	const operation = "ChangePassword"
+
+	log := u.logger.With(
+		slog.String("operation", operation),
+		slog.Int64("id", id))
+
+	log.Info("changing a user password")
+
+	user, err := u.userStorage.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Warn("user not found by id", "error", err)
+
+			return ErrUserNotFound
+		}
+
+		log.Error("failed to find a user", "error", err)
+
+		return err
+	}
+
+	if err = xpassword.Check(currentPassword, user.Password); err != nil {
+		log.Warn("current password does not match", "error", err)
+
+		return ErrInvalidPassword
+	}
+
+	if err = u.updatePassword(ctx, log, id, newPassword); err != nil {
+		return err
+	}
+
+	log.Info("user password has changed")
+
+	return nil
+}
+
+func (u *userServiceImpl) ListRolePermissions(ctx context.Context) (RolePermissions, error) {
+	const operation = "ListRolePermissions"
+
+	log := u.logger.With(slog.String("operation", operation))
+
+	log.Info("listing role permissions")
+
+	rolePermissions, err := u.userStorage.ListRolePermissions(ctx)
+	if err != nil {
+		log.Error("failed to list role permissions", "error", err)
+
+		return nil, err
+	}
+
+	log.Info("role permissions have listed", "roles", len(rolePermissions))
+
+	return rolePermissions, nil
+}
+
+func (u *userServiceImpl) updatePassword(ctx context.Context, log *slog.Logger, id int64, newPassword string) error {
+	passwordHash, err := xpassword.Hash(newPassword)
+	if err != nil {
+		log.Error("failed to hash a password", "error", err)
+
+		return err
+	}
+
+	if err = u.userStorage.UpdatePassword(ctx, id, passwordHash); err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Warn("user not found by id", "error", err)
+
+			return ErrUserNotFound
+		}
+
+		log.Error("failed to update a user password", "error", err)
+
+		return err
+	}
+
+	return nil
+}
@@ -1,5 +1,20 @@
 package user
 
+import "context"
+
 type UserStorage interface {
-	FindByEmail(email string) (*User, error)
+	Create(ctx context.Context, email, passwordHash string, role UserRole) (*User, error)
+	FindById(ctx context.Context, id int64) (*User, error)
+	FindByEmail(ctx context.Context, email string) (*User, error)
+	List(ctx context.Context, limit, offset int) ([]*User, error)
+	Count(ctx context.Context) (int64, error)
+	UpdateRole(ctx context.Context, id int64, role UserRole) (*User, error)
+
+	// Disable disables the user and revokes all of their refresh tokens in the same transaction
+	Disable(ctx context.Context, id int64) (*User, error)
+
+	// UpdatePassword sets the new password hash and revokes all of the user's refresh tokens in the same transaction
+	UpdatePassword(ctx context.Context, id int64, passwordHash string) error
+
+	ListRolePermissions(ctx context.Context) (RolePermissions, error)
 }
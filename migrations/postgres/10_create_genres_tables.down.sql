@@ -0,0 +1,4 @@
+DELETE FROM permissions WHERE name IN ('genres:read', 'genres:write', 'genres:delete');
+
+DROP TABLE IF EXISTS films_genres;
+DROP TABLE IF EXISTS genres;
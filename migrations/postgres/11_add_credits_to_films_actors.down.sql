@@ -0,0 +1,8 @@
+DELETE FROM films_actors WHERE role <> 'actor';
+
+DROP INDEX IF EXISTS films_actors_film_id_role_idx;
+
+ALTER TABLE films_actors
+    DROP COLUMN IF EXISTS billing_order,
+    DROP COLUMN IF EXISTS character_name,
+    DROP COLUMN IF EXISTS role;
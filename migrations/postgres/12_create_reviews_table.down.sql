@@ -0,0 +1,7 @@
+DELETE FROM permissions WHERE name = 'reviews:write';
+
+ALTER TABLE films
+    DROP COLUMN IF EXISTS community_score,
+    DROP COLUMN IF EXISTS votes_count;
+
+DROP TABLE IF EXISTS reviews;
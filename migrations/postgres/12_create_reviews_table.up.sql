@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS reviews
+(
+    id         SERIAL PRIMARY KEY,
+    film_id    INTEGER     NOT NULL REFERENCES films (id) ON DELETE CASCADE,
+    user_id    INTEGER     NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    rating     SMALLINT    NOT NULL CHECK (rating >= 1 AND rating <= 10),
+    text       VARCHAR(5000),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (film_id, user_id)
+);
+CREATE INDEX IF NOT EXISTS reviews_film_id_created_at_idx ON reviews (film_id, created_at DESC, id DESC);
+
+-- Aggregates of reviews are kept with films, so that films can be sorted and paginated by them --
+ALTER TABLE films
+    ADD COLUMN IF NOT EXISTS community_score NUMERIC(4, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS votes_count     INTEGER       NOT NULL DEFAULT 0;
+
+INSERT INTO permissions(name)
+VALUES ('reviews:write')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO roles_permissions(role, permission)
+VALUES ('user', 'reviews:write'),
+       ('editor', 'reviews:write'),
+       ('admin', 'reviews:write')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS user_film_lists
+(
+    user_id  INTEGER     NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    list     VARCHAR(20) NOT NULL CHECK (list IN ('watchlist', 'watched')),
+    film_id  INTEGER     NOT NULL REFERENCES films (id) ON DELETE CASCADE,
+    added_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, list, film_id)
+);
+CREATE INDEX IF NOT EXISTS user_film_lists_film_id_idx ON user_film_lists (film_id);
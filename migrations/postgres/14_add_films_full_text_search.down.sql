@@ -0,0 +1,19 @@
+DROP TRIGGER IF EXISTS actors_search_vector ON actors;
+DROP TRIGGER IF EXISTS films_actors_deleted_search_vector ON films_actors;
+DROP TRIGGER IF EXISTS films_actors_inserted_search_vector ON films_actors;
+DROP TRIGGER IF EXISTS films_search_vector ON films;
+
+DROP FUNCTION IF EXISTS actors_search_vector_trigger();
+DROP FUNCTION IF EXISTS films_actors_deleted_search_vector_trigger();
+DROP FUNCTION IF EXISTS films_actors_inserted_search_vector_trigger();
+DROP FUNCTION IF EXISTS films_search_vector_trigger();
+DROP FUNCTION IF EXISTS refresh_film_search_vector(INTEGER);
+
+DROP INDEX IF EXISTS actors_name_trgm_idx;
+DROP INDEX IF EXISTS films_title_trgm_idx;
+DROP INDEX IF EXISTS films_search_vector_idx;
+
+ALTER TABLE films
+    DROP COLUMN IF EXISTS search_vector;
+
+-- The pg_trgm extension is kept: it may have been installed before and be used by other objects of the database --
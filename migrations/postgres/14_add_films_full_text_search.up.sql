@@ -0,0 +1,88 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE films
+    ADD COLUMN IF NOT EXISTS search_vector TSVECTOR NOT NULL DEFAULT ''::TSVECTOR;
+
+CREATE INDEX IF NOT EXISTS films_search_vector_idx ON films USING GIN (search_vector);
+
+-- Trigram indexes serve fuzzy matching of titles and names as well as case-insensitive fragment search --
+CREATE INDEX IF NOT EXISTS films_title_trgm_idx ON films USING GIN (title gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS actors_name_trgm_idx ON actors USING GIN (name gin_trgm_ops);
+
+-- The search vector of a film holds its title, names of its credits and its description, weighted in this order.
+-- The 'simple' configuration does not stem words, so that titles and names in any language are matched as they are --
+CREATE OR REPLACE FUNCTION refresh_film_search_vector(refreshed_film_id INTEGER) RETURNS VOID AS
+$$
+UPDATE films AS f
+SET search_vector = setweight(to_tsvector('simple', f.title), 'A') ||
+                    setweight(to_tsvector('simple', COALESCE((SELECT string_agg(a.name, ' ')
+                                                              FROM films_actors AS fa
+                                                                  INNER JOIN actors AS a ON a.id = fa.actor_id
+                                                              WHERE fa.film_id = f.id), '')), 'B') ||
+                    setweight(to_tsvector('simple', COALESCE(f.description, '')), 'C')
+WHERE f.id = refreshed_film_id;
+$$ LANGUAGE SQL;
+
+CREATE OR REPLACE FUNCTION films_search_vector_trigger() RETURNS TRIGGER AS
+$$
+BEGIN
+    PERFORM refresh_film_search_vector(NEW.id);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Credits are inserted and deleted in batches, so the triggers on them are statement-level
+-- and refresh every affected film once, however many of its credits have changed --
+CREATE OR REPLACE FUNCTION films_actors_inserted_search_vector_trigger() RETURNS TRIGGER AS
+$$
+BEGIN
+    PERFORM refresh_film_search_vector(c.film_id) FROM (SELECT DISTINCT film_id FROM inserted_credits) AS c;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION films_actors_deleted_search_vector_trigger() RETURNS TRIGGER AS
+$$
+BEGIN
+    PERFORM refresh_film_search_vector(c.film_id) FROM (SELECT DISTINCT film_id FROM deleted_credits) AS c;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION actors_search_vector_trigger() RETURNS TRIGGER AS
+$$
+BEGIN
+    PERFORM refresh_film_search_vector(fa.film_id) FROM films_actors AS fa WHERE fa.actor_id = NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- The search vector itself is not in the column lists, so refreshing it does not fire the triggers again --
+CREATE TRIGGER films_search_vector
+    AFTER INSERT OR UPDATE OF title, description
+    ON films
+    FOR EACH ROW
+EXECUTE FUNCTION films_search_vector_trigger();
+
+-- A trigger with transition tables handles a single event, so insertion and deletion have a trigger each --
+CREATE TRIGGER films_actors_inserted_search_vector
+    AFTER INSERT
+    ON films_actors
+    REFERENCING NEW TABLE AS inserted_credits
+    FOR EACH STATEMENT
+EXECUTE FUNCTION films_actors_inserted_search_vector_trigger();
+
+CREATE TRIGGER films_actors_deleted_search_vector
+    AFTER DELETE
+    ON films_actors
+    REFERENCING OLD TABLE AS deleted_credits
+    FOR EACH STATEMENT
+EXECUTE FUNCTION films_actors_deleted_search_vector_trigger();
+
+CREATE TRIGGER actors_search_vector
+    AFTER UPDATE OF name
+    ON actors
+    FOR EACH ROW
+EXECUTE FUNCTION actors_search_vector_trigger();
+
+SELECT refresh_film_search_vector(id) FROM films;
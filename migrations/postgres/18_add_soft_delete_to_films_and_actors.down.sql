@@ -0,0 +1,36 @@
+DELETE FROM permissions WHERE name = 'trash:manage';
+
+-- Deleted films and actors would appear again without the columns, so they are purged --
+DELETE FROM films WHERE deleted_at IS NOT NULL;
+DELETE FROM actors WHERE deleted_at IS NOT NULL;
+
+DROP TRIGGER IF EXISTS actors_search_vector ON actors;
+
+CREATE TRIGGER actors_search_vector
+    AFTER UPDATE OF name
+    ON actors
+    FOR EACH ROW
+EXECUTE FUNCTION actors_search_vector_trigger();
+
+CREATE OR REPLACE FUNCTION refresh_film_search_vector(refreshed_film_id INTEGER) RETURNS VOID AS
+$$
+UPDATE films AS f
+SET search_vector = setweight(to_tsvector('simple', f.title), 'A') ||
+                    setweight(to_tsvector('simple', COALESCE((SELECT string_agg(a.name, ' ')
+                                                              FROM films_actors AS fa
+                                                                  INNER JOIN actors AS a ON a.id = fa.actor_id
+                                                              WHERE fa.film_id = f.id), '')), 'B') ||
+                    setweight(to_tsvector('simple', COALESCE(f.description, '')), 'C')
+WHERE f.id = refreshed_film_id;
+$$ LANGUAGE SQL;
+
+DROP INDEX IF EXISTS actors_deleted_at_idx;
+DROP INDEX IF EXISTS films_deleted_at_idx;
+
+ALTER TABLE actors
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS deleted_by;
+
+ALTER TABLE films
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS deleted_by;
@@ -0,0 +1,41 @@
+-- Deleted films and actors stay in the tables with their credits until the retention job purges them --
+ALTER TABLE films
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS deleted_by INTEGER REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE actors
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS deleted_by INTEGER REFERENCES users (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS films_deleted_at_idx ON films (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS actors_deleted_at_idx ON actors (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- Names of deleted actors are not searchable, so the search vectors of their films are refreshed on deletion and restore --
+CREATE OR REPLACE FUNCTION refresh_film_search_vector(refreshed_film_id INTEGER) RETURNS VOID AS
+$$
+UPDATE films AS f
+SET search_vector = setweight(to_tsvector('simple', f.title), 'A') ||
+                    setweight(to_tsvector('simple', COALESCE((SELECT string_agg(a.name, ' ')
+                                                              FROM films_actors AS fa
+                                                                  INNER JOIN actors AS a ON a.id = fa.actor_id
+                                                              WHERE fa.film_id = f.id
+                                                                AND a.deleted_at IS NULL), '')), 'B') ||
+                    setweight(to_tsvector('simple', COALESCE(f.description, '')), 'C')
+WHERE f.id = refreshed_film_id;
+$$ LANGUAGE SQL;
+
+DROP TRIGGER IF EXISTS actors_search_vector ON actors;
+
+CREATE TRIGGER actors_search_vector
+    AFTER UPDATE OF name, deleted_at
+    ON actors
+    FOR EACH ROW
+EXECUTE FUNCTION actors_search_vector_trigger();
+
+INSERT INTO permissions(name)
+VALUES ('trash:manage')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO roles_permissions(role, permission)
+VALUES ('admin', 'trash:manage')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,56 @@
+-- A revision is the state of a film or an actor after a change, numbered by the version the change has produced --
+CREATE TABLE IF NOT EXISTS film_revisions
+(
+    film_id      INTEGER      NOT NULL REFERENCES films (id) ON DELETE CASCADE,
+    revision     BIGINT       NOT NULL,
+    user_id      INTEGER REFERENCES users (id) ON DELETE SET NULL,
+    title        VARCHAR(150) NOT NULL,
+    description  VARCHAR(1000),
+    release_date DATE         NOT NULL,
+    rating       SMALLINT     NOT NULL,
+    credits      JSONB        NOT NULL DEFAULT '[]',
+    genre_ids    INTEGER[]    NOT NULL DEFAULT '{}',
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (film_id, revision)
+);
+
+CREATE TABLE IF NOT EXISTS actor_revisions
+(
+    actor_id   INTEGER      NOT NULL REFERENCES actors (id) ON DELETE CASCADE,
+    revision   BIGINT       NOT NULL,
+    user_id    INTEGER REFERENCES users (id) ON DELETE SET NULL,
+    name       VARCHAR(100) NOT NULL,
+    sex        SMALLINT     NOT NULL,
+    birthdate  DATE         NOT NULL,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (actor_id, revision)
+);
+
+-- The current state of existing films and actors is their first known revision --
+INSERT INTO film_revisions (film_id, revision, user_id, title, description, release_date, rating, credits, genre_ids)
+SELECT f.id,
+       f.version,
+       COALESCE(f.updated_by, f.created_by),
+       f.title,
+       f.description,
+       f.release_date,
+       COALESCE(f.rating, 0),
+       COALESCE((SELECT jsonb_agg(jsonb_build_object('actor_id', fa.actor_id,
+                                                     'role', fa.role,
+                                                     'character_name', COALESCE(fa.character_name, ''),
+                                                     'billing_order', fa.billing_order)
+                                  ORDER BY fa.billing_order, fa.id)
+                 FROM films_actors AS fa
+                     INNER JOIN actors AS a ON a.id = fa.actor_id
+                 WHERE fa.film_id = f.id
+                   AND a.deleted_at IS NULL), '[]'),
+       COALESCE((SELECT array_agg(fg.genre_id ORDER BY fg.genre_id)
+                 FROM films_genres AS fg
+                 WHERE fg.film_id = f.id), '{}')
+FROM films AS f
+ON CONFLICT DO NOTHING;
+
+INSERT INTO actor_revisions (actor_id, revision, user_id, name, sex, birthdate)
+SELECT a.id, a.version, COALESCE(a.updated_by, a.created_by), a.name, a.sex, a.birthdate
+FROM actors AS a
+ON CONFLICT DO NOTHING;
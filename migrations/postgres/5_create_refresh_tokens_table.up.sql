@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS refresh_tokens
+(
+    id         SERIAL PRIMARY KEY,
+    user_id    INT                NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMPTZ        NOT NULL,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ        NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS user_id_idx ON refresh_tokens (user_id);
+
+COMMENT ON COLUMN refresh_tokens.token_hash IS 'Hex encoded SHA-256 hash of the refresh token, the token itself is never stored';
@@ -0,0 +1,12 @@
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS users_role_fkey;
+-- Only the 'user' and 'admin' roles existed before, so editors and users of any other roles become plain users
+UPDATE users
+SET role = 'user'
+WHERE role NOT IN ('user', 'admin');
+ALTER TABLE users
+    ADD CONSTRAINT users_role_check CHECK ( role IN ('user', 'admin') );
+
+DROP TABLE IF EXISTS roles_permissions;
+DROP TABLE IF EXISTS permissions;
+DROP TABLE IF EXISTS roles;
@@ -0,0 +1,7 @@
+ALTER TABLE films
+    DROP COLUMN IF EXISTS created_by,
+    DROP COLUMN IF EXISTS updated_by;
+
+ALTER TABLE actors
+    DROP COLUMN IF EXISTS created_by,
+    DROP COLUMN IF EXISTS updated_by;
@@ -0,0 +1,7 @@
+ALTER TABLE films
+    ADD COLUMN IF NOT EXISTS created_by INTEGER REFERENCES users (id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS updated_by INTEGER REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE actors
+    ADD COLUMN IF NOT EXISTS created_by INTEGER REFERENCES users (id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS updated_by INTEGER REFERENCES users (id) ON DELETE SET NULL;
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS audit_events
+(
+    id          BIGSERIAL PRIMARY KEY,
+    user_id     INTEGER REFERENCES users (id) ON DELETE SET NULL,
+    operation   VARCHAR(20) NOT NULL,
+    entity_type VARCHAR(20) NOT NULL,
+    entity_id   BIGINT      NOT NULL,
+    before      JSONB,
+    after       JSONB,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS audit_events_entity_idx ON audit_events (entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS audit_events_user_id_idx ON audit_events (user_id);
+CREATE INDEX IF NOT EXISTS audit_events_created_at_idx ON audit_events (created_at);
+
+INSERT INTO permissions(name)
+VALUES ('audit:read')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO roles_permissions(role, permission)
+VALUES ('admin', 'audit:read')
+ON CONFLICT DO NOTHING;
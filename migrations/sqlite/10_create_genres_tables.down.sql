@@ -0,0 +1,5 @@
+DELETE FROM roles_permissions WHERE permission IN ('genres:read', 'genres:write', 'genres:delete');
+DELETE FROM permissions WHERE name IN ('genres:read', 'genres:write', 'genres:delete');
+
+DROP TABLE IF EXISTS films_genres;
+DROP TABLE IF EXISTS genres;
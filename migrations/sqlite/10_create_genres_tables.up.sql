@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS genres
+(
+    id   INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(50) UNIQUE NOT NULL CHECK (LENGTH(name) > 0)
+);
+
+CREATE TABLE IF NOT EXISTS films_genres
+(
+    film_id  INTEGER NOT NULL REFERENCES films (id) ON DELETE CASCADE,
+    genre_id INTEGER NOT NULL REFERENCES genres (id) ON DELETE CASCADE,
+    PRIMARY KEY (film_id, genre_id)
+);
+CREATE INDEX IF NOT EXISTS films_genres_genre_id_idx ON films_genres (genre_id);
+
+INSERT INTO permissions(name)
+VALUES ('genres:read'),
+       ('genres:write'),
+       ('genres:delete')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO roles_permissions(role, permission)
+VALUES ('user', 'genres:read'),
+
+       ('editor', 'genres:read'),
+       ('editor', 'genres:write'),
+
+       ('admin', 'genres:read'),
+       ('admin', 'genres:write'),
+       ('admin', 'genres:delete')
+ON CONFLICT DO NOTHING;
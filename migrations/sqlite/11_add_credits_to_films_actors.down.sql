@@ -0,0 +1,10 @@
+DELETE FROM films_actors WHERE role <> 'actor';
+
+DROP INDEX IF EXISTS films_actors_film_id_role_idx;
+
+ALTER TABLE films_actors
+    DROP COLUMN billing_order;
+ALTER TABLE films_actors
+    DROP COLUMN character_name;
+ALTER TABLE films_actors
+    DROP COLUMN role;
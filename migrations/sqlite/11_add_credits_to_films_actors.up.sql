@@ -0,0 +1,10 @@
+-- Every person attached to a film gets a role. Existing links are actors --
+ALTER TABLE films_actors
+    ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'actor'
+        CHECK (role IN ('actor', 'director', 'writer', 'producer', 'composer'));
+ALTER TABLE films_actors
+    ADD COLUMN character_name VARCHAR(100);
+ALTER TABLE films_actors
+    ADD COLUMN billing_order INTEGER NOT NULL DEFAULT 0 CHECK (billing_order >= 0);
+
+CREATE INDEX IF NOT EXISTS films_actors_film_id_role_idx ON films_actors (film_id, role);
@@ -0,0 +1,9 @@
+DELETE FROM roles_permissions WHERE permission = 'reviews:write';
+DELETE FROM permissions WHERE name = 'reviews:write';
+
+ALTER TABLE films
+    DROP COLUMN community_score;
+ALTER TABLE films
+    DROP COLUMN votes_count;
+
+DROP TABLE IF EXISTS reviews;
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS reviews
+(
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    film_id    INTEGER   NOT NULL REFERENCES films (id) ON DELETE CASCADE,
+    user_id    INTEGER   NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    rating     SMALLINT  NOT NULL CHECK (rating >= 1 AND rating <= 10),
+    text       VARCHAR(5000),
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    UNIQUE (film_id, user_id)
+);
+CREATE INDEX IF NOT EXISTS reviews_film_id_created_at_idx ON reviews (film_id, created_at DESC, id DESC);
+
+-- Aggregates of reviews are kept with films, so that films can be sorted and paginated by them --
+ALTER TABLE films
+    ADD COLUMN community_score REAL NOT NULL DEFAULT 0;
+ALTER TABLE films
+    ADD COLUMN votes_count INTEGER NOT NULL DEFAULT 0;
+
+INSERT INTO permissions(name)
+VALUES ('reviews:write')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO roles_permissions(role, permission)
+VALUES ('user', 'reviews:write'),
+       ('editor', 'reviews:write'),
+       ('admin', 'reviews:write')
+ON CONFLICT DO NOTHING;
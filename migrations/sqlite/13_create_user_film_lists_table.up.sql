@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS user_film_lists
+(
+    user_id  INTEGER     NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    list     VARCHAR(20) NOT NULL CHECK (list IN ('watchlist', 'watched')),
+    film_id  INTEGER     NOT NULL REFERENCES films (id) ON DELETE CASCADE,
+    added_at TIMESTAMP   NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    PRIMARY KEY (user_id, list, film_id)
+);
+CREATE INDEX IF NOT EXISTS user_film_lists_film_id_idx ON user_film_lists (film_id);
@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS actors_search_update;
+DROP TRIGGER IF EXISTS films_actors_search_delete;
+DROP TRIGGER IF EXISTS films_actors_search_insert;
+DROP TRIGGER IF EXISTS films_search_delete;
+DROP TRIGGER IF EXISTS films_search_update;
+DROP TRIGGER IF EXISTS films_search_insert;
+
+DROP VIEW IF EXISTS films_search_source;
+
+DROP TABLE IF EXISTS films_search;
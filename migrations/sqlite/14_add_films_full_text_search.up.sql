@@ -0,0 +1,76 @@
+-- The search index of films holds titles, names of their credits and descriptions, the row id of an entry is the film id.
+-- The 'unicode61' tokenizer does not stem words, so that titles and names in any language are matched as they are --
+CREATE VIRTUAL TABLE IF NOT EXISTS films_search USING fts5
+(
+    title,
+    names,
+    description,
+    tokenize = 'unicode61 remove_diacritics 2'
+);
+
+-- The view is the source of the search index entries, the triggers below copy its rows of the changed films into the index --
+CREATE VIEW IF NOT EXISTS films_search_source AS
+SELECT f.id,
+       f.title,
+       COALESCE((SELECT group_concat(a.name, ' ')
+                 FROM films_actors AS fa
+                     INNER JOIN actors AS a ON a.id = fa.actor_id
+                 WHERE fa.film_id = f.id), '') AS names,
+       COALESCE(f.description, '')           AS description
+FROM films AS f;
+
+CREATE TRIGGER IF NOT EXISTS films_search_insert
+    AFTER INSERT
+    ON films
+BEGIN
+    INSERT INTO films_search (rowid, title, names, description)
+    SELECT id, title, names, description FROM films_search_source WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS films_search_update
+    AFTER UPDATE OF title, description
+    ON films
+BEGIN
+    DELETE FROM films_search WHERE rowid = NEW.id;
+    INSERT INTO films_search (rowid, title, names, description)
+    SELECT id, title, names, description FROM films_search_source WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS films_search_delete
+    AFTER DELETE
+    ON films
+BEGIN
+    DELETE FROM films_search WHERE rowid = OLD.id;
+END;
+
+-- Entries are taken from the view, so that an entry is never created for a film deleted along with its credits --
+CREATE TRIGGER IF NOT EXISTS films_actors_search_insert
+    AFTER INSERT
+    ON films_actors
+BEGIN
+    DELETE FROM films_search WHERE rowid = NEW.film_id;
+    INSERT INTO films_search (rowid, title, names, description)
+    SELECT id, title, names, description FROM films_search_source WHERE id = NEW.film_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS films_actors_search_delete
+    AFTER DELETE
+    ON films_actors
+BEGIN
+    DELETE FROM films_search WHERE rowid = OLD.film_id;
+    INSERT INTO films_search (rowid, title, names, description)
+    SELECT id, title, names, description FROM films_search_source WHERE id = OLD.film_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS actors_search_update
+    AFTER UPDATE OF name
+    ON actors
+BEGIN
+    DELETE FROM films_search WHERE rowid IN (SELECT film_id FROM films_actors WHERE actor_id = NEW.id);
+    INSERT INTO films_search (rowid, title, names, description)
+    SELECT id, title, names, description FROM films_search_source
+    WHERE id IN (SELECT film_id FROM films_actors WHERE actor_id = NEW.id);
+END;
+
+INSERT INTO films_search (rowid, title, names, description)
+SELECT id, title, names, description FROM films_search_source;
@@ -0,0 +1,2 @@
+DELETE FROM roles_permissions WHERE permission = 'catalog:import';
+DELETE FROM permissions WHERE name = 'catalog:import';
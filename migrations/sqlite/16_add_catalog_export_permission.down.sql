@@ -0,0 +1,2 @@
+DELETE FROM roles_permissions WHERE permission = 'catalog:export';
+DELETE FROM permissions WHERE name = 'catalog:export';
@@ -0,0 +1,7 @@
+INSERT INTO permissions(name)
+VALUES ('catalog:export')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO roles_permissions(role, permission)
+VALUES ('admin', 'catalog:export')
+ON CONFLICT DO NOTHING;
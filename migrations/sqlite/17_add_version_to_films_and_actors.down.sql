@@ -0,0 +1,5 @@
+ALTER TABLE films
+    DROP COLUMN version;
+
+ALTER TABLE actors
+    DROP COLUMN version;
@@ -0,0 +1,5 @@
+ALTER TABLE films
+    ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+
+ALTER TABLE actors
+    ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,50 @@
+DELETE FROM roles_permissions WHERE permission = 'trash:manage';
+DELETE FROM permissions WHERE name = 'trash:manage';
+
+-- Deleted films and actors would appear again without the columns, so they are purged.
+-- Foreign keys are off while migrating, so the rows referring to them are deleted explicitly --
+DELETE FROM films_actors
+WHERE film_id IN (SELECT id FROM films WHERE deleted_at IS NOT NULL)
+   OR actor_id IN (SELECT id FROM actors WHERE deleted_at IS NOT NULL);
+DELETE FROM films_genres WHERE film_id IN (SELECT id FROM films WHERE deleted_at IS NOT NULL);
+DELETE FROM reviews WHERE film_id IN (SELECT id FROM films WHERE deleted_at IS NOT NULL);
+DELETE FROM user_film_lists WHERE film_id IN (SELECT id FROM films WHERE deleted_at IS NOT NULL);
+DELETE FROM films WHERE deleted_at IS NOT NULL;
+DELETE FROM actors WHERE deleted_at IS NOT NULL;
+
+DROP TRIGGER IF EXISTS actors_search_update;
+
+CREATE TRIGGER actors_search_update
+    AFTER UPDATE OF name
+    ON actors
+BEGIN
+    DELETE FROM films_search WHERE rowid IN (SELECT film_id FROM films_actors WHERE actor_id = NEW.id);
+    INSERT INTO films_search (rowid, title, names, description)
+    SELECT id, title, names, description FROM films_search_source
+    WHERE id IN (SELECT film_id FROM films_actors WHERE actor_id = NEW.id);
+END;
+
+DROP VIEW IF EXISTS films_search_source;
+
+CREATE VIEW films_search_source AS
+SELECT f.id,
+       f.title,
+       COALESCE((SELECT group_concat(a.name, ' ')
+                 FROM films_actors AS fa
+                     INNER JOIN actors AS a ON a.id = fa.actor_id
+                 WHERE fa.film_id = f.id), '') AS names,
+       COALESCE(f.description, '')           AS description
+FROM films AS f;
+
+DROP INDEX IF EXISTS actors_deleted_at_idx;
+DROP INDEX IF EXISTS films_deleted_at_idx;
+
+ALTER TABLE actors
+    DROP COLUMN deleted_at;
+ALTER TABLE actors
+    DROP COLUMN deleted_by;
+
+ALTER TABLE films
+    DROP COLUMN deleted_at;
+ALTER TABLE films
+    DROP COLUMN deleted_by;
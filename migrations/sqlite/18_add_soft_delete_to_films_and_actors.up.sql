@@ -0,0 +1,47 @@
+-- Deleted films and actors stay in the tables with their credits until the retention job purges them --
+ALTER TABLE films
+    ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE films
+    ADD COLUMN deleted_by INTEGER REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE actors
+    ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE actors
+    ADD COLUMN deleted_by INTEGER REFERENCES users (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS films_deleted_at_idx ON films (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS actors_deleted_at_idx ON actors (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- Names of deleted actors are not searchable, so the search index entries of their films are refreshed on deletion and restore --
+DROP VIEW IF EXISTS films_search_source;
+
+CREATE VIEW films_search_source AS
+SELECT f.id,
+       f.title,
+       COALESCE((SELECT group_concat(a.name, ' ')
+                 FROM films_actors AS fa
+                     INNER JOIN actors AS a ON a.id = fa.actor_id
+                 WHERE fa.film_id = f.id
+                   AND a.deleted_at IS NULL), '') AS names,
+       COALESCE(f.description, '')              AS description
+FROM films AS f;
+
+DROP TRIGGER IF EXISTS actors_search_update;
+
+CREATE TRIGGER actors_search_update
+    AFTER UPDATE OF name, deleted_at
+    ON actors
+BEGIN
+    DELETE FROM films_search WHERE rowid IN (SELECT film_id FROM films_actors WHERE actor_id = NEW.id);
+    INSERT INTO films_search (rowid, title, names, description)
+    SELECT id, title, names, description FROM films_search_source
+    WHERE id IN (SELECT film_id FROM films_actors WHERE actor_id = NEW.id);
+END;
+
+INSERT INTO permissions(name)
+VALUES ('trash:manage')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO roles_permissions(role, permission)
+VALUES ('admin', 'trash:manage')
+ON CONFLICT DO NOTHING;
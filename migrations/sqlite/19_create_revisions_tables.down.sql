@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS actor_revisions;
+
+DROP TABLE IF EXISTS film_revisions;
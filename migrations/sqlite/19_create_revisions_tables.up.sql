@@ -0,0 +1,63 @@
+-- A revision is the state of a film or an actor after a change, numbered by the version the change has produced.
+-- Credits of a film revision are a JSON array of objects and its genre ids are a JSON array of numbers --
+CREATE TABLE IF NOT EXISTS film_revisions
+(
+    film_id      INTEGER      NOT NULL REFERENCES films (id) ON DELETE CASCADE,
+    revision     BIGINT       NOT NULL,
+    user_id      INTEGER REFERENCES users (id) ON DELETE SET NULL,
+    title        VARCHAR(150) NOT NULL,
+    description  VARCHAR(1000),
+    release_date DATE         NOT NULL,
+    rating       SMALLINT     NOT NULL,
+    credits      TEXT         NOT NULL DEFAULT '[]',
+    genre_ids    TEXT         NOT NULL DEFAULT '[]',
+    created_at   TIMESTAMP    NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    PRIMARY KEY (film_id, revision)
+);
+
+CREATE TABLE IF NOT EXISTS actor_revisions
+(
+    actor_id   INTEGER      NOT NULL REFERENCES actors (id) ON DELETE CASCADE,
+    revision   BIGINT       NOT NULL,
+    user_id    INTEGER REFERENCES users (id) ON DELETE SET NULL,
+    name       VARCHAR(100) NOT NULL,
+    sex        SMALLINT     NOT NULL,
+    birthdate  DATE         NOT NULL,
+    created_at TIMESTAMP    NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    PRIMARY KEY (actor_id, revision)
+);
+
+-- The current state of existing films and actors is their first known revision.
+-- The arrays are aggregated from ordered subqueries, which SQLite aggregates in their order --
+INSERT INTO film_revisions (film_id, revision, user_id, title, description, release_date, rating, credits, genre_ids)
+SELECT f.id,
+       f.version,
+       COALESCE(f.updated_by, f.created_by),
+       f.title,
+       f.description,
+       f.release_date,
+       COALESCE(f.rating, 0),
+       (SELECT json_group_array(json(c.credit))
+        FROM (SELECT json_object('actor_id', fa.actor_id,
+                                 'role', fa.role,
+                                 'character_name', COALESCE(fa.character_name, ''),
+                                 'billing_order', fa.billing_order) AS credit
+              FROM films_actors AS fa
+                  INNER JOIN actors AS a ON a.id = fa.actor_id
+              WHERE fa.film_id = f.id
+                AND a.deleted_at IS NULL
+              ORDER BY fa.billing_order, fa.id) AS c),
+       (SELECT json_group_array(g.genre_id)
+        FROM (SELECT fg.genre_id
+              FROM films_genres AS fg
+              WHERE fg.film_id = f.id
+              ORDER BY fg.genre_id) AS g)
+FROM films AS f
+WHERE TRUE
+ON CONFLICT DO NOTHING;
+
+INSERT INTO actor_revisions (actor_id, revision, user_id, name, sex, birthdate)
+SELECT a.id, a.version, COALESCE(a.updated_by, a.created_by), a.name, a.sex, a.birthdate
+FROM actors AS a
+WHERE TRUE
+ON CONFLICT DO NOTHING;
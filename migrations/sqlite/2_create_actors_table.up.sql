@@ -0,0 +1,9 @@
+-- Codes of the 'sex' column represent human sexes according to ISO/IEC 5218 --
+CREATE TABLE IF NOT EXISTS actors
+(
+    id        INTEGER PRIMARY KEY AUTOINCREMENT,
+    name      VARCHAR(100)                           NOT NULL,
+    sex       SMALLINT CHECK ( sex IN (0, 1, 2, 9) ) NOT NULL DEFAULT 0,
+    birthdate DATE                                   NOT NULL
+);
+CREATE INDEX IF NOT EXISTS name_idx ON actors (name);
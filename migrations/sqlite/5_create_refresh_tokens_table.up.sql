@@ -0,0 +1,12 @@
+-- Timestamps are kept as UTC text, which sorts in time order and is read back as time.Time by the driver.
+-- The 'token_hash' column holds the hex encoded SHA-256 hash of the refresh token, the token itself is never stored --
+CREATE TABLE IF NOT EXISTS refresh_tokens
+(
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id    INTEGER            NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMP          NOT NULL,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP          NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+CREATE INDEX IF NOT EXISTS user_id_idx ON refresh_tokens (user_id);
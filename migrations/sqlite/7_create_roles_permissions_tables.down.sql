@@ -0,0 +1,25 @@
+-- Only the 'user' and 'admin' roles existed before, so editors and users of any other roles become plain users
+UPDATE users
+SET role = 'user'
+WHERE role NOT IN ('user', 'admin');
+
+CREATE TABLE users_new
+(
+    id       INTEGER PRIMARY KEY AUTOINCREMENT,
+    email    VARCHAR(50) UNIQUE NOT NULL,
+    password VARCHAR            NOT NULL,
+    role     VARCHAR(50)        NOT NULL CHECK ( role IN ('user', 'admin') ),
+    disabled BOOLEAN            NOT NULL DEFAULT FALSE
+);
+
+INSERT INTO users_new(id, email, password, role, disabled)
+SELECT id, email, password, role, disabled
+FROM users;
+
+DROP TABLE users;
+ALTER TABLE users_new RENAME TO users;
+CREATE INDEX IF NOT EXISTS email_idx ON users (email);
+
+DROP TABLE IF EXISTS roles_permissions;
+DROP TABLE IF EXISTS permissions;
+DROP TABLE IF EXISTS roles;
@@ -0,0 +1,70 @@
+CREATE TABLE IF NOT EXISTS roles
+(
+    name VARCHAR(50) PRIMARY KEY
+);
+
+CREATE TABLE IF NOT EXISTS permissions
+(
+    name VARCHAR(50) PRIMARY KEY
+);
+
+CREATE TABLE IF NOT EXISTS roles_permissions
+(
+    role       VARCHAR(50) NOT NULL REFERENCES roles (name) ON DELETE CASCADE,
+    permission VARCHAR(50) NOT NULL REFERENCES permissions (name) ON DELETE CASCADE,
+    PRIMARY KEY (role, permission)
+);
+
+INSERT INTO roles(name)
+VALUES ('user'),
+       ('editor'),
+       ('admin')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO permissions(name)
+VALUES ('films:read'),
+       ('films:write'),
+       ('films:delete'),
+       ('actors:read'),
+       ('actors:write'),
+       ('actors:delete'),
+       ('users:manage')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO roles_permissions(role, permission)
+VALUES ('user', 'films:read'),
+       ('user', 'actors:read'),
+
+       ('editor', 'films:read'),
+       ('editor', 'films:write'),
+       ('editor', 'actors:read'),
+       ('editor', 'actors:write'),
+
+       ('admin', 'films:read'),
+       ('admin', 'films:write'),
+       ('admin', 'films:delete'),
+       ('admin', 'actors:read'),
+       ('admin', 'actors:write'),
+       ('admin', 'actors:delete'),
+       ('admin', 'users:manage')
+ON CONFLICT DO NOTHING;
+
+-- Roles are no longer hard-coded: any role from 'roles' table can be assigned.
+-- SQLite cannot change constraints of a table, so the table is rebuilt. Migrations run with foreign keys off,
+-- so that dropping the old table does not cascade to the refresh tokens --
+CREATE TABLE users_new
+(
+    id       INTEGER PRIMARY KEY AUTOINCREMENT,
+    email    VARCHAR(50) UNIQUE NOT NULL,
+    password VARCHAR            NOT NULL,
+    role     VARCHAR(50)        NOT NULL REFERENCES roles (name),
+    disabled BOOLEAN            NOT NULL DEFAULT FALSE
+);
+
+INSERT INTO users_new(id, email, password, role, disabled)
+SELECT id, email, password, role, disabled
+FROM users;
+
+DROP TABLE users;
+ALTER TABLE users_new RENAME TO users;
+CREATE INDEX IF NOT EXISTS email_idx ON users (email);
@@ -0,0 +1,9 @@
+ALTER TABLE films
+    DROP COLUMN created_by;
+ALTER TABLE films
+    DROP COLUMN updated_by;
+
+ALTER TABLE actors
+    DROP COLUMN created_by;
+ALTER TABLE actors
+    DROP COLUMN updated_by;
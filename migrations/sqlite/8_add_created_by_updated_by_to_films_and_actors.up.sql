@@ -0,0 +1,9 @@
+ALTER TABLE films
+    ADD COLUMN created_by INTEGER REFERENCES users (id) ON DELETE SET NULL;
+ALTER TABLE films
+    ADD COLUMN updated_by INTEGER REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE actors
+    ADD COLUMN created_by INTEGER REFERENCES users (id) ON DELETE SET NULL;
+ALTER TABLE actors
+    ADD COLUMN updated_by INTEGER REFERENCES users (id) ON DELETE SET NULL;
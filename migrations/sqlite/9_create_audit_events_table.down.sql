@@ -0,0 +1,5 @@
+-- Foreign keys are off while migrating, so grants of the permission are deleted along with it --
+DELETE FROM roles_permissions WHERE permission = 'audit:read';
+DELETE FROM permissions WHERE name = 'audit:read';
+
+DROP TABLE IF EXISTS audit_events;
@@ -0,0 +1,23 @@
+-- Snapshots of audited entities are JSON text --
+CREATE TABLE IF NOT EXISTS audit_events
+(
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id     INTEGER REFERENCES users (id) ON DELETE SET NULL,
+    operation   VARCHAR(20) NOT NULL,
+    entity_type VARCHAR(20) NOT NULL,
+    entity_id   BIGINT      NOT NULL,
+    before      TEXT,
+    after       TEXT,
+    created_at  TIMESTAMP   NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+CREATE INDEX IF NOT EXISTS audit_events_entity_idx ON audit_events (entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS audit_events_user_id_idx ON audit_events (user_id);
+CREATE INDEX IF NOT EXISTS audit_events_created_at_idx ON audit_events (created_at);
+
+INSERT INTO permissions(name)
+VALUES ('audit:read')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO roles_permissions(role, permission)
+VALUES ('admin', 'audit:read')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,46 @@
+// Package savepoint runs parts of a transaction within savepoints, so that a failed part is rolled back alone.
+package savepoint
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// ItemError is the error of a part of a transaction, such as an operation of a batch or a row of an import,
+// whose changes have been rolled back to its savepoint. The transaction itself can go on.
+type ItemError struct {
+	Err error
+}
+
+func (e *ItemError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+// Run runs fn within a savepoint of the transaction. A failure of fn rolls back only its own changes
+// and is returned as *ItemError, any other error is a failure of the savepoint itself and ends the transaction.
+func Run(ctx context.Context, tx *sql.Tx, name string, fn func() error) error {
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("failed to create savepoint '%s': %w", name, err)
+	}
+
+	if fnErr := fn(); fnErr != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); err != nil {
+			return fmt.Errorf("failed to roll back to savepoint '%s': %w", name, err)
+		}
+		return &ItemError{Err: fnErr}
+	}
+
+	if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("failed to release savepoint '%s': %w", name, err)
+	}
+
+	return nil
+}
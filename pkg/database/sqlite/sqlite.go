@@ -0,0 +1,58 @@
+package sqlite
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/vaberof/vk-internship-task/pkg/trigram"
+	"modernc.org/sqlite"
+	"strings"
+)
+
+type Config struct {
+	Path string
+}
+
+type ManagedDatabase struct {
+	SqliteDb *sqlx.DB
+}
+
+func init() {
+	// SQLite folds the case of ASCII letters only, so case-insensitive matching of names in any language lowers them with this function
+	sqlite.MustRegisterDeterministicScalarFunction("unicode_lower", 1, func(ctx *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		value, ok := args[0].(string)
+		if !ok {
+			return args[0], nil
+		}
+		return strings.ToLower(value), nil
+	})
+
+	// Searches tolerate typos in words of the query by the similarity of their trigrams, like Postgres with the pg_trgm extension
+	sqlite.MustRegisterDeterministicScalarFunction("word_similarity", 2, func(ctx *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		text, _ := args[0].(string)
+		otherText, _ := args[1].(string)
+		return trigram.WordSimilarity(text, otherText), nil
+	})
+}
+
+// New opens the database file, creating it if it does not exist.
+// Foreign keys are enforced, and write transactions take the write lock as they begin,
+// so that concurrent writers wait for each other instead of failing on upgrading their locks.
+func New(config *Config) (*ManagedDatabase, error) {
+	sqliteUrl := fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_time_format=sqlite&_txlock=immediate", config.Path)
+
+	sqliteDb, err := sqlx.Connect("sqlite", sqliteUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	managedDatabase := &ManagedDatabase{
+		SqliteDb: sqliteDb,
+	}
+
+	return managedDatabase, nil
+}
+
+func (db *ManagedDatabase) Disconnect() error {
+	return db.SqliteDb.Close()
+}
@@ -1,6 +1,7 @@
 package logging
 
 import (
+	"context"
 	"fmt"
 	"github.com/vaberof/vk-internship-task/pkg/logging/logs"
 	"log/slog"
@@ -26,6 +27,24 @@ func (rw *responseWriterWrapper) WriteHeader(status int) {
 	rw.ResponseWriter.WriteHeader(status)
 }
 
+type requestFieldsCtxKeyType struct{}
+
+var requestFieldsCtxKey = &requestFieldsCtxKeyType{}
+
+// requestFields are filled in by the inner handlers, so that they can be logged when the request is finished
+type requestFields struct {
+	userId *int64
+}
+
+// SetUserId sets the id of the authenticated user to be logged with the request
+func SetUserId(ctx context.Context, userId int64) {
+	fields, ok := ctx.Value(requestFieldsCtxKey).(*requestFields)
+	if !ok {
+		return
+	}
+	fields.userId = &userId
+}
+
 func impl(logs *logs.Logs, serverName string) *Middleware {
 	loggerName := "http-server"
 	if serverName != "" {
@@ -46,18 +65,25 @@ func impl(logs *logs.Logs, serverName string) *Middleware {
 				ResponseWriter: writer,
 			}
 
+			fields := &requestFields{}
+
 			defer func() {
 				status := writerWrapper.status
 
+				var userAttrs []any
+				if fields.userId != nil {
+					userAttrs = append(userAttrs, slog.Int64("userId", *fields.userId))
+				}
+
 				if status >= 500 {
-					logger.Info("Request finished", slog.Group("http", "path", path, "method", method, "result", "error", "status", status))
+					logger.Info("Request finished", append(userAttrs, slog.Group("http", "path", path, "method", method, "result", "error", "status", status))...)
 					return
 				}
 
-				logger.Info("Request finished", slog.Group("http", "path", path, "method", method, "result", "success", "status", status))
+				logger.Info("Request finished", append(userAttrs, slog.Group("http", "path", path, "method", method, "result", "success", "status", status))...)
 			}()
 
-			next.ServeHTTP(writerWrapper, request)
+			next.ServeHTTP(writerWrapper, request.WithContext(context.WithValue(request.Context(), requestFieldsCtxKey, fields)))
 		})
 	}
 
@@ -1,9 +1,12 @@
 package apiv1
 
 var (
-	CodeForbidden     = "FORBIDDEN"
-	CodeUnauthorized  = "Unauthorized"
-	CodeBadRequest    = "BAD_REQUEST"
-	CodeNotFound      = "NOT_FOUND"
-	CodeInternalError = "INTERNAL_ERROR"
+	CodeForbidden          = "FORBIDDEN"
+	CodeUnauthorized       = "Unauthorized"
+	CodeBadRequest         = "BAD_REQUEST"
+	CodeNotFound           = "NOT_FOUND"
+	CodeConflict           = "CONFLICT"
+	CodeNotAcceptable      = "NOT_ACCEPTABLE"
+	CodePreconditionFailed = "PRECONDITION_FAILED"
+	CodeInternalError      = "INTERNAL_ERROR"
 )
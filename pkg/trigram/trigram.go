@@ -0,0 +1,67 @@
+// Package trigram measures the similarity of texts by their trigrams, like the Postgres pg_trgm extension.
+package trigram
+
+import (
+	"strings"
+	"unicode"
+)
+
+// WordSimilarity is the 'word_similarity' function of the Postgres pg_trgm extension: the greatest similarity between
+// the trigrams of the first text and the trigrams of any continuous extent of the second text, from 0 to 1.
+// Similarity of two sets of trigrams is the number of their shared trigrams divided by the number of all their trigrams.
+func WordSimilarity(text, otherText string) float64 {
+	textTrigrams := make(map[string]bool)
+	for _, trigram := range trigrams(text) {
+		textTrigrams[trigram] = true
+	}
+	if len(textTrigrams) == 0 {
+		return 0
+	}
+
+	otherTrigrams := trigrams(otherText)
+
+	var maxSimilarity float64
+	for lower := range otherTrigrams {
+		if !textTrigrams[otherTrigrams[lower]] {
+			continue
+		}
+
+		extentTrigrams := make(map[string]bool)
+		var sharedCount int
+		for upper := lower; upper < len(otherTrigrams); upper++ {
+			trigram := otherTrigrams[upper]
+			if extentTrigrams[trigram] {
+				continue
+			}
+			extentTrigrams[trigram] = true
+			if !textTrigrams[trigram] {
+				continue
+			}
+			sharedCount++
+
+			similarity := float64(sharedCount) / float64(len(textTrigrams)+len(extentTrigrams)-sharedCount)
+			if similarity > maxSimilarity {
+				maxSimilarity = similarity
+			}
+		}
+	}
+
+	return maxSimilarity
+}
+
+// trigrams splits the lower-cased text into words of letters and digits and returns trigrams of the words in order.
+// Like in pg_trgm, every word is prefixed with two spaces and suffixed with one, e.g. 'cat' has trigrams '  c', ' ca', 'cat' and 'at '
+func trigrams(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var textTrigrams []string
+	for _, word := range words {
+		paddedWord := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(paddedWord); i++ {
+			textTrigrams = append(textTrigrams, string(paddedWord[i:i+3]))
+		}
+	}
+	return textTrigrams
+}
@@ -0,0 +1,33 @@
+package xjwt
+
+import (
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Sign returns the HMAC-SHA256 signed token with the claims
+func Sign(claims jwt.Claims, secret []byte) (string, error) {
+	signedToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+	return signedToken, nil
+}
+
+// Parse verifies the HMAC-SHA256 signature and the expiration time of the token and fills the claims.
+// Tokens signed with any other method are rejected
+func Parse(signedToken string, claims jwt.Claims, secret []byte) error {
+	_, err := jwt.ParseWithClaims(
+		signedToken,
+		claims,
+		func(token *jwt.Token) (any, error) {
+			return secret, nil
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to parse token: %w", err)
+	}
+	return nil
+}